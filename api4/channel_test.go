@@ -129,6 +129,48 @@ func TestCreateChannel(t *testing.T) {
 	require.Equal(t, *groupConstrainedChannel.GroupConstrained, *rchannel.GroupConstrained, "GroupConstrained flags do not match")
 }
 
+func TestCreateChannelAuditTrail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	clients := map[string]*model.Client4{
+		th.BasicUser.Id:       th.Client,
+		th.SystemAdminUser.Id: th.SystemAdminClient,
+	}
+
+	var wg sync.WaitGroup
+	var mut sync.Mutex
+	channelIds := map[string]string{}
+	for userId, client := range clients {
+		wg.Add(1)
+		go func(userId string, client *model.Client4) {
+			defer wg.Done()
+			channel := &model.Channel{DisplayName: "Test API Name", Name: GenerateTestChannelName(), Type: model.CHANNEL_OPEN, TeamId: th.BasicTeam.Id}
+			rchannel, resp := client.CreateChannel(channel)
+			CheckNoError(t, resp)
+
+			mut.Lock()
+			channelIds[userId] = rchannel.Id
+			mut.Unlock()
+		}(userId, client)
+	}
+	wg.Wait()
+
+	for userId, channelId := range channelIds {
+		entries, err := th.App.Srv().Store.AuditTrail().GetByEntity("Channel", channelId, 0, 10)
+		require.NoError(t, err)
+
+		var saveEntry *model.AuditTrailEntry
+		for _, entry := range entries {
+			if entry.Operation == "ChannelStore.Save" {
+				saveEntry = entry
+			}
+		}
+		require.NotNil(t, saveEntry, "the creation of the channel should be recorded")
+		assert.Equal(t, userId, saveEntry.ActorId, "the creation should be recorded as made by the user of the request")
+	}
+}
+
 func TestUpdateChannel(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
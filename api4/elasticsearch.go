@@ -33,7 +33,8 @@ func testElasticsearch(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	// no sniffing for ports or passwords
 	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_SYSCONSOLE_WRITE_ENVIRONMENT) {
-		if (*cfg.ElasticsearchSettings.ConnectionUrl != *c.App.Config().ElasticsearchSettings.ConnectionUrl) || (*cfg.ElasticsearchSettings.Password != model.FAKE_SETTING) {
+		if (*cfg.ElasticsearchSettings.ConnectionUrl != *c.App.Config().ElasticsearchSettings.ConnectionUrl) || (*cfg.ElasticsearchSettings.Password != model.FAKE_SETTING) ||
+			(*cfg.ElasticsearchSettings.APIKey != "" && *cfg.ElasticsearchSettings.APIKey != model.FAKE_SETTING) {
 			c.SetPermissionError(model.PERMISSION_SYSCONSOLE_WRITE_ENVIRONMENT)
 			return
 		}
@@ -12,8 +12,10 @@ import (
 
 func TestMain(m *testing.M) {
 	var options = testlib.HelperOptions{
-		EnableStore:     true,
-		EnableResources: true,
+		EnableStore:         true,
+		EnableResources:     true,
+		EnableReadOnlyLayer: true,
+		EnableAuditLayer:    true,
 	}
 
 	mlog.DisableZap()
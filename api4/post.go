@@ -13,6 +13,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/web"
 )
 
 func (api *API) InitPost() {
@@ -27,7 +28,9 @@ func (api *API) InitPost() {
 
 	api.BaseRoutes.ChannelForUser.Handle("/posts/unread", api.ApiSessionRequired(getPostsForChannelAroundLastUnread)).Methods("GET")
 
+	api.BaseRoutes.Posts.Handle("/search", api.ApiSessionRequiredDisableWhenBusy(searchPostsInAllTeams)).Methods("POST")
 	api.BaseRoutes.Team.Handle("/posts/search", api.ApiSessionRequiredDisableWhenBusy(searchPosts)).Methods("POST")
+	api.BaseRoutes.Team.Handle("/posts/search/suggest", api.ApiSessionRequiredDisableWhenBusy(suggestSearchTerms)).Methods("GET")
 	api.BaseRoutes.Post.Handle("", api.ApiSessionRequired(updatePost)).Methods("PUT")
 	api.BaseRoutes.Post.Handle("/patch", api.ApiSessionRequired(patchPost)).Methods("PUT")
 	api.BaseRoutes.PostForUser.Handle("/set_unread", api.ApiSessionRequired(setPostUnread)).Methods("POST")
@@ -501,9 +504,14 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 		includeDeletedChannels = *params.IncludeDeletedChannels
 	}
 
+	includeHighlights := false
+	if params.IncludeHighlights != nil {
+		includeHighlights = *params.IncludeHighlights
+	}
+
 	startTime := time.Now()
 
-	results, err := c.App.SearchPostsInTeamForUser(terms, c.App.Session().UserId, c.Params.TeamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	results, err := c.App.SearchPostsInTeamForUser(terms, c.App.Session().UserId, c.Params.TeamId, isOrSearch, includeDeletedChannels, includeHighlights, timeZoneOffset, page, perPage)
 
 	elapsedTime := float64(time.Since(startTime)) / float64(time.Second)
 	metrics := c.App.Metrics()
@@ -519,12 +527,120 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	clientPostList := c.App.PreparePostListForClient(results.PostList)
 
-	results = model.MakePostSearchResults(clientPostList, results.Matches)
+	results = &model.PostSearchResults{
+		PostList:   clientPostList,
+		Matches:    results.Matches,
+		Highlights: results.Highlights,
+	}
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Write([]byte(results.ToJson()))
+}
+
+func searchPostsInAllTeams(c *Context, w http.ResponseWriter, r *http.Request) {
+	params, jsonErr := model.SearchParameterFromJson(r.Body)
+	if jsonErr != nil {
+		c.Err = model.NewAppError("searchPostsInAllTeams", "api.post.search_posts.invalid_body.app_error", nil, jsonErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if params.Terms == nil || len(*params.Terms) == 0 {
+		c.SetInvalidParam("terms")
+		return
+	}
+
+	opts := model.SearchAllTeamsOptions{
+		Page:    0,
+		PerPage: 60,
+	}
+	if params.TimeZoneOffset != nil {
+		opts.TimeZoneOffset = *params.TimeZoneOffset
+	}
+	if params.IsOrSearch != nil {
+		opts.IsOrSearch = *params.IsOrSearch
+	}
+	if params.IncludeDeletedChannels != nil {
+		opts.IncludeDeletedChannels = *params.IncludeDeletedChannels
+	}
+	if params.Page != nil {
+		opts.Page = *params.Page
+	}
+	if params.PerPage != nil {
+		opts.PerPage = *params.PerPage
+	}
+
+	if opts.Page < 0 {
+		c.SetInvalidParam("page")
+		return
+	}
+	if opts.PerPage <= 0 || opts.PerPage > web.PER_PAGE_MAXIMUM {
+		c.SetInvalidParam("per_page")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("searchPostsInAllTeams", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	auditRec.AddMeta("terms", *params.Terms)
+	auditRec.AddMeta("page", opts.Page)
+	auditRec.AddMeta("per_page", opts.PerPage)
+
+	startTime := time.Now()
+
+	results, err := c.App.SearchPostsInAllTeams(c.App.Session().UserId, *params.Terms, opts)
+
+	elapsedTime := float64(time.Since(startTime)) / float64(time.Second)
+	metrics := c.App.Metrics()
+	if metrics != nil {
+		metrics.IncrementPostsSearchCounter()
+		metrics.ObservePostsSearchDuration(elapsedTime)
+	}
+
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("results", len(results.Order))
+
+	results = &model.PostSearchResults{
+		PostList: c.App.PreparePostListForClient(results.PostList),
+		Matches:  results.Matches,
+	}
 
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Write([]byte(results.ToJson()))
 }
 
+func suggestSearchTerms(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.App.Session(), c.Params.TeamId, model.PERMISSION_VIEW_TEAM) {
+		c.SetPermissionError(model.PERMISSION_VIEW_TEAM)
+		return
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+	limitStr := r.URL.Query().Get("limit")
+	limit, _ := strconv.Atoi(limitStr)
+	if limitStr == "" || limit <= 0 {
+		limit = model.SEARCH_SUGGESTION_DEFAULT_LIMIT
+	} else if limit > model.SEARCH_SUGGESTION_MAX_LIMIT {
+		limit = model.SEARCH_SUGGESTION_MAX_LIMIT
+	}
+
+	suggestions, err := c.App.SuggestSearchTerms(c.App.Session().UserId, c.Params.TeamId, prefix, limit)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Write([]byte(model.SearchSuggestionsToJson(suggestions)))
+}
+
 func updatePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
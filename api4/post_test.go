@@ -2175,6 +2175,89 @@ func TestSearchPosts(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestSuggestSearchTerms(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.LoginBasic()
+	Client := th.Client
+
+	th.CreateMessagePost("deployment scheduled")
+
+	suggestions, resp := Client.SuggestSearchTerms(th.BasicTeam.Id, "deploy", 10)
+	CheckNoError(t, resp)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, model.SEARCH_SUGGESTION_TYPE_TERM, suggestions[0].Type)
+	assert.Equal(t, "deployment", suggestions[0].Term)
+
+	suggestions, resp = Client.SuggestSearchTerms(th.BasicTeam.Id, th.BasicChannel.Name, 10)
+	CheckNoError(t, resp)
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, model.SEARCH_SUGGESTION_TYPE_CHANNEL, suggestions[0].Type)
+	assert.Equal(t, th.BasicChannel.Name, suggestions[0].Term)
+
+	suggestions, resp = Client.SuggestSearchTerms(th.BasicTeam.Id, "", 10)
+	CheckNoError(t, resp)
+	assert.Empty(t, suggestions)
+
+	_, resp = Client.SuggestSearchTerms("junk", "deploy", 10)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp = Client.SuggestSearchTerms(model.NewId(), "deploy", 10)
+	CheckForbiddenStatus(t, resp)
+
+	Client.Logout()
+	_, resp = Client.SuggestSearchTerms(th.BasicTeam.Id, "deploy", 10)
+	CheckUnauthorizedStatus(t, resp)
+}
+
+func TestSearchPostsInAllTeams(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+	otherChannel := th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.CHANNEL_OPEN, otherTeam.Id)
+
+	post1 := th.CreateMessagePostNoClient(th.BasicChannel, "audit search", 1000)
+	post2 := th.CreateMessagePostNoClient(otherChannel, "audit search", 2000)
+
+	params := &model.SearchParameter{Terms: model.NewString("from:" + th.BasicUser.Username)}
+
+	t.Run("should require the permission to search all the teams", func(t *testing.T) {
+		_, resp := th.Client.SearchPostsInAllTeams(params)
+		CheckForbiddenStatus(t, resp)
+
+		th.AddPermissionToRole(model.PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS.Id, model.SYSTEM_USER_ROLE_ID)
+		defer th.RemovePermissionFromRole(model.PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS.Id, model.SYSTEM_USER_ROLE_ID)
+
+		posts, resp := th.Client.SearchPostsInAllTeams(params)
+		CheckNoError(t, resp)
+		require.Equal(t, []string{post2.Id, post1.Id}, posts.Order)
+	})
+
+	t.Run("should search the channels of all the teams", func(t *testing.T) {
+		posts, resp := th.SystemAdminClient.SearchPostsInAllTeams(params)
+		CheckNoError(t, resp)
+		require.Equal(t, []string{post2.Id, post1.Id}, posts.Order)
+	})
+
+	t.Run("should paginate the results", func(t *testing.T) {
+		paginated := &model.SearchParameter{Terms: params.Terms, Page: model.NewInt(1), PerPage: model.NewInt(1)}
+		posts, resp := th.SystemAdminClient.SearchPostsInAllTeams(paginated)
+		CheckNoError(t, resp)
+		require.Equal(t, []string{post1.Id}, posts.Order)
+
+		paginated.PerPage = model.NewInt(0)
+		_, resp = th.SystemAdminClient.SearchPostsInAllTeams(paginated)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("should require terms", func(t *testing.T) {
+		_, resp := th.SystemAdminClient.SearchPostsInAllTeams(&model.SearchParameter{Terms: model.NewString("")})
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestSearchHashtagPosts(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
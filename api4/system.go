@@ -21,6 +21,8 @@ import (
 	"github.com/mattermost/mattermost-server/v5/services/cache"
 	"github.com/mattermost/mattermost-server/v5/services/filesstore"
 	"github.com/mattermost/mattermost-server/v5/services/upgrader"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/circuitbreakerlayer"
 )
 
 const (
@@ -57,6 +59,9 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiSessionRequired(setServerBusy)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiSessionRequired(getServerBusyExpires)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiSessionRequired(clearServerBusy)).Methods("DELETE")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiSessionRequired(setStoreReadOnly)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiSessionRequired(getStoreReadOnly)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiSessionRequired(clearStoreReadOnly)).Methods("DELETE")
 	api.BaseRoutes.ApiRoot.Handle("/upgrade_to_enterprise", api.ApiSessionRequired(upgradeToEnterprise)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/upgrade_to_enterprise/status", api.ApiSessionRequired(upgradeToEnterpriseStatus)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/restart", api.ApiSessionRequired(restart)).Methods("POST")
@@ -111,10 +116,27 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 			s[model.STATUS] = model.STATUS_UNHEALTHY
 		}
 
+		// The search engines being down doesn't make the database unhealthy.
+		var degradedErr *store.ErrSearchDegraded
+		if err := c.App.Srv().Store.HealthCheck(r.Context()); err != nil && !errors.As(err, &degradedErr) {
+			mlog.Warn("The database failed its health check.", mlog.Err(err))
+			s[dbStatusKey] = model.STATUS_UNHEALTHY
+			s[model.STATUS] = model.STATUS_UNHEALTHY
+		}
+
 		if s[dbStatusKey] == model.STATUS_OK {
 			mlog.Debug("Able to write to database.")
 		}
 
+		if breaker := c.App.Srv().DatabaseCircuitBreaker(); breaker != nil {
+			state := breaker.State()
+			s["database_circuit_breaker"] = state.String()
+			if state != circuitbreakerlayer.StateClosed {
+				s[dbStatusKey] = model.STATUS_UNHEALTHY
+				s[model.STATUS] = model.STATUS_UNHEALTHY
+			}
+		}
+
 		filestoreStatusKey := "filestore_status"
 		s[filestoreStatusKey] = model.STATUS_OK
 		license := c.App.Srv().License()
@@ -569,6 +591,50 @@ func getServerBusyExpires(c *Context, w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(c.App.Srv().Busy.ToJson()))
 }
 
+func setStoreReadOnly(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("setStoreReadOnly", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if err := c.App.Srv().SetStoreReadOnly(true); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func clearStoreReadOnly(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("clearStoreReadOnly", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if err := c.App.Srv().SetStoreReadOnly(false); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func getStoreReadOnly(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.App.Session(), model.PERMISSION_MANAGE_SYSTEM) {
+		c.SetPermissionError(model.PERMISSION_MANAGE_SYSTEM)
+		return
+	}
+	w.Write([]byte(model.MapBoolToJson(map[string]bool{"read_only": c.App.Srv().IsStoreReadOnly()})))
+}
+
 func upgradeToEnterprise(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec := c.MakeAuditRecord("upgradeToEnterprise", audit.Fail)
 	defer c.LogAuditRec(auditRec)
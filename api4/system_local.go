@@ -17,6 +17,9 @@ func (api *API) InitSystemLocal() {
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiLocal(setServerBusy)).Methods("POST")
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiLocal(getServerBusyExpires)).Methods("GET")
 	api.BaseRoutes.ApiRoot.Handle("/server_busy", api.ApiLocal(clearServerBusy)).Methods("DELETE")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiLocal(setStoreReadOnly)).Methods("POST")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiLocal(getStoreReadOnly)).Methods("GET")
+	api.BaseRoutes.ApiRoot.Handle("/store_read_only", api.ApiLocal(clearStoreReadOnly)).Methods("DELETE")
 	api.BaseRoutes.ApiRoot.Handle("/integrity", api.ApiLocal(localCheckIntegrity)).Methods("POST")
 }
 
@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -638,6 +640,76 @@ func TestGetServerBusy(t *testing.T) {
 	}, "as system admin")
 }
 
+func TestSetStoreReadOnly(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	defer mainHelper.SetStoreReadOnly(false)
+
+	t.Run("as system user", func(t *testing.T) {
+		ok, resp := th.Client.SetStoreReadOnly()
+		CheckForbiddenStatus(t, resp)
+		require.False(t, ok, "should not set the store read-only due to no permission")
+		require.False(t, th.App.Srv().IsStoreReadOnly(), "store should not be read-only")
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, c *model.Client4) {
+		defer mainHelper.SetStoreReadOnly(false)
+
+		ok, resp := c.SetStoreReadOnly()
+		CheckNoError(t, resp)
+		require.True(t, ok, "should set the store read-only successfully")
+		require.True(t, th.App.Srv().IsStoreReadOnly(), "store should be read-only")
+
+		err := th.App.Srv().Store.User().UpdatePassword(th.BasicUser.Id, "hash")
+		require.True(t, errors.Is(err, &store.ErrReadOnly{}), "store should reject the changes")
+
+		_, err = th.App.Srv().Store.User().Get(th.BasicUser.Id)
+		require.NoError(t, err, "store should serve the reads")
+	}, "as system admin")
+}
+
+func TestClearStoreReadOnly(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	defer mainHelper.SetStoreReadOnly(false)
+
+	mainHelper.SetStoreReadOnly(true)
+	t.Run("as system user", func(t *testing.T) {
+		ok, resp := th.Client.ClearStoreReadOnly()
+		CheckForbiddenStatus(t, resp)
+		require.False(t, ok, "should not clear the read-only mode due to no permission")
+		require.True(t, th.App.Srv().IsStoreReadOnly(), "store should be read-only")
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, c *model.Client4) {
+		mainHelper.SetStoreReadOnly(true)
+
+		ok, resp := c.ClearStoreReadOnly()
+		CheckNoError(t, resp)
+		require.True(t, ok, "should clear the read-only mode successfully")
+		require.False(t, th.App.Srv().IsStoreReadOnly(), "store should not be read-only")
+	}, "as system admin")
+}
+
+func TestGetStoreReadOnly(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	defer mainHelper.SetStoreReadOnly(false)
+
+	mainHelper.SetStoreReadOnly(true)
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp := th.Client.GetStoreReadOnly()
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, c *model.Client4) {
+		readOnly, resp := c.GetStoreReadOnly()
+		CheckNoError(t, resp)
+		require.True(t, readOnly)
+	}, "as system admin")
+}
+
 func TestGetServerBusyExpires(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
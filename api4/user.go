@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-server/v5/app"
 	"github.com/mattermost/mattermost-server/v5/audit"
 	"github.com/mattermost/mattermost-server/v5/mlog"
@@ -125,12 +127,10 @@ func createUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	if len(tokenId) > 0 {
 		token, nErr := c.App.Srv().Store.Token().GetByToken(tokenId)
 		if nErr != nil {
-			var status int
-			switch nErr.(type) {
-			case *store.ErrNotFound:
+			status := http.StatusInternalServerError
+			var nfErr *store.ErrNotFound
+			if errors.As(nErr, &nfErr) {
 				status = http.StatusNotFound
-			default:
-				status = http.StatusInternalServerError
 			}
 			c.Err = model.NewAppError("CreateUserWithToken", "api.user.create_user.signup_link_invalid.app_error", nil, nErr.Error(), status)
 			return
@@ -2869,6 +2869,14 @@ func getThreadsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	options.Deleted, _ = strconv.ParseBool(deletedStr)
 	options.Extended, _ = strconv.ParseBool(extendedStr)
 
+	if before := r.URL.Query().Get("before"); before != "" {
+		if !model.IsValidId(before) {
+			c.SetInvalidParam("before")
+			return
+		}
+		options.Before = before
+	}
+
 	threads, err := c.App.GetThreadsForUser(c.Params.UserId, options)
 	if err != nil {
 		c.Err = err
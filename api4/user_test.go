@@ -280,6 +280,20 @@ func TestCreateUserWithToken(t *testing.T) {
 		CheckErrorMessage(t, resp, "api.user.create_user.signup_link_invalid.app_error")
 	})
 
+	t.Run("DeletedToken", func(t *testing.T) {
+		user := model.User{Email: th.GenerateTestEmail(), Nickname: "Corey Hulen", Password: "hello1", Username: GenerateTestUsername(), Roles: model.SYSTEM_ADMIN_ROLE_ID + " " + model.SYSTEM_USER_ROLE_ID}
+		token := model.NewToken(
+			app.TOKEN_TYPE_TEAM_INVITATION,
+			model.MapToJson(map[string]string{"teamId": th.BasicTeam.Id, "email": user.Email}),
+		)
+		require.Nil(t, th.App.Srv().Store.Token().Save(token))
+		require.Nil(t, th.App.DeleteToken(token))
+
+		_, resp := th.Client.CreateUserWithToken(&user, token.Token)
+		CheckNotFoundStatus(t, resp)
+		CheckErrorMessage(t, resp, "api.user.create_user.signup_link_invalid.app_error")
+	})
+
 	t.Run("EnableUserCreationDisable", func(t *testing.T) {
 
 		enableUserCreation := th.App.Config().TeamSettings.EnableUserCreation
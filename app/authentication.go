@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/services/mfa"
 	"github.com/mattermost/mattermost-server/v5/utils"
@@ -78,6 +79,8 @@ func (a *App) CheckPasswordAndAllCriteria(user *model.User, password string, mfa
 		return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, passErr.Error(), http.StatusInternalServerError)
 	}
 
+	a.rehashUserPassword(user, password)
+
 	a.InvalidateCacheForUser(user.Id)
 
 	if err := a.CheckUserPostflightAuthenticationCriteria(user); err != nil {
@@ -120,6 +123,22 @@ func (a *App) checkUserPassword(user *model.User, password string) *model.AppErr
 	return nil
 }
 
+// rehashUserPassword hashes the password of the user again, once it's known to match, when it was
+// hashed with a lower cost than PasswordSettings.HashCost. Failing to do so doesn't fail the
+// authentication, the password is hashed again the next time instead.
+func (a *App) rehashUserPassword(user *model.User, password string) {
+	if !model.PasswordNeedsRehash(user.Password) {
+		return
+	}
+
+	hash := model.HashPassword(password)
+	if err := a.Srv().Store.User().UpdatePasswordHash(user.Id, user.Password, hash); err != nil {
+		mlog.Warn("Failed to hash the password of the user again", mlog.String("user_id", user.Id), mlog.Err(err))
+		return
+	}
+	user.Password = hash
+}
+
 func (a *App) checkLdapUserPasswordAndAllCriteria(ldapId *string, password string, mfaToken string) (*model.User, *model.AppError) {
 	if a.Ldap() == nil || ldapId == nil {
 		err := model.NewAppError("doLdapAuthentication", "api.user.login_ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestParseAuthTokenFromRequest(t *testing.T) {
@@ -50,3 +51,41 @@ func TestParseAuthTokenFromRequest(t *testing.T) {
 		require.Equal(t, tc.expectedLocation, location, "Wrong location on test "+strconv.Itoa(testnum))
 	}
 }
+
+func TestCheckPasswordAndAllCriteriaRehashesPassword(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PasswordSettings.HashCost = 11
+	})
+
+	oldHash, err := bcrypt.GenerateFromPassword([]byte("Password1"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, th.App.Srv().Store.User().UpdatePassword(th.BasicUser.Id, string(oldHash)))
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	before, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	user, appErr := th.App.AuthenticateUserForLogin("", th.BasicUser.Username, "Password1", "", "", false)
+	require.Nil(t, appErr, "a password hashed with an older cost should still match")
+	require.Equal(t, th.BasicUser.Id, user.Id)
+
+	after, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	cost, err := bcrypt.Cost([]byte(after.Password))
+	require.NoError(t, err)
+	require.Equal(t, 11, cost, "the password should have been hashed again with the configured cost")
+	require.True(t, model.ComparePassword(after.Password, "Password1"))
+	require.Equal(t, before.LastPasswordUpdate, after.LastPasswordUpdate)
+
+	_, appErr = th.App.AuthenticateUserForLogin("", th.BasicUser.Username, "Password1", "", "", false)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.AuthenticateUserForLogin("", th.BasicUser.Username, "Password2", "", "", false)
+	require.NotNil(t, appErr)
+	current, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Equal(t, after.Password, current.Password, "a failed login shouldn't change the hash")
+}
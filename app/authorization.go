@@ -5,10 +5,12 @@ package app
 
 import (
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
 )
 
 func (a *App) MakePermissionError(permissions []*model.Permission) *model.AppError {
@@ -243,6 +245,92 @@ func (a *App) RolesGrantPermission(roleNames []string, permissionId string) bool
 	return false
 }
 
+// GetEffectivePermissionsForUsers returns the permissions granted to each user in a channel by
+// their system, team and channel roles, including the roles of the schemes of the team and the
+// channel. The roles of all the users are looked up at once. The unknown users are left out.
+func (a *App) GetEffectivePermissionsForUsers(channelId string, userIds []string) (map[string][]string, *model.AppError) {
+	permissions := map[string][]string{}
+	if len(userIds) == 0 {
+		return permissions, nil
+	}
+
+	channel, err := a.GetChannel(channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	users, nErr := a.Srv().Store.User().GetProfileByIds(userIds, &store.UserGetByIdsOpts{}, true)
+	if nErr != nil {
+		return nil, model.NewAppError("GetEffectivePermissionsForUsers", "app.user.get_profiles.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+	}
+
+	userRoles := map[string][]string{}
+	for _, user := range users {
+		userRoles[user.Id] = user.GetRoles()
+	}
+
+	if channel.TeamId != "" {
+		teamMembers, err := a.GetTeamMembersByIds(channel.TeamId, userIds, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range teamMembers {
+			if _, ok := userRoles[member.UserId]; ok && member.DeleteAt == 0 {
+				userRoles[member.UserId] = append(userRoles[member.UserId], member.GetRoles()...)
+			}
+		}
+	}
+
+	channelMembers, err := a.GetChannelMembersByIds(channelId, userIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range *channelMembers {
+		if _, ok := userRoles[member.UserId]; ok {
+			userRoles[member.UserId] = append(userRoles[member.UserId], member.GetRoles()...)
+		}
+	}
+
+	roleNames := map[string]bool{}
+	for _, names := range userRoles {
+		for _, name := range names {
+			roleNames[name] = true
+		}
+	}
+	names := make([]string, 0, len(roleNames))
+	for name := range roleNames {
+		names = append(names, name)
+	}
+
+	roles, err := a.GetRolesByNames(names)
+	if err != nil {
+		return nil, err
+	}
+	rolePermissions := map[string][]string{}
+	for _, role := range roles {
+		if role.DeleteAt == 0 {
+			rolePermissions[role.Name] = role.Permissions
+		}
+	}
+
+	for userId, names := range userRoles {
+		granted := map[string]bool{}
+		for _, name := range names {
+			for _, permission := range rolePermissions[name] {
+				granted[permission] = true
+			}
+		}
+
+		permissions[userId] = make([]string, 0, len(granted))
+		for permission := range granted {
+			permissions[userId] = append(permissions[userId], permission)
+		}
+		sort.Strings(permissions[userId])
+	}
+
+	return permissions, nil
+}
+
 // SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
 // This function deviates from other authorization checks in returning an error instead of just
 // a boolean, allowing the permission failure to be exposed with more granularity.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/mattermost/mattermost-server/v5/plugin/plugintest/mock"
 	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -118,3 +119,48 @@ func TestHasPermissionToCategory(t *testing.T) {
 	require.Nil(t, err)
 	require.False(t, th.App.SessionHasPermissionToCategory(*session, th.BasicUser.Id, th.BasicTeam.Id, categories2.Order[0]))
 }
+
+func TestGetEffectivePermissionsForUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("merges the system, team and channel roles", func(t *testing.T) {
+		permissions, err := th.App.GetEffectivePermissionsForUsers(th.BasicChannel.Id, []string{th.BasicUser.Id, th.BasicUser2.Id, model.NewId()})
+		require.Nil(t, err)
+		require.Len(t, permissions, 2, "unknown users should be left out")
+
+		for _, userId := range []string{th.BasicUser.Id, th.BasicUser2.Id} {
+			assert.Contains(t, permissions[userId], model.PERMISSION_CREATE_DIRECT_CHANNEL.Id)
+			assert.Contains(t, permissions[userId], model.PERMISSION_LIST_TEAM_CHANNELS.Id)
+			assert.Contains(t, permissions[userId], model.PERMISSION_GET_PUBLIC_LINK.Id)
+			assert.True(t, sort.StringsAreSorted(permissions[userId]))
+		}
+		assert.NotContains(t, permissions[th.BasicUser2.Id], model.PERMISSION_MANAGE_CHANNEL_ROLES.Id)
+	})
+
+	t.Run("applies the roles of the channel scheme", func(t *testing.T) {
+		th.App.Srv().Store.System().Save(&model.System{Name: model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2, Value: "true"})
+		defer th.App.Srv().Store.System().PermanentDeleteByName(model.MIGRATION_KEY_ADVANCED_PERMISSIONS_PHASE_2)
+
+		scheme := th.SetupChannelScheme()
+		th.RemovePermissionFromRole(model.PERMISSION_GET_PUBLIC_LINK.Id, scheme.DefaultChannelUserRole)
+
+		channel := th.CreateChannel(th.BasicTeam)
+		th.AddUserToChannel(th.BasicUser2, channel)
+		channel.SchemeId = &scheme.Id
+		_, err := th.App.UpdateChannelScheme(channel)
+		require.Nil(t, err)
+
+		permissions, err := th.App.GetEffectivePermissionsForUsers(channel.Id, []string{th.BasicUser.Id, th.BasicUser2.Id})
+		require.Nil(t, err)
+		assert.Contains(t, permissions[th.BasicUser.Id], model.PERMISSION_MANAGE_CHANNEL_ROLES.Id, "the creator of the channel should be its admin")
+		assert.NotContains(t, permissions[th.BasicUser2.Id], model.PERMISSION_GET_PUBLIC_LINK.Id)
+		assert.Contains(t, permissions[th.BasicUser2.Id], model.PERMISSION_CREATE_POST.Id)
+	})
+
+	t.Run("returns an empty map without users", func(t *testing.T) {
+		permissions, err := th.App.GetEffectivePermissionsForUsers(th.BasicChannel.Id, nil)
+		require.Nil(t, err)
+		assert.Empty(t, permissions)
+	})
+}
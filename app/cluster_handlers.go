@@ -27,6 +27,7 @@ func (a *App) registerAllClusterMessageHandlers() {
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_INSTALL_PLUGIN, a.clusterInstallPluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_REMOVE_PLUGIN, a.clusterRemovePluginHandler)
 	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_BUSY_STATE_CHANGED, a.clusterBusyStateChgHandler)
+	a.Cluster().RegisterClusterMessageHandler(model.CLUSTER_EVENT_STORE_READ_ONLY_CHANGED, a.clusterStoreReadOnlyChgHandler)
 }
 
 func (a *App) clusterPublishHandler(msg *model.ClusterMessage) {
@@ -81,3 +82,7 @@ func (a *App) clusterRemovePluginHandler(msg *model.ClusterMessage) {
 func (a *App) clusterBusyStateChgHandler(msg *model.ClusterMessage) {
 	a.ServerBusyStateChanged(model.ServerBusyStateFromJson(strings.NewReader(msg.Data)))
 }
+
+func (a *App) clusterStoreReadOnlyChgHandler(msg *model.ClusterMessage) {
+	a.Srv().storeReadOnlyChanged(model.MapBoolFromJson(strings.NewReader(msg.Data))["read_only"])
+}
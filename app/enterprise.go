@@ -9,6 +9,7 @@ import (
 	tjobs "github.com/mattermost/mattermost-server/v5/jobs/interfaces"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/pgcluster"
 	"github.com/mattermost/mattermost-server/v5/services/searchengine"
 )
 
@@ -90,6 +91,12 @@ func RegisterJobsBleveIndexerInterface(f func(*Server) tjobs.IndexerJobInterface
 	jobsBleveIndexerInterface = f
 }
 
+var jobsOpenSearchIndexerInterface func(*Server) tjobs.IndexerJobInterface
+
+func RegisterJobsOpenSearchIndexerInterface(f func(*Server) tjobs.IndexerJobInterface) {
+	jobsOpenSearchIndexerInterface = f
+}
+
 var jobsActiveUsersInterface func(*App) tjobs.ActiveUsersJobInterface
 
 func RegisterJobsActiveUsersInterface(f func(*App) tjobs.ActiveUsersJobInterface) {
@@ -171,6 +178,8 @@ func (s *Server) initEnterprise() {
 	}
 	if clusterInterface != nil {
 		s.Cluster = clusterInterface(s)
+	} else if *s.Config().ClusterSettings.EnablePostgresNotify && *s.Config().SqlSettings.DriverName == model.DATABASE_DRIVER_POSTGRES {
+		s.Cluster = pgcluster.NewPostgresCluster(*s.Config().SqlSettings.DataSource, &s.Config().ClusterSettings)
 	}
 	if elasticsearchInterface != nil {
 		s.SearchEngine.RegisterElasticsearchEngine(elasticsearchInterface(s))
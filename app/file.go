@@ -33,6 +33,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
+	"github.com/mattermost/mattermost-server/v5/services/docextractor"
 	"github.com/mattermost/mattermost-server/v5/services/filesstore"
 	"github.com/mattermost/mattermost-server/v5/store"
 	"github.com/mattermost/mattermost-server/v5/utils"
@@ -65,6 +66,7 @@ const (
 	ImagePreviewWidth    = 1920
 
 	maxUploadInitialBufferSize = 1024 * 1024 // 1Mb
+	maxContentExtractionSize   = 1024 * 1024 // 1Mb
 
 	// Deprecated
 	IMAGE_THUMBNAIL_PIXEL_WIDTH  = 120
@@ -668,6 +670,8 @@ func (a *App) UploadFileX(channelId, name string, input io.Reader,
 		}
 	}
 
+	a.extractContentInBackground(t.fileinfo)
+
 	return t.fileinfo, nil
 }
 
@@ -917,9 +921,52 @@ func (a *App) DoUploadFileExpectModification(now time.Time, rawTeamId string, ra
 		}
 	}
 
+	a.extractContentInBackground(info)
+
 	return info, data, nil
 }
 
+// extractContentInBackground extracts the text of the file for the search engines to index it,
+// when enabled through FileSettings.ExtractContent.
+func (a *App) extractContentInBackground(info *model.FileInfo) {
+	if !*a.Config().FileSettings.ExtractContent {
+		return
+	}
+
+	infoCopy := *info
+	a.Srv().Go(func() {
+		if err := a.extractContentFromFileInfo(&infoCopy); err != nil {
+			mlog.Error("Failed to extract file content", mlog.Err(err), mlog.String("file_info_id", infoCopy.Id))
+		}
+	})
+}
+
+func (a *App) extractContentFromFileInfo(fileInfo *model.FileInfo) error {
+	file, aerr := a.FileReader(fileInfo.Path)
+	if aerr != nil {
+		return fmt.Errorf("failed to open file to extract its content, %w", aerr)
+	}
+	defer file.Close()
+
+	text, err := docextractor.Extract(fileInfo.Name, file, docextractor.ExtractSettings{
+		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract file content, %w", err)
+	}
+	if text == "" {
+		return nil
+	}
+
+	if len(text) > maxContentExtractionSize {
+		text = text[0:maxContentExtractionSize]
+	}
+	if err := a.Srv().Store.FileInfo().SetContent(fileInfo.Id, text); err != nil {
+		return fmt.Errorf("failed to save the extracted file content, %w", err)
+	}
+	return nil
+}
+
 func (a *App) HandleImages(previewPathList []string, thumbnailPathList []string, fileData [][]byte) {
 	wg := new(sync.WaitGroup)
 
@@ -1159,3 +1206,51 @@ func (a *App) CopyFileInfos(userId string, fileIds []string) ([]string, *model.A
 
 	return newFileIds, nil
 }
+
+func (a *App) SearchFilesInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.FileInfoList, *model.AppError) {
+	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return nil, model.NewAppError("SearchFilesInTeamForUser", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamId, userId), http.StatusNotImplemented)
+	}
+
+	finalParamsList := []*model.SearchParams{}
+	timeZone := a.getSearchTimeZone(userId)
+
+	for _, params := range paramsList {
+		params.OrTerms = isOrSearch
+		params.IncludeDeletedChannels = includeDeleted
+		params.TimeZone = timeZone
+		// Don't allow users to search for "*"
+		if params.Terms != "*" {
+			// Convert channel names to channel IDs
+			params.InChannels = a.convertChannelNamesToChannelIds(params.InChannels, userId, teamId, includeDeletedChannels)
+			params.ExcludedChannels = a.convertChannelNamesToChannelIds(params.ExcludedChannels, userId, teamId, includeDeletedChannels)
+
+			// Convert usernames to user IDs
+			params.FromUsers = a.convertUserNameToUserIds(params.FromUsers)
+			params.ExcludedUsers = a.convertUserNameToUserIds(params.ExcludedUsers)
+
+			finalParamsList = append(finalParamsList, params)
+		}
+	}
+
+	// If the processed search params are empty, return empty search results.
+	if len(finalParamsList) == 0 {
+		return model.NewFileInfoList(), nil
+	}
+
+	fileInfoSearchResults, nErr := a.Srv().Store.FileInfo().Search(finalParamsList, userId, teamId, page, perPage)
+	if nErr != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SearchFilesInTeamForUser", "app.file_info.search.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return fileInfoSearchResults, nil
+}
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
 	"github.com/mattermost/mattermost-server/v5/utils/fileutils"
 )
 
@@ -299,3 +301,169 @@ func TestCopyFileInfos(t *testing.T) {
 	assert.NotEqual(t, info1.Id, info2.Id, "should not be equal")
 	assert.Equal(t, info2.PostId, "", "should be empty string")
 }
+
+func TestExtractContentFromUploadedFile(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	upload := func() *model.FileInfo {
+		info, err := th.App.DoUploadFile(time.Now(), model.NewId(), model.NewId(), model.NewId(), "notes.txt", []byte("some searchable notes"))
+		require.Nil(t, err)
+		t.Cleanup(func() {
+			th.App.Srv().Store.FileInfo().PermanentDelete(info.Id)
+			th.App.RemoveFile(info.Path)
+		})
+		return info
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		info := upload()
+
+		info, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+		require.Nil(t, err)
+		assert.Empty(t, info.Content)
+	})
+
+	t.Run("extracts the content when enabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileSettings.ExtractContent = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileSettings.ExtractContent = false
+		})
+
+		info := upload()
+		assert.Eventually(t, func() bool {
+			info, err := th.App.Srv().Store.FileInfo().Get(info.Id)
+			return err == nil && info.Content == "some searchable notes"
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
+
+func TestSearchFilesInTeamForUser(t *testing.T) {
+	perPage := 5
+	searchTerm := "searchTerm"
+
+	setup := func(t *testing.T, enableElasticsearch bool) (*TestHelper, []*model.FileInfo) {
+		th := Setup(t).InitBasic()
+
+		fileInfos := make([]*model.FileInfo, 7)
+		for i := 0; i < cap(fileInfos); i++ {
+			fileInfo, err := th.App.Srv().Store.FileInfo().Save(&model.FileInfo{
+				CreatorId: th.BasicUser.Id,
+				PostId:    th.BasicPost.Id,
+				Name:      searchTerm + ".txt",
+				Path:      searchTerm + ".txt",
+				Extension: "txt",
+				CreateAt:  int64(i + 1),
+			})
+			require.Nil(t, err)
+
+			fileInfos[i] = fileInfo
+		}
+
+		if enableElasticsearch {
+			th.App.Srv().SetLicense(model.NewTestLicense("elastic_search"))
+
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ElasticsearchSettings.EnableIndexing = true
+				*cfg.ElasticsearchSettings.EnableSearching = true
+			})
+		} else {
+			th.App.UpdateConfig(func(cfg *model.Config) {
+				*cfg.ElasticsearchSettings.EnableSearching = false
+			})
+		}
+
+		return th, fileInfos
+	}
+
+	t.Run("should return everything as first page of files from database", func(t *testing.T) {
+		th, fileInfos := setup(t, false)
+		defer th.TearDown()
+
+		results, err := th.App.SearchFilesInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			fileInfos[6].Id,
+			fileInfos[5].Id,
+			fileInfos[4].Id,
+			fileInfos[3].Id,
+			fileInfos[2].Id,
+			fileInfos[1].Id,
+			fileInfos[0].Id,
+		}, results.Order)
+	})
+
+	t.Run("should not return files from channels the user is not a member of", func(t *testing.T) {
+		th, _ := setup(t, false)
+		defer th.TearDown()
+
+		results, err := th.App.SearchFilesInTeamForUser(searchTerm, th.BasicUser2.Id, model.NewId(), false, false, 0, 0, 20)
+
+		assert.Nil(t, err)
+		assert.Empty(t, results.Order)
+	})
+
+	t.Run("should return first page of files from ElasticSearch", func(t *testing.T) {
+		th, fileInfos := setup(t, true)
+		defer th.TearDown()
+
+		page := 0
+		resultsPage := []string{
+			fileInfos[6].Id,
+			fileInfos[5].Id,
+			fileInfos[4].Id,
+			fileInfos[3].Id,
+			fileInfos[2].Id,
+		}
+
+		es := &mocks.SearchEngineInterface{}
+		es.On("SearchFiles", mock.Anything, mock.Anything, page, perPage).Return(resultsPage, nil)
+		es.On("GetName").Return("mock")
+		es.On("Start").Return(nil).Maybe()
+		es.On("IsActive").Return(true)
+		es.On("IsSearchEnabled").Return(true)
+		th.App.Srv().SearchEngine.ElasticsearchEngine = es
+		defer func() {
+			th.App.Srv().SearchEngine.ElasticsearchEngine = nil
+		}()
+
+		results, err := th.App.SearchFilesInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, page, perPage)
+
+		assert.Nil(t, err)
+		assert.Equal(t, resultsPage, results.Order)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("should fall back to database if ElasticSearch fails", func(t *testing.T) {
+		th, fileInfos := setup(t, true)
+		defer th.TearDown()
+
+		page := 0
+
+		es := &mocks.SearchEngineInterface{}
+		es.On("SearchFiles", mock.Anything, mock.Anything, page, perPage).Return(nil, &model.AppError{})
+		es.On("GetName").Return("mock")
+		es.On("Start").Return(nil).Maybe()
+		es.On("IsActive").Return(true)
+		es.On("IsSearchEnabled").Return(true)
+		th.App.Srv().SearchEngine.ElasticsearchEngine = es
+		defer func() {
+			th.App.Srv().SearchEngine.ElasticsearchEngine = nil
+		}()
+
+		results, err := th.App.SearchFilesInTeamForUser(searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, page, perPage)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			fileInfos[6].Id,
+			fileInfos[5].Id,
+			fileInfos[4].Id,
+			fileInfos[3].Id,
+			fileInfos[2].Id,
+		}, results.Order)
+		es.AssertExpectations(t)
+	})
+}
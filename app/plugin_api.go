@@ -473,7 +473,12 @@ func (api *PluginAPI) SearchPostsInTeamForUser(teamId string, userId string, sea
 		includeDeletedChannels = *searchParams.IncludeDeletedChannels
 	}
 
-	return api.app.SearchPostsInTeamForUser(terms, userId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	includeHighlights := false
+	if searchParams.IncludeHighlights != nil {
+		includeHighlights = *searchParams.IncludeHighlights
+	}
+
+	return api.app.SearchPostsInTeamForUser(terms, userId, teamId, isOrSearch, includeDeletedChannels, includeHighlights, timeZoneOffset, page, perPage)
 }
 
 func (api *PluginAPI) AddChannelMember(channelId, userId string) (*model.ChannelMember, *model.AppError) {
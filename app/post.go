@@ -732,7 +732,7 @@ func (a *App) GetPostsSince(options model.GetPostsSinceOptions) (*model.PostList
 }
 
 func (a *App) GetSinglePost(postId string) (*model.Post, *model.AppError) {
-	post, err := a.Srv().Store.Post().GetSingle(postId)
+	post, err := a.Srv().Store.Post().GetPost(postId, false)
 	if err != nil {
 		var nfErr *store.ErrNotFound
 		switch {
@@ -1016,7 +1016,7 @@ func (a *App) GetPostsForChannelAroundLastUnread(channelId, userId string, limit
 }
 
 func (a *App) DeletePost(postId, deleteByID string) (*model.Post, *model.AppError) {
-	post, nErr := a.Srv().Store.Post().GetSingle(postId)
+	post, nErr := a.Srv().Store.Post().GetPost(postId, false)
 	if nErr != nil {
 		return nil, model.NewAppError("DeletePost", "app.post.get.app_error", nil, nErr.Error(), http.StatusBadRequest)
 	}
@@ -1160,6 +1160,21 @@ func (a *App) convertChannelNamesToChannelIds(channels []string, userId string,
 	return channels
 }
 
+// getSearchTimeZone returns the time zone the dates of the user's searches are interpreted in,
+// or an empty string to fall back to the time zone offset sent along with the search.
+func (a *App) getSearchTimeZone(userId string) string {
+	if !*a.Config().DisplaySettings.ExperimentalTimezone {
+		return ""
+	}
+
+	user, err := a.Srv().Store.User().Get(userId)
+	if err != nil {
+		mlog.Warn("Unable to get the time zone of the user for the search", mlog.String("user_id", userId), mlog.Err(err))
+		return ""
+	}
+	return user.GetPreferredTimezone()
+}
+
 func (a *App) convertUserNameToUserIds(usernames []string) []string {
 	for idx, username := range usernames {
 		if user, err := a.GetUserByUsername(username); err != nil {
@@ -1180,7 +1195,7 @@ func (a *App) SearchPostsInTeam(teamId string, paramsList []*model.SearchParams)
 	})
 }
 
-func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, includeHighlights bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
 	var postSearchResults *model.PostSearchResults
 	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
 	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
@@ -1190,10 +1205,14 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 	}
 
 	finalParamsList := []*model.SearchParams{}
+	timeZone := a.getSearchTimeZone(userId)
 
 	for _, params := range paramsList {
 		params.OrTerms = isOrSearch
 		params.IncludeDeletedChannels = includeDeleted
+		params.IncludeHighlights = includeHighlights
+		params.TimeZone = timeZone
+		params.Fuzziness = *a.Config().SearchSettings.Fuzziness
 		// Don't allow users to search for "*"
 		if params.Terms != "*" {
 			// Convert channel names to channel IDs
@@ -1216,9 +1235,12 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 	postSearchResults, nErr := a.Srv().Store.Post().SearchPostsInTeamForUser(finalParamsList, userId, teamId, page, perPage)
 	if nErr != nil {
 		var appErr *model.AppError
+		var ltErr *store.ErrLimitExceeded
 		switch {
 		case errors.As(nErr, &appErr):
 			return nil, appErr
+		case errors.As(nErr, &ltErr):
+			return nil, model.NewAppError("SearchPostsInTeamForUser", "app.post.search.limit_exceeded.app_error", nil, ltErr.Error(), http.StatusBadRequest)
 		default:
 			return nil, model.NewAppError("SearchPostsInTeamForUser", "app.post.search.app_error", nil, nErr.Error(), http.StatusInternalServerError)
 		}
@@ -1227,11 +1249,67 @@ func (a *App) SearchPostsInTeamForUser(terms string, userId string, teamId strin
 	return postSearchResults, nil
 }
 
+// SearchPostsInAllTeams searches the posts of all the teams, whatever the channels the user is a
+// member of, provided the user has the permission to.
+func (a *App) SearchPostsInAllTeams(userId, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, *model.AppError) {
+	if !a.HasPermissionTo(userId, model.PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS) {
+		return nil, model.NewAppError("SearchPostsInAllTeams", "app.post.search_all_teams.unauthorized.app_error", nil, fmt.Sprintf("userId=%v", userId), http.StatusForbidden)
+	}
+
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return nil, model.NewAppError("SearchPostsInAllTeams", "store.sql_post.search.disabled", nil, fmt.Sprintf("userId=%v", userId), http.StatusNotImplemented)
+	}
+
+	opts.IncludeDeletedChannels = opts.IncludeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
+
+	postSearchResults, nErr := a.Srv().Store.Post().SearchAllTeams(userId, terms, opts)
+	if nErr != nil {
+		var appErr *model.AppError
+		var ltErr *store.ErrLimitExceeded
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		case errors.As(nErr, &ltErr):
+			return nil, model.NewAppError("SearchPostsInAllTeams", "app.post.search.limit_exceeded.app_error", nil, ltErr.Error(), http.StatusBadRequest)
+		default:
+			return nil, model.NewAppError("SearchPostsInAllTeams", "app.post.search.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return postSearchResults, nil
+}
+
+// SuggestSearchTerms suggests the channels, usernames and terms starting with the prefix typed
+// in the search box, limited to what the user has access to.
+func (a *App) SuggestSearchTerms(userId, teamId, prefix string, limit int) ([]*model.SearchSuggestion, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePostSearch {
+		return nil, model.NewAppError("SuggestSearchTerms", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamId, userId), http.StatusNotImplemented)
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []*model.SearchSuggestion{}, nil
+	}
+
+	suggestions, err := a.Srv().Store.Post().SuggestTerms(userId, teamId, prefix, limit)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SuggestSearchTerms", "app.post.suggest_search_terms.app_error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return suggestions, nil
+}
+
 func (a *App) GetFileInfosForPostWithMigration(postId string) ([]*model.FileInfo, *model.AppError) {
 
 	pchan := make(chan store.StoreResult, 1)
 	go func() {
-		post, err := a.Srv().Store.Post().GetSingle(postId)
+		post, err := a.Srv().Store.Post().GetPost(postId, false)
 		pchan <- store.StoreResult{Data: post, NErr: err}
 		close(pchan)
 	}()
@@ -55,8 +55,24 @@ func (a *App) PreparePostListForClient(originalList *model.PostList) *model.Post
 		PrevPostId: originalList.PrevPostId,
 	}
 
+	// Fetch the reactions of the whole list at once rather than post by post.
+	var postIds []string
+	for _, originalPost := range originalList.Posts {
+		if originalPost.HasReactions && originalPost.DeleteAt == 0 {
+			postIds = append(postIds, originalPost.Id)
+		}
+	}
+	reactions := map[string][]*model.Reaction{}
+	if len(postIds) > 0 {
+		var err *model.AppError
+		if reactions, err = a.GetBulkReactionsForPosts(postIds); err != nil {
+			mlog.Warn("Failed to get the reactions for a list of posts", mlog.Err(err))
+			reactions = nil
+		}
+	}
+
 	for id, originalPost := range originalList.Posts {
-		post := a.PreparePostForClient(originalPost, false, false)
+		post := a.preparePostForClient(originalPost, false, false, reactions)
 
 		list.Posts[id] = post
 	}
@@ -91,6 +107,12 @@ func (a *App) OverrideIconURLIfEmoji(post *model.Post) {
 }
 
 func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool) *model.Post {
+	return a.preparePostForClient(originalPost, isNewPost, isEditPost, nil)
+}
+
+// preparePostForClient takes the reactions of the post from reactionsByPost when it's not nil,
+// rather than fetching them.
+func (a *App) preparePostForClient(originalPost *model.Post, isNewPost bool, isEditPost bool, reactionsByPost map[string][]*model.Reaction) *model.Post {
 	post := originalPost.Clone()
 
 	// Proxy image links before constructing metadata so that requests go through the proxy
@@ -107,7 +129,7 @@ func (a *App) PreparePostForClient(originalPost *model.Post, isNewPost bool, isE
 	}
 
 	// Emojis and reaction counts
-	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post); err != nil {
+	if emojis, reactions, err := a.getEmojisAndReactionsForPost(post, reactionsByPost); err != nil {
 		mlog.Warn("Failed to get emojis and reactions for a post", mlog.String("post_id", post.Id), mlog.Err(err))
 	} else {
 		post.Metadata.Emojis = emojis
@@ -145,9 +167,11 @@ func (a *App) getFileMetadataForPost(post *model.Post, fromMaster bool) ([]*mode
 	return a.GetFileInfosForPost(post.Id, fromMaster)
 }
 
-func (a *App) getEmojisAndReactionsForPost(post *model.Post) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
+func (a *App) getEmojisAndReactionsForPost(post *model.Post, reactionsByPost map[string][]*model.Reaction) ([]*model.Emoji, []*model.Reaction, *model.AppError) {
 	var reactions []*model.Reaction
-	if post.HasReactions {
+	if post.HasReactions && reactionsByPost != nil {
+		reactions = reactionsByPost[post.Id]
+	} else if post.HasReactions {
 		var err *model.AppError
 		reactions, err = a.GetReactionsForPost(post.Id)
 		if err != nil {
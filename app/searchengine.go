@@ -18,8 +18,15 @@ func (a *App) TestElasticsearch(cfg *model.Config) *model.AppError {
 			return model.NewAppError("TestElasticsearch", "ent.elasticsearch.test_config.reenter_password", nil, "", http.StatusBadRequest)
 		}
 	}
+	if *cfg.ElasticsearchSettings.APIKey == model.FAKE_SETTING {
+		if *cfg.ElasticsearchSettings.ConnectionUrl == *a.Config().ElasticsearchSettings.ConnectionUrl {
+			*cfg.ElasticsearchSettings.APIKey = *a.Config().ElasticsearchSettings.APIKey
+		} else {
+			return model.NewAppError("TestElasticsearch", "ent.elasticsearch.test_config.reenter_api_key", nil, "", http.StatusBadRequest)
+		}
+	}
 
-	seI := a.SearchEngine().ElasticsearchEngine
+	seI := a.SearchEngine().ConfiguredEngine()
 	if seI == nil {
 		err := model.NewAppError("TestElasticsearch", "ent.elasticsearch.test_config.license.error", nil, "", http.StatusNotImplemented)
 		return err
@@ -36,7 +43,7 @@ func (a *App) SetSearchEngine(se *searchengine.Broker) {
 }
 
 func (a *App) PurgeElasticsearchIndexes() *model.AppError {
-	engine := a.SearchEngine().ElasticsearchEngine
+	engine := a.SearchEngine().ConfiguredEngine()
 	if engine == nil {
 		err := model.NewAppError("PurgeElasticsearchIndexes", "ent.elasticsearch.test_config.license.error", nil, "", http.StatusNotImplemented)
 		return err
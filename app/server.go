@@ -25,6 +25,7 @@ import (
 	"github.com/getsentry/sentry-go"
 	sentryhttp "github.com/getsentry/sentry-go/http"
 	"github.com/gorilla/mux"
+	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
 
@@ -45,12 +46,17 @@ import (
 	"github.com/mattermost/mattermost-server/v5/services/mailservice"
 	"github.com/mattermost/mattermost-server/v5/services/searchengine"
 	"github.com/mattermost/mattermost-server/v5/services/searchengine/bleveengine"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/opensearchengine"
 	"github.com/mattermost/mattermost-server/v5/services/telemetry"
 	"github.com/mattermost/mattermost-server/v5/services/timezones"
 	"github.com/mattermost/mattermost-server/v5/services/tracing"
 	"github.com/mattermost/mattermost-server/v5/services/upgrader"
 	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/auditlayer"
+	"github.com/mattermost/mattermost-server/v5/store/circuitbreakerlayer"
+	"github.com/mattermost/mattermost-server/v5/store/errorcontextlayer"
 	"github.com/mattermost/mattermost-server/v5/store/localcachelayer"
+	"github.com/mattermost/mattermost-server/v5/store/readonlylayer"
 	"github.com/mattermost/mattermost-server/v5/store/retrylayer"
 	"github.com/mattermost/mattermost-server/v5/store/searchlayer"
 	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
@@ -65,6 +71,9 @@ var SENTRY_DSN = "placeholder_sentry_dsn"
 
 type Server struct {
 	sqlStore           *sqlstore.SqlSupplier
+	circuitBreaker     *circuitbreakerlayer.Breaker
+	readOnlyStore      *readonlylayer.ReadOnlyLayer
+	auditStore         *auditlayer.AuditLayer
 	Store              store.Store
 	WebSocketRouter    *WebSocketRouter
 	AppInitializedOnce sync.Once
@@ -115,7 +124,7 @@ type Server struct {
 
 	timezones *timezones.Timezones
 
-	newStore func() store.Store
+	newStore func() (store.Store, error)
 
 	htmlTemplateWatcher     *utils.HTMLTemplateWatcher
 	sessionCache            cache.Cache
@@ -277,6 +286,7 @@ func NewServer(options ...Option) (*Server, error) {
 		return nil, err
 	}
 	searchEngine.RegisterBleveEngine(bleveEngine)
+	searchEngine.RegisterOpenSearchEngine(opensearchengine.NewOpenSearchEngine(s.Config(), s.License, func() einterfaces.MetricsInterface { return s.Metrics }, s.Jobs))
 	s.SearchEngine = searchEngine
 
 	// at the moment we only have this implementation
@@ -305,11 +315,24 @@ func NewServer(options ...Option) (*Server, error) {
 	s.initEnterprise()
 
 	if s.newStore == nil {
-		s.newStore = func() store.Store {
-			s.sqlStore = sqlstore.NewSqlSupplier(s.Config().SqlSettings, s.Metrics)
+		s.newStore = func() (store.Store, error) {
+			var err error
+			s.sqlStore, err = sqlstore.NewSqlSupplier(s.Config().SqlSettings, s.Metrics)
+			if err != nil {
+				return nil, err
+			}
+			if s.tracer != nil {
+				s.sqlStore.SetTracer(opentracing.GlobalTracer())
+			}
+			var childStore store.Store = retrylayer.New(errorcontextlayer.New(s.sqlStore))
+			if *s.Config().SqlSettings.CircuitBreakerThreshold > 0 {
+				s.circuitBreaker = circuitbreakerlayer.NewBreaker(&s.Config().SqlSettings)
+				childStore = circuitbreakerlayer.New(childStore, s.circuitBreaker)
+			}
+
 			searchStore := searchlayer.NewSearchLayer(
 				localcachelayer.NewLocalCacheLayer(
-					retrylayer.New(s.sqlStore),
+					childStore,
 					s.Metrics,
 					s.Cluster,
 					s.CacheProvider,
@@ -327,10 +350,18 @@ func NewServer(options ...Option) (*Server, error) {
 				s.sqlStore.UpdateLicense(newLicense)
 			})
 
-			return timerlayer.New(
+			timerStore := timerlayer.New(
 				searchStore,
 				s.Metrics,
 			)
+			timerStore.Stats = s.sqlStore
+			readOnlyStore := readonlylayer.New(timerStore)
+			if *s.Config().SqlSettings.EnableAuditTrail {
+				// The audit layer is the outermost one, for the requests to record their changes
+				// through a view of it carrying their user in its context.
+				return auditlayer.New(readOnlyStore, context.Background()), nil
+			}
+			return readOnlyStore, nil
 		}
 	}
 
@@ -340,7 +371,15 @@ func NewServer(options ...Option) (*Server, error) {
 		s.htmlTemplateWatcher = htmlTemplateWatcher
 	}
 
-	s.Store = s.newStore()
+	var err error
+	if s.Store, err = s.newStore(); err != nil {
+		return nil, errors.Wrap(err, "cannot create store")
+	}
+	storeBelowAudit := s.Store
+	if s.auditStore, _ = s.Store.(*auditlayer.AuditLayer); s.auditStore != nil {
+		storeBelowAudit = s.auditStore.Store
+	}
+	s.readOnlyStore, _ = storeBelowAudit.(*readonlylayer.ReadOnlyLayer)
 
 	s.configListenerId = s.AddConfigListener(func(_, _ *model.Config) {
 		s.configOrLicenseListener()
@@ -453,6 +492,11 @@ func NewServer(options ...Option) (*Server, error) {
 
 	model.AppErrorInit(utils.T)
 
+	model.SetPasswordHashCost(*s.Config().PasswordSettings.HashCost)
+	s.AddConfigListener(func(_, c *model.Config) {
+		model.SetPasswordHashCost(*c.PasswordSettings.HashCost)
+	})
+
 	s.timezones = timezones.New()
 	// Start email batching because it's not like the other jobs
 	s.AddConfigListener(func(_, _ *model.Config) {
@@ -536,6 +580,7 @@ func NewServer(options ...Option) (*Server, error) {
 	searchConfigListenerId, searchLicenseListenerId := s.StartSearchEngine()
 	s.searchConfigListenerId = searchConfigListenerId
 	s.searchLicenseListenerId = searchLicenseListenerId
+	s.SearchEngine.StartHealthChecks()
 
 	// if enabled - perform initial product notices fetch
 	if *s.Config().AnnouncementSettings.AdminNoticesEnabled || *s.Config().AnnouncementSettings.UserNoticesEnabled {
@@ -852,6 +897,11 @@ func (s *Server) UpgradeToE0Status() (int64, error) {
 	return upgrader.UpgradeToE0Status()
 }
 
+// AuditStore returns the audit layer of the store, or nil when the audit trail is disabled.
+func (s *Server) AuditStore() *auditlayer.AuditLayer {
+	return s.auditStore
+}
+
 // Go creates a goroutine, but maintains a record of it to ensure that execution completes before
 // the server is shutdown.
 func (s *Server) Go(f func()) {
@@ -1424,9 +1474,9 @@ func doLicenseExpirationCheck(a *App) {
 }
 
 func (s *Server) StartSearchEngine() (string, string) {
-	if s.SearchEngine.ElasticsearchEngine != nil && s.SearchEngine.ElasticsearchEngine.IsActive() {
+	if engine := s.SearchEngine.ConfiguredEngine(); engine != nil && engine.IsActive() {
 		s.Go(func() {
-			if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
+			if err := engine.Start(); err != nil {
 				s.Log.Error(err.Error())
 			}
 		})
@@ -1436,27 +1486,45 @@ func (s *Server) StartSearchEngine() (string, string) {
 		if s.SearchEngine == nil {
 			return
 		}
+		oldEngine := s.SearchEngine.ConfiguredEngine()
 		s.SearchEngine.UpdateConfig(newConfig)
+		engine := s.SearchEngine.ConfiguredEngine()
 
-		if s.SearchEngine.ElasticsearchEngine != nil && !*oldConfig.ElasticsearchSettings.EnableIndexing && *newConfig.ElasticsearchSettings.EnableIndexing {
+		if engine != oldEngine {
 			s.Go(func() {
-				if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
+				if oldEngine != nil && *oldConfig.ElasticsearchSettings.EnableIndexing {
+					if err := oldEngine.Stop(); err != nil {
+						mlog.Error(err.Error())
+					}
+				}
+				if engine != nil && *newConfig.ElasticsearchSettings.EnableIndexing {
+					if err := engine.Start(); err != nil {
+						mlog.Error(err.Error())
+					}
+				}
+			})
+		} else if engine != nil && !*oldConfig.ElasticsearchSettings.EnableIndexing && *newConfig.ElasticsearchSettings.EnableIndexing {
+			s.Go(func() {
+				if err := engine.Start(); err != nil {
 					mlog.Error(err.Error())
 				}
 			})
-		} else if s.SearchEngine.ElasticsearchEngine != nil && *oldConfig.ElasticsearchSettings.EnableIndexing && !*newConfig.ElasticsearchSettings.EnableIndexing {
+		} else if engine != nil && *oldConfig.ElasticsearchSettings.EnableIndexing && !*newConfig.ElasticsearchSettings.EnableIndexing {
 			s.Go(func() {
-				if err := s.SearchEngine.ElasticsearchEngine.Stop(); err != nil {
+				if err := engine.Stop(); err != nil {
 					mlog.Error(err.Error())
 				}
 			})
-		} else if s.SearchEngine.ElasticsearchEngine != nil && *oldConfig.ElasticsearchSettings.Password != *newConfig.ElasticsearchSettings.Password || *oldConfig.ElasticsearchSettings.Username != *newConfig.ElasticsearchSettings.Username || *oldConfig.ElasticsearchSettings.ConnectionUrl != *newConfig.ElasticsearchSettings.ConnectionUrl || *oldConfig.ElasticsearchSettings.Sniff != *newConfig.ElasticsearchSettings.Sniff {
+		} else if engine != nil && (*oldConfig.ElasticsearchSettings.Password != *newConfig.ElasticsearchSettings.Password || *oldConfig.ElasticsearchSettings.Username != *newConfig.ElasticsearchSettings.Username || *oldConfig.ElasticsearchSettings.ConnectionUrl != *newConfig.ElasticsearchSettings.ConnectionUrl || *oldConfig.ElasticsearchSettings.Sniff != *newConfig.ElasticsearchSettings.Sniff ||
+			*oldConfig.ElasticsearchSettings.SkipTLSVerification != *newConfig.ElasticsearchSettings.SkipTLSVerification || *oldConfig.ElasticsearchSettings.CA != *newConfig.ElasticsearchSettings.CA ||
+			*oldConfig.ElasticsearchSettings.ClientCert != *newConfig.ElasticsearchSettings.ClientCert || *oldConfig.ElasticsearchSettings.ClientKey != *newConfig.ElasticsearchSettings.ClientKey ||
+			*oldConfig.ElasticsearchSettings.APIKey != *newConfig.ElasticsearchSettings.APIKey) {
 			s.Go(func() {
 				if *oldConfig.ElasticsearchSettings.EnableIndexing {
-					if err := s.SearchEngine.ElasticsearchEngine.Stop(); err != nil {
+					if err := engine.Stop(); err != nil {
 						mlog.Error(err.Error())
 					}
-					if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
+					if err := engine.Start(); err != nil {
 						mlog.Error(err.Error())
 					}
 				}
@@ -1468,18 +1536,19 @@ func (s *Server) StartSearchEngine() (string, string) {
 		if s.SearchEngine == nil {
 			return
 		}
+		engine := s.SearchEngine.ConfiguredEngine()
 		if oldLicense == nil && newLicense != nil {
-			if s.SearchEngine.ElasticsearchEngine != nil && s.SearchEngine.ElasticsearchEngine.IsActive() {
+			if engine != nil && engine.IsActive() {
 				s.Go(func() {
-					if err := s.SearchEngine.ElasticsearchEngine.Start(); err != nil {
+					if err := engine.Start(); err != nil {
 						mlog.Error(err.Error())
 					}
 				})
 			}
 		} else if oldLicense != nil && newLicense == nil {
-			if s.SearchEngine.ElasticsearchEngine != nil {
+			if engine != nil {
 				s.Go(func() {
-					if err := s.SearchEngine.ElasticsearchEngine.Stop(); err != nil {
+					if err := engine.Stop(); err != nil {
 						mlog.Error(err.Error())
 					}
 				})
@@ -1493,8 +1562,11 @@ func (s *Server) StartSearchEngine() (string, string) {
 func (s *Server) stopSearchEngine() {
 	s.RemoveConfigListener(s.searchConfigListenerId)
 	s.RemoveLicenseListener(s.searchLicenseListenerId)
-	if s.SearchEngine != nil && s.SearchEngine.ElasticsearchEngine != nil && s.SearchEngine.ElasticsearchEngine.IsActive() {
-		s.SearchEngine.ElasticsearchEngine.Stop()
+	if s.SearchEngine != nil {
+		s.SearchEngine.StopHealthChecks()
+		if engine := s.SearchEngine.ConfiguredEngine(); engine != nil && engine.IsActive() {
+			engine.Stop()
+		}
 	}
 	if s.SearchEngine != nil && s.SearchEngine.BleveEngine != nil && s.SearchEngine.BleveEngine.IsActive() {
 		s.SearchEngine.BleveEngine.Stop()
@@ -1521,6 +1593,12 @@ func (s *Server) ClusterHealthScore() int {
 	return s.Cluster.HealthScore()
 }
 
+// DatabaseCircuitBreaker returns the circuit breaker guarding the database, or nil when
+// it's disabled.
+func (s *Server) DatabaseCircuitBreaker() *circuitbreakerlayer.Breaker {
+	return s.circuitBreaker
+}
+
 func (s *Server) configOrLicenseListener() {
 	s.regenerateClientConfig()
 }
@@ -1546,6 +1624,9 @@ func (s *Server) initJobs() {
 	if jobsBleveIndexerInterface != nil {
 		s.Jobs.BleveIndexer = jobsBleveIndexerInterface(s)
 	}
+	if jobsOpenSearchIndexerInterface != nil {
+		s.Jobs.OpenSearchIndexer = jobsOpenSearchIndexerInterface(s)
+	}
 	if jobsMigrationsInterface != nil {
 		s.Jobs.Migrations = jobsMigrationsInterface(s)
 	}
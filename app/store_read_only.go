@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// SetStoreReadOnly turns the read-only mode of the store on or off, and notifies the cluster
+// nodes so that they all switch together. In read-only mode, the store rejects the changes with
+// a store.ErrReadOnly error while serving the reads.
+func (s *Server) SetStoreReadOnly(readOnly bool) *model.AppError {
+	if s.readOnlyStore == nil {
+		return model.NewAppError("SetStoreReadOnly", "app.store_read_only.not_available.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	s.storeReadOnlyChanged(readOnly)
+
+	if s.Cluster != nil {
+		s.Cluster.SendClusterMessage(&model.ClusterMessage{
+			Event:            model.CLUSTER_EVENT_STORE_READ_ONLY_CHANGED,
+			SendType:         model.CLUSTER_SEND_RELIABLE,
+			WaitForAllToSend: true,
+			Data:             model.MapBoolToJson(map[string]bool{"read_only": readOnly}),
+		})
+	}
+	return nil
+}
+
+// IsStoreReadOnly returns true when the store rejects the changes.
+func (s *Server) IsStoreReadOnly() bool {
+	return s.readOnlyStore != nil && s.readOnlyStore.IsReadOnly()
+}
+
+// storeReadOnlyChanged switches the read-only mode of the store without notifying the cluster
+// nodes, as done when a CLUSTER_EVENT_STORE_READ_ONLY_CHANGED is received.
+func (s *Server) storeReadOnlyChanged(readOnly bool) {
+	if s.readOnlyStore == nil {
+		return
+	}
+
+	s.readOnlyStore.SetReadOnly(readOnly)
+	if readOnly {
+		mlog.Warn("store read-only mode activated - changes are rejected")
+	} else {
+		mlog.Info("store read-only mode cleared - changes are accepted")
+	}
+}
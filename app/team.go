@@ -1804,33 +1804,10 @@ func (a *App) RestoreTeam(teamId string) *model.AppError {
 }
 
 func (a *App) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, *model.AppError) {
-	tchan := make(chan store.StoreResult, 1)
-	go func() {
-		totalMemberCount, err := a.Srv().Store.Team().GetTotalMemberCount(teamId, restrictions)
-		tchan <- store.StoreResult{Data: totalMemberCount, NErr: err}
-		close(tchan)
-	}()
-	achan := make(chan store.StoreResult, 1)
-	go func() {
-		memberCount, err := a.Srv().Store.Team().GetActiveMemberCount(teamId, restrictions)
-		achan <- store.StoreResult{Data: memberCount, NErr: err}
-		close(achan)
-	}()
-
-	stats := &model.TeamStats{}
-	stats.TeamId = teamId
-
-	result := <-tchan
-	if result.NErr != nil {
-		return nil, model.NewAppError("GetTeamStats", "app.team.get_member_count.app_error", nil, result.NErr.Error(), http.StatusInternalServerError)
-	}
-	stats.TotalMemberCount = result.Data.(int64)
-
-	result = <-achan
-	if result.NErr != nil {
-		return nil, model.NewAppError("GetTeamStats", "app.team.get_active_member_count.app_error", nil, result.NErr.Error(), http.StatusInternalServerError)
+	stats, err := a.Srv().Store.Team().GetTeamStats(teamId, restrictions)
+	if err != nil {
+		return nil, model.NewAppError("GetTeamStats", "app.team.get_member_count.app_error", nil, err.Error(), http.StatusInternalServerError)
 	}
-	stats.ActiveMemberCount = result.Data.(int64)
 
 	return stats, nil
 }
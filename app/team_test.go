@@ -1032,6 +1032,27 @@ func TestGetTeamStats(t *testing.T) {
 	})
 }
 
+func TestGetTeamStatsQueriesOnce(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	teamId := model.NewId()
+	restrictions := &model.ViewUsersRestrictions{Teams: []string{teamId}}
+	mockTeamStore := mocks.TeamStore{}
+	mockTeamStore.On("GetTeamStats", teamId, restrictions).Return(&model.TeamStats{TeamId: teamId, TotalMemberCount: 3, ActiveMemberCount: 2}, nil)
+	mockStore := th.App.Srv().Store.(*mocks.Store)
+	mockStore.On("Team").Return(&mockTeamStore)
+
+	stats, err := th.App.GetTeamStats(teamId, restrictions)
+	require.Nil(t, err)
+	assert.Equal(t, &model.TeamStats{TeamId: teamId, TotalMemberCount: 3, ActiveMemberCount: 2}, stats)
+
+	// Both counts come from a single query rather than one query each.
+	assert.Len(t, mockTeamStore.Calls, 1)
+	mockTeamStore.AssertNotCalled(t, "GetTotalMemberCount", mock.Anything, mock.Anything)
+	mockTeamStore.AssertNotCalled(t, "GetActiveMemberCount", mock.Anything, mock.Anything)
+}
+
 func TestUpdateTeamMemberRolesChangingGuest(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -446,7 +446,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 	}
 
 	if len(hook.ChannelId) != 0 {
-		channel, errCh := a.Srv().Store.Channel().Get(hook.ChannelId, true)
+		channel, errCh := a.Srv().Store.Channel().GetChannel(hook.ChannelId, false)
 		if errCh != nil {
 			var nfErr *store.ErrNotFound
 			switch {
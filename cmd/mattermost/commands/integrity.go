@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -18,9 +19,22 @@ var IntegrityCmd = &cobra.Command{
 	RunE:  integrityCmdF,
 }
 
+var IntegrityOrphansCmd = &cobra.Command{
+	Use:     "orphans [type]",
+	Short:   "Find and delete orphaned records",
+	Long:    "Find the records of the given [type] referencing a channel, a post or a user that doesn't exist anymore, and delete them with --delete. The type is one of " + strings.Join(model.OrphanTypes, ", ") + ".",
+	Example: "  integrity orphans file_info\n  integrity orphans channel_member --delete",
+	Args:    cobra.ExactArgs(1),
+	RunE:    integrityOrphansCmdF,
+}
+
 func init() {
 	IntegrityCmd.Flags().Bool("confirm", false, "Confirm you really want to run a complete integrity check that may temporarily harm system performance")
 	IntegrityCmd.Flags().BoolP("verbose", "v", false, "Show detailed information on integrity check results")
+	IntegrityOrphansCmd.Flags().Int("limit", 100, "Maximum number of orphaned records to list")
+	IntegrityOrphansCmd.Flags().Bool("delete", false, "Delete all the orphaned records of the type instead of listing them")
+	IntegrityOrphansCmd.Flags().Int("batch-size", 1000, "Number of orphaned records deleted per transaction")
+	IntegrityCmd.AddCommand(IntegrityOrphansCmd)
 	RootCmd.AddCommand(IntegrityCmd)
 }
 
@@ -94,3 +108,34 @@ func integrityCmdF(command *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func integrityOrphansCmdF(command *cobra.Command, args []string) error {
+	a, err := InitDBCommandContextCobra(command)
+	if err != nil {
+		return err
+	}
+	defer a.Srv().Shutdown()
+
+	entityType := args[0]
+	deleteFlag, _ := command.Flags().GetBool("delete")
+	if deleteFlag {
+		batchSize, _ := command.Flags().GetInt("batch-size")
+		deleted, err := a.Srv().Store.DeleteOrphans(entityType, batchSize)
+		fmt.Printf("Deleted %d orphaned records of type %s\n", deleted, entityType)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete the orphaned records")
+		}
+		return nil
+	}
+
+	limit, _ := command.Flags().GetInt("limit")
+	ids, err := a.Srv().Store.FindOrphans(entityType, limit)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the orphaned records")
+	}
+	fmt.Printf("Found %d orphaned records of type %s\n", len(ids), entityType)
+	for _, id := range ids {
+		fmt.Printf("  %s\n", id)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
@@ -15,6 +16,8 @@ import (
 	"github.com/mattermost/mattermost-server/v5/config"
 	"github.com/mattermost/mattermost-server/v5/manualtesting"
 	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
 	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/mattermost/mattermost-server/v5/web"
 	"github.com/mattermost/mattermost-server/v5/wsapi"
@@ -30,6 +33,9 @@ var serverCmd = &cobra.Command{
 }
 
 func init() {
+	serverCmd.Flags().Bool("migrate-dry-run", false, "Print the database schema upgrades the server would run, without running them or starting the server.")
+	RootCmd.Flags().Bool("migrate-dry-run", false, "Print the database schema upgrades the server would run, without running them or starting the server.")
+
 	RootCmd.AddCommand(serverCmd)
 	RootCmd.RunE = serverCmdF
 }
@@ -37,6 +43,7 @@ func init() {
 func serverCmdF(command *cobra.Command, args []string) error {
 	disableConfigWatch, _ := command.Flags().GetBool("disableconfigwatch")
 	usedPlatform, _ := command.Flags().GetBool("platform")
+	migrateDryRun, _ := command.Flags().GetBool("migrate-dry-run")
 
 	interruptChan := make(chan os.Signal, 1)
 
@@ -48,6 +55,11 @@ func serverCmdF(command *cobra.Command, args []string) error {
 		return errors.Wrap(err, "failed to load configuration")
 	}
 
+	if migrateDryRun {
+		defer configStore.Close()
+		return printMigrationPlan(configStore.Get().SqlSettings)
+	}
+
 	return runServer(configStore, usedPlatform, interruptChan)
 }
 
@@ -101,6 +113,30 @@ func runServer(configStore *config.Store, usedPlatform bool, interruptChan chan
 	return nil
 }
 
+// printMigrationPlan prints the schema upgrades pending on the configured database, flagging
+// the ones which can't be undone.
+func printMigrationPlan(settings model.SqlSettings) error {
+	plan, err := sqlstore.PlanMigrations(settings)
+	if err != nil {
+		return errors.Wrap(err, "failed to plan the database migrations")
+	}
+
+	if len(plan) == 0 {
+		CommandPrintln("The database schema is up to date.")
+		return nil
+	}
+
+	for _, step := range plan {
+		line := fmt.Sprintf("%s -> %s: %s", step.FromVersion, step.Version, step.Description)
+		if step.Destructive {
+			line += " (destructive)"
+		}
+		CommandPrintln(line)
+	}
+
+	return nil
+}
+
 func notifyReady() {
 	// If the environment vars provide a systemd notification socket,
 	// notify systemd that the server is ready.
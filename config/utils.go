@@ -52,6 +52,9 @@ func desanitize(actual, target *model.Config) {
 	if *target.ElasticsearchSettings.Password == model.FAKE_SETTING {
 		*target.ElasticsearchSettings.Password = *actual.ElasticsearchSettings.Password
 	}
+	if *target.ElasticsearchSettings.APIKey == model.FAKE_SETTING {
+		*target.ElasticsearchSettings.APIKey = *actual.ElasticsearchSettings.APIKey
+	}
 
 	if len(target.SqlSettings.DataSourceReplicas) == len(actual.SqlSettings.DataSourceReplicas) {
 		for i, value := range target.SqlSettings.DataSourceReplicas {
@@ -49,10 +49,12 @@ type MetricsInterface interface {
 	IncrementPostsSearchCounter()
 	ObservePostsSearchDuration(elapsed float64)
 	ObserveStoreMethodDuration(method, success string, elapsed float64)
+	ObserveStoreQueryDuration(method string, elapsed float64)
 	ObserveApiEndpointDuration(endpoint, method, statusCode string, elapsed float64)
 	IncrementPostIndexCounter()
 	IncrementUserIndexCounter()
 	IncrementChannelIndexCounter()
+	IncrementSearchRequestRetryCounter(engine, operation string)
 
 	ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64)
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
@@ -160,6 +160,11 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementSearchRequestRetryCounter provides a mock function with given fields: engine, operation
+func (_m *MetricsInterface) IncrementSearchRequestRetryCounter(engine string, operation string) {
+	_m.Called(engine, operation)
+}
+
 // IncrementUserIndexCounter provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementUserIndexCounter() {
 	_m.Called()
@@ -235,6 +240,11 @@ func (_m *MetricsInterface) ObserveStoreMethodDuration(method string, success st
 	_m.Called(method, success, elapsed)
 }
 
+// ObserveStoreQueryDuration provides a mock function with given fields: method, elapsed
+func (_m *MetricsInterface) ObserveStoreQueryDuration(method string, elapsed float64) {
+	_m.Called(method, elapsed)
+}
+
 // StartServer provides a mock function with given fields:
 func (_m *MetricsInterface) StartServer() {
 	_m.Called()
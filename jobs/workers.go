@@ -46,7 +46,13 @@ func (srv *JobServer) InitWorkers() *Workers {
 		workers.MessageExport = srv.MessageExportJob.MakeWorker()
 	}
 
-	if elasticsearchIndexerInterface := srv.ElasticsearchIndexer; elasticsearchIndexerInterface != nil {
+	// The OpenSearch indexer runs the Elasticsearch indexing jobs when OpenSearch backs the
+	// ElasticsearchSettings.
+	if *srv.Config().SearchSettings.Backend == model.SEARCH_BACKEND_OPENSEARCH {
+		if openSearchIndexerInterface := srv.OpenSearchIndexer; openSearchIndexerInterface != nil {
+			workers.ElasticsearchIndexing = openSearchIndexerInterface.MakeWorker()
+		}
+	} else if elasticsearchIndexerInterface := srv.ElasticsearchIndexer; elasticsearchIndexerInterface != nil {
 		workers.ElasticsearchIndexing = elasticsearchIndexerInterface.MakeWorker()
 	}
 
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+)
+
+// DefaultConsoleBufferSize is the number of entries buffered by the console output
+// when ConsoleBuffered is set without a size.
+const DefaultConsoleBufferSize = 1000
+
+type bufferedRecord struct {
+	data  []byte
+	flush chan struct{}
+}
+
+// bufferedWriter writes to the wrapped writer from a background goroutine, so that
+// logging goroutines don't serialize on the output. Records are dropped, and counted,
+// once the buffer is full.
+type bufferedWriter struct {
+	out     io.Writer
+	queue   chan bufferedRecord
+	dropped uint64
+}
+
+func newBufferedWriter(out io.Writer, size int) *bufferedWriter {
+	if size <= 0 {
+		size = DefaultConsoleBufferSize
+	}
+
+	w := &bufferedWriter{
+		out:   out,
+		queue: make(chan bufferedRecord, size),
+	}
+	go w.run()
+
+	return w
+}
+
+func (w *bufferedWriter) run() {
+	for rec := range w.queue {
+		if rec.flush != nil {
+			close(rec.flush)
+			continue
+		}
+		w.out.Write(rec.data)
+	}
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	// The encoder reuses p once Write returns.
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	select {
+	case w.queue <- bufferedRecord{data: data}:
+	default:
+		atomic.AddUint64(&w.dropped, 1)
+	}
+	return len(p), nil
+}
+
+func (w *bufferedWriter) Sync() error {
+	return w.Flush(context.Background())
+}
+
+// Flush waits until the records buffered so far are written, or the context is done.
+func (w *bufferedWriter) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case w.queue <- bufferedRecord{flush: done}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped returns the number of records dropped because the buffer was full.
+func (w *bufferedWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingWriter struct {
+	mut     sync.Mutex
+	written [][]byte
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.unblock
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	w.written = append(w.written, p)
+	return len(p), nil
+}
+
+func TestBufferedWriter(t *testing.T) {
+	out := &blockingWriter{unblock: make(chan struct{})}
+	w := newBufferedWriter(out, 2)
+
+	// The first record is taken by the background goroutine, which then blocks on the output.
+	w.Write([]byte("1"))
+	require.Eventually(t, func() bool { return len(w.queue) == 0 }, time.Second, time.Millisecond)
+
+	w.Write([]byte("2"))
+	w.Write([]byte("3"))
+	w.Write([]byte("4"))
+	assert.EqualValues(t, 1, w.Dropped())
+
+	close(out.unblock)
+	require.NoError(t, w.Flush(context.Background()))
+
+	out.mut.Lock()
+	defer out.mut.Unlock()
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2"), []byte("3")}, out.written)
+}
+
+func TestBufferedWriterFlushTimeout(t *testing.T) {
+	out := &blockingWriter{unblock: make(chan struct{})}
+	defer close(out.unblock)
+	w := newBufferedWriter(out, 2)
+	w.Write([]byte("1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, w.Flush(ctx))
+}
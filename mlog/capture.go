@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEntry is a single log record captured by a LogCapture.
+type LogEntry struct {
+	Level   string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// LogCapture keeps the log records written to it in memory so they can be
+// inspected by tests.
+type LogCapture struct {
+	mut sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewCapturingLogger creates a Logger that writes every record at or above the given
+// level into the returned LogCapture.
+func NewCapturingLogger(level string) (*Logger, *LogCapture) {
+	capture := &LogCapture{}
+
+	logger := &Logger{
+		consoleLevel: zap.NewAtomicLevelAt(getZapLevel(level)),
+		fileLevel:    zap.NewAtomicLevelAt(getZapLevel(level)),
+		syslogLevel:  zap.NewAtomicLevelAt(getZapLevel(level)),
+		logrLogger:   newLogr(),
+	}
+
+	core := zapcore.NewCore(makeEncoder(true), zapcore.AddSync(capture), logger.consoleLevel)
+	logger.zap = zap.New(core, zap.AddCaller())
+
+	return logger, capture
+}
+
+func (c *LogCapture) Write(p []byte) (int, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.buf.Write(p)
+}
+
+// Entries returns the log records captured so far, in the order they were written.
+func (c *LogCapture) Entries() []LogEntry {
+	c.mut.Lock()
+	data := make([]byte, c.buf.Len())
+	copy(data, c.buf.Bytes())
+	c.mut.Unlock()
+
+	entries := []LogEntry{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			continue
+		}
+
+		entry := LogEntry{Fields: make(map[string]interface{})}
+		for key, value := range record {
+			switch key {
+			case "level":
+				entry.Level, _ = value.(string)
+			case "msg":
+				entry.Message, _ = value.(string)
+			case "ts", "caller", "stacktrace":
+			default:
+				entry.Fields[key] = value
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// Reset discards the log records captured so far.
+func (c *LogCapture) Reset() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.buf.Reset()
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"context"
+)
+
+type contextFieldsKey struct{}
+
+// WithContext returns a copy of ctx carrying the given fields, merged with the fields
+// already attached to ctx. Fields are identified by key, and the ones given here win.
+func WithContext(ctx context.Context, fields ...Field) context.Context {
+	existing := FieldsFromContext(ctx)
+	merged := make([]Field, 0, len(existing)+len(fields))
+
+	overridden := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		overridden[field.Key] = true
+	}
+	for _, field := range existing {
+		if !overridden[field.Key] {
+			merged = append(merged, field)
+		}
+	}
+	merged = append(merged, fields...)
+
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields attached to ctx with WithContext.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	return fields
+}
+
+// WithContext returns a logger adding the fields attached to ctx to every log entry.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
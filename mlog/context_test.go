@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx := WithContext(context.Background(), String("request_id", "outer"), String("user_id", "user1"))
+	ctx = WithContext(ctx, String("request_id", "inner"))
+
+	assert.Equal(t, []Field{String("user_id", "user1"), String("request_id", "inner")}, FieldsFromContext(ctx))
+	assert.Empty(t, FieldsFromContext(context.Background()))
+
+	logger, capture := NewCapturingLogger(LevelDebug)
+	logger.WithContext(ctx).Info("message", String("extra", "value"))
+	logger.WithContext(context.Background()).Info("no fields")
+
+	entries := capture.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "inner",
+		"user_id":    "user1",
+		"extra":      "value",
+	}, entries[0].Fields)
+	assert.Empty(t, entries[1].Fields)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrorWithFields is implemented by the errors carrying structured context, such as the store
+// errors wrapped with store.WrapErr. Their fields are logged next to the error by Err.
+type ErrorWithFields interface {
+	error
+	LogFields() []Field
+}
+
+// withErrorFields appends the fields of the logged errors carrying structured context, anywhere
+// in their chain, to the fields of an entry.
+func withErrorFields(fields []Field) []Field {
+	var errorFields []Field
+	for _, field := range fields {
+		if field.Type != zapcore.ErrorType {
+			continue
+		}
+		err, ok := field.Interface.(error)
+		if !ok {
+			continue
+		}
+		var withFields ErrorWithFields
+		if errors.As(err, &withFields) {
+			errorFields = append(errorFields, withFields.LogFields()...)
+		}
+	}
+	if len(errorFields) == 0 {
+		return fields
+	}
+	return append(fields[:len(fields):len(fields)], errorFields...)
+}
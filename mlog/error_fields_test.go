@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testErrorWithFields struct {
+	err error
+}
+
+func (e *testErrorWithFields) Error() string {
+	return "context: " + e.err.Error()
+}
+
+func (e *testErrorWithFields) Unwrap() error {
+	return e.err
+}
+
+func (e *testErrorWithFields) LogFields() []Field {
+	return []Field{String("operation", "ChannelStore.Get"), String("channel_id", "channel1")}
+}
+
+func TestErrorFields(t *testing.T) {
+	logger, capture := NewCapturingLogger(LevelDebug)
+
+	err := fmt.Errorf("outer: %w", &testErrorWithFields{err: errors.New("failed")})
+	logger.Error("message", Err(err), String("extra", "value"))
+	logger.Error("plain", Err(errors.New("failed")))
+
+	entries := capture.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"error":      "outer: context: failed",
+		"extra":      "value",
+		"operation":  "ChannelStore.Get",
+		"channel_id": "channel1",
+	}, entries[0].Fields)
+	assert.Equal(t, map[string]interface{}{
+		"error": "failed",
+	}, entries[1].Fields)
+}
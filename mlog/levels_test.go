@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLevelsAtRuntime(t *testing.T) {
+	consoleBuf := &bytes.Buffer{}
+	fileBuf := &bytes.Buffer{}
+
+	logger := &Logger{
+		consoleLevel: zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		fileLevel:    zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		logrLogger:   newLogr(),
+	}
+	logger.zap = zap.New(zapcore.NewTee(
+		zapcore.NewCore(makeEncoder(true), zapcore.Lock(zapcore.AddSync(consoleBuf)), logger.consoleLevel),
+		zapcore.NewCore(makeEncoder(true), zapcore.Lock(zapcore.AddSync(fileBuf)), logger.fileLevel),
+	))
+
+	logger.Debug("hidden")
+	assert.Empty(t, consoleBuf.String())
+	assert.Empty(t, fileBuf.String())
+
+	logger.SetConsoleLevel(LevelDebug)
+	logger.Debug("console only")
+	assert.Contains(t, consoleBuf.String(), "console only")
+	assert.NotContains(t, fileBuf.String(), "console only")
+
+	logger.SetFileLevel(LevelDebug)
+	logger.Debug("both")
+	assert.Contains(t, consoleBuf.String(), "both")
+	assert.Contains(t, fileBuf.String(), "both")
+
+	t.Run("concurrent with logging", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Debug("concurrent")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.SetConsoleLevel(LevelInfo)
+				logger.SetFileLevel(LevelDebug)
+			}
+		}()
+		wg.Wait()
+	})
+}
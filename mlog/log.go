@@ -66,13 +66,44 @@ type LoggerConfiguration struct {
 	FileJson      bool
 	FileLevel     string
 	FileLocation  string
+
+	// ConsoleBuffered writes console output from a background goroutine, buffering up
+	// to ConsoleBufferSize entries. Entries are dropped when the buffer is full.
+	ConsoleBuffered   bool
+	ConsoleBufferSize int
+
+	EnableSyslog bool
+	SyslogJson   bool
+	SyslogLevel  string
+	// SyslogNetwork is one of "tcp", "udp" or "tcp+tls". It is ignored when
+	// SyslogAddr starts with syslog+tls://.
+	SyslogNetwork  string
+	SyslogAddr     string
+	SyslogTag      string
+	SyslogCAPath   string
+	SyslogInsecure bool
+
+	// SampleInitial enables sampling when set: within every SampleTick window, only the first
+	// SampleInitial entries with a given level and message are logged, then every
+	// SampleThereafter-th one. The number of dropped entries is reported once the window is over.
+	SampleInitial    int
+	SampleThereafter int
+	SampleTick       time.Duration
+
+	// PrefixLevels overrides the console and file levels for loggers whose name
+	// starts with one of the given prefixes. The longest matching prefix wins.
+	PrefixLevels map[string]string
 }
 
 type Logger struct {
 	zap          *zap.Logger
 	consoleLevel zap.AtomicLevel
 	fileLevel    zap.AtomicLevel
+	syslogLevel  zap.AtomicLevel
+	prefixLevels *prefixLevels
 	logrLogger   *logr.Logger
+
+	consoleBuffer *bufferedWriter
 }
 
 func getZapLevel(level string) zapcore.Level {
@@ -105,13 +136,19 @@ func NewLogger(config *LoggerConfiguration) *Logger {
 	logger := &Logger{
 		consoleLevel: zap.NewAtomicLevelAt(getZapLevel(config.ConsoleLevel)),
 		fileLevel:    zap.NewAtomicLevelAt(getZapLevel(config.FileLevel)),
+		syslogLevel:  zap.NewAtomicLevelAt(getZapLevel(config.SyslogLevel)),
+		prefixLevels: newPrefixLevels(config.PrefixLevels),
 		logrLogger:   newLogr(),
 	}
 
 	if config.EnableConsole {
 		writer := zapcore.Lock(os.Stderr)
-		core := zapcore.NewCore(makeEncoder(config.ConsoleJson), writer, logger.consoleLevel)
-		cores = append(cores, core)
+		if config.ConsoleBuffered {
+			logger.consoleBuffer = newBufferedWriter(writer, config.ConsoleBufferSize)
+			writer = logger.consoleBuffer
+		}
+		core := zapcore.NewCore(makeEncoder(config.ConsoleJson), writer, zapcore.DebugLevel)
+		cores = append(cores, newPrefixLevelCore(core, logger.consoleLevel, logger.prefixLevels))
 	}
 
 	if config.EnableFile {
@@ -139,12 +176,24 @@ func NewLogger(config *LoggerConfiguration) *Logger {
 				Compress: true,
 			})
 
-			core := zapcore.NewCore(makeEncoder(config.FileJson), writer, logger.fileLevel)
-			cores = append(cores, core)
+			core := zapcore.NewCore(makeEncoder(config.FileJson), writer, zapcore.DebugLevel)
+			cores = append(cores, newPrefixLevelCore(core, logger.fileLevel, logger.prefixLevels))
+		}
+	}
+
+	if config.EnableSyslog {
+		if writer, err := newSyslogWriter(config); err == nil {
+			core := newSyslogCore(makeEncoder(config.SyslogJson), writer, zapcore.DebugLevel)
+			cores = append(cores, newPrefixLevelCore(core, logger.syslogLevel, logger.prefixLevels))
+		} else {
+			Error("error creating syslog logger", Err(err))
 		}
 	}
 
 	combinedCore := zapcore.NewTee(cores...)
+	if config.SampleInitial > 0 {
+		combinedCore = newSampledCore(combinedCore, config)
+	}
 
 	logger.zap = zap.New(combinedCore,
 		zap.AddCaller(),
@@ -155,13 +204,59 @@ func NewLogger(config *LoggerConfiguration) *Logger {
 func (l *Logger) ChangeLevels(config *LoggerConfiguration) {
 	l.consoleLevel.SetLevel(getZapLevel(config.ConsoleLevel))
 	l.fileLevel.SetLevel(getZapLevel(config.FileLevel))
+	l.syslogLevel.SetLevel(getZapLevel(config.SyslogLevel))
+	if l.prefixLevels != nil {
+		l.prefixLevels.replace(config.PrefixLevels)
+	}
 }
 
+// SetConsoleLevel changes the level of the console output at runtime.
+// It is safe to call concurrently with logging.
 func (l *Logger) SetConsoleLevel(level string) {
 	l.consoleLevel.SetLevel(getZapLevel(level))
 }
 
+// SetFileLevel changes the level of the file output at runtime.
+// It is safe to call concurrently with logging.
+func (l *Logger) SetFileLevel(level string) {
+	l.fileLevel.SetLevel(getZapLevel(level))
+}
+
+// SetLevelForPrefix overrides the level of the loggers whose name starts with the given prefix.
+// An empty level removes the override.
+func (l *Logger) SetLevelForPrefix(prefix, level string) {
+	if l.prefixLevels != nil {
+		l.prefixLevels.set(prefix, level)
+	}
+}
+
+// GetPrefixLevels returns the levels currently overridden by logger name prefix.
+func (l *Logger) GetPrefixLevels() map[string]string {
+	if l.prefixLevels == nil {
+		return map[string]string{}
+	}
+	return l.prefixLevels.toMap()
+}
+
+// GetEffectiveLevel returns the console level applied to the logger with the given name.
+func (l *Logger) GetEffectiveLevel(name string) string {
+	if l.prefixLevels != nil {
+		if level, ok := l.prefixLevels.levelFor(name); ok {
+			return level.String()
+		}
+	}
+	return l.consoleLevel.Level().String()
+}
+
+// Named returns a logger whose name is extended with the given name, separated by a period.
+func (l *Logger) Named(name string) *Logger {
+	newlogger := *l
+	newlogger.zap = newlogger.zap.Named(name)
+	return &newlogger
+}
+
 func (l *Logger) With(fields ...Field) *Logger {
+	fields = withErrorFields(fields)
 	newlogger := *l
 	newlogger.zap = newlogger.zap.With(fields...)
 	if newlogger.logrLogger != nil {
@@ -205,6 +300,7 @@ func (l *Logger) Sugar() *SugarLogger {
 }
 
 func (l *Logger) Debug(message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.zap.Debug(message, fields...)
 	if isLevelEnabled(l.logrLogger, logr.Debug) {
 		l.logrLogger.WithFields(zapToLogr(fields)).Debug(message)
@@ -212,6 +308,7 @@ func (l *Logger) Debug(message string, fields ...Field) {
 }
 
 func (l *Logger) Info(message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.zap.Info(message, fields...)
 	if isLevelEnabled(l.logrLogger, logr.Info) {
 		l.logrLogger.WithFields(zapToLogr(fields)).Info(message)
@@ -219,6 +316,7 @@ func (l *Logger) Info(message string, fields ...Field) {
 }
 
 func (l *Logger) Warn(message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.zap.Warn(message, fields...)
 	if isLevelEnabled(l.logrLogger, logr.Warn) {
 		l.logrLogger.WithFields(zapToLogr(fields)).Warn(message)
@@ -226,6 +324,7 @@ func (l *Logger) Warn(message string, fields ...Field) {
 }
 
 func (l *Logger) Error(message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.zap.Error(message, fields...)
 	if isLevelEnabled(l.logrLogger, logr.Error) {
 		l.logrLogger.WithFields(zapToLogr(fields)).Error(message)
@@ -233,6 +332,7 @@ func (l *Logger) Error(message string, fields ...Field) {
 }
 
 func (l *Logger) Critical(message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.zap.Error(message, fields...)
 	if isLevelEnabled(l.logrLogger, logr.Error) {
 		l.logrLogger.WithFields(zapToLogr(fields)).Error(message)
@@ -240,10 +340,12 @@ func (l *Logger) Critical(message string, fields ...Field) {
 }
 
 func (l *Logger) Log(level LogLevel, message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	l.logrLogger.WithFields(zapToLogr(fields)).Log(logr.Level(level), message)
 }
 
 func (l *Logger) LogM(levels []LogLevel, message string, fields ...Field) {
+	fields = withErrorFields(fields)
 	var logger *logr.Logger
 	for _, lvl := range levels {
 		if isLevelEnabled(l.logrLogger, logr.Level(lvl)) {
@@ -257,10 +359,26 @@ func (l *Logger) LogM(levels []LogLevel, message string, fields ...Field) {
 	}
 }
 
+// Flush waits for the buffered console output, if any, and the advanced logging targets
+// to be written, or for the context to be done.
 func (l *Logger) Flush(cxt context.Context) error {
+	if l.consoleBuffer != nil {
+		if err := l.consoleBuffer.Flush(cxt); err != nil {
+			return err
+		}
+	}
 	return l.logrLogger.Logr().FlushWithTimeout(cxt)
 }
 
+// DroppedConsoleMessages returns the number of console entries dropped because
+// the console buffer was full.
+func (l *Logger) DroppedConsoleMessages() uint64 {
+	if l.consoleBuffer == nil {
+		return 0
+	}
+	return l.consoleBuffer.Dropped()
+}
+
 // ShutdownAdvancedLogging stops the logger from accepting new log records and tries to
 // flush queues within the context timeout. Once complete all targets are shutdown
 // and any resources released.
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// prefixLevels holds the levels overriding the console and file levels for loggers
+// whose name starts with a given prefix.
+type prefixLevels struct {
+	mut    sync.RWMutex
+	levels map[string]zapcore.Level
+}
+
+func newPrefixLevels(levels map[string]string) *prefixLevels {
+	p := &prefixLevels{}
+	p.replace(levels)
+	return p
+}
+
+func (p *prefixLevels) replace(levels map[string]string) {
+	converted := make(map[string]zapcore.Level, len(levels))
+	for prefix, level := range levels {
+		converted[prefix] = getZapLevel(level)
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.levels = converted
+}
+
+func (p *prefixLevels) set(prefix string, level string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if level == "" {
+		delete(p.levels, prefix)
+		return
+	}
+	p.levels[prefix] = getZapLevel(level)
+}
+
+// levelFor returns the level of the longest prefix matching the given logger name.
+func (p *prefixLevels) levelFor(name string) (zapcore.Level, bool) {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	var level zapcore.Level
+	found := false
+	longest := -1
+	for prefix, prefixLevel := range p.levels {
+		if len(prefix) > longest && strings.HasPrefix(name, prefix) {
+			level = prefixLevel
+			longest = len(prefix)
+			found = true
+		}
+	}
+	return level, found
+}
+
+// enabled reports whether any prefix enables the given level.
+func (p *prefixLevels) enabled(level zapcore.Level) bool {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	for _, prefixLevel := range p.levels {
+		if prefixLevel.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *prefixLevels) toMap() map[string]string {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	levels := make(map[string]string, len(p.levels))
+	for prefix, level := range p.levels {
+		levels[prefix] = level.String()
+	}
+	return levels
+}
+
+// prefixLevelCore filters the entries written to the wrapped core according to
+// the logger name, falling back to the default level when no prefix matches.
+// The wrapped core must accept all levels.
+type prefixLevelCore struct {
+	zapcore.Core
+	level    zap.AtomicLevel
+	prefixes *prefixLevels
+}
+
+func newPrefixLevelCore(core zapcore.Core, level zap.AtomicLevel, prefixes *prefixLevels) zapcore.Core {
+	return &prefixLevelCore{
+		Core:     core,
+		level:    level,
+		prefixes: prefixes,
+	}
+}
+
+func (c *prefixLevelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level) || c.prefixes.enabled(level)
+}
+
+func (c *prefixLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return newPrefixLevelCore(c.Core.With(fields), c.level, c.prefixes)
+}
+
+func (c *prefixLevelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	enabled := c.level.Enabled(entry.Level)
+	if level, ok := c.prefixes.levelFor(entry.LoggerName); ok {
+		enabled = level.Enabled(entry.Level)
+	}
+
+	if enabled {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
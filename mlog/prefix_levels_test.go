@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newPrefixLevelTestLogger(buf *bytes.Buffer, prefixes map[string]string) *Logger {
+	logger := &Logger{
+		consoleLevel: zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		fileLevel:    zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		prefixLevels: newPrefixLevels(prefixes),
+		logrLogger:   newLogr(),
+	}
+	core := zapcore.NewCore(makeEncoder(true), zapcore.AddSync(buf), zapcore.DebugLevel)
+	logger.zap = zap.New(newPrefixLevelCore(core, logger.consoleLevel, logger.prefixLevels))
+	return logger
+}
+
+func TestPrefixLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := newPrefixLevelTestLogger(buf, map[string]string{"store.sqlstore": LevelDebug})
+
+	logger.Named("store").Named("sqlstore").Debug("sqlstore debug")
+	logger.Named("app").Debug("app debug")
+	logger.Named("app").Info("app info")
+
+	assert.Contains(t, buf.String(), "sqlstore debug")
+	assert.NotContains(t, buf.String(), "app debug")
+	assert.Contains(t, buf.String(), "app info")
+
+	t.Run("runtime changes", func(t *testing.T) {
+		buf.Reset()
+		logger.SetLevelForPrefix("store.sqlstore", LevelError)
+		logger.SetLevelForPrefix("app", LevelDebug)
+
+		logger.Named("store.sqlstore").Info("sqlstore info")
+		logger.Named("app").Debug("app debug")
+
+		assert.NotContains(t, buf.String(), "sqlstore info")
+		assert.Contains(t, buf.String(), "app debug")
+	})
+
+	t.Run("longest prefix wins", func(t *testing.T) {
+		buf.Reset()
+		logger.SetLevelForPrefix("app.plugins", LevelWarn)
+
+		logger.Named("app.plugins").Info("plugins info")
+		logger.Named("app.web").Debug("web debug")
+
+		assert.NotContains(t, buf.String(), "plugins info")
+		assert.Contains(t, buf.String(), "web debug")
+		assert.Equal(t, "warn", logger.GetEffectiveLevel("app.plugins.jira"))
+		assert.Equal(t, "debug", logger.GetEffectiveLevel("app.web"))
+		assert.Equal(t, "info", logger.GetEffectiveLevel("jobs"))
+	})
+
+	t.Run("remove override", func(t *testing.T) {
+		logger.SetLevelForPrefix("app.plugins", "")
+		assert.Equal(t, map[string]string{"store.sqlstore": "error", "app": "debug"}, logger.GetPrefixLevels())
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultSampleTick is the window used to sample log entries when none is configured.
+const DefaultSampleTick = time.Second
+
+type sampleKey struct {
+	level   zapcore.Level
+	message string
+}
+
+// sampleSummary counts the entries dropped by the sampler, so they can be
+// reported as a single entry once the sampling window is over.
+type sampleSummary struct {
+	mut       sync.Mutex
+	core      zapcore.Core
+	tick      time.Duration
+	lastFlush time.Time
+	dropped   map[sampleKey]int
+}
+
+func (s *sampleSummary) hook(entry zapcore.Entry, decision zapcore.SamplingDecision) {
+	if decision&zapcore.LogDropped == 0 {
+		return
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.dropped[sampleKey{level: entry.Level, message: entry.Message}]++
+}
+
+// flushIfDue writes one entry per dropped level and message, with the number of
+// dropped occurrences, once the sampling window is over.
+func (s *sampleSummary) flushIfDue(now time.Time) {
+	s.mut.Lock()
+	if now.Sub(s.lastFlush) < s.tick || len(s.dropped) == 0 {
+		s.mut.Unlock()
+		return
+	}
+	dropped := s.dropped
+	s.dropped = make(map[sampleKey]int)
+	s.lastFlush = now
+	s.mut.Unlock()
+
+	for key, count := range dropped {
+		entry := zapcore.Entry{Level: key.level, Time: now, Message: key.message}
+		if checked := s.core.Check(entry, nil); checked != nil {
+			checked.Write(zap.Int("occurrences", count))
+		}
+	}
+}
+
+// sampledCore wraps a zap sampler, reporting the dropped entries through the summary.
+type sampledCore struct {
+	zapcore.Core
+	summary *sampleSummary
+}
+
+// newSampledCore samples the entries written to core as configured. Entries are deduplicated
+// by level and message only: the same message logged with different fields counts as one key,
+// and the summary entry does not carry the dropped fields.
+func newSampledCore(core zapcore.Core, config *LoggerConfiguration) zapcore.Core {
+	tick := config.SampleTick
+	if tick <= 0 {
+		tick = DefaultSampleTick
+	}
+
+	thereafter := config.SampleThereafter
+	if thereafter <= 0 {
+		// Drop every entry past the initial ones within the window.
+		thereafter = math.MaxInt32
+	}
+
+	summary := &sampleSummary{
+		core:      core,
+		tick:      tick,
+		lastFlush: time.Now(),
+		dropped:   make(map[sampleKey]int),
+	}
+
+	return &sampledCore{
+		Core:    zapcore.NewSamplerWithOptions(core, tick, config.SampleInitial, thereafter, zapcore.SamplerHook(summary.hook)),
+		summary: summary,
+	}
+}
+
+func (c *sampledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampledCore{
+		Core:    c.Core.With(fields),
+		summary: c.summary,
+	}
+}
+
+func (c *sampledCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	c.summary.flushIfDue(entry.Time)
+	return c.Core.Check(entry, checked)
+}
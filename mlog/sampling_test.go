@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSampledCore(t *testing.T) {
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(makeEncoder(true), zapcore.AddSync(buf), zapcore.DebugLevel)
+	logger := zap.New(newSampledCore(core, &LoggerConfiguration{
+		SampleInitial: 2,
+		SampleTick:    50 * time.Millisecond,
+	}))
+
+	for i := 0; i < 10; i++ {
+		logger.Error("flooding error", zap.Int("attempt", i))
+	}
+	logger.Info("other message")
+
+	decode := func() []map[string]interface{} {
+		var records []map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		for dec.More() {
+			var record map[string]interface{}
+			require.NoError(t, dec.Decode(&record))
+			records = append(records, record)
+		}
+		return records
+	}
+
+	records := decode()
+	require.Len(t, records, 3)
+	assert.Equal(t, "flooding error", records[0]["msg"])
+	assert.Equal(t, "flooding error", records[1]["msg"])
+	assert.Equal(t, "other message", records[2]["msg"])
+
+	time.Sleep(60 * time.Millisecond)
+	logger.Info("after window")
+
+	records = decode()
+	require.Len(t, records, 5)
+	assert.Equal(t, "flooding error", records[3]["msg"])
+	assert.Equal(t, "error", records[3]["level"])
+	assert.EqualValues(t, 8, records[3]["occurrences"])
+	assert.Equal(t, "after window", records[4]["msg"])
+}
+
+func TestSamplingDisabledByDefault(t *testing.T) {
+	logger := NewLogger(&LoggerConfiguration{})
+	_, sampled := logger.zap.Core().(*sampledCore)
+	assert.False(t, sampled)
+}
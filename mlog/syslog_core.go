@@ -0,0 +1,195 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	syslog "github.com/wiggin77/srslog"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	syslogTLSScheme = "syslog+tls://"
+	syslogScheme    = "syslog://"
+
+	syslogMinBackoff = 100 * time.Millisecond
+	syslogMaxBackoff = 30 * time.Second
+)
+
+type syslogRecord struct {
+	priority syslog.Priority
+	msg      string
+}
+
+// syslogWriter delivers records to a syslog daemon from a background goroutine, so that
+// log calls never block on the network. While the daemon is unreachable, the writer keeps
+// reconnecting with an exponential backoff and records are dropped once the queue is full.
+type syslogWriter struct {
+	network   string
+	addr      string
+	tag       string
+	tlsConfig *tls.Config
+	queue     chan syslogRecord
+}
+
+// parseSyslogAddr extracts the network from a syslog:// or syslog+tls:// address.
+func parseSyslogAddr(network, addr string) (string, string) {
+	switch {
+	case strings.HasPrefix(addr, syslogTLSScheme):
+		return "tcp+tls", strings.TrimPrefix(addr, syslogTLSScheme)
+	case strings.HasPrefix(addr, syslogScheme):
+		addr = strings.TrimPrefix(addr, syslogScheme)
+	}
+
+	if network == "" {
+		network = "tcp"
+	}
+	return network, addr
+}
+
+func newSyslogWriter(config *LoggerConfiguration) (*syslogWriter, error) {
+	network, addr := parseSyslogAddr(config.SyslogNetwork, config.SyslogAddr)
+
+	w := &syslogWriter{
+		network: network,
+		addr:    addr,
+		tag:     config.SyslogTag,
+		queue:   make(chan syslogRecord, DefaultMaxTargetQueue),
+	}
+
+	if network == "tcp+tls" {
+		w.tlsConfig = &tls.Config{InsecureSkipVerify: config.SyslogInsecure}
+		if config.SyslogCAPath != "" {
+			pool, err := getCertPool(config.SyslogCAPath)
+			if err != nil {
+				return nil, err
+			}
+			w.tlsConfig.RootCAs = pool
+		}
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// enqueue hands the record to the background goroutine, dropping it if the queue is full.
+func (w *syslogWriter) enqueue(rec syslogRecord) {
+	select {
+	case w.queue <- rec:
+	default:
+	}
+}
+
+func (w *syslogWriter) dial() (*syslog.Writer, error) {
+	writer, err := syslog.DialWithTLSConfig(w.network, w.addr, syslog.LOG_INFO|syslog.LOG_USER, w.tag, w.tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	writer.SetFormatter(syslog.RFC5424Formatter)
+	if w.network != "udp" {
+		writer.SetFramer(syslog.RFC5425MessageLengthFramer)
+	}
+	return writer, nil
+}
+
+func (w *syslogWriter) run() {
+	var writer *syslog.Writer
+	backoff := syslogMinBackoff
+
+	for rec := range w.queue {
+		for {
+			var err error
+			if writer == nil {
+				writer, err = w.dial()
+			}
+			if err == nil {
+				// The writer transparently reconnects once before reporting an error.
+				_, err = writer.WriteWithPriority(rec.priority, []byte(rec.msg))
+			}
+			if err == nil {
+				backoff = syslogMinBackoff
+				break
+			}
+
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > syslogMaxBackoff {
+				backoff = syslogMaxBackoff
+			}
+		}
+	}
+}
+
+// syslogCore is a zap core writing encoded entries to syslog with a priority matching their level.
+type syslogCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	writer *syslogWriter
+}
+
+func newSyslogCore(enc zapcore.Encoder, writer *syslogWriter, enabler zapcore.LevelEnabler) zapcore.Core {
+	return &syslogCore{
+		LevelEnabler: enabler,
+		enc:          enc,
+		writer:       writer,
+	}
+}
+
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &syslogCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          c.enc.Clone(),
+		writer:       c.writer,
+	}
+	for i := range fields {
+		fields[i].AddTo(clone.enc)
+	}
+	return clone
+}
+
+func (c *syslogCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *syslogCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	msg := buf.String()
+	buf.Free()
+
+	c.writer.enqueue(syslogRecord{
+		priority: syslog.LOG_USER | syslogSeverity(entry.Level),
+		msg:      msg,
+	})
+	return nil
+}
+
+func (c *syslogCore) Sync() error {
+	return nil
+}
+
+func syslogSeverity(level zapcore.Level) syslog.Priority {
+	switch level {
+	case zapcore.DebugLevel:
+		return syslog.LOG_DEBUG
+	case zapcore.InfoLevel:
+		return syslog.LOG_INFO
+	case zapcore.WarnLevel:
+		return syslog.LOG_WARNING
+	case zapcore.ErrorLevel:
+		return syslog.LOG_ERR
+	default:
+		return syslog.LOG_CRIT
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyslogAddr(t *testing.T) {
+	network, addr := parseSyslogAddr("", "syslog+tls://localhost:6514")
+	assert.Equal(t, "tcp+tls", network)
+	assert.Equal(t, "localhost:6514", addr)
+
+	network, addr = parseSyslogAddr("udp", "syslog://localhost:514")
+	assert.Equal(t, "udp", network)
+	assert.Equal(t, "localhost:514", addr)
+
+	network, addr = parseSyslogAddr("", "localhost:514")
+	assert.Equal(t, "tcp", network)
+	assert.Equal(t, "localhost:514", addr)
+}
+
+func TestSyslogLogger(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	logger := NewLogger(&LoggerConfiguration{
+		EnableSyslog: true,
+		SyslogJson:   true,
+		SyslogLevel:  LevelInfo,
+		SyslogAddr:   "syslog://" + listener.Addr().String(),
+		SyslogTag:    "mattermost",
+	})
+
+	logger.Debug("filtered")
+	logger.Warn("warning message", String("user_id", "abc"))
+
+	select {
+	case line := <-lines:
+		// RFC5425 octet counting framing, RFC5424 header with the warning priority (user facility).
+		assert.Regexp(t, `^\d+ <12>1 `, line)
+		assert.Contains(t, line, " mattermost - ")
+		assert.Contains(t, line, `"msg":"warning message"`)
+		assert.Contains(t, line, `"user_id":"abc"`)
+		assert.False(t, strings.Contains(line, "filtered"))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for syslog message")
+	}
+}
+
+func TestSyslogLoggerUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	logger := NewLogger(&LoggerConfiguration{
+		EnableSyslog: true,
+		SyslogLevel:  LevelDebug,
+		SyslogAddr:   addr,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*DefaultMaxTargetQueue; i++ {
+			logger.Info("message")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "logging blocked on unreachable syslog")
+	}
+}
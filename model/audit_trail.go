@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	AUDIT_TRAIL_ENTITY_TYPE_MAX_LENGTH = 64
+	AUDIT_TRAIL_ENTITY_ID_MAX_LENGTH   = 256
+	AUDIT_TRAIL_OPERATION_MAX_LENGTH   = 128
+)
+
+// AuditTrailEntry records a change made to the store: who made it, to which entity and through
+// which store method. An entry is only saved along with a change that succeeded, and never
+// updated afterwards.
+type AuditTrailEntry struct {
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	ActorId    string `json:"actor_id"`
+	EntityType string `json:"entity_type"`
+	EntityId   string `json:"entity_id"`
+	Operation  string `json:"operation"`
+}
+
+// PreSave fills the id and the creation time of a new entry.
+func (o *AuditTrailEntry) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+// IsValid validates an AuditTrailEntry. It returns an error in case of failure.
+func (o *AuditTrailEntry) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("AuditTrailEntry.IsValid", "model.audit_trail_entry.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("AuditTrailEntry.IsValid", "model.audit_trail_entry.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.EntityType == "" || len(o.EntityType) > AUDIT_TRAIL_ENTITY_TYPE_MAX_LENGTH {
+		return NewAppError("AuditTrailEntry.IsValid", "model.audit_trail_entry.is_valid.entity_type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.EntityId) > AUDIT_TRAIL_ENTITY_ID_MAX_LENGTH {
+		return NewAppError("AuditTrailEntry.IsValid", "model.audit_trail_entry.is_valid.entity_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.Operation == "" || len(o.Operation) > AUDIT_TRAIL_OPERATION_MAX_LENGTH {
+		return NewAppError("AuditTrailEntry.IsValid", "model.audit_trail_entry.is_valid.operation.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
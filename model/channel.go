@@ -31,6 +31,7 @@ const (
 
 	CHANNEL_SORT_BY_USERNAME = "username"
 	CHANNEL_SORT_BY_STATUS   = "status"
+	CHANNEL_SORT_BY_ROLE     = "role"
 )
 
 type Channel struct {
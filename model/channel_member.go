@@ -31,6 +31,18 @@ type ChannelUnread struct {
 	NotifyProps  StringMap `json:"-"`
 }
 
+// ChannelUnreadCounts is the number of posts and of mentions a user hasn't read in a channel.
+type ChannelUnreadCounts struct {
+	ChannelId string `json:"channel_id"`
+	// MsgCount counts the unread posts, and MsgCountRoot those of them that aren't replies, for
+	// the clients following the threads apart from the channels. Both are zero for the muted
+	// channels, whose posts aren't shown as unread.
+	MsgCount     int64 `json:"msg_count"`
+	MsgCountRoot int64 `json:"msg_count_root"`
+	MentionCount int64 `json:"mention_count"`
+	Muted        bool  `json:"muted"`
+}
+
 type ChannelUnreadAt struct {
 	TeamId       string    `json:"team_id"`
 	UserId       string    `json:"user_id"`
@@ -58,6 +70,13 @@ type ChannelMember struct {
 
 type ChannelMembers []ChannelMember
 
+// ChannelMembersSince are the changes to the members of a channel since a time, for the clients
+// to sync the members they know of: the members added or updated, and the users removed.
+type ChannelMembersSince struct {
+	Members        ChannelMembers `json:"members"`
+	RemovedUserIds []string       `json:"removed_user_ids"`
+}
+
 type ChannelMemberForExport struct {
 	ChannelMember
 	ChannelName string
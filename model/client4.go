@@ -514,6 +514,10 @@ func (c *Client4) GetServerBusyRoute() string {
 	return "/server_busy"
 }
 
+func (c *Client4) GetStoreReadOnlyRoute() string {
+	return "/store_read_only"
+}
+
 func (c *Client4) GetUserTermsOfServiceRoute(userId string) string {
 	return c.GetUserRoute(userId) + "/terms_of_service"
 }
@@ -3023,6 +3027,17 @@ func (c *Client4) SearchPostsWithParams(teamId string, params *SearchParameter)
 	return PostListFromJson(r.Body), BuildResponse(r)
 }
 
+// SearchPostsInAllTeams returns the posts of all the teams matching the search parameters. It
+// requires the sysconsole_read_compliance permission.
+func (c *Client4) SearchPostsInAllTeams(params *SearchParameter) (*PostList, *Response) {
+	r, err := c.DoApiPost(c.GetPostsRoute()+"/search", params.SearchParameterToJson())
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return PostListFromJson(r.Body), BuildResponse(r)
+}
+
 // SearchPostsWithMatches returns any posts with matching terms string, including.
 func (c *Client4) SearchPostsWithMatches(teamId string, terms string, isOrSearch bool) (*PostSearchResults, *Response) {
 	requestBody := map[string]interface{}{"terms": terms, "is_or_search": isOrSearch}
@@ -3034,6 +3049,18 @@ func (c *Client4) SearchPostsWithMatches(teamId string, terms string, isOrSearch
 	return PostSearchResultsFromJson(r.Body), BuildResponse(r)
 }
 
+// SuggestSearchTerms returns the channels, usernames and terms completing the prefix typed in
+// the search box.
+func (c *Client4) SuggestSearchTerms(teamId string, prefix string, limit int) ([]*SearchSuggestion, *Response) {
+	query := fmt.Sprintf("?prefix=%v&limit=%d", url.QueryEscape(prefix), limit)
+	r, err := c.DoApiGet(c.GetTeamRoute(teamId)+"/posts/search/suggest"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return SearchSuggestionsFromJson(r.Body), BuildResponse(r)
+}
+
 // DoPostAction performs a post action.
 func (c *Client4) DoPostAction(postId, actionId string) (bool, *Response) {
 	r, err := c.DoApiPost(c.GetPostRoute(postId)+"/actions/"+actionId, "")
@@ -5238,6 +5265,36 @@ func (c *Client4) GetServerBusyExpires() (*time.Time, *Response) {
 	return &expires, BuildResponse(r)
 }
 
+// SetStoreReadOnly puts the store of every cluster node in read-only mode, rejecting the changes.
+func (c *Client4) SetStoreReadOnly() (bool, *Response) {
+	r, err := c.DoApiPost(c.GetStoreReadOnlyRoute(), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// ClearStoreReadOnly takes the store of every cluster node out of read-only mode.
+func (c *Client4) ClearStoreReadOnly() (bool, *Response) {
+	r, err := c.DoApiDelete(c.GetStoreReadOnlyRoute())
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return CheckStatusOK(r), BuildResponse(r)
+}
+
+// GetStoreReadOnly returns true when the store is in read-only mode.
+func (c *Client4) GetStoreReadOnly() (bool, *Response) {
+	r, err := c.DoApiGet(c.GetStoreReadOnlyRoute(), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+	return MapBoolFromJson(r.Body)["read_only"], BuildResponse(r)
+}
+
 // RegisterTermsOfServiceAction saves action performed by a user against a specific terms of service.
 func (c *Client4) RegisterTermsOfServiceAction(userId, termsOfServiceId string, accepted bool) (*bool, *Response) {
 	url := c.GetUserTermsOfServiceRoute(userId)
@@ -5770,6 +5827,9 @@ func (c *Client4) GetUserThreads(userId string, options GetUserThreadsOpts) (*Th
 	if options.Deleted {
 		v.Set("deleted", "true")
 	}
+	if options.Before != "" {
+		v.Set("before", options.Before)
+	}
 
 	url := c.GetUserThreadsRoute(userId)
 	if len(v) > 0 {
@@ -4,8 +4,16 @@
 package model
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -20,6 +28,7 @@ const (
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_BY_NAME              = "inv_channel_name"
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL                      = "inv_channel"
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_GUEST_COUNT          = "inv_channel_guest_count"
+	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBER               = "inv_channel_member"
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER                         = "inv_user"
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER_TEAMS                   = "inv_user_teams"
 	CLUSTER_EVENT_CLEAR_SESSION_CACHE_FOR_USER                      = "clear_session_user"
@@ -42,6 +51,11 @@ const (
 	CLUSTER_EVENT_REMOVE_PLUGIN                                     = "remove_plugin"
 	CLUSTER_EVENT_INVALIDATE_CACHE_FOR_TERMS_OF_SERVICE             = "inv_terms_of_service"
 	CLUSTER_EVENT_BUSY_STATE_CHANGED                                = "busy_state_change"
+	CLUSTER_EVENT_STORE_READ_ONLY_CHANGED                           = "store_read_only_change"
+
+	// CLUSTER_EVENT_RATE_LIMIT_PREFIX is followed by the name of the rate limiter the tokens were
+	// consumed from.
+	CLUSTER_EVENT_RATE_LIMIT_PREFIX = "rate_limit_"
 
 	// Gossip communication
 	CLUSTER_GOSSIP_EVENT_REQUEST_GET_LOGS             = "gossip_request_get_logs"
@@ -64,6 +78,24 @@ type ClusterMessage struct {
 	WaitForAllToSend bool              `json:"-"`
 	Data             string            `json:"data,omitempty"`
 	Props            map[string]string `json:"props,omitempty"`
+	// Compressed is set when Data holds the base64 encoding of the gzipped data.
+	Compressed bool `json:"compressed,omitempty"`
+	// Id and CreateAt are set by PreSend for the receivers to drop the messages delivered more
+	// than once and those delivered too late.
+	Id       string `json:"id,omitempty"`
+	CreateAt int64  `json:"create_at,omitempty"`
+}
+
+// PreSend gives an id and a creation time to a message about to be sent, unless it was already
+// sent, so that the message sent again is recognized as a duplicate.
+func (o *ClusterMessage) PreSend() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
 }
 
 func (o *ClusterMessage) ToJson() string {
@@ -76,3 +108,126 @@ func ClusterMessageFromJson(data io.Reader) *ClusterMessage {
 	json.NewDecoder(data).Decode(&o)
 	return o
 }
+
+// Compress gzips the data of the message if it's larger than threshold bytes, leaving the
+// smaller messages as they are. A threshold of zero or less disables the compression.
+func (o *ClusterMessage) Compress(threshold int) error {
+	if o.Compressed || threshold <= 0 || len(o.Data) <= threshold {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(o.Data)); err != nil {
+		return errors.Wrap(err, "failed to compress the cluster message")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress the cluster message")
+	}
+
+	o.Data = base64.StdEncoding.EncodeToString(buf.Bytes())
+	o.Compressed = true
+	return nil
+}
+
+// Decompress restores the data of a message compressed by Compress.
+func (o *ClusterMessage) Decompress() error {
+	if !o.Compressed {
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(o.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode the compressed cluster message")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to decompress the cluster message")
+	}
+	defer reader.Close()
+	decompressed, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to decompress the cluster message")
+	}
+
+	o.Data = string(decompressed)
+	o.Compressed = false
+	return nil
+}
+
+// ClusterMessageFilter tells the cluster messages to handle from those to drop, which are the
+// messages already received within the deduplication TTL and the messages older than the
+// maximum age. The messages sent without an id or a creation time, such as by older nodes, are
+// never dropped.
+type ClusterMessageFilter struct {
+	mut    sync.Mutex
+	ttl    time.Duration
+	maxAge time.Duration
+	seen   map[string]int64
+	pruned int64
+
+	// now returns the current time in milliseconds, replaced by the tests.
+	now func() int64
+}
+
+// NewClusterMessageFilter creates a filter remembering the ids of the messages for ttl, and
+// dropping the messages older than maxAge. A ttl or a maxAge of zero or less disables the
+// corresponding check.
+func NewClusterMessageFilter(ttl, maxAge time.Duration) *ClusterMessageFilter {
+	return &ClusterMessageFilter{
+		ttl:    ttl,
+		maxAge: maxAge,
+		seen:   map[string]int64{},
+		now:    GetMillis,
+	}
+}
+
+// NewClusterMessageFilterFromSettings creates a filter with the limits of the cluster settings.
+func NewClusterMessageFilterFromSettings(settings *ClusterSettings) *ClusterMessageFilter {
+	filter := NewClusterMessageFilter(0, 0)
+	filter.SetLimits(settings)
+	return filter
+}
+
+// SetLimits applies the deduplication TTL and the maximum age of the cluster settings.
+func (f *ClusterMessageFilter) SetLimits(settings *ClusterSettings) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	f.ttl = time.Duration(*settings.MessageDeduplicationTTLSeconds) * time.Second
+	f.maxAge = time.Duration(*settings.MessageMaxAgeSeconds) * time.Second
+	if f.ttl <= 0 {
+		f.seen = map[string]int64{}
+	}
+}
+
+// Accept returns whether the message is to be handled, and records its id if so.
+func (f *ClusterMessageFilter) Accept(msg *ClusterMessage) bool {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	now := f.now()
+	if f.maxAge > 0 && msg.CreateAt > 0 && now-msg.CreateAt > int64(f.maxAge/time.Millisecond) {
+		return false
+	}
+
+	if f.ttl <= 0 || msg.Id == "" {
+		return true
+	}
+
+	ttl := int64(f.ttl / time.Millisecond)
+	if now-f.pruned > ttl {
+		for id, seenAt := range f.seen {
+			if now-seenAt > ttl {
+				delete(f.seen, id)
+			}
+		}
+		f.pruned = now
+	}
+
+	if seenAt, ok := f.seen[msg.Id]; ok && now-seenAt <= ttl {
+		return false
+	}
+	f.seen[msg.Id] = now
+	return true
+}
@@ -6,6 +6,7 @@ package model
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -25,3 +26,96 @@ func TestClusterMessage(t *testing.T) {
 
 	require.Nil(t, badresult, "should not have parsed")
 }
+
+func TestClusterMessageCompression(t *testing.T) {
+	data := strings.Repeat("channel member ", 100)
+
+	t.Run("compresses the messages above the threshold", func(t *testing.T) {
+		m := &ClusterMessage{Event: CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBERS, Data: data}
+		require.NoError(t, m.Compress(100))
+		require.True(t, m.Compressed)
+		require.Less(t, len(m.Data), len(data))
+
+		result := ClusterMessageFromJson(strings.NewReader(m.ToJson()))
+		require.True(t, result.Compressed)
+		require.NoError(t, result.Decompress())
+		require.False(t, result.Compressed)
+		require.Equal(t, data, result.Data)
+	})
+
+	t.Run("keeps the small messages uncompressed", func(t *testing.T) {
+		m := &ClusterMessage{Data: "hello"}
+		require.NoError(t, m.Compress(100))
+		require.False(t, m.Compressed)
+		require.NoError(t, m.Decompress())
+		require.Equal(t, "hello", m.Data)
+
+		m = &ClusterMessage{Data: data}
+		require.NoError(t, m.Compress(0))
+		require.False(t, m.Compressed, "a zero threshold should disable the compression")
+	})
+
+	t.Run("fails on corrupted data", func(t *testing.T) {
+		m := &ClusterMessage{Data: "not base64!", Compressed: true}
+		require.Error(t, m.Decompress())
+	})
+}
+
+func TestClusterMessageFilter(t *testing.T) {
+	now := int64(1000000)
+	newFilter := func(ttl, maxAge time.Duration) *ClusterMessageFilter {
+		filter := NewClusterMessageFilter(ttl, maxAge)
+		filter.now = func() int64 { return now }
+		return filter
+	}
+
+	t.Run("drops the duplicates within the ttl", func(t *testing.T) {
+		filter := newFilter(time.Minute, 0)
+		m := &ClusterMessage{Id: NewId(), CreateAt: now}
+		require.True(t, filter.Accept(m))
+		require.False(t, filter.Accept(m))
+		require.True(t, filter.Accept(&ClusterMessage{Id: NewId(), CreateAt: now}))
+
+		now += time.Minute.Milliseconds() + 1
+		require.True(t, filter.Accept(m), "the id should be forgotten once the ttl expires")
+		require.Len(t, filter.seen, 1, "the expired ids should be pruned")
+	})
+
+	t.Run("drops the stale messages", func(t *testing.T) {
+		filter := newFilter(time.Minute, 5*time.Minute)
+		require.True(t, filter.Accept(&ClusterMessage{Id: NewId(), CreateAt: now - time.Minute.Milliseconds()}))
+		require.False(t, filter.Accept(&ClusterMessage{Id: NewId(), CreateAt: now - 6*time.Minute.Milliseconds()}))
+	})
+
+	t.Run("accepts the messages without an id or a creation time", func(t *testing.T) {
+		filter := newFilter(time.Minute, 5*time.Minute)
+		m := &ClusterMessage{Event: CLUSTER_EVENT_INVALIDATE_ALL_CACHES}
+		require.True(t, filter.Accept(m))
+		require.True(t, filter.Accept(m))
+	})
+
+	t.Run("applies the limits of the settings", func(t *testing.T) {
+		settings := &ClusterSettings{}
+		settings.SetDefaults()
+		settings.MessageDeduplicationTTLSeconds = NewInt(0)
+		settings.MessageMaxAgeSeconds = NewInt(0)
+		filter := newFilter(time.Minute, 5*time.Minute)
+		filter.SetLimits(settings)
+
+		m := &ClusterMessage{Id: NewId(), CreateAt: 1}
+		require.True(t, filter.Accept(m))
+		require.True(t, filter.Accept(m), "a zero ttl and maximum age should disable the checks")
+	})
+
+	t.Run("gives an id and a creation time to the messages sent", func(t *testing.T) {
+		m := &ClusterMessage{}
+		m.PreSend()
+		require.True(t, IsValidId(m.Id))
+		require.NotZero(t, m.CreateAt)
+
+		id, createAt := m.Id, m.CreateAt
+		m.PreSend()
+		require.Equal(t, id, m.Id, "a message sent again should keep its id")
+		require.Equal(t, createAt, m.CreateAt)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -342,6 +343,166 @@ func TestTeamSettingsIsValidSiteNameEmpty(t *testing.T) {
 	require.Nil(t, c1.TeamSettings.isValid())
 }
 
+func TestSearchSettingsIsValidFuzziness(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, 0, *c1.SearchSettings.Fuzziness)
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.Fuzziness = NewInt(SEARCH_MAX_FUZZINESS)
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.Fuzziness = NewInt(SEARCH_MAX_FUZZINESS + 1)
+	require.NotNil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.Fuzziness = NewInt(-1)
+	require.NotNil(t, c1.SearchSettings.isValid())
+}
+
+func TestSearchSettingsIsValidBulkIndexing(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.BulkIndexingTimeWindowSeconds = NewInt(0)
+	require.NotNil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.BulkIndexingTimeWindowSeconds = NewInt(60)
+	c1.SearchSettings.BulkIndexingMaxInFlightRequests = NewInt(0)
+	require.NotNil(t, c1.SearchSettings.isValid())
+}
+
+func TestSearchSettingsIsValidHealthCheckInterval(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.HealthCheckIntervalSeconds = NewInt(0)
+	require.NotNil(t, c1.SearchSettings.isValid())
+}
+
+func TestSearchSettingsIsValidLanguageAnalyzers(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Empty(t, c1.SearchSettings.LanguageAnalyzers)
+
+	c1.SearchSettings.LanguageAnalyzers = map[string]string{"ja": "kuromoji", "de": "german"}
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.LanguageAnalyzers = map[string]string{"xx": "standard"}
+	require.NotNil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.LanguageAnalyzers = map[string]string{"ja": ""}
+	require.NotNil(t, c1.SearchSettings.isValid())
+}
+
+func TestSearchSettingsIsValidFailoverOrder(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Empty(t, c1.SearchSettings.FailoverOrder)
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.FailoverOrder = []string{SEARCH_BACKEND_ELASTICSEARCH, SEARCH_ENGINE_BLEVE, SEARCH_ENGINE_DATABASE}
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.FailoverOrder = []string{"solr"}
+	require.NotNil(t, c1.SearchSettings.isValid())
+
+	c1.SearchSettings.FailoverOrder = []string{SEARCH_ENGINE_BLEVE, SEARCH_ENGINE_BLEVE}
+	require.NotNil(t, c1.SearchSettings.isValid())
+}
+
+func TestSearchSettingsIsValidIndexPrefix(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, "", *c1.SearchSettings.IndexPrefix)
+	require.Nil(t, c1.SearchSettings.isValid())
+	require.Equal(t, *c1.ElasticsearchSettings.IndexPrefix, c1.SearchSettings.GetIndexPrefix(&c1.ElasticsearchSettings))
+
+	for _, prefix := range []string{"blue_", "mm-prod.", "team1"} {
+		*c1.SearchSettings.IndexPrefix = prefix
+		require.Nil(t, c1.SearchSettings.isValid(), prefix)
+		require.Equal(t, prefix, c1.SearchSettings.GetIndexPrefix(&c1.ElasticsearchSettings))
+	}
+
+	for _, prefix := range []string{"Blue_", "_blue", "-blue", "+blue", ".blue", "blue green", "blue*", "blue/", "blue:", "blue,", "blue#", strings.Repeat("a", SEARCH_MAX_INDEX_PREFIX_LENGTH+1)} {
+		*c1.SearchSettings.IndexPrefix = prefix
+		appErr := c1.SearchSettings.isValid()
+		require.NotNil(t, appErr, prefix)
+		require.Equal(t, "model.config.is_valid.search_index_prefix.app_error", appErr.Id)
+	}
+}
+
+func TestSearchSettingsIsValidRequestRetries(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, SEARCH_SETTINGS_DEFAULT_REQUEST_RETRIES, *c1.SearchSettings.RequestRetries)
+	require.Equal(t, SEARCH_SETTINGS_DEFAULT_REQUEST_RETRY_BACKOFF_MILLISECONDS, *c1.SearchSettings.RequestRetryBackoffMilliseconds)
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	for _, retries := range []int{0, SEARCH_MAX_REQUEST_RETRIES} {
+		*c1.SearchSettings.RequestRetries = retries
+		require.Nil(t, c1.SearchSettings.isValid(), retries)
+	}
+
+	for _, retries := range []int{-1, SEARCH_MAX_REQUEST_RETRIES + 1} {
+		*c1.SearchSettings.RequestRetries = retries
+		appErr := c1.SearchSettings.isValid()
+		require.NotNil(t, appErr, retries)
+		require.Equal(t, "model.config.is_valid.search_request_retries.app_error", appErr.Id)
+	}
+	*c1.SearchSettings.RequestRetries = SEARCH_SETTINGS_DEFAULT_REQUEST_RETRIES
+
+	*c1.SearchSettings.RequestRetryBackoffMilliseconds = 0
+	appErr := c1.SearchSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.search_request_retry_backoff_milliseconds.app_error", appErr.Id)
+}
+
+func TestSearchSettingsIsValidLiveIndexingBatchSize(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.False(t, *c1.SearchSettings.DisableRefreshDuringBulkIndexing)
+	require.Equal(t, SEARCH_SETTINGS_DEFAULT_LIVE_INDEXING_BATCH_SIZE, *c1.SearchSettings.LiveIndexingBatchSize)
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	*c1.SearchSettings.LiveIndexingBatchSize = 0
+	require.Nil(t, c1.SearchSettings.isValid())
+
+	*c1.SearchSettings.LiveIndexingBatchSize = -1
+	appErr := c1.SearchSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.search_live_indexing_batch_size.app_error", appErr.Id)
+}
+
+func TestElasticsearchSettingsIsValidClientCert(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Nil(t, c1.ElasticsearchSettings.isValid())
+
+	*c1.ElasticsearchSettings.ClientCert = "cert.pem"
+	appErr := c1.ElasticsearchSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.elastic_search.client_cert.app_error", appErr.Id)
+
+	*c1.ElasticsearchSettings.ClientKey = "key.pem"
+	require.Nil(t, c1.ElasticsearchSettings.isValid())
+
+	*c1.ElasticsearchSettings.ClientCert = ""
+	require.NotNil(t, c1.ElasticsearchSettings.isValid())
+}
+
+func TestConfigSanitizeElasticsearchAPIKey(t *testing.T) {
+	c := Config{}
+	c.SetDefaults()
+	c.Sanitize()
+	require.Empty(t, *c.ElasticsearchSettings.APIKey)
+
+	*c.ElasticsearchSettings.APIKey = "key"
+	c.Sanitize()
+	require.Equal(t, FAKE_SETTING, *c.ElasticsearchSettings.APIKey)
+}
+
 func TestMessageExportSettingsIsValidEnableExportNotSet(t *testing.T) {
 	fs := &FileSettings{}
 	mes := &MessageExportSettings{}
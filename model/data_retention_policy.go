@@ -6,6 +6,7 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"time"
 )
 
 type DataRetentionPolicy struct {
@@ -25,3 +26,22 @@ func DataRetentionPolicyFromJson(data io.Reader) *DataRetentionPolicy {
 	json.NewDecoder(data).Decode(&me)
 	return me
 }
+
+// RetentionBatchOptions governs the permanent deletion of the rows past the data retention
+// policy in batches, which keeps the locks of every batch short and leaves the database to the
+// other queries between batches.
+type RetentionBatchOptions struct {
+	BatchSize          int
+	TimeBetweenBatches time.Duration
+	// MaxBatches stops the deletion after as many batches, for it to be resumed later on. The
+	// deletion goes on until no row is left when it's 0.
+	MaxBatches int
+}
+
+// BatchOptions returns the batch size and the time between batches of the settings.
+func (s *DataRetentionSettings) BatchOptions() RetentionBatchOptions {
+	return RetentionBatchOptions{
+		BatchSize:          *s.BatchSize,
+		TimeBetweenBatches: time.Duration(*s.TimeBetweenBatchesMilliseconds) * time.Millisecond,
+	}
+}
@@ -26,6 +26,14 @@ type Emoji struct {
 	Name      string `json:"name"`
 }
 
+// EmojiUsage is the number of times an emoji is used, either as a reaction or within the message
+// of a post. The reactions and posts that belong to deleted posts aren't counted.
+type EmojiUsage struct {
+	Name          string `json:"name"`
+	ReactionCount int64  `json:"reaction_count"`
+	MessageCount  int64  `json:"message_count"`
+}
+
 func inSystemEmoji(emojiName string) bool {
 	_, ok := SystemEmojis[emojiName]
 	return ok
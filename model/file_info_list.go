@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type FileInfoList struct {
+	Order     []string             `json:"order"`
+	FileInfos map[string]*FileInfo `json:"file_infos"`
+}
+
+func NewFileInfoList() *FileInfoList {
+	return &FileInfoList{
+		Order:     make([]string, 0),
+		FileInfos: make(map[string]*FileInfo),
+	}
+}
+
+func (o *FileInfoList) ToSlice() []*FileInfo {
+	var fileInfos []*FileInfo
+	for _, id := range o.Order {
+		fileInfos = append(fileInfos, o.FileInfos[id])
+	}
+	return fileInfos
+}
+
+func (o *FileInfoList) ToJson() string {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return ""
+	} else {
+		return string(b)
+	}
+}
+
+func (o *FileInfoList) MakeNonNil() {
+	if o.Order == nil {
+		o.Order = make([]string, 0)
+	}
+
+	if o.FileInfos == nil {
+		o.FileInfos = make(map[string]*FileInfo)
+	}
+}
+
+func (o *FileInfoList) AddOrder(id string) {
+	if o.Order == nil {
+		o.Order = make([]string, 0, 128)
+	}
+
+	o.Order = append(o.Order, id)
+}
+
+func (o *FileInfoList) AddFileInfo(fileInfo *FileInfo) {
+	if o.FileInfos == nil {
+		o.FileInfos = make(map[string]*FileInfo)
+	}
+
+	o.FileInfos[fileInfo.Id] = fileInfo
+}
+
+func FileInfoListFromJson(data io.Reader) *FileInfoList {
+	var o *FileInfoList
+	json.NewDecoder(data).Decode(&o)
+	return o
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileInfoListJson(t *testing.T) {
+	fileInfos := NewFileInfoList()
+	fileInfo := &FileInfo{Id: NewId(), Name: "file.txt", Content: "secret"}
+	fileInfos.AddOrder(fileInfo.Id)
+	fileInfos.AddFileInfo(fileInfo)
+
+	json := fileInfos.ToJson()
+	assert.NotContains(t, json, "secret")
+
+	result := FileInfoListFromJson(strings.NewReader(json))
+	require.NotNil(t, result)
+	assert.Equal(t, []string{fileInfo.Id}, result.Order)
+	assert.Equal(t, fileInfo.Name, result.FileInfos[fileInfo.Id].Name)
+	assert.Equal(t, []*FileInfo{result.FileInfos[fileInfo.Id]}, result.ToSlice())
+}
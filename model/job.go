@@ -31,6 +31,9 @@ const (
 	JOB_STATUS_CANCEL_REQUESTED = "cancel_requested"
 	JOB_STATUS_CANCELED         = "canceled"
 	JOB_STATUS_WARNING          = "warning"
+
+	// JOB_DATA_NODE_ID is the key of the data of a job recording the node that claimed it.
+	JOB_DATA_NODE_ID = "node_id"
 )
 
 type Job struct {
@@ -22,4 +22,5 @@ const (
 	MIGRATION_KEY_SIDEBAR_CATEGORIES_PHASE_2                  = "migration_sidebar_categories_phase_2"
 	MIGRATION_KEY_ADD_CONVERT_CHANNEL_PERMISSIONS             = "add_convert_channel_permissions"
 	MIGRATION_KEY_ADD_MANAGE_SHARED_CHANNEL_PERMISSIONS       = "manage_shared_channel_permissions"
+	MIGRATION_KEY_ADD_SEARCH_POSTS_IN_ALL_TEAMS_PERMISSION    = "add_search_posts_in_all_teams_permission"
 )
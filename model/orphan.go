@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// The classes of orphaned records, referencing a channel, a post or a user that doesn't exist
+// anymore.
+const (
+	// ORPHAN_TYPE_CHANNEL_MEMBER is a channel member whose channel or user doesn't exist.
+	ORPHAN_TYPE_CHANNEL_MEMBER = "channel_member"
+	// ORPHAN_TYPE_FILE_INFO is a file info attached to a post that doesn't exist.
+	ORPHAN_TYPE_FILE_INFO = "file_info"
+	// ORPHAN_TYPE_REACTION is a reaction whose post or user doesn't exist.
+	ORPHAN_TYPE_REACTION = "reaction"
+	// ORPHAN_TYPE_PREFERENCE is a preference whose user doesn't exist.
+	ORPHAN_TYPE_PREFERENCE = "preference"
+)
+
+// OrphanTypes lists the classes of orphaned records the store finds and deletes.
+var OrphanTypes = []string{
+	ORPHAN_TYPE_CHANNEL_MEMBER,
+	ORPHAN_TYPE_FILE_INFO,
+	ORPHAN_TYPE_REACTION,
+	ORPHAN_TYPE_PREFERENCE,
+}
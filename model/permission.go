@@ -100,6 +100,7 @@ var PERMISSION_USE_GROUP_MENTIONS *Permission
 var PERMISSION_READ_OTHER_USERS_TEAMS *Permission
 var PERMISSION_EDIT_BRAND *Permission
 var PERMISSION_MANAGE_SHARED_CHANNELS *Permission
+var PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS *Permission
 
 var PERMISSION_SYSCONSOLE_READ_ABOUT *Permission
 var PERMISSION_SYSCONSOLE_WRITE_ABOUT *Permission
@@ -523,6 +524,12 @@ func initializePermissions() {
 		"authentication.permissions.manage_shared_channels.description",
 		PermissionScopeSystem,
 	}
+	PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS = &Permission{
+		"search_posts_in_all_teams",
+		"authentication.permissions.search_posts_in_all_teams.name",
+		"authentication.permissions.search_posts_in_all_teams.description",
+		PermissionScopeSystem,
+	}
 	PERMISSION_REMOVE_USER_FROM_TEAM = &Permission{
 		"remove_user_from_team",
 		"authentication.permissions.remove_user_from_team.name",
@@ -920,6 +927,7 @@ func initializePermissions() {
 		PERMISSION_DEMOTE_TO_GUEST,
 		PERMISSION_EDIT_BRAND,
 		PERMISSION_MANAGE_SHARED_CHANNELS,
+		PERMISSION_SEARCH_POSTS_IN_ALL_TEAMS,
 	}
 
 	TeamScopedPermissions := []*Permission{
@@ -76,6 +76,7 @@ type Post struct {
 	EditAt     int64  `json:"edit_at"`
 	DeleteAt   int64  `json:"delete_at"`
 	IsPinned   bool   `json:"is_pinned"`
+	PinnedAt   int64  `json:"pinned_at"`
 	UserId     string `json:"user_id"`
 	ChannelId  string `json:"channel_id"`
 	RootId     string `json:"root_id"`
@@ -122,6 +123,7 @@ type SearchParameter struct {
 	Page                   *int    `json:"page"`
 	PerPage                *int    `json:"per_page"`
 	IncludeDeletedChannels *bool   `json:"include_deleted_channels"`
+	IncludeHighlights      *bool   `json:"include_highlights"`
 }
 
 type AnalyticsPostCountsOptions struct {
@@ -179,6 +181,7 @@ func (o *Post) ShallowCopy(dst *Post) error {
 	dst.EditAt = o.EditAt
 	dst.DeleteAt = o.DeleteAt
 	dst.IsPinned = o.IsPinned
+	dst.PinnedAt = o.PinnedAt
 	dst.UserId = o.UserId
 	dst.ChannelId = o.ChannelId
 	dst.RootId = o.RootId
@@ -231,6 +234,25 @@ type GetPostsOptions struct {
 	SkipFetchThreads bool
 }
 
+// GetPostsCursorOptions selects a page of posts next to either a post or the cursor
+// returned along with the previous page. Cursor takes precedence over PostId.
+type GetPostsCursorOptions struct {
+	ChannelId        string
+	PostId           string
+	Cursor           string
+	PerPage          int
+	SkipFetchThreads bool
+}
+
+// GetRecentPostsOptions selects a page of the most recent posts across the channels a user
+// is a member of, starting after the cursor returned along with the previous page.
+type GetRecentPostsOptions struct {
+	UserId       string
+	Cursor       string
+	PerPage      int
+	ExcludeMuted bool
+}
+
 func PostFromJson(data io.Reader) *Post {
 	var o *Post
 	json.NewDecoder(data).Decode(&o)
@@ -360,9 +382,26 @@ func (o *Post) PreSave() {
 	}
 
 	o.UpdateAt = o.CreateAt
+
+	if !o.IsPinned {
+		o.PinnedAt = 0
+	} else if o.PinnedAt == 0 {
+		o.PinnedAt = o.CreateAt
+	}
+
 	o.PreCommit()
 }
 
+// SetPinnedAt sets PinnedAt, the time the post was last pinned, to millis when the post was
+// pinned since oldPost, and clears it once unpinned.
+func (o *Post) SetPinnedAt(oldPost *Post, millis int64) {
+	if !o.IsPinned {
+		o.PinnedAt = 0
+	} else if !oldPost.IsPinned || o.PinnedAt == 0 {
+		o.PinnedAt = millis
+	}
+}
+
 func (o *Post) PreCommit() {
 	if o.GetProps() == nil {
 		o.SetProps(make(map[string]interface{}))
@@ -8,17 +8,43 @@ import (
 	"io"
 )
 
+const (
+	POST_SEARCH_HIGHLIGHT_PRE_TAG  = "<mark>"
+	POST_SEARCH_HIGHLIGHT_POST_TAG = "</mark>"
+)
+
 type PostSearchMatches map[string][]string
 
+// PostSearchHighlights holds fragments of the messages matching a search, keyed by post id,
+// with the matched terms enclosed in POST_SEARCH_HIGHLIGHT_PRE_TAG and POST_SEARCH_HIGHLIGHT_POST_TAG.
+type PostSearchHighlights map[string][]string
+
+// PostSearchCursor is the position of a post in the results of a search paged with a cursor,
+// the next page starting right after it. The results are sorted by descending score, and then
+// by descending CreateAt and Id so that the pages never overlap. The database searches don't
+// score the posts, leaving Score to zero.
+type PostSearchCursor struct {
+	Score    float64 `json:"score"`
+	CreateAt int64   `json:"create_at"`
+	Id       string  `json:"id"`
+}
+
 type PostSearchResults struct {
 	*PostList
-	Matches PostSearchMatches `json:"matches"`
+	Matches    PostSearchMatches    `json:"matches"`
+	Highlights PostSearchHighlights `json:"highlights,omitempty"`
+	// NextCursor is set by the searches paged with a cursor when there may be more results,
+	// to get them from.
+	NextCursor *PostSearchCursor `json:"next_cursor,omitempty"`
+	// SearchEngine is the name of the search engine that served the results, such as "bleve"
+	// or "database", when they went through the search engine broker.
+	SearchEngine string `json:"-"`
 }
 
 func MakePostSearchResults(posts *PostList, matches PostSearchMatches) *PostSearchResults {
 	return &PostSearchResults{
-		posts,
-		matches,
+		PostList: posts,
+		Matches:  matches,
 	}
 }
 
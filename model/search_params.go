@@ -10,6 +10,13 @@ import (
 	"time"
 )
 
+// SEARCH_MAX_FUZZINESS is the maximum edit distance supported by the fuzzy searches.
+const SEARCH_MAX_FUZZINESS = 2
+
+// SearchLanguages are the languages the search engines can detect in the indexed messages, so
+// that they can be analyzed by the analyzer configured for them in SearchSettings.LanguageAnalyzers.
+var SearchLanguages = []string{"de", "en", "es", "fr", "ja", "ko", "ru", "zh"}
+
 var searchTermPuncStart = regexp.MustCompile(`^[^\pL\d\s#"]+`)
 var searchTermPuncEnd = regexp.MustCompile(`[^\pL\d\s*"]+$`)
 
@@ -30,8 +37,38 @@ type SearchParams struct {
 	OrTerms                bool
 	IncludeDeletedChannels bool
 	TimeZoneOffset         int
+	// The IANA name of the time zone the dates are interpreted in. It takes precedence over
+	// TimeZoneOffset, which doesn't account for the daylight saving time changes.
+	TimeZone string
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool
+	// True if the results should include highlighted fragments of the matching posts.
+	IncludeHighlights bool
+	// The edit distance allowed between the terms and the words of the messages, 0 to only
+	// match the exact terms.
+	Fuzziness int
+	// True if only the pinned posts should be returned. The search engines don't index whether
+	// the posts are pinned, so these searches are run against the database.
+	PinnedOnly bool
+}
+
+// SearchAllTeamsOptions tunes a search of the posts of all the teams.
+type SearchAllTeamsOptions struct {
+	IsOrSearch             bool
+	IncludeDeletedChannels bool
+	TimeZoneOffset         int
+	Page                   int
+	PerPage                int
+}
+
+// getLocation returns the time zone the dates of the search are interpreted in.
+func (p *SearchParams) getLocation() *time.Location {
+	if p.TimeZone != "" {
+		if location, err := time.LoadLocation(p.TimeZone); err == nil {
+			return location
+		}
+	}
+	return time.FixedZone("Local Search Time Zone", p.TimeZoneOffset)
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -44,7 +81,7 @@ func (p *SearchParams) GetAfterDateMillis() int64 {
 	// travel forward 1 day
 	oneDay := time.Hour * 24
 	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return GetStartOfDayMillisInLocation(afterDate, p.getLocation())
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.ExcludedAfterDate
@@ -57,7 +94,7 @@ func (p *SearchParams) GetExcludedAfterDateMillis() int64 {
 	// travel forward 1 day
 	oneDay := time.Hour * 24
 	afterDate := date.Add(oneDay)
-	return GetStartOfDayMillis(afterDate, p.TimeZoneOffset)
+	return GetStartOfDayMillisInLocation(afterDate, p.getLocation())
 }
 
 // Returns the epoch timestamp of the end of the day specified by SearchParams.BeforeDate
@@ -70,7 +107,7 @@ func (p *SearchParams) GetBeforeDateMillis() int64 {
 	// travel back 1 day
 	oneDay := time.Hour * -24
 	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return GetEndOfDayMillisInLocation(beforeDate, p.getLocation())
 }
 
 // Returns the epoch timestamp of the end of the day specified by SearchParams.ExcludedBeforeDate
@@ -83,7 +120,7 @@ func (p *SearchParams) GetExcludedBeforeDateMillis() int64 {
 	// travel back 1 day
 	oneDay := time.Hour * -24
 	beforeDate := date.Add(oneDay)
-	return GetEndOfDayMillis(beforeDate, p.TimeZoneOffset)
+	return GetEndOfDayMillisInLocation(beforeDate, p.getLocation())
 }
 
 // Returns the epoch timestamps of the start and end of the day specified by SearchParams.OnDate
@@ -93,7 +130,7 @@ func (p *SearchParams) GetOnDateMillis() (int64, int64) {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return GetStartOfDayMillisInLocation(date, p.getLocation()), GetEndOfDayMillisInLocation(date, p.getLocation())
 }
 
 // Returns the epoch timestamps of the start and end of the day specified by SearchParams.ExcludedDate
@@ -103,7 +140,7 @@ func (p *SearchParams) GetExcludedDateMillis() (int64, int64) {
 		return 0, 0
 	}
 
-	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
+	return GetStartOfDayMillisInLocation(date, p.getLocation()), GetEndOfDayMillisInLocation(date, p.getLocation())
 }
 
 var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on"}
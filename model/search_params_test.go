@@ -1619,6 +1619,32 @@ func TestGetAfterDateMillis(t *testing.T) {
 	}
 }
 
+func TestGetDateMillisInTimeZone(t *testing.T) {
+	t.Run("Daylight saving time", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2018-08-01", TimeZone: "America/New_York", TimeZoneOffset: -5 * 60 * 60}
+		startOnDate, endOnDate := sp.GetOnDateMillis()
+		assert.Equal(t, int64(1533096000000), startOnDate)
+		assert.Equal(t, int64(1533182399999), endOnDate)
+	})
+
+	t.Run("Standard time", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2018-01-15", TimeZone: "America/New_York", TimeZoneOffset: -4 * 60 * 60}
+		startOnDate, endOnDate := sp.GetOnDateMillis()
+		assert.Equal(t, int64(1515992400000), startOnDate)
+		assert.Equal(t, int64(1516078799999), endOnDate)
+
+		sp = &SearchParams{BeforeDate: "2018-01-16", AfterDate: "2018-01-14", TimeZone: "America/New_York"}
+		assert.Equal(t, startOnDate, sp.GetAfterDateMillis())
+		assert.Equal(t, endOnDate, sp.GetBeforeDateMillis())
+	})
+
+	t.Run("Unknown time zone falls back to the offset", func(t *testing.T) {
+		sp := &SearchParams{OnDate: "2018-08-01", TimeZone: "Nowhere/Unknown", TimeZoneOffset: -4 * 60 * 60}
+		startOnDate, _ := sp.GetOnDateMillis()
+		assert.Equal(t, int64(1533096000000), startOnDate)
+	})
+}
+
 func TestIsSearchParamsListValid(t *testing.T) {
 	var err *AppError
 
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	SEARCH_SUGGESTION_TYPE_CHANNEL = "channel"
+	SEARCH_SUGGESTION_TYPE_USER    = "user"
+	SEARCH_SUGGESTION_TYPE_TERM    = "term"
+
+	SEARCH_SUGGESTION_DEFAULT_LIMIT = 10
+	SEARCH_SUGGESTION_MAX_LIMIT     = 50
+
+	// SEARCH_SUGGESTION_POSTS_SAMPLE_SIZE is the number of recent posts the terms are suggested from.
+	SEARCH_SUGGESTION_POSTS_SAMPLE_SIZE = 200
+)
+
+// SearchSuggestion is a completion of what the user is typing in the search box: the name of a
+// channel, a username, or a term used in the recent posts.
+type SearchSuggestion struct {
+	Type        string `json:"type"`
+	Term        string `json:"term"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+func SearchSuggestionsToJson(suggestions []*SearchSuggestion) string {
+	b, _ := json.Marshal(suggestions)
+	return string(b)
+}
+
+func SearchSuggestionsFromJson(data io.Reader) []*SearchSuggestion {
+	var suggestions []*SearchSuggestion
+	json.NewDecoder(data).Decode(&suggestions)
+	return suggestions
+}
+
+// MergeSearchSuggestions interleaves the ranked lists of suggestions, so that each type of
+// suggestion is represented within the limit.
+func MergeSearchSuggestions(limit int, lists ...[]*SearchSuggestion) []*SearchSuggestion {
+	merged := []*SearchSuggestion{}
+	for i := 0; len(merged) < limit; i++ {
+		added := false
+		for _, list := range lists {
+			if i < len(list) && len(merged) < limit {
+				merged = append(merged, list[i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return merged
+}
+
+// ExtractSuggestedTerms returns the words of the messages starting with the prefix, ranked by
+// number of occurrences. The messages are expected to be sorted from the most recent, which
+// breaks the ties.
+func ExtractSuggestedTerms(messages []string, prefix string, limit int) []string {
+	prefix = strings.ToLower(prefix)
+
+	terms := []string{}
+	counts := map[string]int{}
+	for _, message := range messages {
+		words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+		})
+		for _, word := range words {
+			if word == prefix || !strings.HasPrefix(word, prefix) {
+				continue
+			}
+			if _, ok := counts[word]; !ok {
+				terms = append(terms, word)
+			}
+			counts[word]++
+		}
+	}
+
+	sort.SliceStable(terms, func(i, j int) bool {
+		return counts[terms[i]] > counts[terms[j]]
+	})
+
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	return terms
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSuggestionsJson(t *testing.T) {
+	suggestions := []*SearchSuggestion{
+		{Type: SEARCH_SUGGESTION_TYPE_CHANNEL, Term: "town-square", DisplayName: "Town Square"},
+		{Type: SEARCH_SUGGESTION_TYPE_USER, Term: "someone"},
+	}
+
+	assert.Equal(t, suggestions, SearchSuggestionsFromJson(strings.NewReader(SearchSuggestionsToJson(suggestions))))
+}
+
+func TestMergeSearchSuggestions(t *testing.T) {
+	channels := []*SearchSuggestion{{Term: "c1"}, {Term: "c2"}, {Term: "c3"}}
+	users := []*SearchSuggestion{{Term: "u1"}}
+	terms := []*SearchSuggestion{{Term: "t1"}, {Term: "t2"}}
+
+	getTerms := func(suggestions []*SearchSuggestion) []string {
+		result := []string{}
+		for _, suggestion := range suggestions {
+			result = append(result, suggestion.Term)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"c1", "u1", "t1", "c2", "t2", "c3"}, getTerms(MergeSearchSuggestions(10, channels, users, terms)))
+	assert.Equal(t, []string{"c1", "u1", "t1", "c2"}, getTerms(MergeSearchSuggestions(4, channels, users, terms)))
+	assert.Empty(t, MergeSearchSuggestions(10))
+}
+
+func TestExtractSuggestedTerms(t *testing.T) {
+	messages := []string{
+		"Deploying the new release",
+		"deploy failed, retrying the deployment",
+		"The deployment is done. Deploy!",
+	}
+
+	assert.Equal(t, []string{"deployment", "deploying"}, ExtractSuggestedTerms(messages, "Deploy", 10))
+	assert.Equal(t, []string{"deployment"}, ExtractSuggestedTerms(messages, "deploy", 1))
+	assert.Equal(t, []string{"release", "retrying"}, ExtractSuggestedTerms(messages, "re", 10))
+	assert.Empty(t, ExtractSuggestedTerms(messages, "nothing", 10))
+}
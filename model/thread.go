@@ -16,12 +16,13 @@ type Thread struct {
 }
 
 type ThreadResponse struct {
-	PostId       string  `json:"id"`
-	ReplyCount   int64   `json:"reply_count"`
-	LastReplyAt  int64   `json:"last_reply_at"`
-	LastViewedAt int64   `json:"last_viewed_at"`
-	Participants []*User `json:"participants"`
-	Post         *Post   `json:"post"`
+	PostId        string  `json:"id"`
+	ReplyCount    int64   `json:"reply_count"`
+	LastReplyAt   int64   `json:"last_reply_at"`
+	LastViewedAt  int64   `json:"last_viewed_at"`
+	UnreadReplies int64   `json:"unread_replies"`
+	Participants  []*User `json:"participants"`
+	Post          *Post   `json:"post"`
 }
 
 type Threads struct {
@@ -44,6 +45,10 @@ type GetUserThreadsOpts struct {
 
 	// Since filters the threads based on their LastUpdateAt timestamp.
 	Since uint64
+
+	// Before is the id of the last thread of the previous page, to return the threads following
+	// it instead of the page given by Page. The threads are ordered by their last reply.
+	Before string
 }
 
 func (o *Threads) ToJson() string {
@@ -14,6 +14,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -747,9 +748,19 @@ func UserListFromJson(data io.Reader) []*User {
 	return users
 }
 
-// HashPassword generates a hash using the bcrypt.GenerateFromPassword
+// passwordHashCost is the bcrypt cost of the hashes generated by HashPassword.
+var passwordHashCost int32 = PASSWORD_SETTINGS_DEFAULT_HASH_COST
+
+// SetPasswordHashCost sets the bcrypt cost of the hashes generated by HashPassword, from
+// PasswordSettings.HashCost.
+func SetPasswordHashCost(cost int) {
+	atomic.StoreInt32(&passwordHashCost, int32(cost))
+}
+
+// HashPassword generates a hash using the bcrypt.GenerateFromPassword, with the cost set by
+// SetPasswordHashCost.
 func HashPassword(password string) string {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), int(atomic.LoadInt32(&passwordHashCost)))
 	if err != nil {
 		panic(err)
 	}
@@ -757,6 +768,13 @@ func HashPassword(password string) string {
 	return string(hash)
 }
 
+// PasswordNeedsRehash returns whether a hash was generated with a lower cost than the one
+// HashPassword uses, and should be generated again from the password once it's known to match.
+func PasswordNeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < int(atomic.LoadInt32(&passwordHashCost))
+}
+
 // ComparePassword compares the hash
 func ComparePassword(hash string, password string) bool {
 
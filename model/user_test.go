@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestPasswordHash(t *testing.T) {
@@ -21,6 +22,31 @@ func TestPasswordHash(t *testing.T) {
 	assert.False(t, ComparePassword(hash, "Test2"), "Passwords should not have matched")
 }
 
+func TestPasswordHashCost(t *testing.T) {
+	defer SetPasswordHashCost(PASSWORD_SETTINGS_DEFAULT_HASH_COST)
+
+	SetPasswordHashCost(bcrypt.MinCost)
+	oldHash := HashPassword("Test")
+	cost, err := bcrypt.Cost([]byte(oldHash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost, cost)
+	assert.False(t, PasswordNeedsRehash(oldHash))
+
+	SetPasswordHashCost(bcrypt.MinCost + 1)
+	assert.True(t, ComparePassword(oldHash, "Test"), "a hash with an older cost should still match")
+	assert.True(t, PasswordNeedsRehash(oldHash))
+
+	newHash := HashPassword("Test")
+	cost, err = bcrypt.Cost([]byte(newHash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+	assert.False(t, PasswordNeedsRehash(newHash))
+
+	SetPasswordHashCost(bcrypt.MinCost)
+	assert.False(t, PasswordNeedsRehash(newHash), "a hash with a higher cost shouldn't be downgraded")
+	assert.False(t, PasswordNeedsRehash("not a hash"))
+}
+
 func TestUserDeepCopy(t *testing.T) {
 	id := NewId()
 	authData := "authdata"
@@ -215,15 +215,23 @@ func PadDateStringZeros(dateString string) string {
 
 // GetStartOfDayMillis is a convenience method to get milliseconds since epoch for provided date's start of day
 func GetStartOfDayMillis(thisTime time.Time, timeZoneOffset int) int64 {
-	localSearchTimeZone := time.FixedZone("Local Search Time Zone", timeZoneOffset)
-	resultTime := time.Date(thisTime.Year(), thisTime.Month(), thisTime.Day(), 0, 0, 0, 0, localSearchTimeZone)
+	return GetStartOfDayMillisInLocation(thisTime, time.FixedZone("Local Search Time Zone", timeZoneOffset))
+}
+
+// GetStartOfDayMillisInLocation is a convenience method to get milliseconds since epoch for provided date's start of day in the location
+func GetStartOfDayMillisInLocation(thisTime time.Time, location *time.Location) int64 {
+	resultTime := time.Date(thisTime.Year(), thisTime.Month(), thisTime.Day(), 0, 0, 0, 0, location)
 	return GetMillisForTime(resultTime)
 }
 
 // GetEndOfDayMillis is a convenience method to get milliseconds since epoch for provided date's end of day
 func GetEndOfDayMillis(thisTime time.Time, timeZoneOffset int) int64 {
-	localSearchTimeZone := time.FixedZone("Local Search Time Zone", timeZoneOffset)
-	resultTime := time.Date(thisTime.Year(), thisTime.Month(), thisTime.Day(), 23, 59, 59, 999999999, localSearchTimeZone)
+	return GetEndOfDayMillisInLocation(thisTime, time.FixedZone("Local Search Time Zone", timeZoneOffset))
+}
+
+// GetEndOfDayMillisInLocation is a convenience method to get milliseconds since epoch for provided date's end of day in the location
+func GetEndOfDayMillisInLocation(thisTime time.Time, location *time.Location) int64 {
+	resultTime := time.Date(thisTime.Year(), thisTime.Month(), thisTime.Day(), 23, 59, 59, 999999999, location)
 	return GetMillisForTime(resultTime)
 }
 
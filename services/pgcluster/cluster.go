@@ -0,0 +1,308 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package pgcluster implements the cluster interface with the LISTEN/NOTIFY commands of
+// Postgres, propagating the cluster messages between the nodes sharing a database without any
+// other infrastructure.
+package pgcluster
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	// NOTIFY_CHANNEL is the channel the messages are sent on, suffixed with the cluster name.
+	NOTIFY_CHANNEL = "mattermost_cluster"
+
+	// MAX_PAYLOAD_SIZE is the size of the largest payload accepted by NOTIFY, which must be
+	// shorter than 8000 bytes. The larger messages are dropped.
+	MAX_PAYLOAD_SIZE = 7999
+
+	minReconnectInterval = 100 * time.Millisecond
+	maxReconnectInterval = 30 * time.Second
+
+	// pingInterval is the delay without notification after which the listener connection
+	// is checked.
+	pingInterval = 90 * time.Second
+)
+
+var _ einterfaces.ClusterInterface = (*PostgresCluster)(nil)
+
+// notification is the payload of the notifications, holding the message along with the node
+// that sent it for the nodes to ignore their own messages.
+type notification struct {
+	NodeId  string                `json:"node_id"`
+	Message *model.ClusterMessage `json:"message"`
+}
+
+// PostgresCluster is a cluster interface sending the messages through the database. Every node
+// listens on the same channel and receives the messages of the others, but it doesn't know the
+// other nodes nor elect a leader.
+type PostgresCluster struct {
+	dataSource string
+	channel    string
+	id         string
+
+	mut      sync.RWMutex
+	handlers map[string]einterfaces.ClusterMessageHandler
+	db       *sql.DB
+	listener *pq.Listener
+	stop     chan struct{}
+	done     chan struct{}
+
+	// connected is 1 while the listener is connected.
+	connected int32
+	// compressionThreshold is the size above which the data of the messages is compressed.
+	compressionThreshold int32
+	// filter drops the messages received twice, as NOTIFY may deliver them again after the
+	// listener reconnects, and the stale ones.
+	filter *model.ClusterMessageFilter
+}
+
+// NewPostgresCluster creates a cluster interface for the Postgres database of the data source.
+// The nodes of the same cluster name receive each other's messages, whose data is compressed
+// when it's larger than MessageCompressionThresholdBytes.
+func NewPostgresCluster(dataSource string, settings *model.ClusterSettings) *PostgresCluster {
+	channel := NOTIFY_CHANNEL
+	if *settings.ClusterName != "" {
+		channel += "_" + *settings.ClusterName
+	}
+
+	return &PostgresCluster{
+		dataSource: dataSource,
+		channel:    channel,
+		id:         model.NewId(),
+		handlers:   map[string]einterfaces.ClusterMessageHandler{},
+
+		compressionThreshold: int32(*settings.MessageCompressionThresholdBytes),
+		filter:               model.NewClusterMessageFilterFromSettings(settings),
+	}
+}
+
+// StartInterNodeCommunication opens the connections to the database and starts dispatching
+// the messages of the other nodes to the handlers.
+func (c *PostgresCluster) StartInterNodeCommunication() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.listener != nil {
+		return
+	}
+
+	db, err := sql.Open(model.DATABASE_DRIVER_POSTGRES, c.dataSource)
+	if err != nil {
+		mlog.Error("Failed to open the database of the cluster", mlog.Err(err))
+		return
+	}
+
+	listener := pq.NewListener(c.dataSource, minReconnectInterval, maxReconnectInterval, c.handleListenerEvent)
+	if err := listener.Listen(c.channel); err != nil {
+		mlog.Error("Failed to listen to the cluster messages", mlog.String("channel", c.channel), mlog.Err(err))
+		listener.Close()
+		db.Close()
+		return
+	}
+
+	c.db = db
+	c.listener = listener
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+	go c.listen(listener, c.stop, c.done)
+
+	mlog.Info("Started the cluster communication through Postgres", mlog.String("channel", c.channel), mlog.String("node_id", c.id))
+}
+
+// StopInterNodeCommunication stops listening to the messages and closes the connections.
+func (c *PostgresCluster) StopInterNodeCommunication() {
+	c.mut.Lock()
+	listener, db, stop, done := c.listener, c.db, c.stop, c.done
+	c.listener, c.db = nil, nil
+	c.mut.Unlock()
+
+	if listener == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+	if err := listener.Close(); err != nil {
+		mlog.Warn("Failed to close the cluster listener", mlog.Err(err))
+	}
+	db.Close()
+	atomic.StoreInt32(&c.connected, 0)
+}
+
+func (c *PostgresCluster) listen(listener *pq.Listener, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case n := <-listener.Notify:
+			if n == nil {
+				// The connection was lost and the notifications sent in the meantime are
+				// gone, so the caches may be stale.
+				c.dispatch(&model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_ALL_CACHES})
+				continue
+			}
+			c.NotifyMsg([]byte(n.Extra))
+		case <-time.After(pingInterval):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					mlog.Warn("Failed to ping the cluster listener", mlog.Err(err))
+				}
+			}()
+		}
+	}
+}
+
+func (c *PostgresCluster) handleListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+		atomic.StoreInt32(&c.connected, 1)
+	case pq.ListenerEventDisconnected:
+		atomic.StoreInt32(&c.connected, 0)
+		mlog.Warn("The cluster listener lost its connection to the database", mlog.Err(err))
+	case pq.ListenerEventConnectionAttemptFailed:
+		mlog.Warn("The cluster listener failed to connect to the database", mlog.Err(err))
+	}
+}
+
+func (c *PostgresCluster) RegisterClusterMessageHandler(event string, crm einterfaces.ClusterMessageHandler) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.handlers[event] = crm
+}
+
+func (c *PostgresCluster) dispatch(msg *model.ClusterMessage) {
+	c.mut.RLock()
+	handler := c.handlers[msg.Event]
+	c.mut.RUnlock()
+
+	if handler != nil {
+		handler(msg)
+	}
+}
+
+func (c *PostgresCluster) GetClusterId() string {
+	return c.id
+}
+
+func (c *PostgresCluster) IsLeader() bool {
+	return false
+}
+
+// HealthScore is zero while the listener is connected to the database, and one otherwise.
+func (c *PostgresCluster) HealthScore() int {
+	if atomic.LoadInt32(&c.connected) == 1 {
+		return 0
+	}
+	return 1
+}
+
+func (c *PostgresCluster) GetMyClusterInfo() *model.ClusterInfo {
+	hostname, _ := os.Hostname()
+	return &model.ClusterInfo{
+		Id:       c.id,
+		Version:  model.CurrentVersion,
+		Hostname: hostname,
+	}
+}
+
+// GetClusterInfos only returns this node, the others being unknown.
+func (c *PostgresCluster) GetClusterInfos() []*model.ClusterInfo {
+	return []*model.ClusterInfo{c.GetMyClusterInfo()}
+}
+
+// SendClusterMessage notifies the other nodes of the message, dropping it if it's still too
+// large for a notification once compressed.
+func (c *PostgresCluster) SendClusterMessage(msg *model.ClusterMessage) {
+	msg.PreSend()
+	compressed := *msg
+	err := compressed.Compress(int(atomic.LoadInt32(&c.compressionThreshold)))
+	var payload string
+	if err == nil {
+		payload, err = encodeNotification(c.id, &compressed)
+	}
+	if err != nil {
+		mlog.Error("Failed to send the cluster message", mlog.String("event", msg.Event), mlog.Err(err))
+		return
+	}
+
+	c.mut.RLock()
+	db := c.db
+	c.mut.RUnlock()
+	if db == nil {
+		return
+	}
+
+	if _, err := db.Exec("SELECT pg_notify($1, $2)", c.channel, payload); err != nil {
+		mlog.Error("Failed to send the cluster message", mlog.String("event", msg.Event), mlog.Err(err))
+	}
+}
+
+func encodeNotification(nodeId string, msg *model.ClusterMessage) (string, error) {
+	payload, err := json.Marshal(&notification{NodeId: nodeId, Message: msg})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode the cluster message")
+	}
+	if len(payload) > MAX_PAYLOAD_SIZE {
+		return "", errors.Errorf("the cluster message of %d bytes exceeds the size limit of %d bytes", len(payload), MAX_PAYLOAD_SIZE)
+	}
+	return string(payload), nil
+}
+
+// NotifyMsg dispatches a notification payload to the handler of its message, ignoring the
+// messages sent by this node and those dropped by the filter.
+func (c *PostgresCluster) NotifyMsg(buf []byte) {
+	var n notification
+	if err := json.Unmarshal(buf, &n); err != nil || n.Message == nil {
+		mlog.Warn("Failed to decode the cluster message", mlog.Err(err))
+		return
+	}
+	if n.NodeId == c.id {
+		return
+	}
+	if !c.filter.Accept(n.Message) {
+		mlog.Debug("Dropped a duplicate or stale cluster message", mlog.String("event", n.Message.Event), mlog.String("id", n.Message.Id))
+		return
+	}
+	if err := n.Message.Decompress(); err != nil {
+		mlog.Warn("Failed to decompress the cluster message", mlog.String("event", n.Message.Event), mlog.Err(err))
+		return
+	}
+	c.dispatch(n.Message)
+}
+
+func (c *PostgresCluster) GetClusterStats() ([]*model.ClusterStats, *model.AppError) {
+	return nil, nil
+}
+
+func (c *PostgresCluster) GetLogs(page, perPage int) ([]string, *model.AppError) {
+	return []string{}, nil
+}
+
+func (c *PostgresCluster) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return nil, nil
+}
+
+// ConfigChanged applies the new compression threshold and message limits. It doesn't propagate
+// the configuration, which the nodes are expected to share through the database.
+func (c *PostgresCluster) ConfigChanged(previousConfig *model.Config, newConfig *model.Config, sendToOtherServer bool) *model.AppError {
+	atomic.StoreInt32(&c.compressionThreshold, int32(*newConfig.ClusterSettings.MessageCompressionThresholdBytes))
+	c.filter.SetLimits(&newConfig.ClusterSettings)
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package pgcluster
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func newClusterSettings(clusterName string, compressionThreshold int) *model.ClusterSettings {
+	settings := &model.ClusterSettings{}
+	settings.SetDefaults()
+	settings.ClusterName = model.NewString(clusterName)
+	settings.MessageCompressionThresholdBytes = model.NewInt(compressionThreshold)
+	return settings
+}
+
+func TestEncodeNotification(t *testing.T) {
+	msg := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user1"}
+	payload, err := encodeNotification("node1", msg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"node_id":"node1","message":{"event":"inv_user","data":"user1"}}`, payload)
+
+	msg.Data = strings.Repeat("a", MAX_PAYLOAD_SIZE)
+	_, err = encodeNotification("node1", msg)
+	assert.Error(t, err)
+}
+
+func TestNotifyMsg(t *testing.T) {
+	c := NewPostgresCluster("", newClusterSettings("", 0))
+
+	var received []*model.ClusterMessage
+	c.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, func(msg *model.ClusterMessage) {
+		received = append(received, msg)
+	})
+
+	payload, err := encodeNotification("other", &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user1"})
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+
+	payload, err = encodeNotification(c.GetClusterId(), &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user2"})
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+
+	payload, err = encodeNotification("other", &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_TEAMS})
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+	c.NotifyMsg([]byte("not json"))
+
+	compressed := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: strings.Repeat("user3", 100)}
+	require.NoError(t, compressed.Compress(100))
+	payload, err = encodeNotification("other", compressed)
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+
+	require.Len(t, received, 2, "only the messages of the other nodes with a handler should be dispatched")
+	assert.Equal(t, "user1", received[0].Data)
+	assert.False(t, received[1].Compressed)
+	assert.Equal(t, strings.Repeat("user3", 100), received[1].Data)
+}
+
+func TestNotifyMsgFiltersMessages(t *testing.T) {
+	c := NewPostgresCluster("", newClusterSettings("", 0))
+
+	var received []*model.ClusterMessage
+	c.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, func(msg *model.ClusterMessage) {
+		received = append(received, msg)
+	})
+
+	msg := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user1"}
+	msg.PreSend()
+	payload, err := encodeNotification("other", msg)
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+	c.NotifyMsg([]byte(payload))
+
+	stale := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user2"}
+	stale.PreSend()
+	stale.CreateAt -= (model.CLUSTER_SETTINGS_DEFAULT_MESSAGE_MAX_AGE_SECONDS + 1) * 1000
+	payload, err = encodeNotification("other", stale)
+	require.NoError(t, err)
+	c.NotifyMsg([]byte(payload))
+
+	require.Len(t, received, 1, "the duplicate and the stale messages should be dropped")
+	assert.Equal(t, "user1", received[0].Data)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.ClusterSettings.MessageDeduplicationTTLSeconds = model.NewInt(0)
+	cfg.ClusterSettings.MessageMaxAgeSeconds = model.NewInt(0)
+	require.Nil(t, c.ConfigChanged(cfg, cfg, false))
+	c.NotifyMsg([]byte(payload))
+	require.Len(t, received, 2, "the stale message should be dispatched once the maximum age is disabled")
+}
+
+func TestPostgresCluster(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_POSTGRES)
+	defer storetest.CleanupSqlSettings(settings)
+
+	sender := NewPostgresCluster(*settings.DataSource, newClusterSettings("test", 100))
+	sender.StartInterNodeCommunication()
+	defer sender.StopInterNodeCommunication()
+
+	received := make(chan *model.ClusterMessage, 1)
+	receiver := NewPostgresCluster(*settings.DataSource, newClusterSettings("test", 100))
+	receiver.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, func(msg *model.ClusterMessage) {
+		received <- msg
+	})
+	receiver.StartInterNodeCommunication()
+	defer receiver.StopInterNodeCommunication()
+	require.Eventually(t, func() bool {
+		return receiver.HealthScore() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The data is larger than a notification until it's compressed.
+	for _, data := range []string{"user1", strings.Repeat("user2", 2*MAX_PAYLOAD_SIZE)} {
+		msg := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: data}
+		sender.SendClusterMessage(msg)
+		assert.False(t, msg.Compressed, "the message sent should be left as it is")
+
+		select {
+		case msg := <-received:
+			assert.Equal(t, data, msg.Data)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for the cluster message")
+		}
+	}
+
+	receiver.StopInterNodeCommunication()
+	assert.Equal(t, 1, receiver.HealthScore())
+}
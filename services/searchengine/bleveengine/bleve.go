@@ -26,12 +26,14 @@ const (
 	POST_INDEX    = "posts"
 	USER_INDEX    = "users"
 	CHANNEL_INDEX = "channels"
+	FILE_INDEX    = "files"
 )
 
 type BleveEngine struct {
 	PostIndex    bleve.Index
 	UserIndex    bleve.Index
 	ChannelIndex bleve.Index
+	FileIndex    bleve.Index
 	Mutex        sync.RWMutex
 	ready        int32
 	cfg          *model.Config
@@ -97,6 +99,23 @@ func getUserIndexMapping() *mapping.IndexMappingImpl {
 	return indexMapping
 }
 
+func getFileIndexMapping() *mapping.IndexMappingImpl {
+	fileMapping := bleve.NewDocumentMapping()
+	fileMapping.AddFieldMappingsAt("Id", keywordMapping)
+	fileMapping.AddFieldMappingsAt("CreatorId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("ChannelId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("PostId", keywordMapping)
+	fileMapping.AddFieldMappingsAt("CreateAt", dateMapping)
+	fileMapping.AddFieldMappingsAt("Name", standardMapping)
+	fileMapping.AddFieldMappingsAt("Content", standardMapping)
+	fileMapping.AddFieldMappingsAt("Extension", keywordMapping)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.AddDocumentMapping("_default", fileMapping)
+
+	return indexMapping
+}
+
 func NewBleveEngine(cfg *model.Config, jobServer *jobs.JobServer) *BleveEngine {
 	return &BleveEngine{
 		cfg:       cfg,
@@ -108,17 +127,29 @@ func (b *BleveEngine) getIndexDir(indexName string) string {
 	return filepath.Join(*b.cfg.BleveSettings.IndexDir, indexName+".bleve")
 }
 
-func (b *BleveEngine) createOrOpenIndex(indexName string, mapping *mapping.IndexMappingImpl) (bleve.Index, error) {
+// createOrOpenIndex creates the index when it doesn't exist yet. An index that exists but can't
+// be opened, such as after a crash or running out of disk space left it corrupted, is deleted
+// and created again, in which case rebuilt is true for the index to be populated again.
+func (b *BleveEngine) createOrOpenIndex(indexName string, mapping *mapping.IndexMappingImpl) (index bleve.Index, rebuilt bool, err error) {
 	indexPath := b.getIndexDir(indexName)
-	if index, err := bleve.Open(indexPath); err == nil {
-		return index, nil
+	index, err = bleve.Open(indexPath)
+	if err == nil {
+		return index, false, nil
+	}
+
+	if err != bleve.ErrorIndexPathDoesNotExist {
+		mlog.Warn("Failed to open the Bleve index, rebuilding it.", mlog.String("index", indexName), mlog.Err(err))
+		if err = os.RemoveAll(indexPath); err != nil {
+			return nil, false, err
+		}
+		rebuilt = true
 	}
 
-	index, err := bleve.New(indexPath, mapping)
+	index, err = bleve.New(indexPath, mapping)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return index, nil
+	return index, rebuilt, nil
 }
 
 func (b *BleveEngine) openIndexes() *model.AppError {
@@ -127,21 +158,34 @@ func (b *BleveEngine) openIndexes() *model.AppError {
 	}
 
 	var err error
-	b.PostIndex, err = b.createOrOpenIndex(POST_INDEX, getPostIndexMapping())
+	var postRebuilt, userRebuilt, channelRebuilt, fileRebuilt bool
+	b.PostIndex, postRebuilt, err = b.createOrOpenIndex(POST_INDEX, getPostIndexMapping())
 	if err != nil {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.create_post_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	b.UserIndex, err = b.createOrOpenIndex(USER_INDEX, getUserIndexMapping())
+	b.UserIndex, userRebuilt, err = b.createOrOpenIndex(USER_INDEX, getUserIndexMapping())
 	if err != nil {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.create_user_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	b.ChannelIndex, err = b.createOrOpenIndex(CHANNEL_INDEX, getChannelIndexMapping())
+	b.ChannelIndex, channelRebuilt, err = b.createOrOpenIndex(CHANNEL_INDEX, getChannelIndexMapping())
 	if err != nil {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.create_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
+	b.FileIndex, fileRebuilt, err = b.createOrOpenIndex(FILE_INDEX, getFileIndexMapping())
+	if err != nil {
+		return model.NewAppError("Bleveengine.Start", "bleveengine.create_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// The indexing job populates the rebuilt indexes from the database again.
+	if (postRebuilt || userRebuilt || channelRebuilt || fileRebuilt) && b.jobServer != nil {
+		if _, appErr := b.jobServer.CreateJob(model.JOB_TYPE_BLEVE_POST_INDEXING, nil); appErr != nil {
+			mlog.Error("Failed to create the job indexing the rebuilt Bleve indexes.", mlog.Err(appErr))
+		}
+	}
+
 	atomic.StoreInt32(&b.ready, 1)
 	return nil
 }
@@ -172,6 +216,10 @@ func (b *BleveEngine) closeIndexes() *model.AppError {
 		if err := b.ChannelIndex.Close(); err != nil {
 			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 		}
+
+		if err := b.FileIndex.Close(); err != nil {
+			return model.NewAppError("Bleveengine.Stop", "bleveengine.stop_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+		}
 	}
 
 	atomic.StoreInt32(&b.ready, 0)
@@ -221,6 +269,9 @@ func (b *BleveEngine) deleteIndexes() *model.AppError {
 	if err := os.RemoveAll(b.getIndexDir(CHANNEL_INDEX)); err != nil {
 		return model.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_channel_index.error", nil, err.Error(), http.StatusInternalServerError)
 	}
+	if err := os.RemoveAll(b.getIndexDir(FILE_INDEX)); err != nil {
+		return model.NewAppError("Bleveengine.PurgeIndexes", "bleveengine.purge_file_index.error", nil, err.Error(), http.StatusInternalServerError)
+	}
 	return nil
 }
 
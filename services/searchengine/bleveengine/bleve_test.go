@@ -6,6 +6,7 @@ package bleveengine
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blevesearch/bleve"
@@ -50,7 +51,9 @@ func (s *BleveEngineTestSuite) setupStore() {
 		driverName = model.DATABASE_DRIVER_POSTGRES
 	}
 	s.SQLSettings = storetest.MakeSqlSettings(driverName)
-	s.SQLSupplier = sqlstore.NewSqlSupplier(*s.SQLSettings, nil)
+	var err error
+	s.SQLSupplier, err = sqlstore.NewSqlSupplier(*s.SQLSettings, nil)
+	s.Require().NoError(err)
 
 	cfg := &model.Config{}
 	cfg.SetDefaults()
@@ -227,3 +230,46 @@ func (s *BleveEngineTestSuite) TestDeletePosts() {
 	require.Nil(s.T(), err)
 	require.Equal(s.T(), 1, int(numberDocs))
 }
+
+func TestBleveEngineRebuildsCorruptedIndexes(t *testing.T) {
+	indexDir, err := ioutil.TempDir("", "mmbleve")
+	require.NoError(t, err)
+	defer os.RemoveAll(indexDir)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model.NewBool(true)
+	cfg.BleveSettings.IndexDir = model.NewString(indexDir)
+
+	engine := NewBleveEngine(cfg, nil)
+	engine.indexSync = true
+	require.Nil(t, engine.Start())
+	post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Message: "message", CreateAt: model.GetMillis()}
+	require.Nil(t, engine.IndexPost(post, model.NewId()))
+	require.Nil(t, engine.Stop())
+
+	t.Run("reopens the indexes", func(t *testing.T) {
+		require.Nil(t, engine.Start())
+		defer engine.Stop()
+
+		count, err := engine.PostIndex.DocCount()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+	})
+
+	t.Run("rebuilds a corrupted index", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(engine.getIndexDir(POST_INDEX), "index_meta.json"), []byte("corrupted"), 0600))
+
+		require.Nil(t, engine.Start())
+		defer engine.Stop()
+
+		count, err := engine.PostIndex.DocCount()
+		require.NoError(t, err)
+		require.Zero(t, count)
+		require.Nil(t, engine.IndexPost(post, model.NewId()))
+
+		count, err = engine.UserIndex.DocCount()
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
@@ -36,6 +36,17 @@ type BLVPost struct {
 	Attachments string
 }
 
+type BLVFile struct {
+	Id        string
+	CreatorId string
+	ChannelId string
+	PostId    string
+	CreateAt  int64
+	Name      string
+	Content   string
+	Extension string
+}
+
 func BLVChannelFromChannel(channel *model.Channel) *BLVChannel {
 	displayNameInputs := searchengine.GetSuggestionInputsSplitBy(channel.DisplayName, " ")
 	nameInputs := searchengine.GetSuggestionInputsSplitByMultiple(channel.Name, []string{"-", "_"})
@@ -114,3 +125,16 @@ func BLVPostFromPostForIndexing(post *model.PostForIndexing) *BLVPost {
 		Hashtags:  strings.Fields(post.Hashtags),
 	}
 }
+
+func BLVFileFromFileInfo(file *model.FileInfo, channelId string) *BLVFile {
+	return &BLVFile{
+		Id:        file.Id,
+		CreatorId: file.CreatorId,
+		ChannelId: channelId,
+		PostId:    file.PostId,
+		CreateAt:  file.CreateAt,
+		Name:      file.Name,
+		Content:   file.Content,
+		Extension: file.Extension,
+	}
+}
@@ -5,6 +5,7 @@ package ebleveengine
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -62,6 +63,7 @@ func (bi *BleveIndexerInterfaceImpl) MakeWorker() model.Worker {
 
 type IndexingProgress struct {
 	Now                time.Time
+	ResumedCount       int64
 	StartAtTime        int64
 	EndAtTime          int64
 	LastEntityTime     int64
@@ -84,6 +86,90 @@ func (ip *IndexingProgress) IsDone() bool {
 	return ip.DonePosts && ip.DoneChannels && ip.DoneUsers
 }
 
+// CurrentEntity returns the type of the entities being indexed.
+func (ip *IndexingProgress) CurrentEntity() string {
+	switch {
+	case !ip.DonePosts:
+		return "posts"
+	case !ip.DoneChannels:
+		return "channels"
+	case !ip.DoneUsers:
+		return "users"
+	}
+	return ""
+}
+
+// Rate returns the number of entities indexed per second since the job started or resumed.
+func (ip *IndexingProgress) Rate() float64 {
+	elapsed := time.Since(ip.Now).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(ip.DonePostsCount+ip.DoneChannelsCount+ip.DoneUsersCount-ip.ResumedCount) / elapsed
+}
+
+// SaveToJobData records the progress in the job data, so that it can be polled through the job
+// status and the job resumed from its last indexed batch if it gets interrupted.
+func (ip *IndexingProgress) SaveToJobData(job *model.Job) {
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+	job.Data["start_time"] = strconv.FormatInt(ip.StartAtTime, 10)
+	job.Data["end_time"] = strconv.FormatInt(ip.EndAtTime, 10)
+	job.Data["last_entity_time"] = strconv.FormatInt(ip.LastEntityTime, 10)
+	job.Data["entity"] = ip.CurrentEntity()
+	job.Data["rate"] = strconv.FormatFloat(ip.Rate(), 'f', 2, 64)
+	job.Data["total_posts_count"] = strconv.FormatInt(ip.TotalPostsCount, 10)
+	job.Data["done_posts_count"] = strconv.FormatInt(ip.DonePostsCount, 10)
+	job.Data["done_posts"] = strconv.FormatBool(ip.DonePosts)
+	job.Data["total_channels_count"] = strconv.FormatInt(ip.TotalChannelsCount, 10)
+	job.Data["done_channels_count"] = strconv.FormatInt(ip.DoneChannelsCount, 10)
+	job.Data["done_channels"] = strconv.FormatBool(ip.DoneChannels)
+	job.Data["total_users_count"] = strconv.FormatInt(ip.TotalUsersCount, 10)
+	job.Data["done_users_count"] = strconv.FormatInt(ip.DoneUsersCount, 10)
+	job.Data["done_users"] = strconv.FormatBool(ip.DoneUsers)
+}
+
+// IndexingProgressFromJobData restores the progress recorded by SaveToJobData.
+func IndexingProgressFromJobData(data map[string]string) (IndexingProgress, error) {
+	progress := IndexingProgress{Now: time.Now()}
+
+	ints := map[string]*int64{
+		"start_time":           &progress.StartAtTime,
+		"end_time":             &progress.EndAtTime,
+		"last_entity_time":     &progress.LastEntityTime,
+		"total_posts_count":    &progress.TotalPostsCount,
+		"done_posts_count":     &progress.DonePostsCount,
+		"total_channels_count": &progress.TotalChannelsCount,
+		"done_channels_count":  &progress.DoneChannelsCount,
+		"total_users_count":    &progress.TotalUsersCount,
+		"done_users_count":     &progress.DoneUsersCount,
+	}
+	for key, value := range ints {
+		parsed, err := strconv.ParseInt(data[key], 10, 64)
+		if err != nil {
+			return progress, fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		*value = parsed
+	}
+
+	bools := map[string]*bool{
+		"done_posts":    &progress.DonePosts,
+		"done_channels": &progress.DoneChannels,
+		"done_users":    &progress.DoneUsers,
+	}
+	for key, value := range bools {
+		parsed, err := strconv.ParseBool(data[key])
+		if err != nil {
+			return progress, fmt.Errorf("failed to parse %s: %w", key, err)
+		}
+		*value = parsed
+	}
+
+	progress.ResumedCount = progress.DonePostsCount + progress.DoneChannelsCount + progress.DoneUsersCount
+	return progress, nil
+}
+
 func (worker *BleveIndexerWorker) JobChannel() chan<- model.Job {
 	return worker.jobs
 }
@@ -134,6 +220,23 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 		return
 	}
 
+	// Pick up where a canceled or failed job left off. Each batch is committed to the index as a
+	// whole before the progress is recorded, so the index is consistent with the recorded progress.
+	if resumeJobId, ok := job.Data["resume_from_job_id"]; ok {
+		progress, appError := worker.resumeProgress(job, resumeJobId)
+		if appError != nil {
+			mlog.Error("Worker: Failed to resume job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.String("resume_from_job_id", resumeJobId), mlog.Err(appError))
+			if err := worker.jobServer.SetJobError(job, appError); err != nil {
+				mlog.Error("Worker: Failed to set job error", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err), mlog.NamedErr("set_error", appError))
+			}
+			return
+		}
+
+		mlog.Info("Worker: Resuming indexing job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.String("resume_from_job_id", resumeJobId), mlog.String("entity", progress.CurrentEntity()))
+		worker.indexBatches(job, progress)
+		return
+	}
+
 	progress := IndexingProgress{
 		Now:          time.Now(),
 		DonePosts:    false,
@@ -209,6 +312,30 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 		progress.TotalUsersCount = count
 	}
 
+	worker.indexBatches(job, progress)
+}
+
+func (worker *BleveIndexerWorker) resumeProgress(job *model.Job, resumeJobId string) (IndexingProgress, *model.AppError) {
+	resumeJob, err := worker.jobServer.Store.Job().Get(resumeJobId)
+	if err != nil {
+		return IndexingProgress{}, model.NewAppError("BleveIndexerWorker.resumeProgress", "bleveengine.indexer.do_job.resume.get_job.error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if resumeJob.Type != job.Type || (resumeJob.Status != model.JOB_STATUS_CANCELED && resumeJob.Status != model.JOB_STATUS_ERROR) {
+		return IndexingProgress{}, model.NewAppError("BleveIndexerWorker.resumeProgress", "bleveengine.indexer.do_job.resume.invalid_job.error", nil, "type="+resumeJob.Type+", status="+resumeJob.Status, http.StatusBadRequest)
+	}
+
+	progress, err := IndexingProgressFromJobData(resumeJob.Data)
+	if err != nil {
+		return IndexingProgress{}, model.NewAppError("BleveIndexerWorker.resumeProgress", "bleveengine.indexer.do_job.resume.parse_data.error", nil, err.Error(), http.StatusBadRequest)
+	}
+	return progress, nil
+}
+
+// indexBatches indexes the entities batch by batch until they're all indexed or the job is
+// canceled. The job is only interrupted between batches, and the progress is saved in the job
+// data after each of them.
+func (worker *BleveIndexerWorker) indexBatches(job *model.Job, progress IndexingProgress) {
 	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
 	cancelWatcherChan := make(chan interface{}, 1)
 	go worker.jobServer.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
@@ -241,6 +368,7 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 				return
 			}
 
+			progress.SaveToJobData(job)
 			if err := worker.jobServer.SetJobProgress(job, progress.CurrentProgress()); err != nil {
 				mlog.Error("Worker: Failed to set progress for job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
 				if err2 := worker.jobServer.SetJobError(job, err); err2 != nil {
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package ebleveengine
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestIndexingProgressJobData(t *testing.T) {
+	progress := IndexingProgress{
+		Now:                time.Now().Add(-10 * time.Second),
+		StartAtTime:        1000,
+		EndAtTime:          5000,
+		LastEntityTime:     2000,
+		TotalPostsCount:    300,
+		DonePostsCount:     300,
+		DonePosts:          true,
+		TotalChannelsCount: 50,
+		DoneChannelsCount:  20,
+		TotalUsersCount:    10,
+	}
+
+	job := &model.Job{Id: model.NewId()}
+	progress.SaveToJobData(job)
+
+	assert.Equal(t, "channels", job.Data["entity"])
+	assert.Equal(t, "300", job.Data["done_posts_count"])
+	assert.Equal(t, "2000", job.Data["last_entity_time"])
+	rate, err := strconv.ParseFloat(job.Data["rate"], 64)
+	require.Nil(t, err)
+	assert.InDelta(t, 32, rate, 1)
+
+	t.Run("resume", func(t *testing.T) {
+		resumed, err := IndexingProgressFromJobData(job.Data)
+		require.Nil(t, err)
+
+		assert.Equal(t, progress.StartAtTime, resumed.StartAtTime)
+		assert.Equal(t, progress.EndAtTime, resumed.EndAtTime)
+		assert.Equal(t, progress.LastEntityTime, resumed.LastEntityTime)
+		assert.Equal(t, progress.DonePostsCount, resumed.DonePostsCount)
+		assert.Equal(t, progress.DoneChannelsCount, resumed.DoneChannelsCount)
+		assert.Equal(t, progress.TotalUsersCount, resumed.TotalUsersCount)
+		assert.True(t, resumed.DonePosts)
+		assert.False(t, resumed.DoneChannels)
+		assert.False(t, resumed.DoneUsers)
+		assert.Equal(t, progress.CurrentProgress(), resumed.CurrentProgress())
+		assert.Equal(t, int64(320), resumed.ResumedCount)
+		assert.InDelta(t, 0, resumed.Rate(), 1)
+	})
+
+	t.Run("missing progress", func(t *testing.T) {
+		_, err := IndexingProgressFromJobData(map[string]string{"start_time": "1000"})
+		require.NotNil(t, err)
+	})
+}
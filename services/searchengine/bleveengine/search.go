@@ -28,14 +28,19 @@ func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError
 }
 
 func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
-	channelQueries := []query.Query{}
-	for _, channel := range *channels {
-		channelIdQ := bleve.NewTermQuery(channel.Id)
-		channelIdQ.SetField("ChannelId")
-		channelQueries = append(channelQueries, channelIdQ)
+	if channels == nil {
+		channels = &model.ChannelList{}
 	}
-	channelDisjunctionQ := bleve.NewDisjunctionQuery(channelQueries...)
+	return b.searchPosts("Bleveengine.SearchPosts", channels, searchParams, page, perPage)
+}
+
+func (b *BleveEngine) SearchPostsInAllChannels(searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	return b.searchPosts("Bleveengine.SearchPostsInAllChannels", nil, searchParams, page, perPage)
+}
 
+// searchPosts searches the posts of the given channels, or of all the channels if channels is
+// nil.
+func (b *BleveEngine) searchPosts(where string, channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
 	var termQueries []query.Query
 	var notTermQueries []query.Query
 	var filters []query.Query
@@ -174,6 +179,7 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 					messageQ := bleve.NewMatchQuery(strings.Join(terms, " "))
 					messageQ.SetField("Message")
 					messageQ.SetOperator(termOperator)
+					messageQ.SetFuzziness(params.Fuzziness)
 					termQueries = append(termQueries, messageQ)
 				}
 			}
@@ -195,8 +201,19 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 		allTermsQ.AddMust(termQueries...)
 	}
 
+	var channelQueries []query.Query
+	if channels != nil {
+		for _, channel := range *channels {
+			channelIdQ := bleve.NewTermQuery(channel.Id)
+			channelIdQ.SetField("ChannelId")
+			channelQueries = append(channelQueries, channelIdQ)
+		}
+	}
+
 	query := bleve.NewBooleanQuery()
-	query.AddMust(channelDisjunctionQ)
+	if channels != nil {
+		query.AddMust(bleve.NewDisjunctionQuery(channelQueries...))
+	}
 
 	if len(termQueries) > 0 || len(notTermQueries) > 0 {
 		query.AddMust(allTermsQ)
@@ -213,7 +230,7 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 	search.SortBy([]string{"-CreateAt"})
 	results, err := b.PostIndex.Search(search)
 	if err != nil {
-		return nil, nil, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
+		return nil, nil, model.NewAppError(where, "bleveengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
 	postIds := []string{}
@@ -226,23 +243,61 @@ func (b *BleveEngine) SearchPosts(channels *model.ChannelList, searchParams []*m
 	return postIds, matches, nil
 }
 
+func (b *BleveEngine) SuggestTerms(channels *model.ChannelList, prefix string, limit int) ([]string, *model.AppError) {
+	channelQueries := []query.Query{}
+	for _, channel := range *channels {
+		channelIdQ := bleve.NewTermQuery(channel.Id)
+		channelIdQ.SetField("ChannelId")
+		channelQueries = append(channelQueries, channelIdQ)
+	}
+
+	typeQ := bleve.NewTermQuery("")
+	typeQ.SetField("Type")
+
+	messageQ := bleve.NewPrefixQuery(strings.ToLower(prefix))
+	messageQ.SetField("Message")
+
+	query := bleve.NewConjunctionQuery(bleve.NewDisjunctionQuery(channelQueries...), typeQ, messageQ)
+
+	search := bleve.NewSearchRequestOptions(query, model.SEARCH_SUGGESTION_POSTS_SAMPLE_SIZE, 0, false)
+	search.SortBy([]string{"-CreateAt"})
+	search.Fields = []string{"Message"}
+	results, err := b.PostIndex.Search(search)
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.SuggestTerms", "bleveengine.suggest_terms.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	messages := []string{}
+	for _, r := range results.Hits {
+		if message, ok := r.Fields["Message"].(string); ok {
+			messages = append(messages, message)
+		}
+	}
+
+	return model.ExtractSuggestedTerms(messages, prefix, limit), nil
+}
+
 func (b *BleveEngine) deletePosts(searchRequest *bleve.SearchRequest, batchSize int) (int64, error) {
+	return b.deleteDocuments(b.PostIndex, searchRequest, batchSize)
+}
+
+func (b *BleveEngine) deleteDocuments(index bleve.Index, searchRequest *bleve.SearchRequest, batchSize int) (int64, error) {
 	resultsCount := int64(0)
 
 	for {
-		// As we are deleting the posts after fetching them, we need to keep
+		// As we are deleting the documents after fetching them, we need to keep
 		// From fixed always to 0
 		searchRequest.From = 0
 		searchRequest.Size = batchSize
-		results, err := b.PostIndex.Search(searchRequest)
+		results, err := index.Search(searchRequest)
 		if err != nil {
 			return -1, err
 		}
-		batch := b.PostIndex.NewBatch()
-		for _, post := range results.Hits {
-			batch.Delete(post.ID)
+		batch := index.NewBatch()
+		for _, doc := range results.Hits {
+			batch.Delete(doc.ID)
 		}
-		if err := b.PostIndex.Batch(batch); err != nil {
+		if err := index.Batch(batch); err != nil {
 			return -1, err
 		}
 		resultsCount += int64(results.Hits.Len())
@@ -507,3 +562,211 @@ func (b *BleveEngine) DeleteUser(user *model.User) *model.AppError {
 	}
 	return nil
 }
+
+func (b *BleveEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	blvFile := BLVFileFromFileInfo(file, channelId)
+	if err := b.FileIndex.Index(blvFile.Id, blvFile); err != nil {
+		return model.NewAppError("Bleveengine.IndexFile", "bleveengine.index_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// fileFieldsQuery matches the query built by newQuery against the name or the content of the files.
+func fileFieldsQuery(newQuery func(field string) query.Query) query.Query {
+	return bleve.NewDisjunctionQuery(newQuery("Name"), newQuery("Content"))
+}
+
+func (b *BleveEngine) SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	channelQueries := []query.Query{}
+	for _, channel := range *channels {
+		channelIdQ := bleve.NewTermQuery(channel.Id)
+		channelIdQ.SetField("ChannelId")
+		channelQueries = append(channelQueries, channelIdQ)
+	}
+	channelDisjunctionQ := bleve.NewDisjunctionQuery(channelQueries...)
+
+	var termQueries []query.Query
+	var notTermQueries []query.Query
+	var filters []query.Query
+	var notFilters []query.Query
+
+	var termOperator query.MatchQueryOperator = query.MatchQueryOperatorAnd
+	if searchParams[0].OrTerms {
+		termOperator = query.MatchQueryOperatorOr
+	}
+
+	newTermsQuery := func(terms string) func(field string) query.Query {
+		return func(field string) query.Query {
+			matchQ := bleve.NewMatchQuery(terms)
+			matchQ.SetField(field)
+			matchQ.SetOperator(termOperator)
+			return matchQ
+		}
+	}
+
+	newFieldTermQuery := func(field string, values []string) query.Query {
+		queries := []query.Query{}
+		for _, value := range values {
+			termQ := bleve.NewTermQuery(value)
+			termQ.SetField(field)
+			queries = append(queries, termQ)
+		}
+		return bleve.NewDisjunctionQuery(queries...)
+	}
+
+	for i, params := range searchParams {
+		// Channels, users and date filters are global to the query,
+		// so we only need to process them once
+		if i == 0 {
+			if len(params.InChannels) > 0 {
+				filters = append(filters, newFieldTermQuery("ChannelId", params.InChannels))
+			}
+			if len(params.ExcludedChannels) > 0 {
+				notFilters = append(notFilters, newFieldTermQuery("ChannelId", params.ExcludedChannels))
+			}
+			if len(params.FromUsers) > 0 {
+				filters = append(filters, newFieldTermQuery("CreatorId", params.FromUsers))
+			}
+			if len(params.ExcludedUsers) > 0 {
+				notFilters = append(notFilters, newFieldTermQuery("CreatorId", params.ExcludedUsers))
+			}
+
+			if params.OnDate != "" {
+				before, after := params.GetOnDateMillis()
+				beforef := float64(before)
+				afterf := float64(after)
+				onDateQ := bleve.NewNumericRangeQuery(&beforef, &afterf)
+				onDateQ.SetField("CreateAt")
+				filters = append(filters, onDateQ)
+			} else if params.AfterDate != "" || params.BeforeDate != "" {
+				var min, max *float64
+				if params.AfterDate != "" {
+					minf := float64(params.GetAfterDateMillis())
+					min = &minf
+				}
+				if params.BeforeDate != "" {
+					maxf := float64(params.GetBeforeDateMillis())
+					max = &maxf
+				}
+				dateQ := bleve.NewNumericRangeQuery(min, max)
+				dateQ.SetField("CreateAt")
+				filters = append(filters, dateQ)
+			}
+		}
+
+		// Files don't have hashtags
+		if params.IsHashtag {
+			continue
+		}
+
+		if len(params.Terms) > 0 {
+			terms := []string{}
+			for _, term := range strings.Split(params.Terms, " ") {
+				if strings.HasSuffix(term, "*") {
+					termQueries = append(termQueries, fileFieldsQuery(func(field string) query.Query {
+						wildcardQ := bleve.NewWildcardQuery(strings.ToLower(term))
+						wildcardQ.SetField(field)
+						return wildcardQ
+					}))
+				} else {
+					terms = append(terms, term)
+				}
+			}
+
+			if len(terms) > 0 {
+				termQueries = append(termQueries, fileFieldsQuery(newTermsQuery(strings.Join(terms, " "))))
+			}
+		}
+
+		if len(params.ExcludedTerms) > 0 {
+			notTermQueries = append(notTermQueries, fileFieldsQuery(newTermsQuery(params.ExcludedTerms)))
+		}
+	}
+
+	allTermsQ := bleve.NewBooleanQuery()
+	allTermsQ.AddMustNot(notTermQueries...)
+	if searchParams[0].OrTerms {
+		allTermsQ.AddShould(termQueries...)
+	} else {
+		allTermsQ.AddMust(termQueries...)
+	}
+
+	query := bleve.NewBooleanQuery()
+	query.AddMust(channelDisjunctionQ)
+
+	if len(termQueries) > 0 || len(notTermQueries) > 0 {
+		query.AddMust(allTermsQ)
+	}
+
+	if len(filters) > 0 {
+		query.AddMust(bleve.NewConjunctionQuery(filters...))
+	}
+	if len(notFilters) > 0 {
+		query.AddMustNot(notFilters...)
+	}
+
+	search := bleve.NewSearchRequestOptions(query, perPage, page*perPage, false)
+	search.SortBy([]string{"-CreateAt"})
+	results, err := b.FileIndex.Search(search)
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.SearchFiles", "bleveengine.search_files.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	fileIds := []string{}
+	for _, r := range results.Hits {
+		fileIds = append(fileIds, r.ID)
+	}
+
+	return fileIds, nil
+}
+
+func (b *BleveEngine) DeleteFile(fileID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if err := b.FileIndex.Delete(fileID); err != nil {
+		return model.NewAppError("Bleveengine.DeleteFile", "bleveengine.delete_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (b *BleveEngine) DeletePostFiles(postID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	query := bleve.NewTermQuery(postID)
+	query.SetField("PostId")
+	search := bleve.NewSearchRequest(query)
+	deleted, err := b.deleteDocuments(b.FileIndex, search, DELETE_POSTS_BATCH_SIZE)
+	if err != nil {
+		return model.NewAppError("Bleveengine.DeletePostFiles",
+			"bleveengine.delete_post_files.error", nil,
+			err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Files for post deleted", mlog.String("post_id", postID), mlog.Int64("deleted", deleted))
+
+	return nil
+}
+
+func (b *BleveEngine) DeleteUserFiles(userID string) *model.AppError {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	query := bleve.NewTermQuery(userID)
+	query.SetField("CreatorId")
+	search := bleve.NewSearchRequest(query)
+	deleted, err := b.deleteDocuments(b.FileIndex, search, DELETE_POSTS_BATCH_SIZE)
+	if err != nil {
+		return model.NewAppError("Bleveengine.DeleteUserFiles",
+			"bleveengine.delete_user_files.error", nil,
+			err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Files for user deleted", mlog.String("user_id", userID), mlog.Int64("deleted", deleted))
+
+	return nil
+}
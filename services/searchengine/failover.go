@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// ErrSkipEngine is returned by the functions searching an engine for Broker.Search when the
+// engine can't run the search, to try the next one without reporting an error.
+var ErrSkipEngine = errors.New("the search engine can't run this search")
+
+// ErrNoSearchEngine is returned by Broker.Search when none of the engines of the failover order
+// served the results.
+var ErrNoSearchEngine = errors.New("no search engine is available")
+
+// failoverOrder returns the names of the engines to search in order, the database included.
+func (seb *Broker) failoverOrder() []string {
+	if seb.cfg != nil && len(seb.cfg.SearchSettings.FailoverOrder) > 0 {
+		return seb.cfg.SearchSettings.FailoverOrder
+	}
+
+	order := []string{}
+	if engine := seb.ConfiguredEngine(); engine != nil {
+		order = append(order, engine.GetName())
+	}
+	return append(order, model.SEARCH_ENGINE_BLEVE, model.SEARCH_ENGINE_DATABASE)
+}
+
+// isKnownDown returns whether the last health probe of the engine failed to reach it. The engines
+// that were never probed are assumed to be up.
+func (seb *Broker) isKnownDown(name string) bool {
+	health, ok := seb.HealthStatus()[name]
+	return ok && !health.Reachable
+}
+
+// GetSearchEngines returns the active engines with the search enabled in the failover order,
+// without those that failed their last health probe.
+func (seb *Broker) GetSearchEngines() []SearchEngineInterface {
+	engines := []SearchEngineInterface{}
+	for _, name := range seb.failoverOrder() {
+		if engine := seb.activeEngine(name); engine != nil && engine.IsSearchEnabled() && !seb.isKnownDown(name) {
+			engines = append(engines, engine)
+		}
+	}
+	return engines
+}
+
+func (seb *Broker) activeEngine(name string) SearchEngineInterface {
+	for _, engine := range seb.GetActiveEngines() {
+		if engine.GetName() == name {
+			return engine
+		}
+	}
+	return nil
+}
+
+// Search runs a search against the engines of SearchSettings.FailoverOrder in turn until one of
+// them succeeds, calling database at the position of the database in the order. It returns the
+// name of the engine that served the results, model.SEARCH_ENGINE_DATABASE for the database, or
+// ErrNoSearchEngine once they all failed. The failures are logged under the operation name.
+func (seb *Broker) Search(operation string, search func(engine SearchEngineInterface) error, database func() error) (string, error) {
+	engines := seb.GetSearchEngines()
+	for _, name := range seb.failoverOrder() {
+		if name == model.SEARCH_ENGINE_DATABASE {
+			if err := database(); err != nil {
+				return "", err
+			}
+			mlog.Debug("Using database search because no other search engine is available", mlog.String("operation", operation))
+			return name, nil
+		}
+
+		for _, engine := range engines {
+			if engine.GetName() != name {
+				continue
+			}
+			if err := search(engine); err != nil {
+				if err != ErrSkipEngine {
+					mlog.Error("Encountered error on "+operation+".", mlog.String("search_engine", name), mlog.Err(err))
+				}
+				break
+			}
+			mlog.Debug("Using the first available search engine", mlog.String("search_engine", name), mlog.String("operation", operation))
+			return name, nil
+		}
+	}
+
+	return "", ErrNoSearchEngine
+}
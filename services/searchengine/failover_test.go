@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+)
+
+func TestBrokerSearch(t *testing.T) {
+	newEngine := func(name string) *mocks.SearchEngineInterface {
+		engine := &mocks.SearchEngineInterface{}
+		engine.On("GetName").Return(name)
+		engine.On("IsActive").Return(true)
+		engine.On("IsSearchEnabled").Return(true)
+		engine.On("UpdateConfig", mock.Anything).Return()
+		engine.On("TestConfig", mock.Anything).Return(nil).Maybe()
+		return engine
+	}
+
+	setup := func(failoverOrder ...string) (*Broker, *mocks.SearchEngineInterface, *mocks.SearchEngineInterface) {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+		cfg.SearchSettings.FailoverOrder = failoverOrder
+
+		es := newEngine(model.SEARCH_BACKEND_ELASTICSEARCH)
+		bleve := newEngine(model.SEARCH_ENGINE_BLEVE)
+
+		broker := NewBroker(cfg, nil)
+		broker.RegisterElasticsearchEngine(es)
+		broker.RegisterBleveEngine(bleve)
+		return broker, es, bleve
+	}
+
+	// search records the engines it's run against, failing for those of failing.
+	search := func(searched *[]string, failing ...string) func(SearchEngineInterface) error {
+		return func(engine SearchEngineInterface) error {
+			*searched = append(*searched, engine.GetName())
+			for _, name := range failing {
+				if name == engine.GetName() {
+					return errors.New("injected failure")
+				}
+			}
+			return nil
+		}
+	}
+	database := func(searched *[]string, err error) func() error {
+		return func() error {
+			*searched = append(*searched, model.SEARCH_ENGINE_DATABASE)
+			return err
+		}
+	}
+
+	t.Run("defaults to the configured engine, then bleve, then the database", func(t *testing.T) {
+		broker, _, _ := setup()
+
+		var searched []string
+		served, err := broker.Search("Test", search(&searched), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_BACKEND_ELASTICSEARCH, served)
+		assert.Equal(t, []string{model.SEARCH_BACKEND_ELASTICSEARCH}, searched)
+
+		searched = nil
+		served, err = broker.Search("Test", search(&searched, model.SEARCH_BACKEND_ELASTICSEARCH), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_BLEVE, served)
+		assert.Equal(t, []string{model.SEARCH_BACKEND_ELASTICSEARCH, model.SEARCH_ENGINE_BLEVE}, searched)
+
+		searched = nil
+		served, err = broker.Search("Test", search(&searched, model.SEARCH_BACKEND_ELASTICSEARCH, model.SEARCH_ENGINE_BLEVE), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_DATABASE, served)
+		assert.Equal(t, []string{model.SEARCH_BACKEND_ELASTICSEARCH, model.SEARCH_ENGINE_BLEVE, model.SEARCH_ENGINE_DATABASE}, searched)
+	})
+
+	t.Run("follows the failover order", func(t *testing.T) {
+		broker, _, _ := setup(model.SEARCH_ENGINE_BLEVE, model.SEARCH_ENGINE_DATABASE, model.SEARCH_BACKEND_ELASTICSEARCH)
+
+		var searched []string
+		served, err := broker.Search("Test", search(&searched, model.SEARCH_ENGINE_BLEVE), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_DATABASE, served)
+		assert.Equal(t, []string{model.SEARCH_ENGINE_BLEVE, model.SEARCH_ENGINE_DATABASE}, searched)
+	})
+
+	t.Run("leaves out the engines that aren't listed", func(t *testing.T) {
+		broker, _, _ := setup(model.SEARCH_BACKEND_ELASTICSEARCH)
+
+		var searched []string
+		_, err := broker.Search("Test", search(&searched, model.SEARCH_BACKEND_ELASTICSEARCH), database(&searched, nil))
+		assert.Equal(t, ErrNoSearchEngine, err)
+		assert.Equal(t, []string{model.SEARCH_BACKEND_ELASTICSEARCH}, searched)
+	})
+
+	t.Run("skips the engines unable to run the search", func(t *testing.T) {
+		broker, _, _ := setup()
+
+		var searched []string
+		served, err := broker.Search("Test", func(engine SearchEngineInterface) error {
+			if engine.GetName() == model.SEARCH_BACKEND_ELASTICSEARCH {
+				return ErrSkipEngine
+			}
+			return search(&searched)(engine)
+		}, database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_BLEVE, served)
+		assert.Equal(t, []string{model.SEARCH_ENGINE_BLEVE}, searched)
+	})
+
+	t.Run("returns the error of the database", func(t *testing.T) {
+		broker, _, _ := setup()
+		dbErr := errors.New("database failure")
+
+		var searched []string
+		_, err := broker.Search("Test", search(&searched, model.SEARCH_BACKEND_ELASTICSEARCH, model.SEARCH_ENGINE_BLEVE), database(&searched, dbErr))
+		assert.Equal(t, dbErr, err)
+	})
+
+	t.Run("skips the engines known to be down", func(t *testing.T) {
+		broker, es, _ := setup()
+		es.ExpectedCalls = nil
+		es.On("GetName").Return(model.SEARCH_BACKEND_ELASTICSEARCH)
+		es.On("IsActive").Return(true)
+		es.On("IsSearchEnabled").Return(true)
+		es.On("TestConfig", mock.Anything).Return(model.NewAppError("TestConfig", "unreachable", nil, "", http.StatusInternalServerError))
+		broker.CheckHealth()
+
+		var searched []string
+		served, err := broker.Search("Test", search(&searched), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_BLEVE, served)
+		assert.Equal(t, []string{model.SEARCH_ENGINE_BLEVE}, searched)
+
+		engines := broker.GetSearchEngines()
+		require.Len(t, engines, 1)
+		assert.Equal(t, model.SEARCH_ENGINE_BLEVE, engines[0].GetName())
+	})
+
+	t.Run("skips the engines with the search disabled", func(t *testing.T) {
+		broker, _, bleve := setup()
+		bleve.ExpectedCalls = nil
+		bleve.On("GetName").Return(model.SEARCH_ENGINE_BLEVE)
+		bleve.On("IsActive").Return(true)
+		bleve.On("IsSearchEnabled").Return(false)
+
+		var searched []string
+		served, err := broker.Search("Test", search(&searched, model.SEARCH_BACKEND_ELASTICSEARCH), database(&searched, nil))
+		require.NoError(t, err)
+		assert.Equal(t, model.SEARCH_ENGINE_DATABASE, served)
+		assert.Equal(t, []string{model.SEARCH_BACKEND_ELASTICSEARCH, model.SEARCH_ENGINE_DATABASE}, searched)
+	})
+}
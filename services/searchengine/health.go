@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// EngineHealth is the outcome of the last probe of a search engine.
+type EngineHealth struct {
+	Engine    string
+	Reachable bool
+	// Latency is how long the last probe took.
+	Latency time.Duration
+	// LastError is the error of the last failed probe, kept after the engine recovers. It's
+	// empty if no probe ever failed.
+	LastError string
+	// CheckedAt is the time of the last probe, in milliseconds.
+	CheckedAt int64
+	// MappingVersions holds the versions of the mappings of the indexes, for the engines
+	// reporting them.
+	MappingVersions []IndexMappingVersion
+}
+
+// StartHealthChecks starts probing the active engines in the background, right away and then
+// every SearchSettings.HealthCheckIntervalSeconds. Probing doesn't change which engines serve
+// the searches, it's only reported through HealthStatus.
+func (seb *Broker) StartHealthChecks() {
+	seb.healthMutex.Lock()
+	defer seb.healthMutex.Unlock()
+
+	if seb.healthStop != nil {
+		return
+	}
+	seb.healthStop = make(chan struct{})
+	seb.healthDone = make(chan struct{})
+
+	go seb.runHealthChecks(seb.healthStop, seb.healthDone)
+}
+
+// StopHealthChecks stops the background probes and waits for the running one to finish.
+func (seb *Broker) StopHealthChecks() {
+	seb.healthMutex.Lock()
+	stop, done := seb.healthStop, seb.healthDone
+	seb.healthStop, seb.healthDone = nil, nil
+	seb.healthMutex.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (seb *Broker) runHealthChecks(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		seb.CheckHealth()
+
+		// The interval is read before every wait so that config changes apply to the next probe.
+		timer := time.NewTimer(seb.healthCheckInterval())
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (seb *Broker) healthCheckInterval() time.Duration {
+	interval := model.SEARCH_SETTINGS_DEFAULT_HEALTH_CHECK_INTERVAL_SECONDS
+	if seb.cfg != nil && seb.cfg.SearchSettings.HealthCheckIntervalSeconds != nil {
+		interval = *seb.cfg.SearchSettings.HealthCheckIntervalSeconds
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// CheckHealth probes the active engines now, replacing the results of the previous probes. The
+// engines that are no longer active are dropped from the health status.
+func (seb *Broker) CheckHealth() {
+	engines := seb.GetActiveEngines()
+
+	probed := make(map[string]EngineHealth, len(engines))
+	for _, engine := range engines {
+		name := engine.GetName()
+		start := time.Now()
+		appErr := engine.TestConfig(seb.cfg)
+
+		health := EngineHealth{
+			Engine:    name,
+			Reachable: appErr == nil,
+			Latency:   time.Since(start),
+			CheckedAt: model.GetMillis(),
+		}
+		if appErr != nil {
+			health.LastError = appErr.Error()
+		} else {
+			health.LastError = seb.HealthStatus()[name].LastError
+		}
+		if reporter, ok := engine.(MappingVersionReporter); ok {
+			health.MappingVersions = reporter.GetIndexMappingVersions()
+		}
+		probed[name] = health
+	}
+
+	seb.healthMutex.Lock()
+	seb.health = probed
+	seb.healthMutex.Unlock()
+}
+
+// HealthStatus returns the outcome of the last probe of every active engine, by engine name. It's
+// empty until the first probe completes.
+func (seb *Broker) HealthStatus() map[string]EngineHealth {
+	seb.healthMutex.RLock()
+	defer seb.healthMutex.RUnlock()
+
+	status := make(map[string]EngineHealth, len(seb.health))
+	for name, health := range seb.health {
+		status[name] = health
+	}
+	return status
+}
+
+// IsSearchDegraded returns whether the last probe failed to reach any of the active engines.
+func (seb *Broker) IsSearchDegraded() bool {
+	for _, health := range seb.HealthStatus() {
+		if !health.Reachable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+)
+
+func TestBrokerHealthStatus(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	newEngine := func(name string) *mocks.SearchEngineInterface {
+		engine := &mocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(true)
+		engine.On("GetName").Return(name)
+		return engine
+	}
+	connectErr := model.NewAppError("TestConfig", "ent.elasticsearch.test_config.connect_failed", nil, "", http.StatusInternalServerError)
+
+	t.Run("is empty before the first probe", func(t *testing.T) {
+		broker := NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(newEngine("bleve"))
+
+		assert.Empty(t, broker.HealthStatus())
+		assert.False(t, broker.IsSearchDegraded())
+	})
+
+	t.Run("reports every engine separately", func(t *testing.T) {
+		es := newEngine("elasticsearch")
+		es.On("TestConfig", mock.Anything).Return(connectErr)
+		bleve := newEngine("bleve")
+		bleve.On("TestConfig", mock.Anything).Return(nil)
+
+		broker := NewBroker(cfg, nil)
+		broker.RegisterElasticsearchEngine(es)
+		broker.RegisterBleveEngine(bleve)
+		broker.CheckHealth()
+
+		status := broker.HealthStatus()
+		require.Len(t, status, 2)
+		assert.False(t, status["elasticsearch"].Reachable)
+		assert.Equal(t, connectErr.Error(), status["elasticsearch"].LastError)
+		assert.True(t, status["bleve"].Reachable)
+		assert.Empty(t, status["bleve"].LastError)
+		assert.NotZero(t, status["bleve"].CheckedAt)
+		assert.True(t, broker.IsSearchDegraded())
+	})
+
+	t.Run("keeps the last error once the engine recovers", func(t *testing.T) {
+		es := newEngine("elasticsearch")
+		es.On("TestConfig", mock.Anything).Return(connectErr).Once()
+		es.On("TestConfig", mock.Anything).Return(nil)
+
+		broker := NewBroker(cfg, nil)
+		broker.RegisterElasticsearchEngine(es)
+		broker.CheckHealth()
+		broker.CheckHealth()
+
+		health := broker.HealthStatus()["elasticsearch"]
+		assert.True(t, health.Reachable)
+		assert.Equal(t, connectErr.Error(), health.LastError)
+		assert.False(t, broker.IsSearchDegraded())
+	})
+
+	t.Run("probes in the background until stopped", func(t *testing.T) {
+		bleve := newEngine("bleve")
+		bleve.On("TestConfig", mock.Anything).Return(nil)
+
+		broker := NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(bleve)
+		broker.StartHealthChecks()
+		broker.StartHealthChecks()
+
+		require.Eventually(t, func() bool {
+			return broker.HealthStatus()["bleve"].Reachable
+		}, time.Second, 10*time.Millisecond)
+
+		broker.StopHealthChecks()
+		broker.StopHealthChecks()
+		bleve.AssertNumberOfCalls(t, "TestConfig", 1)
+	})
+
+	t.Run("reports the mapping versions of the indexes", func(t *testing.T) {
+		versions := []IndexMappingVersion{{Index: "posts", Current: 1, Expected: 2, Migrating: true}}
+		es := &mappingVersionEngine{newEngine("elasticsearch"), versions}
+		es.On("TestConfig", mock.Anything).Return(nil)
+
+		broker := NewBroker(cfg, nil)
+		broker.RegisterElasticsearchEngine(es)
+		broker.CheckHealth()
+
+		assert.Equal(t, versions, broker.HealthStatus()["elasticsearch"].MappingVersions)
+	})
+}
+
+type mappingVersionEngine struct {
+	*mocks.SearchEngineInterface
+	versions []IndexMappingVersion
+}
+
+func (e *mappingVersionEngine) GetIndexMappingVersions() []IndexMappingVersion {
+	return e.versions
+}
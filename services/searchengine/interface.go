@@ -32,8 +32,72 @@ type SearchEngineInterface interface {
 	SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError)
 	SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError)
 	DeleteUser(user *model.User) *model.AppError
+	IndexFile(file *model.FileInfo, channelId string) *model.AppError
+	SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError)
+	DeleteFile(fileID string) *model.AppError
+	DeletePostFiles(postID string) *model.AppError
+	DeleteUserFiles(userID string) *model.AppError
 	TestConfig(cfg *model.Config) *model.AppError
 	PurgeIndexes() *model.AppError
 	RefreshIndexes() *model.AppError
 	DataRetentionDeleteIndexes(cutoff time.Time) *model.AppError
 }
+
+// PostHighlighter is implemented by the engines able to return highlighted fragments of the
+// posts matching a search.
+type PostHighlighter interface {
+	SearchPostsWithHighlights(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, model.PostSearchHighlights, *model.AppError)
+}
+
+// PostCursorSearcher is implemented by the engines able to page through the posts matching a
+// search with a cursor rather than an offset, so that the posts indexed in the meantime don't
+// shift the pages. The first page is returned for a nil searchAfter, and the cursor returned
+// along the posts is nil once there are no more.
+type PostCursorSearcher interface {
+	SearchPostsAfter(channels *model.ChannelList, searchParams []*model.SearchParams, searchAfter *model.PostSearchCursor, perPage int) ([]string, model.PostSearchMatches, *model.PostSearchCursor, *model.AppError)
+}
+
+// TermSuggester is implemented by the engines able to complete the terms used in the recent
+// posts of a set of channels.
+type TermSuggester interface {
+	SuggestTerms(channels *model.ChannelList, prefix string, limit int) ([]string, *model.AppError)
+}
+
+// AllChannelsPostSearcher is implemented by the engines able to search the posts of all the
+// channels at once, whatever their team. Only authorized callers should get to use it.
+type AllChannelsPostSearcher interface {
+	SearchPostsInAllChannels(searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError)
+}
+
+// DocumentFinder is implemented by the engines whose documents only become searchable some
+// time after being indexed, to tell whether a document can be found yet.
+type DocumentFinder interface {
+	IsDocumentSearchable(id string) (bool, *model.AppError)
+}
+
+// IndexMappingVersion is the version of the mapping of an index, against the version expected by
+// the server.
+type IndexMappingVersion struct {
+	Index    string
+	Current  int
+	Expected int
+	// Migrating is set while the documents are copied into a new index with the expected mapping.
+	Migrating bool
+	// Shards is the number of shards of the index, against the number configured. The indexes
+	// must be purged and rebuilt for the configured number to apply when they differ.
+	Shards         int
+	ExpectedShards int
+}
+
+// MappingVersionReporter is implemented by the engines migrating their indexes to the new
+// mappings on startup, to report the versions of the mappings of their indexes.
+type MappingVersionReporter interface {
+	GetIndexMappingVersions() []IndexMappingVersion
+}
+
+// RefreshIntervalSetter is implemented by the engines whose indexes are refreshed periodically
+// for the documents to become searchable, to change how often they are, such as to disable the
+// refresh during a bulk indexing job.
+type RefreshIntervalSetter interface {
+	SetRefreshInterval(interval string) *model.AppError
+}
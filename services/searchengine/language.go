@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+// stopWords are the most frequent words of the languages written in the Latin script, which
+// tell them apart in short messages better than their letters do.
+var stopWords = map[string]map[string]bool{
+	"de": wordSet("der die das und ist nicht ich mit ein eine zu den auf sich auch wir"),
+	"en": wordSet("the and is are of to in with that this for it you was have we"),
+	"es": wordSet("el los las y es una por para con que del pero se está muy estoy"),
+	"fr": wordSet("le les et est une des pas je vous dans pour qui avec sur nous ce"),
+}
+
+func wordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// DetectLanguage returns the language of the text among the given languages of
+// model.SearchLanguages, or an empty string if it doesn't recognizably use any of them.
+//
+// The CJK languages and Russian are detected by their scripts. Kanji without kana is taken for
+// Chinese, or for Japanese when only Japanese is allowed. The other languages are told apart by
+// their most frequent words, so that a message too short to contain any is left undetected.
+func DetectLanguage(text string, languages []string) string {
+	var kana, hangul, han, cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	allowed := func(language string) bool {
+		return utils.StringInSlice(language, languages)
+	}
+	switch {
+	case kana > 0 && allowed("ja"):
+		return "ja"
+	case hangul > 0 && allowed("ko"):
+		return "ko"
+	case han > 0 && allowed("zh"):
+		return "zh"
+	case han > 0 && allowed("ja"):
+		return "ja"
+	case cyrillic > latin && allowed("ru"):
+		return "ru"
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	detected := ""
+	best := 0
+	for _, language := range languages {
+		set, ok := stopWords[language]
+		if !ok {
+			continue
+		}
+
+		count := 0
+		for _, word := range words {
+			if set[word] {
+				count++
+			}
+		}
+		if count > best {
+			detected, best = language, count
+		} else if count == best {
+			// A tie doesn't tell the languages apart.
+			detected = ""
+		}
+	}
+
+	return detected
+}
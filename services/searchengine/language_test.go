@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/utils"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Text      string
+		Languages []string
+		Expected  string
+	}{
+		{"Japanese with kana", "明日の会議は10時からです", []string{"ja", "zh"}, "ja"},
+		{"Kanji only with Chinese allowed", "东京会议", []string{"ja", "zh"}, "zh"},
+		{"Kanji only with Japanese allowed", "東京会議", []string{"ja", "de"}, "ja"},
+		{"Korean", "내일 회의가 있습니다", model.SearchLanguages, "ko"},
+		{"Russian", "Встреча завтра в десять", model.SearchLanguages, "ru"},
+		{"German", "Ich habe die Datei nicht gefunden", model.SearchLanguages, "de"},
+		{"English", "The build is broken on the main branch", model.SearchLanguages, "en"},
+		{"French", "Je ne trouve pas le fichier dans le dossier", model.SearchLanguages, "fr"},
+		{"Spanish", "No encuentro el archivo para la reunión", model.SearchLanguages, "es"},
+		{"Language not allowed", "Ich habe die Datei nicht gefunden", []string{"en", "ja"}, ""},
+		{"Too short to tell", "Datei", model.SearchLanguages, ""},
+		{"No languages", "明日の会議は10時からです", nil, ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.Expected, DetectLanguage(testCase.Text, testCase.Languages))
+		})
+	}
+}
+
+func TestDetectLanguageSupportsSearchLanguages(t *testing.T) {
+	scriptLanguages := []string{"ja", "ko", "ru", "zh"}
+	for _, language := range model.SearchLanguages {
+		_, hasStopWords := stopWords[language]
+		assert.True(t, hasStopWords || utils.StringInSlice(language, scriptLanguages), "no way to detect %s", language)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	BULK_REQUEST_SIZE            = 100
+	BULK_MAX_RETRIES             = 8
+	BULK_INITIAL_BACKOFF         = time.Second
+	BULK_MAX_BACKOFF             = time.Minute
+	BULK_THROUGHPUT_LOG_INTERVAL = 30 * time.Second
+)
+
+// BulkIndexer sends the documents of a bulk indexing job to OpenSearch in requests of
+// BULK_REQUEST_SIZE documents, limiting how many of them are in flight at once. The limit is
+// halved whenever OpenSearch rejects documents because it's overloaded, and raised back one
+// request at a time up to SearchSettings.BulkIndexingMaxInFlightRequests as requests succeed,
+// so that indexing runs as fast as the cluster can take it. Rejected documents are retried
+// after a backoff rather than dropped.
+type BulkIndexer struct {
+	engine         *OpenSearchEngine
+	maxInFlight    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mutex         sync.Mutex
+	cond          *sync.Cond
+	limit         int
+	inFlight      int
+	indexed       int64
+	loggedIndexed int64
+	lastLog       time.Time
+}
+
+func (e *OpenSearchEngine) NewBulkIndexer() *BulkIndexer {
+	maxInFlight := *e.cfg.SearchSettings.BulkIndexingMaxInFlightRequests
+	b := &BulkIndexer{
+		engine:         e,
+		maxInFlight:    maxInFlight,
+		initialBackoff: BULK_INITIAL_BACKOFF,
+		maxBackoff:     BULK_MAX_BACKOFF,
+		limit:          maxInFlight,
+		lastLog:        time.Now(),
+	}
+	b.cond = sync.NewCond(&b.mutex)
+	return b
+}
+
+func (b *BulkIndexer) IndexPosts(posts []*model.PostForIndexing) *model.AppError {
+	indexes := b.engine.getWriteIndexNames(POST_INDEX)
+	languages := analyzedLanguages(b.engine.cfg.SearchSettings.LanguageAnalyzers)
+	actions := make([]bulkAction, 0, len(posts))
+	for _, post := range posts {
+		var document interface{}
+		if post.DeleteAt == 0 {
+			document = OSPostFromPost(&post.Post, post.TeamId, languages)
+		}
+		actions = appendBulkActions(actions, indexes, post.Id, document)
+	}
+
+	return b.index("BulkIndexer.IndexPosts", "opensearchengine.bulk_index_posts.error", actions)
+}
+
+func (b *BulkIndexer) IndexChannels(channels []*model.Channel) *model.AppError {
+	indexes := b.engine.getWriteIndexNames(CHANNEL_INDEX)
+	actions := make([]bulkAction, 0, len(channels))
+	for _, channel := range channels {
+		var document interface{}
+		if channel.DeleteAt == 0 {
+			document = OSChannelFromChannel(channel)
+		}
+		actions = appendBulkActions(actions, indexes, channel.Id, document)
+	}
+
+	return b.index("BulkIndexer.IndexChannels", "opensearchengine.bulk_index_channels.error", actions)
+}
+
+func (b *BulkIndexer) IndexUsers(users []*model.UserForIndexing) *model.AppError {
+	indexes := b.engine.getWriteIndexNames(USER_INDEX)
+	actions := make([]bulkAction, 0, len(users))
+	for _, user := range users {
+		var document interface{}
+		if user.DeleteAt == 0 {
+			document = OSUserFromUserAndTeams(&model.User{
+				Id:        user.Id,
+				Username:  user.Username,
+				Nickname:  user.Nickname,
+				FirstName: user.FirstName,
+				LastName:  user.LastName,
+			}, user.TeamsIds, user.ChannelsIds)
+		}
+		actions = appendBulkActions(actions, indexes, user.Id, document)
+	}
+
+	return b.index("BulkIndexer.IndexUsers", "opensearchengine.bulk_index_users.error", actions)
+}
+
+// appendBulkActions appends the actions indexing the document in each of the indexes, or
+// deleting it if the document is nil.
+func appendBulkActions(actions []bulkAction, indexes []string, id string, document interface{}) []bulkAction {
+	for _, index := range indexes {
+		actions = append(actions, bulkAction{Index: index, Id: id, Document: document})
+	}
+	return actions
+}
+
+// index sends the actions concurrently, returning once all of them are applied.
+func (b *BulkIndexer) index(where, errorId string, actions []bulkAction) *model.AppError {
+	b.engine.Mutex.RLock()
+	client := b.engine.client
+	b.engine.Mutex.RUnlock()
+
+	if client == nil {
+		return notStartedError(where)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(actions)/BULK_REQUEST_SIZE+1)
+	for start := 0; start < len(actions); start += BULK_REQUEST_SIZE {
+		end := start + BULK_REQUEST_SIZE
+		if end > len(actions) {
+			end = len(actions)
+		}
+
+		wg.Add(1)
+		go func(actions []bulkAction) {
+			defer wg.Done()
+			errs <- b.sendWithRetries(client, actions)
+		}(actions[start:end])
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return model.NewAppError(where, errorId, nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+	return nil
+}
+
+// sendWithRetries sends the actions, retrying those rejected by OpenSearch with an exponential
+// backoff.
+func (b *BulkIndexer) sendWithRetries(client *client, actions []bulkAction) error {
+	backoff := b.initialBackoff
+	for attempt := 0; ; attempt++ {
+		b.acquire()
+		rejected, err := sendBulk(client, actions)
+		b.release(err == nil && len(rejected) == 0)
+		if err != nil {
+			return err
+		}
+
+		b.recordIndexed(len(actions) - len(rejected))
+		if len(rejected) == 0 {
+			return nil
+		}
+		if attempt == BULK_MAX_RETRIES {
+			return errors.Errorf("%d documents were still rejected after %d retries", len(rejected), BULK_MAX_RETRIES)
+		}
+
+		mlog.Warn("OpenSearch rejected documents of a bulk indexing request. Retrying.", mlog.Int("rejected", len(rejected)), mlog.Duration("backoff", backoff))
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > b.maxBackoff {
+			backoff = b.maxBackoff
+		}
+		actions = rejected
+	}
+}
+
+// sendBulk sends the actions in a single request, returning those that OpenSearch rejected
+// because it's overloaded.
+func sendBulk(client *client, actions []bulkAction) ([]bulkAction, error) {
+	response, err := client.bulk(actions)
+	if isTooManyRequests(err) {
+		return actions, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !response.Errors {
+		return nil, nil
+	}
+
+	var rejected []bulkAction
+	for i, item := range response.Items {
+		for operation, result := range item {
+			switch {
+			case result.Status == http.StatusTooManyRequests:
+				rejected = append(rejected, actions[i])
+			case operation == "delete" && result.Status == http.StatusNotFound:
+			case result.Status < 200 || result.Status >= 300:
+				return nil, errors.Errorf("failed to %s document %s: %s", operation, actions[i].Id, result.Error)
+			}
+		}
+	}
+	return rejected, nil
+}
+
+// acquire waits until fewer requests than the current limit are in flight.
+func (b *BulkIndexer) acquire() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for b.inFlight >= b.limit {
+		b.cond.Wait()
+	}
+	b.inFlight++
+}
+
+// release ends a request, raising the limit if all of its documents were accepted and halving
+// it otherwise.
+func (b *BulkIndexer) release(accepted bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.inFlight--
+	if accepted {
+		if b.limit < b.maxInFlight {
+			b.limit++
+		}
+	} else if b.limit > 1 {
+		b.limit /= 2
+	}
+	b.cond.Broadcast()
+}
+
+// recordIndexed counts the indexed documents, logging the throughput every
+// BULK_THROUGHPUT_LOG_INTERVAL.
+func (b *BulkIndexer) recordIndexed(count int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.indexed += int64(count)
+
+	elapsed := time.Since(b.lastLog)
+	if elapsed < BULK_THROUGHPUT_LOG_INTERVAL {
+		return
+	}
+
+	rate := float64(b.indexed-b.loggedIndexed) / elapsed.Seconds()
+	mlog.Info("OpenSearch bulk indexing throughput", mlog.Int64("indexed", b.indexed), mlog.String("rate", fmt.Sprintf("%.2f/s", rate)), mlog.Int("max_in_flight_requests", b.limit))
+	b.loggedIndexed = b.indexed
+	b.lastLog = time.Now()
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestBulkIndexer(t *testing.T) {
+	makePosts := func(count int) []*model.PostForIndexing {
+		posts := make([]*model.PostForIndexing, 0, count)
+		for i := 0; i < count; i++ {
+			post := &model.PostForIndexing{TeamId: "team"}
+			post.Id = model.NewId()
+			post.ChannelId = "channel"
+			post.Message = "message"
+			posts = append(posts, post)
+		}
+		return posts
+	}
+
+	setup := func(t *testing.T) (*fakeServer, *BulkIndexer) {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		t.Cleanup(server.Close)
+
+		engine := newTestEngine(t, server, true)
+		require.Nil(t, engine.Start())
+		t.Cleanup(func() { engine.Stop() })
+
+		bulkIndexer := engine.NewBulkIndexer()
+		bulkIndexer.initialBackoff = time.Millisecond
+		return server, bulkIndexer
+	}
+
+	t.Run("indexes and deletes documents", func(t *testing.T) {
+		server, bulkIndexer := setup(t)
+
+		posts := makePosts(2*BULK_REQUEST_SIZE + 1)
+		require.Nil(t, bulkIndexer.IndexPosts(posts))
+		assert.Equal(t, 3, server.bulkRequests)
+		assert.Len(t, server.documents, len(posts))
+		assert.Equal(t, "message", server.document("test_posts", posts[0].Id)["Message"])
+
+		posts[0].DeleteAt = model.GetMillis()
+		require.Nil(t, bulkIndexer.IndexPosts(posts[:1]))
+		assert.Nil(t, server.document("test_posts", posts[0].Id))
+	})
+
+	t.Run("retries rejected documents", func(t *testing.T) {
+		server, bulkIndexer := setup(t)
+		server.bulkRejections = 10
+
+		posts := makePosts(BULK_REQUEST_SIZE)
+		require.Nil(t, bulkIndexer.IndexPosts(posts))
+		assert.Equal(t, 2, server.bulkRequests)
+		assert.Len(t, server.documents, len(posts))
+	})
+
+	t.Run("gives up after too many retries", func(t *testing.T) {
+		server, bulkIndexer := setup(t)
+		server.bulkRejections = BULK_MAX_RETRIES + 1
+
+		appErr := bulkIndexer.IndexPosts(makePosts(1))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.bulk_index_posts.error", appErr.Id)
+	})
+
+	t.Run("fails if not started", func(t *testing.T) {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		defer server.Close()
+
+		appErr := newTestEngine(t, server, true).NewBulkIndexer().IndexPosts(makePosts(1))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.not_started.error", appErr.Id)
+	})
+}
+
+func TestBulkIndexerInFlightLimit(t *testing.T) {
+	engine := &OpenSearchEngine{cfg: &model.Config{}}
+	engine.cfg.SetDefaults()
+	*engine.cfg.SearchSettings.BulkIndexingMaxInFlightRequests = 4
+
+	bulkIndexer := engine.NewBulkIndexer()
+	assert.Equal(t, 4, bulkIndexer.limit)
+
+	bulkIndexer.acquire()
+	bulkIndexer.release(false)
+	assert.Equal(t, 2, bulkIndexer.limit)
+
+	bulkIndexer.acquire()
+	bulkIndexer.release(false)
+	bulkIndexer.acquire()
+	bulkIndexer.release(false)
+	assert.Equal(t, 1, bulkIndexer.limit)
+
+	for i := 0; i < 5; i++ {
+		bulkIndexer.acquire()
+		bulkIndexer.release(true)
+	}
+	assert.Equal(t, 4, bulkIndexer.limit)
+	assert.Equal(t, 0, bulkIndexer.inFlight)
+}
+
+func TestSendBulk(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, false)
+	c, err := newClient(&engine.cfg.ElasticsearchSettings)
+	require.NoError(t, err)
+
+	t.Run("tolerates deleting missing documents", func(t *testing.T) {
+		rejected, err := sendBulk(c, []bulkAction{{Index: "test_posts", Id: "missing"}})
+		require.NoError(t, err)
+		assert.Empty(t, rejected)
+	})
+
+	t.Run("returns the rejected actions", func(t *testing.T) {
+		server.bulkRejections = 1
+		actions := []bulkAction{
+			{Index: "test_posts", Id: "a", Document: jsonObject{"message": "a"}},
+			{Index: "test_posts", Id: "b", Document: jsonObject{"message": "b"}},
+		}
+		rejected, err := sendBulk(c, actions)
+		require.NoError(t, err)
+		assert.Equal(t, actions[:1], rejected)
+		assert.NotNil(t, server.document("test_posts", "b"))
+	})
+
+	t.Run("fails on other errors", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"errors": true, "items": [{"index": {"_id": "a", "status": 400, "error": {"type": "mapper_parsing_exception"}}}]}`))
+		}))
+		defer failing.Close()
+
+		*engine.cfg.ElasticsearchSettings.ConnectionUrl = failing.URL
+		failingClient, err := newClient(&engine.cfg.ElasticsearchSettings)
+		require.NoError(t, err)
+		_, err = sendBulk(failingClient, []bulkAction{{Index: "test_posts", Id: "a", Document: jsonObject{}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mapper_parsing_exception")
+	})
+}
@@ -0,0 +1,533 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	OPENSEARCH_DISTRIBUTION = "opensearch"
+	// REQUEST_MAX_RETRY_BACKOFF caps the doubling delay between the retries of a request.
+	REQUEST_MAX_RETRY_BACKOFF = 5 * time.Second
+)
+
+type jsonObject map[string]interface{}
+
+// client is a minimal client of the OpenSearch REST API.
+type client struct {
+	url        string
+	username   string
+	password   string
+	apiKey     string
+	httpClient *http.Client
+
+	retryMutex sync.RWMutex
+	retry      retryPolicy
+}
+
+// retryPolicy is how the requests reading from OpenSearch are retried after a transient
+// failure. onRetry, if set, is called with the name of the operation before each retry.
+type retryPolicy struct {
+	retries int
+	backoff time.Duration
+	onRetry func(operation string)
+}
+
+// delay returns how long to wait before the given retry, counting from 0.
+func (p retryPolicy) delay(retry int) time.Duration {
+	delay := p.backoff
+	for i := 0; i < retry && delay < REQUEST_MAX_RETRY_BACKOFF; i++ {
+		delay *= 2
+	}
+	if delay > REQUEST_MAX_RETRY_BACKOFF {
+		return REQUEST_MAX_RETRY_BACKOFF
+	}
+	return delay
+}
+
+func newClient(settings *model.ElasticsearchSettings) (*client, error) {
+	tlsConfig, err := newTLSConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &client{
+		url:      strings.TrimSuffix(*settings.ConnectionUrl, "/"),
+		username: *settings.Username,
+		password: *settings.Password,
+		apiKey:   *settings.APIKey,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(*settings.RequestTimeoutSeconds) * time.Second,
+		},
+	}, nil
+}
+
+// requestError is returned when OpenSearch fails a request.
+type requestError struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (c *client) setRetryPolicy(policy retryPolicy) {
+	c.retryMutex.Lock()
+	defer c.retryMutex.Unlock()
+	c.retry = policy
+}
+
+func (c *client) getRetryPolicy() retryPolicy {
+	c.retryMutex.RLock()
+	defer c.retryMutex.RUnlock()
+	return c.retry
+}
+
+func (e *requestError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("opensearch request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("opensearch request failed with status %d: %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+func isNotFound(err error) bool {
+	var reqErr *requestError
+	return errors.As(err, &reqErr) && reqErr.StatusCode == http.StatusNotFound
+}
+
+// isTooManyRequests reports whether OpenSearch rejected the request because it's overloaded.
+func isTooManyRequests(err error) bool {
+	var reqErr *requestError
+	return errors.As(err, &reqErr) && reqErr.StatusCode == http.StatusTooManyRequests
+}
+
+// transportError is returned when a request fails before OpenSearch answers it, such as when
+// the server can't be reached or the request times out.
+type transportError struct {
+	error
+}
+
+func (e *transportError) Unwrap() error {
+	return e.error
+}
+
+// isTransient reports whether a request failed for a reason that may go away by itself, such
+// as the server being unreachable, overloaded or restarting behind a proxy.
+func isTransient(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	var reqErr *requestError
+	if !errors.As(err, &reqErr) {
+		return false
+	}
+	switch reqErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRequestError reads the error from a failed response. OpenSearch reports most errors
+// as an object, but some endpoints report them as a plain string.
+func parseRequestError(statusCode int, body []byte) *requestError {
+	reqErr := &requestError{StatusCode: statusCode}
+
+	var response struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Error) == 0 {
+		return reqErr
+	}
+
+	var detail struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(response.Error, &detail); err == nil {
+		reqErr.Type = detail.Type
+		reqErr.Reason = detail.Reason
+		return reqErr
+	}
+
+	var reason string
+	if err := json.Unmarshal(response.Error, &reason); err == nil {
+		reqErr.Reason = reason
+	}
+	return reqErr
+}
+
+// do sends a request with the given body encoded as JSON, decoding the response into result
+// unless it's nil.
+func (c *client) do(method, path string, body interface{}, result interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return errors.Wrap(err, "failed to encode request")
+		}
+	}
+	return c.send(method, path, "application/json", data, result)
+}
+
+// send sends a request with the given raw body, decoding the response into result unless it's
+// nil.
+func (c *client) send(method, path, contentType string, body []byte, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, c.url+path, reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	// The API key takes precedence over the username and password.
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+c.apiKey)
+	} else if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &transportError{errors.Wrapf(err, "failed to send request to %s", path)}
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &transportError{errors.Wrap(err, "failed to read response")}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return parseRequestError(resp.StatusCode, data)
+	}
+
+	if result == nil || method == http.MethodHead {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+	return nil
+}
+
+// read sends a request like do, retrying it after the transient failures as allowed by the
+// retry policy. It's only used for the requests that don't change anything, since a request
+// timing out may still have been applied.
+func (c *client) read(operation, method, path string, body interface{}, result interface{}) error {
+	policy := c.getRetryPolicy()
+	for retry := 0; ; retry++ {
+		err := c.do(method, path, body, result)
+		if err == nil || retry >= policy.retries || !isTransient(err) {
+			return err
+		}
+
+		if policy.onRetry != nil {
+			policy.onRetry(operation)
+		}
+		time.Sleep(policy.delay(retry))
+	}
+}
+
+type versionResponse struct {
+	Version struct {
+		Distribution string `json:"distribution"`
+		Number       string `json:"number"`
+	} `json:"version"`
+}
+
+// getVersion returns the major version of the server, failing if it's not an OpenSearch server.
+// Elasticsearch servers don't report a distribution.
+func (c *client) getVersion() (int, error) {
+	var response versionResponse
+	if err := c.read("version", http.MethodGet, "/", nil, &response); err != nil {
+		return 0, err
+	}
+
+	if response.Version.Distribution != OPENSEARCH_DISTRIBUTION {
+		return 0, errors.Errorf("unsupported search server distribution %q", response.Version.Distribution)
+	}
+
+	major, err := strconv.Atoi(strings.SplitN(response.Version.Number, ".", 2)[0])
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid version number %q", response.Version.Number)
+	}
+	return major, nil
+}
+
+// totalHits decodes the total number of hits of a search, which OpenSearch reports as an
+// object, unless rest_total_hits_as_int is set.
+type totalHits int64
+
+func (t *totalHits) UnmarshalJSON(data []byte) error {
+	var value int64
+	if err := json.Unmarshal(data, &value); err == nil {
+		*t = totalHits(value)
+		return nil
+	}
+
+	var total struct {
+		Value int64 `json:"value"`
+	}
+	if err := json.Unmarshal(data, &total); err != nil {
+		return err
+	}
+	*t = totalHits(total.Value)
+	return nil
+}
+
+type searchHit struct {
+	Id        string              `json:"_id"`
+	Source    json.RawMessage     `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+	// Sort holds the sort values of the hit, which the next page of the search can start after.
+	Sort []json.RawMessage `json:"sort"`
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total totalHits   `json:"total"`
+		Hits  []searchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+func (c *client) search(index string, query jsonObject) (*searchResponse, error) {
+	var response searchResponse
+	if err := c.read("search", http.MethodPost, "/"+index+"/_search", query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *client) indexDocument(index, id string, document interface{}) error {
+	return c.do(http.MethodPut, "/"+index+"/_doc/"+id, document, nil)
+}
+
+func (c *client) deleteDocument(index, id string) error {
+	if err := c.do(http.MethodDelete, "/"+index+"/_doc/"+id, nil, nil); err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteByQuery deletes the documents matching the query, returning how many were deleted.
+func (c *client) deleteByQuery(index string, query jsonObject) (int64, error) {
+	var response struct {
+		Deleted int64 `json:"deleted"`
+	}
+	if err := c.do(http.MethodPost, "/"+index+"/_delete_by_query", jsonObject{"query": query}, &response); err != nil {
+		return 0, err
+	}
+	return response.Deleted, nil
+}
+
+func (c *client) createIndex(index string, definition jsonObject) error {
+	return c.do(http.MethodPut, "/"+index, definition, nil)
+}
+
+// getMappingVersion returns the name of the index behind the given index or alias, along with
+// the version of its mapping, which is 0 for the indexes created before the mappings were
+// versioned.
+func (c *client) getMappingVersion(index string) (string, int, error) {
+	var response map[string]struct {
+		Mappings struct {
+			Meta struct {
+				MappingVersion int `json:"mapping_version"`
+			} `json:"_meta"`
+		} `json:"mappings"`
+	}
+	if err := c.read("get_mapping", http.MethodGet, "/"+index+"/_mapping", nil, &response); err != nil {
+		return "", 0, err
+	}
+	if len(response) != 1 {
+		return "", 0, errors.Errorf("%s matches %d indexes", index, len(response))
+	}
+
+	for name, mapping := range response {
+		return name, mapping.Mappings.Meta.MappingVersion, nil
+	}
+	return "", 0, nil
+}
+
+// getShardSettings returns the number of shards and replicas of the index behind the given
+// index or alias.
+func (c *client) getShardSettings(index string) (int, int, error) {
+	var response map[string]struct {
+		Settings struct {
+			Index struct {
+				NumberOfShards   string `json:"number_of_shards"`
+				NumberOfReplicas string `json:"number_of_replicas"`
+			} `json:"index"`
+		} `json:"settings"`
+	}
+	if err := c.read("get_settings", http.MethodGet, "/"+index+"/_settings", nil, &response); err != nil {
+		return 0, 0, err
+	}
+	if len(response) != 1 {
+		return 0, 0, errors.Errorf("%s matches %d indexes", index, len(response))
+	}
+
+	for _, settings := range response {
+		shards, err := strconv.Atoi(settings.Settings.Index.NumberOfShards)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse the number of shards")
+		}
+		replicas, err := strconv.Atoi(settings.Settings.Index.NumberOfReplicas)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "failed to parse the number of replicas")
+		}
+		return shards, replicas, nil
+	}
+	return 0, 0, nil
+}
+
+// setReplicas changes the number of replicas of an index, which unlike its number of shards
+// applies to an existing index.
+func (c *client) setReplicas(index string, replicas int) error {
+	return c.do(http.MethodPut, "/"+index+"/_settings", jsonObject{"index": jsonObject{"number_of_replicas": replicas}}, nil)
+}
+
+// startReindex starts copying the documents of the source index into the destination index in
+// the background, returning the id of the task doing it. The documents already in the
+// destination index are kept.
+func (c *client) startReindex(source, dest string) (string, error) {
+	var response struct {
+		Task string `json:"task"`
+	}
+	body := jsonObject{
+		"conflicts": "proceed",
+		"source":    jsonObject{"index": source},
+		"dest":      jsonObject{"index": dest, "op_type": "create"},
+	}
+	if err := c.do(http.MethodPost, "/_reindex?wait_for_completion=false", body, &response); err != nil {
+		return "", err
+	}
+	return response.Task, nil
+}
+
+// getReindexTask returns whether the reindex task is completed, and the error it failed with,
+// if any.
+func (c *client) getReindexTask(task string) (bool, error) {
+	var response struct {
+		Completed bool            `json:"completed"`
+		Error     json.RawMessage `json:"error"`
+		Response  struct {
+			Failures []json.RawMessage `json:"failures"`
+		} `json:"response"`
+	}
+	if err := c.read("get_task", http.MethodGet, "/_tasks/"+task, nil, &response); err != nil {
+		return false, err
+	}
+
+	switch {
+	case len(response.Error) > 0:
+		return true, errors.Errorf("reindex task %s failed: %s", task, response.Error)
+	case len(response.Response.Failures) > 0:
+		return true, errors.Errorf("reindex task %s failed to copy %d documents, the first one with: %s", task, len(response.Response.Failures), response.Response.Failures[0])
+	}
+	return response.Completed, nil
+}
+
+// moveAlias atomically moves the alias from one index to another. When the old index has the
+// name of the alias, it's deleted in the same step for the alias to be created.
+func (c *client) moveAlias(alias, from, to string) error {
+	actions := []jsonObject{{"add": jsonObject{"index": to, "alias": alias}}}
+	if from == alias {
+		actions = append(actions, jsonObject{"remove_index": jsonObject{"index": from}})
+	} else {
+		actions = append(actions, jsonObject{"remove": jsonObject{"index": from, "alias": alias}})
+	}
+	return c.do(http.MethodPost, "/_aliases", jsonObject{"actions": actions}, nil)
+}
+
+func (c *client) deleteIndex(index string) error {
+	if err := c.do(http.MethodDelete, "/"+index, nil, nil); err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *client) refreshIndexes(indexes ...string) error {
+	return c.do(http.MethodPost, "/"+strings.Join(indexes, ",")+"/_refresh", nil, nil)
+}
+
+// setRefreshInterval changes how often the indexes are refreshed, such as "1s", "-1" disabling
+// the refresh, or an empty interval restoring the default of the server.
+func (c *client) setRefreshInterval(interval string, indexes ...string) error {
+	var value interface{}
+	if interval != "" {
+		value = interval
+	}
+	return c.do(http.MethodPut, "/"+strings.Join(indexes, ",")+"/_settings", jsonObject{"index": jsonObject{"refresh_interval": value}}, nil)
+}
+
+// bulkAction indexes the document with the given id, or deletes it if the document is nil.
+type bulkAction struct {
+	Index    string
+	Id       string
+	Document interface{}
+}
+
+type bulkItemResult struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// bulkResponse holds the result of each action of a bulk request, in the order of the actions.
+type bulkResponse struct {
+	Errors bool                        `json:"errors"`
+	Items  []map[string]bulkItemResult `json:"items"`
+}
+
+// bulk sends the actions in a single request. The request succeeds even if some of the
+// actions fail, so the results of the actions need to be checked.
+func (c *client) bulk(actions []bulkAction) (*bulkResponse, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, action := range actions {
+		operation := "index"
+		if action.Document == nil {
+			operation = "delete"
+		}
+		if err := encoder.Encode(jsonObject{operation: jsonObject{"_index": action.Index, "_id": action.Id}}); err != nil {
+			return nil, errors.Wrap(err, "failed to encode bulk action")
+		}
+		if action.Document != nil {
+			if err := encoder.Encode(action.Document); err != nil {
+				return nil, errors.Wrap(err, "failed to encode bulk document")
+			}
+		}
+	}
+
+	var response bulkResponse
+	if err := c.send(http.MethodPost, "/_bulk", "application/x-ndjson", body.Bytes(), &response); err != nil {
+		return nil, err
+	}
+	if len(response.Items) != len(actions) {
+		return nil, errors.Errorf("bulk response has %d items for %d actions", len(response.Items), len(actions))
+	}
+	return &response, nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+type OSChannel struct {
+	Id          string
+	TeamId      []string
+	NameSuggest []string
+}
+
+type OSUser struct {
+	Id                         string
+	SuggestionsWithFullname    []string
+	SuggestionsWithoutFullname []string
+	TeamsIds                   []string
+	ChannelsIds                []string
+}
+
+type OSPost struct {
+	Id          string
+	TeamId      string
+	ChannelId   string
+	UserId      string
+	CreateAt    int64
+	Message     string
+	Type        string
+	Hashtags    []string
+	Attachments string
+	// Language is the language detected in the message, if any of SearchSettings.LanguageAnalyzers.
+	Language string `json:",omitempty"`
+	// MessageLocalized holds the message under its language, in the field analyzed by the analyzer
+	// of that language.
+	MessageLocalized map[string]string `json:",omitempty"`
+}
+
+type OSFile struct {
+	Id        string
+	CreatorId string
+	ChannelId string
+	PostId    string
+	CreateAt  int64
+	Name      string
+	Content   string
+	Extension string
+}
+
+var keywordMapping = jsonObject{"type": "keyword"}
+var textMapping = jsonObject{"type": "text", "analyzer": "standard"}
+var dateMapping = jsonObject{"type": "long"}
+
+func getIndexDefinition(shards, replicas int, properties jsonObject) jsonObject {
+	return jsonObject{
+		"settings": jsonObject{
+			"index": jsonObject{
+				"number_of_shards":   shards,
+				"number_of_replicas": replicas,
+			},
+		},
+		"mappings": jsonObject{
+			"properties": properties,
+		},
+	}
+}
+
+func getChannelIndexDefinition(settings *model.ElasticsearchSettings) jsonObject {
+	return getIndexDefinition(*settings.ChannelIndexShards, *settings.ChannelIndexReplicas, jsonObject{
+		"Id":          keywordMapping,
+		"TeamId":      keywordMapping,
+		"NameSuggest": keywordMapping,
+	})
+}
+
+// getPostIndexDefinition maps the messages to the standard analyzer, and if any language
+// analyzers are configured, also maps the localized messages to the analyzer of their language.
+func getPostIndexDefinition(settings *model.ElasticsearchSettings, languageAnalyzers map[string]string) jsonObject {
+	properties := jsonObject{
+		"Id":          keywordMapping,
+		"TeamId":      keywordMapping,
+		"ChannelId":   keywordMapping,
+		"UserId":      keywordMapping,
+		"CreateAt":    dateMapping,
+		"Message":     textMapping,
+		"Type":        keywordMapping,
+		"Hashtags":    textMapping,
+		"Attachments": textMapping,
+	}
+
+	if len(languageAnalyzers) > 0 {
+		localized := jsonObject{}
+		for language, analyzer := range languageAnalyzers {
+			localized[language] = jsonObject{"type": "text", "analyzer": analyzer}
+		}
+		properties["Language"] = keywordMapping
+		properties["MessageLocalized"] = jsonObject{"properties": localized}
+	}
+
+	return getIndexDefinition(*settings.PostIndexShards, *settings.PostIndexReplicas, properties)
+}
+
+// analyzedLanguages returns the languages with an analyzer, sorted.
+func analyzedLanguages(languageAnalyzers map[string]string) []string {
+	languages := make([]string, 0, len(languageAnalyzers))
+	for language := range languageAnalyzers {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// messageFields returns the fields the messages are searched in: the message analyzed by the
+// standard analyzer, and the localized messages of the given languages.
+func messageFields(languages []string) []string {
+	fields := []string{"Message"}
+	for _, language := range languages {
+		fields = append(fields, "MessageLocalized."+language)
+	}
+	return fields
+}
+
+func getFileIndexDefinition(settings *model.ElasticsearchSettings) jsonObject {
+	return getIndexDefinition(*settings.PostIndexShards, *settings.PostIndexReplicas, jsonObject{
+		"Id":        keywordMapping,
+		"CreatorId": keywordMapping,
+		"ChannelId": keywordMapping,
+		"PostId":    keywordMapping,
+		"CreateAt":  dateMapping,
+		"Name":      textMapping,
+		"Content":   textMapping,
+		"Extension": keywordMapping,
+	})
+}
+
+func getUserIndexDefinition(settings *model.ElasticsearchSettings) jsonObject {
+	return getIndexDefinition(*settings.UserIndexShards, *settings.UserIndexReplicas, jsonObject{
+		"Id":                         keywordMapping,
+		"SuggestionsWithFullname":    keywordMapping,
+		"SuggestionsWithoutFullname": keywordMapping,
+		"TeamsIds":                   keywordMapping,
+		"ChannelsIds":                keywordMapping,
+	})
+}
+
+func OSChannelFromChannel(channel *model.Channel) *OSChannel {
+	displayNameInputs := searchengine.GetSuggestionInputsSplitBy(channel.DisplayName, " ")
+	nameInputs := searchengine.GetSuggestionInputsSplitByMultiple(channel.Name, []string{"-", "_"})
+
+	return &OSChannel{
+		Id:          channel.Id,
+		TeamId:      []string{channel.TeamId},
+		NameSuggest: append(displayNameInputs, nameInputs...),
+	}
+}
+
+func OSUserFromUserAndTeams(user *model.User, teamsIds, channelsIds []string) *OSUser {
+	usernameSuggestions := searchengine.GetSuggestionInputsSplitByMultiple(user.Username, []string{".", "-", "_"})
+
+	fullnameStrings := []string{}
+	if user.FirstName != "" {
+		fullnameStrings = append(fullnameStrings, user.FirstName)
+	}
+	if user.LastName != "" {
+		fullnameStrings = append(fullnameStrings, user.LastName)
+	}
+
+	fullnameSuggestions := []string{}
+	if len(fullnameStrings) > 0 {
+		fullname := strings.Join(fullnameStrings, " ")
+		fullnameSuggestions = searchengine.GetSuggestionInputsSplitBy(fullname, " ")
+	}
+
+	nicknameSuggestions := []string{}
+	if user.Nickname != "" {
+		nicknameSuggestions = searchengine.GetSuggestionInputsSplitBy(user.Nickname, " ")
+	}
+
+	usernameAndNicknameSuggestions := append(usernameSuggestions, nicknameSuggestions...)
+
+	return &OSUser{
+		Id:                         user.Id,
+		SuggestionsWithFullname:    append(usernameAndNicknameSuggestions, fullnameSuggestions...),
+		SuggestionsWithoutFullname: usernameAndNicknameSuggestions,
+		TeamsIds:                   teamsIds,
+		ChannelsIds:                channelsIds,
+	}
+}
+
+// OSPostFromPost converts the post to a document, detecting the language of its message among
+// the given languages.
+func OSPostFromPost(post *model.Post, teamId string, languages []string) *OSPost {
+	osPost := &OSPost{
+		Id:        post.Id,
+		TeamId:    teamId,
+		ChannelId: post.ChannelId,
+		UserId:    post.UserId,
+		CreateAt:  post.CreateAt,
+		Message:   post.Message,
+		Type:      post.Type,
+		Hashtags:  strings.Fields(post.Hashtags),
+	}
+
+	if language := searchengine.DetectLanguage(post.Message, languages); language != "" {
+		osPost.Language = language
+		osPost.MessageLocalized = map[string]string{language: post.Message}
+	}
+
+	return osPost
+}
+
+func OSFileFromFileInfo(file *model.FileInfo, channelId string) *OSFile {
+	return &OSFile{
+		Id:        file.Id,
+		CreatorId: file.CreatorId,
+		ChannelId: channelId,
+		PostId:    file.PostId,
+		CreateAt:  file.CreateAt,
+		Name:      file.Name,
+		Content:   file.Content,
+		Extension: file.Extension,
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/app"
+	"github.com/mattermost/mattermost-server/v5/jobs"
+	tjobs "github.com/mattermost/mattermost-server/v5/jobs/interfaces"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/opensearchengine"
+)
+
+const (
+	BATCH_SIZE              = 1000
+	TIME_BETWEEN_BATCHES    = 100
+	ESTIMATED_POST_COUNT    = 10000000
+	ESTIMATED_CHANNEL_COUNT = 100000
+	ESTIMATED_USER_COUNT    = 10000
+)
+
+func init() {
+	app.RegisterJobsOpenSearchIndexerInterface(func(s *app.Server) tjobs.IndexerJobInterface {
+		return &OpenSearchIndexerInterfaceImpl{s}
+	})
+}
+
+type OpenSearchIndexerInterfaceImpl struct {
+	Server *app.Server
+}
+
+// OpenSearchIndexerWorker runs the Elasticsearch indexing jobs against OpenSearch, sending the
+// batches through a BulkIndexer so that the cluster isn't sent more than it can handle.
+type OpenSearchIndexerWorker struct {
+	name      string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+
+	engine *opensearchengine.OpenSearchEngine
+}
+
+func (oi *OpenSearchIndexerInterfaceImpl) MakeWorker() model.Worker {
+	if oi.Server.SearchEngine.OpenSearchEngine == nil {
+		return nil
+	}
+	return &OpenSearchIndexerWorker{
+		name:      "OpenSearchIndexer",
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: oi.Server.Jobs,
+
+		engine: oi.Server.SearchEngine.OpenSearchEngine.(*opensearchengine.OpenSearchEngine),
+	}
+}
+
+type IndexingProgress struct {
+	Now                time.Time
+	StartAtTime        int64
+	EndAtTime          int64
+	LastEntityTime     int64
+	TotalPostsCount    int64
+	DonePostsCount     int64
+	DonePosts          bool
+	TotalChannelsCount int64
+	DoneChannelsCount  int64
+	DoneChannels       bool
+	TotalUsersCount    int64
+	DoneUsersCount     int64
+	DoneUsers          bool
+}
+
+func (ip *IndexingProgress) CurrentProgress() int64 {
+	return (ip.DonePostsCount + ip.DoneChannelsCount + ip.DoneUsersCount) * 100 / (ip.TotalPostsCount + ip.TotalChannelsCount + ip.TotalUsersCount)
+}
+
+func (ip *IndexingProgress) IsDone() bool {
+	return ip.DonePosts && ip.DoneChannels && ip.DoneUsers
+}
+
+func (worker *OpenSearchIndexerWorker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *OpenSearchIndexerWorker) Run() {
+	mlog.Debug("Worker Started", mlog.String("workername", worker.name))
+
+	defer func() {
+		mlog.Debug("Worker: Finished", mlog.String("workername", worker.name))
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			mlog.Debug("Worker: Received stop signal", mlog.String("workername", worker.name))
+			return
+		case job := <-worker.jobs:
+			mlog.Debug("Worker: Received a new candidate job.", mlog.String("workername", worker.name))
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *OpenSearchIndexerWorker) Stop() {
+	mlog.Debug("Worker Stopping", mlog.String("workername", worker.name))
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *OpenSearchIndexerWorker) setJobError(job *model.Job, appError *model.AppError) {
+	if err := worker.jobServer.SetJobError(job, appError); err != nil {
+		mlog.Error("Worker: Failed to set job error", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err), mlog.NamedErr("set_error", appError))
+	}
+}
+
+func (worker *OpenSearchIndexerWorker) DoJob(job *model.Job) {
+	claimed, err := worker.jobServer.ClaimJob(job)
+	if err != nil {
+		mlog.Warn("Worker: Error ocurred while trying to claim job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	mlog.Info("Worker: Indexing job claimed by worker", mlog.String("workername", worker.name), mlog.String("job_id", job.Id))
+
+	if !worker.engine.IsActive() {
+		worker.setJobError(job, model.NewAppError("OpenSearchIndexerWorker", "opensearchengine.indexer.do_job.engine_inactive", nil, "", http.StatusInternalServerError))
+		return
+	}
+
+	progress := IndexingProgress{
+		Now:       time.Now(),
+		EndAtTime: model.GetMillis(),
+	}
+
+	// Extract the start and end times, if they are set.
+	if startString, ok := job.Data["start_time"]; ok {
+		startInt, err := strconv.ParseInt(startString, 10, 64)
+		if err != nil {
+			mlog.Error("Worker: Failed to parse start_time for job", mlog.String("workername", worker.name), mlog.String("start_time", startString), mlog.String("job_id", job.Id), mlog.Err(err))
+			worker.setJobError(job, model.NewAppError("OpenSearchIndexerWorker", "opensearchengine.indexer.do_job.parse_start_time.error", nil, err.Error(), http.StatusInternalServerError))
+			return
+		}
+		progress.StartAtTime = startInt
+	} else {
+		// Set start time to oldest post in the database.
+		oldestPost, err := worker.jobServer.Store.Post().GetOldest()
+		if err != nil {
+			mlog.Error("Worker: Failed to fetch oldest post for job.", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+			worker.setJobError(job, model.NewAppError("OpenSearchIndexerWorker", "opensearchengine.indexer.do_job.get_oldest_post.error", nil, err.Error(), http.StatusInternalServerError))
+			return
+		}
+		progress.StartAtTime = oldestPost.CreateAt
+	}
+	progress.LastEntityTime = progress.StartAtTime
+
+	if endString, ok := job.Data["end_time"]; ok {
+		endInt, err := strconv.ParseInt(endString, 10, 64)
+		if err != nil {
+			mlog.Error("Worker: Failed to parse end_time for job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.String("end_time", endString), mlog.Err(err))
+			worker.setJobError(job, model.NewAppError("OpenSearchIndexerWorker", "opensearchengine.indexer.do_job.parse_end_time.error", nil, err.Error(), http.StatusInternalServerError))
+			return
+		}
+		progress.EndAtTime = endInt
+	}
+
+	// Counting the entities may fail or timeout when the tables are large. If this happens, log a warning, but carry
+	// on with the indexing job anyway. The only issue is that the progress % reporting will be inaccurate.
+	if count, err := worker.jobServer.Store.Post().AnalyticsPostCount("", false, false); err != nil {
+		mlog.Warn("Worker: Failed to fetch total post count for job. An estimated value will be used for progress reporting.", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		progress.TotalPostsCount = ESTIMATED_POST_COUNT
+	} else {
+		progress.TotalPostsCount = count
+	}
+
+	if count, err := worker.jobServer.Store.Channel().AnalyticsTypeCount("", "O"); err != nil {
+		mlog.Warn("Worker: Failed to fetch total channel count for job. An estimated value will be used for progress reporting.", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		progress.TotalChannelsCount = ESTIMATED_CHANNEL_COUNT
+	} else {
+		progress.TotalChannelsCount = count
+	}
+
+	if count, err := worker.jobServer.Store.User().Count(model.UserCountOptions{}); err != nil {
+		mlog.Warn("Worker: Failed to fetch total user count for job. An estimated value will be used for progress reporting.", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		progress.TotalUsersCount = ESTIMATED_USER_COUNT
+	} else {
+		progress.TotalUsersCount = count
+	}
+
+	if restoreRefresh := worker.disableRefresh(job, progress); restoreRefresh != nil {
+		defer restoreRefresh()
+	}
+
+	worker.indexBatches(job, progress, worker.engine.NewBulkIndexer())
+}
+
+// disableRefresh disables the refresh of the indexes while the job runs when
+// SearchSettings.DisableRefreshDuringBulkIndexing is set and the job indexes more entities than
+// SearchSettings.LiveIndexingBatchSize. It returns the function restoring the refresh, to be
+// called however the job ends, or nil if the refresh was left as it is.
+func (worker *OpenSearchIndexerWorker) disableRefresh(job *model.Job, progress IndexingProgress) func() {
+	settings := worker.jobServer.Config().SearchSettings
+	total := progress.TotalPostsCount + progress.TotalChannelsCount + progress.TotalUsersCount
+	if !*settings.DisableRefreshDuringBulkIndexing || total <= int64(*settings.LiveIndexingBatchSize) {
+		return nil
+	}
+
+	if err := worker.engine.SetRefreshInterval(opensearchengine.REFRESH_INTERVAL_DISABLED); err != nil {
+		mlog.Warn("Worker: Failed to disable the refresh of the indexes for job. The indexes will be refreshed during it.", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		return nil
+	}
+	mlog.Info("Worker: Disabled the refresh of the indexes during job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Int64("entities", total))
+
+	return func() {
+		if err := worker.engine.SetRefreshInterval(""); err != nil {
+			mlog.Error("Worker: Failed to restore the refresh of the indexes after job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+			return
+		}
+		// The documents indexed during the job become searchable right away rather than at the
+		// next refresh.
+		if err := worker.engine.RefreshIndexes(); err != nil {
+			mlog.Warn("Worker: Failed to refresh the indexes after job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+		}
+	}
+}
+
+// indexBatches indexes the entities batch by batch until they're all indexed or the job is
+// canceled. Each batch is fully indexed, retries included, before the next one is fetched.
+func (worker *OpenSearchIndexerWorker) indexBatches(job *model.Job, progress IndexingProgress, bulkIndexer *opensearchengine.BulkIndexer) {
+	cancelCtx, cancelCancelWatcher := context.WithCancel(context.Background())
+	cancelWatcherChan := make(chan interface{}, 1)
+	go worker.jobServer.CancellationWatcher(cancelCtx, job.Id, cancelWatcherChan)
+
+	defer cancelCancelWatcher()
+
+	for {
+		select {
+		case <-cancelWatcherChan:
+			mlog.Info("Worker: Indexing job has been canceled via CancellationWatcher", mlog.String("workername", worker.name), mlog.String("job_id", job.Id))
+			if err := worker.jobServer.SetJobCanceled(job); err != nil {
+				mlog.Error("Worker: Failed to mark job as cancelled", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+			}
+			return
+
+		case <-worker.stop:
+			mlog.Info("Worker: Indexing has been canceled via Worker Stop", mlog.String("workername", worker.name), mlog.String("job_id", job.Id))
+			if err := worker.jobServer.SetJobCanceled(job); err != nil {
+				mlog.Error("Worker: Failed to mark job as canceled", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+			}
+			return
+
+		case <-time.After(TIME_BETWEEN_BATCHES * time.Millisecond):
+			var err *model.AppError
+			if progress, err = worker.IndexBatch(progress, bulkIndexer); err != nil {
+				mlog.Error("Worker: Failed to index batch for job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+				worker.setJobError(job, err)
+				return
+			}
+
+			if err := worker.jobServer.SetJobProgress(job, progress.CurrentProgress()); err != nil {
+				mlog.Error("Worker: Failed to set progress for job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+				worker.setJobError(job, err)
+				return
+			}
+
+			if progress.IsDone() {
+				if err := worker.jobServer.SetJobSuccess(job); err != nil {
+					mlog.Error("Worker: Failed to set success for job", mlog.String("workername", worker.name), mlog.String("job_id", job.Id), mlog.Err(err))
+					worker.setJobError(job, err)
+				}
+				mlog.Info("Worker: Indexing job finished successfully", mlog.String("workername", worker.name), mlog.String("job_id", job.Id))
+				return
+			}
+		}
+	}
+}
+
+func (worker *OpenSearchIndexerWorker) IndexBatch(progress IndexingProgress, bulkIndexer *opensearchengine.BulkIndexer) (IndexingProgress, *model.AppError) {
+	if !progress.DonePosts {
+		return worker.IndexPostsBatch(progress, bulkIndexer)
+	}
+	if !progress.DoneChannels {
+		return worker.IndexChannelsBatch(progress, bulkIndexer)
+	}
+	if !progress.DoneUsers {
+		return worker.IndexUsersBatch(progress, bulkIndexer)
+	}
+	return progress, model.NewAppError("OpenSearchIndexerWorker", "opensearchengine.indexer.index_batch.nothing_left_to_index.error", nil, "", http.StatusInternalServerError)
+}
+
+func (worker *OpenSearchIndexerWorker) batchEndTime(progress IndexingProgress) int64 {
+	return progress.LastEntityTime + int64(*worker.jobServer.Config().SearchSettings.BulkIndexingTimeWindowSeconds*1000)
+}
+
+// advance moves the progress past a batch of count entities whose last one was created at
+// lastCreateAt, returning whether all the entities of this type are indexed.
+//
+// We index either until we pass a batch of entities where the last one is created at or after
+// the specified end time, or until two consecutive full batches have the same end time of their
+// final entities. This second case is safe as long as the assumption that the database cannot
+// contain more entities with the same CreateAt time than the batch size holds.
+func advance(progress *IndexingProgress, entity string, lastCreateAt, endTime int64, count int) bool {
+	// Due to the "endTime" parameter in the store query, we might get an incomplete batch before the end. In this
+	// case, move to the endTime so we don't get stuck running the same query in a loop.
+	if count < BATCH_SIZE {
+		lastCreateAt = endTime
+	}
+
+	if progress.EndAtTime <= lastCreateAt {
+		progress.LastEntityTime = progress.StartAtTime
+		return true
+	} else if progress.LastEntityTime == lastCreateAt && count == BATCH_SIZE {
+		mlog.Error("More entities with the same CreateAt time were detected than the permitted batch size. Aborting indexing job.", mlog.String("entity", entity), mlog.Int64("CreateAt", lastCreateAt), mlog.Int("Batch Size", BATCH_SIZE))
+		progress.LastEntityTime = progress.StartAtTime
+		return true
+	}
+
+	progress.LastEntityTime = lastCreateAt
+	return false
+}
+
+func (worker *OpenSearchIndexerWorker) IndexPostsBatch(progress IndexingProgress, bulkIndexer *opensearchengine.BulkIndexer) (IndexingProgress, *model.AppError) {
+	endTime := worker.batchEndTime(progress)
+
+	var posts []*model.PostForIndexing
+
+	tries := 0
+	for posts == nil {
+		var err error
+		posts, err = worker.jobServer.Store.Post().GetPostsBatchForIndexing(progress.LastEntityTime, endTime, BATCH_SIZE)
+		if err != nil {
+			if tries >= 10 {
+				return progress, model.NewAppError("IndexPostsBatch", "app.post.get_posts_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			mlog.Warn("Failed to get posts batch for indexing. Retrying.", mlog.Err(err))
+
+			// Wait a bit before trying again.
+			time.Sleep(15 * time.Second)
+		}
+		tries++
+	}
+
+	if err := bulkIndexer.IndexPosts(posts); err != nil {
+		return progress, err
+	}
+
+	lastCreateAt := int64(0)
+	if len(posts) > 0 {
+		lastCreateAt = posts[len(posts)-1].CreateAt
+	}
+	progress.DonePosts = advance(&progress, "posts", lastCreateAt, endTime, len(posts))
+	progress.DonePostsCount += int64(len(posts))
+
+	return progress, nil
+}
+
+func (worker *OpenSearchIndexerWorker) IndexChannelsBatch(progress IndexingProgress, bulkIndexer *opensearchengine.BulkIndexer) (IndexingProgress, *model.AppError) {
+	endTime := worker.batchEndTime(progress)
+
+	var channels []*model.Channel
+
+	tries := 0
+	for channels == nil {
+		var err error
+		channels, err = worker.jobServer.Store.Channel().GetChannelsBatchForIndexing(progress.LastEntityTime, endTime, BATCH_SIZE)
+		if err != nil {
+			if tries >= 10 {
+				return progress, model.NewAppError("IndexChannelsBatch", "app.channel.get_channels_batch_for_indexing.get.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			mlog.Warn("Failed to get channels batch for indexing. Retrying.", mlog.Err(err))
+
+			// Wait a bit before trying again.
+			time.Sleep(15 * time.Second)
+		}
+		tries++
+	}
+
+	if err := bulkIndexer.IndexChannels(channels); err != nil {
+		return progress, err
+	}
+
+	lastCreateAt := int64(0)
+	if len(channels) > 0 {
+		lastCreateAt = channels[len(channels)-1].CreateAt
+	}
+	progress.DoneChannels = advance(&progress, "channels", lastCreateAt, endTime, len(channels))
+	progress.DoneChannelsCount += int64(len(channels))
+
+	return progress, nil
+}
+
+func (worker *OpenSearchIndexerWorker) IndexUsersBatch(progress IndexingProgress, bulkIndexer *opensearchengine.BulkIndexer) (IndexingProgress, *model.AppError) {
+	endTime := worker.batchEndTime(progress)
+
+	var users []*model.UserForIndexing
+
+	tries := 0
+	for users == nil {
+		var err error
+		users, err = worker.jobServer.Store.User().GetUsersBatchForIndexing(progress.LastEntityTime, endTime, BATCH_SIZE)
+		if err != nil {
+			if tries >= 10 {
+				return progress, model.NewAppError("IndexUsersBatch", "app.user.get_users_batch_for_indexing.get_users.app_error", nil, err.Error(), http.StatusInternalServerError)
+			}
+
+			mlog.Warn("Failed to get users batch for indexing. Retrying.", mlog.Err(err))
+
+			// Wait a bit before trying again.
+			time.Sleep(15 * time.Second)
+		}
+		tries++
+	}
+
+	if err := bulkIndexer.IndexUsers(users); err != nil {
+		return progress, err
+	}
+
+	lastCreateAt := int64(0)
+	if len(users) > 0 {
+		lastCreateAt = users[len(users)-1].CreateAt
+	}
+	progress.DoneUsers = advance(&progress, "users", lastCreateAt, endTime, len(users))
+	progress.DoneUsersCount += int64(len(users))
+
+	return progress, nil
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+// indexMappingVersions holds the version of the mapping of each index. The version of an index
+// must be bumped whenever its definition changes, for the existing indexes to be migrated to
+// the new mapping on startup.
+var indexMappingVersions = map[string]int{
+	POST_INDEX:    1,
+	CHANNEL_INDEX: 1,
+	USER_INDEX:    1,
+	FILE_INDEX:    1,
+}
+
+// reindexPollInterval is the delay between two checks of a running reindex.
+var reindexPollInterval = 5 * time.Second
+
+// indexState tracks the index an alias points to. The searches always go through the alias,
+// while the documents are also written to the index being migrated to, if any.
+type indexState struct {
+	name        string
+	version     int
+	shards      int
+	migratingTo string
+	// migratingShards is the number of shards of the index being migrated to.
+	migratingShards int
+}
+
+// indexShards returns the number of shards and replicas configured for an index. The files
+// share the settings of the posts.
+func indexShards(settings *model.ElasticsearchSettings, index string) (int, int) {
+	switch index {
+	case CHANNEL_INDEX:
+		return *settings.ChannelIndexShards, *settings.ChannelIndexReplicas
+	case USER_INDEX:
+		return *settings.UserIndexShards, *settings.UserIndexReplicas
+	default:
+		return *settings.PostIndexShards, *settings.PostIndexReplicas
+	}
+}
+
+// shardSettingsChanged returns whether the number of shards or replicas of any index differs
+// between the configurations.
+func shardSettingsChanged(previous, current *model.ElasticsearchSettings) bool {
+	for _, index := range []string{POST_INDEX, CHANNEL_INDEX, USER_INDEX} {
+		previousShards, previousReplicas := indexShards(previous, index)
+		shards, replicas := indexShards(current, index)
+		if shards != previousShards || replicas != previousReplicas {
+			return true
+		}
+	}
+	return false
+}
+
+// versionedIndexName returns the name of the index behind the alias for the given version.
+func versionedIndexName(alias string, version int) string {
+	return alias + "_v" + strconv.Itoa(version)
+}
+
+func (e *OpenSearchEngine) getIndexDefinitions() map[string]jsonObject {
+	settings := &e.cfg.ElasticsearchSettings
+	definitions := map[string]jsonObject{
+		POST_INDEX:    getPostIndexDefinition(settings, e.cfg.SearchSettings.LanguageAnalyzers),
+		CHANNEL_INDEX: getChannelIndexDefinition(settings),
+		USER_INDEX:    getUserIndexDefinition(settings),
+		FILE_INDEX:    getFileIndexDefinition(settings),
+	}
+	for index, definition := range definitions {
+		definition["mappings"].(jsonObject)["_meta"] = jsonObject{"mapping_version": indexMappingVersions[index]}
+	}
+	return definitions
+}
+
+// createIndexes creates the missing indexes behind their aliases, and starts migrating the
+// indexes whose mapping is older than the current one. The searches keep using the old index
+// until the documents are copied into the new one. It must be called with the lock held.
+func (e *OpenSearchEngine) createIndexes() *model.AppError {
+	e.indexes = map[string]*indexState{}
+	for index, definition := range e.getIndexDefinitions() {
+		alias := e.indexName(index)
+		expected := indexMappingVersions[index]
+
+		name, version, err := e.client.getMappingVersion(alias)
+		if isNotFound(err) {
+			name, version = versionedIndexName(alias, expected), expected
+			definition["aliases"] = jsonObject{alias: jsonObject{}}
+			err = e.client.createIndex(name, definition)
+		}
+		if err != nil {
+			return model.NewAppError("OpenSearchEngine.Start", "opensearchengine.create_index.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+		}
+
+		state := &indexState{name: name, version: version}
+		e.indexes[index] = state
+		if appErr := e.applyShardSettings(index, state); appErr != nil {
+			return appErr
+		}
+
+		if version > expected {
+			mlog.Warn("The OpenSearch index has a newer mapping than this server's", mlog.String("index", alias), mlog.Int("mapping_version", version), mlog.Int("expected_mapping_version", expected))
+		} else if version < expected {
+			if appErr := e.startMigration(index, state, definition); appErr != nil {
+				return appErr
+			}
+		}
+	}
+	return nil
+}
+
+// startMigration creates the index with the current mapping and starts copying the documents
+// of the old index into it in the background, then moves the alias to the new index. A new
+// index left over by an interrupted migration is created again.
+func (e *OpenSearchEngine) startMigration(index string, state *indexState, definition jsonObject) *model.AppError {
+	alias := e.indexName(index)
+	expected := indexMappingVersions[index]
+	target := versionedIndexName(alias, expected)
+
+	mlog.Info("Migrating the OpenSearch index to a new mapping", mlog.String("index", alias), mlog.Int("mapping_version", state.version), mlog.Int("expected_mapping_version", expected))
+
+	err := e.client.deleteIndex(target)
+	if err == nil {
+		err = e.client.createIndex(target, definition)
+	}
+	if err != nil {
+		return model.NewAppError("OpenSearchEngine.Start", "opensearchengine.create_index.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+	}
+	state.migratingTo = target
+	state.migratingShards, _ = indexShards(&e.cfg.ElasticsearchSettings, index)
+
+	if e.migrationStop == nil {
+		e.migrationStop = make(chan struct{})
+	}
+	e.migrations.Add(1)
+	go e.migrateIndex(e.client, e.migrationStop, index, alias, state.name, target)
+	return nil
+}
+
+func (e *OpenSearchEngine) migrateIndex(client *client, stop <-chan struct{}, index, alias, from, to string) {
+	defer e.migrations.Done()
+
+	err := e.reindex(client, stop, from, to)
+	if err == errMigrationStopped {
+		return
+	}
+	if err == nil {
+		err = client.moveAlias(alias, from, to)
+	}
+	if err != nil {
+		mlog.Error("Failed to migrate the OpenSearch index to the new mapping", mlog.String("index", alias), mlog.Err(err))
+		if !e.endMigration(index, to, false) {
+			return
+		}
+		if err := client.deleteIndex(to); err != nil {
+			mlog.Warn("Failed to delete the index of the failed migration", mlog.String("index", to), mlog.Err(err))
+		}
+		return
+	}
+
+	if !e.endMigration(index, to, true) {
+		return
+	}
+	mlog.Info("Migrated the OpenSearch index to the new mapping", mlog.String("index", alias), mlog.Int("mapping_version", indexMappingVersions[index]))
+	if from != alias {
+		if err := client.deleteIndex(from); err != nil {
+			mlog.Warn("Failed to delete the index of the old mapping", mlog.String("index", from), mlog.Err(err))
+		}
+	}
+}
+
+var errMigrationStopped = errors.New("migration stopped")
+
+// reindex copies the documents of an index into another one, waiting until it's done or the
+// engine is stopped.
+func (e *OpenSearchEngine) reindex(client *client, stop <-chan struct{}, from, to string) error {
+	task, err := client.startReindex(from, to)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			return errMigrationStopped
+		case <-time.After(reindexPollInterval):
+		}
+
+		completed, err := client.getReindexTask(task)
+		if err != nil || completed {
+			return err
+		}
+	}
+}
+
+// endMigration stops writing to the index being migrated to, which becomes the index of the
+// alias if the migration succeeded. It returns false if the indexes were recreated in the
+// meantime.
+func (e *OpenSearchEngine) endMigration(index, target string, succeeded bool) bool {
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	state, ok := e.indexes[index]
+	if !ok || state.migratingTo != target {
+		return false
+	}
+	state.migratingTo = ""
+	if succeeded {
+		state.name = target
+		state.version = indexMappingVersions[index]
+		state.shards = state.migratingShards
+	}
+	return true
+}
+
+// applyShardSettings reads the number of shards of the index, and sets its number of replicas
+// to the configured one. The number of shards of an existing index can't be changed, so a
+// different number of shards is only reported: the documents keep being written to the
+// existing index until it's purged and rebuilt. It must be called with the lock held.
+func (e *OpenSearchEngine) applyShardSettings(index string, state *indexState) *model.AppError {
+	alias := e.indexName(index)
+	expectedShards, expectedReplicas := indexShards(&e.cfg.ElasticsearchSettings, index)
+
+	shards, replicas, err := e.client.getShardSettings(state.name)
+	if err == nil && replicas != expectedReplicas {
+		mlog.Info("Changing the number of replicas of the OpenSearch index", mlog.String("index", alias), mlog.Int("replicas", replicas), mlog.Int("expected_replicas", expectedReplicas))
+		err = e.client.setReplicas(state.name, expectedReplicas)
+	}
+	if err == nil && state.migratingTo != "" {
+		err = e.client.setReplicas(state.migratingTo, expectedReplicas)
+	}
+	if err != nil {
+		return model.NewAppError("OpenSearchEngine.applyShardSettings", "opensearchengine.index_settings.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+	}
+
+	state.shards = shards
+	if shards != expectedShards {
+		mlog.Warn("The OpenSearch index has a different number of shards than configured, the indexes must be purged and rebuilt for it to apply", mlog.String("index", alias), mlog.Int("shards", shards), mlog.Int("expected_shards", expectedShards))
+	}
+	return nil
+}
+
+// stopMigrations interrupts the running migrations, which are resumed on the next start, and
+// waits for them to return. It must be called without the lock held.
+func (e *OpenSearchEngine) stopMigrations() {
+	e.Mutex.Lock()
+	stop := e.migrationStop
+	e.migrationStop = nil
+	e.Mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+	}
+	e.migrations.Wait()
+}
+
+// writeIndexNames returns the names of the indexes to write the documents of an index to: its
+// alias, along with the index it's being migrated to, if any. It must be called with the lock
+// held.
+func (e *OpenSearchEngine) writeIndexNames(index string) []string {
+	names := []string{e.indexName(index)}
+	if state, ok := e.indexes[index]; ok && state.migratingTo != "" {
+		names = append(names, state.migratingTo)
+	}
+	return names
+}
+
+// getWriteIndexNames is writeIndexNames for the callers not holding the lock.
+func (e *OpenSearchEngine) getWriteIndexNames(index string) []string {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+	return e.writeIndexNames(index)
+}
+
+func (e *OpenSearchEngine) indexDocument(index, id string, document interface{}) error {
+	for _, name := range e.writeIndexNames(index) {
+		if err := e.client.indexDocument(name, id, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) deleteDocument(index, id string) error {
+	for _, name := range e.writeIndexNames(index) {
+		if err := e.client.deleteDocument(name, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteByQuery deletes the documents matching the query, returning how many were deleted from
+// the index searched.
+func (e *OpenSearchEngine) deleteByQuery(index string, query jsonObject) (int64, error) {
+	var deleted int64
+	for i, name := range e.writeIndexNames(index) {
+		count, err := e.client.deleteByQuery(name, query)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 {
+			deleted = count
+		}
+	}
+	return deleted, nil
+}
+
+// GetIndexMappingVersions returns the version of the mapping of each index, against the version
+// expected by the server. It's empty until the engine is started.
+func (e *OpenSearchEngine) GetIndexMappingVersions() []searchengine.IndexMappingVersion {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	versions := []searchengine.IndexMappingVersion{}
+	for index, state := range e.indexes {
+		expectedShards, _ := indexShards(&e.cfg.ElasticsearchSettings, index)
+		versions = append(versions, searchengine.IndexMappingVersion{
+			Index:          e.indexName(index),
+			Current:        state.version,
+			Expected:       indexMappingVersions[index],
+			Migrating:      state.migratingTo != "",
+			Shards:         state.shards,
+			ExpectedShards: expectedShards,
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Index < versions[j].Index })
+	return versions
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/jobs"
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	ENGINE_NAME   = "opensearch"
+	POST_INDEX    = "posts"
+	USER_INDEX    = "users"
+	CHANNEL_INDEX = "channels"
+	FILE_INDEX    = "files"
+
+	// REFRESH_INTERVAL_DISABLED is the refresh interval disabling the refresh of the indexes.
+	REFRESH_INTERVAL_DISABLED = "-1"
+)
+
+// OpenSearchEngine indexes and searches through an OpenSearch server, configured through
+// the ElasticsearchSettings. It's only used when SearchSettings.Backend selects it.
+type OpenSearchEngine struct {
+	client    *client
+	version   int
+	Mutex     sync.RWMutex
+	cfg       *model.Config
+	license   func() *model.License
+	metrics   func() einterfaces.MetricsInterface
+	jobServer *jobs.JobServer
+
+	// indexes tracks the index behind the alias of each index, by index name without the prefix.
+	indexes       map[string]*indexState
+	migrationStop chan struct{}
+	migrations    sync.WaitGroup
+}
+
+// NewOpenSearchEngine creates the engine. metrics returns the metrics to report the retried
+// requests to, which may be nil.
+func NewOpenSearchEngine(cfg *model.Config, license func() *model.License, metrics func() einterfaces.MetricsInterface, jobServer *jobs.JobServer) *OpenSearchEngine {
+	return &OpenSearchEngine{
+		cfg:       cfg,
+		license:   license,
+		metrics:   metrics,
+		jobServer: jobServer,
+	}
+}
+
+func (e *OpenSearchEngine) isLicensed() bool {
+	license := e.license()
+	return license != nil && *license.Features.Elasticsearch
+}
+
+func (e *OpenSearchEngine) indexName(index string) string {
+	return e.cfg.SearchSettings.GetIndexPrefix(&e.cfg.ElasticsearchSettings) + index
+}
+
+// retryPolicy returns the policy retrying the requests reading from OpenSearch, as configured
+// by the SearchSettings.
+func (e *OpenSearchEngine) retryPolicy(cfg *model.Config) retryPolicy {
+	return retryPolicy{
+		retries: *cfg.SearchSettings.RequestRetries,
+		backoff: time.Duration(*cfg.SearchSettings.RequestRetryBackoffMilliseconds) * time.Millisecond,
+		onRetry: func(operation string) {
+			mlog.Debug("Retrying OpenSearch request", mlog.String("operation", operation))
+			if e.metrics == nil {
+				return
+			}
+			if metrics := e.metrics(); metrics != nil {
+				metrics.IncrementSearchRequestRetryCounter(ENGINE_NAME, operation)
+			}
+		},
+	}
+}
+
+func notStartedError(where string) *model.AppError {
+	return model.NewAppError(where, "opensearchengine.not_started.error", nil, "", http.StatusInternalServerError)
+}
+
+func (e *OpenSearchEngine) Start() *model.AppError {
+	if !*e.cfg.ElasticsearchSettings.EnableIndexing {
+		return nil
+	}
+
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	if e.client != nil {
+		return model.NewAppError("OpenSearchEngine.Start", "opensearchengine.already_started.error", nil, "", http.StatusInternalServerError)
+	}
+	if !e.isLicensed() {
+		return model.NewAppError("OpenSearchEngine.Start", "opensearchengine.license.error", nil, "", http.StatusNotImplemented)
+	}
+
+	mlog.Info("Starting OpenSearch")
+
+	client, err := newClient(&e.cfg.ElasticsearchSettings)
+	if err != nil {
+		return model.NewAppError("OpenSearchEngine.Start", "opensearchengine.tls_config.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	client.setRetryPolicy(e.retryPolicy(e.cfg))
+	version, err := client.getVersion()
+	if err != nil {
+		return connectError("OpenSearchEngine.Start", err)
+	}
+
+	e.client = client
+	e.version = version
+	if appErr := e.createIndexes(); appErr != nil {
+		e.client = nil
+		return appErr
+	}
+
+	return nil
+}
+
+func (e *OpenSearchEngine) Stop() *model.AppError {
+	mlog.Info("Stopping OpenSearch")
+
+	e.stopMigrations()
+
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	e.client = nil
+	e.indexes = nil
+	return nil
+}
+
+func (e *OpenSearchEngine) GetVersion() int {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+	return e.version
+}
+
+// UpdateConfig applies the new configuration. A change of the number of replicas is applied to
+// the existing indexes, while a change of the number of shards is reported as needing a reindex.
+// A change of the index prefix switches to the indexes of the new prefix, creating the missing
+// ones, which are then filled by a bulk indexing job.
+func (e *OpenSearchEngine) UpdateConfig(cfg *model.Config) {
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	previous := e.cfg
+	e.cfg = cfg
+	if e.client == nil {
+		return
+	}
+	e.client.setRetryPolicy(e.retryPolicy(cfg))
+
+	if previous.SearchSettings.GetIndexPrefix(&previous.ElasticsearchSettings) != cfg.SearchSettings.GetIndexPrefix(&cfg.ElasticsearchSettings) {
+		if appErr := e.createIndexes(); appErr != nil {
+			mlog.Error("Failed to create the OpenSearch indexes for the new index prefix", mlog.Err(appErr))
+		}
+		return
+	}
+
+	if !shardSettingsChanged(&previous.ElasticsearchSettings, &cfg.ElasticsearchSettings) {
+		return
+	}
+	for index, state := range e.indexes {
+		if appErr := e.applyShardSettings(index, state); appErr != nil {
+			mlog.Warn("Failed to apply the shard settings to the OpenSearch index", mlog.String("index", index), mlog.Err(appErr))
+		}
+	}
+}
+
+func (e *OpenSearchEngine) GetName() string {
+	return ENGINE_NAME
+}
+
+func (e *OpenSearchEngine) IsActive() bool {
+	return *e.cfg.ElasticsearchSettings.EnableIndexing && e.isLicensed()
+}
+
+func (e *OpenSearchEngine) IsIndexingEnabled() bool {
+	return *e.cfg.ElasticsearchSettings.EnableIndexing
+}
+
+func (e *OpenSearchEngine) IsSearchEnabled() bool {
+	return *e.cfg.ElasticsearchSettings.EnableSearching
+}
+
+func (e *OpenSearchEngine) IsAutocompletionEnabled() bool {
+	return *e.cfg.ElasticsearchSettings.EnableAutocomplete
+}
+
+func (e *OpenSearchEngine) IsIndexingSync() bool {
+	return false
+}
+
+func (e *OpenSearchEngine) TestConfig(cfg *model.Config) *model.AppError {
+	if !e.isLicensed() {
+		return model.NewAppError("OpenSearchEngine.TestConfig", "opensearchengine.license.error", nil, "", http.StatusNotImplemented)
+	}
+
+	client, err := newClient(&cfg.ElasticsearchSettings)
+	if err != nil {
+		return model.NewAppError("OpenSearchEngine.TestConfig", "opensearchengine.tls_config.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err := client.getVersion(); err != nil {
+		return connectError("OpenSearchEngine.TestConfig", err)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) PurgeIndexes() *model.AppError {
+	e.stopMigrations()
+
+	e.Mutex.Lock()
+	defer e.Mutex.Unlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.PurgeIndexes")
+	}
+
+	mlog.Info("PurgeIndexes OpenSearch")
+	for _, index := range []string{POST_INDEX, CHANNEL_INDEX, USER_INDEX, FILE_INDEX} {
+		// The indexes can't be deleted through their aliases.
+		names := []string{e.indexName(index)}
+		if state, ok := e.indexes[index]; ok {
+			names = []string{state.name, state.migratingTo}
+		}
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if err := e.client.deleteIndex(name); err != nil {
+				return model.NewAppError("OpenSearchEngine.PurgeIndexes", "opensearchengine.purge_index.error", map[string]interface{}{"Index": index}, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	return e.createIndexes()
+}
+
+func (e *OpenSearchEngine) RefreshIndexes() *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.RefreshIndexes")
+	}
+
+	if err := e.client.refreshIndexes(e.indexName(POST_INDEX), e.indexName(CHANNEL_INDEX), e.indexName(USER_INDEX), e.indexName(FILE_INDEX)); err != nil {
+		return model.NewAppError("OpenSearchEngine.RefreshIndexes", "opensearchengine.refresh_indexes.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// SetRefreshInterval changes how often the indexes are refreshed, REFRESH_INTERVAL_DISABLED
+// disabling the refresh and an empty interval restoring the default of the server.
+func (e *OpenSearchEngine) SetRefreshInterval(interval string) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.SetRefreshInterval")
+	}
+
+	if err := e.client.setRefreshInterval(interval, e.indexName(POST_INDEX), e.indexName(CHANNEL_INDEX), e.indexName(USER_INDEX), e.indexName(FILE_INDEX)); err != nil {
+		return model.NewAppError("OpenSearchEngine.SetRefreshInterval", "opensearchengine.set_refresh_interval.error", map[string]interface{}{"Interval": interval}, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) DataRetentionDeleteIndexes(cutoff time.Time) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.DataRetentionDeleteIndexes")
+	}
+
+	query := jsonObject{"range": jsonObject{"CreateAt": jsonObject{"lt": model.GetMillisForTime(cutoff)}}}
+	deleted, err := e.deleteByQuery(POST_INDEX, query)
+	if err != nil {
+		return model.NewAppError("OpenSearchEngine.DataRetentionDeleteIndexes", "opensearchengine.data_retention_delete_indexes.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Posts deleted by data retention", mlog.Int64("deleted", deleted))
+	return nil
+}
@@ -0,0 +1,990 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/einterfaces/mocks"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+// fakeServer emulates the subset of the OpenSearch API used by the engine.
+type fakeServer struct {
+	*httptest.Server
+
+	mut          sync.Mutex
+	distribution string
+	indexes      map[string]bool
+	// versions holds the mapping versions of the indexes, aliases the index of each alias, and
+	// shards and replicas the shard settings of the indexes.
+	versions     map[string]int
+	aliases      map[string]string
+	shards       map[string]int
+	replicas     map[string]int
+	documents    map[string]json.RawMessage
+	searches     []map[string]interface{}
+	searchResult string
+	// taskResult is the status of the reindex tasks.
+	taskResult string
+	// refreshIntervals holds the refresh interval set on each index, nil for the default.
+	refreshIntervals map[string]interface{}
+
+	// bulkRejections is the number of bulk actions to reject as if the server was overloaded.
+	bulkRejections int
+	bulkRequests   int
+	// unavailable is the number of requests to reject as if the server was restarting.
+	unavailable int
+	requests    int
+}
+
+func newFakeServer(t *testing.T, distribution string) *fakeServer {
+	s := &fakeServer{
+		distribution: distribution,
+		indexes:      map[string]bool{},
+		versions:     map[string]int{},
+		aliases:      map[string]string{},
+		shards:       map[string]int{},
+		replicas:     map[string]int{},
+		documents:    map[string]json.RawMessage{},
+		searchResult: `{"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}}`,
+		taskResult:   `{"completed": true}`,
+
+		refreshIntervals: map[string]interface{}{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mut.Lock()
+		defer s.mut.Unlock()
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		s.requests++
+		if s.unavailable > 0 {
+			s.unavailable--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		// The indexes are deleted by name, while the other requests may go through an alias.
+		if len(parts) > 1 || r.Method != http.MethodDelete {
+			parts[0] = s.resolve(parts[0])
+		}
+		switch {
+		case r.URL.Path == "/":
+			version := `{"version": {"number": "7.10.2"}}`
+			if s.distribution != "" {
+				version = `{"version": {"distribution": "` + s.distribution + `", "number": "1.3.2"}}`
+			}
+			w.Write([]byte(version))
+		case len(parts) == 1 && r.Method == http.MethodHead:
+			if !s.indexes[parts[0]] {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			var definition struct {
+				Mappings struct {
+					Meta struct {
+						MappingVersion int `json:"mapping_version"`
+					} `json:"_meta"`
+				} `json:"mappings"`
+				Aliases  map[string]interface{} `json:"aliases"`
+				Settings struct {
+					Index struct {
+						NumberOfShards   int `json:"number_of_shards"`
+						NumberOfReplicas int `json:"number_of_replicas"`
+					} `json:"index"`
+				} `json:"settings"`
+			}
+			require.NoError(t, json.Unmarshal(body, &definition))
+			s.indexes[parts[0]] = true
+			s.versions[parts[0]] = definition.Mappings.Meta.MappingVersion
+			s.shards[parts[0]] = definition.Settings.Index.NumberOfShards
+			s.replicas[parts[0]] = definition.Settings.Index.NumberOfReplicas
+			for alias := range definition.Aliases {
+				s.aliases[alias] = parts[0]
+			}
+			w.Write([]byte(`{"acknowledged": true}`))
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			if _, ok := s.aliases[parts[0]]; ok {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": {"type": "illegal_argument_exception", "reason": "matches an alias"}, "status": 400}`))
+				return
+			}
+			if !s.indexes[parts[0]] {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index"}, "status": 404}`))
+				return
+			}
+			s.deleteIndex(parts[0])
+			w.Write([]byte(`{"acknowledged": true}`))
+		case len(parts) == 2 && parts[1] == "_mapping" && r.Method == http.MethodGet:
+			if !s.indexes[parts[0]] {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index"}, "status": 404}`))
+				return
+			}
+			meta := ""
+			if version := s.versions[parts[0]]; version > 0 {
+				meta = fmt.Sprintf(`"_meta": {"mapping_version": %d}`, version)
+			}
+			w.Write([]byte(`{"` + parts[0] + `": {"mappings": {` + meta + `}}}`))
+		case len(parts) == 2 && parts[1] == "_settings" && r.Method == http.MethodGet:
+			w.Write([]byte(fmt.Sprintf(`{"%s": {"settings": {"index": {"number_of_shards": "%d", "number_of_replicas": "%d"}}}}`, parts[0], s.shards[parts[0]], s.replicas[parts[0]])))
+		case len(parts) == 2 && parts[1] == "_settings" && r.Method == http.MethodPut:
+			var settings struct {
+				Index map[string]interface{} `json:"index"`
+			}
+			require.NoError(t, json.Unmarshal(body, &settings))
+			if _, ok := settings.Index["number_of_shards"]; ok {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": {"type": "illegal_argument_exception", "reason": "final index setting"}, "status": 400}`))
+				return
+			}
+			if replicas, ok := settings.Index["number_of_replicas"]; ok {
+				s.replicas[parts[0]] = int(replicas.(float64))
+			}
+			if interval, ok := settings.Index["refresh_interval"]; ok {
+				for _, index := range strings.Split(parts[0], ",") {
+					s.refreshIntervals[s.resolve(index)] = interval
+				}
+			}
+			w.Write([]byte(`{"acknowledged": true}`))
+		case r.URL.Path == "/_reindex":
+			assert.Equal(t, "false", r.URL.Query().Get("wait_for_completion"))
+			var reindex struct {
+				Source struct {
+					Index string `json:"index"`
+				} `json:"source"`
+				Dest struct {
+					Index  string `json:"index"`
+					OpType string `json:"op_type"`
+				} `json:"dest"`
+			}
+			require.NoError(t, json.Unmarshal(body, &reindex))
+			assert.Equal(t, "create", reindex.Dest.OpType)
+			for key, document := range s.documents {
+				if id := strings.TrimPrefix(key, reindex.Source.Index+"/"); id != key {
+					if _, ok := s.documents[reindex.Dest.Index+"/"+id]; !ok {
+						s.documents[reindex.Dest.Index+"/"+id] = document
+					}
+				}
+			}
+			w.Write([]byte(`{"task": "node:1"}`))
+		case len(parts) == 2 && parts[0] == "_tasks":
+			w.Write([]byte(s.taskResult))
+		case r.URL.Path == "/_aliases":
+			var request struct {
+				Actions []map[string]struct {
+					Index string `json:"index"`
+					Alias string `json:"alias"`
+				} `json:"actions"`
+			}
+			require.NoError(t, json.Unmarshal(body, &request))
+			for _, action := range request.Actions {
+				for operation, target := range action {
+					switch operation {
+					case "add":
+						s.aliases[target.Alias] = target.Index
+					case "remove":
+						delete(s.aliases, target.Alias)
+					case "remove_index":
+						s.deleteIndex(target.Index)
+					}
+				}
+			}
+			w.Write([]byte(`{"acknowledged": true}`))
+		case len(parts) == 3 && parts[1] == "_doc" && r.Method == http.MethodPut:
+			s.documents[parts[0]+"/"+parts[2]] = body
+			w.Write([]byte(`{"result": "created"}`))
+		case len(parts) == 3 && parts[1] == "_doc" && r.Method == http.MethodDelete:
+			if _, ok := s.documents[parts[0]+"/"+parts[2]]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"result": "not_found"}`))
+				return
+			}
+			delete(s.documents, parts[0]+"/"+parts[2])
+			w.Write([]byte(`{"result": "deleted"}`))
+		case len(parts) == 2 && parts[1] == "_search":
+			var search map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &search))
+			s.searches = append(s.searches, search)
+			w.Write([]byte(s.searchResult))
+		case len(parts) == 2 && parts[1] == "_delete_by_query":
+			w.Write([]byte(`{"deleted": 3}`))
+		case r.URL.Path == "/_bulk":
+			s.bulkRequests++
+			w.Write(s.applyBulk(t, body))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"type": "illegal_argument_exception", "reason": "unexpected request"}, "status": 400}`))
+		}
+	}))
+
+	return s
+}
+
+// resolve returns the index of an alias, or the given name if it's not an alias.
+func (s *fakeServer) resolve(name string) string {
+	if index, ok := s.aliases[name]; ok {
+		return index
+	}
+	return name
+}
+
+func (s *fakeServer) deleteIndex(index string) {
+	delete(s.indexes, index)
+	delete(s.versions, index)
+	delete(s.shards, index)
+	delete(s.replicas, index)
+	for alias, target := range s.aliases {
+		if target == index {
+			delete(s.aliases, alias)
+		}
+	}
+	for key := range s.documents {
+		if strings.HasPrefix(key, index+"/") {
+			delete(s.documents, key)
+		}
+	}
+}
+
+// applyBulk applies the actions of a bulk request body, rejecting the first bulkRejections of
+// them.
+func (s *fakeServer) applyBulk(t *testing.T, body []byte) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+
+	var items []map[string]interface{}
+	for i := 0; i < len(lines); i++ {
+		var action map[string]struct {
+			Index string `json:"_index"`
+			Id    string `json:"_id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &action))
+
+		for operation, target := range action {
+			key := s.resolve(target.Index) + "/" + target.Id
+			status := http.StatusOK
+			if operation == "index" {
+				i++
+			}
+
+			switch {
+			case s.bulkRejections > 0:
+				s.bulkRejections--
+				status = http.StatusTooManyRequests
+			case operation == "index":
+				s.documents[key] = json.RawMessage(lines[i])
+			case operation == "delete":
+				if _, ok := s.documents[key]; !ok {
+					status = http.StatusNotFound
+				}
+				delete(s.documents, key)
+			}
+			items = append(items, map[string]interface{}{operation: map[string]interface{}{"_id": target.Id, "status": status}})
+		}
+	}
+
+	response, err := json.Marshal(map[string]interface{}{"errors": true, "items": items})
+	require.NoError(t, err)
+	return response
+}
+
+func (s *fakeServer) document(index, id string) map[string]interface{} {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	data, ok := s.documents[s.resolve(index)+"/"+id]
+	if !ok {
+		return nil
+	}
+	var document map[string]interface{}
+	json.Unmarshal(data, &document)
+	return document
+}
+
+func newTestEngine(t *testing.T, server *fakeServer, licensed bool) *OpenSearchEngine {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.ElasticsearchSettings.ConnectionUrl = server.URL
+	*cfg.ElasticsearchSettings.EnableIndexing = true
+	*cfg.ElasticsearchSettings.IndexPrefix = "test_"
+
+	license := func() *model.License {
+		if !licensed {
+			return nil
+		}
+		license := &model.License{Features: &model.Features{}}
+		license.Features.SetDefaults()
+		*license.Features.Elasticsearch = true
+		return license
+	}
+
+	return NewOpenSearchEngine(cfg, license, nil, nil)
+}
+
+func TestOpenSearchEngineStart(t *testing.T) {
+	t.Run("creates the indexes", func(t *testing.T) {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		defer server.Close()
+
+		engine := newTestEngine(t, server, true)
+		require.Nil(t, engine.Start())
+		defer engine.Stop()
+
+		assert.True(t, engine.IsActive())
+		assert.Equal(t, 1, engine.GetVersion())
+		assert.Equal(t, map[string]bool{"test_posts_v1": true, "test_channels_v1": true, "test_users_v1": true, "test_files_v1": true}, server.indexes)
+		assert.Equal(t, map[string]string{"test_posts": "test_posts_v1", "test_channels": "test_channels_v1", "test_users": "test_users_v1", "test_files": "test_files_v1"}, server.aliases)
+		assert.Equal(t, 1, server.versions["test_posts_v1"])
+
+		appErr := engine.Start()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.already_started.error", appErr.Id)
+	})
+
+	t.Run("rejects Elasticsearch servers", func(t *testing.T) {
+		server := newFakeServer(t, "")
+		defer server.Close()
+
+		engine := newTestEngine(t, server, true)
+		appErr := engine.Start()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.connect.error", appErr.Id)
+
+		appErr = engine.TestConfig(engine.cfg)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.connect.error", appErr.Id)
+	})
+
+	t.Run("requires a license", func(t *testing.T) {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		defer server.Close()
+
+		engine := newTestEngine(t, server, false)
+		assert.False(t, engine.IsActive())
+
+		appErr := engine.Start()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.license.error", appErr.Id)
+	})
+
+	t.Run("is not started when indexing is disabled", func(t *testing.T) {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		defer server.Close()
+
+		engine := newTestEngine(t, server, true)
+		*engine.cfg.ElasticsearchSettings.EnableIndexing = false
+		require.Nil(t, engine.Start())
+		assert.False(t, engine.IsActive())
+
+		appErr := engine.IndexPost(&model.Post{Id: model.NewId()}, model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.not_started.error", appErr.Id)
+	})
+}
+
+func TestOpenSearchEngineMappingMigration(t *testing.T) {
+	defer func(interval time.Duration) { reindexPollInterval = interval }(reindexPollInterval)
+	reindexPollInterval = 10 * time.Millisecond
+
+	// newLegacyServer returns a server with a posts index created before the mappings were
+	// versioned.
+	newLegacyServer := func(t *testing.T) *fakeServer {
+		server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+		server.indexes["test_posts"] = true
+		server.shards["test_posts"] = 1
+		server.replicas["test_posts"] = 1
+		server.documents["test_posts/post1"] = json.RawMessage(`{"Message": "hello"}`)
+		return server
+	}
+
+	postsVersion := func(engine *OpenSearchEngine) searchengine.IndexMappingVersion {
+		for _, version := range engine.GetIndexMappingVersions() {
+			if version.Index == "test_posts" {
+				return version
+			}
+		}
+		return searchengine.IndexMappingVersion{}
+	}
+
+	t.Run("migrates the outdated indexes behind an alias", func(t *testing.T) {
+		server := newLegacyServer(t)
+		defer server.Close()
+
+		engine := newTestEngine(t, server, true)
+		require.Nil(t, engine.Start())
+		defer engine.Stop()
+
+		require.Eventually(t, func() bool {
+			return !postsVersion(engine).Migrating
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, searchengine.IndexMappingVersion{Index: "test_posts", Current: 1, Expected: 1, Shards: 1, ExpectedShards: 1}, postsVersion(engine))
+
+		server.mut.Lock()
+		assert.Equal(t, map[string]bool{"test_posts_v1": true, "test_channels_v1": true, "test_users_v1": true, "test_files_v1": true}, server.indexes)
+		assert.Equal(t, "test_posts_v1", server.aliases["test_posts"])
+		server.mut.Unlock()
+		assert.Equal(t, "hello", server.document("test_posts_v1", "post1")["Message"])
+	})
+
+	t.Run("writes to both indexes while migrating", func(t *testing.T) {
+		server := newLegacyServer(t)
+		defer server.Close()
+		server.taskResult = `{"completed": false}`
+
+		engine := newTestEngine(t, server, true)
+		require.Nil(t, engine.Start())
+
+		assert.Equal(t, searchengine.IndexMappingVersion{Index: "test_posts", Current: 0, Expected: 1, Migrating: true, Shards: 1, ExpectedShards: 1}, postsVersion(engine))
+
+		post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), Message: "hello again"}
+		require.Nil(t, engine.IndexPost(post, model.NewId()))
+		assert.NotNil(t, server.document("test_posts", post.Id))
+		assert.NotNil(t, server.document("test_posts_v1", post.Id))
+
+		require.Nil(t, engine.DeletePost(post))
+		assert.Nil(t, server.document("test_posts", post.Id))
+		assert.Nil(t, server.document("test_posts_v1", post.Id))
+
+		require.Nil(t, engine.Stop())
+		server.mut.Lock()
+		assert.Equal(t, "", server.aliases["test_posts"], "the alias should not move before the migration is done")
+		server.mut.Unlock()
+	})
+
+	t.Run("keeps the old index when the migration fails", func(t *testing.T) {
+		server := newLegacyServer(t)
+		defer server.Close()
+		server.taskResult = `{"completed": true, "error": {"type": "illegal_argument_exception", "reason": "bad mapping"}}`
+
+		engine := newTestEngine(t, server, true)
+		require.Nil(t, engine.Start())
+		defer engine.Stop()
+
+		require.Eventually(t, func() bool {
+			return !postsVersion(engine).Migrating
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 0, postsVersion(engine).Current)
+
+		server.mut.Lock()
+		defer server.mut.Unlock()
+		assert.True(t, server.indexes["test_posts"])
+		assert.False(t, server.indexes["test_posts_v1"])
+	})
+}
+
+func TestOpenSearchEngineShardSettings(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	*engine.cfg.ElasticsearchSettings.PostIndexShards = 3
+	*engine.cfg.ElasticsearchSettings.PostIndexReplicas = 2
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	postsVersion := func() searchengine.IndexMappingVersion {
+		for _, version := range engine.GetIndexMappingVersions() {
+			if version.Index == "test_posts" {
+				return version
+			}
+		}
+		return searchengine.IndexMappingVersion{}
+	}
+
+	server.mut.Lock()
+	assert.Equal(t, 3, server.shards["test_posts_v1"])
+	assert.Equal(t, 2, server.replicas["test_posts_v1"])
+	assert.Equal(t, 1, server.shards["test_users_v1"], "the other indexes should keep the default settings")
+	server.mut.Unlock()
+	assert.Equal(t, 3, postsVersion().Shards)
+	assert.Equal(t, 3, postsVersion().ExpectedShards)
+
+	t.Run("applies a change of the replicas", func(t *testing.T) {
+		cfg := engine.cfg.Clone()
+		*cfg.ElasticsearchSettings.PostIndexReplicas = 0
+		engine.UpdateConfig(cfg)
+
+		server.mut.Lock()
+		defer server.mut.Unlock()
+		assert.Equal(t, 0, server.replicas["test_posts_v1"])
+	})
+
+	t.Run("reports a change of the shards as needing a reindex", func(t *testing.T) {
+		cfg := engine.cfg.Clone()
+		*cfg.ElasticsearchSettings.PostIndexShards = 5
+		engine.UpdateConfig(cfg)
+
+		assert.Equal(t, 3, postsVersion().Shards)
+		assert.Equal(t, 5, postsVersion().ExpectedShards)
+		server.mut.Lock()
+		assert.Equal(t, 3, server.shards["test_posts_v1"], "the existing index should be left as it is")
+		server.mut.Unlock()
+
+		post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), Message: "hello"}
+		require.Nil(t, engine.IndexPost(post, model.NewId()))
+		assert.NotNil(t, server.document("test_posts", post.Id), "the documents should still be written to the existing index")
+
+		require.Nil(t, engine.PurgeIndexes())
+		assert.Equal(t, 5, postsVersion().Shards)
+		server.mut.Lock()
+		assert.Equal(t, 5, server.shards["test_posts_v1"])
+		server.mut.Unlock()
+	})
+}
+
+func TestOpenSearchEngineSetRefreshInterval(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	require.NotNil(t, engine.SetRefreshInterval(REFRESH_INTERVAL_DISABLED), "the engine should have to be started")
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	require.Nil(t, engine.SetRefreshInterval(REFRESH_INTERVAL_DISABLED))
+	server.mut.Lock()
+	assert.Equal(t, map[string]interface{}{
+		"test_posts_v1":    "-1",
+		"test_channels_v1": "-1",
+		"test_users_v1":    "-1",
+		"test_files_v1":    "-1",
+	}, server.refreshIntervals)
+	server.mut.Unlock()
+
+	require.Nil(t, engine.SetRefreshInterval(""))
+	server.mut.Lock()
+	assert.Nil(t, server.refreshIntervals["test_posts_v1"], "an empty interval should restore the default")
+	server.mut.Unlock()
+}
+
+func TestOpenSearchEngineIndexPrefix(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	*engine.cfg.SearchSettings.IndexPrefix = "blue_"
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	server.mut.Lock()
+	assert.Equal(t, map[string]bool{"blue_posts_v1": true, "blue_channels_v1": true, "blue_users_v1": true, "blue_files_v1": true}, server.indexes, "the prefix should override the one of the Elasticsearch settings")
+	assert.Equal(t, "blue_posts_v1", server.aliases["blue_posts"])
+	server.mut.Unlock()
+
+	post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), Message: "hello"}
+	require.Nil(t, engine.IndexPost(post, model.NewId()))
+	assert.NotNil(t, server.document("blue_posts", post.Id))
+
+	t.Run("switches to the indexes of a new prefix", func(t *testing.T) {
+		cfg := engine.cfg.Clone()
+		*cfg.SearchSettings.IndexPrefix = "green_"
+		engine.UpdateConfig(cfg)
+
+		server.mut.Lock()
+		assert.Equal(t, "green_posts_v1", server.aliases["green_posts"])
+		assert.True(t, server.indexes["blue_posts_v1"], "the indexes of the previous prefix should be left as they are")
+		server.mut.Unlock()
+
+		other := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), Message: "hello"}
+		require.Nil(t, engine.IndexPost(other, model.NewId()))
+		assert.NotNil(t, server.document("green_posts", other.Id))
+		assert.Nil(t, server.document("blue_posts", other.Id))
+	})
+
+	t.Run("falls back to the prefix of the Elasticsearch settings", func(t *testing.T) {
+		cfg := engine.cfg.Clone()
+		*cfg.SearchSettings.IndexPrefix = ""
+		engine.UpdateConfig(cfg)
+
+		server.mut.Lock()
+		defer server.mut.Unlock()
+		assert.Equal(t, "test_posts_v1", server.aliases["test_posts"])
+	})
+}
+
+func TestOpenSearchEngineIndexing(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "hello #world",
+		Hashtags:  "#world",
+		CreateAt:  1000,
+	}
+	teamId := model.NewId()
+	require.Nil(t, engine.IndexPost(post, teamId))
+
+	document := server.document("test_posts", post.Id)
+	require.NotNil(t, document)
+	assert.Equal(t, teamId, document["TeamId"])
+	assert.Equal(t, post.Message, document["Message"])
+	assert.Equal(t, []interface{}{"#world"}, document["Hashtags"])
+
+	require.Nil(t, engine.DeletePost(post))
+	assert.Nil(t, server.document("test_posts", post.Id))
+	assert.Nil(t, engine.DeletePost(post), "deleting a missing document should succeed")
+
+	channel := &model.Channel{Id: model.NewId(), TeamId: teamId, Name: "town-square", DisplayName: "Town Square"}
+	require.Nil(t, engine.IndexChannel(channel))
+	document = server.document("test_channels", channel.Id)
+	require.NotNil(t, document)
+	assert.Contains(t, document["NameSuggest"], "square")
+
+	user := &model.User{Id: model.NewId(), Username: "john.doe", FirstName: "John"}
+	require.Nil(t, engine.IndexUser(user, []string{teamId}, []string{channel.Id}))
+	document = server.document("test_users", user.Id)
+	require.NotNil(t, document)
+	assert.Contains(t, document["SuggestionsWithFullname"], "john")
+	assert.Equal(t, []interface{}{channel.Id}, document["ChannelsIds"])
+
+	file := &model.FileInfo{Id: model.NewId(), CreatorId: user.Id, PostId: post.Id, Name: "report.pdf", Extension: "pdf", Content: "quarterly figures"}
+	require.Nil(t, engine.IndexFile(file, channel.Id))
+	document = server.document("test_files", file.Id)
+	require.NotNil(t, document)
+	assert.Equal(t, channel.Id, document["ChannelId"])
+	assert.Equal(t, file.Content, document["Content"])
+
+	require.Nil(t, engine.DeleteFile(file.Id))
+	assert.Nil(t, server.document("test_files", file.Id))
+	assert.Nil(t, engine.DeletePostFiles(post.Id))
+	assert.Nil(t, engine.DeleteUserFiles(user.Id))
+
+	assert.Nil(t, engine.DeleteChannelPosts(channel.Id))
+	assert.Nil(t, engine.PurgeIndexes())
+}
+
+func TestOpenSearchEngineSearch(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	channels := &model.ChannelList{{Id: model.NewId()}}
+
+	t.Run("hits total as an object", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 2, "relation": "eq"}, "hits": [
+			{"_id": "post1", "highlight": {"Message": ["say <mark>hello</mark> to <mark>everyone</mark>"]}},
+			{"_id": "post2"}
+		]}}`
+
+		params := model.ParseSearchParams("hello everyone", 0)
+		ids, matches, appErr := engine.SearchPosts(channels, params, 1, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post1", "post2"}, ids)
+		assert.Equal(t, model.PostSearchMatches{"post1": {"hello", "everyone"}}, matches)
+
+		search := server.searches[len(server.searches)-1]
+		assert.EqualValues(t, 20, search["from"])
+		assert.EqualValues(t, 20, search["size"])
+	})
+
+	t.Run("hits total as a number", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": 1, "hits": [{"_id": "post3"}]}}`
+
+		ids, _, appErr := engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post3"}, ids)
+	})
+
+	t.Run("search after a cursor", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 5, "relation": "eq"}, "hits": [
+			{"_id": "post6", "sort": [1.5, 3000, "post6"]},
+			{"_id": "post7", "sort": [null, 2000, "post7"]}
+		]}}`
+
+		params := model.ParseSearchParams("hello", 0)
+		ids, _, cursor, appErr := engine.SearchPostsAfter(channels, params, nil, 2)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post6", "post7"}, ids)
+		assert.Equal(t, &model.PostSearchCursor{Score: 0, CreateAt: 2000, Id: "post7"}, cursor)
+
+		search := server.searches[len(server.searches)-1]
+		assert.NotContains(t, search, "search_after")
+		assert.NotContains(t, search, "from")
+		assert.EqualValues(t, 2, search["size"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"_score": map[string]interface{}{"order": "desc"}},
+			map[string]interface{}{"CreateAt": map[string]interface{}{"order": "desc"}},
+			map[string]interface{}{"Id": map[string]interface{}{"order": "desc"}},
+		}, search["sort"])
+
+		server.searchResult = `{"hits": {"total": {"value": 5, "relation": "eq"}, "hits": [
+			{"_id": "post8", "sort": [1.2, 1000, "post8"]}
+		]}}`
+		ids, _, cursor, appErr = engine.SearchPostsAfter(channels, params, &model.PostSearchCursor{Score: 1.5, CreateAt: 3000, Id: "post6"}, 2)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post8"}, ids)
+		assert.Nil(t, cursor)
+
+		search = server.searches[len(server.searches)-1]
+		assert.Equal(t, []interface{}{1.5, float64(3000), "post6"}, search["search_after"])
+	})
+
+	t.Run("document searchable", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 1, "relation": "eq"}, "hits": []}}`
+		searchable, appErr := engine.IsDocumentSearchable("post5")
+		require.Nil(t, appErr)
+		assert.True(t, searchable)
+
+		search := server.searches[len(server.searches)-1]
+		assert.Equal(t, map[string]interface{}{"ids": map[string]interface{}{"values": []interface{}{"post5"}}}, search["query"])
+
+		server.searchResult = `{"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}}`
+		searchable, appErr = engine.IsDocumentSearchable("post5")
+		require.Nil(t, appErr)
+		assert.False(t, searchable)
+	})
+
+	t.Run("search in all the channels", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": 1, "hits": [{"_id": "post4"}]}}`
+
+		ids, _, appErr := engine.SearchPostsInAllChannels(model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post4"}, ids)
+
+		search, err := json.Marshal(server.searches[len(server.searches)-1])
+		require.NoError(t, err)
+		assert.NotContains(t, string(search), "ChannelId")
+
+		_, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+		search, err = json.Marshal(server.searches[len(server.searches)-1])
+		require.NoError(t, err)
+		assert.Contains(t, string(search), (*channels)[0].Id)
+	})
+
+	t.Run("fuzzy search", func(t *testing.T) {
+		params := model.ParseSearchParams("recieve", 0)
+		_, _, appErr := engine.SearchPosts(channels, params, 0, 20)
+		require.Nil(t, appErr)
+
+		search := server.searches[len(server.searches)-1]
+		query := search["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		match := query["must"].([]interface{})[0].(map[string]interface{})["bool"].(map[string]interface{})["must"].([]interface{})[0]
+		assert.Equal(t, map[string]interface{}{"match": map[string]interface{}{"Message": map[string]interface{}{"query": "recieve", "operator": "and"}}}, match)
+
+		params[0].Fuzziness = 1
+		_, _, appErr = engine.SearchPosts(channels, params, 0, 20)
+		require.Nil(t, appErr)
+
+		search = server.searches[len(server.searches)-1]
+		query = search["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		match = query["must"].([]interface{})[0].(map[string]interface{})["bool"].(map[string]interface{})["must"].([]interface{})[0]
+		assert.Equal(t, map[string]interface{}{"match": map[string]interface{}{"Message": map[string]interface{}{"query": "recieve", "operator": "and", "fuzziness": float64(1)}}}, match)
+	})
+
+	t.Run("search files", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [{"_id": "file1"}]}}`
+
+		fileIds, appErr := engine.SearchFiles(channels, model.ParseSearchParams("report -draft", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"file1"}, fileIds)
+
+		search := server.searches[len(server.searches)-1]
+		query := search["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Len(t, query["filter"], 1)
+		assert.Len(t, query["must"], 1)
+	})
+
+	t.Run("suggest terms", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 2, "relation": "eq"}, "hits": [
+			{"_id": "post1", "_source": {"Message": "the deployment failed"}},
+			{"_id": "post2", "_source": {"Message": "Deploying again, same deployment"}}
+		]}}`
+
+		terms, appErr := engine.SuggestTerms(channels, "Deploy", 5)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"deployment", "deploying"}, terms)
+
+		search := server.searches[len(server.searches)-1]
+		assert.Equal(t, []interface{}{"Message"}, search["_source"])
+		query := search["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{"prefix": map[string]interface{}{"Message": "deploy"}}}, query["must"])
+	})
+
+	t.Run("search users and channels", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [{"_id": "id1"}]}}`
+
+		channelIds, appErr := engine.SearchChannels(model.NewId(), "town")
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"id1"}, channelIds)
+
+		options := &model.UserSearchOptions{Limit: 10}
+		uchan, nuchan, appErr := engine.SearchUsersInChannel(model.NewId(), model.NewId(), nil, "john", options)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"id1"}, uchan)
+		assert.Equal(t, []string{"id1"}, nuchan)
+
+		userIds, appErr := engine.SearchUsersInTeam("", []string{}, "john", options)
+		require.Nil(t, appErr)
+		assert.Empty(t, userIds)
+	})
+}
+
+func TestOpenSearchEngineRequestRetries(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	metrics := &mocks.MetricsInterface{}
+	metrics.On("IncrementSearchRequestRetryCounter", ENGINE_NAME, "search").Return()
+
+	engine := newTestEngine(t, server, true)
+	engine.metrics = func() einterfaces.MetricsInterface { return metrics }
+	*engine.cfg.SearchSettings.RequestRetries = 2
+	*engine.cfg.SearchSettings.RequestRetryBackoffMilliseconds = 1
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	channels := &model.ChannelList{{Id: model.NewId()}}
+	params := model.ParseSearchParams("hello", 0)
+
+	t.Run("retries the searches until they succeed", func(t *testing.T) {
+		server.unavailable = 2
+		_, _, appErr := engine.SearchPosts(channels, params, 0, 20)
+		require.Nil(t, appErr)
+		metrics.AssertNumberOfCalls(t, "IncrementSearchRequestRetryCounter", 2)
+	})
+
+	t.Run("fails once the retries are exhausted", func(t *testing.T) {
+		server.unavailable = 3
+		server.requests = 0
+		_, _, appErr := engine.SearchPosts(channels, params, 0, 20)
+		require.NotNil(t, appErr)
+		assert.Equal(t, 3, server.requests)
+		server.unavailable = 0
+	})
+
+	t.Run("doesn't retry the writes", func(t *testing.T) {
+		server.unavailable = 1
+		server.requests = 0
+		post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Message: "hello"}
+		require.NotNil(t, engine.IndexPost(post, model.NewId()))
+		assert.Equal(t, 1, server.requests)
+	})
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, isTransient(&transportError{errors.New("connection refused")}))
+	assert.True(t, isTransient(&requestError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isTransient(&requestError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, isTransient(&requestError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, isTransient(&requestError{StatusCode: http.StatusNotFound}))
+	assert.False(t, isTransient(errors.New("failed to decode response")))
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{backoff: time.Second}
+	assert.Equal(t, time.Second, policy.delay(0))
+	assert.Equal(t, 2*time.Second, policy.delay(1))
+	assert.Equal(t, 4*time.Second, policy.delay(2))
+	assert.Equal(t, REQUEST_MAX_RETRY_BACKOFF, policy.delay(3))
+	assert.Equal(t, REQUEST_MAX_RETRY_BACKOFF, policy.delay(40))
+}
+
+func TestOpenSearchEngineLanguageAnalyzers(t *testing.T) {
+	server := newFakeServer(t, OPENSEARCH_DISTRIBUTION)
+	defer server.Close()
+
+	engine := newTestEngine(t, server, true)
+	engine.cfg.SearchSettings.LanguageAnalyzers = map[string]string{"ja": "kuromoji", "de": "german"}
+	require.Nil(t, engine.Start())
+	defer engine.Stop()
+
+	t.Run("maps the localized messages to their analyzers", func(t *testing.T) {
+		definition := getPostIndexDefinition(&engine.cfg.ElasticsearchSettings, engine.cfg.SearchSettings.LanguageAnalyzers)
+		properties := definition["mappings"].(jsonObject)["properties"].(jsonObject)
+		assert.Equal(t, textMapping, properties["Message"])
+		assert.Equal(t, jsonObject{"properties": jsonObject{
+			"ja": jsonObject{"type": "text", "analyzer": "kuromoji"},
+			"de": jsonObject{"type": "text", "analyzer": "german"},
+		}}, properties["MessageLocalized"])
+
+		definition = getPostIndexDefinition(&engine.cfg.ElasticsearchSettings, map[string]string{})
+		properties = definition["mappings"].(jsonObject)["properties"].(jsonObject)
+		assert.NotContains(t, properties, "MessageLocalized")
+	})
+
+	t.Run("indexes the message under its language", func(t *testing.T) {
+		post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Message: "明日の会議は10時からです"}
+		require.Nil(t, engine.IndexPost(post, model.NewId()))
+
+		document := server.document("test_posts", post.Id)
+		require.NotNil(t, document)
+		assert.Equal(t, post.Message, document["Message"])
+		assert.Equal(t, "ja", document["Language"])
+		assert.Equal(t, map[string]interface{}{"ja": post.Message}, document["MessageLocalized"])
+
+		post = &model.Post{Id: model.NewId(), ChannelId: model.NewId(), UserId: model.NewId(), Message: "ok"}
+		require.Nil(t, engine.IndexPost(post, model.NewId()))
+
+		document = server.document("test_posts", post.Id)
+		require.NotNil(t, document)
+		assert.NotContains(t, document, "Language")
+		assert.NotContains(t, document, "MessageLocalized")
+	})
+
+	t.Run("searches across the analyzed fields", func(t *testing.T) {
+		server.searchResult = `{"hits": {"total": 1, "hits": [
+			{"_id": "post1", "highlight": {"MessageLocalized.ja": ["<mark>会議</mark>は10時から"], "Message": ["<mark>会議</mark>は10時から"]}}
+		]}}`
+
+		channels := &model.ChannelList{{Id: model.NewId()}}
+		ids, matches, highlights, appErr := engine.SearchPostsWithHighlights(channels, model.ParseSearchParams("会議", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"post1"}, ids)
+		assert.Equal(t, model.PostSearchMatches{"post1": {"会議"}}, matches)
+		assert.Equal(t, model.PostSearchHighlights{"post1": {"<mark>会議</mark>は10時から"}}, highlights)
+
+		search := server.searches[len(server.searches)-1]
+		query := search["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		match := query["must"].([]interface{})[0].(map[string]interface{})["bool"].(map[string]interface{})["must"].([]interface{})[0]
+		assert.Equal(t, map[string]interface{}{"multi_match": map[string]interface{}{
+			"query":    "会議",
+			"fields":   []interface{}{"Message", "MessageLocalized.de", "MessageLocalized.ja"},
+			"operator": "and",
+		}}, match)
+		assert.Contains(t, search["highlight"].(map[string]interface{})["fields"], "MessageLocalized.ja")
+	})
+}
+
+func TestParseRequestError(t *testing.T) {
+	reqErr := parseRequestError(http.StatusNotFound, []byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index"}, "status": 404}`))
+	assert.Equal(t, "index_not_found_exception", reqErr.Type)
+	assert.Equal(t, "no such index", reqErr.Reason)
+	assert.True(t, isNotFound(reqErr))
+
+	reqErr = parseRequestError(http.StatusBadRequest, []byte(`{"error": "Incorrect HTTP method", "status": 405}`))
+	assert.Equal(t, "Incorrect HTTP method", reqErr.Reason)
+	assert.False(t, isNotFound(reqErr))
+
+	reqErr = parseRequestError(http.StatusInternalServerError, []byte(`not json`))
+	assert.Equal(t, http.StatusInternalServerError, reqErr.StatusCode)
+}
@@ -0,0 +1,840 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func termsQuery(field string, values []string) jsonObject {
+	return jsonObject{"terms": jsonObject{field: values}}
+}
+
+func termQuery(field, value string) jsonObject {
+	return jsonObject{"term": jsonObject{field: value}}
+}
+
+func prefixQuery(field, value string) jsonObject {
+	return jsonObject{"prefix": jsonObject{field: value}}
+}
+
+func matchQuery(field, value, operator string) jsonObject {
+	return jsonObject{"match": jsonObject{field: jsonObject{"query": value, "operator": operator}}}
+}
+
+// fuzzyMatchQuery is a match query also matching the words within the fuzziness edit distance
+// of the value, or a plain match query when the fuzziness is zero.
+func fuzzyMatchQuery(field, value, operator string, fuzziness int) jsonObject {
+	if fuzziness <= 0 {
+		return matchQuery(field, value, operator)
+	}
+	return jsonObject{"match": jsonObject{field: jsonObject{"query": value, "operator": operator, "fuzziness": fuzziness}}}
+}
+
+// messageMatchQuery is a fuzzy match query on the message fields, searching all of them when
+// there are several.
+func messageMatchQuery(fields []string, value, operator string, fuzziness int) jsonObject {
+	if len(fields) == 1 {
+		return fuzzyMatchQuery(fields[0], value, operator, fuzziness)
+	}
+
+	query := jsonObject{"query": value, "fields": fields, "operator": operator}
+	if fuzziness > 0 {
+		query["fuzziness"] = fuzziness
+	}
+	return jsonObject{"multi_match": query}
+}
+
+func rangeQuery(field string, bounds jsonObject) jsonObject {
+	return jsonObject{"range": jsonObject{field: bounds}}
+}
+
+// boolQuery combines the given clauses, leaving out the empty ones.
+func boolQuery(clauses jsonObject) jsonObject {
+	query := jsonObject{}
+	for occur, queries := range clauses {
+		if queries, ok := queries.([]jsonObject); ok && len(queries) > 0 {
+			query[occur] = queries
+		}
+	}
+	if should, ok := query["should"]; ok && len(should.([]jsonObject)) > 0 {
+		query["minimum_should_match"] = 1
+	}
+	return jsonObject{"bool": query}
+}
+
+// getMatches extracts the highlighted terms of a search hit. A term highlighted in several fields
+// of the message is only returned once.
+func getMatches(hit searchHit) []string {
+	matches := []string{}
+	seen := map[string]bool{}
+	for _, fragments := range hit.Highlight {
+		for _, fragment := range fragments {
+			for {
+				start := strings.Index(fragment, model.POST_SEARCH_HIGHLIGHT_PRE_TAG)
+				if start == -1 {
+					break
+				}
+				fragment = fragment[start+len(model.POST_SEARCH_HIGHLIGHT_PRE_TAG):]
+				end := strings.Index(fragment, model.POST_SEARCH_HIGHLIGHT_POST_TAG)
+				if end == -1 {
+					break
+				}
+				if match := fragment[:end]; !seen[match] {
+					seen[match] = true
+					matches = append(matches, match)
+				}
+				fragment = fragment[end+len(model.POST_SEARCH_HIGHLIGHT_POST_TAG):]
+			}
+		}
+	}
+	return matches
+}
+
+func (e *OpenSearchEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.IndexPost")
+	}
+
+	osPost := OSPostFromPost(post, teamId, analyzedLanguages(e.cfg.SearchSettings.LanguageAnalyzers))
+	if err := e.indexDocument(POST_INDEX, osPost.Id, osPost); err != nil {
+		return model.NewAppError("OpenSearchEngine.IndexPost", "opensearchengine.index_post.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	if channels == nil {
+		channels = &model.ChannelList{}
+	}
+	postIds, matches, _, appErr := e.searchPosts("OpenSearchEngine.SearchPosts", channels, searchParams, page, perPage)
+	return postIds, matches, appErr
+}
+
+func (e *OpenSearchEngine) SearchPostsWithHighlights(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, model.PostSearchHighlights, *model.AppError) {
+	if channels == nil {
+		channels = &model.ChannelList{}
+	}
+	return e.searchPosts("OpenSearchEngine.SearchPostsWithHighlights", channels, searchParams, page, perPage)
+}
+
+func (e *OpenSearchEngine) SearchPostsInAllChannels(searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	postIds, matches, _, appErr := e.searchPosts("OpenSearchEngine.SearchPostsInAllChannels", nil, searchParams, page, perPage)
+	return postIds, matches, appErr
+}
+
+// searchPosts searches the posts of the given channels, or of all the channels if channels is
+// nil.
+func (e *OpenSearchEngine) searchPosts(where string, channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, model.PostSearchHighlights, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, nil, nil, notStartedError(where)
+	}
+
+	query, fields := e.postSearchQuery(channels, searchParams)
+	query["sort"] = []jsonObject{{"CreateAt": jsonObject{"order": "desc"}}}
+	query["from"] = page * perPage
+	query["size"] = perPage
+
+	results, err := e.client.search(e.indexName(POST_INDEX), query)
+	if err != nil {
+		return nil, nil, nil, model.NewAppError(where, "opensearchengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	postIds, matches, highlights := getPostSearchResults(results, fields)
+	return postIds, matches, highlights, nil
+}
+
+// SearchPostsAfter searches the posts of the given channels sorted by score, and then by
+// CreateAt and Id to break the ties, returning the page following searchAfter through the
+// search_after parameter of the searches.
+func (e *OpenSearchEngine) SearchPostsAfter(channels *model.ChannelList, searchParams []*model.SearchParams, searchAfter *model.PostSearchCursor, perPage int) ([]string, model.PostSearchMatches, *model.PostSearchCursor, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, nil, nil, notStartedError("OpenSearchEngine.SearchPostsAfter")
+	}
+	if channels == nil {
+		channels = &model.ChannelList{}
+	}
+
+	query, fields := e.postSearchQuery(channels, searchParams)
+	query["sort"] = []jsonObject{
+		{"_score": jsonObject{"order": "desc"}},
+		{"CreateAt": jsonObject{"order": "desc"}},
+		{"Id": jsonObject{"order": "desc"}},
+	}
+	query["size"] = perPage
+	if searchAfter != nil {
+		query["search_after"] = []interface{}{searchAfter.Score, searchAfter.CreateAt, searchAfter.Id}
+	}
+
+	results, err := e.client.search(e.indexName(POST_INDEX), query)
+	if err != nil {
+		return nil, nil, nil, model.NewAppError("OpenSearchEngine.SearchPostsAfter", "opensearchengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	postIds, matches, _ := getPostSearchResults(results, fields)
+
+	var nextCursor *model.PostSearchCursor
+	if hits := results.Hits.Hits; perPage > 0 && len(hits) == perPage {
+		if nextCursor, err = getPostSearchCursor(hits[len(hits)-1]); err != nil {
+			return nil, nil, nil, model.NewAppError("OpenSearchEngine.SearchPostsAfter", "opensearchengine.search_posts.error", nil, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	return postIds, matches, nextCursor, nil
+}
+
+// getPostSearchCursor returns the cursor of the sort values of a hit of SearchPostsAfter.
+func getPostSearchCursor(hit searchHit) (*model.PostSearchCursor, error) {
+	if len(hit.Sort) != 3 {
+		return nil, errors.Errorf("unexpected sort values for post %s", hit.Id)
+	}
+
+	cursor := &model.PostSearchCursor{}
+	// The score is null when the query doesn't score the posts.
+	if string(hit.Sort[0]) != "null" {
+		if err := json.Unmarshal(hit.Sort[0], &cursor.Score); err != nil {
+			return nil, errors.Wrapf(err, "invalid score for post %s", hit.Id)
+		}
+	}
+	if err := json.Unmarshal(hit.Sort[1], &cursor.CreateAt); err != nil {
+		return nil, errors.Wrapf(err, "invalid CreateAt for post %s", hit.Id)
+	}
+	if err := json.Unmarshal(hit.Sort[2], &cursor.Id); err != nil {
+		return nil, errors.Wrapf(err, "invalid Id for post %s", hit.Id)
+	}
+	return cursor, nil
+}
+
+// postSearchQuery returns the query of a search of the posts of the given channels, or of all
+// the channels if channels is nil, without its sort and paging, along with the fields of the
+// message the query searches.
+func (e *OpenSearchEngine) postSearchQuery(channels *model.ChannelList, searchParams []*model.SearchParams) (jsonObject, []string) {
+	filters := []jsonObject{termQuery("Type", "")}
+	if channels != nil {
+		channelIds := []string{}
+		for _, channel := range *channels {
+			channelIds = append(channelIds, channel.Id)
+		}
+		filters = append(filters, termsQuery("ChannelId", channelIds))
+	}
+	notFilters := []jsonObject{}
+	termQueries := []jsonObject{}
+	notTermQueries := []jsonObject{}
+	fields := messageFields(analyzedLanguages(e.cfg.SearchSettings.LanguageAnalyzers))
+
+	termOperator := "and"
+	if searchParams[0].OrTerms {
+		termOperator = "or"
+	}
+
+	for i, params := range searchParams {
+		// Date, channels and FromUsers filters come in all
+		// searchParams iteration, and as they are global to the
+		// query, we only need to process them once
+		if i == 0 {
+			if len(params.InChannels) > 0 {
+				filters = append(filters, termsQuery("ChannelId", params.InChannels))
+			}
+			if len(params.ExcludedChannels) > 0 {
+				notFilters = append(notFilters, termsQuery("ChannelId", params.ExcludedChannels))
+			}
+			if len(params.FromUsers) > 0 {
+				filters = append(filters, termsQuery("UserId", params.FromUsers))
+			}
+			if len(params.ExcludedUsers) > 0 {
+				notFilters = append(notFilters, termsQuery("UserId", params.ExcludedUsers))
+			}
+
+			if params.OnDate != "" {
+				before, after := params.GetOnDateMillis()
+				filters = append(filters, rangeQuery("CreateAt", jsonObject{"gte": before, "lte": after}))
+			} else {
+				if params.AfterDate != "" || params.BeforeDate != "" {
+					bounds := jsonObject{}
+					if params.AfterDate != "" {
+						bounds["gte"] = params.GetAfterDateMillis()
+					}
+					if params.BeforeDate != "" {
+						bounds["lte"] = params.GetBeforeDateMillis()
+					}
+					filters = append(filters, rangeQuery("CreateAt", bounds))
+				}
+
+				if params.ExcludedAfterDate != "" {
+					notFilters = append(notFilters, rangeQuery("CreateAt", jsonObject{"gte": params.GetExcludedAfterDateMillis()}))
+				}
+
+				if params.ExcludedBeforeDate != "" {
+					notFilters = append(notFilters, rangeQuery("CreateAt", jsonObject{"lte": params.GetExcludedBeforeDateMillis()}))
+				}
+
+				if params.ExcludedDate != "" {
+					before, after := params.GetExcludedDateMillis()
+					notFilters = append(notFilters, rangeQuery("CreateAt", jsonObject{"gte": before, "lte": after}))
+				}
+			}
+		}
+
+		if params.IsHashtag {
+			if params.Terms != "" {
+				termQueries = append(termQueries, matchQuery("Hashtags", params.Terms, termOperator))
+			} else if params.ExcludedTerms != "" {
+				notTermQueries = append(notTermQueries, matchQuery("Hashtags", params.ExcludedTerms, termOperator))
+			}
+			continue
+		}
+
+		if len(params.Terms) > 0 {
+			terms := []string{}
+			for _, term := range strings.Split(params.Terms, " ") {
+				if strings.HasSuffix(term, "*") {
+					termQueries = append(termQueries, jsonObject{"wildcard": jsonObject{"Message": strings.ToLower(term)}})
+				} else {
+					terms = append(terms, term)
+				}
+			}
+
+			if len(terms) > 0 {
+				termQueries = append(termQueries, messageMatchQuery(fields, strings.Join(terms, " "), termOperator, params.Fuzziness))
+			}
+		}
+
+		if len(params.ExcludedTerms) > 0 {
+			notTermQueries = append(notTermQueries, messageMatchQuery(fields, params.ExcludedTerms, termOperator, 0))
+		}
+	}
+
+	allTermsClauses := jsonObject{"must_not": notTermQueries}
+	if searchParams[0].OrTerms {
+		allTermsClauses["should"] = termQueries
+	} else {
+		allTermsClauses["must"] = termQueries
+	}
+
+	must := []jsonObject{}
+	if len(termQueries) > 0 || len(notTermQueries) > 0 {
+		must = append(must, boolQuery(allTermsClauses))
+	}
+
+	highlightFields := jsonObject{"Hashtags": jsonObject{}}
+	for _, field := range fields {
+		highlightFields[field] = jsonObject{}
+	}
+
+	query := jsonObject{
+		"query": boolQuery(jsonObject{
+			"must":     must,
+			"filter":   filters,
+			"must_not": notFilters,
+		}),
+		"_source": false,
+		"highlight": jsonObject{
+			"pre_tags":  []string{model.POST_SEARCH_HIGHLIGHT_PRE_TAG},
+			"post_tags": []string{model.POST_SEARCH_HIGHLIGHT_POST_TAG},
+			"fields":    highlightFields,
+		},
+	}
+
+	return query, fields
+}
+
+// getPostSearchResults returns the ids of the posts found by a search, along with their matches
+// and highlights.
+func getPostSearchResults(results *searchResponse, fields []string) ([]string, model.PostSearchMatches, model.PostSearchHighlights) {
+	postIds := []string{}
+	matches := model.PostSearchMatches{}
+	highlights := model.PostSearchHighlights{}
+	for _, hit := range results.Hits.Hits {
+		postIds = append(postIds, hit.Id)
+		if hitMatches := getMatches(hit); len(hitMatches) > 0 {
+			matches[hit.Id] = hitMatches
+		}
+		// The message is highlighted in the first of its fields that matched.
+		for _, field := range fields {
+			if fragments := hit.Highlight[field]; len(fragments) > 0 {
+				highlights[hit.Id] = fragments
+				break
+			}
+		}
+	}
+
+	return postIds, matches, highlights
+}
+
+func (e *OpenSearchEngine) SuggestTerms(channels *model.ChannelList, prefix string, limit int) ([]string, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, notStartedError("OpenSearchEngine.SuggestTerms")
+	}
+
+	channelIds := []string{}
+	for _, channel := range *channels {
+		channelIds = append(channelIds, channel.Id)
+	}
+
+	query := jsonObject{
+		"query": boolQuery(jsonObject{
+			"must":   []jsonObject{prefixQuery("Message", strings.ToLower(prefix))},
+			"filter": []jsonObject{termsQuery("ChannelId", channelIds), termQuery("Type", "")},
+		}),
+		"sort":    []jsonObject{{"CreateAt": jsonObject{"order": "desc"}}},
+		"size":    model.SEARCH_SUGGESTION_POSTS_SAMPLE_SIZE,
+		"_source": []string{"Message"},
+	}
+
+	results, err := e.client.search(e.indexName(POST_INDEX), query)
+	if err != nil {
+		return nil, model.NewAppError("OpenSearchEngine.SuggestTerms", "opensearchengine.suggest_terms.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	messages := []string{}
+	for _, hit := range results.Hits.Hits {
+		var post OSPost
+		if err := json.Unmarshal(hit.Source, &post); err == nil {
+			messages = append(messages, post.Message)
+		}
+	}
+
+	return model.ExtractSuggestedTerms(messages, prefix, limit), nil
+}
+
+func (e *OpenSearchEngine) deleteDocuments(where, errorId, index string, query jsonObject) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError(where)
+	}
+
+	deleted, err := e.deleteByQuery(index, query)
+	if err != nil {
+		return model.NewAppError(where, errorId, nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	mlog.Info("Documents deleted from OpenSearch", mlog.String("index", index), mlog.Any("query", query), mlog.Int64("deleted", deleted))
+	return nil
+}
+
+func (e *OpenSearchEngine) DeleteChannelPosts(channelID string) *model.AppError {
+	return e.deleteDocuments("OpenSearchEngine.DeleteChannelPosts", "opensearchengine.delete_channel_posts.error", POST_INDEX, termQuery("ChannelId", channelID))
+}
+
+func (e *OpenSearchEngine) DeleteUserPosts(userID string) *model.AppError {
+	return e.deleteDocuments("OpenSearchEngine.DeleteUserPosts", "opensearchengine.delete_user_posts.error", POST_INDEX, termQuery("UserId", userID))
+}
+
+func (e *OpenSearchEngine) DeletePost(post *model.Post) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.DeletePost")
+	}
+
+	if err := e.deleteDocument(POST_INDEX, post.Id); err != nil {
+		return model.NewAppError("OpenSearchEngine.DeletePost", "opensearchengine.delete_post.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) IndexChannel(channel *model.Channel) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.IndexChannel")
+	}
+
+	osChannel := OSChannelFromChannel(channel)
+	if err := e.indexDocument(CHANNEL_INDEX, osChannel.Id, osChannel); err != nil {
+		return model.NewAppError("OpenSearchEngine.IndexChannel", "opensearchengine.index_channel.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) SearchChannels(teamId, term string) ([]string, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, notStartedError("OpenSearchEngine.SearchChannels")
+	}
+
+	filters := []jsonObject{termQuery("TeamId", teamId)}
+	if term != "" {
+		filters = append(filters, prefixQuery("NameSuggest", strings.ToLower(term)))
+	}
+
+	query := jsonObject{
+		"query":   boolQuery(jsonObject{"filter": filters}),
+		"size":    model.CHANNEL_SEARCH_DEFAULT_LIMIT,
+		"_source": false,
+	}
+	results, err := e.client.search(e.indexName(CHANNEL_INDEX), query)
+	if err != nil {
+		return nil, model.NewAppError("OpenSearchEngine.SearchChannels", "opensearchengine.search_channels.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	channelIds := []string{}
+	for _, hit := range results.Hits.Hits {
+		channelIds = append(channelIds, hit.Id)
+	}
+
+	return channelIds, nil
+}
+
+func (e *OpenSearchEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.DeleteChannel")
+	}
+
+	if err := e.deleteDocument(CHANNEL_INDEX, channel.Id); err != nil {
+		return model.NewAppError("OpenSearchEngine.DeleteChannel", "opensearchengine.delete_channel.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) IndexUser(user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.IndexUser")
+	}
+
+	osUser := OSUserFromUserAndTeams(user, teamsIds, channelsIds)
+	if err := e.indexDocument(USER_INDEX, osUser.Id, osUser); err != nil {
+		return model.NewAppError("OpenSearchEngine.IndexUser", "opensearchengine.index_user.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func userSuggestionsQuery(term string, options *model.UserSearchOptions) jsonObject {
+	if options.AllowFullNames {
+		return prefixQuery("SuggestionsWithFullname", strings.ToLower(term))
+	}
+	return prefixQuery("SuggestionsWithoutFullname", strings.ToLower(term))
+}
+
+func (e *OpenSearchEngine) searchUserIds(query jsonObject, limit int) ([]string, error) {
+	results, err := e.client.search(e.indexName(USER_INDEX), jsonObject{
+		"query":   query,
+		"size":    limit,
+		"_source": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := []string{}
+	for _, hit := range results.Hits.Hits {
+		userIds = append(userIds, hit.Id)
+	}
+	return userIds, nil
+}
+
+func (e *OpenSearchEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
+		return []string{}, []string{}, nil
+	}
+
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, nil, notStartedError("OpenSearchEngine.SearchUsersInChannel")
+	}
+
+	// users in channel
+	filters := []jsonObject{termQuery("ChannelsIds", channelId)}
+	if term != "" {
+		filters = append(filters, userSuggestionsQuery(term, options))
+	}
+
+	uchanIds, err := e.searchUserIds(boolQuery(jsonObject{"filter": filters}), options.Limit)
+	if err != nil {
+		return nil, nil, model.NewAppError("OpenSearchEngine.SearchUsersInChannel", "opensearchengine.search_users_in_channel.uchan.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	// users not in channel
+	filters = []jsonObject{termQuery("TeamsIds", teamId)}
+	if term != "" {
+		filters = append(filters, userSuggestionsQuery(term, options))
+	}
+	if len(restrictedToChannels) > 0 {
+		filters = append(filters, termsQuery("ChannelsIds", restrictedToChannels))
+	}
+
+	nuchanQuery := boolQuery(jsonObject{
+		"filter":   filters,
+		"must_not": []jsonObject{termQuery("ChannelsIds", channelId)},
+	})
+	nuchanIds, err := e.searchUserIds(nuchanQuery, options.Limit)
+	if err != nil {
+		return nil, nil, model.NewAppError("OpenSearchEngine.SearchUsersInChannel", "opensearchengine.search_users_in_channel.nuchan.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return uchanIds, nuchanIds, nil
+}
+
+func (e *OpenSearchEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
+		return []string{}, nil
+	}
+
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, notStartedError("OpenSearchEngine.SearchUsersInTeam")
+	}
+
+	filters := []jsonObject{}
+	if term != "" {
+		filters = append(filters, userSuggestionsQuery(term, options))
+	}
+	if len(restrictedToChannels) > 0 {
+		// restricted channels are already filtered by team, so we
+		// can search only those matches
+		filters = append(filters, termsQuery("ChannelsIds", restrictedToChannels))
+	} else if teamId != "" {
+		// this means that we only need to restrict by team
+		filters = append(filters, termQuery("TeamsIds", teamId))
+	}
+
+	query := jsonObject{"match_all": jsonObject{}}
+	if len(filters) > 0 {
+		query = boolQuery(jsonObject{"filter": filters})
+	}
+
+	usersIds, err := e.searchUserIds(query, options.Limit)
+	if err != nil {
+		return nil, model.NewAppError("OpenSearchEngine.SearchUsersInTeam", "opensearchengine.search_users_in_team.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return usersIds, nil
+}
+
+func (e *OpenSearchEngine) DeleteUser(user *model.User) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.DeleteUser")
+	}
+
+	if err := e.deleteDocument(USER_INDEX, user.Id); err != nil {
+		return model.NewAppError("OpenSearchEngine.DeleteUser", "opensearchengine.delete_user.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.IndexFile")
+	}
+
+	osFile := OSFileFromFileInfo(file, channelId)
+	if err := e.indexDocument(FILE_INDEX, osFile.Id, osFile); err != nil {
+		return model.NewAppError("OpenSearchEngine.IndexFile", "opensearchengine.index_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return nil, notStartedError("OpenSearchEngine.SearchFiles")
+	}
+
+	channelIds := []string{}
+	for _, channel := range *channels {
+		channelIds = append(channelIds, channel.Id)
+	}
+
+	filters := []jsonObject{termsQuery("ChannelId", channelIds)}
+	notFilters := []jsonObject{}
+	termQueries := []jsonObject{}
+	notTermQueries := []jsonObject{}
+
+	termOperator := "and"
+	if searchParams[0].OrTerms {
+		termOperator = "or"
+	}
+
+	for i, params := range searchParams {
+		// Channels, users and date filters are global to the query,
+		// so we only need to process them once
+		if i == 0 {
+			if len(params.InChannels) > 0 {
+				filters = append(filters, termsQuery("ChannelId", params.InChannels))
+			}
+			if len(params.ExcludedChannels) > 0 {
+				notFilters = append(notFilters, termsQuery("ChannelId", params.ExcludedChannels))
+			}
+			if len(params.FromUsers) > 0 {
+				filters = append(filters, termsQuery("CreatorId", params.FromUsers))
+			}
+			if len(params.ExcludedUsers) > 0 {
+				notFilters = append(notFilters, termsQuery("CreatorId", params.ExcludedUsers))
+			}
+
+			if params.OnDate != "" {
+				before, after := params.GetOnDateMillis()
+				filters = append(filters, rangeQuery("CreateAt", jsonObject{"gte": before, "lte": after}))
+			} else if params.AfterDate != "" || params.BeforeDate != "" {
+				bounds := jsonObject{}
+				if params.AfterDate != "" {
+					bounds["gte"] = params.GetAfterDateMillis()
+				}
+				if params.BeforeDate != "" {
+					bounds["lte"] = params.GetBeforeDateMillis()
+				}
+				filters = append(filters, rangeQuery("CreateAt", bounds))
+			}
+		}
+
+		// Files don't have hashtags
+		if params.IsHashtag {
+			continue
+		}
+
+		if len(params.Terms) > 0 {
+			terms := []string{}
+			for _, term := range strings.Split(params.Terms, " ") {
+				if strings.HasSuffix(term, "*") {
+					termQueries = append(termQueries, boolQuery(jsonObject{"should": []jsonObject{
+						{"wildcard": jsonObject{"Name": strings.ToLower(term)}},
+						{"wildcard": jsonObject{"Content": strings.ToLower(term)}},
+					}}))
+				} else {
+					terms = append(terms, term)
+				}
+			}
+
+			if len(terms) > 0 {
+				termQueries = append(termQueries, jsonObject{"multi_match": jsonObject{
+					"query":    strings.Join(terms, " "),
+					"fields":   []string{"Name", "Content"},
+					"operator": termOperator,
+				}})
+			}
+		}
+
+		if len(params.ExcludedTerms) > 0 {
+			notTermQueries = append(notTermQueries, jsonObject{"multi_match": jsonObject{
+				"query":    params.ExcludedTerms,
+				"fields":   []string{"Name", "Content"},
+				"operator": termOperator,
+			}})
+		}
+	}
+
+	allTermsClauses := jsonObject{"must_not": notTermQueries}
+	if searchParams[0].OrTerms {
+		allTermsClauses["should"] = termQueries
+	} else {
+		allTermsClauses["must"] = termQueries
+	}
+
+	must := []jsonObject{}
+	if len(termQueries) > 0 || len(notTermQueries) > 0 {
+		must = append(must, boolQuery(allTermsClauses))
+	}
+
+	query := jsonObject{
+		"query": boolQuery(jsonObject{
+			"must":     must,
+			"filter":   filters,
+			"must_not": notFilters,
+		}),
+		"sort":    []jsonObject{{"CreateAt": jsonObject{"order": "desc"}}},
+		"from":    page * perPage,
+		"size":    perPage,
+		"_source": false,
+	}
+
+	results, err := e.client.search(e.indexName(FILE_INDEX), query)
+	if err != nil {
+		return nil, model.NewAppError("OpenSearchEngine.SearchFiles", "opensearchengine.search_files.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	fileIds := []string{}
+	for _, hit := range results.Hits.Hits {
+		fileIds = append(fileIds, hit.Id)
+	}
+
+	return fileIds, nil
+}
+
+func (e *OpenSearchEngine) DeleteFile(fileID string) *model.AppError {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return notStartedError("OpenSearchEngine.DeleteFile")
+	}
+
+	if err := e.deleteDocument(FILE_INDEX, fileID); err != nil {
+		return model.NewAppError("OpenSearchEngine.DeleteFile", "opensearchengine.delete_file.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return nil
+}
+
+func (e *OpenSearchEngine) DeletePostFiles(postID string) *model.AppError {
+	return e.deleteDocuments("OpenSearchEngine.DeletePostFiles", "opensearchengine.delete_post_files.error", FILE_INDEX, termQuery("PostId", postID))
+}
+
+func (e *OpenSearchEngine) DeleteUserFiles(userID string) *model.AppError {
+	return e.deleteDocuments("OpenSearchEngine.DeleteUserFiles", "opensearchengine.delete_user_files.error", FILE_INDEX, termQuery("CreatorId", userID))
+}
+
+// IsDocumentSearchable reports whether the post, channel, user or file with the given id is
+// returned by the searches, which only happens once its index has been refreshed.
+func (e *OpenSearchEngine) IsDocumentSearchable(id string) (bool, *model.AppError) {
+	e.Mutex.RLock()
+	defer e.Mutex.RUnlock()
+
+	if e.client == nil {
+		return false, notStartedError("OpenSearchEngine.IsDocumentSearchable")
+	}
+
+	query := jsonObject{
+		"query": jsonObject{"ids": jsonObject{"values": []string{id}}},
+		"size":  0,
+	}
+	indexes := strings.Join([]string{e.indexName(POST_INDEX), e.indexName(CHANNEL_INDEX), e.indexName(USER_INDEX), e.indexName(FILE_INDEX)}, ",")
+	results, err := e.client.search(indexes, query)
+	if err != nil {
+		return false, model.NewAppError("OpenSearchEngine.IsDocumentSearchable", "opensearchengine.search_document.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return results.Hits.Total > 0, nil
+}
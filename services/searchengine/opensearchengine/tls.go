@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// newTLSConfig returns the TLS configuration of the connections to the server. The certificate
+// of the server is verified against the CA file of the settings when set, and against the
+// certificates of the system otherwise. The client certificate and key files, set together,
+// authenticate the client to the servers requiring mutual TLS.
+func newTLSConfig(settings *model.ElasticsearchSettings) (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: *settings.SkipTLSVerification}
+	if *settings.SkipTLSVerification {
+		mlog.Warn("The TLS certificate of the search server isn't verified, which leaves the connection open to interception. Set ElasticsearchSettings.CA rather than ElasticsearchSettings.SkipTLSVerification to trust a self-signed certificate.")
+	}
+
+	if *settings.CA != "" {
+		pem, err := ioutil.ReadFile(*settings.CA)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the CA file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no PEM encoded certificate found in the CA file %s", *settings.CA)
+		}
+		config.RootCAs = pool
+	}
+
+	if *settings.ClientCert != "" || *settings.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(*settings.ClientCert, *settings.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the client certificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// isTLSError reports whether a request failed during the TLS handshake, such as when the
+// certificate of the server isn't trusted or the server rejects the client certificate.
+func isTLSError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	if errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) ||
+		errors.As(err, &verificationErr) || errors.As(err, &recordHeaderErr) {
+		return true
+	}
+
+	// The alerts sent by the server, such as for a missing client certificate, aren't exported.
+	return strings.Contains(err.Error(), "tls: ")
+}
+
+// connectError returns the error of a failure to connect to the server, telling the TLS
+// handshake failures apart for the administrators to check the TLS settings.
+func connectError(where string, err error) *model.AppError {
+	if isTLSError(err) {
+		return model.NewAppError(where, "opensearchengine.connect.tls.error", nil, err.Error(), http.StatusInternalServerError)
+	}
+	return model.NewAppError(where, "opensearchengine.connect.error", nil, err.Error(), http.StatusInternalServerError)
+}
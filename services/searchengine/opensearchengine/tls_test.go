@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package opensearchengine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSServer starts a server answering like OpenSearch over TLS, recording the Authorization
+// header of the last request. The client authentication is configured before it starts.
+func newTLSServer(t *testing.T, clientAuth tls.ClientAuthType) (*httptest.Server, func() string) {
+	var mut sync.Mutex
+	authorization := ""
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		authorization = r.Header.Get("Authorization")
+		mut.Unlock()
+		w.Write([]byte(`{"version": {"distribution": "opensearch", "number": "1.3.2"}}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: clientAuth}
+	server.StartTLS()
+
+	return server, func() string {
+		mut.Lock()
+		defer mut.Unlock()
+		return authorization
+	}
+}
+
+// writePEM writes the certificate and the key of the server to PEM files, for them to be used
+// as the CA or the client certificate of the engine.
+func writePEM(t *testing.T, server *httptest.Server) (certFile, keyFile string) {
+	dir, err := ioutil.TempDir("", "opensearch-tls")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	cert := server.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600))
+	return certFile, keyFile
+}
+
+func TestOpenSearchEngineTLS(t *testing.T) {
+	server, authorization := newTLSServer(t, tls.NoClientCert)
+	defer server.Close()
+	certFile, keyFile := writePEM(t, server)
+
+	newEngine := func() *OpenSearchEngine {
+		return newTestEngine(t, &fakeServer{Server: server}, true)
+	}
+
+	t.Run("reports the untrusted certificates as TLS errors", func(t *testing.T) {
+		engine := newEngine()
+		appErr := engine.TestConfig(engine.cfg)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.connect.tls.error", appErr.Id)
+
+		appErr = engine.Start()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.connect.tls.error", appErr.Id)
+	})
+
+	t.Run("trusts the certificates of the CA", func(t *testing.T) {
+		engine := newEngine()
+		*engine.cfg.ElasticsearchSettings.CA = certFile
+		assert.Nil(t, engine.TestConfig(engine.cfg))
+	})
+
+	t.Run("skips the verification when asked to", func(t *testing.T) {
+		engine := newEngine()
+		*engine.cfg.ElasticsearchSettings.SkipTLSVerification = true
+		assert.Nil(t, engine.TestConfig(engine.cfg))
+	})
+
+	t.Run("fails to load a missing CA", func(t *testing.T) {
+		engine := newEngine()
+		*engine.cfg.ElasticsearchSettings.CA = filepath.Join(filepath.Dir(certFile), "missing.pem")
+		appErr := engine.TestConfig(engine.cfg)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.tls_config.error", appErr.Id)
+
+		*engine.cfg.ElasticsearchSettings.CA = keyFile
+		appErr = engine.TestConfig(engine.cfg)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.tls_config.error", appErr.Id)
+	})
+
+	t.Run("prefers the API key to the password", func(t *testing.T) {
+		engine := newEngine()
+		*engine.cfg.ElasticsearchSettings.CA = certFile
+		require.Nil(t, engine.TestConfig(engine.cfg))
+		assert.Contains(t, authorization(), "Basic ")
+
+		*engine.cfg.ElasticsearchSettings.APIKey = "key"
+		require.Nil(t, engine.TestConfig(engine.cfg))
+		assert.Equal(t, "ApiKey key", authorization())
+	})
+}
+
+func TestOpenSearchEngineMutualTLS(t *testing.T) {
+	server, _ := newTLSServer(t, tls.RequireAnyClientCert)
+	defer server.Close()
+	certFile, keyFile := writePEM(t, server)
+
+	engine := newTestEngine(t, &fakeServer{Server: server}, true)
+	*engine.cfg.ElasticsearchSettings.CA = certFile
+
+	t.Run("reports the missing client certificate as a TLS error", func(t *testing.T) {
+		appErr := engine.TestConfig(engine.cfg)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "opensearchengine.connect.tls.error", appErr.Id)
+	})
+
+	t.Run("authenticates with the client certificate", func(t *testing.T) {
+		*engine.cfg.ElasticsearchSettings.ClientCert = certFile
+		*engine.cfg.ElasticsearchSettings.ClientKey = keyFile
+		assert.Nil(t, engine.TestConfig(engine.cfg))
+	})
+}
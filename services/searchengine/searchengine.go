@@ -4,6 +4,8 @@
 package searchengine
 
 import (
+	"sync"
+
 	"github.com/mattermost/mattermost-server/v5/jobs"
 	"github.com/mattermost/mattermost-server/v5/model"
 )
@@ -19,6 +21,10 @@ func (seb *Broker) RegisterElasticsearchEngine(es SearchEngineInterface) {
 	seb.ElasticsearchEngine = es
 }
 
+func (seb *Broker) RegisterOpenSearchEngine(ose SearchEngineInterface) {
+	seb.OpenSearchEngine = ose
+}
+
 func (seb *Broker) RegisterBleveEngine(be SearchEngineInterface) {
 	seb.BleveEngine = be
 }
@@ -27,7 +33,13 @@ type Broker struct {
 	cfg                 *model.Config
 	jobServer           *jobs.JobServer
 	ElasticsearchEngine SearchEngineInterface
+	OpenSearchEngine    SearchEngineInterface
 	BleveEngine         SearchEngineInterface
+
+	healthMutex sync.RWMutex
+	health      map[string]EngineHealth
+	healthStop  chan struct{}
+	healthDone  chan struct{}
 }
 
 func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
@@ -36,6 +48,10 @@ func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
 		seb.ElasticsearchEngine.UpdateConfig(cfg)
 	}
 
+	if seb.OpenSearchEngine != nil {
+		seb.OpenSearchEngine.UpdateConfig(cfg)
+	}
+
 	if seb.BleveEngine != nil {
 		seb.BleveEngine.UpdateConfig(cfg)
 	}
@@ -43,10 +59,29 @@ func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
 	return nil
 }
 
+// ConfiguredEngine returns the engine configured through the ElasticsearchSettings: the
+// OpenSearch engine when SearchSettings.Backend selects it, the Elasticsearch engine otherwise.
+func (seb *Broker) ConfiguredEngine() SearchEngineInterface {
+	if seb.cfg != nil && seb.cfg.SearchSettings.Backend != nil && *seb.cfg.SearchSettings.Backend == model.SEARCH_BACKEND_OPENSEARCH {
+		return seb.OpenSearchEngine
+	}
+	return seb.ElasticsearchEngine
+}
+
+// ActiveEngine returns the preferred active engine, or nil if none is active. The configured
+// engine is preferred over Bleve.
+func (seb *Broker) ActiveEngine() SearchEngineInterface {
+	if engines := seb.GetActiveEngines(); len(engines) > 0 {
+		return engines[0]
+	}
+	return nil
+}
+
+// GetActiveEngines returns the active engines, in order of preference.
 func (seb *Broker) GetActiveEngines() []SearchEngineInterface {
 	engines := []SearchEngineInterface{}
-	if seb.ElasticsearchEngine != nil && seb.ElasticsearchEngine.IsActive() {
-		engines = append(engines, seb.ElasticsearchEngine)
+	if engine := seb.ConfiguredEngine(); engine != nil && engine.IsActive() {
+		engines = append(engines, engine)
 	}
 	if seb.BleveEngine != nil && seb.BleveEngine.IsActive() {
 		engines = append(engines, seb.BleveEngine)
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+)
+
+func TestBrokerActiveEngine(t *testing.T) {
+	newEngine := func(active bool) *mocks.SearchEngineInterface {
+		engine := &mocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(active)
+		engine.On("UpdateConfig", mock.Anything).Return()
+		return engine
+	}
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	es := newEngine(true)
+	ose := newEngine(true)
+	bleve := newEngine(true)
+
+	broker := NewBroker(cfg, nil)
+	broker.RegisterElasticsearchEngine(es)
+	broker.RegisterOpenSearchEngine(ose)
+	broker.RegisterBleveEngine(bleve)
+
+	assert.Same(t, es, broker.ConfiguredEngine())
+	assert.Same(t, es, broker.ActiveEngine())
+	engines := broker.GetActiveEngines()
+	assert.Len(t, engines, 2)
+	assert.Same(t, es, engines[0])
+	assert.Same(t, bleve, engines[1])
+
+	cfg = cfg.Clone()
+	*cfg.SearchSettings.Backend = model.SEARCH_BACKEND_OPENSEARCH
+	broker.UpdateConfig(cfg)
+
+	assert.Same(t, ose, broker.ConfiguredEngine())
+	assert.Same(t, ose, broker.ActiveEngine())
+	engines = broker.GetActiveEngines()
+	assert.Len(t, engines, 2)
+	assert.Same(t, ose, engines[0])
+	assert.Same(t, bleve, engines[1])
+
+	broker.RegisterOpenSearchEngine(newEngine(false))
+	assert.Same(t, bleve, broker.ActiveEngine())
+
+	broker.RegisterBleveEngine(nil)
+	assert.Nil(t, broker.ActiveEngine())
+	assert.Empty(t, broker.GetActiveEngines())
+}
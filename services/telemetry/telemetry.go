@@ -535,6 +535,8 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_file_attachments": *cfg.FileSettings.EnableFileAttachments,
 		"enable_mobile_upload":    *cfg.FileSettings.EnableMobileUpload,
 		"enable_mobile_download":  *cfg.FileSettings.EnableMobileDownload,
+		"extract_content":         *cfg.FileSettings.ExtractContent,
+		"archive_recursion":       *cfg.FileSettings.ArchiveRecursion,
 	})
 
 	ts.sendTelemetry(TRACK_CONFIG_EMAIL, map[string]interface{}{
@@ -723,35 +725,50 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.sendTelemetry(TRACK_CONFIG_ELASTICSEARCH, map[string]interface{}{
-		"isdefault_connection_url":          isDefault(*cfg.ElasticsearchSettings.ConnectionUrl, model.ELASTICSEARCH_SETTINGS_DEFAULT_CONNECTION_URL),
-		"isdefault_username":                isDefault(*cfg.ElasticsearchSettings.Username, model.ELASTICSEARCH_SETTINGS_DEFAULT_USERNAME),
-		"isdefault_password":                isDefault(*cfg.ElasticsearchSettings.Password, model.ELASTICSEARCH_SETTINGS_DEFAULT_PASSWORD),
-		"enable_indexing":                   *cfg.ElasticsearchSettings.EnableIndexing,
-		"enable_searching":                  *cfg.ElasticsearchSettings.EnableSearching,
-		"enable_autocomplete":               *cfg.ElasticsearchSettings.EnableAutocomplete,
-		"sniff":                             *cfg.ElasticsearchSettings.Sniff,
-		"post_index_replicas":               *cfg.ElasticsearchSettings.PostIndexReplicas,
-		"post_index_shards":                 *cfg.ElasticsearchSettings.PostIndexShards,
-		"channel_index_replicas":            *cfg.ElasticsearchSettings.ChannelIndexReplicas,
-		"channel_index_shards":              *cfg.ElasticsearchSettings.ChannelIndexShards,
-		"user_index_replicas":               *cfg.ElasticsearchSettings.UserIndexReplicas,
-		"user_index_shards":                 *cfg.ElasticsearchSettings.UserIndexShards,
-		"isdefault_index_prefix":            isDefault(*cfg.ElasticsearchSettings.IndexPrefix, model.ELASTICSEARCH_SETTINGS_DEFAULT_INDEX_PREFIX),
-		"live_indexing_batch_size":          *cfg.ElasticsearchSettings.LiveIndexingBatchSize,
-		"bulk_indexing_time_window_seconds": *cfg.ElasticsearchSettings.BulkIndexingTimeWindowSeconds,
-		"request_timeout_seconds":           *cfg.ElasticsearchSettings.RequestTimeoutSeconds,
-		"skip_tls_verification":             *cfg.ElasticsearchSettings.SkipTLSVerification,
-		"trace":                             *cfg.ElasticsearchSettings.Trace,
+		"isdefault_connection_url":                 isDefault(*cfg.ElasticsearchSettings.ConnectionUrl, model.ELASTICSEARCH_SETTINGS_DEFAULT_CONNECTION_URL),
+		"isdefault_username":                       isDefault(*cfg.ElasticsearchSettings.Username, model.ELASTICSEARCH_SETTINGS_DEFAULT_USERNAME),
+		"isdefault_password":                       isDefault(*cfg.ElasticsearchSettings.Password, model.ELASTICSEARCH_SETTINGS_DEFAULT_PASSWORD),
+		"enable_indexing":                          *cfg.ElasticsearchSettings.EnableIndexing,
+		"enable_searching":                         *cfg.ElasticsearchSettings.EnableSearching,
+		"enable_autocomplete":                      *cfg.ElasticsearchSettings.EnableAutocomplete,
+		"sniff":                                    *cfg.ElasticsearchSettings.Sniff,
+		"post_index_replicas":                      *cfg.ElasticsearchSettings.PostIndexReplicas,
+		"post_index_shards":                        *cfg.ElasticsearchSettings.PostIndexShards,
+		"channel_index_replicas":                   *cfg.ElasticsearchSettings.ChannelIndexReplicas,
+		"channel_index_shards":                     *cfg.ElasticsearchSettings.ChannelIndexShards,
+		"user_index_replicas":                      *cfg.ElasticsearchSettings.UserIndexReplicas,
+		"user_index_shards":                        *cfg.ElasticsearchSettings.UserIndexShards,
+		"isdefault_index_prefix":                   isDefault(*cfg.ElasticsearchSettings.IndexPrefix, model.ELASTICSEARCH_SETTINGS_DEFAULT_INDEX_PREFIX),
+		"live_indexing_batch_size":                 *cfg.ElasticsearchSettings.LiveIndexingBatchSize,
+		"bulk_indexing_time_window_seconds":        *cfg.ElasticsearchSettings.BulkIndexingTimeWindowSeconds,
+		"request_timeout_seconds":                  *cfg.ElasticsearchSettings.RequestTimeoutSeconds,
+		"skip_tls_verification":                    *cfg.ElasticsearchSettings.SkipTLSVerification,
+		"isdefault_ca":                             isDefault(*cfg.ElasticsearchSettings.CA, ""),
+		"isdefault_client_cert":                    isDefault(*cfg.ElasticsearchSettings.ClientCert, ""),
+		"isdefault_client_key":                     isDefault(*cfg.ElasticsearchSettings.ClientKey, ""),
+		"isdefault_api_key":                        isDefault(*cfg.ElasticsearchSettings.APIKey, ""),
+		"trace":                                    *cfg.ElasticsearchSettings.Trace,
+		"backend":                                  *cfg.SearchSettings.Backend,
+		"fuzziness":                                *cfg.SearchSettings.Fuzziness,
+		"search_bulk_indexing_time_window_seconds": *cfg.SearchSettings.BulkIndexingTimeWindowSeconds,
+		"bulk_indexing_max_in_flight_requests":     *cfg.SearchSettings.BulkIndexingMaxInFlightRequests,
+		"health_check_interval_seconds":            *cfg.SearchSettings.HealthCheckIntervalSeconds,
+		"request_retries":                          *cfg.SearchSettings.RequestRetries,
+		"request_retry_backoff_milliseconds":       *cfg.SearchSettings.RequestRetryBackoffMilliseconds,
+		"disable_refresh_during_bulk_indexing":     *cfg.SearchSettings.DisableRefreshDuringBulkIndexing,
+		"search_live_indexing_batch_size":          *cfg.SearchSettings.LiveIndexingBatchSize,
 	})
 
 	ts.trackPluginConfig(cfg, model.PLUGIN_SETTINGS_DEFAULT_MARKETPLACE_URL)
 
 	ts.sendTelemetry(TRACK_CONFIG_DATA_RETENTION, map[string]interface{}{
-		"enable_message_deletion": *cfg.DataRetentionSettings.EnableMessageDeletion,
-		"enable_file_deletion":    *cfg.DataRetentionSettings.EnableFileDeletion,
-		"message_retention_days":  *cfg.DataRetentionSettings.MessageRetentionDays,
-		"file_retention_days":     *cfg.DataRetentionSettings.FileRetentionDays,
-		"deletion_job_start_time": *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"enable_message_deletion":           *cfg.DataRetentionSettings.EnableMessageDeletion,
+		"enable_file_deletion":              *cfg.DataRetentionSettings.EnableFileDeletion,
+		"message_retention_days":            *cfg.DataRetentionSettings.MessageRetentionDays,
+		"file_retention_days":               *cfg.DataRetentionSettings.FileRetentionDays,
+		"deletion_job_start_time":           *cfg.DataRetentionSettings.DeletionJobStartTime,
+		"batch_size":                        *cfg.DataRetentionSettings.BatchSize,
+		"time_between_batches_milliseconds": *cfg.DataRetentionSettings.TimeBetweenBatchesMilliseconds,
 	})
 
 	ts.sendTelemetry(TRACK_CONFIG_MESSAGE_EXPORT, map[string]interface{}{
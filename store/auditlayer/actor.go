@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package auditlayer records the changes made to the store in its audit trail. Each change is
+// made through AuditTrailStore.Record, which only commits its entry once the change succeeded
+// and rejects the changes that can't be recorded.
+package auditlayer
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type contextKey struct{}
+
+// WithActor returns a copy of the context carrying the id of the user making the changes.
+func WithActor(ctx context.Context, actorId string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, contextKey{}, actorId)
+}
+
+// ActorFromContext returns the id of the user making the changes, or an empty string when the
+// context doesn't carry one.
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actorId, _ := ctx.Value(contextKey{}).(string)
+	return actorId
+}
+
+// WithContext returns a layer over the same child store recording the changes made through it
+// as made by the actor of ctx, such as the user of a request.
+func (s *AuditLayer) WithContext(ctx context.Context) *AuditLayer {
+	return New(s.Store, ctx)
+}
+
+// record makes the change through the audit trail. The change is only made once even when the
+// audit trail is retried, a retry recording the outcome of the first attempt. The entity id is
+// read again once the change is made, the parameter holding the id of the entities it created.
+func (s *AuditLayer) record(entityType, operation string, param interface{}, change func() bool) error {
+	entry := &model.AuditTrailEntry{
+		ActorId:    ActorFromContext(s.ctx),
+		EntityType: entityType,
+		EntityId:   entityId(param),
+		Operation:  operation,
+	}
+
+	var once sync.Once
+	var succeeded bool
+	err := s.AuditTrailStore.Record(entry, func() bool {
+		once.Do(func() { succeeded = change() })
+		if succeeded {
+			entry.EntityId = entityId(param)
+		}
+		return succeeded
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to record %s in the audit trail", operation)
+	}
+	return nil
+}
+
+// newAppError returns the error of a change that couldn't be recorded, unavailable rather than
+// failed when the store below is read-only.
+func newAppError(where string, err error) *model.AppError {
+	status := http.StatusInternalServerError
+	var readOnlyErr *store.ErrReadOnly
+	if errors.As(err, &readOnlyErr) {
+		status = http.StatusServiceUnavailable
+	}
+	return model.NewAppError(where, "store.audit_layer.record.app_error", nil, err.Error(), status)
+}
+
+// entityId returns the id of the entity changed through the parameter: the parameter itself
+// when it's a string, or else its Id field, or else its first field suffixed with Id.
+func entityId(param interface{}) string {
+	if id, ok := param.(string); ok {
+		return truncate(id)
+	}
+
+	v := reflect.ValueOf(param)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	if field := v.FieldByName("Id"); field.IsValid() && field.Kind() == reflect.String {
+		return truncate(field.String())
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if strings.HasSuffix(v.Type().Field(i).Name, "Id") && v.Field(i).Kind() == reflect.String {
+			return truncate(v.Field(i).String())
+		}
+	}
+	return ""
+}
+
+func truncate(id string) string {
+	if len(id) > model.AUDIT_TRAIL_ENTITY_ID_MAX_LENGTH {
+		return id[:model.AUDIT_TRAIL_ENTITY_ID_MAX_LENGTH]
+	}
+	return id
+}
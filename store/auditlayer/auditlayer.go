@@ -0,0 +1,3198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package auditlayer
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type AuditLayer struct {
+	store.Store
+	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
+	BotStore                  store.BotStore
+	ChannelStore              store.ChannelStore
+	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore     store.ClusterDiscoveryStore
+	CommandStore              store.CommandStore
+	CommandWebhookStore       store.CommandWebhookStore
+	ComplianceStore           store.ComplianceStore
+	EmojiStore                store.EmojiStore
+	FileInfoStore             store.FileInfoStore
+	GroupStore                store.GroupStore
+	JobStore                  store.JobStore
+	LicenseStore              store.LicenseStore
+	LinkMetadataStore         store.LinkMetadataStore
+	OAuthStore                store.OAuthStore
+	PluginStore               store.PluginStore
+	PostStore                 store.PostStore
+	PreferenceStore           store.PreferenceStore
+	ProductNoticesStore       store.ProductNoticesStore
+	ReactionStore             store.ReactionStore
+	RoleStore                 store.RoleStore
+	SchemeStore               store.SchemeStore
+	SessionStore              store.SessionStore
+	StatusStore               store.StatusStore
+	SystemStore               store.SystemStore
+	TeamStore                 store.TeamStore
+	TermsOfServiceStore       store.TermsOfServiceStore
+	ThreadStore               store.ThreadStore
+	TokenStore                store.TokenStore
+	UploadSessionStore        store.UploadSessionStore
+	UserStore                 store.UserStore
+	UserAccessTokenStore      store.UserAccessTokenStore
+	UserTermsOfServiceStore   store.UserTermsOfServiceStore
+	WebhookStore              store.WebhookStore
+
+	ctx context.Context
+}
+
+func (s *AuditLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *AuditLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
+func (s *AuditLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *AuditLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *AuditLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *AuditLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *AuditLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *AuditLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *AuditLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *AuditLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *AuditLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *AuditLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *AuditLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *AuditLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *AuditLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *AuditLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *AuditLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *AuditLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *AuditLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *AuditLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *AuditLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *AuditLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *AuditLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *AuditLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *AuditLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *AuditLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *AuditLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *AuditLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *AuditLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *AuditLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *AuditLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *AuditLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *AuditLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *AuditLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *AuditLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type AuditLayerAuditStore struct {
+	store.AuditStore
+	Root *AuditLayer
+}
+
+type AuditLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *AuditLayer
+}
+
+type AuditLayerBotStore struct {
+	store.BotStore
+	Root *AuditLayer
+}
+
+type AuditLayerChannelStore struct {
+	store.ChannelStore
+	Root *AuditLayer
+}
+
+type AuditLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *AuditLayer
+}
+
+type AuditLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *AuditLayer
+}
+
+type AuditLayerCommandStore struct {
+	store.CommandStore
+	Root *AuditLayer
+}
+
+type AuditLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *AuditLayer
+}
+
+type AuditLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *AuditLayer
+}
+
+type AuditLayerEmojiStore struct {
+	store.EmojiStore
+	Root *AuditLayer
+}
+
+type AuditLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *AuditLayer
+}
+
+type AuditLayerGroupStore struct {
+	store.GroupStore
+	Root *AuditLayer
+}
+
+type AuditLayerJobStore struct {
+	store.JobStore
+	Root *AuditLayer
+}
+
+type AuditLayerLicenseStore struct {
+	store.LicenseStore
+	Root *AuditLayer
+}
+
+type AuditLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *AuditLayer
+}
+
+type AuditLayerOAuthStore struct {
+	store.OAuthStore
+	Root *AuditLayer
+}
+
+type AuditLayerPluginStore struct {
+	store.PluginStore
+	Root *AuditLayer
+}
+
+type AuditLayerPostStore struct {
+	store.PostStore
+	Root *AuditLayer
+}
+
+type AuditLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *AuditLayer
+}
+
+type AuditLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *AuditLayer
+}
+
+type AuditLayerReactionStore struct {
+	store.ReactionStore
+	Root *AuditLayer
+}
+
+type AuditLayerRoleStore struct {
+	store.RoleStore
+	Root *AuditLayer
+}
+
+type AuditLayerSchemeStore struct {
+	store.SchemeStore
+	Root *AuditLayer
+}
+
+type AuditLayerSessionStore struct {
+	store.SessionStore
+	Root *AuditLayer
+}
+
+type AuditLayerStatusStore struct {
+	store.StatusStore
+	Root *AuditLayer
+}
+
+type AuditLayerSystemStore struct {
+	store.SystemStore
+	Root *AuditLayer
+}
+
+type AuditLayerTeamStore struct {
+	store.TeamStore
+	Root *AuditLayer
+}
+
+type AuditLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *AuditLayer
+}
+
+type AuditLayerThreadStore struct {
+	store.ThreadStore
+	Root *AuditLayer
+}
+
+type AuditLayerTokenStore struct {
+	store.TokenStore
+	Root *AuditLayer
+}
+
+type AuditLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserStore struct {
+	store.UserStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *AuditLayer
+}
+
+type AuditLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *AuditLayer
+}
+
+type AuditLayerWebhookStore struct {
+	store.WebhookStore
+	Root *AuditLayer
+}
+
+func (s *AuditLayerAuditStore) PermanentDeleteByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Audit", "AuditStore.PermanentDeleteByUser", userId, func() bool {
+		err = s.AuditStore.PermanentDeleteByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerAuditStore) Save(audit *model.Audit) error {
+	var err error
+	auditErr := s.Root.record("Audit", "AuditStore.Save", audit, func() bool {
+		err = s.AuditStore.Save(audit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerBotStore) PermanentDelete(userId string) error {
+	var err error
+	auditErr := s.Root.record("Bot", "BotStore.PermanentDelete", userId, func() bool {
+		err = s.BotStore.PermanentDelete(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+	var result *model.Bot
+	var err error
+	auditErr := s.Root.record("Bot", "BotStore.Save", bot, func() bool {
+		result, err = s.BotStore.Save(bot)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+	var result *model.Bot
+	var err error
+	auditErr := s.Root.record("Bot", "BotStore.Update", bot, func() bool {
+		result, err = s.BotStore.Update(bot)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) ClearAllCustomRoleAssignments() error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.ClearAllCustomRoleAssignments", nil, func() bool {
+		err = s.ChannelStore.ClearAllCustomRoleAssignments()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.ClearSidebarOnTeamLeave", userId, func() bool {
+		err = s.ChannelStore.ClearSidebarOnTeamLeave(userId, teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+	var result *model.Channel
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.CreateDirectChannel", userId, func() bool {
+		result, err = s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.CreateInitialSidebarCategories", userId, func() bool {
+		err = s.ChannelStore.CreateInitialSidebarCategories(userId, teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+	var result *model.SidebarCategoryWithChannels
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.CreateSidebarCategory", userId, func() bool {
+		result, err = s.ChannelStore.CreateSidebarCategory(userId, teamId, newCategory)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) Delete(channelId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.Delete", channelId, func() bool {
+		err = s.ChannelStore.Delete(channelId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) DeleteSidebarCategory(categoryId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.DeleteSidebarCategory", categoryId, func() bool {
+		err = s.ChannelStore.DeleteSidebarCategory(categoryId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.DeleteSidebarChannelsByPreferences", preferences, func() bool {
+		err = s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.IncrementMentionCount", channelId, func() bool {
+		err = s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+	var result map[string]string
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.MigrateChannelMembers", fromChannelId, func() bool {
+		result, err = s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) MigratePublicChannels() error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.MigratePublicChannels", nil, func() bool {
+		err = s.ChannelStore.MigratePublicChannels()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) PermanentDelete(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.PermanentDelete", channelId, func() bool {
+		err = s.ChannelStore.PermanentDelete(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteByTeam(teamId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.PermanentDeleteByTeam", teamId, func() bool {
+		err = s.ChannelStore.PermanentDeleteByTeam(teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.PermanentDeleteMembersByChannel", channelId, func() bool {
+		err = s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) PermanentDeleteMembersByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.PermanentDeleteMembersByUser", userId, func() bool {
+		err = s.ChannelStore.PermanentDeleteMembersByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.RemoveAllDeactivatedMembers", channelId, func() bool {
+		err = s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) RemoveMember(channelId string, userId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.RemoveMember", channelId, func() bool {
+		err = s.ChannelStore.RemoveMember(channelId, userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) RemoveMembers(channelId string, userIds []string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.RemoveMembers", channelId, func() bool {
+		err = s.ChannelStore.RemoveMembers(channelId, userIds)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) ResetAllChannelSchemes() error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.ResetAllChannelSchemes", nil, func() bool {
+		err = s.ChannelStore.ResetAllChannelSchemes()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) Restore(channelId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.Restore", channelId, func() bool {
+		err = s.ChannelStore.Restore(channelId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	var result *model.Channel
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.Save", channel, func() bool {
+		result, err = s.ChannelStore.Save(channel, maxChannelsPerTeam)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	var result *model.Channel
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.SaveDirectChannel", channel, func() bool {
+		result, err = s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	var result *model.ChannelMember
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.SaveMember", member, func() bool {
+		result, err = s.ChannelStore.SaveMember(member)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	var result []*model.ChannelMember
+	var resultVar1 []*model.ChannelMember
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.SaveMemberMultiple", members, func() bool {
+		result, resultVar1, err = s.ChannelStore.SaveMemberMultiple(members)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, resultVar1, err
+}
+
+func (s *AuditLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	var result []*model.ChannelMember
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.SaveMultipleMembers", members, func() bool {
+		result, err = s.ChannelStore.SaveMultipleMembers(members)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.SetDeleteAt", channelId, func() bool {
+		err = s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	var result *model.Channel
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.Update", channel, func() bool {
+		result, err = s.ChannelStore.Update(channel)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+	var result map[string]int64
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateLastViewedAt", channelIds, func() bool {
+		result, err = s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	var result map[string]*model.ChannelUnreadCounts
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateLastViewedAtMulti", channelIds, func() bool {
+		result, err = s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+	var result *model.ChannelUnreadAt
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateLastViewedAtPost", unreadPost, func() bool {
+		result, err = s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	var result *model.ChannelMember
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateMember", member, func() bool {
+		result, err = s.ChannelStore.UpdateMember(member)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateMembersRole", channelID, func() bool {
+		err = s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	var result []*model.ChannelMember
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateMultipleMembers", members, func() bool {
+		result, err = s.ChannelStore.UpdateMultipleMembers(members)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+	var result []*model.SidebarCategoryWithChannels
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateSidebarCategories", userId, func() bool {
+		result, err = s.ChannelStore.UpdateSidebarCategories(userId, teamId, categories)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateSidebarCategoryOrder", userId, func() bool {
+		err = s.ChannelStore.UpdateSidebarCategoryOrder(userId, teamId, categoryOrder)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateSidebarChannelCategoryOnMove", channel, func() bool {
+		err = s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	var err error
+	auditErr := s.Root.record("Channel", "ChannelStore.UpdateSidebarChannelsByPreferences", preferences, func() bool {
+		err = s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+	var err error
+	auditErr := s.Root.record("ChannelMemberHistory", "ChannelMemberHistoryStore.LogJoinEvent", userId, func() bool {
+		err = s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+	var err error
+	auditErr := s.Root.record("ChannelMemberHistory", "ChannelMemberHistoryStore.LogLeaveEvent", userId, func() bool {
+		err = s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("ChannelMemberHistory", "ChannelMemberHistoryStore.PermanentDeleteBatch", endTime, func() bool {
+		result, err = s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerClusterDiscoveryStore) Cleanup() error {
+	var err error
+	auditErr := s.Root.record("ClusterDiscovery", "ClusterDiscoveryStore.Cleanup", nil, func() bool {
+		err = s.ClusterDiscoveryStore.Cleanup()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("ClusterDiscovery", "ClusterDiscoveryStore.Delete", discovery, func() bool {
+		result, err = s.ClusterDiscoveryStore.Delete(discovery)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+	var err error
+	auditErr := s.Root.record("ClusterDiscovery", "ClusterDiscoveryStore.Save", discovery, func() bool {
+		err = s.ClusterDiscoveryStore.Save(discovery)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+	var err error
+	auditErr := s.Root.record("ClusterDiscovery", "ClusterDiscoveryStore.SetLastPingAt", discovery, func() bool {
+		err = s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerCommandStore) Delete(commandId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Command", "CommandStore.Delete", commandId, func() bool {
+		err = s.CommandStore.Delete(commandId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerCommandStore) PermanentDeleteByTeam(teamId string) error {
+	var err error
+	auditErr := s.Root.record("Command", "CommandStore.PermanentDeleteByTeam", teamId, func() bool {
+		err = s.CommandStore.PermanentDeleteByTeam(teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerCommandStore) PermanentDeleteByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Command", "CommandStore.PermanentDeleteByUser", userId, func() bool {
+		err = s.CommandStore.PermanentDeleteByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+	var result *model.Command
+	var err error
+	auditErr := s.Root.record("Command", "CommandStore.Save", webhook, func() bool {
+		result, err = s.CommandStore.Save(webhook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+	var result *model.Command
+	var err error
+	auditErr := s.Root.record("Command", "CommandStore.Update", hook, func() bool {
+		result, err = s.CommandStore.Update(hook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+	var result *model.CommandWebhook
+	var err error
+	auditErr := s.Root.record("CommandWebhook", "CommandWebhookStore.Save", webhook, func() bool {
+		result, err = s.CommandWebhookStore.Save(webhook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerCommandWebhookStore) TryUse(id string, limit int) error {
+	var err error
+	auditErr := s.Root.record("CommandWebhook", "CommandWebhookStore.TryUse", id, func() bool {
+		err = s.CommandWebhookStore.TryUse(id, limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+	var result *model.Compliance
+	var err error
+	auditErr := s.Root.record("Compliance", "ComplianceStore.Save", compliance, func() bool {
+		result, err = s.ComplianceStore.Save(compliance)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+	var result *model.Compliance
+	var err error
+	auditErr := s.Root.record("Compliance", "ComplianceStore.Update", compliance, func() bool {
+		result, err = s.ComplianceStore.Update(compliance)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+	var err error
+	auditErr := s.Root.record("Emoji", "EmojiStore.Delete", emoji, func() bool {
+		err = s.EmojiStore.Delete(emoji, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	var result *model.Emoji
+	var err error
+	auditErr := s.Root.record("Emoji", "EmojiStore.Save", emoji, func() bool {
+		result, err = s.EmojiStore.Save(emoji)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.AttachToPost", fileId, func() bool {
+		err = s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.DeleteForPost", postId, func() bool {
+		result, err = s.FileInfoStore.DeleteForPost(postId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDelete(fileId string) error {
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.PermanentDelete", fileId, func() bool {
+		err = s.FileInfoStore.PermanentDelete(fileId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.PermanentDeleteBatch", endTime, func() bool {
+		result, err = s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.PermanentDeleteByUser", userId, func() bool {
+		result, err = s.FileInfoStore.PermanentDeleteByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	var result *model.FileInfo
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.Save", info, func() bool {
+		result, err = s.FileInfoStore.Save(info)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerFileInfoStore) SetContent(fileId string, content string) error {
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.SetContent", fileId, func() bool {
+		err = s.FileInfoStore.SetContent(fileId, content)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	var result *model.FileInfo
+	var err error
+	auditErr := s.Root.record("FileInfo", "FileInfoStore.Upsert", info, func() bool {
+		result, err = s.FileInfoStore.Upsert(info)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	var result *model.Group
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.Create", group, func() bool {
+		result, err = s.GroupStore.Create(group)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.Create", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	var result *model.GroupSyncable
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.CreateGroupSyncable", groupSyncable, func() bool {
+		result, err = s.GroupStore.CreateGroupSyncable(groupSyncable)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.CreateGroupSyncable", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	var result *model.Group
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.Delete", groupID, func() bool {
+		result, err = s.GroupStore.Delete(groupID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.Delete", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	var result *model.GroupSyncable
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.DeleteGroupSyncable", groupID, func() bool {
+		result, err = s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.DeleteGroupSyncable", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	var result *model.GroupMember
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.DeleteMember", groupID, func() bool {
+		result, err = s.GroupStore.DeleteMember(groupID, userID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.DeleteMember", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.PermanentDeleteMembersByUser", userId, func() bool {
+		err = s.GroupStore.PermanentDeleteMembersByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.PermanentDeleteMembersByUser", auditErr)
+	}
+	return err
+}
+
+func (s *AuditLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	var result *model.Group
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.Update", group, func() bool {
+		result, err = s.GroupStore.Update(group)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.Update", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	var result *model.GroupSyncable
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.UpdateGroupSyncable", groupSyncable, func() bool {
+		result, err = s.GroupStore.UpdateGroupSyncable(groupSyncable)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.UpdateGroupSyncable", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	var result *model.GroupMember
+	var err *model.AppError
+	auditErr := s.Root.record("Group", "GroupStore.UpsertMember", groupID, func() bool {
+		result, err = s.GroupStore.UpsertMember(groupID, userID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = newAppError("GroupStore.UpsertMember", auditErr)
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	var result *model.Job
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.ClaimPending", jobType, func() bool {
+		result, err = s.JobStore.ClaimPending(jobType, nodeId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) Delete(id string) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.Delete", id, func() bool {
+		result, err = s.JobStore.Delete(id)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+	var result *model.Job
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.Save", job, func() bool {
+		result, err = s.JobStore.Save(job)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.UpdateOptimistically", job, func() bool {
+		result, err = s.JobStore.UpdateOptimistically(job, currentStatus)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+	var result *model.Job
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.UpdateStatus", id, func() bool {
+		result, err = s.JobStore.UpdateStatus(id, status)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("Job", "JobStore.UpdateStatusOptimistically", id, func() bool {
+		result, err = s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+	var result *model.LicenseRecord
+	var err error
+	auditErr := s.Root.record("License", "LicenseStore.Save", license, func() bool {
+		result, err = s.LicenseStore.Save(license)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+	var result *model.LinkMetadata
+	var err error
+	auditErr := s.Root.record("LinkMetadata", "LinkMetadataStore.Save", linkMetadata, func() bool {
+		result, err = s.LinkMetadataStore.Save(linkMetadata)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerOAuthStore) DeleteApp(id string) error {
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.DeleteApp", id, func() bool {
+		err = s.OAuthStore.DeleteApp(id)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.PermanentDeleteAuthDataByUser", userId, func() bool {
+		err = s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerOAuthStore) RemoveAccessData(token string) error {
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.RemoveAccessData", token, func() bool {
+		err = s.OAuthStore.RemoveAccessData(token)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerOAuthStore) RemoveAllAccessData() error {
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.RemoveAllAccessData", nil, func() bool {
+		err = s.OAuthStore.RemoveAllAccessData()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerOAuthStore) RemoveAuthData(code string) error {
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.RemoveAuthData", code, func() bool {
+		err = s.OAuthStore.RemoveAuthData(code)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	var result *model.AccessData
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.SaveAccessData", accessData, func() bool {
+		result, err = s.OAuthStore.SaveAccessData(accessData)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	var result *model.OAuthApp
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.SaveApp", app, func() bool {
+		result, err = s.OAuthStore.SaveApp(app)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	var result *model.AuthData
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.SaveAuthData", authData, func() bool {
+		result, err = s.OAuthStore.SaveAuthData(authData)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	var result *model.AccessData
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.UpdateAccessData", accessData, func() bool {
+		result, err = s.OAuthStore.UpdateAccessData(accessData)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	var result *model.OAuthApp
+	var err error
+	auditErr := s.Root.record("OAuth", "OAuthStore.UpdateApp", app, func() bool {
+		result, err = s.OAuthStore.UpdateApp(app)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.CompareAndDelete", keyVal, func() bool {
+		result, err = s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.CompareAndSet", keyVal, func() bool {
+		result, err = s.PluginStore.CompareAndSet(keyVal, oldValue)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPluginStore) Delete(pluginId string, key string) error {
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.Delete", pluginId, func() bool {
+		err = s.PluginStore.Delete(pluginId, key)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPluginStore) DeleteAllExpired() error {
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.DeleteAllExpired", nil, func() bool {
+		err = s.PluginStore.DeleteAllExpired()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.DeleteAllForPlugin", PluginId, func() bool {
+		err = s.PluginStore.DeleteAllForPlugin(PluginId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	var result *model.PluginKeyValue
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.SaveOrUpdate", keyVal, func() bool {
+		result, err = s.PluginStore.SaveOrUpdate(keyVal)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	var result bool
+	var err error
+	auditErr := s.Root.record("Plugin", "PluginStore.SetWithOptions", pluginId, func() bool {
+		result, err = s.PluginStore.SetWithOptions(pluginId, key, value, options)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.Delete", postId, func() bool {
+		err = s.PostStore.Delete(postId, time, deleteByID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	var result []*model.Post
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.MoveThread", rootId, func() bool {
+		result, err = s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+	var result *model.Post
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.Overwrite", post, func() bool {
+		result, err = s.PostStore.Overwrite(post)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	var result []*model.Post
+	var resultVar1 int
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.OverwriteMultiple", posts, func() bool {
+		result, resultVar1, err = s.PostStore.OverwriteMultiple(posts)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, resultVar1, err
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.PermanentDeleteBatch", endTime, func() bool {
+		result, err = s.PostStore.PermanentDeleteBatch(endTime, limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.PermanentDeleteByChannel", channelId, func() bool {
+		err = s.PostStore.PermanentDeleteByChannel(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPostStore) PermanentDeleteByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.PermanentDeleteByUser", userId, func() bool {
+		err = s.PostStore.PermanentDeleteByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+	var result *model.Post
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.Save", post, func() bool {
+		result, err = s.PostStore.Save(post)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	var result []*model.Post
+	var resultVar1 int
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.SaveMultiple", posts, func() bool {
+		result, resultVar1, err = s.PostStore.SaveMultiple(posts)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, resultVar1, err
+}
+
+func (s *AuditLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	var result *model.Post
+	var err error
+	auditErr := s.Root.record("Post", "PostStore.Update", newPost, func() bool {
+		result, err = s.PostStore.Update(newPost, oldPost)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.CleanupFlagsBatch", limit, func() bool {
+		result, err = s.PreferenceStore.CleanupFlagsBatch(limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerPreferenceStore) Delete(userId string, category string, name string) error {
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.Delete", userId, func() bool {
+		err = s.PreferenceStore.Delete(userId, category, name)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.DeleteCategory", userId, func() bool {
+		err = s.PreferenceStore.DeleteCategory(userId, category)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.DeleteCategoryAndName", category, func() bool {
+		err = s.PreferenceStore.DeleteCategoryAndName(category, name)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.PermanentDeleteByUser", userId, func() bool {
+		err = s.PreferenceStore.PermanentDeleteByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPreferenceStore) Save(preferences *model.Preferences) error {
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.Save", preferences, func() bool {
+		err = s.PreferenceStore.Save(preferences)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	var result model.Preferences
+	var err error
+	auditErr := s.Root.record("Preference", "PreferenceStore.SaveMultiple", preferences, func() bool {
+		result, err = s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerProductNoticesStore) Clear(notices []string) error {
+	var err error
+	auditErr := s.Root.record("ProductNotices", "ProductNoticesStore.Clear", notices, func() bool {
+		err = s.ProductNoticesStore.Clear(notices)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+	var err error
+	auditErr := s.Root.record("ProductNotices", "ProductNoticesStore.ClearOldNotices", currentNotices, func() bool {
+		err = s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerProductNoticesStore) View(userId string, notices []string) error {
+	var err error
+	auditErr := s.Root.record("ProductNotices", "ProductNoticesStore.View", userId, func() bool {
+		err = s.ProductNoticesStore.View(userId, notices)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	var result *model.Reaction
+	var err error
+	auditErr := s.Root.record("Reaction", "ReactionStore.Delete", reaction, func() bool {
+		result, err = s.ReactionStore.Delete(reaction)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	var err error
+	auditErr := s.Root.record("Reaction", "ReactionStore.DeleteAllWithEmojiName", emojiName, func() bool {
+		err = s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("Reaction", "ReactionStore.PermanentDeleteBatch", endTime, func() bool {
+		result, err = s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	var result *model.Reaction
+	var err error
+	auditErr := s.Root.record("Reaction", "ReactionStore.Save", reaction, func() bool {
+		result, err = s.ReactionStore.Save(reaction)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerRoleStore) Delete(roleId string) (*model.Role, error) {
+	var result *model.Role
+	var err error
+	auditErr := s.Root.record("Role", "RoleStore.Delete", roleId, func() bool {
+		result, err = s.RoleStore.Delete(roleId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerRoleStore) PermanentDeleteAll() error {
+	var err error
+	auditErr := s.Root.record("Role", "RoleStore.PermanentDeleteAll", nil, func() bool {
+		err = s.RoleStore.PermanentDeleteAll()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+	var result *model.Role
+	var err error
+	auditErr := s.Root.record("Role", "RoleStore.Save", role, func() bool {
+		result, err = s.RoleStore.Save(role)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+	var result *model.Scheme
+	var err error
+	auditErr := s.Root.record("Scheme", "SchemeStore.Delete", schemeId, func() bool {
+		result, err = s.SchemeStore.Delete(schemeId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSchemeStore) PermanentDeleteAll() error {
+	var err error
+	auditErr := s.Root.record("Scheme", "SchemeStore.PermanentDeleteAll", nil, func() bool {
+		err = s.SchemeStore.PermanentDeleteAll()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	var result *model.Scheme
+	var err error
+	auditErr := s.Root.record("Scheme", "SchemeStore.Save", scheme, func() bool {
+		result, err = s.SchemeStore.Save(scheme)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.PermanentDeleteSessionsByUser", teamId, func() bool {
+		err = s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) Remove(sessionIdOrToken string) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.Remove", sessionIdOrToken, func() bool {
+		err = s.SessionStore.Remove(sessionIdOrToken)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) RemoveAllSessions() error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.RemoveAllSessions", nil, func() bool {
+		err = s.SessionStore.RemoveAllSessions()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+	var result *model.Session
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.Save", session, func() bool {
+		result, err = s.SessionStore.Save(session)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateDeviceId", id, func() bool {
+		result, err = s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateExpiredNotify", sessionid, func() bool {
+		err = s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateExpiresAt", sessionId, func() bool {
+		err = s.SessionStore.UpdateExpiresAt(sessionId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateLastActivityAt", sessionId, func() bool {
+		err = s.SessionStore.UpdateLastActivityAt(sessionId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) UpdateProps(session *model.Session) error {
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateProps", session, func() bool {
+		err = s.SessionStore.UpdateProps(session)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("Session", "SessionStore.UpdateRoles", userId, func() bool {
+		result, err = s.SessionStore.UpdateRoles(userId, roles)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerStatusStore) ResetAll() error {
+	var err error
+	auditErr := s.Root.record("Status", "StatusStore.ResetAll", nil, func() bool {
+		err = s.StatusStore.ResetAll()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+	var err error
+	auditErr := s.Root.record("Status", "StatusStore.SaveOrUpdate", status, func() bool {
+		err = s.StatusStore.SaveOrUpdate(status)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+	var err error
+	auditErr := s.Root.record("Status", "StatusStore.UpdateLastActivityAt", userId, func() bool {
+		err = s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	var result *model.System
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.InsertIfExists", system, func() bool {
+		result, err = s.SystemStore.InsertIfExists(system)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	var result *model.System
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.PermanentDeleteByName", name, func() bool {
+		result, err = s.SystemStore.PermanentDeleteByName(name)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerSystemStore) Save(system *model.System) error {
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.Save", system, func() bool {
+		err = s.SystemStore.Save(system)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSystemStore) SaveOrUpdate(system *model.System) error {
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.SaveOrUpdate", system, func() bool {
+		err = s.SystemStore.SaveOrUpdate(system)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.SaveOrUpdateWithWarnMetricHandling", system, func() bool {
+		err = s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerSystemStore) Update(system *model.System) error {
+	var err error
+	auditErr := s.Root.record("System", "SystemStore.Update", system, func() bool {
+		err = s.SystemStore.Update(system)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) ClearAllCustomRoleAssignments() error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.ClearAllCustomRoleAssignments", nil, func() bool {
+		err = s.TeamStore.ClearAllCustomRoleAssignments()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+	var result map[string]string
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.MigrateTeamMembers", fromTeamId, func() bool {
+		result, err = s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) PermanentDelete(teamId string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.PermanentDelete", teamId, func() bool {
+		err = s.TeamStore.PermanentDelete(teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) RemoveAllMembersByTeam(teamId string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.RemoveAllMembersByTeam", teamId, func() bool {
+		err = s.TeamStore.RemoveAllMembersByTeam(teamId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) RemoveAllMembersByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.RemoveAllMembersByUser", userId, func() bool {
+		err = s.TeamStore.RemoveAllMembersByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) RemoveMember(teamId string, userId string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.RemoveMember", teamId, func() bool {
+		err = s.TeamStore.RemoveMember(teamId, userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) RemoveMembers(teamId string, userIds []string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.RemoveMembers", teamId, func() bool {
+		err = s.TeamStore.RemoveMembers(teamId, userIds)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) ResetAllTeamSchemes() error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.ResetAllTeamSchemes", nil, func() bool {
+		err = s.TeamStore.ResetAllTeamSchemes()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+	var result *model.Team
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.Save", team, func() bool {
+		result, err = s.TeamStore.Save(team)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	var result *model.TeamMember
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.SaveMember", member, func() bool {
+		result, err = s.TeamStore.SaveMember(member, maxUsersPerTeam)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+	var result []*model.TeamMember
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.SaveMultipleMembers", members, func() bool {
+		result, err = s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+	var result *model.Team
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.Update", team, func() bool {
+		result, err = s.TeamStore.Update(team)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.UpdateLastTeamIconUpdate", teamId, func() bool {
+		err = s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+	var result *model.TeamMember
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.UpdateMember", member, func() bool {
+		result, err = s.TeamStore.UpdateMember(member)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.UpdateMembersRole", teamID, func() bool {
+		err = s.TeamStore.UpdateMembersRole(teamID, userIDs)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+	var result []*model.TeamMember
+	var err error
+	auditErr := s.Root.record("Team", "TeamStore.UpdateMultipleMembers", members, func() bool {
+		result, err = s.TeamStore.UpdateMultipleMembers(members)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+	var result *model.TermsOfService
+	var err error
+	auditErr := s.Root.record("TermsOfService", "TermsOfServiceStore.Save", termsOfService, func() bool {
+		result, err = s.TermsOfServiceStore.Save(termsOfService)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.CreateMembershipIfNeeded", userId, func() bool {
+		err = s.ThreadStore.CreateMembershipIfNeeded(userId, postId, following)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerThreadStore) Delete(postId string) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.Delete", postId, func() bool {
+		err = s.ThreadStore.Delete(postId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.DeleteMembershipForUser", userId, func() bool {
+		err = s.ThreadStore.DeleteMembershipForUser(userId, postId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.MarkAllAsRead", userId, func() bool {
+		err = s.ThreadStore.MarkAllAsRead(userId, timestamp)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.MarkAsRead", userId, func() bool {
+		err = s.ThreadStore.MarkAsRead(userId, threadId, timestamp)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+	var result *model.Thread
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.Save", thread, func() bool {
+		result, err = s.ThreadStore.Save(thread)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	var result *model.ThreadMembership
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.SaveMembership", membership, func() bool {
+		result, err = s.ThreadStore.SaveMembership(membership)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+	var result []*model.Thread
+	var resultVar1 int
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.SaveMultiple", thread, func() bool {
+		result, resultVar1, err = s.ThreadStore.SaveMultiple(thread)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, resultVar1, err
+}
+
+func (s *AuditLayerThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+	var result *model.Thread
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.Update", thread, func() bool {
+		result, err = s.ThreadStore.Update(thread)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	var result *model.ThreadMembership
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.UpdateMembership", membership, func() bool {
+		result, err = s.ThreadStore.UpdateMembership(membership)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+	var err error
+	auditErr := s.Root.record("Thread", "ThreadStore.UpdateUnreadsByChannel", userId, func() bool {
+		err = s.ThreadStore.UpdateUnreadsByChannel(userId, changedThreads, timestamp)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTokenStore) Delete(token string) error {
+	var err error
+	auditErr := s.Root.record("Token", "TokenStore.Delete", token, func() bool {
+		err = s.TokenStore.Delete(token)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+	var err error
+	auditErr := s.Root.record("Token", "TokenStore.RemoveAllTokensByType", tokenType, func() bool {
+		err = s.TokenStore.RemoveAllTokensByType(tokenType)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerTokenStore) Save(recovery *model.Token) error {
+	var err error
+	auditErr := s.Root.record("Token", "TokenStore.Save", recovery, func() bool {
+		err = s.TokenStore.Save(recovery)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUploadSessionStore) Delete(id string) error {
+	var err error
+	auditErr := s.Root.record("UploadSession", "UploadSessionStore.Delete", id, func() bool {
+		err = s.UploadSessionStore.Delete(id)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+	var result *model.UploadSession
+	var err error
+	auditErr := s.Root.record("UploadSession", "UploadSessionStore.Save", session, func() bool {
+		result, err = s.UploadSessionStore.Save(session)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUploadSessionStore) Update(session *model.UploadSession) error {
+	var err error
+	auditErr := s.Root.record("UploadSession", "UploadSessionStore.Update", session, func() bool {
+		err = s.UploadSessionStore.Update(session)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) ClearAllCustomRoleAssignments() error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.ClearAllCustomRoleAssignments", nil, func() bool {
+		err = s.UserStore.ClearAllCustomRoleAssignments()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) DeactivateGuests() ([]string, error) {
+	var result []string
+	var err error
+	auditErr := s.Root.record("User", "UserStore.DeactivateGuests", nil, func() bool {
+		result, err = s.UserStore.DeactivateGuests()
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserStore) DemoteUserToGuest(userID string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.DemoteUserToGuest", userID, func() bool {
+		err = s.UserStore.DemoteUserToGuest(userID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) PermanentDelete(userId string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.PermanentDelete", userId, func() bool {
+		err = s.UserStore.PermanentDelete(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) PromoteGuestToUser(userID string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.PromoteGuestToUser", userID, func() bool {
+		err = s.UserStore.PromoteGuestToUser(userID)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) ResetLastPictureUpdate(userId string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.ResetLastPictureUpdate", userId, func() bool {
+		err = s.UserStore.ResetLastPictureUpdate(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) Save(user *model.User) (*model.User, error) {
+	var result *model.User
+	var err error
+	auditErr := s.Root.record("User", "UserStore.Save", user, func() bool {
+		result, err = s.UserStore.Save(user)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	var result *model.UserUpdate
+	var err error
+	auditErr := s.Root.record("User", "UserStore.Update", user, func() bool {
+		result, err = s.UserStore.Update(user, allowRoleUpdate)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateAuthData", userId, func() bool {
+		result, err = s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateFailedPasswordAttempts", userId, func() bool {
+		err = s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdateLastPictureUpdate(userId string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateLastPictureUpdate", userId, func() bool {
+		err = s.UserStore.UpdateLastPictureUpdate(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdateMfaActive(userId string, active bool) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateMfaActive", userId, func() bool {
+		err = s.UserStore.UpdateMfaActive(userId, active)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdateMfaSecret(userId string, secret string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateMfaSecret", userId, func() bool {
+		err = s.UserStore.UpdateMfaSecret(userId, secret)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdatePassword(userId string, newPassword string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdatePassword", userId, func() bool {
+		err = s.UserStore.UpdatePassword(userId, newPassword)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdatePasswordHash", userId, func() bool {
+		err = s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
+	var result int64
+	var err error
+	auditErr := s.Root.record("User", "UserStore.UpdateUpdateAt", userId, func() bool {
+		result, err = s.UserStore.UpdateUpdateAt(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserStore) VerifyEmail(userId string, email string) (string, error) {
+	var result string
+	var err error
+	auditErr := s.Root.record("User", "UserStore.VerifyEmail", userId, func() bool {
+		result, err = s.UserStore.VerifyEmail(userId, email)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserAccessTokenStore) Delete(tokenId string) error {
+	var err error
+	auditErr := s.Root.record("UserAccessToken", "UserAccessTokenStore.Delete", tokenId, func() bool {
+		err = s.UserAccessTokenStore.Delete(tokenId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserAccessTokenStore) DeleteAllForUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("UserAccessToken", "UserAccessTokenStore.DeleteAllForUser", userId, func() bool {
+		err = s.UserAccessTokenStore.DeleteAllForUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+	var result *model.UserAccessToken
+	var err error
+	auditErr := s.Root.record("UserAccessToken", "UserAccessTokenStore.Save", token, func() bool {
+		result, err = s.UserAccessTokenStore.Save(token)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+	var err error
+	auditErr := s.Root.record("UserAccessToken", "UserAccessTokenStore.UpdateTokenDisable", tokenId, func() bool {
+		err = s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+	var err error
+	auditErr := s.Root.record("UserAccessToken", "UserAccessTokenStore.UpdateTokenEnable", tokenId, func() bool {
+		err = s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+	var err error
+	auditErr := s.Root.record("UserTermsOfService", "UserTermsOfServiceStore.Delete", userId, func() bool {
+		err = s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+	var result *model.UserTermsOfService
+	var err error
+	auditErr := s.Root.record("UserTermsOfService", "UserTermsOfServiceStore.Save", userTermsOfService, func() bool {
+		result, err = s.UserTermsOfServiceStore.Save(userTermsOfService)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.DeleteIncoming", webhookId, func() bool {
+		err = s.WebhookStore.DeleteIncoming(webhookId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.DeleteOutgoing", webhookId, func() bool {
+		err = s.WebhookStore.DeleteOutgoing(webhookId, time)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.PermanentDeleteIncomingByChannel", channelId, func() bool {
+		err = s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.PermanentDeleteIncomingByUser", userId, func() bool {
+		err = s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.PermanentDeleteOutgoingByChannel", channelId, func() bool {
+		err = s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.PermanentDeleteOutgoingByUser", userId, func() bool {
+		err = s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return err
+}
+
+func (s *AuditLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	var result *model.IncomingWebhook
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.SaveIncoming", webhook, func() bool {
+		result, err = s.WebhookStore.SaveIncoming(webhook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	var result *model.OutgoingWebhook
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.SaveOutgoing", webhook, func() bool {
+		result, err = s.WebhookStore.SaveOutgoing(webhook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	var result *model.IncomingWebhook
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.UpdateIncoming", webhook, func() bool {
+		result, err = s.WebhookStore.UpdateIncoming(webhook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	var result *model.OutgoingWebhook
+	var err error
+	auditErr := s.Root.record("Webhook", "WebhookStore.UpdateOutgoing", hook, func() bool {
+		result, err = s.WebhookStore.UpdateOutgoing(hook)
+		return err == nil
+	})
+	if err == nil && auditErr != nil {
+		err = auditErr
+	}
+	return result, err
+}
+
+func (s *AuditLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *AuditLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
+func (s *AuditLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *AuditLayer) GetCurrentSchemaVersion() string {
+	return s.Store.GetCurrentSchemaVersion()
+}
+
+func (s *AuditLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *AuditLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *AuditLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
+func (s *AuditLayer) SetContext(context context.Context) {
+	s.Store.SetContext(context)
+}
+
+func (s *AuditLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *AuditLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *AuditLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *AuditLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+// New wraps the child store with the audit layer, recording the changes made through every
+// store but the audit trail itself as made by the actor of the context, see WithActor.
+func New(childStore store.Store, ctx context.Context) *AuditLayer {
+	newStore := AuditLayer{
+		Store: childStore,
+		ctx:   ctx,
+	}
+
+	newStore.AuditStore = &AuditLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &AuditLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
+	newStore.BotStore = &AuditLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &AuditLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &AuditLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &AuditLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &AuditLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &AuditLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &AuditLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.EmojiStore = &AuditLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &AuditLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &AuditLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &AuditLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &AuditLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &AuditLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.OAuthStore = &AuditLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PluginStore = &AuditLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &AuditLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PreferenceStore = &AuditLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &AuditLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.ReactionStore = &AuditLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RoleStore = &AuditLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemeStore = &AuditLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &AuditLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &AuditLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &AuditLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &AuditLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &AuditLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &AuditLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &AuditLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &AuditLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &AuditLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &AuditLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &AuditLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &AuditLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package auditlayer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+// runChange makes the mock audit trail run the change it's given, like the store does.
+func runChange(args mock.Arguments) {
+	args.Get(1).(func() bool)()
+}
+
+func TestAuditLayer(t *testing.T) {
+	ctx := WithActor(context.Background(), "actor")
+
+	t.Run("records a successful change", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		var recorded *model.AuditTrailEntry
+		mockStore.AuditTrailStore.On("Record", mock.MatchedBy(func(entry *model.AuditTrailEntry) bool {
+			return entry.ActorId == "actor" && entry.EntityType == "Channel" && entry.EntityId == "" && entry.Operation == "ChannelStore.Save"
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			runChange(args)
+			recorded = args.Get(0).(*model.AuditTrailEntry)
+		}).Return(nil).Once()
+		mockStore.ChannelStore.On("Save", mock.Anything, int64(-1)).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Channel).Id = "channel"
+		}).Return(&model.Channel{Id: "channel"}, nil).Once()
+
+		channel, err := New(mockStore, ctx).Channel().Save(&model.Channel{}, -1)
+		require.NoError(t, err)
+		assert.Equal(t, "channel", channel.Id)
+		assert.Equal(t, "channel", recorded.EntityId, "the id of the created entity should be recorded")
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("returns the error of a failed change", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.AuditTrailStore.On("Record", mock.Anything, mock.Anything).Run(runChange).Return(nil).Once()
+		mockStore.UserStore.On("UpdatePassword", "user", "hash").Return(errors.New("failed")).Once()
+
+		require.EqualError(t, New(mockStore, ctx).User().UpdatePassword("user", "hash"), "failed")
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("fails the changes that can't be recorded", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.AuditTrailStore.On("Record", mock.Anything, mock.Anything).Return(errors.New("failed"))
+
+		err := New(mockStore, ctx).User().UpdatePassword("user", "hash")
+		require.Error(t, err)
+
+		_, appErr := New(mockStore, ctx).Group().Create(&model.Group{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+
+		mockStore.UserStore.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+		mockStore.GroupStore.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("makes the change once when the audit trail is retried", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.AuditTrailStore.On("Record", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			runChange(args)
+			runChange(args)
+		}).Return(nil).Once()
+		mockStore.UserStore.On("UpdatePassword", "user", "hash").Return(nil).Once()
+
+		require.NoError(t, New(mockStore, ctx).User().UpdatePassword("user", "hash"))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("records the actor of each context", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.AuditTrailStore.On("Record", mock.MatchedBy(func(entry *model.AuditTrailEntry) bool {
+			return entry.ActorId == "" && entry.EntityId == "user1"
+		}), mock.Anything).Run(runChange).Return(nil).Once()
+		mockStore.AuditTrailStore.On("Record", mock.MatchedBy(func(entry *model.AuditTrailEntry) bool {
+			return entry.ActorId == "actor" && entry.EntityId == "user2"
+		}), mock.Anything).Run(runChange).Return(nil).Once()
+		mockStore.UserStore.On("UpdatePassword", mock.Anything, "hash").Return(nil).Twice()
+
+		layer := New(mockStore, context.Background())
+		require.NoError(t, layer.User().UpdatePassword("user1", "hash"))
+		require.NoError(t, layer.WithContext(ctx).User().UpdatePassword("user2", "hash"))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects the changes as unavailable when the store is read-only", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.AuditTrailStore.On("Record", mock.Anything, mock.Anything).Return(store.NewErrReadOnly("AuditTrailStore.Record"))
+
+		err := New(mockStore, ctx).User().UpdatePassword("user", "hash")
+		var readOnlyErr *store.ErrReadOnly
+		require.True(t, errors.As(err, &readOnlyErr))
+
+		_, appErr := New(mockStore, ctx).Group().Create(&model.Group{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.StatusCode)
+	})
+
+	t.Run("doesn't record the reads", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("Get", "user").Return(&model.User{Id: "user"}, nil).Once()
+
+		_, err := New(mockStore, ctx).User().Get("user")
+		require.NoError(t, err)
+		mockStore.AuditTrailStore.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+}
+
+func TestActorFromContext(t *testing.T) {
+	assert.Equal(t, "", ActorFromContext(nil))
+	assert.Equal(t, "", ActorFromContext(context.Background()))
+	assert.Equal(t, "actor", ActorFromContext(WithActor(nil, "actor")))
+}
+
+func TestEntityId(t *testing.T) {
+	assert.Equal(t, "id", entityId("id"))
+	assert.Equal(t, "post", entityId(&model.Post{Id: "post"}))
+	assert.Equal(t, "channel", entityId(&model.ChannelMember{ChannelId: "channel", UserId: "user"}))
+	assert.Equal(t, "", entityId([]string{"id"}))
+	assert.Equal(t, "", entityId((*model.Post)(nil)))
+	assert.Equal(t, "", entityId(nil))
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package circuitbreakerlayer
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets every query through.
+	StateClosed State = iota
+	// StateOpen rejects queries until the cool-down period is over.
+	StateOpen
+	// StateHalfOpen lets a single probe query through, closing the circuit if it succeeds.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker trips after a number of consecutive query failures within a window, rejecting
+// queries with a store.ErrCircuitOpen error for a cool-down period. Once the cool-down
+// period is over, a single probe query decides whether the circuit closes again.
+type Breaker struct {
+	mut          sync.Mutex
+	threshold    int
+	window       time.Duration
+	cooldown     time.Duration
+	bypassWrites bool
+
+	state        State
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+
+	now func() time.Time
+}
+
+// NewBreaker creates a breaker as configured by the CircuitBreaker SqlSettings. A
+// non-positive threshold disables the breaker.
+func NewBreaker(settings *model.SqlSettings) *Breaker {
+	b := &Breaker{
+		threshold: model.SQL_SETTINGS_DEFAULT_CIRCUIT_BREAKER_THRESHOLD,
+		window:    time.Duration(model.SQL_SETTINGS_DEFAULT_CIRCUIT_BREAKER_WINDOW_SECONDS) * time.Second,
+		cooldown:  time.Duration(model.SQL_SETTINGS_DEFAULT_CIRCUIT_BREAKER_COOLDOWN_SECONDS) * time.Second,
+		now:       time.Now,
+	}
+
+	if settings.CircuitBreakerThreshold != nil {
+		b.threshold = *settings.CircuitBreakerThreshold
+	}
+	if settings.CircuitBreakerWindowSeconds != nil {
+		b.window = time.Duration(*settings.CircuitBreakerWindowSeconds) * time.Second
+	}
+	if settings.CircuitBreakerCooldownSeconds != nil {
+		b.cooldown = time.Duration(*settings.CircuitBreakerCooldownSeconds) * time.Second
+	}
+	if settings.CircuitBreakerBypassWrites != nil {
+		b.bypassWrites = *settings.CircuitBreakerBypassWrites
+	}
+
+	return b
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.state
+}
+
+func (b *Breaker) ignores(write bool) bool {
+	return b.threshold <= 0 || (write && b.bypassWrites)
+}
+
+// Allow returns a store.ErrCircuitOpen error if the query must be rejected. Every allowed
+// query must be followed by a call to Done with its result.
+func (b *Breaker) Allow(write bool) error {
+	if b.ignores(write) {
+		return nil
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return store.NewErrCircuitOpen()
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return store.NewErrCircuitOpen()
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Done records the result of a query allowed by Allow.
+func (b *Breaker) Done(write bool, err error) {
+	if b.ignores(write) {
+		return
+	}
+
+	failed := isFailure(err)
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		if failed {
+			b.trip(now)
+		} else {
+			mlog.Info("Database circuit breaker closed.")
+			b.state = StateClosed
+			b.failures = 0
+		}
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		if b.failures == 0 || now.Sub(b.firstFailure) > b.window {
+			b.failures = 0
+			b.firstFailure = now
+		}
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip(now)
+		}
+	}
+}
+
+func (b *Breaker) trip(now time.Time) {
+	mlog.Warn("Database circuit breaker opened.", mlog.Int("failures", b.failures), mlog.Duration("cooldown", b.cooldown))
+	b.state = StateOpen
+	b.openedAt = now
+	b.failures = 0
+}
+
+// isFailure reports whether the error hints at an unavailable database, as opposed
+// to errors caused by the query itself.
+func isFailure(err error) bool {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+
+	var invalidInput *store.ErrInvalidInput
+	var limitExceeded *store.ErrLimitExceeded
+	var conflict *store.ErrConflict
+	var notFound *store.ErrNotFound
+	var outOfBounds *store.ErrOutOfBounds
+	var notImplemented *store.ErrNotImplemented
+	var appErr *model.AppError
+	switch {
+	case errors.As(err, &invalidInput),
+		errors.As(err, &limitExceeded),
+		errors.As(err, &conflict),
+		errors.As(err, &notFound),
+		errors.As(err, &outOfBounds),
+		errors.As(err, &notImplemented):
+		return false
+	case errors.As(err, &appErr):
+		return appErr.StatusCode >= 500
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package circuitbreakerlayer
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func newTestBreaker(threshold int, bypassWrites bool) (*Breaker, *time.Time) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	settings := &model.SqlSettings{}
+	settings.SetDefaults(false)
+	settings.CircuitBreakerThreshold = model.NewInt(threshold)
+	settings.CircuitBreakerWindowSeconds = model.NewInt(10)
+	settings.CircuitBreakerCooldownSeconds = model.NewInt(30)
+	settings.CircuitBreakerBypassWrites = model.NewBool(bypassWrites)
+
+	b := NewBreaker(settings)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func fail(t *testing.T, b *Breaker, write bool, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		require.Nil(t, b.Allow(write))
+		b.Done(write, errors.New("connection refused"))
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("trips after consecutive failures", func(t *testing.T) {
+		b, _ := newTestBreaker(3, false)
+
+		fail(t, b, false, 2)
+		assert.Equal(t, StateClosed, b.State())
+
+		fail(t, b, false, 1)
+		assert.Equal(t, StateOpen, b.State())
+
+		err := b.Allow(false)
+		var circuitOpen *store.ErrCircuitOpen
+		assert.True(t, errors.As(err, &circuitOpen))
+	})
+
+	t.Run("success resets the failure count", func(t *testing.T) {
+		b, _ := newTestBreaker(3, false)
+
+		fail(t, b, false, 2)
+		require.Nil(t, b.Allow(false))
+		b.Done(false, nil)
+		fail(t, b, false, 2)
+		assert.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("failures outside the window don't add up", func(t *testing.T) {
+		b, now := newTestBreaker(3, false)
+
+		fail(t, b, false, 2)
+		*now = now.Add(11 * time.Second)
+		fail(t, b, false, 2)
+		assert.Equal(t, StateClosed, b.State())
+
+		fail(t, b, false, 1)
+		assert.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("errors caused by the query are ignored", func(t *testing.T) {
+		b, _ := newTestBreaker(1, false)
+
+		for _, err := range []error{
+			sql.ErrNoRows,
+			store.NewErrNotFound("Post", "id"),
+			store.NewErrInvalidInput("Post", "id", "value"),
+			model.NewAppError("Test", "id", nil, "", 400),
+		} {
+			require.Nil(t, b.Allow(false))
+			b.Done(false, err)
+		}
+		assert.Equal(t, StateClosed, b.State())
+
+		require.Nil(t, b.Allow(false))
+		b.Done(false, model.NewAppError("Test", "id", nil, "", 500))
+		assert.Equal(t, StateOpen, b.State())
+	})
+
+	t.Run("successful probe closes the circuit", func(t *testing.T) {
+		b, now := newTestBreaker(1, false)
+
+		fail(t, b, false, 1)
+		*now = now.Add(29 * time.Second)
+		assert.NotNil(t, b.Allow(false))
+
+		*now = now.Add(time.Second)
+		require.Nil(t, b.Allow(false))
+		assert.Equal(t, StateHalfOpen, b.State())
+		assert.NotNil(t, b.Allow(false), "only a single probe is allowed")
+
+		b.Done(false, nil)
+		assert.Equal(t, StateClosed, b.State())
+		assert.Nil(t, b.Allow(false))
+	})
+
+	t.Run("failed probe reopens the circuit", func(t *testing.T) {
+		b, now := newTestBreaker(1, false)
+
+		fail(t, b, false, 1)
+		*now = now.Add(30 * time.Second)
+		fail(t, b, false, 1)
+		assert.Equal(t, StateOpen, b.State())
+
+		*now = now.Add(29 * time.Second)
+		assert.NotNil(t, b.Allow(false))
+	})
+
+	t.Run("writes bypass the breaker", func(t *testing.T) {
+		b, _ := newTestBreaker(1, true)
+
+		fail(t, b, true, 5)
+		assert.Equal(t, StateClosed, b.State())
+
+		fail(t, b, false, 1)
+		assert.Equal(t, StateOpen, b.State())
+		assert.Nil(t, b.Allow(true))
+		assert.NotNil(t, b.Allow(false))
+	})
+
+	t.Run("disabled breaker", func(t *testing.T) {
+		b, _ := newTestBreaker(0, false)
+
+		fail(t, b, false, 5)
+		assert.Equal(t, StateClosed, b.State())
+	})
+}
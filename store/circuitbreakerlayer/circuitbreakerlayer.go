@@ -0,0 +1,6951 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package circuitbreakerlayer
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type CircuitBreakerLayer struct {
+	store.Store
+	Breaker                   *Breaker
+	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
+	BotStore                  store.BotStore
+	ChannelStore              store.ChannelStore
+	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore     store.ClusterDiscoveryStore
+	CommandStore              store.CommandStore
+	CommandWebhookStore       store.CommandWebhookStore
+	ComplianceStore           store.ComplianceStore
+	EmojiStore                store.EmojiStore
+	FileInfoStore             store.FileInfoStore
+	GroupStore                store.GroupStore
+	JobStore                  store.JobStore
+	LicenseStore              store.LicenseStore
+	LinkMetadataStore         store.LinkMetadataStore
+	OAuthStore                store.OAuthStore
+	PluginStore               store.PluginStore
+	PostStore                 store.PostStore
+	PreferenceStore           store.PreferenceStore
+	ProductNoticesStore       store.ProductNoticesStore
+	ReactionStore             store.ReactionStore
+	RoleStore                 store.RoleStore
+	SchemeStore               store.SchemeStore
+	SessionStore              store.SessionStore
+	StatusStore               store.StatusStore
+	SystemStore               store.SystemStore
+	TeamStore                 store.TeamStore
+	TermsOfServiceStore       store.TermsOfServiceStore
+	ThreadStore               store.ThreadStore
+	TokenStore                store.TokenStore
+	UploadSessionStore        store.UploadSessionStore
+	UserStore                 store.UserStore
+	UserAccessTokenStore      store.UserAccessTokenStore
+	UserTermsOfServiceStore   store.UserTermsOfServiceStore
+	WebhookStore              store.WebhookStore
+}
+
+func (s *CircuitBreakerLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *CircuitBreakerLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
+func (s *CircuitBreakerLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *CircuitBreakerLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *CircuitBreakerLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *CircuitBreakerLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *CircuitBreakerLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *CircuitBreakerLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *CircuitBreakerLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *CircuitBreakerLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *CircuitBreakerLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *CircuitBreakerLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *CircuitBreakerLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *CircuitBreakerLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *CircuitBreakerLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *CircuitBreakerLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *CircuitBreakerLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *CircuitBreakerLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *CircuitBreakerLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *CircuitBreakerLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *CircuitBreakerLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *CircuitBreakerLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *CircuitBreakerLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *CircuitBreakerLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *CircuitBreakerLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *CircuitBreakerLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *CircuitBreakerLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *CircuitBreakerLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *CircuitBreakerLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *CircuitBreakerLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *CircuitBreakerLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *CircuitBreakerLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *CircuitBreakerLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *CircuitBreakerLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *CircuitBreakerLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type CircuitBreakerLayerAuditStore struct {
+	store.AuditStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerBotStore struct {
+	store.BotStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerChannelStore struct {
+	store.ChannelStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerCommandStore struct {
+	store.CommandStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerEmojiStore struct {
+	store.EmojiStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerGroupStore struct {
+	store.GroupStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerJobStore struct {
+	store.JobStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerLicenseStore struct {
+	store.LicenseStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerOAuthStore struct {
+	store.OAuthStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerPluginStore struct {
+	store.PluginStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerPostStore struct {
+	store.PostStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerReactionStore struct {
+	store.ReactionStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerRoleStore struct {
+	store.RoleStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerSchemeStore struct {
+	store.SchemeStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerSessionStore struct {
+	store.SessionStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerStatusStore struct {
+	store.StatusStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerSystemStore struct {
+	store.SystemStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerTeamStore struct {
+	store.TeamStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerThreadStore struct {
+	store.ThreadStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerTokenStore struct {
+	store.TokenStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerUserStore struct {
+	store.UserStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *CircuitBreakerLayer
+}
+
+type CircuitBreakerLayerWebhookStore struct {
+	store.WebhookStore
+	Root *CircuitBreakerLayer
+}
+
+func (s *CircuitBreakerLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.Audits
+		return result, err
+	}
+	result, err := s.AuditStore.Get(user_id, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerAuditStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.AuditStore.PermanentDeleteByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerAuditStore) Save(audit *model.Audit) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.AuditStore.Save(audit)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.AuditTrailEntry
+		return result, err
+	}
+	result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.AuditTrailStore.Record(entry, change)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+	result, err := s.BotStore.Get(userId, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Bot
+		return result, err
+	}
+	result, err := s.BotStore.GetAll(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerBotStore) PermanentDelete(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.BotStore.PermanentDelete(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+	result, err := s.BotStore.Save(bot)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Bot
+		return result, err
+	}
+	result, err := s.BotStore.Update(bot)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.ClearAllCustomRoleAssignments()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) ClearCaches() {
+
+	s.ChannelStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.ClearSidebarOnTeamLeave(userId, teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) CountPostsAfter(channelId string, timestamp int64, userId string) (int, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int
+		return result, err
+	}
+	result, err := s.ChannelStore.CountPostsAfter(channelId, timestamp, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.CreateInitialSidebarCategories(userId, teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.SidebarCategoryWithChannels
+		return result, err
+	}
+	result, err := s.ChannelStore.CreateSidebarCategory(userId, teamId, newCategory)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) Delete(channelId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.Delete(channelId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) DeleteSidebarCategory(categoryId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.DeleteSidebarCategory(categoryId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.Get(id, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAll(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]string
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]model.StringMap
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelListWithTeamData
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllChannelsCount(opts)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelForExport
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.DirectChannelForExport
+		return result, err
+	}
+	result, err := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelCounts
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelCounts(teamId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelMemberForExport
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []model.StringMap
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelMembersTimezones(channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelUnread
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelUnread(channelId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannels(teamId, userId, includeDeleted, lastDeleteAt)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetDeleted(team_id, offset, limit, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetDeletedByName(team_id, name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetForPost(postId string) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetForPost(postId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetFromMaster(id string) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.GetFromMaster(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMember(channelId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMemberCountFromCache(channelId string) int64 {
+
+	return s.ChannelStore.GetMemberCountFromCache(channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMemberCountsByGroup(channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelMemberCountByGroup
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMemberCountsByGroup(channelID, includeTimezones)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMemberForPost(postId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembers
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMembers(channelId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembers
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMembersByIds(channelId, userIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembers
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMembersForUser(teamId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembers
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembers
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelMembersSince
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMembersSince(channelId, since)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetPrivateChannelsForTeam(teamId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.OrderedSidebarCategories
+		return result, err
+	}
+	result, err := s.ChannelStore.GetSidebarCategories(userId, teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetSidebarCategory(categoryId string) (*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.SidebarCategoryWithChannels
+		return result, err
+	}
+	result, err := s.ChannelStore.GetSidebarCategory(categoryId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetSidebarCategoryOrder(userId string, teamId string) ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.ChannelStore.GetSidebarCategoryOrder(userId, teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.GetTeamChannels(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]*model.ChannelUnreadCounts
+		return result, err
+	}
+	result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelStore.GroupSyncedChannelCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateChannel(id string) {
+
+	s.ChannelStore.InvalidateChannel(id)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
+
+	s.ChannelStore.InvalidateChannelByName(teamId, name)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateGuestCount(channelId string) {
+
+	s.ChannelStore.InvalidateGuestCount(channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidateMemberCount(channelId string) {
+
+	s.ChannelStore.InvalidateMemberCount(channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
+
+	s.ChannelStore.InvalidatePinnedPostCount(channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+
+	return s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result map[string]string
+		return result, err
+	}
+	result, err := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) MigratePublicChannels() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.MigratePublicChannels()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) PermanentDelete(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.PermanentDelete(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) PermanentDeleteByTeam(teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.PermanentDeleteByTeam(teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) PermanentDeleteMembersByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) RemoveMember(channelId string, userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.RemoveMember(channelId, userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) RemoveMembers(channelId string, userIds []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.RemoveMembers(channelId, userIds)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) ResetAllChannelSchemes() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.ResetAllChannelSchemes()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) Restore(channelId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.Restore(channelId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.SaveMember(member)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.ChannelMember
+		var resultVar1 []*model.ChannelMember
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+	s.Root.Breaker.Done(true, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.SaveMultipleMembers(members)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelListWithTeamData
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.ChannelStore.SearchAllChannels(term, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchArchivedInTeam(teamId string, term string, userId string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.SearchArchivedInTeam(teamId, term, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.SearchGroupChannels(userId, term)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ChannelList
+		return result, err
+	}
+	result, err := s.ChannelStore.SearchMore(userId, teamId, term)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Channel
+		return result, err
+	}
+	result, err := s.ChannelStore.Update(channel)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result map[string]int64
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result map[string]*model.ChannelUnreadCounts
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.ChannelUnreadAt
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateMember(member)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.ChannelMember
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateMultipleMembers(members)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.SidebarCategoryWithChannels
+		return result, err
+	}
+	result, err := s.ChannelStore.UpdateSidebarCategories(userId, teamId, categories)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.UpdateSidebarCategoryOrder(userId, teamId, categoryOrder)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelMemberHistoryResult
+		return result, err
+	}
+	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) Cleanup() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ClusterDiscoveryStore.Cleanup()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ClusterDiscovery
+		return result, err
+	}
+	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ClusterDiscoveryStore.Save(discovery)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.CommandStore.AnalyticsCommandCount(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) Delete(commandId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.CommandStore.Delete(commandId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) Get(id string) (*model.Command, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Command
+		return result, err
+	}
+	result, err := s.CommandStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Command
+		return result, err
+	}
+	result, err := s.CommandStore.GetByTeam(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Command
+		return result, err
+	}
+	result, err := s.CommandStore.GetByTrigger(teamId, trigger)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) PermanentDeleteByTeam(teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.CommandStore.PermanentDeleteByTeam(teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.CommandStore.PermanentDeleteByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Command
+		return result, err
+	}
+	result, err := s.CommandStore.Save(webhook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Command
+		return result, err
+	}
+	result, err := s.CommandStore.Update(hook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandWebhookStore) Cleanup() {
+
+	s.CommandWebhookStore.Cleanup()
+
+}
+
+func (s *CircuitBreakerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.CommandWebhook
+		return result, err
+	}
+	result, err := s.CommandWebhookStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.CommandWebhook
+		return result, err
+	}
+	result, err := s.CommandWebhookStore.Save(webhook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerCommandWebhookStore) TryUse(id string, limit int) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.CommandWebhookStore.TryUse(id, limit)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.CompliancePost
+		return result, err
+	}
+	result, err := s.ComplianceStore.ComplianceExport(compliance)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+	result, err := s.ComplianceStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.Compliances
+		return result, err
+	}
+	result, err := s.ComplianceStore.GetAll(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.MessageExport
+		return result, err
+	}
+	result, err := s.ComplianceStore.MessageExport(after, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+	result, err := s.ComplianceStore.Save(compliance)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Compliance
+		return result, err
+	}
+	result, err := s.ComplianceStore.Update(compliance)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.EmojiStore.Delete(emoji, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.Get(id, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.GetByName(name, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.GetMultipleByName(names)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]*model.EmojiUsage
+		return result, err
+	}
+	result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.Save(emoji)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Emoji
+		return result, err
+	}
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) ClearCaches() {
+
+	s.FileInfoStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.GetByIds(ids)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.GetByPath(path)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.GetForUser(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postId, deleted)
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) PermanentDelete(fileId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.FileInfoStore.PermanentDelete(fileId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.Save(info)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.FileInfoList
+		return result, err
+	}
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) SetContent(fileId string, content string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.FileInfoStore.SetContent(fileId, content)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.FileInfo
+		return result, err
+	}
+	result, err := s.FileInfoStore.Upsert(info)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, *model.AppError) {
+
+	return s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+
+	return s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, *model.AppError) {
+
+	return s.GroupStore.ChannelMembersToAdd(since, channelID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, *model.AppError) {
+
+	return s.GroupStore.ChannelMembersToRemove(channelID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+
+	return s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+
+	return s.GroupStore.CountGroupsByChannel(channelId, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+
+	return s.GroupStore.CountGroupsByTeam(teamId, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+
+	return s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.Create(group)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+
+	return s.GroupStore.CreateGroupSyncable(groupSyncable)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.Delete(groupID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+
+	return s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+
+	return s.GroupStore.DeleteMember(groupID, userID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) DistinctGroupMemberCount() (int64, *model.AppError) {
+
+	return s.GroupStore.DistinctGroupMemberCount()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.Get(groupID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetAllBySource(groupSource)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+
+	return s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetByIDs(groupIDs)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetByName(name, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetByRemoteID(remoteID, groupSource)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetByUser(userId string) ([]*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetByUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+
+	return s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+
+	return s.GroupStore.GetGroups(page, perPage, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	return s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamId, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	return s.GroupStore.GetGroupsByChannel(channelId, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	return s.GroupStore.GetGroupsByTeam(teamId, opts)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+
+	return s.GroupStore.GetMemberCount(groupID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+
+	return s.GroupStore.GetMemberUsers(groupID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, *model.AppError) {
+
+	return s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, *model.AppError) {
+
+	return s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+
+	return s.GroupStore.GetMemberUsersPage(groupID, page, perPage)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GroupChannelCount() (int64, *model.AppError) {
+
+	return s.GroupStore.GroupChannelCount()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GroupCount() (int64, *model.AppError) {
+
+	return s.GroupStore.GroupCount()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GroupCountWithAllowReference() (int64, *model.AppError) {
+
+	return s.GroupStore.GroupCountWithAllowReference()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GroupMemberCount() (int64, *model.AppError) {
+
+	return s.GroupStore.GroupMemberCount()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) GroupTeamCount() (int64, *model.AppError) {
+
+	return s.GroupStore.GroupTeamCount()
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+
+	return s.GroupStore.PermanentDeleteMembersByUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, *model.AppError) {
+
+	return s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+
+	return s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]*model.UserTeamIDPair, *model.AppError) {
+
+	return s.GroupStore.TeamMembersToAdd(since, teamID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, *model.AppError) {
+
+	return s.GroupStore.TeamMembersToRemove(teamID)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+
+	return s.GroupStore.Update(group)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+
+	return s.GroupStore.UpdateGroupSyncable(groupSyncable)
+
+}
+
+func (s *CircuitBreakerLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+
+	return s.GroupStore.UpsertMember(groupID, userID)
+
+}
+
+func (s *CircuitBreakerLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.ClaimPending(jobType, nodeId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) Delete(id string) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.JobStore.Delete(id)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) Get(id string) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetAllByStatus(status)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetAllByType(jobType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetAllPage(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.Save(job)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Job
+		return result, err
+	}
+	result, err := s.JobStore.UpdateStatus(id, status)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.LicenseRecord
+		return result, err
+	}
+	result, err := s.LicenseStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.LicenseRecord
+		return result, err
+	}
+	result, err := s.LicenseStore.Save(license)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.LinkMetadata
+		return result, err
+	}
+	result, err := s.LinkMetadataStore.Get(url, timestamp)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.LinkMetadata
+		return result, err
+	}
+	result, err := s.LinkMetadataStore.Save(linkMetadata)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) DeleteApp(id string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.OAuthStore.DeleteApp(id)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAccessData(token)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.GetApp(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAppByUser(userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.GetApps(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.AuthData
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAuthData(code)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) RemoveAccessData(token string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.OAuthStore.RemoveAccessData(token)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) RemoveAllAccessData() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.OAuthStore.RemoveAllAccessData()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) RemoveAuthData(code string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.OAuthStore.RemoveAuthData(code)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.SaveAccessData(accessData)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.SaveApp(app)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.AuthData
+		return result, err
+	}
+	result, err := s.OAuthStore.SaveAuthData(authData)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.AccessData
+		return result, err
+	}
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.OAuthApp
+		return result, err
+	}
+	result, err := s.OAuthStore.UpdateApp(app)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) Delete(pluginId string, key string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PluginStore.Delete(pluginId, key)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) DeleteAllExpired() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PluginStore.DeleteAllExpired()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PluginStore.DeleteAllForPlugin(PluginId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PluginKeyValue
+		return result, err
+	}
+	result, err := s.PluginStore.Get(pluginId, key)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.PluginStore.List(pluginId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.PluginKeyValue
+		return result, err
+	}
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.PluginStore.SetWithOptions(pluginId, key, value, options)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.AnalyticsRow
+		return result, err
+	}
+	result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.AnalyticsRows
+		return result, err
+	}
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.AnalyticsRows
+		return result, err
+	}
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) ClearCaches() {
+
+	s.PostStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PostStore.Delete(postId, time, deleteByID)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.Get(id, skipFetchThreads)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.DirectPostForExport
+		return result, err
+	}
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+
+	return s.PostStore.GetEtag(channelId, allowFromCache)
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetMaxPostSize() int {
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetOldest() (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetOldest()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.PostStore.GetOldestEntityCreationTime()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.PostForExport
+		return result, err
+	}
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPinnedPosts(channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetPost(id, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetPostAfterTime(channelId, time)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostWithContext(postId, before, after)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPosts(options, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsAfter(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		var resultVar1 string
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.PostForIndexing
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+
+		return err
+	}
+	err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
+	s.Root.Breaker.Done(false, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsBefore(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		var resultVar1 string
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsByIds(postIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsByProp(channelId, key, value)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		var resultVar1 string
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ReplyForExport
+		return result, err
+	}
+	result, err := s.PostStore.GetRepliesForExport(parentId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetSingle(id string) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetSingle(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.GetSingleCtx(ctx, id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+
+	s.PostStore.InvalidateLastPostTimeCache(channelId)
+
+}
+
+func (s *CircuitBreakerLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.Post
+		return result, err
+	}
+	result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.Overwrite(post)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.Post
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+	s.Root.Breaker.Done(true, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PostStore.PermanentDeleteByChannel(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PostStore.PermanentDeleteByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.Save(post)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.Post
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+	s.Root.Breaker.Done(true, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostList
+		return result, err
+	}
+	result, err := s.PostStore.Search(teamId, userId, params)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostSearchResults
+		return result, err
+	}
+	result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostSearchResults
+		return result, err
+	}
+	result, err := s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.PostSearchResults
+		return result, err
+	}
+	result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.SearchSuggestion
+		return result, err
+	}
+	result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Post
+		return result, err
+	}
+	result, err := s.PostStore.Update(newPost, oldPost)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) Delete(userId string, category string, name string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PreferenceStore.Delete(userId, category, name)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PreferenceStore.DeleteCategory(userId, category)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Preference
+		return result, err
+	}
+	result, err := s.PreferenceStore.Get(userId, category, name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+	result, err := s.PreferenceStore.GetAll(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+	result, err := s.PreferenceStore.GetCategory(userId, category)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PreferenceStore.PermanentDeleteByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) Save(preferences *model.Preferences) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.PreferenceStore.Save(preferences)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result model.Preferences
+		return result, err
+	}
+	result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerProductNoticesStore) Clear(notices []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ProductNoticesStore.Clear(notices)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []model.ProductNoticeViewState
+		return result, err
+	}
+	result, err := s.ProductNoticesStore.GetViews(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerProductNoticesStore) View(userId string, notices []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ProductNoticesStore.View(userId, notices)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Reaction
+		return result, err
+	}
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Reaction
+		return result, err
+	}
+	result, err := s.ReactionStore.Delete(reaction)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Reaction
+		return result, err
+	}
+	result, err := s.ReactionStore.GetForPost(postId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string][]*model.Reaction
+		return result, err
+	}
+	result, err := s.ReactionStore.GetForPosts(postIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Reaction
+		return result, err
+	}
+	result, err := s.ReactionStore.Save(reaction)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]*model.RolePermissions
+		return result, err
+	}
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) Delete(roleId string) (*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.Delete(roleId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) Get(roleId string) (*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.Get(roleId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) GetAll() ([]*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.GetAll()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) GetByName(name string) (*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.GetByName(name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.GetByNames(names)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) PermanentDeleteAll() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.RoleStore.PermanentDeleteAll()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Role
+		return result, err
+	}
+	result, err := s.RoleStore.Save(role)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) CountByScope(scope string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.SchemeStore.CountByScope(scope)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+	result, err := s.SchemeStore.Delete(schemeId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) Get(schemeId string) (*model.Scheme, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+	result, err := s.SchemeStore.Get(schemeId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Scheme
+		return result, err
+	}
+	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+	result, err := s.SchemeStore.GetByName(schemeName)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) PermanentDeleteAll() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SchemeStore.PermanentDeleteAll()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Scheme
+		return result, err
+	}
+	result, err := s.SchemeStore.Save(scheme)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.SessionStore.AnalyticsSessionCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+
+	s.SessionStore.Cleanup(expiryTime, batchSize)
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Session
+		return result, err
+	}
+	result, err := s.SessionStore.Get(sessionIdOrToken)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) GetSessions(userId string) ([]*model.Session, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+	result, err := s.SessionStore.GetSessions(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Session
+		return result, err
+	}
+	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) Remove(sessionIdOrToken string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.Remove(sessionIdOrToken)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) RemoveAllSessions() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.RemoveAllSessions()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Session
+		return result, err
+	}
+	result, err := s.SessionStore.Save(session)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.UpdateExpiresAt(sessionId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateProps(session *model.Session) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SessionStore.UpdateProps(session)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.SessionStore.UpdateRoles(userId, roles)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) Get(userId string) (*model.Status, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Status
+		return result, err
+	}
+	result, err := s.StatusStore.Get(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Status
+		return result, err
+	}
+	result, err := s.StatusStore.GetByIds(userIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.StatusStore.GetTotalActiveUsersCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) ResetAll() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.StatusStore.ResetAll()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.StatusStore.SaveOrUpdate(status)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) Get() (model.StringMap, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result model.StringMap
+		return result, err
+	}
+	result, err := s.SystemStore.Get()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) GetByName(name string) (*model.System, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.System
+		return result, err
+	}
+	result, err := s.SystemStore.GetByName(name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.System
+		return result, err
+	}
+	result, err := s.SystemStore.InsertIfExists(system)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.System
+		return result, err
+	}
+	result, err := s.SystemStore.PermanentDeleteByName(name)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) Save(system *model.System) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SystemStore.Save(system)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) SaveOrUpdate(system *model.System) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SystemStore.SaveOrUpdate(system)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerSystemStore) Update(system *model.System) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.SystemStore.Update(system)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.AnalyticsPrivateTeamCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) AnalyticsPublicTeamCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.AnalyticsPublicTeamCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) AnalyticsTeamCount(includeDeleted bool) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.AnalyticsTeamCount(includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.ClearAllCustomRoleAssignments()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) ClearCaches() {
+
+	s.TeamStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) Get(id string) (*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAll() ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAll()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamForExport
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllForExportAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllPage(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllPrivateTeamListing()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllTeamListing()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetAllTeamPageListing(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetByInviteId(inviteId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetByName(name string) (*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetByName(name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetByNames(name)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelUnread
+		return result, err
+	}
+	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ChannelUnread
+		return result, err
+	}
+	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.GetMember(teamId, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetMembers(teamId string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.GetMembers(teamId, offset, limit, teamMembersGetOptions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamMemberForExport
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamMembersForExport(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.TeamStats
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamsByUserId(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamsForUser(ctx context.Context, userId string) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamsForUser(ctx, userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.TeamStore.GroupSyncedTeamCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+
+	s.TeamStore.InvalidateAllTeamIdsForUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result map[string]string
+		return result, err
+	}
+	result, err := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) PermanentDelete(teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.PermanentDelete(teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) RemoveAllMembersByTeam(teamId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.RemoveAllMembersByTeam(teamId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) RemoveAllMembersByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.RemoveAllMembersByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) RemoveMember(teamId string, userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.RemoveMember(teamId, userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) RemoveMembers(teamId string, userIds []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.RemoveMembers(teamId, userIds)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) ResetAllTeamSchemes() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.ResetAllTeamSchemes()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.Save(team)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SearchAll(term string, opts *model.TeamSearch) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.SearchAll(term, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SearchAllPaged(term string, opts *model.TeamSearch) ([]*model.Team, int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		var resultVar1 int64
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.TeamStore.SearchAllPaged(term, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SearchOpen(term string) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.SearchOpen(term)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.SearchPrivate(term)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Team
+		return result, err
+	}
+	result, err := s.TeamStore.Update(team)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.UpdateMember(member)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.TeamMember
+		return result, err
+	}
+	result, err := s.TeamStore.UpdateMultipleMembers(members)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+	result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+	result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.TermsOfService
+		return result, err
+	}
+	result, err := s.TermsOfServiceStore.Save(termsOfService)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) CollectThreadsWithNewerReplies(userId string, channelIds []string, timestamp int64) ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.ThreadStore.CollectThreadsWithNewerReplies(userId, channelIds, timestamp)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.CreateMembershipIfNeeded(userId, postId, following)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) Delete(postId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.Delete(postId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.DeleteMembershipForUser(userId, postId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) Get(id string) (*model.Thread, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Thread
+		return result, err
+	}
+	result, err := s.ThreadStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) GetMembershipForUser(userId string, postId string) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+	result, err := s.ThreadStore.GetMembershipForUser(userId, postId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) GetMembershipsForUser(userId string) ([]*model.ThreadMembership, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.ThreadMembership
+		return result, err
+	}
+	result, err := s.ThreadStore.GetMembershipsForUser(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Threads
+		return result, err
+	}
+	result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.MarkAllAsRead(userId, timestamp)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.MarkAsRead(userId, threadId, timestamp)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Thread
+		return result, err
+	}
+	result, err := s.ThreadStore.Save(thread)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+	result, err := s.ThreadStore.SaveMembership(membership)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []*model.Thread
+		var resultVar1 int
+		return result, resultVar1, err
+	}
+	result, resultVar1, err := s.ThreadStore.SaveMultiple(thread)
+	s.Root.Breaker.Done(true, err)
+	return result, resultVar1, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.Thread
+		return result, err
+	}
+	result, err := s.ThreadStore.Update(thread)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.ThreadMembership
+		return result, err
+	}
+	result, err := s.ThreadStore.UpdateMembership(membership)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.ThreadStore.UpdateUnreadsByChannel(userId, changedThreads, timestamp)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTokenStore) Cleanup() {
+
+	s.TokenStore.Cleanup()
+
+}
+
+func (s *CircuitBreakerLayerTokenStore) Delete(token string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TokenStore.Delete(token)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTokenStore) GetByToken(token string) (*model.Token, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.Token
+		return result, err
+	}
+	result, err := s.TokenStore.GetByToken(token)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TokenStore.RemoveAllTokensByType(tokenType)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerTokenStore) Save(recovery *model.Token) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.TokenStore.Save(recovery)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUploadSessionStore) Delete(id string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UploadSessionStore.Delete(id)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUploadSessionStore) Get(id string) (*model.UploadSession, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.UploadSession
+		return result, err
+	}
+	result, err := s.UploadSessionStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.UploadSession
+		return result, err
+	}
+	result, err := s.UploadSessionStore.GetForUser(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.UploadSession
+		return result, err
+	}
+	result, err := s.UploadSessionStore.Save(session)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUploadSessionStore) Update(session *model.UploadSession) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UploadSessionStore.Update(session)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsActiveCount(time, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result bool
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsGetGuestCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.AnalyticsGetSystemAdminCount()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) AutocompleteUsersInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.UserAutocompleteInChannel
+		return result, err
+	}
+	result, err := s.UserStore.AutocompleteUsersInChannel(teamId, channelId, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) ClearAllCustomRoleAssignments() error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.ClearAllCustomRoleAssignments()
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) ClearCaches() {
+
+	s.UserStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.Count(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) DeactivateGuests() ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.UserStore.DeactivateGuests()
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) DemoteUserToGuest(userID string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.DemoteUserToGuest(userID)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) Get(id string) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.Get(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAll() ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAll()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAllAfter(limit, afterId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAllNotInAuthService(authServices)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAllProfiles(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetAllUsingAuthService(authService)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetByAuth(authData, authService)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetByEmail(email string) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetByEmail(email)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetByUsername(username string) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetByUsername(username)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetChannelGroupUsers(channelID)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetEtagForAllProfiles() string {
+
+	return s.UserStore.GetEtagForAllProfiles()
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetEtagForProfiles(teamId string) string {
+
+	return s.UserStore.GetEtagForProfiles(teamId)
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+
+	return s.UserStore.GetEtagForProfilesNotInTeam(teamId)
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []string
+		return result, err
+	}
+	result, err := s.UserStore.GetKnownUsers(userID)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string][]*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfiles(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesInChannel(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesInChannelByStatus(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetProfilesWithoutTeam(options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result map[string]*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetSystemAdminProfiles()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetTeamGroupUsers(teamID)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.GetUnreadCount(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.GetUser(id, includeDeleted)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.UserForIndexing
+		return result, err
+	}
+	result, err := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) InferSystemInstallDate() (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.InferSystemInstallDate()
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) InvalidateProfileCacheForUser(userId string) {
+
+	s.UserStore.InvalidateProfileCacheForUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
+
+	s.UserStore.InvalidateProfilesInChannelCache(channelId)
+
+}
+
+func (s *CircuitBreakerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
+
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userId)
+
+}
+
+func (s *CircuitBreakerLayerUserStore) PermanentDelete(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.PermanentDelete(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) PromoteGuestToUser(userID string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.PromoteGuestToUser(userID)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) ResetLastPictureUpdate(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.ResetLastPictureUpdate(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) Save(user *model.User) (*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.User
+		return result, err
+	}
+	result, err := s.UserStore.Save(user)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.Search(teamId, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.SearchInChannel(channelId, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.SearchInGroup(groupID, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.SearchNotInChannel(teamId, channelId, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.SearchNotInTeam(notInTeamId, term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.User
+		return result, err
+	}
+	result, err := s.UserStore.SearchWithoutTeam(term, options)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.UserUpdate
+		return result, err
+	}
+	result, err := s.UserStore.Update(user, allowRoleUpdate)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateLastPictureUpdate(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdateLastPictureUpdate(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateMfaActive(userId string, active bool) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdateMfaActive(userId, active)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateMfaSecret(userId string, secret string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdateMfaSecret(userId, secret)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdatePassword(userId string, newPassword string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdatePassword(userId, newPassword)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.UserStore.UpdateUpdateAt(userId)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserStore) VerifyEmail(userId string, email string) (string, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result string
+		return result, err
+	}
+	result, err := s.UserStore.VerifyEmail(userId, email)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) Delete(tokenId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserAccessTokenStore.Delete(tokenId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) DeleteAllForUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserAccessTokenStore.DeleteAllForUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.Get(tokenId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.Save(token)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.UserAccessToken
+		return result, err
+	}
+	result, err := s.UserAccessTokenStore.Search(term)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.UserTermsOfService
+		return result, err
+	}
+	result, err := s.UserTermsOfServiceStore.GetByUser(userId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.UserTermsOfService
+		return result, err
+	}
+	result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.WebhookStore.AnalyticsIncomingCount(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result int64
+		return result, err
+	}
+	result, err := s.WebhookStore.AnalyticsOutgoingCount(teamId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) ClearCaches() {
+
+	s.WebhookStore.ClearCaches()
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.DeleteIncoming(webhookId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.DeleteOutgoing(webhookId, time)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncomingByChannel(channelId)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncomingByTeam(teamId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncomingList(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetIncomingListByUser(userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoing(id)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingByChannel(channelId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingByTeam(teamId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingList(offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(false); err != nil {
+		var result []*model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.GetOutgoingListByUser(userId, offset, limit)
+	s.Root.Breaker.Done(false, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
+
+	s.WebhookStore.InvalidateWebhookCache(webhook)
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+
+		return err
+	}
+	err := s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+	s.Root.Breaker.Done(true, err)
+	return err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.SaveIncoming(webhook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.SaveOutgoing(webhook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.IncomingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.UpdateIncoming(webhook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	if err := s.Root.Breaker.Allow(true); err != nil {
+		var result *model.OutgoingWebhook
+		return result, err
+	}
+	result, err := s.WebhookStore.UpdateOutgoing(hook)
+	s.Root.Breaker.Done(true, err)
+	return result, err
+
+}
+
+func (s *CircuitBreakerLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *CircuitBreakerLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
+func (s *CircuitBreakerLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *CircuitBreakerLayer) GetCurrentSchemaVersion() string {
+	return s.Store.GetCurrentSchemaVersion()
+}
+
+func (s *CircuitBreakerLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *CircuitBreakerLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *CircuitBreakerLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
+func (s *CircuitBreakerLayer) SetContext(context context.Context) {
+	s.Store.SetContext(context)
+}
+
+func (s *CircuitBreakerLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *CircuitBreakerLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *CircuitBreakerLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *CircuitBreakerLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+func New(childStore store.Store, breaker *Breaker) *CircuitBreakerLayer {
+	newStore := CircuitBreakerLayer{
+		Store:   childStore,
+		Breaker: breaker,
+	}
+
+	newStore.AuditStore = &CircuitBreakerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &CircuitBreakerLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
+	newStore.BotStore = &CircuitBreakerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &CircuitBreakerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &CircuitBreakerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &CircuitBreakerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &CircuitBreakerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &CircuitBreakerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &CircuitBreakerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.EmojiStore = &CircuitBreakerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &CircuitBreakerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &CircuitBreakerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &CircuitBreakerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &CircuitBreakerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &CircuitBreakerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.OAuthStore = &CircuitBreakerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PluginStore = &CircuitBreakerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &CircuitBreakerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PreferenceStore = &CircuitBreakerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &CircuitBreakerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.ReactionStore = &CircuitBreakerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RoleStore = &CircuitBreakerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemeStore = &CircuitBreakerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &CircuitBreakerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &CircuitBreakerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &CircuitBreakerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &CircuitBreakerLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &CircuitBreakerLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &CircuitBreakerLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &CircuitBreakerLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &CircuitBreakerLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &CircuitBreakerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &CircuitBreakerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &CircuitBreakerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &CircuitBreakerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
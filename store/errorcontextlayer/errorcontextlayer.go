@@ -0,0 +1,4485 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package errorcontextlayer
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type ErrorContextLayer struct {
+	store.Store
+	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
+	BotStore                  store.BotStore
+	ChannelStore              store.ChannelStore
+	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore     store.ClusterDiscoveryStore
+	CommandStore              store.CommandStore
+	CommandWebhookStore       store.CommandWebhookStore
+	ComplianceStore           store.ComplianceStore
+	EmojiStore                store.EmojiStore
+	FileInfoStore             store.FileInfoStore
+	GroupStore                store.GroupStore
+	JobStore                  store.JobStore
+	LicenseStore              store.LicenseStore
+	LinkMetadataStore         store.LinkMetadataStore
+	OAuthStore                store.OAuthStore
+	PluginStore               store.PluginStore
+	PostStore                 store.PostStore
+	PreferenceStore           store.PreferenceStore
+	ProductNoticesStore       store.ProductNoticesStore
+	ReactionStore             store.ReactionStore
+	RoleStore                 store.RoleStore
+	SchemeStore               store.SchemeStore
+	SessionStore              store.SessionStore
+	StatusStore               store.StatusStore
+	SystemStore               store.SystemStore
+	TeamStore                 store.TeamStore
+	TermsOfServiceStore       store.TermsOfServiceStore
+	ThreadStore               store.ThreadStore
+	TokenStore                store.TokenStore
+	UploadSessionStore        store.UploadSessionStore
+	UserStore                 store.UserStore
+	UserAccessTokenStore      store.UserAccessTokenStore
+	UserTermsOfServiceStore   store.UserTermsOfServiceStore
+	WebhookStore              store.WebhookStore
+}
+
+func (s *ErrorContextLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *ErrorContextLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
+func (s *ErrorContextLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *ErrorContextLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *ErrorContextLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *ErrorContextLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *ErrorContextLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *ErrorContextLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *ErrorContextLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *ErrorContextLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *ErrorContextLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *ErrorContextLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *ErrorContextLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *ErrorContextLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *ErrorContextLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *ErrorContextLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *ErrorContextLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *ErrorContextLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *ErrorContextLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *ErrorContextLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *ErrorContextLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *ErrorContextLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *ErrorContextLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *ErrorContextLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *ErrorContextLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *ErrorContextLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *ErrorContextLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *ErrorContextLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *ErrorContextLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *ErrorContextLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *ErrorContextLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *ErrorContextLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *ErrorContextLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *ErrorContextLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *ErrorContextLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type ErrorContextLayerAuditStore struct {
+	store.AuditStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerBotStore struct {
+	store.BotStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerChannelStore struct {
+	store.ChannelStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerCommandStore struct {
+	store.CommandStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerEmojiStore struct {
+	store.EmojiStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerGroupStore struct {
+	store.GroupStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerJobStore struct {
+	store.JobStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerLicenseStore struct {
+	store.LicenseStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerOAuthStore struct {
+	store.OAuthStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerPluginStore struct {
+	store.PluginStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerPostStore struct {
+	store.PostStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerReactionStore struct {
+	store.ReactionStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerRoleStore struct {
+	store.RoleStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerSchemeStore struct {
+	store.SchemeStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerSessionStore struct {
+	store.SessionStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerStatusStore struct {
+	store.StatusStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerSystemStore struct {
+	store.SystemStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerTeamStore struct {
+	store.TeamStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerThreadStore struct {
+	store.ThreadStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerTokenStore struct {
+	store.TokenStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerUserStore struct {
+	store.UserStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *ErrorContextLayer
+}
+
+type ErrorContextLayerWebhookStore struct {
+	store.WebhookStore
+	Root *ErrorContextLayer
+}
+
+func (s *ErrorContextLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
+	result, err := s.AuditStore.Get(user_id, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "AuditStore.Get", "Audit", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerAuditStore) PermanentDeleteByUser(userId string) error {
+	err := s.AuditStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "AuditStore.PermanentDeleteByUser", "Audit", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerAuditStore) Save(audit *model.Audit) error {
+	err := s.AuditStore.Save(audit)
+	if err != nil {
+		err = store.WrapErr(err, "AuditStore.Save", "Audit", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+	result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "AuditTrailStore.GetByEntity", "AuditTrail", map[string]string{"entity_id": entityId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	err := s.AuditTrailStore.Record(entry, change)
+	if err != nil {
+		err = store.WrapErr(err, "AuditTrailStore.Record", "AuditTrail", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
+	result, err := s.BotStore.Get(userId, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "BotStore.Get", "Bot", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+	result, err := s.BotStore.GetAll(options)
+	if err != nil {
+		err = store.WrapErr(err, "BotStore.GetAll", "Bot", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerBotStore) PermanentDelete(userId string) error {
+	err := s.BotStore.PermanentDelete(userId)
+	if err != nil {
+		err = store.WrapErr(err, "BotStore.PermanentDelete", "Bot", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+	result, err := s.BotStore.Save(bot)
+	if err != nil {
+		err = store.WrapErr(err, "BotStore.Save", "Bot", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+	result, err := s.BotStore.Update(bot)
+	if err != nil {
+		err = store.WrapErr(err, "BotStore.Update", "Bot", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, error) {
+	result, err := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.AnalyticsDeletedTypeCount", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, error) {
+	result, err := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.AnalyticsTypeCount", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.AutocompleteInTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.AutocompleteInTeamForSearch", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) ClearAllCustomRoleAssignments() error {
+	err := s.ChannelStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.ClearAllCustomRoleAssignments", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+	err := s.ChannelStore.ClearSidebarOnTeamLeave(userId, teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.ClearSidebarOnTeamLeave", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) CountPostsAfter(channelId string, timestamp int64, userId string) (int, error) {
+	result, err := s.ChannelStore.CountPostsAfter(channelId, timestamp, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.CountPostsAfter", "Channel", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+	result, err := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.CreateDirectChannel", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+	err := s.ChannelStore.CreateInitialSidebarCategories(userId, teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.CreateInitialSidebarCategories", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.CreateSidebarCategory(userId, teamId, newCategory)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.CreateSidebarCategory", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) Delete(channelId string, time int64) error {
+	err := s.ChannelStore.Delete(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.Delete", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) DeleteSidebarCategory(categoryId string) error {
+	err := s.ChannelStore.DeleteSidebarCategory(categoryId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.DeleteSidebarCategory", "Channel", map[string]string{"category_id": categoryId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	err := s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.DeleteSidebarChannelsByPreferences", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.Get(id, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.Get", "Channel", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAll(teamId string) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetAll(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAll", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+	result, err := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllChannelMembersForUser", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, error) {
+	result, err := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllChannelMembersNotifyPropsForChannel", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, error) {
+	result, err := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllChannels", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+	result, err := s.ChannelStore.GetAllChannelsCount(opts)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllChannelsCount", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, error) {
+	result, err := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllChannelsForExportAfter", "Channel", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, error) {
+	result, err := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetAllDirectChannelsForExportAfter", "Channel", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetByName", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetByNameIncludeDeleted", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetByNames", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannel", "Channel", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
+	result, err := s.ChannelStore.GetChannelCounts(teamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelCounts", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, error) {
+	result, err := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelMembersForExport", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
+	result, err := s.ChannelStore.GetChannelMembersTimezones(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelMembersTimezones", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, error) {
+	result, err := s.ChannelStore.GetChannelUnread(channelId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelUnread", "Channel", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannels(teamId, userId, includeDeleted, lastDeleteAt)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannels", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelsBatchForIndexing", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelsByIds", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelsByScheme", "Channel", map[string]string{"scheme_id": schemeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetChannelsCtx", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetCtx", "Channel", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetDeleted(team_id, offset, limit, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetDeleted", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetDeletedByName(team_id, name)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetDeletedByName", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetForPost(postId string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetForPost(postId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetForPost", "Channel", map[string]string{"post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetFromMaster(id string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetFromMaster(id)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetFromMaster", "Channel", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetGuestCount", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.GetMember(channelId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMember", "Channel", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMemberCount", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMemberCountsByGroup(channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+	result, err := s.ChannelStore.GetMemberCountsByGroup(channelID, includeTimezones)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMemberCountsByGroup", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.GetMemberForPost(postId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMemberForPost", "Channel", map[string]string{"post_id": postId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembers(channelId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembers", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersByIds(channelId, userIds)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembersByIds", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersForUser(teamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembersForUser", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembersForUserWithPagination", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+	result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembersPaged", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	result, err := s.ChannelStore.GetMembersSince(channelId, since)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMembersSince", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetMoreChannels", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetPinnedPostCount", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPrivateChannelsForTeam(teamId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetPrivateChannelsForTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetPublicChannelsByIdsForTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetPublicChannelsForTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, error) {
+	result, err := s.ChannelStore.GetSidebarCategories(userId, teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetSidebarCategories", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetSidebarCategory(categoryId string) (*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.GetSidebarCategory(categoryId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetSidebarCategory", "Channel", map[string]string{"category_id": categoryId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetSidebarCategoryOrder(userId string, teamId string) ([]string, error) {
+	result, err := s.ChannelStore.GetSidebarCategoryOrder(userId, teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetSidebarCategoryOrder", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetTeamChannels(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetTeamChannels", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GetUnreadCountsForUser", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
+	result, err := s.ChannelStore.GroupSyncedChannelCount()
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.GroupSyncedChannelCount", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+	err := s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.IncrementMentionCount", "Channel", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+	result, err := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.MigrateChannelMembers", "Channel", map[string]string{"from_channel_id": fromChannelId, "from_user_id": fromUserId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) MigratePublicChannels() error {
+	err := s.ChannelStore.MigratePublicChannels()
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.MigratePublicChannels", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) PermanentDelete(channelId string) error {
+	err := s.ChannelStore.PermanentDelete(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.PermanentDelete", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) PermanentDeleteByTeam(teamId string) error {
+	err := s.ChannelStore.PermanentDeleteByTeam(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.PermanentDeleteByTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+	err := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.PermanentDeleteMembersByChannel", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) PermanentDeleteMembersByUser(userId string) error {
+	err := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.PermanentDeleteMembersByUser", "Channel", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	err := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.RemoveAllDeactivatedMembers", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) RemoveMember(channelId string, userId string) error {
+	err := s.ChannelStore.RemoveMember(channelId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.RemoveMember", "Channel", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) RemoveMembers(channelId string, userIds []string) error {
+	err := s.ChannelStore.RemoveMembers(channelId, userIds)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.RemoveMembers", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) ResetAllChannelSchemes() error {
+	err := s.ChannelStore.ResetAllChannelSchemes()
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.ResetAllChannelSchemes", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) Restore(channelId string, time int64) error {
+	err := s.ChannelStore.Restore(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.Restore", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	result, err := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.Save", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	result, err := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SaveDirectChannel", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.SaveMember(member)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SaveMember", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SaveMemberMultiple", "Channel", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	result, err := s.ChannelStore.SaveMultipleMembers(members)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SaveMultipleMembers", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, error) {
+	result, resultVar1, err := s.ChannelStore.SearchAllChannels(term, opts)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchAllChannels", "Channel", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchArchivedInTeam(teamId string, term string, userId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchArchivedInTeam(teamId, term, userId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchArchivedInTeam", "Channel", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchForUserInTeam", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchGroupChannels(userId, term)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchGroupChannels", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchInTeam", "Channel", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchMore(userId, teamId, term)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SearchMore", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+	err := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.SetDeleteAt", "Channel", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	result, err := s.ChannelStore.Update(channel)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.Update", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateLastViewedAt", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateLastViewedAtMulti", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateLastViewedAtPost", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.UpdateMember(member)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateMember", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+	err := s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateMembersRole", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	result, err := s.ChannelStore.UpdateMultipleMembers(members)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateMultipleMembers", "Channel", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.UpdateSidebarCategories(userId, teamId, categories)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateSidebarCategories", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+	err := s.ChannelStore.UpdateSidebarCategoryOrder(userId, teamId, categoryOrder)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateSidebarCategoryOrder", "Channel", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+	err := s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateSidebarChannelCategoryOnMove", "Channel", map[string]string{"new_team_id": newTeamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	err := s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UpdateSidebarChannelsByPreferences", "Channel", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, error) {
+	result, err := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelStore.UserBelongsToChannels", "Channel", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
+	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelMemberHistoryStore.GetUsersInChannelDuring", "ChannelMemberHistory", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+	err := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelMemberHistoryStore.LogJoinEvent", "ChannelMemberHistory", map[string]string{"user_id": userId, "channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelMemberHistoryStore.LogLeaveEvent", "ChannelMemberHistory", map[string]string{"user_id": userId, "channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ChannelMemberHistoryStore.PermanentDeleteBatch", "ChannelMemberHistory", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) Cleanup() error {
+	err := s.ClusterDiscoveryStore.Cleanup()
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.Cleanup", "ClusterDiscovery", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.Delete", "ClusterDiscovery", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.Exists", "ClusterDiscovery", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.GetAll", "ClusterDiscovery", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+	err := s.ClusterDiscoveryStore.Save(discovery)
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.Save", "ClusterDiscovery", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	if err != nil {
+		err = store.WrapErr(err, "ClusterDiscoveryStore.SetLastPingAt", "ClusterDiscovery", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+	result, err := s.CommandStore.AnalyticsCommandCount(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.AnalyticsCommandCount", "Command", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandStore) Delete(commandId string, time int64) error {
+	err := s.CommandStore.Delete(commandId, time)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.Delete", "Command", map[string]string{"command_id": commandId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerCommandStore) Get(id string) (*model.Command, error) {
+	result, err := s.CommandStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.Get", "Command", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, error) {
+	result, err := s.CommandStore.GetByTeam(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.GetByTeam", "Command", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, error) {
+	result, err := s.CommandStore.GetByTrigger(teamId, trigger)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.GetByTrigger", "Command", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandStore) PermanentDeleteByTeam(teamId string) error {
+	err := s.CommandStore.PermanentDeleteByTeam(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.PermanentDeleteByTeam", "Command", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerCommandStore) PermanentDeleteByUser(userId string) error {
+	err := s.CommandStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.PermanentDeleteByUser", "Command", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+	result, err := s.CommandStore.Save(webhook)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.Save", "Command", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+	result, err := s.CommandStore.Update(hook)
+	if err != nil {
+		err = store.WrapErr(err, "CommandStore.Update", "Command", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+	result, err := s.CommandWebhookStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "CommandWebhookStore.Get", "CommandWebhook", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+	result, err := s.CommandWebhookStore.Save(webhook)
+	if err != nil {
+		err = store.WrapErr(err, "CommandWebhookStore.Save", "CommandWebhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerCommandWebhookStore) TryUse(id string, limit int) error {
+	err := s.CommandWebhookStore.TryUse(id, limit)
+	if err != nil {
+		err = store.WrapErr(err, "CommandWebhookStore.TryUse", "CommandWebhook", map[string]string{"id": id})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, error) {
+	result, err := s.ComplianceStore.ComplianceExport(compliance)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.ComplianceExport", "Compliance", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.Get", "Compliance", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+	result, err := s.ComplianceStore.GetAll(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.GetAll", "Compliance", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, error) {
+	result, err := s.ComplianceStore.MessageExport(after, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.MessageExport", "Compliance", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Save(compliance)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.Save", "Compliance", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Update(compliance)
+	if err != nil {
+		err = store.WrapErr(err, "ComplianceStore.Update", "Compliance", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+	err := s.EmojiStore.Delete(emoji, time)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.Delete", "Emoji", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+	result, err := s.EmojiStore.Get(id, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.Get", "Emoji", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+	result, err := s.EmojiStore.GetByName(name, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.GetByName", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.GetList", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.GetMultipleByName(names)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.GetMultipleByName", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.GetUsageCounts", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	result, err := s.EmojiStore.Save(emoji)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.Save", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	if err != nil {
+		err = store.WrapErr(err, "EmojiStore.Search", "Emoji", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.AttachToPost", "FileInfo", map[string]string{"file_id": fileId, "post_id": postId, "creator_id": creatorId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.DeleteForPost", "FileInfo", map[string]string{"post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.Get", "FileInfo", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetByIds(ids)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.GetByIds", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetByPath(path)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.GetByPath", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.GetForPost", "FileInfo", map[string]string{"post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetForUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.GetForUser", "FileInfo", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.GetWithOptions", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) PermanentDelete(fileId string) error {
+	err := s.FileInfoStore.PermanentDelete(fileId)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.PermanentDelete", "FileInfo", map[string]string{"file_id": fileId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.PermanentDeleteBatch", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.PermanentDeleteByUser", "FileInfo", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Save(info)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.Save", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.Search", "FileInfo", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerFileInfoStore) SetContent(fileId string, content string) error {
+	err := s.FileInfoStore.SetContent(fileId, content)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.SetContent", "FileInfo", map[string]string{"file_id": fileId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Upsert(info)
+	if err != nil {
+		err = store.WrapErr(err, "FileInfoStore.Upsert", "FileInfo", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	result, err := s.JobStore.ClaimPending(jobType, nodeId)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.ClaimPending", "Job", map[string]string{"node_id": nodeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) Delete(id string) (string, error) {
+	result, err := s.JobStore.Delete(id)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.Delete", "Job", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) Get(id string) (*model.Job, error) {
+	result, err := s.JobStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.Get", "Job", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByStatus(status)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetAllByStatus", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByType(jobType)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetAllByType", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetAllByTypePage", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllPage(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetAllPage", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetCountByStatusAndType", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetNewestJobByStatusAndType", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.GetNewestJobByStatusesAndType", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+	result, err := s.JobStore.Save(job)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.Save", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.UpdateOptimistically", "Job", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+	result, err := s.JobStore.UpdateStatus(id, status)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.UpdateStatus", "Job", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	if err != nil {
+		err = store.WrapErr(err, "JobStore.UpdateStatusOptimistically", "Job", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+	result, err := s.LicenseStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "LicenseStore.Get", "License", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+	result, err := s.LicenseStore.Save(license)
+	if err != nil {
+		err = store.WrapErr(err, "LicenseStore.Save", "License", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+	result, err := s.LinkMetadataStore.Get(url, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "LinkMetadataStore.Get", "LinkMetadata", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+	result, err := s.LinkMetadataStore.Save(linkMetadata)
+	if err != nil {
+		err = store.WrapErr(err, "LinkMetadataStore.Save", "LinkMetadata", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) DeleteApp(id string) error {
+	err := s.OAuthStore.DeleteApp(id)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.DeleteApp", "OAuth", map[string]string{"id": id})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessData(token)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAccessData", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAccessDataByRefreshToken", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAccessDataByUserForApp", "OAuth", map[string]string{"user_id": userId, "client_id": clientId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetApp(id)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetApp", "OAuth", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetAppByUser(userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAppByUser", "OAuth", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetApps(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetApps", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+	result, err := s.OAuthStore.GetAuthData(code)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAuthData", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetAuthorizedApps", "OAuth", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.GetPreviousAccessData", "OAuth", map[string]string{"user_id": userId, "client_id": clientId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.PermanentDeleteAuthDataByUser", "OAuth", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerOAuthStore) RemoveAccessData(token string) error {
+	err := s.OAuthStore.RemoveAccessData(token)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.RemoveAccessData", "OAuth", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerOAuthStore) RemoveAllAccessData() error {
+	err := s.OAuthStore.RemoveAllAccessData()
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.RemoveAllAccessData", "OAuth", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerOAuthStore) RemoveAuthData(code string) error {
+	err := s.OAuthStore.RemoveAuthData(code)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.RemoveAuthData", "OAuth", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	result, err := s.OAuthStore.SaveAccessData(accessData)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.SaveAccessData", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.SaveApp(app)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.SaveApp", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	result, err := s.OAuthStore.SaveAuthData(authData)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.SaveAuthData", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.UpdateAccessData", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.UpdateApp(app)
+	if err != nil {
+		err = store.WrapErr(err, "OAuthStore.UpdateApp", "OAuth", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.CompareAndDelete", "Plugin", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.CompareAndSet", "Plugin", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) Delete(pluginId string, key string) error {
+	err := s.PluginStore.Delete(pluginId, key)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.Delete", "Plugin", map[string]string{"plugin_id": pluginId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPluginStore) DeleteAllExpired() error {
+	err := s.PluginStore.DeleteAllExpired()
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.DeleteAllExpired", "Plugin", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
+	err := s.PluginStore.DeleteAllForPlugin(PluginId)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.DeleteAllForPlugin", "Plugin", map[string]string{"plugin_id": PluginId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+	result, err := s.PluginStore.Get(pluginId, key)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.Get", "Plugin", map[string]string{"plugin_id": pluginId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
+	result, err := s.PluginStore.List(pluginId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.List", "Plugin", map[string]string{"plugin_id": pluginId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.SaveOrUpdate", "Plugin", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	result, err := s.PluginStore.SetWithOptions(pluginId, key, value, options)
+	if err != nil {
+		err = store.WrapErr(err, "PluginStore.SetWithOptions", "Plugin", map[string]string{"plugin_id": pluginId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.AnalyticsCountByDay", "Post", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+	result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.AnalyticsPostCount", "Post", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.AnalyticsPostCountsByDay", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.AnalyticsUserCountsWithPostsByDay", "Post", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+	err := s.PostStore.Delete(postId, time, deleteByID)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Delete", "Post", map[string]string{"post_id": postId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+	result, err := s.PostStore.Get(id, skipFetchThreads)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Get", "Post", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetDirectPostParentsForExportAfter", "Post", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetFlaggedPosts", "Post", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetFlaggedPostsForChannel", "Post", map[string]string{"user_id": userId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetFlaggedPostsForTeam", "Post", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetOldest() (*model.Post, error) {
+	result, err := s.PostStore.GetOldest()
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetOldest", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+	result, err := s.PostStore.GetOldestEntityCreationTime()
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetOldestEntityCreationTime", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetParentsForExportAfter", "Post", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+	result, err := s.PostStore.GetPinnedPosts(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPinnedPosts", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+	result, err := s.PostStore.GetPost(id, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPost", "Post", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+	result, err := s.PostStore.GetPostAfterTime(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostAfterTime", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+	result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostIdAfterTime", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+	result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostIdBeforeTime", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostWithContext(postId, before, after)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostWithContext", "Post", map[string]string{"post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPosts(options, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPosts", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsAfter(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsAfter", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsAfterCursor", "Post", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsBatchForIndexing", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsBatched", "Post", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsBefore(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsBefore", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsBeforeCursor", "Post", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsByIds(postIds)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsByIds", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsByProp(channelId, key, value)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsByProp", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsCreatedAt", "Post", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsCtx", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsSince", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetPostsSinceCtx", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetRecentPostsForUser", "Post", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+	result, err := s.PostStore.GetRepliesForExport(parentId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetRepliesForExport", "Post", map[string]string{"parent_id": parentId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetSingle(id string) (*model.Post, error) {
+	result, err := s.PostStore.GetSingle(id)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetSingle", "Post", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	result, err := s.PostStore.GetSingleCtx(ctx, id)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.GetSingleCtx", "Post", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.MoveThread", "Post", map[string]string{"root_id": rootId, "target_channel_id": targetChannelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Overwrite(post)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Overwrite", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.OverwriteMultiple", "Post", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.PermanentDeleteBatch", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+	err := s.PostStore.PermanentDeleteByChannel(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.PermanentDeleteByChannel", "Post", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPostStore) PermanentDeleteByUser(userId string) error {
+	err := s.PostStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.PermanentDeleteByUser", "Post", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Save(post)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Save", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.SaveMultiple", "Post", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+	result, err := s.PostStore.Search(teamId, userId, params)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Search", "Post", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.SearchAllTeams", "Post", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.SearchPostsInTeamForUser", "Post", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.SearchPostsInTeamForUserAfter", "Post", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.SuggestTerms", "Post", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Update(newPost, oldPost)
+	if err != nil {
+		err = store.WrapErr(err, "PostStore.Update", "Post", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.CleanupFlagsBatch", "Preference", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPreferenceStore) Delete(userId string, category string, name string) error {
+	err := s.PreferenceStore.Delete(userId, category, name)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.Delete", "Preference", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+	err := s.PreferenceStore.DeleteCategory(userId, category)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.DeleteCategory", "Preference", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.DeleteCategoryAndName", "Preference", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+	result, err := s.PreferenceStore.Get(userId, category, name)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.Get", "Preference", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+	result, err := s.PreferenceStore.GetAll(userId)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.GetAll", "Preference", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+	result, err := s.PreferenceStore.GetCategory(userId, category)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.GetCategory", "Preference", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+	err := s.PreferenceStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.PermanentDeleteByUser", "Preference", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPreferenceStore) Save(preferences *model.Preferences) error {
+	err := s.PreferenceStore.Save(preferences)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.Save", "Preference", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+	if err != nil {
+		err = store.WrapErr(err, "PreferenceStore.SaveMultiple", "Preference", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerProductNoticesStore) Clear(notices []string) error {
+	err := s.ProductNoticesStore.Clear(notices)
+	if err != nil {
+		err = store.WrapErr(err, "ProductNoticesStore.Clear", "ProductNotices", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	if err != nil {
+		err = store.WrapErr(err, "ProductNoticesStore.ClearOldNotices", "ProductNotices", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+	result, err := s.ProductNoticesStore.GetViews(userId)
+	if err != nil {
+		err = store.WrapErr(err, "ProductNoticesStore.GetViews", "ProductNotices", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerProductNoticesStore) View(userId string, notices []string) error {
+	err := s.ProductNoticesStore.View(userId, notices)
+	if err != nil {
+		err = store.WrapErr(err, "ProductNoticesStore.View", "ProductNotices", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.BulkGetForPosts", "Reaction", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	result, err := s.ReactionStore.Delete(reaction)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.Delete", "Reaction", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.DeleteAllWithEmojiName", "Reaction", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
+	result, err := s.ReactionStore.GetForPost(postId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.GetForPost", "Reaction", map[string]string{"post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	result, err := s.ReactionStore.GetForPosts(postIds)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.GetForPosts", "Reaction", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.PermanentDeleteBatch", "Reaction", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	result, err := s.ReactionStore.Save(reaction)
+	if err != nil {
+		err = store.WrapErr(err, "ReactionStore.Save", "Reaction", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.AllChannelSchemeRoles", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.ChannelHigherScopedPermissions", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.ChannelRolesUnderTeamRole", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) Delete(roleId string) (*model.Role, error) {
+	result, err := s.RoleStore.Delete(roleId)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.Delete", "Role", map[string]string{"role_id": roleId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) Get(roleId string) (*model.Role, error) {
+	result, err := s.RoleStore.Get(roleId)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.Get", "Role", map[string]string{"role_id": roleId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) GetAll() ([]*model.Role, error) {
+	result, err := s.RoleStore.GetAll()
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.GetAll", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) GetByName(name string) (*model.Role, error) {
+	result, err := s.RoleStore.GetByName(name)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.GetByName", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+	result, err := s.RoleStore.GetByNames(names)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.GetByNames", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerRoleStore) PermanentDeleteAll() error {
+	err := s.RoleStore.PermanentDeleteAll()
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.PermanentDeleteAll", "Role", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+	result, err := s.RoleStore.Save(role)
+	if err != nil {
+		err = store.WrapErr(err, "RoleStore.Save", "Role", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) CountByScope(scope string) (int64, error) {
+	result, err := s.SchemeStore.CountByScope(scope)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.CountByScope", "Scheme", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.CountWithoutPermission", "Scheme", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Delete(schemeId)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.Delete", "Scheme", map[string]string{"scheme_id": schemeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) Get(schemeId string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Get(schemeId)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.Get", "Scheme", map[string]string{"scheme_id": schemeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.GetAllPage", "Scheme", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.GetByName(schemeName)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.GetByName", "Scheme", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSchemeStore) PermanentDeleteAll() error {
+	err := s.SchemeStore.PermanentDeleteAll()
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.PermanentDeleteAll", "Scheme", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Save(scheme)
+	if err != nil {
+		err = store.WrapErr(err, "SchemeStore.Save", "Scheme", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+	result, err := s.SessionStore.AnalyticsSessionCount()
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.AnalyticsSessionCount", "Session", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
+	result, err := s.SessionStore.Get(sessionIdOrToken)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.Get", "Session", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) GetSessions(userId string) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessions(userId)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.GetSessions", "Session", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.GetSessionsExpired", "Session", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.GetSessionsWithActiveDeviceIds", "Session", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+	err := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.PermanentDeleteSessionsByUser", "Session", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) Remove(sessionIdOrToken string) error {
+	err := s.SessionStore.Remove(sessionIdOrToken)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.Remove", "Session", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) RemoveAllSessions() error {
+	err := s.SessionStore.RemoveAllSessions()
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.RemoveAllSessions", "Session", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+	result, err := s.SessionStore.Save(session)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.Save", "Session", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+	result, err := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateDeviceId", "Session", map[string]string{"id": id, "device_id": deviceId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateExpiredNotify", "Session", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+	err := s.SessionStore.UpdateExpiresAt(sessionId, time)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateExpiresAt", "Session", map[string]string{"session_id": sessionId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+	err := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateLastActivityAt", "Session", map[string]string{"session_id": sessionId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateProps(session *model.Session) error {
+	err := s.SessionStore.UpdateProps(session)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateProps", "Session", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+	result, err := s.SessionStore.UpdateRoles(userId, roles)
+	if err != nil {
+		err = store.WrapErr(err, "SessionStore.UpdateRoles", "Session", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerStatusStore) Get(userId string) (*model.Status, error) {
+	result, err := s.StatusStore.Get(userId)
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.Get", "Status", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+	result, err := s.StatusStore.GetByIds(userIds)
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.GetByIds", "Status", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+	result, err := s.StatusStore.GetTotalActiveUsersCount()
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.GetTotalActiveUsersCount", "Status", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerStatusStore) ResetAll() error {
+	err := s.StatusStore.ResetAll()
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.ResetAll", "Status", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+	err := s.StatusStore.SaveOrUpdate(status)
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.SaveOrUpdate", "Status", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+	err := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+	if err != nil {
+		err = store.WrapErr(err, "StatusStore.UpdateLastActivityAt", "Status", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSystemStore) Get() (model.StringMap, error) {
+	result, err := s.SystemStore.Get()
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.Get", "System", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSystemStore) GetByName(name string) (*model.System, error) {
+	result, err := s.SystemStore.GetByName(name)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.GetByName", "System", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	result, err := s.SystemStore.InsertIfExists(system)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.InsertIfExists", "System", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	result, err := s.SystemStore.PermanentDeleteByName(name)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.PermanentDeleteByName", "System", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerSystemStore) Save(system *model.System) error {
+	err := s.SystemStore.Save(system)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.Save", "System", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSystemStore) SaveOrUpdate(system *model.System) error {
+	err := s.SystemStore.SaveOrUpdate(system)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.SaveOrUpdate", "System", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+	err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.SaveOrUpdateWithWarnMetricHandling", "System", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerSystemStore) Update(system *model.System) error {
+	err := s.SystemStore.Update(system)
+	if err != nil {
+		err = store.WrapErr(err, "SystemStore.Update", "System", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, error) {
+	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.AnalyticsGetTeamCountForScheme", "Team", map[string]string{"scheme_id": schemeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
+	result, err := s.TeamStore.AnalyticsPrivateTeamCount()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.AnalyticsPrivateTeamCount", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) AnalyticsPublicTeamCount() (int64, error) {
+	result, err := s.TeamStore.AnalyticsPublicTeamCount()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.AnalyticsPublicTeamCount", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) AnalyticsTeamCount(includeDeleted bool) (int64, error) {
+	result, err := s.TeamStore.AnalyticsTeamCount(includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.AnalyticsTeamCount", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) ClearAllCustomRoleAssignments() error {
+	err := s.TeamStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.ClearAllCustomRoleAssignments", "Team", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) Get(id string) (*model.Team, error) {
+	result, err := s.TeamStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.Get", "Team", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	result, err := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetActiveMemberCount", "Team", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAll() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAll()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAll", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, error) {
+	result, err := s.TeamStore.GetAllForExportAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllForExportAfter", "Team", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPage(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllPage", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPrivateTeamListing()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllPrivateTeamListing", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllPrivateTeamPageListing", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllPublicTeamPageListing", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllTeamListing()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllTeamListing", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllTeamPageListing(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetAllTeamPageListing", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, error) {
+	result, err := s.TeamStore.GetByInviteId(inviteId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetByInviteId", "Team", map[string]string{"invite_id": inviteId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetByName(name string) (*model.Team, error) {
+	result, err := s.TeamStore.GetByName(name)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetByName", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetByNames(name)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetByNames", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, error) {
+	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetChannelUnreadsForAllTeams", "Team", map[string]string{"exclude_team_id": excludeTeamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, error) {
+	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetChannelUnreadsForTeam", "Team", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMember(teamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetMember", "Team", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetMembers(teamId string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMembers(teamId, offset, limit, teamMembersGetOptions)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetMembers", "Team", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetMembersByIds", "Team", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, error) {
+	result, err := s.TeamStore.GetTeamMembersForExport(userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamMembersForExport", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamStats", "Team", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamsByScheme", "Team", map[string]string{"scheme_id": schemeId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetTeamsByUserId(userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamsByUserId", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamsForUser(ctx context.Context, userId string) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetTeamsForUser(ctx, userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamsForUser", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTeamsForUserWithPagination", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	result, err := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetTotalMemberCount", "Team", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, error) {
+	result, err := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GetUserTeamIds", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+	result, err := s.TeamStore.GroupSyncedTeamCount()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.GroupSyncedTeamCount", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+	result, err := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.MigrateTeamMembers", "Team", map[string]string{"from_team_id": fromTeamId, "from_user_id": fromUserId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) PermanentDelete(teamId string) error {
+	err := s.TeamStore.PermanentDelete(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.PermanentDelete", "Team", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) RemoveAllMembersByTeam(teamId string) error {
+	err := s.TeamStore.RemoveAllMembersByTeam(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.RemoveAllMembersByTeam", "Team", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) RemoveAllMembersByUser(userId string) error {
+	err := s.TeamStore.RemoveAllMembersByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.RemoveAllMembersByUser", "Team", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) RemoveMember(teamId string, userId string) error {
+	err := s.TeamStore.RemoveMember(teamId, userId)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.RemoveMember", "Team", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) RemoveMembers(teamId string, userIds []string) error {
+	err := s.TeamStore.RemoveMembers(teamId, userIds)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.RemoveMembers", "Team", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) ResetAllTeamSchemes() error {
+	err := s.TeamStore.ResetAllTeamSchemes()
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.ResetAllTeamSchemes", "Team", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+	result, err := s.TeamStore.Save(team)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.Save", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SaveMember", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SaveMultipleMembers", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) SearchAll(term string, opts *model.TeamSearch) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchAll(term, opts)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SearchAll", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) SearchAllPaged(term string, opts *model.TeamSearch) ([]*model.Team, int64, error) {
+	result, resultVar1, err := s.TeamStore.SearchAllPaged(term, opts)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SearchAllPaged", "Team", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerTeamStore) SearchOpen(term string) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchOpen(term)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SearchOpen", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) SearchPrivate(term string) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchPrivate(term)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.SearchPrivate", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+	result, err := s.TeamStore.Update(team)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.Update", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+	err := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.UpdateLastTeamIconUpdate", "Team", map[string]string{"team_id": teamId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+	result, err := s.TeamStore.UpdateMember(member)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.UpdateMember", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+	err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.UpdateMembersRole", "Team", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.UpdateMultipleMembers(members)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.UpdateMultipleMembers", "Team", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, error) {
+	result, err := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+	if err != nil {
+		err = store.WrapErr(err, "TeamStore.UserBelongsToTeams", "Team", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "TermsOfServiceStore.Get", "TermsOfService", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "TermsOfServiceStore.GetLatest", "TermsOfService", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.Save(termsOfService)
+	if err != nil {
+		err = store.WrapErr(err, "TermsOfServiceStore.Save", "TermsOfService", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) CollectThreadsWithNewerReplies(userId string, channelIds []string, timestamp int64) ([]string, error) {
+	result, err := s.ThreadStore.CollectThreadsWithNewerReplies(userId, channelIds, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.CollectThreadsWithNewerReplies", "Thread", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+	err := s.ThreadStore.CreateMembershipIfNeeded(userId, postId, following)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.CreateMembershipIfNeeded", "Thread", map[string]string{"user_id": userId, "post_id": postId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerThreadStore) Delete(postId string) error {
+	err := s.ThreadStore.Delete(postId)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.Delete", "Thread", map[string]string{"post_id": postId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+	err := s.ThreadStore.DeleteMembershipForUser(userId, postId)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.DeleteMembershipForUser", "Thread", map[string]string{"user_id": userId, "post_id": postId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerThreadStore) Get(id string) (*model.Thread, error) {
+	result, err := s.ThreadStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.Get", "Thread", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) GetMembershipForUser(userId string, postId string) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.GetMembershipForUser(userId, postId)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.GetMembershipForUser", "Thread", map[string]string{"user_id": userId, "post_id": postId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) GetMembershipsForUser(userId string) ([]*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.GetMembershipsForUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.GetMembershipsForUser", "Thread", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+	result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.GetThreadsForUser", "Thread", map[string]string{"user_id": userId, "team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+	err := s.ThreadStore.MarkAllAsRead(userId, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.MarkAllAsRead", "Thread", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+	err := s.ThreadStore.MarkAsRead(userId, threadId, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.MarkAsRead", "Thread", map[string]string{"user_id": userId, "thread_id": threadId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+	result, err := s.ThreadStore.Save(thread)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.Save", "Thread", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.SaveMembership(membership)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.SaveMembership", "Thread", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+	result, resultVar1, err := s.ThreadStore.SaveMultiple(thread)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.SaveMultiple", "Thread", nil)
+	}
+	return result, resultVar1, err
+}
+
+func (s *ErrorContextLayerThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+	result, err := s.ThreadStore.Update(thread)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.Update", "Thread", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.UpdateMembership(membership)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.UpdateMembership", "Thread", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+	err := s.ThreadStore.UpdateUnreadsByChannel(userId, changedThreads, timestamp)
+	if err != nil {
+		err = store.WrapErr(err, "ThreadStore.UpdateUnreadsByChannel", "Thread", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTokenStore) Delete(token string) error {
+	err := s.TokenStore.Delete(token)
+	if err != nil {
+		err = store.WrapErr(err, "TokenStore.Delete", "Token", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTokenStore) GetByToken(token string) (*model.Token, error) {
+	result, err := s.TokenStore.GetByToken(token)
+	if err != nil {
+		err = store.WrapErr(err, "TokenStore.GetByToken", "Token", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+	err := s.TokenStore.RemoveAllTokensByType(tokenType)
+	if err != nil {
+		err = store.WrapErr(err, "TokenStore.RemoveAllTokensByType", "Token", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerTokenStore) Save(recovery *model.Token) error {
+	err := s.TokenStore.Save(recovery)
+	if err != nil {
+		err = store.WrapErr(err, "TokenStore.Save", "Token", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUploadSessionStore) Delete(id string) error {
+	err := s.UploadSessionStore.Delete(id)
+	if err != nil {
+		err = store.WrapErr(err, "UploadSessionStore.Delete", "UploadSession", map[string]string{"id": id})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUploadSessionStore) Get(id string) (*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "UploadSessionStore.Get", "UploadSession", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.GetForUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UploadSessionStore.GetForUser", "UploadSession", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.Save(session)
+	if err != nil {
+		err = store.WrapErr(err, "UploadSessionStore.Save", "UploadSession", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUploadSessionStore) Update(session *model.UploadSession) error {
+	err := s.UploadSessionStore.Update(session)
+	if err != nil {
+		err = store.WrapErr(err, "UploadSessionStore.Update", "UploadSession", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.AnalyticsActiveCount(time, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsActiveCount", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsActiveCountForPeriod", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+	result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsGetExternalUsers", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetGuestCount()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsGetGuestCount", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsGetInactiveUsersCount", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetSystemAdminCount()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AnalyticsGetSystemAdminCount", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) AutocompleteUsersInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+	result, err := s.UserStore.AutocompleteUsersInChannel(teamId, channelId, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.AutocompleteUsersInChannel", "User", map[string]string{"team_id": teamId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) ClearAllCustomRoleAssignments() error {
+	err := s.UserStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.ClearAllCustomRoleAssignments", "User", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.Count(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.Count", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) DeactivateGuests() ([]string, error) {
+	result, err := s.UserStore.DeactivateGuests()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.DeactivateGuests", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) DemoteUserToGuest(userID string) error {
+	err := s.UserStore.DemoteUserToGuest(userID)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.DemoteUserToGuest", "User", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) Get(id string) (*model.User, error) {
+	result, err := s.UserStore.Get(id)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.Get", "User", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAll() ([]*model.User, error) {
+	result, err := s.UserStore.GetAll()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAll", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllAfter(limit, afterId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAllAfter", "User", map[string]string{"after_id": afterId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllNotInAuthService(authServices)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAllNotInAuthService", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllProfiles(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAllProfiles", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, error) {
+	result, err := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAllProfilesInChannel", "User", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllUsingAuthService(authService)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAllUsingAuthService", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, error) {
+	result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetAnyUnreadPostCountForChannel", "User", map[string]string{"user_id": userId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+	result, err := s.UserStore.GetByAuth(authData, authService)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetByAuth", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetByEmail(email string) (*model.User, error) {
+	result, err := s.UserStore.GetByEmail(email)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetByEmail", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetByUsername(username string) (*model.User, error) {
+	result, err := s.UserStore.GetByUsername(username)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetByUsername", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+	result, err := s.UserStore.GetChannelGroupUsers(channelID)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetChannelGroupUsers", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+	result, err := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetForLogin", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
+	result, err := s.UserStore.GetKnownUsers(userID)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetKnownUsers", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetNewUsersForTeam", "User", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, error) {
+	result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfileByGroupChannelIdsForUser", "User", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfileByIds", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfiles(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfiles", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesByUsernames", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesInChannel(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesInChannel", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesInChannelByStatus(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesInChannelByStatus", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesNotInChannel", "User", map[string]string{"team_id": teamId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesNotInTeam", "User", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesWithoutTeam(options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetProfilesWithoutTeam", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetRecentlyActiveUsersForTeam", "User", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+	result, err := s.UserStore.GetSystemAdminProfiles()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetSystemAdminProfiles", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+	result, err := s.UserStore.GetTeamGroupUsers(teamID)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetTeamGroupUsers", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetUnreadCount(userId string) (int64, error) {
+	result, err := s.UserStore.GetUnreadCount(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetUnreadCount", "User", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, error) {
+	result, err := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetUnreadCountForChannel", "User", map[string]string{"user_id": userId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+	result, err := s.UserStore.GetUser(id, includeDeleted)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetUser", "User", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
+	result, err := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.GetUsersBatchForIndexing", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) InferSystemInstallDate() (int64, error) {
+	result, err := s.UserStore.InferSystemInstallDate()
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.InferSystemInstallDate", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) PermanentDelete(userId string) error {
+	err := s.UserStore.PermanentDelete(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.PermanentDelete", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) PromoteGuestToUser(userID string) error {
+	err := s.UserStore.PromoteGuestToUser(userID)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.PromoteGuestToUser", "User", nil)
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) ResetLastPictureUpdate(userId string) error {
+	err := s.UserStore.ResetLastPictureUpdate(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.ResetLastPictureUpdate", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) Save(user *model.User) (*model.User, error) {
+	result, err := s.UserStore.Save(user)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.Save", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.Search(teamId, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.Search", "User", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchInChannel(channelId, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.SearchInChannel", "User", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchInGroup(groupID, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.SearchInGroup", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchNotInChannel(teamId, channelId, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.SearchNotInChannel", "User", map[string]string{"team_id": teamId, "channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchNotInTeam(notInTeamId, term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.SearchNotInTeam", "User", map[string]string{"not_in_team_id": notInTeamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchWithoutTeam(term, options)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.SearchWithoutTeam", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	result, err := s.UserStore.Update(user, allowRoleUpdate)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.Update", "User", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	result, err := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateAuthData", "User", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+	err := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateFailedPasswordAttempts", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateLastPictureUpdate(userId string) error {
+	err := s.UserStore.UpdateLastPictureUpdate(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateLastPictureUpdate", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateMfaActive(userId string, active bool) error {
+	err := s.UserStore.UpdateMfaActive(userId, active)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateMfaActive", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateMfaSecret(userId string, secret string) error {
+	err := s.UserStore.UpdateMfaSecret(userId, secret)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateMfaSecret", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdatePassword(userId string, newPassword string) error {
+	err := s.UserStore.UpdatePassword(userId, newPassword)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdatePassword", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdatePasswordHash", "User", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
+	result, err := s.UserStore.UpdateUpdateAt(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.UpdateUpdateAt", "User", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserStore) VerifyEmail(userId string, email string) (string, error) {
+	result, err := s.UserStore.VerifyEmail(userId, email)
+	if err != nil {
+		err = store.WrapErr(err, "UserStore.VerifyEmail", "User", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) Delete(tokenId string) error {
+	err := s.UserAccessTokenStore.Delete(tokenId)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.Delete", "UserAccessToken", map[string]string{"token_id": tokenId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) DeleteAllForUser(userId string) error {
+	err := s.UserAccessTokenStore.DeleteAllForUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.DeleteAllForUser", "UserAccessToken", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Get(tokenId)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.Get", "UserAccessToken", map[string]string{"token_id": tokenId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.GetAll", "UserAccessToken", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.GetByToken", "UserAccessToken", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.GetByUser", "UserAccessToken", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Save(token)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.Save", "UserAccessToken", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Search(term)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.Search", "UserAccessToken", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+	err := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.UpdateTokenDisable", "UserAccessToken", map[string]string{"token_id": tokenId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+	err := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+	if err != nil {
+		err = store.WrapErr(err, "UserAccessTokenStore.UpdateTokenEnable", "UserAccessToken", map[string]string{"token_id": tokenId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+	err := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+	if err != nil {
+		err = store.WrapErr(err, "UserTermsOfServiceStore.Delete", "UserTermsOfService", map[string]string{"user_id": userId, "terms_of_service_id": termsOfServiceId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, error) {
+	result, err := s.UserTermsOfServiceStore.GetByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "UserTermsOfServiceStore.GetByUser", "UserTermsOfService", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+	result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	if err != nil {
+		err = store.WrapErr(err, "UserTermsOfServiceStore.Save", "UserTermsOfService", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, error) {
+	result, err := s.WebhookStore.AnalyticsIncomingCount(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.AnalyticsIncomingCount", "Webhook", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, error) {
+	result, err := s.WebhookStore.AnalyticsOutgoingCount(teamId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.AnalyticsOutgoingCount", "Webhook", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+	err := s.WebhookStore.DeleteIncoming(webhookId, time)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.DeleteIncoming", "Webhook", map[string]string{"webhook_id": webhookId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+	err := s.WebhookStore.DeleteOutgoing(webhookId, time)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.DeleteOutgoing", "Webhook", map[string]string{"webhook_id": webhookId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncoming", "Webhook", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByChannel(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncomingByChannel", "Webhook", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByTeam(teamId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncomingByTeam", "Webhook", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncomingByTeamByUser", "Webhook", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingList(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncomingList", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingListByUser(userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetIncomingListByUser", "Webhook", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoing(id)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoing", "Webhook", map[string]string{"id": id})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByChannel(channelId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingByChannel", "Webhook", map[string]string{"channel_id": channelId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingByChannelByUser", "Webhook", map[string]string{"channel_id": channelId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByTeam(teamId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingByTeam", "Webhook", map[string]string{"team_id": teamId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingByTeamByUser", "Webhook", map[string]string{"team_id": teamId, "user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingList(offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingList", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingListByUser(userId, offset, limit)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.GetOutgoingListByUser", "Webhook", map[string]string{"user_id": userId})
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+	err := s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.PermanentDeleteIncomingByChannel", "Webhook", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+	err := s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.PermanentDeleteIncomingByUser", "Webhook", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+	err := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.PermanentDeleteOutgoingByChannel", "Webhook", map[string]string{"channel_id": channelId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+	err := s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.PermanentDeleteOutgoingByUser", "Webhook", map[string]string{"user_id": userId})
+	}
+	return err
+}
+
+func (s *ErrorContextLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.SaveIncoming(webhook)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.SaveIncoming", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.SaveOutgoing(webhook)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.SaveOutgoing", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.UpdateIncoming(webhook)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.UpdateIncoming", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.UpdateOutgoing(hook)
+	if err != nil {
+		err = store.WrapErr(err, "WebhookStore.UpdateOutgoing", "Webhook", nil)
+	}
+	return result, err
+}
+
+func (s *ErrorContextLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *ErrorContextLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
+func (s *ErrorContextLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *ErrorContextLayer) GetCurrentSchemaVersion() string {
+	return s.Store.GetCurrentSchemaVersion()
+}
+
+func (s *ErrorContextLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *ErrorContextLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *ErrorContextLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
+func (s *ErrorContextLayer) SetContext(context context.Context) {
+	s.Store.SetContext(context)
+}
+
+func (s *ErrorContextLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *ErrorContextLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *ErrorContextLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *ErrorContextLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+// New wraps the child store with the error context layer, adding the failed store method, its
+// entity type and the ids it was called with to the errors returned by the child store.
+func New(childStore store.Store) *ErrorContextLayer {
+	newStore := ErrorContextLayer{
+		Store: childStore,
+	}
+
+	newStore.AuditStore = &ErrorContextLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &ErrorContextLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
+	newStore.BotStore = &ErrorContextLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &ErrorContextLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &ErrorContextLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &ErrorContextLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &ErrorContextLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &ErrorContextLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &ErrorContextLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.EmojiStore = &ErrorContextLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &ErrorContextLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &ErrorContextLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &ErrorContextLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &ErrorContextLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &ErrorContextLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.OAuthStore = &ErrorContextLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PluginStore = &ErrorContextLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &ErrorContextLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PreferenceStore = &ErrorContextLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &ErrorContextLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.ReactionStore = &ErrorContextLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RoleStore = &ErrorContextLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemeStore = &ErrorContextLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &ErrorContextLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &ErrorContextLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &ErrorContextLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &ErrorContextLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &ErrorContextLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &ErrorContextLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &ErrorContextLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &ErrorContextLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &ErrorContextLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &ErrorContextLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &ErrorContextLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &ErrorContextLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package errorcontextlayer
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestErrorContextLayer(t *testing.T) {
+	t.Run("adds the context to the errors", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.ChannelStore.On("GetMember", "channel", "user").Return(nil, store.NewErrNotFound("ChannelMember", "channel")).Once()
+
+		_, err := New(mockStore).Channel().GetMember("channel", "user")
+		require.Error(t, err)
+		assert.Equal(t, "ChannelStore.GetMember: resource: ChannelMember id: channel", err.Error())
+
+		var errContext *store.ErrContext
+		require.True(t, errors.As(err, &errContext))
+		assert.Equal(t, "ChannelStore.GetMember", errContext.Operation)
+		assert.Equal(t, "Channel", errContext.Entity)
+		assert.Equal(t, map[string]string{"channel_id": "channel", "user_id": "user"}, errContext.Ids)
+		assert.True(t, errors.Is(err, &store.ErrNotFound{}))
+	})
+
+	t.Run("preserves the original error", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("UpdatePassword", "user", "hash").Return(errors.Wrap(sql.ErrNoRows, "failed")).Once()
+
+		err := New(mockStore).User().UpdatePassword("user", "hash")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.Equal(t, "failed: "+sql.ErrNoRows.Error(), errors.Unwrap(err).Error())
+	})
+
+	t.Run("returns nil when there is no error", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("Get", "user").Return(&model.User{Id: "user"}, nil).Once()
+
+		user, err := New(mockStore).User().Get("user")
+		require.NoError(t, err)
+		assert.Equal(t, "user", user.Id)
+	})
+}
+
+func TestErrContextLogFields(t *testing.T) {
+	err := store.WrapErr(errors.New("failed"), "PostStore.Get", "Post", map[string]string{"post_id": "post", "channel_id": "channel"})
+	assert.Equal(t, []mlog.Field{
+		mlog.String("store_operation", "PostStore.Get"),
+		mlog.String("store_entity", "Post"),
+		mlog.String("channel_id", "channel"),
+		mlog.String("post_id", "post"),
+	}, err.(*store.ErrContext).LogFields())
+
+	assert.Nil(t, store.WrapErr(nil, "PostStore.Get", "Post", nil))
+}
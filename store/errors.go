@@ -5,6 +5,9 @@ package store
 
 import (
 	"fmt"
+	"sort"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
 )
 
 // ErrInvalidInput indicates an error that has occured due to an invalid input.
@@ -26,6 +29,14 @@ func (e *ErrInvalidInput) Error() string {
 	return fmt.Sprintf("invalid input: entity: %s field: %s value: %s", e.Entity, e.Field, e.Value)
 }
 
+// Is reports whether the target is an ErrInvalidInput of the same entity and field, ignoring the
+// ones left empty in the target, so that errors.Is(err, &ErrInvalidInput{}) matches any invalid
+// input.
+func (e *ErrInvalidInput) Is(target error) bool {
+	t, ok := target.(*ErrInvalidInput)
+	return ok && (t.Entity == "" || t.Entity == e.Entity) && (t.Field == "" || t.Field == e.Field)
+}
+
 // ErrLimitExceeded indicates an error that has occured because some value exceeded a limit.
 type ErrLimitExceeded struct {
 	What  string // What was the object that exceeded.
@@ -72,6 +83,13 @@ func (e *ErrConflict) Unwrap() error {
 	return e.err
 }
 
+// Is reports whether the target is an ErrConflict of the same resource, or of any resource if the
+// resource of the target is empty.
+func (e *ErrConflict) Is(target error) bool {
+	t, ok := target.(*ErrConflict)
+	return ok && (t.Resource == "" || t.Resource == e.Resource)
+}
+
 // ErrNotFound indicates that a resource was not found
 type ErrNotFound struct {
 	resource string
@@ -89,6 +107,13 @@ func (e *ErrNotFound) Error() string {
 	return "resource: " + e.resource + " id: " + e.Id
 }
 
+// Is reports whether the target is an ErrNotFound of the same resource and id, ignoring the ones
+// left empty in the target, so that errors.Is(err, &ErrNotFound{}) matches any missing resource.
+func (e *ErrNotFound) Is(target error) bool {
+	t, ok := target.(*ErrNotFound)
+	return ok && (t.resource == "" || t.resource == e.resource) && (t.Id == "" || t.Id == e.Id)
+}
+
 // ErrOutOfBounds indicates that the requested total numbers of rows
 // was greater than the allowed limit.
 type ErrOutOfBounds struct {
@@ -115,3 +140,132 @@ func (e *ErrNotImplemented) Error() string {
 func NewErrNotImplemented(detail string) *ErrNotImplemented {
 	return &ErrNotImplemented{detail: detail}
 }
+
+// ErrCircuitOpen indicates that a query was rejected without reaching the database,
+// because too many queries failed recently.
+type ErrCircuitOpen struct{}
+
+func NewErrCircuitOpen() *ErrCircuitOpen {
+	return &ErrCircuitOpen{}
+}
+
+func (e *ErrCircuitOpen) Error() string {
+	return "circuit breaker open: the database is unavailable"
+}
+
+// ErrQueryTimeout indicates that a query was cancelled because it ran longer than the query
+// timeout of the database.
+type ErrQueryTimeout struct {
+	err error // Internal error.
+}
+
+func NewErrQueryTimeout(err error) *ErrQueryTimeout {
+	return &ErrQueryTimeout{err: err}
+}
+
+func (e *ErrQueryTimeout) Error() string {
+	return "query timed out: " + e.err.Error()
+}
+
+func (e *ErrQueryTimeout) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the target is an ErrQueryTimeout, so that errors.Is(err, &ErrQueryTimeout{})
+// matches any timeout.
+func (e *ErrQueryTimeout) Is(target error) bool {
+	_, ok := target.(*ErrQueryTimeout)
+	return ok
+}
+
+// ErrSearchDegraded indicates that the database is healthy but a search engine isn't, so
+// searches fall back to the database until the engine recovers.
+type ErrSearchDegraded struct {
+	Engine string // The name of the unhealthy search engine.
+	err    error  // Internal error.
+}
+
+func NewErrSearchDegraded(engine string, err error) *ErrSearchDegraded {
+	return &ErrSearchDegraded{
+		Engine: engine,
+		err:    err,
+	}
+}
+
+func (e *ErrSearchDegraded) Error() string {
+	return "search engine " + e.Engine + " is unavailable: " + e.err.Error()
+}
+
+func (e *ErrSearchDegraded) Unwrap() error {
+	return e.err
+}
+
+// ErrReadOnly indicates that a change was rejected without reaching the database, because the
+// store is in read-only mode.
+type ErrReadOnly struct {
+	Operation string // The store method rejected.
+}
+
+func NewErrReadOnly(operation string) *ErrReadOnly {
+	return &ErrReadOnly{Operation: operation}
+}
+
+func (e *ErrReadOnly) Error() string {
+	return "store is read-only: " + e.Operation + " rejected"
+}
+
+// Is reports whether the target is an ErrReadOnly, so that errors.Is(err, &ErrReadOnly{}) matches
+// any rejected change.
+func (e *ErrReadOnly) Is(target error) bool {
+	_, ok := target.(*ErrReadOnly)
+	return ok
+}
+
+// ErrContext adds the store method that failed and the entities involved to an error, logged
+// as fields by mlog. The original error is preserved for errors.Is and errors.As.
+type ErrContext struct {
+	Operation string            // The store method that failed, such as ChannelStore.Get.
+	Entity    string            // The type of the entities involved, such as Channel.
+	Ids       map[string]string // The ids of the entities involved, by field name.
+	err       error             // Internal error.
+}
+
+// WrapErr adds context to the error returned by a store method, or returns nil if there is
+// no error.
+func WrapErr(err error, operation, entity string, ids map[string]string) error {
+	if err == nil {
+		return nil
+	}
+	return &ErrContext{
+		Operation: operation,
+		Entity:    entity,
+		Ids:       ids,
+		err:       err,
+	}
+}
+
+func (e *ErrContext) Error() string {
+	return e.Operation + ": " + e.err.Error()
+}
+
+func (e *ErrContext) Unwrap() error {
+	return e.err
+}
+
+// LogFields returns the operation, the entity and the ids, sorted by name, as log fields.
+func (e *ErrContext) LogFields() []mlog.Field {
+	fields := []mlog.Field{
+		mlog.String("store_operation", e.Operation),
+		mlog.String("store_entity", e.Entity),
+	}
+
+	names := make([]string, 0, len(e.Ids))
+	for name := range e.Ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, mlog.String(name, e.Ids[name]))
+	}
+	return fields
+}
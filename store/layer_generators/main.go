@@ -16,6 +16,7 @@ import (
 	"path"
 	"strings"
 	"text/template"
+	"unicode"
 )
 
 const (
@@ -24,6 +25,41 @@ const (
 	ERROR_TYPE                 = "error"
 )
 
+// writeMethodPrefixes identifies the store methods modifying data. The methods modifying data
+// whose name doesn't start with one of them are listed in writeMethods.
+var writeMethodPrefixes = []string{
+	"Attach", "Bulk", "Cleanup", "Clear", "Create", "Deactivate", "Delete", "Demote", "Drop",
+	"Increment", "Insert", "Invalidate", "Log", "Mark", "Migrate", "Overwrite", "Permanent",
+	"Promote", "Remove", "Reset", "Restore", "Save", "Set", "Update", "Upsert",
+}
+
+// writeMethods classifies the store methods that writeMethodPrefixes gets wrong, by name: the
+// methods modifying data with another prefix, and the reads with one of the prefixes.
+var writeMethods = map[string]bool{
+	"BulkGetForPosts":  false,
+	"SetContext":       false,
+	"ClaimPending":     true,
+	"CompareAndDelete": true,
+	"CompareAndSet":    true,
+	"MoveThread":       true,
+	"Record":           true,
+	"TryUse":           true,
+	"VerifyEmail":      true,
+	"View":             true,
+}
+
+func isWriteMethod(methodName string) bool {
+	if isWrite, ok := writeMethods[methodName]; ok {
+		return isWrite
+	}
+	for _, prefix := range writeMethodPrefixes {
+		if strings.HasPrefix(methodName, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func isError(typeName string) bool {
 	return strings.Contains(typeName, APP_ERROR_TYPE) || strings.Contains(typeName, ERROR_TYPE)
 }
@@ -32,6 +68,30 @@ func isAppError(typeName string) bool {
 	return strings.Contains(typeName, APP_ERROR_TYPE)
 }
 
+// isIdParam identifies the string params holding the id of an entity, such as id or channelId.
+// The login id is an email or a username rather than an id, and is kept out of the logs.
+func isIdParam(param methodParam) bool {
+	if param.Type != "string" || param.Name == "loginId" {
+		return false
+	}
+	return param.Name == "id" || strings.HasSuffix(param.Name, "Id")
+}
+
+// toSnakeCase converts a param name such as channelId to channel_id.
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 func main() {
 	if err := buildTimerLayer(); err != nil {
 		log.Fatal(err)
@@ -42,6 +102,102 @@ func main() {
 	if err := buildRetryLayer(); err != nil {
 		log.Fatal(err)
 	}
+	if err := buildCircuitBreakerLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildNotSupportedLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildAuditLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildReadOnlyLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildErrorContextLayer(); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildQueryTimeoutLayer(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func buildQueryTimeoutLayer() error {
+	code, err := generateLayer("queryTimeout", "query_timeout_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("sqlstore", "query_timeout_layer.go"), formatedCode, 0644)
+}
+
+func buildErrorContextLayer() error {
+	code, err := generateLayer("ErrorContextLayer", "error_context_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("errorcontextlayer", "errorcontextlayer.go"), formatedCode, 0644)
+}
+
+func buildReadOnlyLayer() error {
+	code, err := generateLayer("ReadOnlyLayer", "read_only_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("readonlylayer", "readonlylayer.go"), formatedCode, 0644)
+}
+
+func buildAuditLayer() error {
+	code, err := generateLayer("AuditLayer", "audit_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("auditlayer", "auditlayer.go"), formatedCode, 0644)
+}
+
+func buildNotSupportedLayer() error {
+	code, err := generateLayer("notSupported", "not_supported_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("storetest", "not_supported_store.go"), formatedCode, 0644)
+}
+
+func buildCircuitBreakerLayer() error {
+	code, err := generateLayer("CircuitBreakerLayer", "circuit_breaker_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("circuitbreakerlayer", "circuitbreakerlayer.go"), formatedCode, 0644)
 }
 
 func buildRetryLayer() error {
@@ -146,6 +302,9 @@ func extractMethodMetadata(method *ast.Field, src []byte) methodData {
 					log.Fatalf("Unable to find a parameter called '%s' (method '%s') that is mentioned in the '%s' comment. Maybe it was renamed?", paramName, method.Names[0].Name, OPEN_TRACING_PARAMS_MARKER)
 				}
 			}
+			// Don't descend into the function types of the params, whose params and results
+			// aren't the method's.
+			return false
 		}
 		return true
 	})
@@ -171,16 +330,18 @@ func extractStoreMetadata() (*storeMetadata, error) {
 	}
 
 	topLevelFunctions := map[string]bool{
-		"MarkSystemRanUnitTests":   false,
-		"Close":                    false,
-		"LockToMaster":             false,
-		"UnlockFromMaster":         false,
-		"DropAllTables":            false,
-		"TotalMasterDbConnections": true,
-		"TotalReadDbConnections":   true,
-		"SetContext":               true,
-		"TotalSearchDbConnections": true,
-		"GetCurrentSchemaVersion":  true,
+		"MarkSystemRanUnitTests":           false,
+		"Close":                            false,
+		"LockToMaster":                     false,
+		"UnlockFromMaster":                 false,
+		"DropAllTables":                    false,
+		"TotalMasterDbConnections":         true,
+		"TotalReadDbConnections":           true,
+		"SetContext":                       true,
+		"TotalSearchDbConnections":         true,
+		"GetCurrentSchemaVersion":          true,
+		"PermanentDeleteBatchForRetention": true,
+		"DeleteOrphans":                    true,
 	}
 
 	metadata := storeMetadata{Methods: map[string]methodData{}, SubStores: map[string]subStore{}}
@@ -248,6 +409,20 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return strings.Join(vars, ", ")
 		},
+		"genZeroResultsVars": func(results []string) string {
+			vars := []string{}
+			for i, typeName := range results {
+				if isError(typeName) {
+					continue
+				} else if i == 0 {
+					vars = append(vars, fmt.Sprintf("var result %s", typeName))
+				} else {
+					vars = append(vars, fmt.Sprintf("var resultVar%d %s", i, typeName))
+				}
+			}
+			return strings.Join(vars, "\n")
+		},
+		"isWriteMethod": isWriteMethod,
 		"errorToBoolean": func(results []string) string {
 			for _, typeName := range results {
 				if isError(typeName) {
@@ -272,6 +447,21 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return false
 		},
+		"hasResultVar": func(results []string) bool {
+			return len(results) > 0 && !isError(results[0])
+		},
+		"mapQueryTimeout": func(vars string) string {
+			names := strings.Split(vars, ", ")
+			for i, name := range names {
+				if name == "err" {
+					names[i] = "MapQueryTimeout(err)"
+				}
+			}
+			return strings.Join(names, ", ")
+		},
+		"lowerFirst": func(name string) string {
+			return strings.ToLower(name[:1]) + name[1:]
+		},
 		"errorVar": func(results []string) string {
 			for _, typeName := range results {
 				if isError(typeName) {
@@ -280,6 +470,24 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return ""
 		},
+		"genIdsMap": func(params []methodParam) string {
+			ids := []string{}
+			for _, param := range params {
+				if isIdParam(param) {
+					ids = append(ids, fmt.Sprintf("%q: %s", toSnakeCase(param.Name), param.Name))
+				}
+			}
+			if len(ids) == 0 {
+				return "nil"
+			}
+			return fmt.Sprintf("map[string]string{%s}", strings.Join(ids, ", "))
+		},
+		"firstParamOrNil": func(params []methodParam) string {
+			if len(params) == 0 {
+				return "nil"
+			}
+			return params[0].Name
+		},
 		"joinParams": func(params []methodParam) string {
 			paramsNames := make([]string, 0, len(params))
 			for _, param := range params {
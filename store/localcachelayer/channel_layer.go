@@ -4,6 +4,9 @@
 package localcachelayer
 
 import (
+	"context"
+	"strings"
+
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
 )
@@ -45,17 +48,31 @@ func (s *LocalCacheChannelStore) handleClusterInvalidateChannelById(msg *model.C
 	}
 }
 
+func (s *LocalCacheChannelStore) handleClusterInvalidateChannelMember(msg *model.ClusterMessage) {
+	if msg.Data == CLEAR_CACHE_MESSAGE_DATA {
+		s.rootStore.channelMemberCache.Purge()
+	} else {
+		s.rootStore.channelMemberCache.Remove(msg.Data)
+	}
+}
+
+func channelMemberKey(channelId, userId string) string {
+	return channelId + "/" + userId
+}
+
 func (s LocalCacheChannelStore) ClearCaches() {
 	s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCountsCache)
 	s.rootStore.doClearCacheCluster(s.rootStore.channelPinnedPostCountsCache)
 	s.rootStore.doClearCacheCluster(s.rootStore.channelGuestCountCache)
 	s.rootStore.doClearCacheCluster(s.rootStore.channelByIdCache)
+	s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
 	s.ChannelStore.ClearCaches()
 	if s.rootStore.metrics != nil {
 		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel Pinned Post Counts - Purge")
 		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel Member Counts - Purge")
 		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel Guest Count - Purge")
 		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel - Purge")
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel Member - Purge")
 	}
 }
 
@@ -87,6 +104,41 @@ func (s LocalCacheChannelStore) InvalidateChannel(channelId string) {
 	}
 }
 
+func (s LocalCacheChannelStore) invalidateChannelMember(channelId, userId string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.channelMemberCache, channelMemberKey(channelId, userId))
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter("Channel Member - Remove")
+	}
+}
+
+func (s LocalCacheChannelStore) invalidateChannelMembersByChannel(channelId string) {
+	s.invalidateChannelMembers(func(key string) bool {
+		return strings.HasPrefix(key, channelId+"/")
+	}, "Channel Member - Remove by ChannelId")
+}
+
+func (s LocalCacheChannelStore) invalidateChannelMembersByUser(userId string) {
+	s.invalidateChannelMembers(func(key string) bool {
+		return strings.HasSuffix(key, "/"+userId)
+	}, "Channel Member - Remove by UserId")
+}
+
+func (s LocalCacheChannelStore) invalidateChannelMembers(match func(key string) bool, metricName string) {
+	keys, err := s.rootStore.channelMemberCache.Keys()
+	if err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		if match(key) {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.channelMemberCache, key)
+			if s.rootStore.metrics != nil {
+				s.rootStore.metrics.IncrementMemCacheInvalidationCounter(metricName)
+			}
+		}
+	}
+}
+
 func (s LocalCacheChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, error) {
 	if allowFromCache {
 		var count int64
@@ -155,7 +207,16 @@ func (s LocalCacheChannelStore) GetPinnedPostCount(channelId string, allowFromCa
 }
 
 func (s LocalCacheChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+	return s.get(id, allowFromCache, s.ChannelStore.Get)
+}
+
+func (s LocalCacheChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	return s.get(id, allowFromCache, func(id string, allowFromCache bool) (*model.Channel, error) {
+		return s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+	})
+}
 
+func (s LocalCacheChannelStore) get(id string, allowFromCache bool, get func(string, bool) (*model.Channel, error)) (*model.Channel, error) {
 	if allowFromCache {
 		var cacheItem *model.Channel
 		if err := s.rootStore.doStandardReadCache(s.rootStore.channelByIdCache, id, &cacheItem); err == nil {
@@ -163,7 +224,7 @@ func (s LocalCacheChannelStore) Get(id string, allowFromCache bool) (*model.Chan
 		}
 	}
 
-	ch, err := s.ChannelStore.Get(id, allowFromCache)
+	ch, err := get(id, allowFromCache)
 
 	if allowFromCache && err == nil {
 		s.rootStore.doStandardAddToCache(s.rootStore.channelByIdCache, id, ch)
@@ -172,12 +233,71 @@ func (s LocalCacheChannelStore) Get(id string, allowFromCache bool) (*model.Chan
 	return ch, err
 }
 
+func (s LocalCacheChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	channel, err := s.ChannelStore.Update(channel)
+	if err != nil {
+		return nil, err
+	}
+	s.InvalidateChannel(channel.Id)
+	// The channel scheme might have changed, and with it the scheme roles of the members.
+	s.invalidateChannelMembersByChannel(channel.Id)
+	return channel, nil
+}
+
+func (s LocalCacheChannelStore) Delete(channelId string, time int64) error {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Delete(channelId, time)
+}
+
+func (s LocalCacheChannelStore) Restore(channelId string, time int64) error {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.Restore(channelId, time)
+}
+
+func (s LocalCacheChannelStore) SetDeleteAt(channelId string, deleteAt, updateAt int64) error {
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+}
+
+func (s LocalCacheChannelStore) PermanentDelete(channelId string) error {
+	defer s.invalidateChannelMembersByChannel(channelId)
+	defer s.InvalidateChannel(channelId)
+	return s.ChannelStore.PermanentDelete(channelId)
+}
+
+func (s LocalCacheChannelStore) PermanentDeleteByTeam(teamId string) error {
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelByIdCache)
+	return s.ChannelStore.PermanentDeleteByTeam(teamId)
+}
+
+// GetMember is a cache wrapper around the SqlStore method to get a channel member. Every
+// channel store method modifying channel members invalidates the cached ones it affects.
+func (s LocalCacheChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+	key := channelMemberKey(channelId, userId)
+
+	var cacheItem *model.ChannelMember
+	if err := s.rootStore.doStandardReadCache(s.rootStore.channelMemberCache, key, &cacheItem); err == nil {
+		return cacheItem, nil
+	}
+
+	member, err := s.ChannelStore.GetMember(channelId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.channelMemberCache, key, member)
+
+	return member, nil
+}
+
 func (s LocalCacheChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
 	member, err := s.ChannelStore.SaveMember(member)
 	if err != nil {
 		return nil, err
 	}
 	s.InvalidateMemberCount(member.ChannelId)
+	s.invalidateChannelMember(member.ChannelId, member.UserId)
 	return member, nil
 }
 
@@ -188,16 +308,33 @@ func (s LocalCacheChannelStore) SaveMultipleMembers(members []*model.ChannelMemb
 	}
 	for _, member := range members {
 		s.InvalidateMemberCount(member.ChannelId)
+		s.invalidateChannelMember(member.ChannelId, member.UserId)
 	}
 	return members, nil
 }
 
+func (s LocalCacheChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	created, updated, err := s.ChannelStore.SaveMemberMultiple(members)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, member := range created {
+		s.InvalidateMemberCount(member.ChannelId)
+		s.invalidateChannelMember(member.ChannelId, member.UserId)
+	}
+	for _, member := range updated {
+		s.invalidateChannelMember(member.ChannelId, member.UserId)
+	}
+	return created, updated, nil
+}
+
 func (s LocalCacheChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
 	member, err := s.ChannelStore.UpdateMember(member)
 	if err != nil {
 		return nil, err
 	}
 	s.InvalidateMemberCount(member.ChannelId)
+	s.invalidateChannelMember(member.ChannelId, member.UserId)
 	return member, nil
 }
 
@@ -208,6 +345,7 @@ func (s LocalCacheChannelStore) UpdateMultipleMembers(members []*model.ChannelMe
 	}
 	for _, member := range members {
 		s.InvalidateMemberCount(member.ChannelId)
+		s.invalidateChannelMember(member.ChannelId, member.UserId)
 	}
 	return members, nil
 }
@@ -218,6 +356,7 @@ func (s LocalCacheChannelStore) RemoveMember(channelId, userId string) error {
 		return err
 	}
 	s.InvalidateMemberCount(channelId)
+	s.invalidateChannelMember(channelId, userId)
 	return nil
 }
 
@@ -227,5 +366,82 @@ func (s LocalCacheChannelStore) RemoveMembers(channelId string, userIds []string
 		return err
 	}
 	s.InvalidateMemberCount(channelId)
+	for _, userId := range userIds {
+		s.invalidateChannelMember(channelId, userId)
+	}
 	return nil
 }
+
+func (s LocalCacheChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	defer s.invalidateChannelMembersByChannel(channelId)
+	return s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+}
+
+func (s LocalCacheChannelStore) PermanentDeleteMembersByUser(userId string) error {
+	defer s.invalidateChannelMembersByUser(userId)
+	return s.ChannelStore.PermanentDeleteMembersByUser(userId)
+}
+
+func (s LocalCacheChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+	defer s.invalidateChannelMembersByChannel(channelId)
+	return s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+}
+
+func (s LocalCacheChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+	defer func() {
+		for _, channelId := range channelIds {
+			s.invalidateChannelMember(channelId, userId)
+		}
+	}()
+	return s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+}
+
+func (s LocalCacheChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	defer func() {
+		for _, channelId := range channelIds {
+			s.invalidateChannelMember(channelId, userId)
+		}
+	}()
+	return s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+}
+
+func (s LocalCacheChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+	defer s.invalidateChannelMember(unreadPost.ChannelId, userID)
+	return s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+}
+
+func (s LocalCacheChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+	defer s.invalidateChannelMember(channelId, userId)
+	return s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+}
+
+func (s LocalCacheChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+	defer s.invalidateChannelMembersByChannel(channelID)
+	return s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+}
+
+func (s LocalCacheChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
+	return s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+}
+
+func (s LocalCacheChannelStore) ResetAllChannelSchemes() error {
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelByIdCache)
+	return s.ChannelStore.ResetAllChannelSchemes()
+}
+
+func (s LocalCacheChannelStore) ClearAllCustomRoleAssignments() error {
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
+	return s.ChannelStore.ClearAllCustomRoleAssignments()
+}
+
+func (s LocalCacheChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userId)
+	s.invalidateChannelMembersByUser(userId)
+}
+
+func (s LocalCacheChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelId)
+	s.invalidateChannelMembersByChannel(channelId)
+}
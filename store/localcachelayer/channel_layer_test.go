@@ -297,3 +297,85 @@ func TestChannelStoreChannel(t *testing.T) {
 		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "Get", 2)
 	})
 }
+
+func TestChannelStoreChannelMember(t *testing.T) {
+	channelId := "channel1"
+	userId := "123"
+	fakeChannelMember := model.ChannelMember{ChannelId: channelId, UserId: userId, Roles: model.CHANNEL_USER_ROLE_ID}
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		member, err := cachedStore.Channel().GetMember(channelId, userId)
+		require.Nil(t, err)
+		assert.Equal(t, &fakeChannelMember, member)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		member, err = cachedStore.Channel().GetMember(channelId, userId)
+		require.Nil(t, err)
+		assert.Equal(t, &fakeChannelMember, member)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+	})
+
+	t.Run("first call not cached, update member, second not cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		cachedStore.Channel().UpdateMember(&fakeChannelMember)
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 2)
+	})
+
+	t.Run("first call not cached, increment mention count, second not cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		cachedStore.Channel().IncrementMentionCount(channelId, userId, false)
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 2)
+	})
+
+	t.Run("first call not cached, update last viewed at multi, second not cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		cachedStore.Channel().UpdateLastViewedAtMulti([]string{channelId}, userId, 1000)
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 2)
+	})
+
+	t.Run("first call not cached, invalidate members for user, second not cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		cachedStore.Channel().InvalidateAllChannelMembersForUser(userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "InvalidateAllChannelMembersForUser", 1)
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 2)
+	})
+
+	t.Run("first call not cached, clear cache, second not cached", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 1)
+		cachedStore.Channel().ClearCaches()
+		cachedStore.Channel().GetMember(channelId, userId)
+		mockStore.Channel().(*mocks.ChannelStore).AssertNumberOfCalls(t, "GetMember", 2)
+	})
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+func InitTest() {
+	initStores()
+}
+
+func TearDownTest() {
+	tearDownStores()
+}
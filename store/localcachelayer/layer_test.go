@@ -97,7 +97,11 @@ func initStores() {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			st.SqlSupplier = sqlstore.NewSqlSupplier(*st.SqlSettings, nil)
+			var err error
+			st.SqlSupplier, err = sqlstore.NewSqlSupplier(*st.SqlSettings, nil)
+			if err != nil {
+				panic(err)
+			}
 			st.Store = NewLocalCacheLayer(st.SqlSupplier, nil, nil, getMockCacheProvider())
 			st.Store.DropAllTables()
 			st.Store.MarkSystemRanUnitTests()
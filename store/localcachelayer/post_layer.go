@@ -4,6 +4,7 @@
 package localcachelayer
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -80,6 +81,16 @@ func (s LocalCachePostStore) GetEtag(channelId string, allowFromCache bool) stri
 }
 
 func (s LocalCachePostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.getPostsSince(options, allowFromCache, s.PostStore.GetPostsSince)
+}
+
+func (s LocalCachePostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.getPostsSince(options, allowFromCache, func(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+		return s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+	})
+}
+
+func (s LocalCachePostStore) getPostsSince(options model.GetPostsSinceOptions, allowFromCache bool, getPostsSince func(model.GetPostsSinceOptions, bool) (*model.PostList, error)) (*model.PostList, error) {
 	if allowFromCache {
 		// If the last post in the channel's time is less than or equal to the time we are getting posts since,
 		// we can safely return no posts.
@@ -90,7 +101,7 @@ func (s LocalCachePostStore) GetPostsSince(options model.GetPostsSinceOptions, a
 		}
 	}
 
-	list, err := s.PostStore.GetPostsSince(options, allowFromCache)
+	list, err := getPostsSince(options, allowFromCache)
 
 	latestUpdate := options.Time
 	if err == nil {
@@ -106,8 +117,18 @@ func (s LocalCachePostStore) GetPostsSince(options model.GetPostsSinceOptions, a
 }
 
 func (s LocalCachePostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.getPosts(options, allowFromCache, s.PostStore.GetPosts)
+}
+
+func (s LocalCachePostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.getPosts(options, allowFromCache, func(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+		return s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+	})
+}
+
+func (s LocalCachePostStore) getPosts(options model.GetPostsOptions, allowFromCache bool, getPosts func(model.GetPostsOptions, bool) (*model.PostList, error)) (*model.PostList, error) {
 	if !allowFromCache {
-		return s.PostStore.GetPosts(options, allowFromCache)
+		return getPosts(options, allowFromCache)
 	}
 
 	offset := options.PerPage * options.Page
@@ -119,7 +140,7 @@ func (s LocalCachePostStore) GetPosts(options model.GetPostsOptions, allowFromCa
 		}
 	}
 
-	list, err := s.PostStore.GetPosts(options, false)
+	list, err := getPosts(options, false)
 	if err != nil {
 		return nil, err
 	}
@@ -131,3 +152,25 @@ func (s LocalCachePostStore) GetPosts(options model.GetPostsOptions, allowFromCa
 
 	return list, err
 }
+
+// MoveThread invalidates the posts and the channel caches of both the channel the thread is moved
+// from and the one it's moved to.
+func (s LocalCachePostStore) MoveThread(rootId, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	root, err := s.PostStore.GetSingle(rootId)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channelId := range []string{root.ChannelId, targetChannelId} {
+		s.InvalidateLastPostTimeCache(channelId)
+		s.rootStore.Channel().InvalidateChannel(channelId)
+		s.rootStore.Channel().InvalidatePinnedPostCount(channelId)
+	}
+
+	return posts, nil
+}
@@ -78,6 +78,24 @@ func TestPostStoreLastPostTimeCache(t *testing.T) {
 		mockStore.Post().(*mocks.PostStore).AssertNumberOfCalls(t, "GetEtag", 2)
 	})
 
+	t.Run("GetEtag: first call not cached, move a thread, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockPostStore := mockStore.Post().(*mocks.PostStore)
+		mockPostStore.On("GetSingle", "rootId").Return(&model.Post{Id: "rootId", ChannelId: "otherChannelId"}, nil)
+		mockPostStore.On("MoveThread", "rootId", channelId, false).Return([]*model.Post{}, nil)
+		mockPostStore.On("InvalidateLastPostTimeCache", "otherChannelId")
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		cachedStore.Post().GetEtag(channelId, true)
+		mockPostStore.AssertNumberOfCalls(t, "GetEtag", 1)
+		_, err := cachedStore.Post().MoveThread("rootId", channelId, false)
+		require.Nil(t, err)
+		cachedStore.Post().GetEtag(channelId, true)
+		mockPostStore.AssertNumberOfCalls(t, "GetEtag", 2)
+		mockPostStore.AssertCalled(t, "InvalidateLastPostTimeCache", "otherChannelId")
+	})
+
 	t.Run("GetPostsSince: first call not cached, second cached and returning same data", func(t *testing.T) {
 		mockStore := getMockStore()
 		mockCacheProvider := getMockCacheProvider()
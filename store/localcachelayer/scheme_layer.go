@@ -46,6 +46,7 @@ func (s LocalCacheSchemeStore) Get(schemeId string) (*model.Scheme, error) {
 
 func (s LocalCacheSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
 	defer s.rootStore.doInvalidateCacheCluster(s.rootStore.schemeCache, schemeId)
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
 	defer s.rootStore.doClearCacheCluster(s.rootStore.roleCache)
 	defer s.rootStore.doClearCacheCluster(s.rootStore.rolePermissionsCache)
 	return s.SchemeStore.Delete(schemeId)
@@ -53,6 +54,7 @@ func (s LocalCacheSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
 
 func (s LocalCacheSchemeStore) PermanentDeleteAll() error {
 	defer s.rootStore.doClearCacheCluster(s.rootStore.schemeCache)
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
 	defer s.rootStore.doClearCacheCluster(s.rootStore.roleCache)
 	defer s.rootStore.doClearCacheCluster(s.rootStore.rolePermissionsCache)
 	return s.SchemeStore.PermanentDeleteAll()
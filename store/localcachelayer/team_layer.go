@@ -72,6 +72,8 @@ func (s LocalCacheTeamStore) Update(team *model.Team) (*model.Team, error) {
 		return nil, err
 	}
 	defer s.rootStore.doClearCacheCluster(s.rootStore.rolePermissionsCache)
+	// The team scheme might have changed, and with it the scheme roles of the channel members.
+	defer s.rootStore.doClearCacheCluster(s.rootStore.channelMemberCache)
 
 	if oldTeam != nil && oldTeam.DeleteAt == 0 {
 		s.rootStore.doClearCacheCluster(s.rootStore.teamAllTeamIdsForUserCache)
@@ -155,3 +155,97 @@ func (s LocalCacheUserStore) Get(id string) (*model.User, error) {
 	s.rootStore.doStandardAddToCache(s.rootStore.userProfileByIdsCache, id, user)
 	return user, nil
 }
+
+func (s LocalCacheUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	userUpdate, err := s.UserStore.Update(user, allowRoleUpdate)
+	if err != nil {
+		return nil, err
+	}
+	s.InvalidateProfileCacheForUser(user.Id)
+	s.InvalidateProfilesInChannelCacheByUser(user.Id)
+	return userUpdate, nil
+}
+
+func (s LocalCacheUserStore) UpdateLastPictureUpdate(userId string) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateLastPictureUpdate(userId)
+}
+
+func (s LocalCacheUserStore) ResetLastPictureUpdate(userId string) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.ResetLastPictureUpdate(userId)
+}
+
+func (s LocalCacheUserStore) UpdatePassword(userId, newPassword string) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdatePassword(userId, newPassword)
+}
+
+func (s LocalCacheUserStore) UpdateUpdateAt(userId string) (int64, error) {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateUpdateAt(userId)
+}
+
+func (s LocalCacheUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+}
+
+func (s LocalCacheUserStore) UpdateMfaSecret(userId, secret string) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateMfaSecret(userId, secret)
+}
+
+func (s LocalCacheUserStore) UpdateMfaActive(userId string, active bool) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateMfaActive(userId, active)
+}
+
+func (s LocalCacheUserStore) VerifyEmail(userId, email string) (string, error) {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.VerifyEmail(userId, email)
+}
+
+func (s LocalCacheUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+}
+
+func (s LocalCacheUserStore) PermanentDelete(userId string) error {
+	defer s.InvalidateProfilesInChannelCacheByUser(userId)
+	defer s.InvalidateProfileCacheForUser(userId)
+	return s.UserStore.PermanentDelete(userId)
+}
+
+func (s LocalCacheUserStore) ClearAllCustomRoleAssignments() error {
+	defer s.rootStore.doClearCacheCluster(s.rootStore.profilesInChannelCache)
+	defer s.rootStore.doClearCacheCluster(s.rootStore.userProfileByIdsCache)
+	return s.UserStore.ClearAllCustomRoleAssignments()
+}
+
+// PromoteGuestToUser also updates the scheme roles of the channel members of the user, so these
+// are invalidated along with the user.
+func (s LocalCacheUserStore) PromoteGuestToUser(userID string) error {
+	defer s.rootStore.channel.invalidateChannelMembersByUser(userID)
+	defer s.InvalidateProfilesInChannelCacheByUser(userID)
+	defer s.InvalidateProfileCacheForUser(userID)
+	return s.UserStore.PromoteGuestToUser(userID)
+}
+
+// DemoteUserToGuest also updates the scheme roles of the channel members of the user, so these
+// are invalidated along with the user.
+func (s LocalCacheUserStore) DemoteUserToGuest(userID string) error {
+	defer s.rootStore.channel.invalidateChannelMembersByUser(userID)
+	defer s.InvalidateProfilesInChannelCacheByUser(userID)
+	defer s.InvalidateProfileCacheForUser(userID)
+	return s.UserStore.DemoteUserToGuest(userID)
+}
+
+func (s LocalCacheUserStore) DeactivateGuests() ([]string, error) {
+	userIds, err := s.UserStore.DeactivateGuests()
+	for _, userId := range userIds {
+		s.InvalidateProfileCacheForUser(userId)
+		s.InvalidateProfilesInChannelCacheByUser(userId)
+	}
+	return userIds, err
+}
@@ -217,6 +217,22 @@ func TestUserStoreGetCache(t *testing.T) {
 		mockStore.User().(*mocks.UserStore).AssertNumberOfCalls(t, "Get", 2)
 	})
 
+	t.Run("first call not cached, update, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore()
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider)
+
+		gotUser, err := cachedStore.User().Get(fakeUserId)
+		require.Nil(t, err)
+		mockStore.User().(*mocks.UserStore).AssertNumberOfCalls(t, "Get", 1)
+
+		_, err = cachedStore.User().Update(gotUser, false)
+		require.Nil(t, err)
+
+		_, _ = cachedStore.User().Get(fakeUserId)
+		mockStore.User().(*mocks.UserStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
 	t.Run("should always return a copy of the stored data", func(t *testing.T) {
 		mockStore := getMockStore()
 		mockCacheProvider := getMockCacheProvider()
@@ -8,6 +8,7 @@ package opentracinglayer
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/services/tracing"
@@ -19,6 +20,7 @@ import (
 type OpenTracingLayer struct {
 	store.Store
 	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
 	BotStore                  store.BotStore
 	ChannelStore              store.ChannelStore
 	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
@@ -58,6 +60,10 @@ func (s *OpenTracingLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *OpenTracingLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
 func (s *OpenTracingLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -195,6 +201,11 @@ type OpenTracingLayerAuditStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerBotStore struct {
 	store.BotStore
 	Root *OpenTracingLayer
@@ -360,6 +371,30 @@ type OpenTracingLayerWebhookStore struct {
 	Root *OpenTracingLayer
 }
 
+// resultCount returns the number of items of the lists returned by the store methods, for
+// their span to report how many rows were fetched.
+func resultCount(result interface{}) (int, bool) {
+	if list, ok := result.(*model.PostList); ok {
+		if list == nil {
+			return 0, false
+		}
+		return len(list.Order), true
+	}
+
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return 0, false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len(), true
+	}
+	return 0, false
+}
+
 func (s *OpenTracingLayerAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditStore.Get")
@@ -375,6 +410,10 @@ func (s *OpenTracingLayerAuditStore) Get(user_id string, offset int, limit int)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -414,6 +453,46 @@ func (s *OpenTracingLayerAuditStore) Save(audit *model.Audit) error {
 	return err
 }
 
+func (s *OpenTracingLayerAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditTrailStore.GetByEntity")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "AuditTrailStore.Record")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.AuditTrailStore.Record(entry, change)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "BotStore.Get")
@@ -429,6 +508,10 @@ func (s *OpenTracingLayerBotStore) Get(userId string, includeDeleted bool) (*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -447,6 +530,10 @@ func (s *OpenTracingLayerBotStore) GetAll(options *model.BotGetOptions) ([]*mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -483,6 +570,10 @@ func (s *OpenTracingLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -501,6 +592,10 @@ func (s *OpenTracingLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -519,6 +614,10 @@ func (s *OpenTracingLayerChannelStore) AnalyticsDeletedTypeCount(teamId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -537,6 +636,10 @@ func (s *OpenTracingLayerChannelStore) AnalyticsTypeCount(teamId string, channel
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -555,6 +658,10 @@ func (s *OpenTracingLayerChannelStore) AutocompleteInTeam(teamId string, term st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -573,6 +680,10 @@ func (s *OpenTracingLayerChannelStore) AutocompleteInTeamForSearch(teamId string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -640,6 +751,10 @@ func (s *OpenTracingLayerChannelStore) CountPostsAfter(channelId string, timesta
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -658,6 +773,10 @@ func (s *OpenTracingLayerChannelStore) CreateDirectChannel(userId *model.User, o
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -694,6 +813,10 @@ func (s *OpenTracingLayerChannelStore) CreateSidebarCategory(userId string, team
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -766,6 +889,10 @@ func (s *OpenTracingLayerChannelStore) Get(id string, allowFromCache bool) (*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -784,6 +911,10 @@ func (s *OpenTracingLayerChannelStore) GetAll(teamId string) ([]*model.Channel,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -802,6 +933,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelMembersForUser(userId string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -820,6 +955,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -838,6 +977,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannels(page int, perPage int, opt
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -856,6 +999,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelsCount(opts store.ChannelSea
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -874,6 +1021,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelsForExportAfter(limit int, a
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -892,6 +1043,10 @@ func (s *OpenTracingLayerChannelStore) GetAllDirectChannelsForExportAfter(limit
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -910,6 +1065,10 @@ func (s *OpenTracingLayerChannelStore) GetByName(team_id string, name string, al
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -928,6 +1087,10 @@ func (s *OpenTracingLayerChannelStore) GetByNameIncludeDeleted(team_id string, n
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -946,6 +1109,32 @@ func (s *OpenTracingLayerChannelStore) GetByNames(team_id string, names []string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetChannel")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -964,6 +1153,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelCounts(teamId string, userId st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -982,6 +1175,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelMembersForExport(userId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1000,6 +1197,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelMembersTimezones(channelId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1018,6 +1219,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelUnread(channelId string, userId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1036,6 +1241,10 @@ func (s *OpenTracingLayerChannelStore) GetChannels(teamId string, userId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1054,6 +1263,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsBatchForIndexing(startTime int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1072,6 +1285,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsByIds(channelIds []string, inc
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1090,6 +1307,54 @@ func (s *OpenTracingLayerChannelStore) GetChannelsByScheme(schemeId string, offs
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetChannelsCtx")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetCtx")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1108,6 +1373,10 @@ func (s *OpenTracingLayerChannelStore) GetDeleted(team_id string, offset int, li
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1126,6 +1395,10 @@ func (s *OpenTracingLayerChannelStore) GetDeletedByName(team_id string, name str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1144,6 +1417,10 @@ func (s *OpenTracingLayerChannelStore) GetForPost(postId string) (*model.Channel
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1162,6 +1439,10 @@ func (s *OpenTracingLayerChannelStore) GetFromMaster(id string) (*model.Channel,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1180,6 +1461,10 @@ func (s *OpenTracingLayerChannelStore) GetGuestCount(channelId string, allowFrom
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1198,6 +1483,10 @@ func (s *OpenTracingLayerChannelStore) GetMember(channelId string, userId string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1216,6 +1505,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberCount(channelId string, allowFro
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1229,6 +1522,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberCountFromCache(channelId string)
 
 	defer span.Finish()
 	result := s.ChannelStore.GetMemberCountFromCache(channelId)
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -1247,6 +1544,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberCountsByGroup(channelID string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1265,6 +1566,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberForPost(postId string, userId st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1283,6 +1588,10 @@ func (s *OpenTracingLayerChannelStore) GetMembers(channelId string, offset int,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1301,6 +1610,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersByIds(channelId string, userIds
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1319,6 +1632,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersForUser(teamId string, userId s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1337,60 +1654,98 @@ func (s *OpenTracingLayerChannelStore) GetMembersForUserWithPagination(teamId st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
-func (s *OpenTracingLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+func (s *OpenTracingLayerChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetMoreChannels")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetMembersPaged")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, resultVar1, err
+}
+
+func (s *OpenTracingLayerChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetMembersSince")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.GetMembersSince(channelId, since)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
-func (s *OpenTracingLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+func (s *OpenTracingLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetPinnedPostCount")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetMoreChannels")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
-func (s *OpenTracingLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+func (s *OpenTracingLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetPinnedPosts")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetPinnedPostCount")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
 	defer span.Finish()
-	result, err := s.ChannelStore.GetPinnedPosts(channelId)
+	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1409,6 +1764,10 @@ func (s *OpenTracingLayerChannelStore) GetPrivateChannelsForTeam(teamId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1427,6 +1786,10 @@ func (s *OpenTracingLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1445,6 +1808,10 @@ func (s *OpenTracingLayerChannelStore) GetPublicChannelsForTeam(teamId string, o
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1463,6 +1830,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategories(userId string, teamI
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1481,6 +1852,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategory(categoryId string) (*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1499,6 +1874,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategoryOrder(userId string, te
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1517,6 +1896,32 @@ func (s *OpenTracingLayerChannelStore) GetTeamChannels(teamId string) (*model.Ch
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.GetUnreadCountsForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1535,6 +1940,10 @@ func (s *OpenTracingLayerChannelStore) GroupSyncedChannelCount() (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1657,6 +2066,10 @@ func (s *OpenTracingLayerChannelStore) IsUserInChannelUseCache(userId string, ch
 
 	defer span.Finish()
 	result := s.ChannelStore.IsUserInChannelUseCache(userId, channelId)
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -1675,6 +2088,10 @@ func (s *OpenTracingLayerChannelStore) MigrateChannelMembers(fromChannelId strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1873,6 +2290,10 @@ func (s *OpenTracingLayerChannelStore) Save(channel *model.Channel, maxChannelsP
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1891,6 +2312,10 @@ func (s *OpenTracingLayerChannelStore) SaveDirectChannel(channel *model.Channel,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1909,9 +2334,35 @@ func (s *OpenTracingLayerChannelStore) SaveMember(member *model.ChannelMember) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
+func (s *OpenTracingLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.SaveMemberMultiple")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, resultVar1, err
+}
+
 func (s *OpenTracingLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.SaveMultipleMembers")
@@ -1927,6 +2378,10 @@ func (s *OpenTracingLayerChannelStore) SaveMultipleMembers(members []*model.Chan
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1945,6 +2400,10 @@ func (s *OpenTracingLayerChannelStore) SearchAllChannels(term string, opts store
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, resultVar1, err
 }
 
@@ -1963,6 +2422,10 @@ func (s *OpenTracingLayerChannelStore) SearchArchivedInTeam(teamId string, term
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1981,6 +2444,10 @@ func (s *OpenTracingLayerChannelStore) SearchForUserInTeam(userId string, teamId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -1999,6 +2466,10 @@ func (s *OpenTracingLayerChannelStore) SearchGroupChannels(userId string, term s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2017,6 +2488,10 @@ func (s *OpenTracingLayerChannelStore) SearchInTeam(teamId string, term string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2035,6 +2510,10 @@ func (s *OpenTracingLayerChannelStore) SearchMore(userId string, teamId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2071,6 +2550,10 @@ func (s *OpenTracingLayerChannelStore) Update(channel *model.Channel) (*model.Ch
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2089,6 +2572,32 @@ func (s *OpenTracingLayerChannelStore) UpdateLastViewedAt(channelIds []string, u
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ChannelStore.UpdateLastViewedAtMulti")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2107,6 +2616,10 @@ func (s *OpenTracingLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2125,6 +2638,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMember(member *model.ChannelMember)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2161,6 +2678,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMultipleMembers(members []*model.Ch
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2179,6 +2700,10 @@ func (s *OpenTracingLayerChannelStore) UpdateSidebarCategories(userId string, te
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2251,6 +2776,10 @@ func (s *OpenTracingLayerChannelStore) UserBelongsToChannels(userId string, chan
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2269,6 +2798,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) GetUsersInChannelDuring(star
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2323,6 +2856,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2359,6 +2896,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Delete(discovery *model.ClusterD
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2377,6 +2918,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Exists(discovery *model.ClusterD
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2395,6 +2940,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) GetAll(discoveryType string, clu
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2449,6 +2998,10 @@ func (s *OpenTracingLayerCommandStore) AnalyticsCommandCount(teamId string) (int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2485,6 +3038,10 @@ func (s *OpenTracingLayerCommandStore) Get(id string) (*model.Command, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2503,6 +3060,10 @@ func (s *OpenTracingLayerCommandStore) GetByTeam(teamId string) ([]*model.Comman
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2521,6 +3082,10 @@ func (s *OpenTracingLayerCommandStore) GetByTrigger(teamId string, trigger strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2575,6 +3140,10 @@ func (s *OpenTracingLayerCommandStore) Save(webhook *model.Command) (*model.Comm
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2593,6 +3162,10 @@ func (s *OpenTracingLayerCommandStore) Update(hook *model.Command) (*model.Comma
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2624,6 +3197,10 @@ func (s *OpenTracingLayerCommandWebhookStore) Get(id string) (*model.CommandWebh
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2642,6 +3219,10 @@ func (s *OpenTracingLayerCommandWebhookStore) Save(webhook *model.CommandWebhook
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2678,6 +3259,10 @@ func (s *OpenTracingLayerComplianceStore) ComplianceExport(compliance *model.Com
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2696,6 +3281,10 @@ func (s *OpenTracingLayerComplianceStore) Get(id string) (*model.Compliance, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2714,6 +3303,10 @@ func (s *OpenTracingLayerComplianceStore) GetAll(offset int, limit int) (model.C
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2732,6 +3325,10 @@ func (s *OpenTracingLayerComplianceStore) MessageExport(after int64, limit int)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2750,6 +3347,10 @@ func (s *OpenTracingLayerComplianceStore) Save(compliance *model.Compliance) (*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2768,6 +3369,10 @@ func (s *OpenTracingLayerComplianceStore) Update(compliance *model.Compliance) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2804,6 +3409,10 @@ func (s *OpenTracingLayerEmojiStore) Get(id string, allowFromCache bool) (*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2822,6 +3431,10 @@ func (s *OpenTracingLayerEmojiStore) GetByName(name string, allowFromCache bool)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2840,6 +3453,10 @@ func (s *OpenTracingLayerEmojiStore) GetList(offset int, limit int, sort string)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2858,6 +3475,32 @@ func (s *OpenTracingLayerEmojiStore) GetMultipleByName(names []string) ([]*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "EmojiStore.GetUsageCounts")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2876,6 +3519,10 @@ func (s *OpenTracingLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2894,6 +3541,10 @@ func (s *OpenTracingLayerEmojiStore) Search(name string, prefixOnly bool, limit
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2943,6 +3594,10 @@ func (s *OpenTracingLayerFileInfoStore) DeleteForPost(postId string) (string, er
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2961,6 +3616,32 @@ func (s *OpenTracingLayerFileInfoStore) Get(id string) (*model.FileInfo, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "FileInfoStore.GetByIds")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.FileInfoStore.GetByIds(ids)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2979,6 +3660,10 @@ func (s *OpenTracingLayerFileInfoStore) GetByPath(path string) (*model.FileInfo,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -2997,6 +3682,10 @@ func (s *OpenTracingLayerFileInfoStore) GetForPost(postId string, readFromMaster
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3015,6 +3704,10 @@ func (s *OpenTracingLayerFileInfoStore) GetForUser(userId string) ([]*model.File
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3033,6 +3726,10 @@ func (s *OpenTracingLayerFileInfoStore) GetWithOptions(page int, perPage int, op
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3082,6 +3779,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limi
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3100,6 +3801,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDeleteByUser(userId string) (in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3118,6 +3823,32 @@ func (s *OpenTracingLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileI
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "FileInfoStore.Search")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3154,6 +3885,10 @@ func (s *OpenTracingLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.Fil
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3172,6 +3907,10 @@ func (s *OpenTracingLayerGroupStore) AdminRoleGroupsForSyncableMember(userID str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3190,6 +3929,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersMinusGroupMembers(channelID s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3208,6 +3951,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersToAdd(since int64, channelID
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3226,6 +3973,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersToRemove(channelID *string) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3244,6 +3995,10 @@ func (s *OpenTracingLayerGroupStore) CountChannelMembersMinusGroupMembers(channe
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3262,6 +4017,10 @@ func (s *OpenTracingLayerGroupStore) CountGroupsByChannel(channelId string, opts
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3280,6 +4039,10 @@ func (s *OpenTracingLayerGroupStore) CountGroupsByTeam(teamId string, opts model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3298,6 +4061,10 @@ func (s *OpenTracingLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3316,6 +4083,10 @@ func (s *OpenTracingLayerGroupStore) Create(group *model.Group) (*model.Group, *
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3334,6 +4105,10 @@ func (s *OpenTracingLayerGroupStore) CreateGroupSyncable(groupSyncable *model.Gr
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3352,6 +4127,10 @@ func (s *OpenTracingLayerGroupStore) Delete(groupID string) (*model.Group, *mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3370,6 +4149,10 @@ func (s *OpenTracingLayerGroupStore) DeleteGroupSyncable(groupID string, syncabl
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3388,6 +4171,10 @@ func (s *OpenTracingLayerGroupStore) DeleteMember(groupID string, userID string)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3406,6 +4193,10 @@ func (s *OpenTracingLayerGroupStore) DistinctGroupMemberCount() (int64, *model.A
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3424,6 +4215,10 @@ func (s *OpenTracingLayerGroupStore) Get(groupID string) (*model.Group, *model.A
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3442,6 +4237,10 @@ func (s *OpenTracingLayerGroupStore) GetAllBySource(groupSource model.GroupSourc
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3460,6 +4259,10 @@ func (s *OpenTracingLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3478,6 +4281,10 @@ func (s *OpenTracingLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3496,6 +4303,10 @@ func (s *OpenTracingLayerGroupStore) GetByName(name string, opts model.GroupSear
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3514,6 +4325,10 @@ func (s *OpenTracingLayerGroupStore) GetByRemoteID(remoteID string, groupSource
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3532,6 +4347,10 @@ func (s *OpenTracingLayerGroupStore) GetByUser(userId string) ([]*model.Group, *
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3550,6 +4369,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupSyncable(groupID string, syncableID
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3568,6 +4391,10 @@ func (s *OpenTracingLayerGroupStore) GetGroups(page int, perPage int, opts model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3586,6 +4413,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3604,6 +4435,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsByChannel(channelId string, opts m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3622,6 +4457,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsByTeam(teamId string, opts model.G
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3640,6 +4479,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberCount(groupID string) (int64, *mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3658,6 +4501,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsers(groupID string) ([]*model.Us
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3676,6 +4523,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3694,6 +4545,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersNotInChannel(groupID string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3712,6 +4567,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersPage(groupID string, page int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3730,6 +4589,10 @@ func (s *OpenTracingLayerGroupStore) GroupChannelCount() (int64, *model.AppError
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3748,6 +4611,10 @@ func (s *OpenTracingLayerGroupStore) GroupCount() (int64, *model.AppError) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3766,7 +4633,11 @@ func (s *OpenTracingLayerGroupStore) GroupCountWithAllowReference() (int64, *mod
 		ext.Error.Set(span, true)
 	}
 
-	return result, err
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
 }
 
 func (s *OpenTracingLayerGroupStore) GroupMemberCount() (int64, *model.AppError) {
@@ -3784,6 +4655,10 @@ func (s *OpenTracingLayerGroupStore) GroupMemberCount() (int64, *model.AppError)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3802,6 +4677,10 @@ func (s *OpenTracingLayerGroupStore) GroupTeamCount() (int64, *model.AppError) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3838,6 +4717,10 @@ func (s *OpenTracingLayerGroupStore) PermittedSyncableAdmins(syncableID string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3856,6 +4739,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersMinusGroupMembers(teamID string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3874,6 +4761,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersToAdd(since int64, teamID *strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3892,6 +4783,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3910,6 +4805,10 @@ func (s *OpenTracingLayerGroupStore) Update(group *model.Group) (*model.Group, *
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3928,6 +4827,10 @@ func (s *OpenTracingLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.Gr
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3946,6 +4849,32 @@ func (s *OpenTracingLayerGroupStore) UpsertMember(groupID string, userID string)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "JobStore.ClaimPending")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.JobStore.ClaimPending(jobType, nodeId)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3964,6 +4893,10 @@ func (s *OpenTracingLayerJobStore) Delete(id string) (string, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -3982,6 +4915,10 @@ func (s *OpenTracingLayerJobStore) Get(id string) (*model.Job, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4000,6 +4937,10 @@ func (s *OpenTracingLayerJobStore) GetAllByStatus(status string) ([]*model.Job,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4018,6 +4959,10 @@ func (s *OpenTracingLayerJobStore) GetAllByType(jobType string) ([]*model.Job, e
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4036,6 +4981,10 @@ func (s *OpenTracingLayerJobStore) GetAllByTypePage(jobType string, offset int,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4054,6 +5003,10 @@ func (s *OpenTracingLayerJobStore) GetAllPage(offset int, limit int) ([]*model.J
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4072,6 +5025,10 @@ func (s *OpenTracingLayerJobStore) GetCountByStatusAndType(status string, jobTyp
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4090,6 +5047,10 @@ func (s *OpenTracingLayerJobStore) GetNewestJobByStatusAndType(status string, jo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4108,6 +5069,10 @@ func (s *OpenTracingLayerJobStore) GetNewestJobByStatusesAndType(statuses []stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4126,6 +5091,10 @@ func (s *OpenTracingLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4144,6 +5113,10 @@ func (s *OpenTracingLayerJobStore) UpdateOptimistically(job *model.Job, currentS
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4162,6 +5135,10 @@ func (s *OpenTracingLayerJobStore) UpdateStatus(id string, status string) (*mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4180,6 +5157,10 @@ func (s *OpenTracingLayerJobStore) UpdateStatusOptimistically(id string, current
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4198,6 +5179,10 @@ func (s *OpenTracingLayerLicenseStore) Get(id string) (*model.LicenseRecord, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4216,6 +5201,10 @@ func (s *OpenTracingLayerLicenseStore) Save(license *model.LicenseRecord) (*mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4234,6 +5223,10 @@ func (s *OpenTracingLayerLinkMetadataStore) Get(url string, timestamp int64) (*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4252,6 +5245,10 @@ func (s *OpenTracingLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadat
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4288,6 +5285,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessData(token string) (*model.AccessD
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4306,6 +5307,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessDataByRefreshToken(token string) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4324,6 +5329,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessDataByUserForApp(userId string, cl
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4342,6 +5351,10 @@ func (s *OpenTracingLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4360,6 +5373,10 @@ func (s *OpenTracingLayerOAuthStore) GetAppByUser(userId string, offset int, lim
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4378,6 +5395,10 @@ func (s *OpenTracingLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OA
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4396,6 +5417,10 @@ func (s *OpenTracingLayerOAuthStore) GetAuthData(code string) (*model.AuthData,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4414,6 +5439,10 @@ func (s *OpenTracingLayerOAuthStore) GetAuthorizedApps(userId string, offset int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4432,6 +5461,10 @@ func (s *OpenTracingLayerOAuthStore) GetPreviousAccessData(userId string, client
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4522,6 +5555,10 @@ func (s *OpenTracingLayerOAuthStore) SaveAccessData(accessData *model.AccessData
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4540,6 +5577,10 @@ func (s *OpenTracingLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthA
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4558,6 +5599,10 @@ func (s *OpenTracingLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*mo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4576,6 +5621,10 @@ func (s *OpenTracingLayerOAuthStore) UpdateAccessData(accessData *model.AccessDa
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4594,6 +5643,10 @@ func (s *OpenTracingLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAut
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4612,6 +5665,10 @@ func (s *OpenTracingLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyVa
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4630,6 +5687,10 @@ func (s *OpenTracingLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4702,6 +5763,10 @@ func (s *OpenTracingLayerPluginStore) Get(pluginId string, key string) (*model.P
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4720,6 +5785,10 @@ func (s *OpenTracingLayerPluginStore) List(pluginId string, page int, perPage in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4738,6 +5807,10 @@ func (s *OpenTracingLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4756,6 +5829,32 @@ func (s *OpenTracingLayerPluginStore) SetWithOptions(pluginId string, key string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.AnalyticsCountByDay")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4774,6 +5873,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFi
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4792,6 +5895,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsPostCountsByDay(options *model.Anal
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4810,6 +5917,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4859,6 +5970,10 @@ func (s *OpenTracingLayerPostStore) Get(id string, skipFetchThreads bool) (*mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4877,6 +5992,10 @@ func (s *OpenTracingLayerPostStore) GetDirectPostParentsForExportAfter(limit int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4890,6 +6009,10 @@ func (s *OpenTracingLayerPostStore) GetEtag(channelId string, allowFromCache boo
 
 	defer span.Finish()
 	result := s.PostStore.GetEtag(channelId, allowFromCache)
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -4908,6 +6031,10 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPosts(userId string, offset int, l
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4926,6 +6053,10 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPostsForChannel(userId string, cha
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4952,6 +6083,10 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -4965,6 +6100,10 @@ func (s *OpenTracingLayerPostStore) GetMaxPostSize() int {
 
 	defer span.Finish()
 	result := s.PostStore.GetMaxPostSize()
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -4983,6 +6122,10 @@ func (s *OpenTracingLayerPostStore) GetOldest() (*model.Post, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5001,6 +6144,10 @@ func (s *OpenTracingLayerPostStore) GetOldestEntityCreationTime() (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5019,6 +6166,54 @@ func (s *OpenTracingLayerPostStore) GetParentsForExportAfter(limit int, afterId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPinnedPosts")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPinnedPosts(channelId)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPost")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPost(id, includeDeleted)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5037,6 +6232,10 @@ func (s *OpenTracingLayerPostStore) GetPostAfterTime(channelId string, time int6
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5055,6 +6254,10 @@ func (s *OpenTracingLayerPostStore) GetPostIdAfterTime(channelId string, time in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5073,6 +6276,32 @@ func (s *OpenTracingLayerPostStore) GetPostIdBeforeTime(channelId string, time i
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostWithContext")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPostWithContext(postId, before, after)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5091,6 +6320,10 @@ func (s *OpenTracingLayerPostStore) GetPosts(options model.GetPostsOptions, allo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5109,9 +6342,35 @@ func (s *OpenTracingLayerPostStore) GetPostsAfter(options model.GetPostsOptions)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
+func (s *OpenTracingLayerPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsAfterCursor")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, resultVar1, err
+}
+
 func (s *OpenTracingLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsBatchForIndexing")
@@ -5127,9 +6386,31 @@ func (s *OpenTracingLayerPostStore) GetPostsBatchForIndexing(startTime int64, en
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
+func (s *OpenTracingLayerPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsBatched")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsBefore")
@@ -5145,9 +6426,35 @@ func (s *OpenTracingLayerPostStore) GetPostsBefore(options model.GetPostsOptions
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
+func (s *OpenTracingLayerPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsBeforeCursor")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, resultVar1, err
+}
+
 func (s *OpenTracingLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsByIds")
@@ -5163,6 +6470,32 @@ func (s *OpenTracingLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Po
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsByProp")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPostsByProp(channelId, key, value)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5181,6 +6514,32 @@ func (s *OpenTracingLayerPostStore) GetPostsCreatedAt(channelId string, time int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsCtx")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5199,9 +6558,57 @@ func (s *OpenTracingLayerPostStore) GetPostsSince(options model.GetPostsSinceOpt
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetPostsSinceCtx")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
+func (s *OpenTracingLayerPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetRecentPostsForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, resultVar1, err
+}
+
 func (s *OpenTracingLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetRepliesForExport")
@@ -5217,6 +6624,10 @@ func (s *OpenTracingLayerPostStore) GetRepliesForExport(parentId string) ([]*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5235,6 +6646,32 @@ func (s *OpenTracingLayerPostStore) GetSingle(id string) (*model.Post, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetSingleCtx")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.GetSingleCtx(ctx, id)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5251,6 +6688,28 @@ func (s *OpenTracingLayerPostStore) InvalidateLastPostTimeCache(channelId string
 
 }
 
+func (s *OpenTracingLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.MoveThread")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.Overwrite")
@@ -5266,6 +6725,10 @@ func (s *OpenTracingLayerPostStore) Overwrite(post *model.Post) (*model.Post, er
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5284,6 +6747,10 @@ func (s *OpenTracingLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, resultVar1, err
 }
 
@@ -5302,6 +6769,10 @@ func (s *OpenTracingLayerPostStore) PermanentDeleteBatch(endTime int64, limit in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5356,6 +6827,10 @@ func (s *OpenTracingLayerPostStore) Save(post *model.Post) (*model.Post, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5374,6 +6849,10 @@ func (s *OpenTracingLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, resultVar1, err
 }
 
@@ -5392,6 +6871,32 @@ func (s *OpenTracingLayerPostStore) Search(teamId string, userId string, params
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.SearchAllTeams")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5410,6 +6915,54 @@ func (s *OpenTracingLayerPostStore) SearchPostsInTeamForUser(paramsList []*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.SearchPostsInTeamForUserAfter")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.SuggestTerms")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5428,6 +6981,10 @@ func (s *OpenTracingLayerPostStore) Update(newPost *model.Post, oldPost *model.P
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5446,6 +7003,10 @@ func (s *OpenTracingLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5518,6 +7079,10 @@ func (s *OpenTracingLayerPreferenceStore) Get(userId string, category string, na
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5536,6 +7101,10 @@ func (s *OpenTracingLayerPreferenceStore) GetAll(userId string) (model.Preferenc
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5554,6 +7123,10 @@ func (s *OpenTracingLayerPreferenceStore) GetCategory(userId string, category st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5593,6 +7166,28 @@ func (s *OpenTracingLayerPreferenceStore) Save(preferences *model.Preferences) e
 	return err
 }
 
+func (s *OpenTracingLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PreferenceStore.SaveMultiple")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerProductNoticesStore) Clear(notices []string) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ProductNoticesStore.Clear")
@@ -5644,6 +7239,10 @@ func (s *OpenTracingLayerProductNoticesStore) GetViews(userId string) ([]model.P
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5680,6 +7279,10 @@ func (s *OpenTracingLayerReactionStore) BulkGetForPosts(postIds []string) ([]*mo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5698,6 +7301,10 @@ func (s *OpenTracingLayerReactionStore) Delete(reaction *model.Reaction) (*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5734,6 +7341,32 @@ func (s *OpenTracingLayerReactionStore) GetForPost(postId string, allowFromCache
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReactionStore.GetForPosts")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.ReactionStore.GetForPosts(postIds)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5752,6 +7385,10 @@ func (s *OpenTracingLayerReactionStore) PermanentDeleteBatch(endTime int64, limi
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5770,6 +7407,10 @@ func (s *OpenTracingLayerReactionStore) Save(reaction *model.Reaction) (*model.R
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5788,6 +7429,10 @@ func (s *OpenTracingLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, erro
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5806,6 +7451,10 @@ func (s *OpenTracingLayerRoleStore) ChannelHigherScopedPermissions(roleNames []s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5824,6 +7473,10 @@ func (s *OpenTracingLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5842,6 +7495,10 @@ func (s *OpenTracingLayerRoleStore) Delete(roleId string) (*model.Role, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5860,6 +7517,10 @@ func (s *OpenTracingLayerRoleStore) Get(roleId string) (*model.Role, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5878,6 +7539,10 @@ func (s *OpenTracingLayerRoleStore) GetAll() ([]*model.Role, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5896,6 +7561,10 @@ func (s *OpenTracingLayerRoleStore) GetByName(name string) (*model.Role, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5914,6 +7583,10 @@ func (s *OpenTracingLayerRoleStore) GetByNames(names []string) ([]*model.Role, e
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5950,6 +7623,10 @@ func (s *OpenTracingLayerRoleStore) Save(role *model.Role) (*model.Role, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5968,6 +7645,10 @@ func (s *OpenTracingLayerSchemeStore) CountByScope(scope string) (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -5986,6 +7667,10 @@ func (s *OpenTracingLayerSchemeStore) CountWithoutPermission(scope string, permi
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6004,6 +7689,10 @@ func (s *OpenTracingLayerSchemeStore) Delete(schemeId string) (*model.Scheme, er
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6022,6 +7711,10 @@ func (s *OpenTracingLayerSchemeStore) Get(schemeId string) (*model.Scheme, error
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6040,6 +7733,10 @@ func (s *OpenTracingLayerSchemeStore) GetAllPage(scope string, offset int, limit
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6058,6 +7755,10 @@ func (s *OpenTracingLayerSchemeStore) GetByName(schemeName string) (*model.Schem
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6094,6 +7795,10 @@ func (s *OpenTracingLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6112,6 +7817,10 @@ func (s *OpenTracingLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6143,6 +7852,10 @@ func (s *OpenTracingLayerSessionStore) Get(sessionIdOrToken string) (*model.Sess
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6161,6 +7874,10 @@ func (s *OpenTracingLayerSessionStore) GetSessions(userId string) ([]*model.Sess
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6179,6 +7896,10 @@ func (s *OpenTracingLayerSessionStore) GetSessionsExpired(thresholdMillis int64,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6197,6 +7918,10 @@ func (s *OpenTracingLayerSessionStore) GetSessionsWithActiveDeviceIds(userId str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6269,6 +7994,10 @@ func (s *OpenTracingLayerSessionStore) Save(session *model.Session) (*model.Sess
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6287,6 +8016,10 @@ func (s *OpenTracingLayerSessionStore) UpdateDeviceId(id string, deviceId string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6377,6 +8110,10 @@ func (s *OpenTracingLayerSessionStore) UpdateRoles(userId string, roles string)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6395,6 +8132,10 @@ func (s *OpenTracingLayerStatusStore) Get(userId string) (*model.Status, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6413,6 +8154,10 @@ func (s *OpenTracingLayerStatusStore) GetByIds(userIds []string) ([]*model.Statu
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6431,6 +8176,10 @@ func (s *OpenTracingLayerStatusStore) GetTotalActiveUsersCount() (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6503,6 +8252,10 @@ func (s *OpenTracingLayerSystemStore) Get() (model.StringMap, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6521,6 +8274,10 @@ func (s *OpenTracingLayerSystemStore) GetByName(name string) (*model.System, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6539,6 +8296,10 @@ func (s *OpenTracingLayerSystemStore) InsertIfExists(system *model.System) (*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6557,6 +8318,10 @@ func (s *OpenTracingLayerSystemStore) PermanentDeleteByName(name string) (*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6647,6 +8412,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6665,6 +8434,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6683,6 +8456,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsPublicTeamCount() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6701,6 +8478,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsTeamCount(includeDeleted bool) (int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6750,6 +8531,10 @@ func (s *OpenTracingLayerTeamStore) Get(id string) (*model.Team, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6768,6 +8553,10 @@ func (s *OpenTracingLayerTeamStore) GetActiveMemberCount(teamId string, restrict
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6786,6 +8575,10 @@ func (s *OpenTracingLayerTeamStore) GetAll() ([]*model.Team, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6804,6 +8597,10 @@ func (s *OpenTracingLayerTeamStore) GetAllForExportAfter(limit int, afterId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6822,6 +8619,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6840,6 +8641,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, e
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6858,6 +8663,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPrivateTeamPageListing(offset int, lim
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6876,6 +8685,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPublicTeamPageListing(offset int, limi
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6894,6 +8707,10 @@ func (s *OpenTracingLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6912,6 +8729,10 @@ func (s *OpenTracingLayerTeamStore) GetAllTeamPageListing(offset int, limit int)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6930,6 +8751,10 @@ func (s *OpenTracingLayerTeamStore) GetByInviteId(inviteId string) (*model.Team,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6948,6 +8773,10 @@ func (s *OpenTracingLayerTeamStore) GetByName(name string) (*model.Team, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6966,6 +8795,10 @@ func (s *OpenTracingLayerTeamStore) GetByNames(name []string) ([]*model.Team, er
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -6984,6 +8817,10 @@ func (s *OpenTracingLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7002,6 +8839,10 @@ func (s *OpenTracingLayerTeamStore) GetChannelUnreadsForTeam(teamId string, user
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7020,6 +8861,10 @@ func (s *OpenTracingLayerTeamStore) GetMember(teamId string, userId string) (*mo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7038,6 +8883,10 @@ func (s *OpenTracingLayerTeamStore) GetMembers(teamId string, offset int, limit
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7056,6 +8905,10 @@ func (s *OpenTracingLayerTeamStore) GetMembersByIds(teamId string, userIds []str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7074,6 +8927,32 @@ func (s *OpenTracingLayerTeamStore) GetTeamMembersForExport(userId string) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "TeamStore.GetTeamStats")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7092,6 +8971,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsByScheme(schemeId string, offset int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7110,6 +8993,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Te
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7128,6 +9015,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsForUser(ctx context.Context, userId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7146,6 +9037,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsForUserWithPagination(userId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7164,6 +9059,10 @@ func (s *OpenTracingLayerTeamStore) GetTotalMemberCount(teamId string, restricti
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7182,6 +9081,10 @@ func (s *OpenTracingLayerTeamStore) GetUserTeamIds(userId string, allowFromCache
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7200,6 +9103,10 @@ func (s *OpenTracingLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7231,6 +9138,10 @@ func (s *OpenTracingLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUs
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7357,6 +9268,10 @@ func (s *OpenTracingLayerTeamStore) Save(team *model.Team) (*model.Team, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7375,6 +9290,10 @@ func (s *OpenTracingLayerTeamStore) SaveMember(member *model.TeamMember, maxUser
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7393,6 +9312,10 @@ func (s *OpenTracingLayerTeamStore) SaveMultipleMembers(members []*model.TeamMem
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7411,6 +9334,10 @@ func (s *OpenTracingLayerTeamStore) SearchAll(term string, opts *model.TeamSearc
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7429,6 +9356,10 @@ func (s *OpenTracingLayerTeamStore) SearchAllPaged(term string, opts *model.Team
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, resultVar1, err
 }
 
@@ -7447,6 +9378,10 @@ func (s *OpenTracingLayerTeamStore) SearchOpen(term string) ([]*model.Team, erro
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7465,6 +9400,10 @@ func (s *OpenTracingLayerTeamStore) SearchPrivate(term string) ([]*model.Team, e
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7483,6 +9422,10 @@ func (s *OpenTracingLayerTeamStore) Update(team *model.Team) (*model.Team, error
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7519,6 +9462,10 @@ func (s *OpenTracingLayerTeamStore) UpdateMember(member *model.TeamMember) (*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7555,6 +9502,10 @@ func (s *OpenTracingLayerTeamStore) UpdateMultipleMembers(members []*model.TeamM
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7573,6 +9524,10 @@ func (s *OpenTracingLayerTeamStore) UserBelongsToTeams(userId string, teamIds []
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7591,6 +9546,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) Get(id string, allowFromCache bool
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7609,6 +9568,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7627,6 +9590,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) Save(termsOfService *model.TermsOf
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7645,6 +9612,10 @@ func (s *OpenTracingLayerThreadStore) CollectThreadsWithNewerReplies(userId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7717,6 +9688,10 @@ func (s *OpenTracingLayerThreadStore) Get(id string) (*model.Thread, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7735,6 +9710,10 @@ func (s *OpenTracingLayerThreadStore) GetMembershipForUser(userId string, postId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7753,10 +9732,14 @@ func (s *OpenTracingLayerThreadStore) GetMembershipsForUser(userId string) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
-func (s *OpenTracingLayerThreadStore) GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+func (s *OpenTracingLayerThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ThreadStore.GetThreadsForUser")
 	s.Root.Store.SetContext(newCtx)
@@ -7765,12 +9748,16 @@ func (s *OpenTracingLayerThreadStore) GetThreadsForUser(userId string, opts mode
 	}()
 
 	defer span.Finish()
-	result, err := s.ThreadStore.GetThreadsForUser(userId, opts)
+	result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
 	if err != nil {
 		span.LogFields(spanlog.Error(err))
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7825,6 +9812,10 @@ func (s *OpenTracingLayerThreadStore) Save(thread *model.Thread) (*model.Thread,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7843,6 +9834,10 @@ func (s *OpenTracingLayerThreadStore) SaveMembership(membership *model.ThreadMem
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7861,6 +9856,10 @@ func (s *OpenTracingLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, resultVar1, err
 }
 
@@ -7879,6 +9878,10 @@ func (s *OpenTracingLayerThreadStore) Update(thread *model.Thread) (*model.Threa
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7897,6 +9900,10 @@ func (s *OpenTracingLayerThreadStore) UpdateMembership(membership *model.ThreadM
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -7964,6 +9971,10 @@ func (s *OpenTracingLayerTokenStore) GetByToken(token string) (*model.Token, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8036,6 +10047,10 @@ func (s *OpenTracingLayerUploadSessionStore) Get(id string) (*model.UploadSessio
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8054,6 +10069,10 @@ func (s *OpenTracingLayerUploadSessionStore) GetForUser(userId string) ([]*model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8072,6 +10091,10 @@ func (s *OpenTracingLayerUploadSessionStore) Save(session *model.UploadSession)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8108,6 +10131,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsActiveCount(time int64, options mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8126,6 +10153,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsActiveCountForPeriod(startTime int6
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8144,6 +10175,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetExternalUsers(hostDomain string)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8162,6 +10197,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8180,6 +10219,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, err
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8198,6 +10241,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8216,6 +10263,10 @@ func (s *OpenTracingLayerUserStore) AutocompleteUsersInChannel(teamId string, ch
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8265,6 +10316,10 @@ func (s *OpenTracingLayerUserStore) Count(options model.UserCountOptions) (int64
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8283,6 +10338,10 @@ func (s *OpenTracingLayerUserStore) DeactivateGuests() ([]string, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8319,6 +10378,10 @@ func (s *OpenTracingLayerUserStore) Get(id string) (*model.User, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8337,6 +10400,10 @@ func (s *OpenTracingLayerUserStore) GetAll() ([]*model.User, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8355,6 +10422,10 @@ func (s *OpenTracingLayerUserStore) GetAllAfter(limit int, afterId string) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8373,6 +10444,10 @@ func (s *OpenTracingLayerUserStore) GetAllNotInAuthService(authServices []string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8391,6 +10466,10 @@ func (s *OpenTracingLayerUserStore) GetAllProfiles(options *model.UserGetOptions
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8409,6 +10488,10 @@ func (s *OpenTracingLayerUserStore) GetAllProfilesInChannel(channelId string, al
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8427,6 +10510,10 @@ func (s *OpenTracingLayerUserStore) GetAllUsingAuthService(authService string) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8445,6 +10532,10 @@ func (s *OpenTracingLayerUserStore) GetAnyUnreadPostCountForChannel(userId strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8463,6 +10554,10 @@ func (s *OpenTracingLayerUserStore) GetByAuth(authData *string, authService stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8481,6 +10576,10 @@ func (s *OpenTracingLayerUserStore) GetByEmail(email string) (*model.User, error
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8499,6 +10598,10 @@ func (s *OpenTracingLayerUserStore) GetByUsername(username string) (*model.User,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8517,6 +10620,10 @@ func (s *OpenTracingLayerUserStore) GetChannelGroupUsers(channelID string) ([]*m
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8530,6 +10637,10 @@ func (s *OpenTracingLayerUserStore) GetEtagForAllProfiles() string {
 
 	defer span.Finish()
 	result := s.UserStore.GetEtagForAllProfiles()
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -8543,6 +10654,10 @@ func (s *OpenTracingLayerUserStore) GetEtagForProfiles(teamId string) string {
 
 	defer span.Finish()
 	result := s.UserStore.GetEtagForProfiles(teamId)
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -8556,6 +10671,10 @@ func (s *OpenTracingLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) s
 
 	defer span.Finish()
 	result := s.UserStore.GetEtagForProfilesNotInTeam(teamId)
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result
 }
 
@@ -8574,6 +10693,10 @@ func (s *OpenTracingLayerUserStore) GetForLogin(loginId string, allowSignInWithU
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8592,6 +10715,10 @@ func (s *OpenTracingLayerUserStore) GetKnownUsers(userID string) ([]string, erro
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8610,6 +10737,10 @@ func (s *OpenTracingLayerUserStore) GetNewUsersForTeam(teamId string, offset int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8628,6 +10759,10 @@ func (s *OpenTracingLayerUserStore) GetProfileByGroupChannelIdsForUser(userId st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8646,6 +10781,10 @@ func (s *OpenTracingLayerUserStore) GetProfileByIds(userIds []string, options *s
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8664,6 +10803,10 @@ func (s *OpenTracingLayerUserStore) GetProfiles(options *model.UserGetOptions) (
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8682,6 +10825,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesByUsernames(usernames []string, v
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8700,6 +10847,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesInChannel(options *model.UserGetO
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8718,6 +10869,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesInChannelByStatus(options *model.
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8736,6 +10891,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesNotInChannel(teamId string, chann
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8754,6 +10913,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesNotInTeam(teamId string, groupCon
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8772,6 +10935,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesWithoutTeam(options *model.UserGe
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8790,6 +10957,10 @@ func (s *OpenTracingLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string,
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8808,6 +10979,10 @@ func (s *OpenTracingLayerUserStore) GetSystemAdminProfiles() (map[string]*model.
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8826,6 +11001,10 @@ func (s *OpenTracingLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.U
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8844,6 +11023,10 @@ func (s *OpenTracingLayerUserStore) GetUnreadCount(userId string) (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8862,6 +11045,32 @@ func (s *OpenTracingLayerUserStore) GetUnreadCountForChannel(userId string, chan
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.GetUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	result, err := s.UserStore.GetUser(id, includeDeleted)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8880,6 +11089,10 @@ func (s *OpenTracingLayerUserStore) GetUsersBatchForIndexing(startTime int64, en
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -8898,6 +11111,10 @@ func (s *OpenTracingLayerUserStore) InferSystemInstallDate() (int64, error) {
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9009,6 +11226,10 @@ func (s *OpenTracingLayerUserStore) Save(user *model.User) (*model.User, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9027,6 +11248,10 @@ func (s *OpenTracingLayerUserStore) Search(teamId string, term string, options *
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9045,6 +11270,10 @@ func (s *OpenTracingLayerUserStore) SearchInChannel(channelId string, term strin
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9063,6 +11292,10 @@ func (s *OpenTracingLayerUserStore) SearchInGroup(groupID string, term string, o
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9081,6 +11314,10 @@ func (s *OpenTracingLayerUserStore) SearchNotInChannel(teamId string, channelId
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9099,6 +11336,10 @@ func (s *OpenTracingLayerUserStore) SearchNotInTeam(notInTeamId string, term str
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9117,6 +11358,10 @@ func (s *OpenTracingLayerUserStore) SearchWithoutTeam(term string, options *mode
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9135,6 +11380,10 @@ func (s *OpenTracingLayerUserStore) Update(user *model.User, allowRoleUpdate boo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9153,6 +11402,10 @@ func (s *OpenTracingLayerUserStore) UpdateAuthData(userId string, service string
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9246,6 +11499,24 @@ func (s *OpenTracingLayerUserStore) UpdatePassword(userId string, newPassword st
 	return err
 }
 
+func (s *OpenTracingLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.UpdatePasswordHash")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.Finish()
+	err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+	if err != nil {
+		span.LogFields(spanlog.Error(err))
+		ext.Error.Set(span, true)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.UpdateUpdateAt")
@@ -9261,6 +11532,10 @@ func (s *OpenTracingLayerUserStore) UpdateUpdateAt(userId string) (int64, error)
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9279,6 +11554,10 @@ func (s *OpenTracingLayerUserStore) VerifyEmail(userId string, email string) (st
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9333,6 +11612,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Get(tokenId string) (*model.UserA
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9351,6 +11634,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9369,6 +11656,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetByToken(tokenString string) (*
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9387,6 +11678,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetByUser(userId string, page int
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9405,6 +11700,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Save(token *model.UserAccessToken
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9423,6 +11722,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Search(term string) ([]*model.Use
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9495,6 +11798,10 @@ func (s *OpenTracingLayerUserTermsOfServiceStore) GetByUser(userId string) (*mod
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9513,6 +11820,10 @@ func (s *OpenTracingLayerUserTermsOfServiceStore) Save(userTermsOfService *model
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9531,6 +11842,10 @@ func (s *OpenTracingLayerWebhookStore) AnalyticsIncomingCount(teamId string) (in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9549,6 +11864,10 @@ func (s *OpenTracingLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (in
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9616,6 +11935,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncoming(id string, allowFromCache boo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9634,6 +11957,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByChannel(channelId string) ([
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9652,6 +11979,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByTeam(teamId string, offset i
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9670,6 +12001,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByTeamByUser(teamId string, us
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9688,6 +12023,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingList(offset int, limit int) ([
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9706,6 +12045,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingListByUser(userId string, offs
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9724,6 +12067,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWe
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9742,6 +12089,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByChannel(channelId string, of
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9760,6 +12111,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByChannelByUser(channelId stri
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9778,6 +12133,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByTeam(teamId string, offset i
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9796,6 +12155,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, us
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9814,6 +12177,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingList(offset int, limit int) ([
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9832,6 +12199,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingListByUser(userId string, offs
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9935,6 +12306,10 @@ func (s *OpenTracingLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebho
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9953,6 +12328,10 @@ func (s *OpenTracingLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebho
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9971,6 +12350,10 @@ func (s *OpenTracingLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWeb
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9989,6 +12372,10 @@ func (s *OpenTracingLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhoo
 		ext.Error.Set(span, true)
 	}
 
+	if count, ok := resultCount(result); ok {
+		span.SetTag("db.rows", count)
+	}
+
 	return result, err
 }
 
@@ -9996,6 +12383,10 @@ func (s *OpenTracingLayer) Close() {
 	s.Store.Close()
 }
 
+func (s *OpenTracingLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
 func (s *OpenTracingLayer) DropAllTables() {
 	s.Store.DropAllTables()
 }
@@ -10012,6 +12403,10 @@ func (s *OpenTracingLayer) MarkSystemRanUnitTests() {
 	s.Store.MarkSystemRanUnitTests()
 }
 
+func (s *OpenTracingLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
 func (s *OpenTracingLayer) SetContext(context context.Context) {
 	s.Store.SetContext(context)
 }
@@ -10038,6 +12433,7 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	}
 
 	newStore.AuditStore = &OpenTracingLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &OpenTracingLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
 	newStore.BotStore = &OpenTracingLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &OpenTracingLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &OpenTracingLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package readonlylayer rejects the changes made to the store while it's in read-only mode,
+// letting the reads through. The changes fail with a store.ErrReadOnly error, or an AppError for
+// the methods still returning one, before reaching the database, so that maintenance such as
+// schema migrations and backups can run while the server stays up.
+package readonlylayer
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// SetReadOnly turns read-only mode on or off. The changes already under way complete.
+func (s *ReadOnlyLayer) SetReadOnly(readOnly bool) {
+	var value int32
+	if readOnly {
+		value = 1
+	}
+	atomic.StoreInt32(&s.readOnly, value)
+}
+
+// IsReadOnly returns true when the store rejects the changes.
+func (s *ReadOnlyLayer) IsReadOnly() bool {
+	return atomic.LoadInt32(&s.readOnly) != 0
+}
+
+func newAppError(where string) *model.AppError {
+	return model.NewAppError(where, "store.read_only_layer.rejected.app_error", nil, "", http.StatusServiceUnavailable)
+}
@@ -0,0 +1,2453 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package readonlylayer
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type ReadOnlyLayer struct {
+	store.Store
+	readOnly                  int32
+	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
+	BotStore                  store.BotStore
+	ChannelStore              store.ChannelStore
+	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore     store.ClusterDiscoveryStore
+	CommandStore              store.CommandStore
+	CommandWebhookStore       store.CommandWebhookStore
+	ComplianceStore           store.ComplianceStore
+	EmojiStore                store.EmojiStore
+	FileInfoStore             store.FileInfoStore
+	GroupStore                store.GroupStore
+	JobStore                  store.JobStore
+	LicenseStore              store.LicenseStore
+	LinkMetadataStore         store.LinkMetadataStore
+	OAuthStore                store.OAuthStore
+	PluginStore               store.PluginStore
+	PostStore                 store.PostStore
+	PreferenceStore           store.PreferenceStore
+	ProductNoticesStore       store.ProductNoticesStore
+	ReactionStore             store.ReactionStore
+	RoleStore                 store.RoleStore
+	SchemeStore               store.SchemeStore
+	SessionStore              store.SessionStore
+	StatusStore               store.StatusStore
+	SystemStore               store.SystemStore
+	TeamStore                 store.TeamStore
+	TermsOfServiceStore       store.TermsOfServiceStore
+	ThreadStore               store.ThreadStore
+	TokenStore                store.TokenStore
+	UploadSessionStore        store.UploadSessionStore
+	UserStore                 store.UserStore
+	UserAccessTokenStore      store.UserAccessTokenStore
+	UserTermsOfServiceStore   store.UserTermsOfServiceStore
+	WebhookStore              store.WebhookStore
+}
+
+func (s *ReadOnlyLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *ReadOnlyLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
+func (s *ReadOnlyLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *ReadOnlyLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *ReadOnlyLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *ReadOnlyLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *ReadOnlyLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *ReadOnlyLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *ReadOnlyLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *ReadOnlyLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *ReadOnlyLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *ReadOnlyLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *ReadOnlyLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *ReadOnlyLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *ReadOnlyLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *ReadOnlyLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *ReadOnlyLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *ReadOnlyLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *ReadOnlyLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *ReadOnlyLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *ReadOnlyLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *ReadOnlyLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *ReadOnlyLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *ReadOnlyLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *ReadOnlyLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *ReadOnlyLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *ReadOnlyLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *ReadOnlyLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *ReadOnlyLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *ReadOnlyLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *ReadOnlyLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *ReadOnlyLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *ReadOnlyLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *ReadOnlyLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *ReadOnlyLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+type ReadOnlyLayerAuditStore struct {
+	store.AuditStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerBotStore struct {
+	store.BotStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerChannelStore struct {
+	store.ChannelStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerCommandStore struct {
+	store.CommandStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerEmojiStore struct {
+	store.EmojiStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerGroupStore struct {
+	store.GroupStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerJobStore struct {
+	store.JobStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerLicenseStore struct {
+	store.LicenseStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerOAuthStore struct {
+	store.OAuthStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerPluginStore struct {
+	store.PluginStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerPostStore struct {
+	store.PostStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerReactionStore struct {
+	store.ReactionStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerRoleStore struct {
+	store.RoleStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerSchemeStore struct {
+	store.SchemeStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerSessionStore struct {
+	store.SessionStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerStatusStore struct {
+	store.StatusStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerSystemStore struct {
+	store.SystemStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerTeamStore struct {
+	store.TeamStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerThreadStore struct {
+	store.ThreadStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerTokenStore struct {
+	store.TokenStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerUserStore struct {
+	store.UserStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *ReadOnlyLayer
+}
+
+type ReadOnlyLayerWebhookStore struct {
+	store.WebhookStore
+	Root *ReadOnlyLayer
+}
+
+func (s *ReadOnlyLayerAuditStore) PermanentDeleteByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("AuditStore.PermanentDeleteByUser")
+		return err
+	}
+	return s.AuditStore.PermanentDeleteByUser(userId)
+}
+
+func (s *ReadOnlyLayerAuditStore) Save(audit *model.Audit) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("AuditStore.Save")
+		return err
+	}
+	return s.AuditStore.Save(audit)
+}
+
+func (s *ReadOnlyLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("AuditTrailStore.Record")
+		return err
+	}
+	return s.AuditTrailStore.Record(entry, change)
+}
+
+func (s *ReadOnlyLayerBotStore) PermanentDelete(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("BotStore.PermanentDelete")
+		return err
+	}
+	return s.BotStore.PermanentDelete(userId)
+}
+
+func (s *ReadOnlyLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Bot
+		err := store.NewErrReadOnly("BotStore.Save")
+		return result, err
+	}
+	return s.BotStore.Save(bot)
+}
+
+func (s *ReadOnlyLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Bot
+		err := store.NewErrReadOnly("BotStore.Update")
+		return result, err
+	}
+	return s.BotStore.Update(bot)
+}
+
+func (s *ReadOnlyLayerChannelStore) ClearAllCustomRoleAssignments() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.ClearAllCustomRoleAssignments")
+		return err
+	}
+	return s.ChannelStore.ClearAllCustomRoleAssignments()
+}
+
+func (s *ReadOnlyLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.ClearSidebarOnTeamLeave")
+		return err
+	}
+	return s.ChannelStore.ClearSidebarOnTeamLeave(userId, teamId)
+}
+
+func (s *ReadOnlyLayerChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Channel
+		err := store.NewErrReadOnly("ChannelStore.CreateDirectChannel")
+		return result, err
+	}
+	return s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+}
+
+func (s *ReadOnlyLayerChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.CreateInitialSidebarCategories")
+		return err
+	}
+	return s.ChannelStore.CreateInitialSidebarCategories(userId, teamId)
+}
+
+func (s *ReadOnlyLayerChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.SidebarCategoryWithChannels
+		err := store.NewErrReadOnly("ChannelStore.CreateSidebarCategory")
+		return result, err
+	}
+	return s.ChannelStore.CreateSidebarCategory(userId, teamId, newCategory)
+}
+
+func (s *ReadOnlyLayerChannelStore) Delete(channelId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.Delete")
+		return err
+	}
+	return s.ChannelStore.Delete(channelId, time)
+}
+
+func (s *ReadOnlyLayerChannelStore) DeleteSidebarCategory(categoryId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.DeleteSidebarCategory")
+		return err
+	}
+	return s.ChannelStore.DeleteSidebarCategory(categoryId)
+}
+
+func (s *ReadOnlyLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.DeleteSidebarChannelsByPreferences")
+		return err
+	}
+	return s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+}
+
+func (s *ReadOnlyLayerChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.IncrementMentionCount")
+		return err
+	}
+	return s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+}
+
+func (s *ReadOnlyLayerChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+	if s.Root.IsReadOnly() {
+		var result map[string]string
+		err := store.NewErrReadOnly("ChannelStore.MigrateChannelMembers")
+		return result, err
+	}
+	return s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+}
+
+func (s *ReadOnlyLayerChannelStore) MigratePublicChannels() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.MigratePublicChannels")
+		return err
+	}
+	return s.ChannelStore.MigratePublicChannels()
+}
+
+func (s *ReadOnlyLayerChannelStore) PermanentDelete(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.PermanentDelete")
+		return err
+	}
+	return s.ChannelStore.PermanentDelete(channelId)
+}
+
+func (s *ReadOnlyLayerChannelStore) PermanentDeleteByTeam(teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.PermanentDeleteByTeam")
+		return err
+	}
+	return s.ChannelStore.PermanentDeleteByTeam(teamId)
+}
+
+func (s *ReadOnlyLayerChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.PermanentDeleteMembersByChannel")
+		return err
+	}
+	return s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+}
+
+func (s *ReadOnlyLayerChannelStore) PermanentDeleteMembersByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.PermanentDeleteMembersByUser")
+		return err
+	}
+	return s.ChannelStore.PermanentDeleteMembersByUser(userId)
+}
+
+func (s *ReadOnlyLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.RemoveAllDeactivatedMembers")
+		return err
+	}
+	return s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+}
+
+func (s *ReadOnlyLayerChannelStore) RemoveMember(channelId string, userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.RemoveMember")
+		return err
+	}
+	return s.ChannelStore.RemoveMember(channelId, userId)
+}
+
+func (s *ReadOnlyLayerChannelStore) RemoveMembers(channelId string, userIds []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.RemoveMembers")
+		return err
+	}
+	return s.ChannelStore.RemoveMembers(channelId, userIds)
+}
+
+func (s *ReadOnlyLayerChannelStore) ResetAllChannelSchemes() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.ResetAllChannelSchemes")
+		return err
+	}
+	return s.ChannelStore.ResetAllChannelSchemes()
+}
+
+func (s *ReadOnlyLayerChannelStore) Restore(channelId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.Restore")
+		return err
+	}
+	return s.ChannelStore.Restore(channelId, time)
+}
+
+func (s *ReadOnlyLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Channel
+		err := store.NewErrReadOnly("ChannelStore.Save")
+		return result, err
+	}
+	return s.ChannelStore.Save(channel, maxChannelsPerTeam)
+}
+
+func (s *ReadOnlyLayerChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Channel
+		err := store.NewErrReadOnly("ChannelStore.SaveDirectChannel")
+		return result, err
+	}
+	return s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+}
+
+func (s *ReadOnlyLayerChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.ChannelMember
+		err := store.NewErrReadOnly("ChannelStore.SaveMember")
+		return result, err
+	}
+	return s.ChannelStore.SaveMember(member)
+}
+
+func (s *ReadOnlyLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.ChannelMember
+		var resultVar1 []*model.ChannelMember
+		err := store.NewErrReadOnly("ChannelStore.SaveMemberMultiple")
+		return result, resultVar1, err
+	}
+	return s.ChannelStore.SaveMemberMultiple(members)
+}
+
+func (s *ReadOnlyLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.ChannelMember
+		err := store.NewErrReadOnly("ChannelStore.SaveMultipleMembers")
+		return result, err
+	}
+	return s.ChannelStore.SaveMultipleMembers(members)
+}
+
+func (s *ReadOnlyLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.SetDeleteAt")
+		return err
+	}
+	return s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+}
+
+func (s *ReadOnlyLayerChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Channel
+		err := store.NewErrReadOnly("ChannelStore.Update")
+		return result, err
+	}
+	return s.ChannelStore.Update(channel)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+	if s.Root.IsReadOnly() {
+		var result map[string]int64
+		err := store.NewErrReadOnly("ChannelStore.UpdateLastViewedAt")
+		return result, err
+	}
+	return s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	if s.Root.IsReadOnly() {
+		var result map[string]*model.ChannelUnreadCounts
+		err := store.NewErrReadOnly("ChannelStore.UpdateLastViewedAtMulti")
+		return result, err
+	}
+	return s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.ChannelUnreadAt
+		err := store.NewErrReadOnly("ChannelStore.UpdateLastViewedAtPost")
+		return result, err
+	}
+	return s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.ChannelMember
+		err := store.NewErrReadOnly("ChannelStore.UpdateMember")
+		return result, err
+	}
+	return s.ChannelStore.UpdateMember(member)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.UpdateMembersRole")
+		return err
+	}
+	return s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.ChannelMember
+		err := store.NewErrReadOnly("ChannelStore.UpdateMultipleMembers")
+		return result, err
+	}
+	return s.ChannelStore.UpdateMultipleMembers(members)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.SidebarCategoryWithChannels
+		err := store.NewErrReadOnly("ChannelStore.UpdateSidebarCategories")
+		return result, err
+	}
+	return s.ChannelStore.UpdateSidebarCategories(userId, teamId, categories)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.UpdateSidebarCategoryOrder")
+		return err
+	}
+	return s.ChannelStore.UpdateSidebarCategoryOrder(userId, teamId, categoryOrder)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.UpdateSidebarChannelCategoryOnMove")
+		return err
+	}
+	return s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamId)
+}
+
+func (s *ReadOnlyLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelStore.UpdateSidebarChannelsByPreferences")
+		return err
+	}
+	return s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+}
+
+func (s *ReadOnlyLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelMemberHistoryStore.LogJoinEvent")
+		return err
+	}
+	return s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+}
+
+func (s *ReadOnlyLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ChannelMemberHistoryStore.LogLeaveEvent")
+		return err
+	}
+	return s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+}
+
+func (s *ReadOnlyLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("ChannelMemberHistoryStore.PermanentDeleteBatch")
+		return result, err
+	}
+	return s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+}
+
+func (s *ReadOnlyLayerCommandStore) Delete(commandId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("CommandStore.Delete")
+		return err
+	}
+	return s.CommandStore.Delete(commandId, time)
+}
+
+func (s *ReadOnlyLayerCommandStore) PermanentDeleteByTeam(teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("CommandStore.PermanentDeleteByTeam")
+		return err
+	}
+	return s.CommandStore.PermanentDeleteByTeam(teamId)
+}
+
+func (s *ReadOnlyLayerCommandStore) PermanentDeleteByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("CommandStore.PermanentDeleteByUser")
+		return err
+	}
+	return s.CommandStore.PermanentDeleteByUser(userId)
+}
+
+func (s *ReadOnlyLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Command
+		err := store.NewErrReadOnly("CommandStore.Save")
+		return result, err
+	}
+	return s.CommandStore.Save(webhook)
+}
+
+func (s *ReadOnlyLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Command
+		err := store.NewErrReadOnly("CommandStore.Update")
+		return result, err
+	}
+	return s.CommandStore.Update(hook)
+}
+
+func (s *ReadOnlyLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.CommandWebhook
+		err := store.NewErrReadOnly("CommandWebhookStore.Save")
+		return result, err
+	}
+	return s.CommandWebhookStore.Save(webhook)
+}
+
+func (s *ReadOnlyLayerCommandWebhookStore) TryUse(id string, limit int) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("CommandWebhookStore.TryUse")
+		return err
+	}
+	return s.CommandWebhookStore.TryUse(id, limit)
+}
+
+func (s *ReadOnlyLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Compliance
+		err := store.NewErrReadOnly("ComplianceStore.Save")
+		return result, err
+	}
+	return s.ComplianceStore.Save(compliance)
+}
+
+func (s *ReadOnlyLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Compliance
+		err := store.NewErrReadOnly("ComplianceStore.Update")
+		return result, err
+	}
+	return s.ComplianceStore.Update(compliance)
+}
+
+func (s *ReadOnlyLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("EmojiStore.Delete")
+		return err
+	}
+	return s.EmojiStore.Delete(emoji, time)
+}
+
+func (s *ReadOnlyLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Emoji
+		err := store.NewErrReadOnly("EmojiStore.Save")
+		return result, err
+	}
+	return s.EmojiStore.Save(emoji)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("FileInfoStore.AttachToPost")
+		return err
+	}
+	return s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("FileInfoStore.DeleteForPost")
+		return result, err
+	}
+	return s.FileInfoStore.DeleteForPost(postId)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) PermanentDelete(fileId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("FileInfoStore.PermanentDelete")
+		return err
+	}
+	return s.FileInfoStore.PermanentDelete(fileId)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("FileInfoStore.PermanentDeleteBatch")
+		return result, err
+	}
+	return s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("FileInfoStore.PermanentDeleteByUser")
+		return result, err
+	}
+	return s.FileInfoStore.PermanentDeleteByUser(userId)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.FileInfo
+		err := store.NewErrReadOnly("FileInfoStore.Save")
+		return result, err
+	}
+	return s.FileInfoStore.Save(info)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) SetContent(fileId string, content string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("FileInfoStore.SetContent")
+		return err
+	}
+	return s.FileInfoStore.SetContent(fileId, content)
+}
+
+func (s *ReadOnlyLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.FileInfo
+		err := store.NewErrReadOnly("FileInfoStore.Upsert")
+		return result, err
+	}
+	return s.FileInfoStore.Upsert(info)
+}
+
+func (s *ReadOnlyLayerGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.Group
+		err := newAppError("GroupStore.Create")
+		return result, err
+	}
+	return s.GroupStore.Create(group)
+}
+
+func (s *ReadOnlyLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.GroupSyncable
+		err := newAppError("GroupStore.CreateGroupSyncable")
+		return result, err
+	}
+	return s.GroupStore.CreateGroupSyncable(groupSyncable)
+}
+
+func (s *ReadOnlyLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.Group
+		err := newAppError("GroupStore.Delete")
+		return result, err
+	}
+	return s.GroupStore.Delete(groupID)
+}
+
+func (s *ReadOnlyLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.GroupSyncable
+		err := newAppError("GroupStore.DeleteGroupSyncable")
+		return result, err
+	}
+	return s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+}
+
+func (s *ReadOnlyLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.GroupMember
+		err := newAppError("GroupStore.DeleteMember")
+		return result, err
+	}
+	return s.GroupStore.DeleteMember(groupID, userID)
+}
+
+func (s *ReadOnlyLayerGroupStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+	if s.Root.IsReadOnly() {
+
+		err := newAppError("GroupStore.PermanentDeleteMembersByUser")
+		return err
+	}
+	return s.GroupStore.PermanentDeleteMembersByUser(userId)
+}
+
+func (s *ReadOnlyLayerGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.Group
+		err := newAppError("GroupStore.Update")
+		return result, err
+	}
+	return s.GroupStore.Update(group)
+}
+
+func (s *ReadOnlyLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.GroupSyncable
+		err := newAppError("GroupStore.UpdateGroupSyncable")
+		return result, err
+	}
+	return s.GroupStore.UpdateGroupSyncable(groupSyncable)
+}
+
+func (s *ReadOnlyLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if s.Root.IsReadOnly() {
+		var result *model.GroupMember
+		err := newAppError("GroupStore.UpsertMember")
+		return result, err
+	}
+	return s.GroupStore.UpsertMember(groupID, userID)
+}
+
+func (s *ReadOnlyLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Job
+		err := store.NewErrReadOnly("JobStore.ClaimPending")
+		return result, err
+	}
+	return s.JobStore.ClaimPending(jobType, nodeId)
+}
+
+func (s *ReadOnlyLayerJobStore) Delete(id string) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("JobStore.Delete")
+		return result, err
+	}
+	return s.JobStore.Delete(id)
+}
+
+func (s *ReadOnlyLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Job
+		err := store.NewErrReadOnly("JobStore.Save")
+		return result, err
+	}
+	return s.JobStore.Save(job)
+}
+
+func (s *ReadOnlyLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+	if s.Root.IsReadOnly() {
+		var result bool
+		err := store.NewErrReadOnly("JobStore.UpdateOptimistically")
+		return result, err
+	}
+	return s.JobStore.UpdateOptimistically(job, currentStatus)
+}
+
+func (s *ReadOnlyLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Job
+		err := store.NewErrReadOnly("JobStore.UpdateStatus")
+		return result, err
+	}
+	return s.JobStore.UpdateStatus(id, status)
+}
+
+func (s *ReadOnlyLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+	if s.Root.IsReadOnly() {
+		var result bool
+		err := store.NewErrReadOnly("JobStore.UpdateStatusOptimistically")
+		return result, err
+	}
+	return s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+}
+
+func (s *ReadOnlyLayerLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.LicenseRecord
+		err := store.NewErrReadOnly("LicenseStore.Save")
+		return result, err
+	}
+	return s.LicenseStore.Save(license)
+}
+
+func (s *ReadOnlyLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.LinkMetadata
+		err := store.NewErrReadOnly("LinkMetadataStore.Save")
+		return result, err
+	}
+	return s.LinkMetadataStore.Save(linkMetadata)
+}
+
+func (s *ReadOnlyLayerOAuthStore) DeleteApp(id string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("OAuthStore.DeleteApp")
+		return err
+	}
+	return s.OAuthStore.DeleteApp(id)
+}
+
+func (s *ReadOnlyLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("OAuthStore.PermanentDeleteAuthDataByUser")
+		return err
+	}
+	return s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+}
+
+func (s *ReadOnlyLayerOAuthStore) RemoveAccessData(token string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("OAuthStore.RemoveAccessData")
+		return err
+	}
+	return s.OAuthStore.RemoveAccessData(token)
+}
+
+func (s *ReadOnlyLayerOAuthStore) RemoveAllAccessData() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("OAuthStore.RemoveAllAccessData")
+		return err
+	}
+	return s.OAuthStore.RemoveAllAccessData()
+}
+
+func (s *ReadOnlyLayerOAuthStore) RemoveAuthData(code string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("OAuthStore.RemoveAuthData")
+		return err
+	}
+	return s.OAuthStore.RemoveAuthData(code)
+}
+
+func (s *ReadOnlyLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.AccessData
+		err := store.NewErrReadOnly("OAuthStore.SaveAccessData")
+		return result, err
+	}
+	return s.OAuthStore.SaveAccessData(accessData)
+}
+
+func (s *ReadOnlyLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.OAuthApp
+		err := store.NewErrReadOnly("OAuthStore.SaveApp")
+		return result, err
+	}
+	return s.OAuthStore.SaveApp(app)
+}
+
+func (s *ReadOnlyLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.AuthData
+		err := store.NewErrReadOnly("OAuthStore.SaveAuthData")
+		return result, err
+	}
+	return s.OAuthStore.SaveAuthData(authData)
+}
+
+func (s *ReadOnlyLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.AccessData
+		err := store.NewErrReadOnly("OAuthStore.UpdateAccessData")
+		return result, err
+	}
+	return s.OAuthStore.UpdateAccessData(accessData)
+}
+
+func (s *ReadOnlyLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.OAuthApp
+		err := store.NewErrReadOnly("OAuthStore.UpdateApp")
+		return result, err
+	}
+	return s.OAuthStore.UpdateApp(app)
+}
+
+func (s *ReadOnlyLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	if s.Root.IsReadOnly() {
+		var result bool
+		err := store.NewErrReadOnly("PluginStore.CompareAndDelete")
+		return result, err
+	}
+	return s.PluginStore.CompareAndDelete(keyVal, oldValue)
+}
+
+func (s *ReadOnlyLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	if s.Root.IsReadOnly() {
+		var result bool
+		err := store.NewErrReadOnly("PluginStore.CompareAndSet")
+		return result, err
+	}
+	return s.PluginStore.CompareAndSet(keyVal, oldValue)
+}
+
+func (s *ReadOnlyLayerPluginStore) Delete(pluginId string, key string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PluginStore.Delete")
+		return err
+	}
+	return s.PluginStore.Delete(pluginId, key)
+}
+
+func (s *ReadOnlyLayerPluginStore) DeleteAllExpired() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PluginStore.DeleteAllExpired")
+		return err
+	}
+	return s.PluginStore.DeleteAllExpired()
+}
+
+func (s *ReadOnlyLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PluginStore.DeleteAllForPlugin")
+		return err
+	}
+	return s.PluginStore.DeleteAllForPlugin(PluginId)
+}
+
+func (s *ReadOnlyLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.PluginKeyValue
+		err := store.NewErrReadOnly("PluginStore.SaveOrUpdate")
+		return result, err
+	}
+	return s.PluginStore.SaveOrUpdate(keyVal)
+}
+
+func (s *ReadOnlyLayerPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	if s.Root.IsReadOnly() {
+		var result bool
+		err := store.NewErrReadOnly("PluginStore.SetWithOptions")
+		return result, err
+	}
+	return s.PluginStore.SetWithOptions(pluginId, key, value, options)
+}
+
+func (s *ReadOnlyLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PostStore.Delete")
+		return err
+	}
+	return s.PostStore.Delete(postId, time, deleteByID)
+}
+
+func (s *ReadOnlyLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.Post
+		err := store.NewErrReadOnly("PostStore.MoveThread")
+		return result, err
+	}
+	return s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+}
+
+func (s *ReadOnlyLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Post
+		err := store.NewErrReadOnly("PostStore.Overwrite")
+		return result, err
+	}
+	return s.PostStore.Overwrite(post)
+}
+
+func (s *ReadOnlyLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.Post
+		var resultVar1 int
+		err := store.NewErrReadOnly("PostStore.OverwriteMultiple")
+		return result, resultVar1, err
+	}
+	return s.PostStore.OverwriteMultiple(posts)
+}
+
+func (s *ReadOnlyLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("PostStore.PermanentDeleteBatch")
+		return result, err
+	}
+	return s.PostStore.PermanentDeleteBatch(endTime, limit)
+}
+
+func (s *ReadOnlyLayerPostStore) PermanentDeleteByChannel(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PostStore.PermanentDeleteByChannel")
+		return err
+	}
+	return s.PostStore.PermanentDeleteByChannel(channelId)
+}
+
+func (s *ReadOnlyLayerPostStore) PermanentDeleteByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PostStore.PermanentDeleteByUser")
+		return err
+	}
+	return s.PostStore.PermanentDeleteByUser(userId)
+}
+
+func (s *ReadOnlyLayerPostStore) Save(post *model.Post) (*model.Post, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Post
+		err := store.NewErrReadOnly("PostStore.Save")
+		return result, err
+	}
+	return s.PostStore.Save(post)
+}
+
+func (s *ReadOnlyLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.Post
+		var resultVar1 int
+		err := store.NewErrReadOnly("PostStore.SaveMultiple")
+		return result, resultVar1, err
+	}
+	return s.PostStore.SaveMultiple(posts)
+}
+
+func (s *ReadOnlyLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Post
+		err := store.NewErrReadOnly("PostStore.Update")
+		return result, err
+	}
+	return s.PostStore.Update(newPost, oldPost)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("PreferenceStore.CleanupFlagsBatch")
+		return result, err
+	}
+	return s.PreferenceStore.CleanupFlagsBatch(limit)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) Delete(userId string, category string, name string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PreferenceStore.Delete")
+		return err
+	}
+	return s.PreferenceStore.Delete(userId, category, name)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) DeleteCategory(userId string, category string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PreferenceStore.DeleteCategory")
+		return err
+	}
+	return s.PreferenceStore.DeleteCategory(userId, category)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PreferenceStore.DeleteCategoryAndName")
+		return err
+	}
+	return s.PreferenceStore.DeleteCategoryAndName(category, name)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PreferenceStore.PermanentDeleteByUser")
+		return err
+	}
+	return s.PreferenceStore.PermanentDeleteByUser(userId)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) Save(preferences *model.Preferences) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("PreferenceStore.Save")
+		return err
+	}
+	return s.PreferenceStore.Save(preferences)
+}
+
+func (s *ReadOnlyLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	if s.Root.IsReadOnly() {
+		var result model.Preferences
+		err := store.NewErrReadOnly("PreferenceStore.SaveMultiple")
+		return result, err
+	}
+	return s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+}
+
+func (s *ReadOnlyLayerProductNoticesStore) Clear(notices []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ProductNoticesStore.Clear")
+		return err
+	}
+	return s.ProductNoticesStore.Clear(notices)
+}
+
+func (s *ReadOnlyLayerProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ProductNoticesStore.ClearOldNotices")
+		return err
+	}
+	return s.ProductNoticesStore.ClearOldNotices(currentNotices)
+}
+
+func (s *ReadOnlyLayerProductNoticesStore) View(userId string, notices []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ProductNoticesStore.View")
+		return err
+	}
+	return s.ProductNoticesStore.View(userId, notices)
+}
+
+func (s *ReadOnlyLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Reaction
+		err := store.NewErrReadOnly("ReactionStore.Delete")
+		return result, err
+	}
+	return s.ReactionStore.Delete(reaction)
+}
+
+func (s *ReadOnlyLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ReactionStore.DeleteAllWithEmojiName")
+		return err
+	}
+	return s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+}
+
+func (s *ReadOnlyLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("ReactionStore.PermanentDeleteBatch")
+		return result, err
+	}
+	return s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+}
+
+func (s *ReadOnlyLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Reaction
+		err := store.NewErrReadOnly("ReactionStore.Save")
+		return result, err
+	}
+	return s.ReactionStore.Save(reaction)
+}
+
+func (s *ReadOnlyLayerRoleStore) Delete(roleId string) (*model.Role, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Role
+		err := store.NewErrReadOnly("RoleStore.Delete")
+		return result, err
+	}
+	return s.RoleStore.Delete(roleId)
+}
+
+func (s *ReadOnlyLayerRoleStore) PermanentDeleteAll() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("RoleStore.PermanentDeleteAll")
+		return err
+	}
+	return s.RoleStore.PermanentDeleteAll()
+}
+
+func (s *ReadOnlyLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Role
+		err := store.NewErrReadOnly("RoleStore.Save")
+		return result, err
+	}
+	return s.RoleStore.Save(role)
+}
+
+func (s *ReadOnlyLayerSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Scheme
+		err := store.NewErrReadOnly("SchemeStore.Delete")
+		return result, err
+	}
+	return s.SchemeStore.Delete(schemeId)
+}
+
+func (s *ReadOnlyLayerSchemeStore) PermanentDeleteAll() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SchemeStore.PermanentDeleteAll")
+		return err
+	}
+	return s.SchemeStore.PermanentDeleteAll()
+}
+
+func (s *ReadOnlyLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Scheme
+		err := store.NewErrReadOnly("SchemeStore.Save")
+		return result, err
+	}
+	return s.SchemeStore.Save(scheme)
+}
+
+func (s *ReadOnlyLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.PermanentDeleteSessionsByUser")
+		return err
+	}
+	return s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+}
+
+func (s *ReadOnlyLayerSessionStore) Remove(sessionIdOrToken string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.Remove")
+		return err
+	}
+	return s.SessionStore.Remove(sessionIdOrToken)
+}
+
+func (s *ReadOnlyLayerSessionStore) RemoveAllSessions() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.RemoveAllSessions")
+		return err
+	}
+	return s.SessionStore.RemoveAllSessions()
+}
+
+func (s *ReadOnlyLayerSessionStore) Save(session *model.Session) (*model.Session, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Session
+		err := store.NewErrReadOnly("SessionStore.Save")
+		return result, err
+	}
+	return s.SessionStore.Save(session)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("SessionStore.UpdateDeviceId")
+		return result, err
+	}
+	return s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.UpdateExpiredNotify")
+		return err
+	}
+	return s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.UpdateExpiresAt")
+		return err
+	}
+	return s.SessionStore.UpdateExpiresAt(sessionId, time)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.UpdateLastActivityAt")
+		return err
+	}
+	return s.SessionStore.UpdateLastActivityAt(sessionId, time)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateProps(session *model.Session) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SessionStore.UpdateProps")
+		return err
+	}
+	return s.SessionStore.UpdateProps(session)
+}
+
+func (s *ReadOnlyLayerSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("SessionStore.UpdateRoles")
+		return result, err
+	}
+	return s.SessionStore.UpdateRoles(userId, roles)
+}
+
+func (s *ReadOnlyLayerStatusStore) ResetAll() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("StatusStore.ResetAll")
+		return err
+	}
+	return s.StatusStore.ResetAll()
+}
+
+func (s *ReadOnlyLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("StatusStore.SaveOrUpdate")
+		return err
+	}
+	return s.StatusStore.SaveOrUpdate(status)
+}
+
+func (s *ReadOnlyLayerStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("StatusStore.UpdateLastActivityAt")
+		return err
+	}
+	return s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+}
+
+func (s *ReadOnlyLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.System
+		err := store.NewErrReadOnly("SystemStore.InsertIfExists")
+		return result, err
+	}
+	return s.SystemStore.InsertIfExists(system)
+}
+
+func (s *ReadOnlyLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.System
+		err := store.NewErrReadOnly("SystemStore.PermanentDeleteByName")
+		return result, err
+	}
+	return s.SystemStore.PermanentDeleteByName(name)
+}
+
+func (s *ReadOnlyLayerSystemStore) Save(system *model.System) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SystemStore.Save")
+		return err
+	}
+	return s.SystemStore.Save(system)
+}
+
+func (s *ReadOnlyLayerSystemStore) SaveOrUpdate(system *model.System) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SystemStore.SaveOrUpdate")
+		return err
+	}
+	return s.SystemStore.SaveOrUpdate(system)
+}
+
+func (s *ReadOnlyLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SystemStore.SaveOrUpdateWithWarnMetricHandling")
+		return err
+	}
+	return s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+}
+
+func (s *ReadOnlyLayerSystemStore) Update(system *model.System) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("SystemStore.Update")
+		return err
+	}
+	return s.SystemStore.Update(system)
+}
+
+func (s *ReadOnlyLayerTeamStore) ClearAllCustomRoleAssignments() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.ClearAllCustomRoleAssignments")
+		return err
+	}
+	return s.TeamStore.ClearAllCustomRoleAssignments()
+}
+
+func (s *ReadOnlyLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+	if s.Root.IsReadOnly() {
+		var result map[string]string
+		err := store.NewErrReadOnly("TeamStore.MigrateTeamMembers")
+		return result, err
+	}
+	return s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+}
+
+func (s *ReadOnlyLayerTeamStore) PermanentDelete(teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.PermanentDelete")
+		return err
+	}
+	return s.TeamStore.PermanentDelete(teamId)
+}
+
+func (s *ReadOnlyLayerTeamStore) RemoveAllMembersByTeam(teamId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.RemoveAllMembersByTeam")
+		return err
+	}
+	return s.TeamStore.RemoveAllMembersByTeam(teamId)
+}
+
+func (s *ReadOnlyLayerTeamStore) RemoveAllMembersByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.RemoveAllMembersByUser")
+		return err
+	}
+	return s.TeamStore.RemoveAllMembersByUser(userId)
+}
+
+func (s *ReadOnlyLayerTeamStore) RemoveMember(teamId string, userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.RemoveMember")
+		return err
+	}
+	return s.TeamStore.RemoveMember(teamId, userId)
+}
+
+func (s *ReadOnlyLayerTeamStore) RemoveMembers(teamId string, userIds []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.RemoveMembers")
+		return err
+	}
+	return s.TeamStore.RemoveMembers(teamId, userIds)
+}
+
+func (s *ReadOnlyLayerTeamStore) ResetAllTeamSchemes() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.ResetAllTeamSchemes")
+		return err
+	}
+	return s.TeamStore.ResetAllTeamSchemes()
+}
+
+func (s *ReadOnlyLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Team
+		err := store.NewErrReadOnly("TeamStore.Save")
+		return result, err
+	}
+	return s.TeamStore.Save(team)
+}
+
+func (s *ReadOnlyLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.TeamMember
+		err := store.NewErrReadOnly("TeamStore.SaveMember")
+		return result, err
+	}
+	return s.TeamStore.SaveMember(member, maxUsersPerTeam)
+}
+
+func (s *ReadOnlyLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.TeamMember
+		err := store.NewErrReadOnly("TeamStore.SaveMultipleMembers")
+		return result, err
+	}
+	return s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+}
+
+func (s *ReadOnlyLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Team
+		err := store.NewErrReadOnly("TeamStore.Update")
+		return result, err
+	}
+	return s.TeamStore.Update(team)
+}
+
+func (s *ReadOnlyLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.UpdateLastTeamIconUpdate")
+		return err
+	}
+	return s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+}
+
+func (s *ReadOnlyLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.TeamMember
+		err := store.NewErrReadOnly("TeamStore.UpdateMember")
+		return result, err
+	}
+	return s.TeamStore.UpdateMember(member)
+}
+
+func (s *ReadOnlyLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TeamStore.UpdateMembersRole")
+		return err
+	}
+	return s.TeamStore.UpdateMembersRole(teamID, userIDs)
+}
+
+func (s *ReadOnlyLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.TeamMember
+		err := store.NewErrReadOnly("TeamStore.UpdateMultipleMembers")
+		return result, err
+	}
+	return s.TeamStore.UpdateMultipleMembers(members)
+}
+
+func (s *ReadOnlyLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.TermsOfService
+		err := store.NewErrReadOnly("TermsOfServiceStore.Save")
+		return result, err
+	}
+	return s.TermsOfServiceStore.Save(termsOfService)
+}
+
+func (s *ReadOnlyLayerThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.CreateMembershipIfNeeded")
+		return err
+	}
+	return s.ThreadStore.CreateMembershipIfNeeded(userId, postId, following)
+}
+
+func (s *ReadOnlyLayerThreadStore) Delete(postId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.Delete")
+		return err
+	}
+	return s.ThreadStore.Delete(postId)
+}
+
+func (s *ReadOnlyLayerThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.DeleteMembershipForUser")
+		return err
+	}
+	return s.ThreadStore.DeleteMembershipForUser(userId, postId)
+}
+
+func (s *ReadOnlyLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.MarkAllAsRead")
+		return err
+	}
+	return s.ThreadStore.MarkAllAsRead(userId, timestamp)
+}
+
+func (s *ReadOnlyLayerThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.MarkAsRead")
+		return err
+	}
+	return s.ThreadStore.MarkAsRead(userId, threadId, timestamp)
+}
+
+func (s *ReadOnlyLayerThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Thread
+		err := store.NewErrReadOnly("ThreadStore.Save")
+		return result, err
+	}
+	return s.ThreadStore.Save(thread)
+}
+
+func (s *ReadOnlyLayerThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.ThreadMembership
+		err := store.NewErrReadOnly("ThreadStore.SaveMembership")
+		return result, err
+	}
+	return s.ThreadStore.SaveMembership(membership)
+}
+
+func (s *ReadOnlyLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+	if s.Root.IsReadOnly() {
+		var result []*model.Thread
+		var resultVar1 int
+		err := store.NewErrReadOnly("ThreadStore.SaveMultiple")
+		return result, resultVar1, err
+	}
+	return s.ThreadStore.SaveMultiple(thread)
+}
+
+func (s *ReadOnlyLayerThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.Thread
+		err := store.NewErrReadOnly("ThreadStore.Update")
+		return result, err
+	}
+	return s.ThreadStore.Update(thread)
+}
+
+func (s *ReadOnlyLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.ThreadMembership
+		err := store.NewErrReadOnly("ThreadStore.UpdateMembership")
+		return result, err
+	}
+	return s.ThreadStore.UpdateMembership(membership)
+}
+
+func (s *ReadOnlyLayerThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("ThreadStore.UpdateUnreadsByChannel")
+		return err
+	}
+	return s.ThreadStore.UpdateUnreadsByChannel(userId, changedThreads, timestamp)
+}
+
+func (s *ReadOnlyLayerTokenStore) Delete(token string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TokenStore.Delete")
+		return err
+	}
+	return s.TokenStore.Delete(token)
+}
+
+func (s *ReadOnlyLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TokenStore.RemoveAllTokensByType")
+		return err
+	}
+	return s.TokenStore.RemoveAllTokensByType(tokenType)
+}
+
+func (s *ReadOnlyLayerTokenStore) Save(recovery *model.Token) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("TokenStore.Save")
+		return err
+	}
+	return s.TokenStore.Save(recovery)
+}
+
+func (s *ReadOnlyLayerUploadSessionStore) Delete(id string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UploadSessionStore.Delete")
+		return err
+	}
+	return s.UploadSessionStore.Delete(id)
+}
+
+func (s *ReadOnlyLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.UploadSession
+		err := store.NewErrReadOnly("UploadSessionStore.Save")
+		return result, err
+	}
+	return s.UploadSessionStore.Save(session)
+}
+
+func (s *ReadOnlyLayerUploadSessionStore) Update(session *model.UploadSession) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UploadSessionStore.Update")
+		return err
+	}
+	return s.UploadSessionStore.Update(session)
+}
+
+func (s *ReadOnlyLayerUserStore) ClearAllCustomRoleAssignments() error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.ClearAllCustomRoleAssignments")
+		return err
+	}
+	return s.UserStore.ClearAllCustomRoleAssignments()
+}
+
+func (s *ReadOnlyLayerUserStore) DeactivateGuests() ([]string, error) {
+	if s.Root.IsReadOnly() {
+		var result []string
+		err := store.NewErrReadOnly("UserStore.DeactivateGuests")
+		return result, err
+	}
+	return s.UserStore.DeactivateGuests()
+}
+
+func (s *ReadOnlyLayerUserStore) DemoteUserToGuest(userID string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.DemoteUserToGuest")
+		return err
+	}
+	return s.UserStore.DemoteUserToGuest(userID)
+}
+
+func (s *ReadOnlyLayerUserStore) PermanentDelete(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.PermanentDelete")
+		return err
+	}
+	return s.UserStore.PermanentDelete(userId)
+}
+
+func (s *ReadOnlyLayerUserStore) PromoteGuestToUser(userID string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.PromoteGuestToUser")
+		return err
+	}
+	return s.UserStore.PromoteGuestToUser(userID)
+}
+
+func (s *ReadOnlyLayerUserStore) ResetLastPictureUpdate(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.ResetLastPictureUpdate")
+		return err
+	}
+	return s.UserStore.ResetLastPictureUpdate(userId)
+}
+
+func (s *ReadOnlyLayerUserStore) Save(user *model.User) (*model.User, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.User
+		err := store.NewErrReadOnly("UserStore.Save")
+		return result, err
+	}
+	return s.UserStore.Save(user)
+}
+
+func (s *ReadOnlyLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.UserUpdate
+		err := store.NewErrReadOnly("UserStore.Update")
+		return result, err
+	}
+	return s.UserStore.Update(user, allowRoleUpdate)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("UserStore.UpdateAuthData")
+		return result, err
+	}
+	return s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdateFailedPasswordAttempts")
+		return err
+	}
+	return s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateLastPictureUpdate(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdateLastPictureUpdate")
+		return err
+	}
+	return s.UserStore.UpdateLastPictureUpdate(userId)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateMfaActive(userId string, active bool) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdateMfaActive")
+		return err
+	}
+	return s.UserStore.UpdateMfaActive(userId, active)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateMfaSecret(userId string, secret string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdateMfaSecret")
+		return err
+	}
+	return s.UserStore.UpdateMfaSecret(userId, secret)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdatePassword(userId string, newPassword string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdatePassword")
+		return err
+	}
+	return s.UserStore.UpdatePassword(userId, newPassword)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserStore.UpdatePasswordHash")
+		return err
+	}
+	return s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+}
+
+func (s *ReadOnlyLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
+	if s.Root.IsReadOnly() {
+		var result int64
+		err := store.NewErrReadOnly("UserStore.UpdateUpdateAt")
+		return result, err
+	}
+	return s.UserStore.UpdateUpdateAt(userId)
+}
+
+func (s *ReadOnlyLayerUserStore) VerifyEmail(userId string, email string) (string, error) {
+	if s.Root.IsReadOnly() {
+		var result string
+		err := store.NewErrReadOnly("UserStore.VerifyEmail")
+		return result, err
+	}
+	return s.UserStore.VerifyEmail(userId, email)
+}
+
+func (s *ReadOnlyLayerUserAccessTokenStore) Delete(tokenId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserAccessTokenStore.Delete")
+		return err
+	}
+	return s.UserAccessTokenStore.Delete(tokenId)
+}
+
+func (s *ReadOnlyLayerUserAccessTokenStore) DeleteAllForUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserAccessTokenStore.DeleteAllForUser")
+		return err
+	}
+	return s.UserAccessTokenStore.DeleteAllForUser(userId)
+}
+
+func (s *ReadOnlyLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.UserAccessToken
+		err := store.NewErrReadOnly("UserAccessTokenStore.Save")
+		return result, err
+	}
+	return s.UserAccessTokenStore.Save(token)
+}
+
+func (s *ReadOnlyLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserAccessTokenStore.UpdateTokenDisable")
+		return err
+	}
+	return s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+}
+
+func (s *ReadOnlyLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserAccessTokenStore.UpdateTokenEnable")
+		return err
+	}
+	return s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+}
+
+func (s *ReadOnlyLayerUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("UserTermsOfServiceStore.Delete")
+		return err
+	}
+	return s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+}
+
+func (s *ReadOnlyLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.UserTermsOfService
+		err := store.NewErrReadOnly("UserTermsOfServiceStore.Save")
+		return result, err
+	}
+	return s.UserTermsOfServiceStore.Save(userTermsOfService)
+}
+
+func (s *ReadOnlyLayerWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.DeleteIncoming")
+		return err
+	}
+	return s.WebhookStore.DeleteIncoming(webhookId, time)
+}
+
+func (s *ReadOnlyLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.DeleteOutgoing")
+		return err
+	}
+	return s.WebhookStore.DeleteOutgoing(webhookId, time)
+}
+
+func (s *ReadOnlyLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.PermanentDeleteIncomingByChannel")
+		return err
+	}
+	return s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+}
+
+func (s *ReadOnlyLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.PermanentDeleteIncomingByUser")
+		return err
+	}
+	return s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+}
+
+func (s *ReadOnlyLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.PermanentDeleteOutgoingByChannel")
+		return err
+	}
+	return s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+}
+
+func (s *ReadOnlyLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+	if s.Root.IsReadOnly() {
+
+		err := store.NewErrReadOnly("WebhookStore.PermanentDeleteOutgoingByUser")
+		return err
+	}
+	return s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+}
+
+func (s *ReadOnlyLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.IncomingWebhook
+		err := store.NewErrReadOnly("WebhookStore.SaveIncoming")
+		return result, err
+	}
+	return s.WebhookStore.SaveIncoming(webhook)
+}
+
+func (s *ReadOnlyLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.OutgoingWebhook
+		err := store.NewErrReadOnly("WebhookStore.SaveOutgoing")
+		return result, err
+	}
+	return s.WebhookStore.SaveOutgoing(webhook)
+}
+
+func (s *ReadOnlyLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.IncomingWebhook
+		err := store.NewErrReadOnly("WebhookStore.UpdateIncoming")
+		return result, err
+	}
+	return s.WebhookStore.UpdateIncoming(webhook)
+}
+
+func (s *ReadOnlyLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	if s.Root.IsReadOnly() {
+		var result *model.OutgoingWebhook
+		err := store.NewErrReadOnly("WebhookStore.UpdateOutgoing")
+		return result, err
+	}
+	return s.WebhookStore.UpdateOutgoing(hook)
+}
+
+func (s *ReadOnlyLayer) Close() {
+
+	s.Store.Close()
+}
+
+func (s *ReadOnlyLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+
+	if s.IsReadOnly() {
+
+		var result int64
+		err := store.NewErrReadOnly("Store.DeleteOrphans")
+		return result, err
+
+	}
+
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
+func (s *ReadOnlyLayer) DropAllTables() {
+
+	if s.IsReadOnly() {
+
+		mlog.Warn("Skipping a change while the store is read-only", mlog.String("operation", "Store.DropAllTables"))
+		return
+
+	}
+
+	s.Store.DropAllTables()
+}
+
+func (s *ReadOnlyLayer) GetCurrentSchemaVersion() string {
+
+	return s.Store.GetCurrentSchemaVersion()
+}
+
+func (s *ReadOnlyLayer) LockToMaster() {
+
+	s.Store.LockToMaster()
+}
+
+func (s *ReadOnlyLayer) MarkSystemRanUnitTests() {
+
+	if s.IsReadOnly() {
+
+		mlog.Warn("Skipping a change while the store is read-only", mlog.String("operation", "Store.MarkSystemRanUnitTests"))
+		return
+
+	}
+
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *ReadOnlyLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+
+	if s.IsReadOnly() {
+
+		var result int64
+		err := store.NewErrReadOnly("Store.PermanentDeleteBatchForRetention")
+		return result, err
+
+	}
+
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
+func (s *ReadOnlyLayer) SetContext(context context.Context) {
+
+	s.Store.SetContext(context)
+}
+
+func (s *ReadOnlyLayer) TotalMasterDbConnections() int {
+
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *ReadOnlyLayer) TotalReadDbConnections() int {
+
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *ReadOnlyLayer) TotalSearchDbConnections() int {
+
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *ReadOnlyLayer) UnlockFromMaster() {
+
+	s.Store.UnlockFromMaster()
+}
+
+// New wraps the child store with the read-only layer, rejecting the changes made through every
+// store but the cluster discovery one while read-only mode is on. It starts off.
+func New(childStore store.Store) *ReadOnlyLayer {
+	newStore := ReadOnlyLayer{
+		Store: childStore,
+	}
+
+	newStore.AuditStore = &ReadOnlyLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &ReadOnlyLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
+	newStore.BotStore = &ReadOnlyLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &ReadOnlyLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &ReadOnlyLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &ReadOnlyLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &ReadOnlyLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &ReadOnlyLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &ReadOnlyLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.EmojiStore = &ReadOnlyLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.FileInfoStore = &ReadOnlyLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &ReadOnlyLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &ReadOnlyLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &ReadOnlyLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &ReadOnlyLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.OAuthStore = &ReadOnlyLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.PluginStore = &ReadOnlyLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &ReadOnlyLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PreferenceStore = &ReadOnlyLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &ReadOnlyLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.ReactionStore = &ReadOnlyLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RoleStore = &ReadOnlyLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SchemeStore = &ReadOnlyLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &ReadOnlyLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.StatusStore = &ReadOnlyLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &ReadOnlyLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &ReadOnlyLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &ReadOnlyLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &ReadOnlyLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &ReadOnlyLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &ReadOnlyLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &ReadOnlyLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &ReadOnlyLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &ReadOnlyLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &ReadOnlyLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	return &newStore
+}
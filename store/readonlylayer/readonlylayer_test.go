@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package readonlylayer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestReadOnlyLayer(t *testing.T) {
+	t.Run("lets the changes through when off", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("UpdatePassword", "user", "hash").Return(nil).Once()
+
+		layer := New(mockStore)
+		require.False(t, layer.IsReadOnly())
+		require.NoError(t, layer.User().UpdatePassword("user", "hash"))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects the changes when on", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		err := layer.User().UpdatePassword("user", "hash")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}))
+
+		_, appErr := layer.Group().Create(&model.Group{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusServiceUnavailable, appErr.StatusCode)
+
+		mockStore.UserStore.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+		mockStore.GroupStore.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("lets the reads through when on", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("Get", "user").Return(&model.User{Id: "user"}, nil).Once()
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		user, err := layer.User().Get("user")
+		require.NoError(t, err)
+		assert.Equal(t, "user", user.Id)
+	})
+
+	t.Run("lets the cluster discovery through when on", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.ClusterDiscoveryStore.On("SetLastPingAt", mock.Anything).Return(nil).Once()
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		require.NoError(t, layer.ClusterDiscovery().SetLastPingAt(&model.ClusterDiscovery{}))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects the changes of the methods without a write prefix when on", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		_, err := layer.Job().ClaimPending(model.JOB_TYPE_DATA_RETENTION, "node")
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "ClaimPending")
+		_, err = layer.Post().MoveThread("root", "channel", false)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "MoveThread")
+		_, err = layer.Plugin().CompareAndSet(&model.PluginKeyValue{}, nil)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "CompareAndSet")
+		_, err = layer.Plugin().CompareAndDelete(&model.PluginKeyValue{}, nil)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "CompareAndDelete")
+		err = layer.CommandWebhook().TryUse("hook", 1)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "TryUse")
+		_, err = layer.User().VerifyEmail("user", "user@example.com")
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "VerifyEmail")
+		err = layer.ProductNotices().View("user", []string{"notice"})
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "View")
+
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects the changes of the root store when on", func(t *testing.T) {
+		mockStore := &rootStore{}
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		_, err := layer.PermanentDeleteBatchForRetention(30, 100)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "PermanentDeleteBatchForRetention")
+		_, err = layer.DeleteOrphans(model.ORPHAN_TYPE_CHANNEL_MEMBER, 100)
+		assert.True(t, errors.Is(err, &store.ErrReadOnly{}), "DeleteOrphans")
+		layer.DropAllTables()
+		assert.Empty(t, mockStore.calls)
+
+		layer.SetReadOnly(false)
+		layer.DropAllTables()
+		assert.Equal(t, []string{"DropAllTables"}, mockStore.calls)
+	})
+
+	t.Run("lets the reads with a write prefix through when on", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.ReactionStore.On("BulkGetForPosts", []string{"post"}).Return([]*model.Reaction{}, nil).Once()
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+
+		_, err := layer.Reaction().BulkGetForPosts([]string{"post"})
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("lets the changes through once off again", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		mockStore.UserStore.On("UpdatePassword", "user", "hash").Return(nil).Once()
+
+		layer := New(mockStore)
+		layer.SetReadOnly(true)
+		layer.SetReadOnly(false)
+
+		require.NoError(t, layer.User().UpdatePassword("user", "hash"))
+		mockStore.AssertExpectations(t)
+	})
+}
+
+// rootStore records the calls to the methods of the root store changing data.
+type rootStore struct {
+	storetest.Store
+	calls []string
+}
+
+func (s *rootStore) DropAllTables() {
+	s.calls = append(s.calls, "DropAllTables")
+}
+
+func (s *rootStore) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	s.calls = append(s.calls, "PermanentDeleteBatchForRetention")
+	return 0, nil
+}
+
+func (s *rootStore) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	s.calls = append(s.calls, "DeleteOrphans")
+	return 0, nil
+}
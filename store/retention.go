@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// PermanentDeleteBatchFunc permanently deletes up to limit rows created before endTime and
+// returns how many were deleted, as PostStore.PermanentDeleteBatch does.
+type PermanentDeleteBatchFunc func(endTime int64, limit int64) (int64, error)
+
+// PermanentDeleteInBatches calls deleteBatch with batches of options.BatchSize rows, waiting
+// options.TimeBetweenBatches between them, until a batch isn't full or options.MaxBatches
+// batches were deleted. It returns the number of rows deleted by every batch, and whether no
+// row was left, calling it again resuming the deletion otherwise.
+func PermanentDeleteInBatches(deleteBatch PermanentDeleteBatchFunc, endTime int64, options model.RetentionBatchOptions) ([]int64, bool, error) {
+	if options.BatchSize <= 0 {
+		return nil, false, NewErrInvalidInput("Retention", "<options.BatchSize>", options.BatchSize)
+	}
+	if options.MaxBatches < 0 {
+		return nil, false, NewErrInvalidInput("Retention", "<options.MaxBatches>", options.MaxBatches)
+	}
+
+	var deleted []int64
+	for options.MaxBatches == 0 || len(deleted) < options.MaxBatches {
+		if len(deleted) > 0 && options.TimeBetweenBatches > 0 {
+			time.Sleep(options.TimeBetweenBatches)
+		}
+
+		count, err := deleteBatch(endTime, int64(options.BatchSize))
+		if err != nil {
+			return deleted, false, err
+		}
+		deleted = append(deleted, count)
+
+		if count < int64(options.BatchSize) {
+			return deleted, true, nil
+		}
+	}
+
+	return deleted, false, nil
+}
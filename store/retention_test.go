@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// rowsBefore fakes a table of rows created at the given times.
+type rowsBefore struct {
+	createAts []int64
+	limits    []int64
+}
+
+func (r *rowsBefore) permanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	r.limits = append(r.limits, limit)
+
+	var kept []int64
+	var deleted int64
+	for _, createAt := range r.createAts {
+		if createAt < endTime && deleted < limit {
+			deleted++
+			continue
+		}
+		kept = append(kept, createAt)
+	}
+	r.createAts = kept
+	return deleted, nil
+}
+
+func TestPermanentDeleteInBatches(t *testing.T) {
+	newRows := func() *rowsBefore {
+		rows := &rowsBefore{}
+		for i := int64(0); i < 25; i++ {
+			rows.createAts = append(rows.createAts, i)
+		}
+		return rows
+	}
+
+	t.Run("deletes until no row is left", func(t *testing.T) {
+		rows := newRows()
+		deleted, done, err := PermanentDeleteInBatches(rows.permanentDeleteBatch, 20, model.RetentionBatchOptions{BatchSize: 5})
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, []int64{5, 5, 5, 5, 0}, deleted)
+		assert.Equal(t, []int64{5, 5, 5, 5, 5}, rows.limits)
+		assert.Len(t, rows.createAts, 5)
+	})
+
+	t.Run("stops at the batch limit and resumes", func(t *testing.T) {
+		rows := newRows()
+		options := model.RetentionBatchOptions{BatchSize: 4, MaxBatches: 2}
+
+		deleted, done, err := PermanentDeleteInBatches(rows.permanentDeleteBatch, 10, options)
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, []int64{4, 4}, deleted)
+		assert.Len(t, rows.createAts, 17)
+
+		deleted, done, err = PermanentDeleteInBatches(rows.permanentDeleteBatch, 10, options)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, []int64{2}, deleted)
+		assert.Len(t, rows.createAts, 15)
+		assert.EqualValues(t, 10, rows.createAts[0])
+	})
+
+	t.Run("waits between batches", func(t *testing.T) {
+		rows := newRows()
+		var calls []time.Time
+		deleteBatch := func(endTime int64, limit int64) (int64, error) {
+			calls = append(calls, time.Now())
+			return rows.permanentDeleteBatch(endTime, limit)
+		}
+
+		start := time.Now()
+		_, done, err := PermanentDeleteInBatches(deleteBatch, 10, model.RetentionBatchOptions{BatchSize: 5, TimeBetweenBatches: 20 * time.Millisecond})
+		require.NoError(t, err)
+		assert.True(t, done)
+		require.Len(t, calls, 3)
+		assert.Less(t, int64(calls[0].Sub(start)), int64(20*time.Millisecond))
+		assert.GreaterOrEqual(t, int64(calls[1].Sub(calls[0])), int64(20*time.Millisecond))
+		assert.GreaterOrEqual(t, int64(calls[2].Sub(calls[1])), int64(20*time.Millisecond))
+	})
+
+	t.Run("returns the batches deleted before an error", func(t *testing.T) {
+		rows := newRows()
+		deleteBatch := func(endTime int64, limit int64) (int64, error) {
+			if len(rows.limits) == 2 {
+				return 0, errors.New("locked")
+			}
+			return rows.permanentDeleteBatch(endTime, limit)
+		}
+
+		deleted, done, err := PermanentDeleteInBatches(deleteBatch, 20, model.RetentionBatchOptions{BatchSize: 5})
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Equal(t, []int64{5, 5}, deleted)
+	})
+
+	t.Run("rejects invalid options", func(t *testing.T) {
+		_, _, err := PermanentDeleteInBatches(newRows().permanentDeleteBatch, 20, model.RetentionBatchOptions{})
+		var invErr *ErrInvalidInput
+		assert.True(t, errors.As(err, &invErr))
+
+		_, _, err = PermanentDeleteInBatches(newRows().permanentDeleteBatch, 20, model.RetentionBatchOptions{BatchSize: 5, MaxBatches: -1})
+		assert.True(t, errors.As(err, &invErr))
+	})
+}
@@ -21,6 +21,7 @@ const mySQLDeadlockCode = uint16(1213)
 type RetryLayer struct {
 	store.Store
 	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
 	BotStore                  store.BotStore
 	ChannelStore              store.ChannelStore
 	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
@@ -60,6 +61,10 @@ func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *RetryLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
 func (s *RetryLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -197,6 +202,11 @@ type RetryLayerAuditStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *RetryLayer
+}
+
 type RetryLayerBotStore struct {
 	store.BotStore
 	Root *RetryLayer
@@ -438,6 +448,46 @@ func (s *RetryLayerAuditStore) Save(audit *model.Audit) error {
 
 }
 
+func (s *RetryLayerAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+
+	tries := 0
+	for {
+		err := s.AuditTrailStore.Record(entry, change)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
 
 	tries := 0
@@ -1024,6 +1074,26 @@ func (s *RetryLayerChannelStore) GetByNames(team_id string, names []string, allo
 
 }
 
+func (s *RetryLayerChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
 
 	tries := 0
@@ -1184,6 +1254,46 @@ func (s *RetryLayerChannelStore) GetChannelsByScheme(schemeId string, offset int
 
 }
 
+func (s *RetryLayerChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
 
 	tries := 0
@@ -1450,11 +1560,31 @@ func (s *RetryLayerChannelStore) GetMembersForUserWithPagination(teamId string,
 
 }
 
-func (s *RetryLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+func (s *RetryLayerChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+		result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+	}
+
+}
+
+func (s *RetryLayerChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMembersSince(channelId, since)
 		if err == nil {
 			return result, nil
 		}
@@ -1470,11 +1600,11 @@ func (s *RetryLayerChannelStore) GetMoreChannels(teamId string, userId string, o
 
 }
 
-func (s *RetryLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+func (s *RetryLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+		result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -1490,11 +1620,11 @@ func (s *RetryLayerChannelStore) GetPinnedPostCount(channelId string, allowFromC
 
 }
 
-func (s *RetryLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+func (s *RetryLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ChannelStore.GetPinnedPosts(channelId)
+		result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -1650,6 +1780,26 @@ func (s *RetryLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelL
 
 }
 
+func (s *RetryLayerChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
 
 	tries := 0
@@ -2018,6 +2168,26 @@ func (s *RetryLayerChannelStore) SaveMember(member *model.ChannelMember) (*model
 
 }
 
+func (s *RetryLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 
 	tries := 0
@@ -2218,6 +2388,26 @@ func (s *RetryLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId
 
 }
 
+func (s *RetryLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
 
 	tries := 0
@@ -3064,6 +3254,26 @@ func (s *RetryLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 
 }
 
+func (s *RetryLayerEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 
 	tries := 0
@@ -3170,6 +3380,26 @@ func (s *RetryLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 
 }
 
+func (s *RetryLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.GetByIds(ids)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 
 	tries := 0
@@ -3336,6 +3566,26 @@ func (s *RetryLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 
 }
 
+func (s *RetryLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) SetContent(fileId string, content string) error {
 
 	tries := 0
@@ -3640,6 +3890,26 @@ func (s *RetryLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 
 }
 
+func (s *RetryLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+
+	tries := 0
+	for {
+		result, err := s.JobStore.ClaimPending(jobType, nodeId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerJobStore) Delete(id string) (string, error) {
 
 	tries := 0
@@ -4540,11 +4810,11 @@ func (s *RetryLayerPluginStore) SetWithOptions(pluginId string, key string, valu
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+func (s *RetryLayerPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+		result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
 		if err == nil {
 			return result, nil
 		}
@@ -4560,11 +4830,11 @@ func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile boo
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
 		if err == nil {
 			return result, nil
 		}
@@ -4580,11 +4850,31 @@ func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 
 }
 
-func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+func (s *RetryLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+		result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
 		if err == nil {
 			return result, nil
 		}
@@ -4626,11 +4916,263 @@ func (s *RetryLayerPostStore) Delete(postId string, time int64, deleteByID strin
 
 }
 
-func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Get(id, skipFetchThreads)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
+
+	return s.PostStore.GetEtag(channelId, allowFromCache)
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetMaxPostSize() int {
+
+	return s.PostStore.GetMaxPostSize()
+
+}
+
+func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetOldest()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetOldestEntityCreationTime()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPinnedPosts(channelId)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPost(id, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostAfterTime(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Get(id, skipFetchThreads)
+		result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
 		if err == nil {
 			return result, nil
 		}
@@ -4646,11 +5188,11 @@ func (s *RetryLayerPostStore) Get(id string, skipFetchThreads bool) (*model.Post
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+		result, err := s.PostStore.GetPostWithContext(postId, before, after)
 		if err == nil {
 			return result, nil
 		}
@@ -4666,17 +5208,11 @@ func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 
 }
 
-func (s *RetryLayerPostStore) GetEtag(channelId string, allowFromCache bool) string {
-
-	return s.PostStore.GetEtag(channelId, allowFromCache)
-
-}
-
-func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+		result, err := s.PostStore.GetPosts(options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4692,11 +5228,11 @@ func (s *RetryLayerPostStore) GetFlaggedPosts(userId string, offset int, limit i
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+		result, err := s.PostStore.GetPostsAfter(options)
 		if err == nil {
 			return result, nil
 		}
@@ -4712,37 +5248,31 @@ func (s *RetryLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId
 
 }
 
-func (s *RetryLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+		result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetMaxPostSize() int {
-
-	return s.PostStore.GetMaxPostSize()
-
-}
-
-func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldest()
+		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4758,31 +5288,31 @@ func (s *RetryLayerPostStore) GetOldest() (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+func (s *RetryLayerPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetOldestEntityCreationTime()
+		err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+		result, err := s.PostStore.GetPostsBefore(options)
 		if err == nil {
 			return result, nil
 		}
@@ -4798,31 +5328,31 @@ func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterId string
 
 }
 
-func (s *RetryLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+func (s *RetryLayerPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostAfterTime(channelId, time)
+		result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+		result, err := s.PostStore.GetPostsByIds(postIds)
 		if err == nil {
 			return result, nil
 		}
@@ -4838,11 +5368,11 @@ func (s *RetryLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (
 
 }
 
-func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+func (s *RetryLayerPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+		result, err := s.PostStore.GetPostsByProp(channelId, key, value)
 		if err == nil {
 			return result, nil
 		}
@@ -4858,11 +5388,11 @@ func (s *RetryLayerPostStore) GetPostIdBeforeTime(channelId string, time int64)
 
 }
 
-func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPosts(options, allowFromCache)
+		result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
 		if err == nil {
 			return result, nil
 		}
@@ -4878,11 +5408,11 @@ func (s *RetryLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 
 }
 
-func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsAfter(options)
+		result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4898,11 +5428,11 @@ func (s *RetryLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*mod
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4918,11 +5448,11 @@ func (s *RetryLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime
 
 }
 
-func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsBefore(options)
+		result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -4938,31 +5468,31 @@ func (s *RetryLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*mo
 
 }
 
-func (s *RetryLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsByIds(postIds)
+		result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
 		if err == nil {
-			return result, nil
+			return result, resultVar1, nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return result, resultVar1, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return result, resultVar1, err
 		}
 	}
 
 }
 
-func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+		result, err := s.PostStore.GetRepliesForExport(parentId)
 		if err == nil {
 			return result, nil
 		}
@@ -4978,11 +5508,11 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([
 
 }
 
-func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+		result, err := s.PostStore.GetSingle(id)
 		if err == nil {
 			return result, nil
 		}
@@ -4998,11 +5528,11 @@ func (s *RetryLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 
 }
 
-func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+func (s *RetryLayerPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetRepliesForExport(parentId)
+		result, err := s.PostStore.GetSingleCtx(ctx, id)
 		if err == nil {
 			return result, nil
 		}
@@ -5018,11 +5548,17 @@ func (s *RetryLayerPostStore) GetRepliesForExport(parentId string) ([]*model.Rep
 
 }
 
-func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
+func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
+
+	s.PostStore.InvalidateLastPostTimeCache(channelId)
+
+}
+
+func (s *RetryLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetSingle(id)
+		result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
 		if err == nil {
 			return result, nil
 		}
@@ -5038,12 +5574,6 @@ func (s *RetryLayerPostStore) GetSingle(id string) (*model.Post, error) {
 
 }
 
-func (s *RetryLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
-
-	s.PostStore.InvalidateLastPostTimeCache(channelId)
-
-}
-
 func (s *RetryLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 
 	tries := 0
@@ -5204,6 +5734,26 @@ func (s *RetryLayerPostStore) Search(teamId string, userId string, params *model
 
 }
 
+func (s *RetryLayerPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
 
 	tries := 0
@@ -5224,6 +5774,46 @@ func (s *RetryLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.Searc
 
 }
 
+func (s *RetryLayerPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
+func (s *RetryLayerPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 
 	tries := 0
@@ -5424,6 +6014,26 @@ func (s *RetryLayerPreferenceStore) Save(preferences *model.Preferences) error {
 
 }
 
+func (s *RetryLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
 
 	tries := 0
@@ -5584,6 +6194,26 @@ func (s *RetryLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 
 }
 
+func (s *RetryLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetForPosts(postIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 
 	tries := 0
@@ -7056,6 +7686,26 @@ func (s *RetryLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.T
 
 }
 
+func (s *RetryLayerTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+
+	tries := 0
+	for {
+		result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
 
 	tries := 0
@@ -7802,11 +8452,11 @@ func (s *RetryLayerThreadStore) GetMembershipsForUser(userId string) ([]*model.T
 
 }
 
-func (s *RetryLayerThreadStore) GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+func (s *RetryLayerThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
 
 	tries := 0
 	for {
-		result, err := s.ThreadStore.GetThreadsForUser(userId, opts)
+		result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -8992,6 +9642,26 @@ func (s *RetryLayerUserStore) GetUnreadCountForChannel(userId string, channelId
 
 }
 
+func (s *RetryLayerUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUser(id, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+	}
+
+}
+
 func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
 
 	tries := 0
@@ -9390,6 +10060,26 @@ func (s *RetryLayerUserStore) UpdatePassword(userId string, newPassword string)
 
 }
 
+func (s *RetryLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+	}
+
+}
+
 func (s *RetryLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
 
 	tries := 0
@@ -10206,6 +10896,10 @@ func (s *RetryLayer) Close() {
 	s.Store.Close()
 }
 
+func (s *RetryLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
 func (s *RetryLayer) DropAllTables() {
 	s.Store.DropAllTables()
 }
@@ -10222,6 +10916,10 @@ func (s *RetryLayer) MarkSystemRanUnitTests() {
 	s.Store.MarkSystemRanUnitTests()
 }
 
+func (s *RetryLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
 func (s *RetryLayer) SetContext(context context.Context) {
 	s.Store.SetContext(context)
 }
@@ -10248,6 +10946,7 @@ func New(childStore store.Store) *RetryLayer {
 	}
 
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &RetryLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
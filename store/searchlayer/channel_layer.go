@@ -91,6 +91,20 @@ func (c *SearchChannelStore) SaveMember(cm *model.ChannelMember) (*model.Channel
 	return member, err
 }
 
+func (c *SearchChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	created, updated, err := c.ChannelStore.SaveMemberMultiple(members)
+	if err == nil {
+		indexedUsers := map[string]bool{}
+		for _, member := range append(created, updated...) {
+			if !indexedUsers[member.UserId] {
+				indexedUsers[member.UserId] = true
+				c.rootStore.indexUserFromID(member.UserId)
+			}
+		}
+	}
+	return created, updated, err
+}
+
 func (c *SearchChannelStore) RemoveMember(channelId, userIdToRemove string) error {
 	err := c.ChannelStore.RemoveMember(channelId, userIdToRemove)
 	if err == nil {
@@ -163,7 +177,9 @@ func (c *SearchChannelStore) AutocompleteInTeam(teamId string, term string, incl
 }
 
 func (c *SearchChannelStore) searchAutocompleteChannels(engine searchengine.SearchEngineInterface, teamId, term string, includeDeleted bool) (*model.ChannelList, *model.AppError) {
+	span := c.rootStore.startSearchSpan(engine, "SearchChannels")
 	channelIds, err := engine.SearchChannels(teamId, term)
+	finishSearchSpan(span, len(channelIds), err)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type SearchFileInfoStore struct {
+	store.FileInfoStore
+	rootStore *SearchStore
+}
+
+// indexFile indexes the file in the channel of the post it's attached to. Files not attached
+// to a post yet aren't visible to anyone but their creator, so they're indexed when attached.
+func (s SearchFileInfoStore) indexFile(file *model.FileInfo) {
+	if file.PostId == "" {
+		return
+	}
+
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				post, postErr := s.rootStore.Post().GetSingle(file.PostId)
+				if postErr != nil {
+					mlog.Error("Couldn't get post for file for SearchEngine indexing.", mlog.String("post_id", file.PostId), mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id), mlog.Err(postErr))
+					return
+				}
+				if err := engineCopy.IndexFile(file, post.ChannelId); err != nil {
+					mlog.Error("Encountered error indexing file", mlog.String("file_info_id", file.Id), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Indexed file in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", file.Id))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) indexFileFromID(fileId string) {
+	file, err := s.FileInfoStore.Get(fileId)
+	if err != nil {
+		return
+	}
+	s.indexFile(file)
+}
+
+func (s SearchFileInfoStore) deleteFileIndex(fileID string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeleteFile(fileID); err != nil {
+					mlog.Error("Encountered error deleting file", mlog.String("file_info_id", fileID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed file from the index in search engine", mlog.String("search_engine", engineCopy.GetName()), mlog.String("file_info_id", fileID))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) deletePostFilesIndex(postID string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeletePostFiles(postID); err != nil {
+					mlog.Error("Encountered error deleting post files", mlog.String("post_id", postID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed all post files from the index in search engine", mlog.String("post_id", postID), mlog.String("search_engine", engineCopy.GetName()))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) deleteUserFilesIndex(userID string) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
+				if err := engineCopy.DeleteUserFiles(userID); err != nil {
+					mlog.Error("Encountered error deleting user files", mlog.String("user_id", userID), mlog.String("search_engine", engineCopy.GetName()), mlog.Err(err))
+					return
+				}
+				mlog.Debug("Removed all user files from the index in search engine", mlog.String("user_id", userID), mlog.String("search_engine", engineCopy.GetName()))
+			})
+		}
+	}
+}
+
+func (s SearchFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	nfile, err := s.FileInfoStore.Save(info)
+	if err == nil {
+		s.indexFile(nfile)
+	}
+	return nfile, err
+}
+
+func (s SearchFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	nfile, err := s.FileInfoStore.Upsert(info)
+	if err == nil {
+		s.indexFile(nfile)
+	}
+	return nfile, err
+}
+
+func (s SearchFileInfoStore) AttachToPost(fileId, postId, creatorId string) error {
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	if err == nil {
+		s.indexFileFromID(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) SetContent(fileId, content string) error {
+	err := s.FileInfoStore.SetContent(fileId, content)
+	if err == nil {
+		s.indexFileFromID(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) DeleteForPost(postId string) (string, error) {
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+	if err == nil {
+		s.deletePostFilesIndex(postId)
+	}
+	return result, err
+}
+
+func (s SearchFileInfoStore) PermanentDelete(fileId string) error {
+	err := s.FileInfoStore.PermanentDelete(fileId)
+	if err == nil {
+		s.deleteFileIndex(fileId)
+	}
+	return err
+}
+
+func (s SearchFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	if err == nil {
+		s.deleteUserFilesIndex(userId)
+	}
+	return result, err
+}
+
+func (s SearchFileInfoStore) searchFilesInTeamForUserByEngine(engine searchengine.SearchEngineInterface, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	// We only allow the user to search in channels they are a member of.
+	userChannels, nErr := s.rootStore.Channel().GetChannels(teamId, userId, paramsList[0].IncludeDeletedChannels, 0)
+	if nErr != nil {
+		mlog.Error("error getting channel for user", mlog.Err(nErr))
+		var nfErr *store.ErrNotFound
+		switch {
+		// TODO: This error key would go away once this store method is migrated to return plain errors
+		case errors.As(nErr, &nfErr):
+			return nil, model.NewAppError("searchFilesInTeamForUserByEngine", "app.channel.get_channels.not_found.app_error", nil, nfErr.Error(), http.StatusNotFound)
+		default:
+			return nil, model.NewAppError("searchFilesInTeamForUserByEngine", "app.channel.get_channels.get.app_error", nil, nErr.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	span := s.rootStore.startSearchSpan(engine, "SearchFiles")
+	fileIds, appErr := engine.SearchFiles(userChannels, paramsList, page, perPage)
+	finishSearchSpan(span, len(fileIds), appErr)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Get the files, keeping the order of the search results
+	fileInfoList := model.NewFileInfoList()
+	if len(fileIds) > 0 {
+		files, err := s.FileInfoStore.GetByIds(fileIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			fileInfoList.AddFileInfo(file)
+		}
+		for _, fileId := range fileIds {
+			if _, ok := fileInfoList.FileInfos[fileId]; ok {
+				fileInfoList.AddOrder(fileId)
+			}
+		}
+	}
+
+	return fileInfoList, nil
+}
+
+func (s SearchFileInfoStore) Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	var results *model.FileInfoList
+	_, err := s.rootStore.searchEngine.Search("SearchFilesInTeamForUser", func(engine searchengine.SearchEngineInterface) error {
+		var err error
+		results, err = s.searchFilesInTeamForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
+		return err
+	}, func() error {
+		if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+			return searchengine.ErrNoSearchEngine
+		}
+
+		var err error
+		results, err = s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+		return err
+	})
+	if err == searchengine.ErrNoSearchEngine {
+		mlog.Debug("Returning empty results for file Search as no search engine is available")
+		return model.NewFileInfoList(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
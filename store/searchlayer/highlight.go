@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	// HIGHLIGHT_FRAGMENT_CONTEXT is the number of characters kept around the matched terms.
+	HIGHLIGHT_FRAGMENT_CONTEXT = 40
+	// HIGHLIGHT_MAX_FRAGMENTS is the maximum number of fragments extracted from a message.
+	HIGHLIGHT_MAX_FRAGMENTS = 3
+)
+
+var quotedPhrase = regexp.MustCompile(`"([^"]+)"`)
+
+type highlightTerm struct {
+	value  []rune
+	prefix bool
+}
+
+type highlightSpan struct {
+	start, end int
+}
+
+// getHighlightTerms returns the terms to look for in the messages matching the search.
+// Plain terms also match the words they're a prefix of, as a rough approximation of stemming.
+func getHighlightTerms(paramsList []*model.SearchParams) []highlightTerm {
+	terms := []highlightTerm{}
+	for _, params := range paramsList {
+		if params.IsHashtag {
+			for _, hashtag := range strings.Fields(params.Terms) {
+				terms = append(terms, highlightTerm{value: []rune(strings.ToLower(hashtag))})
+			}
+			continue
+		}
+
+		for _, phrase := range quotedPhrase.FindAllStringSubmatch(params.Terms, -1) {
+			terms = append(terms, highlightTerm{value: []rune(strings.ToLower(phrase[1]))})
+		}
+		for _, word := range strings.Fields(quotedPhrase.ReplaceAllString(params.Terms, " ")) {
+			word = strings.TrimSuffix(sanitizeSearchTerm(word), "*")
+			if word != "" {
+				terms = append(terms, highlightTerm{value: []rune(strings.ToLower(word)), prefix: true})
+			}
+		}
+	}
+	return terms
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// findHighlightSpans returns the sorted, non overlapping spans of the message matching the terms.
+func findHighlightSpans(message []rune, terms []highlightTerm) []highlightSpan {
+	lower := make([]rune, len(message))
+	for i, r := range message {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	spans := []highlightSpan{}
+	for _, term := range terms {
+		for i := 0; i+len(term.value) <= len(lower); i++ {
+			if i > 0 && isWordRune(lower[i-1]) && isWordRune(term.value[0]) {
+				continue
+			}
+			if string(lower[i:i+len(term.value)]) != string(term.value) {
+				continue
+			}
+
+			end := i + len(term.value)
+			if term.prefix {
+				for end < len(lower) && isWordRune(lower[end]) {
+					end++
+				}
+			} else if end < len(lower) && isWordRune(lower[end]) && isWordRune(term.value[len(term.value)-1]) {
+				continue
+			}
+			spans = append(spans, highlightSpan{start: i, end: end})
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	merged := []highlightSpan{}
+	for _, span := range spans {
+		if last := len(merged) - 1; last >= 0 && span.start <= merged[last].end {
+			if span.end > merged[last].end {
+				merged[last].end = span.end
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// buildHighlightFragment returns the message between start and end, with the spans enclosed
+// in the highlight tags.
+func buildHighlightFragment(message []rune, start, end int, spans []highlightSpan) string {
+	var fragment strings.Builder
+	position := start
+	for _, span := range spans {
+		fragment.WriteString(string(message[position:span.start]))
+		fragment.WriteString(model.POST_SEARCH_HIGHLIGHT_PRE_TAG)
+		fragment.WriteString(string(message[span.start:span.end]))
+		fragment.WriteString(model.POST_SEARCH_HIGHLIGHT_POST_TAG)
+		position = span.end
+	}
+	fragment.WriteString(string(message[position:end]))
+	return strings.TrimSpace(fragment.String())
+}
+
+// highlightMessage extracts the fragments of the message around the terms, with the terms
+// highlighted. Matches close to each other share a fragment.
+func highlightMessage(message string, terms []highlightTerm) []string {
+	runes := []rune(message)
+	spans := findHighlightSpans(runes, terms)
+
+	fragments := []string{}
+	for i := 0; i < len(spans) && len(fragments) < HIGHLIGHT_MAX_FRAGMENTS; {
+		start := spans[i].start - HIGHLIGHT_FRAGMENT_CONTEXT
+		if start <= 0 {
+			start = 0
+		} else {
+			// Don't cut the first word of the fragment.
+			for start < spans[i].start && !unicode.IsSpace(runes[start-1]) {
+				start++
+			}
+		}
+
+		j := i + 1
+		for j < len(spans) && spans[j].start-spans[j-1].end <= 2*HIGHLIGHT_FRAGMENT_CONTEXT {
+			j++
+		}
+
+		end := spans[j-1].end + HIGHLIGHT_FRAGMENT_CONTEXT
+		if end >= len(runes) {
+			end = len(runes)
+		} else {
+			// Don't cut the last word of the fragment.
+			for end > spans[j-1].end && !unicode.IsSpace(runes[end]) {
+				end--
+			}
+		}
+
+		fragments = append(fragments, buildHighlightFragment(runes, start, end, spans[i:j]))
+		i = j
+	}
+
+	return fragments
+}
+
+// getPostSearchHighlights extracts highlighted fragments of the posts matching a search, for
+// the search backends unable to provide them.
+func getPostSearchHighlights(postList *model.PostList, paramsList []*model.SearchParams) model.PostSearchHighlights {
+	highlights := model.PostSearchHighlights{}
+
+	terms := getHighlightTerms(paramsList)
+	if len(terms) == 0 {
+		return highlights
+	}
+
+	for _, postId := range postList.Order {
+		post, ok := postList.Posts[postId]
+		if !ok {
+			continue
+		}
+		if fragments := highlightMessage(post.Message, terms); len(fragments) > 0 {
+			highlights[postId] = fragments
+		}
+	}
+
+	return highlights
+}
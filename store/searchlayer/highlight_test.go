@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestGetPostSearchHighlights(t *testing.T) {
+	newPostList := func(messages ...string) *model.PostList {
+		postList := model.NewPostList()
+		for i, message := range messages {
+			post := &model.Post{Id: string(rune('a' + i)), Message: message}
+			postList.AddPost(post)
+			postList.AddOrder(post.Id)
+		}
+		return postList
+	}
+
+	t.Run("highlights words starting with the terms", func(t *testing.T) {
+		postList := newPostList("Testing the highlights of a TEST message", "nothing here")
+		highlights := getPostSearchHighlights(postList, model.ParseSearchParams("test", 0))
+		assert.Equal(t, model.PostSearchHighlights{
+			"a": {"<mark>Testing</mark> the highlights of a <mark>TEST</mark> message"},
+		}, highlights)
+	})
+
+	t.Run("does not highlight terms in the middle of words", func(t *testing.T) {
+		postList := newPostList("contest")
+		assert.Empty(t, getPostSearchHighlights(postList, model.ParseSearchParams("test", 0)))
+	})
+
+	t.Run("highlights exact phrases and hashtags", func(t *testing.T) {
+		postList := newPostList("a quoted phrase, another quoted word and a #hashtag")
+		highlights := getPostSearchHighlights(postList, model.ParseSearchParams(`"quoted phrase" #hashtag`, 0))
+		assert.Equal(t, model.PostSearchHighlights{
+			"a": {"a <mark>quoted phrase</mark>, another quoted word and a <mark>#hashtag</mark>"},
+		}, highlights)
+	})
+
+	t.Run("highlights wildcard terms", func(t *testing.T) {
+		postList := newPostList("searching for results")
+		highlights := getPostSearchHighlights(postList, model.ParseSearchParams("sear*", 0))
+		assert.Equal(t, model.PostSearchHighlights{"a": {"<mark>searching</mark> for results"}}, highlights)
+	})
+
+	t.Run("splits long messages into fragments", func(t *testing.T) {
+		filler := strings.Repeat("lorem ipsum ", 20)
+		postList := newPostList("first match " + filler + "second match " + filler + "third match " + filler + "fourth match")
+		highlights := getPostSearchHighlights(postList, model.ParseSearchParams("match", 0))
+		assert.Len(t, highlights["a"], HIGHLIGHT_MAX_FRAGMENTS)
+		assert.True(t, strings.HasPrefix(highlights["a"][0], "first <mark>match</mark> lorem"))
+		assert.True(t, strings.HasPrefix(highlights["a"][1], "ipsum"))
+		assert.True(t, strings.HasSuffix(highlights["a"][1], "ipsum"))
+		for _, fragment := range highlights["a"] {
+			assert.Equal(t, 1, strings.Count(fragment, model.POST_SEARCH_HIGHLIGHT_PRE_TAG))
+		}
+	})
+
+	t.Run("keeps non-latin characters aligned", func(t *testing.T) {
+		postList := newPostList("Ünïcödé TËST ok")
+		highlights := getPostSearchHighlights(postList, model.ParseSearchParams("tëst", 0))
+		assert.Equal(t, model.PostSearchHighlights{"a": {"Ünïcödé <mark>TËST</mark> ok"}}, highlights)
+	})
+}
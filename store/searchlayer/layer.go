@@ -4,6 +4,8 @@
 package searchlayer
 
 import (
+	"context"
+
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/services/searchengine"
@@ -17,6 +19,7 @@ type SearchStore struct {
 	team         *SearchTeamStore
 	channel      *SearchChannelStore
 	post         *SearchPostStore
+	fileInfo     *SearchFileInfoStore
 	config       *model.Config
 }
 
@@ -28,6 +31,7 @@ func NewSearchLayer(baseStore store.Store, searchEngine *searchengine.Broker, cf
 	}
 	searchStore.channel = &SearchChannelStore{ChannelStore: baseStore.Channel(), rootStore: searchStore}
 	searchStore.post = &SearchPostStore{PostStore: baseStore.Post(), rootStore: searchStore}
+	searchStore.fileInfo = &SearchFileInfoStore{FileInfoStore: baseStore.FileInfo(), rootStore: searchStore}
 	searchStore.team = &SearchTeamStore{TeamStore: baseStore.Team(), rootStore: searchStore}
 	searchStore.user = &SearchUserStore{UserStore: baseStore.User(), rootStore: searchStore}
 
@@ -46,6 +50,10 @@ func (s *SearchStore) Post() store.PostStore {
 	return s.post
 }
 
+func (s *SearchStore) FileInfo() store.FileInfoStore {
+	return s.fileInfo
+}
+
 func (s *SearchStore) Team() store.TeamStore {
 	return s.team
 }
@@ -111,3 +119,39 @@ func runIndexFn(engine searchengine.SearchEngineInterface, indexFn func(searchen
 		})(engine)
 	}
 }
+
+// HealthCheck checks the underlying store first, and then pings every active search engine. A
+// failing engine is reported as a *store.ErrSearchDegraded, since the database alone can still
+// serve searches.
+func (s *SearchStore) HealthCheck(ctx context.Context) error {
+	if err := s.Store.HealthCheck(ctx); err != nil {
+		return err
+	}
+
+	for _, engine := range s.searchEngine.GetActiveEngines() {
+		if err := pingEngine(ctx, engine, s.config); err != nil {
+			return store.NewErrSearchDegraded(engine.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// pingEngine tests the configuration of the engine, which connects to it, and gives up once ctx
+// is done since the engines can't be cancelled themselves.
+func pingEngine(ctx context.Context, engine searchengine.SearchEngineInterface, cfg *model.Config) error {
+	result := make(chan *model.AppError, 1)
+	go func() {
+		result <- engine.TestConfig(cfg)
+	}()
+
+	select {
+	case appErr := <-result:
+		if appErr != nil {
+			return appErr
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	searchenginemocks "github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+)
+
+func TestSearchStoreHealthCheck(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	setup := func(storeErr error, engineErr *model.AppError) *SearchStore {
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(&mocks.ChannelStore{})
+		baseStore.On("Post").Return(&mocks.PostStore{})
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+		baseStore.On("HealthCheck", mock.Anything).Return(storeErr)
+
+		engine := &searchenginemocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(true)
+		engine.On("GetName").Return("bleve")
+		engine.On("TestConfig", mock.Anything).Return(engineErr)
+
+		broker := searchengine.NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(engine)
+
+		return NewSearchLayer(baseStore, broker, cfg)
+	}
+
+	t.Run("succeeds when the store and the engines are healthy", func(t *testing.T) {
+		assert.NoError(t, setup(nil, nil).HealthCheck(context.Background()))
+	})
+
+	t.Run("reports the store errors", func(t *testing.T) {
+		storeErr := errors.New("connection refused")
+		assert.Equal(t, storeErr, setup(storeErr, nil).HealthCheck(context.Background()))
+	})
+
+	t.Run("reports a failing engine as degraded search", func(t *testing.T) {
+		err := setup(nil, model.NewAppError("TestConfig", "ent.elasticsearch.test_config.connect_failed", nil, "", http.StatusInternalServerError)).HealthCheck(context.Background())
+		require.Error(t, err)
+
+		var degraded *store.ErrSearchDegraded
+		require.True(t, errors.As(err, &degraded))
+		assert.Equal(t, "bleve", degraded.Engine)
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// countSearchTerms returns the number of terms of the searches, each of their words and of their
+// channel, user and date filters counting as one term.
+func countSearchTerms(paramsList []*model.SearchParams) int {
+	count := 0
+	for _, params := range paramsList {
+		count += len(strings.Fields(params.Terms)) + len(strings.Fields(params.ExcludedTerms))
+		count += len(params.InChannels) + len(params.ExcludedChannels)
+		count += len(params.FromUsers) + len(params.ExcludedUsers)
+		for _, date := range []string{params.OnDate, params.AfterDate, params.BeforeDate, params.ExcludedDate, params.ExcludedAfterDate, params.ExcludedBeforeDate} {
+			if date != "" {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// checkSearchLimits rejects the post searches with more terms than SearchSettings.MaxTerms, or
+// paging beyond SearchSettings.MaxResultWindow, with a *store.ErrLimitExceeded. The limits apply
+// before the search reaches any engine, so that the database search honors them too.
+func (s *SearchStore) checkSearchLimits(paramsList []*model.SearchParams, page, perPage int) error {
+	settings := s.config.SearchSettings
+
+	if count := countSearchTerms(paramsList); count > *settings.MaxTerms {
+		return store.NewErrLimitExceeded("search terms", count, fmt.Sprintf("max=%d", *settings.MaxTerms))
+	}
+
+	if window := (page + 1) * perPage; window > *settings.MaxResultWindow {
+		return store.NewErrLimitExceeded("search result window", window, fmt.Sprintf("max=%d", *settings.MaxResultWindow))
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+)
+
+func TestCountSearchTerms(t *testing.T) {
+	paramsList := model.ParseSearchParams("hello world -bye in:town-square from:user1 on:2020-01-01 \"exact phrase\"", 0)
+	assert.Equal(t, 8, countSearchTerms(paramsList))
+	assert.Equal(t, 0, countSearchTerms(nil))
+}
+
+func TestSearchLimits(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	*cfg.SearchSettings.MaxTerms = 3
+	*cfg.SearchSettings.MaxResultWindow = 100
+
+	setup := func() (*SearchStore, *mocks.PostStore) {
+		postStore := &mocks.PostStore{}
+		postStore.On("SearchPostsInTeamForUser", mock.Anything, "user", "team", mock.Anything, mock.Anything).Return(model.MakePostSearchResults(model.NewPostList(), nil), nil)
+		postStore.On("SearchPostsInTeamForUserAfter", mock.Anything, "user", "team", mock.Anything, mock.Anything).Return(model.MakePostSearchResults(model.NewPostList(), nil), nil)
+		postStore.On("SearchAllTeams", "user", mock.Anything, mock.Anything).Return(model.MakePostSearchResults(model.NewPostList(), nil), nil)
+
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(&mocks.ChannelStore{})
+		baseStore.On("Post").Return(postStore)
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+
+		return NewSearchLayer(baseStore, searchengine.NewBroker(cfg, nil), cfg), postStore
+	}
+
+	requireLimitExceeded := func(t *testing.T, err error, what string) {
+		var ltErr *store.ErrLimitExceeded
+		require.True(t, errors.As(err, &ltErr))
+		assert.Equal(t, what, ltErr.What)
+	}
+
+	t.Run("searches within the limits", func(t *testing.T) {
+		searchStore, postStore := setup()
+
+		_, err := searchStore.Post().SearchPostsInTeamForUser(model.ParseSearchParams("one two three", 0), "user", "team", 1, 50)
+		require.NoError(t, err)
+		_, err = searchStore.Post().SearchPostsInTeamForUserAfter(model.ParseSearchParams("one two", 0), "user", "team", nil, 100)
+		require.NoError(t, err)
+		_, err = searchStore.Post().SearchAllTeams("user", "one", model.SearchAllTeamsOptions{PerPage: 20})
+		require.NoError(t, err)
+
+		postStore.AssertNumberOfCalls(t, "SearchPostsInTeamForUser", 1)
+		postStore.AssertNumberOfCalls(t, "SearchPostsInTeamForUserAfter", 1)
+		postStore.AssertNumberOfCalls(t, "SearchAllTeams", 1)
+	})
+
+	t.Run("rejects too many terms before searching", func(t *testing.T) {
+		searchStore, postStore := setup()
+		terms := strings.Repeat("term ", 4)
+
+		_, err := searchStore.Post().SearchPostsInTeamForUser(model.ParseSearchParams(terms, 0), "user", "team", 0, 20)
+		requireLimitExceeded(t, err, "search terms")
+		_, err = searchStore.Post().SearchPostsInTeamForUserAfter(model.ParseSearchParams(terms, 0), "user", "team", nil, 20)
+		requireLimitExceeded(t, err, "search terms")
+		_, err = searchStore.Post().SearchAllTeams("user", terms, model.SearchAllTeamsOptions{PerPage: 20})
+		requireLimitExceeded(t, err, "search terms")
+
+		postStore.AssertNotCalled(t, "SearchPostsInTeamForUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		postStore.AssertNotCalled(t, "SearchPostsInTeamForUserAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		postStore.AssertNotCalled(t, "SearchAllTeams", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects the pages beyond the result window before searching", func(t *testing.T) {
+		searchStore, postStore := setup()
+
+		_, err := searchStore.Post().SearchPostsInTeamForUser(model.ParseSearchParams("one", 0), "user", "team", 2, 50)
+		requireLimitExceeded(t, err, "search result window")
+		_, err = searchStore.Post().SearchPostsInTeamForUserAfter(model.ParseSearchParams("one", 0), "user", "team", nil, 101)
+		requireLimitExceeded(t, err, "search result window")
+		_, err = searchStore.Post().SearchAllTeams("user", "one", model.SearchAllTeamsOptions{Page: 5, PerPage: 20})
+		requireLimitExceeded(t, err, "search result window")
+
+		postStore.AssertNotCalled(t, "SearchPostsInTeamForUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		postStore.AssertNotCalled(t, "SearchPostsInTeamForUserAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		postStore.AssertNotCalled(t, "SearchAllTeams", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -6,6 +6,8 @@ package searchlayer
 import (
 	"errors"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -18,7 +20,14 @@ type SearchPostStore struct {
 	rootStore *SearchStore
 }
 
+// indexPost indexes the post, or removes it from the indexes once deleted so that the deleted
+// posts never show up in the search results.
 func (s SearchPostStore) indexPost(post *model.Post) {
+	if post.DeleteAt != 0 {
+		s.deletePostIndex(post)
+		return
+	}
+
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(engine, func(engineCopy searchengine.SearchEngineInterface) {
@@ -101,18 +110,54 @@ func (s SearchPostStore) Save(post *model.Post) (*model.Post, error) {
 	return npost, err
 }
 
+// Delete removes the post from the indexes, along with the replies deleted with it. The thread
+// is read beforehand since the deleted replies can't be read afterwards.
 func (s SearchPostStore) Delete(postId string, date int64, deletedByID string) error {
-	err := s.PostStore.Delete(postId, date, deletedByID)
+	postList, getErr := s.PostStore.Get(postId, false)
 
-	if err == nil {
-		postList, err2 := s.PostStore.Get(postId, true)
-		if postList != nil && len(postList.Order) > 0 {
-			if err2 != nil {
-				s.deletePostIndex(postList.Posts[postList.Order[0]])
-			}
+	if err := s.PostStore.Delete(postId, date, deletedByID); err != nil {
+		return err
+	}
+
+	if getErr != nil {
+		mlog.Warn("Couldn't get the deleted post to remove it from the search engines.", mlog.String("post_id", postId), mlog.Err(getErr))
+		return nil
+	}
+
+	for _, post := range postList.Posts {
+		if post.Id == postId || post.RootId == postId {
+			s.deletePostIndex(post)
 		}
 	}
-	return err
+	return nil
+}
+
+// MoveThread indexes the moved posts and their files again, in the channel they're moved to.
+func (s SearchPostStore) MoveThread(rootId, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	posts, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		if post.DeleteAt != 0 {
+			continue
+		}
+		s.indexPost(post)
+		if len(post.FileIds) == 0 {
+			continue
+		}
+		files, err := s.rootStore.FileInfo().GetForPost(post.Id, true, false, false)
+		if err != nil {
+			mlog.Error("Couldn't get the files of the moved post for SearchEngine indexing.", mlog.String("post_id", post.Id), mlog.Err(err))
+			continue
+		}
+		for _, file := range files {
+			s.rootStore.fileInfo.indexFile(file)
+		}
+	}
+
+	return posts, nil
 }
 
 func (s SearchPostStore) PermanentDeleteByUser(userID string) error {
@@ -136,7 +181,68 @@ func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.Se
 		return nil, err
 	}
 
-	// We only allow the user to search in channels they are a member of.
+	userChannels, err := s.getSearchChannels(paramsList, userId, teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	includeHighlights := paramsList[0].IncludeHighlights
+
+	var postIds []string
+	var matches model.PostSearchMatches
+	var highlights model.PostSearchHighlights
+	var appErr *model.AppError
+	span := s.rootStore.startSearchSpan(engine, "SearchPosts")
+	if highlighter, ok := engine.(searchengine.PostHighlighter); ok && includeHighlights {
+		postIds, matches, highlights, appErr = highlighter.SearchPostsWithHighlights(userChannels, paramsList, page, perPage)
+	} else {
+		postIds, matches, appErr = engine.SearchPosts(userChannels, paramsList, page, perPage)
+	}
+	finishSearchSpan(span, len(postIds), appErr)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Get the posts
+	postList := model.NewPostList()
+	if len(postIds) > 0 {
+		posts, err := s.PostStore.GetPostsByIds(postIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range posts {
+			if p.DeleteAt == 0 {
+				postList.AddPost(p)
+				postList.AddOrder(p.Id)
+			}
+		}
+	}
+
+	results := model.MakePostSearchResults(postList, matches)
+	if includeHighlights {
+		if highlights == nil {
+			highlights = getPostSearchHighlights(postList, paramsList)
+		}
+		results.Highlights = highlights
+	}
+
+	return results, nil
+}
+
+// isPinnedOnlySearch returns whether the search is limited to the pinned posts, which the engines
+// can't filter, leaving it to the database.
+func isPinnedOnlySearch(paramsList []*model.SearchParams) bool {
+	for _, params := range paramsList {
+		if params.PinnedOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// getSearchChannels returns the channels of the team the user is a member of, the only ones the
+// user is allowed to search in.
+func (s SearchPostStore) getSearchChannels(paramsList []*model.SearchParams, userId, teamId string) (*model.ChannelList, error) {
 	userChannels, nErr := s.rootStore.Channel().GetChannels(teamId, userId, paramsList[0].IncludeDeletedChannels, 0)
 	if nErr != nil {
 		mlog.Error("error getting channel for user", mlog.Err(nErr))
@@ -150,47 +256,333 @@ func (s SearchPostStore) searchPostsInTeamForUserByEngine(engine searchengine.Se
 		}
 	}
 
-	postIds, matches, err := engine.SearchPosts(userChannels, paramsList, page, perPage)
+	return userChannels, nil
+}
+
+func (s SearchPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
+	if err := s.rootStore.checkSearchLimits(paramsList, page, perPage); err != nil {
+		return nil, err
+	}
+
+	var results *model.PostSearchResults
+	engineName, err := s.rootStore.searchEngine.Search("SearchPostsInTeamForUser", func(engine searchengine.SearchEngineInterface) error {
+		if isPinnedOnlySearch(paramsList) {
+			return searchengine.ErrSkipEngine
+		}
+
+		var err error
+		results, err = s.searchPostsInTeamForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
+		return err
+	}, func() error {
+		if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+			return searchengine.ErrNoSearchEngine
+		}
+
+		var err error
+		if results, err = s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage); err != nil {
+			return err
+		}
+		if len(paramsList) > 0 && paramsList[0].IncludeHighlights {
+			results.Highlights = getPostSearchHighlights(results.PostList, paramsList)
+		}
+		return nil
+	})
+	if err == searchengine.ErrNoSearchEngine {
+		mlog.Debug("Returning empty results for post SearchPostsInTeam as no search engine is available")
+		return &model.PostSearchResults{PostList: model.NewPostList(), Matches: model.PostSearchMatches{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	results.SearchEngine = engineName
+	return results, nil
+}
+
+func (s SearchPostStore) searchPostsInTeamForUserAfterByEngine(searcher searchengine.PostCursorSearcher, paramsList []*model.SearchParams, userId, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	userChannels, err := s.getSearchChannels(paramsList, userId, teamId)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the posts
+	span := s.rootStore.startSearchSpan(searcher, "SearchPostsAfter")
+	postIds, matches, nextCursor, appErr := searcher.SearchPostsAfter(userChannels, paramsList, searchAfter, perPage)
+	finishSearchSpan(span, len(postIds), appErr)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Keep the order of the engine, which the cursor relies on.
 	postList := model.NewPostList()
 	if len(postIds) > 0 {
 		posts, err := s.PostStore.GetPostsByIds(postIds)
 		if err != nil {
 			return nil, err
 		}
+		postsById := make(map[string]*model.Post, len(posts))
 		for _, p := range posts {
-			if p.DeleteAt == 0 {
+			postsById[p.Id] = p
+		}
+		for _, postId := range postIds {
+			if p, ok := postsById[postId]; ok && p.DeleteAt == 0 {
 				postList.AddPost(p)
 				postList.AddOrder(p.Id)
 			}
 		}
 	}
 
-	return model.MakePostSearchResults(postList, matches), nil
+	results := model.MakePostSearchResults(postList, matches)
+	results.NextCursor = nextCursor
+	return results, nil
 }
 
-func (s SearchPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
-		if engine.IsSearchEnabled() {
-			results, err := s.searchPostsInTeamForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
-			if err != nil {
-				mlog.Error("Encountered error on SearchPostsInTeamForUser.", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
-				continue
+// SearchPostsInTeamForUserAfter pages through the results with the first active engine able to
+// search with a cursor, falling back to the database search otherwise. A cursor is only
+// meaningful to the engine that returned it.
+func (s SearchPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	if err := s.rootStore.checkSearchLimits(paramsList, 0, perPage); err != nil {
+		return nil, err
+	}
+
+	var results *model.PostSearchResults
+	engineName, err := s.rootStore.searchEngine.Search("SearchPostsInTeamForUserAfter", func(engine searchengine.SearchEngineInterface) error {
+		searcher, ok := engine.(searchengine.PostCursorSearcher)
+		if !ok || isPinnedOnlySearch(paramsList) {
+			return searchengine.ErrSkipEngine
+		}
+
+		var err error
+		results, err = s.searchPostsInTeamForUserAfterByEngine(searcher, paramsList, userId, teamId, searchAfter, perPage)
+		return err
+	}, func() error {
+		if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+			return searchengine.ErrNoSearchEngine
+		}
+
+		var err error
+		results, err = s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+		return err
+	})
+	if err == searchengine.ErrNoSearchEngine {
+		mlog.Debug("Returning empty results for post SearchPostsInTeamForUserAfter as no search engine is available")
+		return &model.PostSearchResults{PostList: model.NewPostList(), Matches: model.PostSearchMatches{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	results.SearchEngine = engineName
+	return results, nil
+}
+
+func (s SearchPostStore) searchAllTeamsByEngine(searcher searchengine.AllChannelsPostSearcher, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	paramsList := []*model.SearchParams{}
+	for _, params := range model.ParseSearchParams(strings.TrimSpace(terms), opts.TimeZoneOffset) {
+		// Don't allow users to search for "*"
+		if params.Terms == "*" {
+			continue
+		}
+		params.OrTerms = opts.IsOrSearch
+		params.IncludeDeletedChannels = opts.IncludeDeletedChannels
+
+		// The engines filter the channels and users by id, which the names resolve to in any team.
+		var err error
+		if params.InChannels, err = s.getChannelIdsByNames(params.InChannels); err != nil {
+			return nil, err
+		}
+		if params.ExcludedChannels, err = s.getChannelIdsByNames(params.ExcludedChannels); err != nil {
+			return nil, err
+		}
+		if params.FromUsers, err = s.getUserIdsByUsernames(params.FromUsers); err != nil {
+			return nil, err
+		}
+		if params.ExcludedUsers, err = s.getUserIdsByUsernames(params.ExcludedUsers); err != nil {
+			return nil, err
+		}
+		paramsList = append(paramsList, params)
+	}
+
+	if len(paramsList) == 0 {
+		return model.MakePostSearchResults(model.NewPostList(), nil), nil
+	}
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	span := s.rootStore.startSearchSpan(searcher, "SearchPostsInAllChannels")
+	postIds, matches, appErr := searcher.SearchPostsInAllChannels(paramsList, opts.Page, opts.PerPage)
+	finishSearchSpan(span, len(postIds), appErr)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	postList := model.NewPostList()
+	if len(postIds) > 0 {
+		posts, err := s.PostStore.GetPostsByIds(postIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range posts {
+			if p.DeleteAt == 0 {
+				postList.AddPost(p)
+				postList.AddOrder(p.Id)
 			}
-			mlog.Debug("Using the first available search engine", mlog.String("search_engine", engine.GetName()))
-			return results, err
 		}
 	}
 
-	if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
-		mlog.Debug("Returning empty results for post SearchPostsInTeam as the database search is disabled")
+	return model.MakePostSearchResults(postList, matches), nil
+}
+
+func (s SearchPostStore) getChannelIdsByNames(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return names, nil
+	}
+
+	channels, err := s.rootStore.Channel().GetByNames("", names, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, channel := range channels {
+		ids = append(ids, channel.Id)
+	}
+	return ids, nil
+}
+
+func (s SearchPostStore) getUserIdsByUsernames(usernames []string) ([]string, error) {
+	if len(usernames) == 0 {
+		return usernames, nil
+	}
+
+	users, err := s.rootStore.User().GetProfilesByUsernames(usernames, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids, nil
+}
+
+// SearchAllTeams searches the posts of every team for a caller that checked the user is allowed
+// to. The terms themselves aren't logged since they may hold sensitive content.
+func (s SearchPostStore) SearchAllTeams(userId, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), opts.TimeZoneOffset)
+	if err := s.rootStore.checkSearchLimits(paramsList, opts.Page, opts.PerPage); err != nil {
+		return nil, err
+	}
+
+	mlog.Debug("Searching the posts of all the teams", mlog.String("user_id", userId), mlog.Int("terms", len(strings.Fields(terms))), mlog.Int("page", opts.Page), mlog.Int("per_page", opts.PerPage))
+
+	var results *model.PostSearchResults
+	engineName, err := s.rootStore.searchEngine.Search("SearchAllTeams", func(engine searchengine.SearchEngineInterface) error {
+		searcher, ok := engine.(searchengine.AllChannelsPostSearcher)
+		if !ok {
+			return searchengine.ErrSkipEngine
+		}
+
+		var err error
+		results, err = s.searchAllTeamsByEngine(searcher, terms, opts)
+		return err
+	}, func() error {
+		if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+			return searchengine.ErrNoSearchEngine
+		}
+
+		var err error
+		results, err = s.PostStore.SearchAllTeams(userId, terms, opts)
+		return err
+	})
+	if err == searchengine.ErrNoSearchEngine {
+		mlog.Debug("Returning empty results for post SearchAllTeams as no search engine is available")
 		return &model.PostSearchResults{PostList: model.NewPostList(), Matches: model.PostSearchMatches{}}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	results.SearchEngine = engineName
+	return results, nil
+}
+
+func (s SearchPostStore) suggestTermsByEngine(engine searchengine.SearchEngineInterface, suggester searchengine.TermSuggester, userId, teamId, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	// We only suggest the channels the user is a member of, and the terms used in them.
+	userChannels, err := s.rootStore.Channel().GetChannels(teamId, userId, false, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerPrefix := strings.ToLower(prefix)
+	channelSuggestions := []*model.SearchSuggestion{}
+	for _, channel := range *userChannels {
+		if channel.Type != model.CHANNEL_OPEN && channel.Type != model.CHANNEL_PRIVATE {
+			continue
+		}
+		if strings.HasPrefix(channel.Name, lowerPrefix) || strings.HasPrefix(strings.ToLower(channel.DisplayName), lowerPrefix) {
+			channelSuggestions = append(channelSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_CHANNEL, Term: channel.Name, DisplayName: channel.DisplayName})
+		}
+	}
+	sort.Slice(channelSuggestions, func(i, j int) bool {
+		return channelSuggestions[i].Term < channelSuggestions[j].Term
+	})
+
+	span := s.rootStore.startSearchSpan(engine, "SearchUsersInTeam")
+	usersIds, appErr := engine.SearchUsersInTeam(teamId, nil, sanitizeSearchTerm(prefix), &model.UserSearchOptions{Limit: limit})
+	finishSearchSpan(span, len(usersIds), appErr)
+	if appErr != nil {
+		return nil, appErr
+	}
+	users, err := s.rootStore.User().GetProfileByIds(usersIds, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	userSuggestions := []*model.SearchSuggestion{}
+	for _, user := range users {
+		userSuggestions = append(userSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_USER, Term: user.Username})
+	}
+
+	terms, appErr := suggester.SuggestTerms(userChannels, prefix, limit)
+	if appErr != nil {
+		return nil, appErr
+	}
+	termSuggestions := []*model.SearchSuggestion{}
+	for _, term := range terms {
+		termSuggestions = append(termSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_TERM, Term: term})
+	}
+
+	return model.MergeSearchSuggestions(limit, channelSuggestions, userSuggestions, termSuggestions), nil
+}
+
+func (s SearchPostStore) SuggestTerms(userId, teamId, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	var suggestions []*model.SearchSuggestion
+	_, err := s.rootStore.searchEngine.Search("SuggestTerms", func(engine searchengine.SearchEngineInterface) error {
+		suggester, ok := engine.(searchengine.TermSuggester)
+		if !ok {
+			return searchengine.ErrSkipEngine
+		}
+
+		var err error
+		suggestions, err = s.suggestTermsByEngine(engine, suggester, userId, teamId, prefix, limit)
+		return err
+	}, func() error {
+		if *s.rootStore.config.SqlSettings.DisableDatabaseSearch {
+			return searchengine.ErrNoSearchEngine
+		}
+
+		var err error
+		suggestions, err = s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+		return err
+	})
+	if err == searchengine.ErrNoSearchEngine {
+		mlog.Debug("Returning empty results for SuggestTerms as no search engine is available")
+		return []*model.SearchSuggestion{}, nil
+	} else if err != nil {
+		return nil, err
 	}
 
-	mlog.Debug("Using database search because no other search engine is available")
-	return s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+	return suggestions, nil
 }
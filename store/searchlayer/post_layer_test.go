@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	searchenginemocks "github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+)
+
+func TestMoveThreadIndexesPosts(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	target := &model.Channel{Id: model.NewId(), TeamId: model.NewId()}
+	root := &model.Post{Id: model.NewId(), ChannelId: target.Id}
+	reply := &model.Post{Id: model.NewId(), ChannelId: target.Id, RootId: root.Id, FileIds: model.StringArray{"file1"}}
+	deleted := &model.Post{Id: model.NewId(), ChannelId: target.Id, RootId: root.Id, DeleteAt: 1}
+	file := &model.FileInfo{Id: "file1", PostId: reply.Id}
+
+	postStore := &mocks.PostStore{}
+	postStore.On("MoveThread", root.Id, target.Id, false).Return([]*model.Post{root, reply, deleted}, nil)
+	postStore.On("GetSingle", reply.Id).Return(reply, nil)
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("Get", target.Id, true).Return(target, nil)
+	fileInfoStore := &mocks.FileInfoStore{}
+	fileInfoStore.On("GetForPost", reply.Id, true, false, false).Return([]*model.FileInfo{file}, nil)
+
+	baseStore := &mocks.Store{}
+	baseStore.On("Channel").Return(channelStore)
+	baseStore.On("Post").Return(postStore)
+	baseStore.On("FileInfo").Return(fileInfoStore)
+	baseStore.On("Team").Return(&mocks.TeamStore{})
+	baseStore.On("User").Return(&mocks.UserStore{})
+
+	engine := &searchenginemocks.SearchEngineInterface{}
+	engine.On("IsActive").Return(true)
+	engine.On("GetName").Return("bleve")
+	engine.On("IsIndexingEnabled").Return(true)
+	engine.On("IsIndexingSync").Return(true)
+	engine.On("RefreshIndexes").Return(nil)
+	engine.On("IndexPost", mock.Anything, target.TeamId).Return(nil)
+	engine.On("IndexFile", file, target.Id).Return(nil)
+
+	broker := searchengine.NewBroker(cfg, nil)
+	broker.RegisterBleveEngine(engine)
+	searchStore := NewSearchLayer(baseStore, broker, cfg)
+
+	moved, err := searchStore.Post().MoveThread(root.Id, target.Id, false)
+	require.NoError(t, err)
+	require.Len(t, moved, 3)
+
+	engine.AssertCalled(t, "IndexPost", root, target.TeamId)
+	engine.AssertCalled(t, "IndexPost", reply, target.TeamId)
+	engine.AssertNotCalled(t, "IndexPost", deleted, target.TeamId)
+	engine.AssertCalled(t, "IndexFile", file, target.Id)
+}
+
+func TestDeletedPostsAreRemovedFromIndexes(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId()}
+	root := &model.Post{Id: model.NewId(), ChannelId: channel.Id}
+	reply := &model.Post{Id: model.NewId(), ChannelId: channel.Id, RootId: root.Id}
+
+	setup := func() (*SearchStore, *mocks.PostStore, *searchenginemocks.SearchEngineInterface) {
+		postStore := &mocks.PostStore{}
+		channelStore := &mocks.ChannelStore{}
+		channelStore.On("Get", channel.Id, true).Return(channel, nil)
+
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(channelStore)
+		baseStore.On("Post").Return(postStore)
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+
+		engine := &searchenginemocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(true)
+		engine.On("GetName").Return("bleve")
+		engine.On("IsIndexingEnabled").Return(true)
+		engine.On("IsIndexingSync").Return(true)
+		engine.On("RefreshIndexes").Return(nil)
+		engine.On("IndexPost", mock.Anything, channel.TeamId).Return(nil)
+		engine.On("DeletePost", mock.Anything).Return(nil)
+
+		broker := searchengine.NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(engine)
+		return NewSearchLayer(baseStore, broker, cfg), postStore, engine
+	}
+
+	t.Run("deleting a root post removes its replies", func(t *testing.T) {
+		searchStore, postStore, engine := setup()
+		postList := model.NewPostList()
+		postList.AddPost(root)
+		postList.AddPost(reply)
+		postStore.On("Get", root.Id, false).Return(postList, nil)
+		postStore.On("Delete", root.Id, int64(1), "user").Return(nil)
+
+		require.NoError(t, searchStore.Post().Delete(root.Id, 1, "user"))
+
+		engine.AssertCalled(t, "DeletePost", root)
+		engine.AssertCalled(t, "DeletePost", reply)
+	})
+
+	t.Run("deleting a reply leaves the rest of the thread", func(t *testing.T) {
+		searchStore, postStore, engine := setup()
+		postList := model.NewPostList()
+		postList.AddPost(root)
+		postList.AddPost(reply)
+		postStore.On("Get", reply.Id, false).Return(postList, nil)
+		postStore.On("Delete", reply.Id, int64(1), "user").Return(nil)
+
+		require.NoError(t, searchStore.Post().Delete(reply.Id, 1, "user"))
+
+		engine.AssertCalled(t, "DeletePost", reply)
+		engine.AssertNotCalled(t, "DeletePost", root)
+	})
+
+	t.Run("failing to delete leaves the indexes", func(t *testing.T) {
+		searchStore, postStore, engine := setup()
+		postStore.On("Get", root.Id, false).Return(nil, store.NewErrNotFound("Post", root.Id))
+		postStore.On("Delete", root.Id, int64(1), "user").Return(store.NewErrNotFound("Post", root.Id))
+
+		require.Error(t, searchStore.Post().Delete(root.Id, 1, "user"))
+
+		engine.AssertNotCalled(t, "DeletePost", mock.Anything)
+	})
+
+	t.Run("a deleted post isn't indexed", func(t *testing.T) {
+		searchStore, postStore, engine := setup()
+		deleted := &model.Post{Id: model.NewId(), ChannelId: channel.Id, DeleteAt: 1}
+		postStore.On("Overwrite", deleted).Return(deleted, nil)
+
+		_, err := searchStore.Post().Overwrite(deleted)
+		require.NoError(t, err)
+
+		engine.AssertCalled(t, "DeletePost", deleted)
+		engine.AssertNotCalled(t, "IndexPost", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPinnedOnlySearchUsesTheDatabase(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	userId, teamId := model.NewId(), model.NewId()
+	pinned := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), IsPinned: true, PinnedAt: 1}
+	postList := model.NewPostList()
+	postList.AddPost(pinned)
+	postList.AddOrder(pinned.Id)
+
+	setup := func() (*SearchStore, *mocks.PostStore, *searchenginemocks.SearchEngineInterface) {
+		postStore := &mocks.PostStore{}
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(&mocks.ChannelStore{})
+		baseStore.On("Post").Return(postStore)
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+
+		engine := &searchenginemocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(true)
+		engine.On("GetName").Return("bleve")
+		engine.On("IsSearchEnabled").Return(true)
+
+		broker := searchengine.NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(engine)
+		return NewSearchLayer(baseStore, broker, cfg), postStore, engine
+	}
+
+	t.Run("searching a page", func(t *testing.T) {
+		searchStore, postStore, engine := setup()
+		paramsList := []*model.SearchParams{{Terms: "pinned", PinnedOnly: true}}
+		postStore.On("SearchPostsInTeamForUser", paramsList, userId, teamId, 0, 20).Return(model.MakePostSearchResults(postList, nil), nil)
+
+		results, err := searchStore.Post().SearchPostsInTeamForUser(paramsList, userId, teamId, 0, 20)
+		require.NoError(t, err)
+		require.Equal(t, model.SEARCH_ENGINE_DATABASE, results.SearchEngine)
+		require.Equal(t, []string{pinned.Id}, results.Order)
+		engine.AssertNotCalled(t, "SearchPosts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("searching after a cursor", func(t *testing.T) {
+		searchStore, postStore, _ := setup()
+		paramsList := []*model.SearchParams{{Terms: "pinned", PinnedOnly: true}}
+		postStore.On("SearchPostsInTeamForUserAfter", paramsList, userId, teamId, (*model.PostSearchCursor)(nil), 20).Return(model.MakePostSearchResults(postList, nil), nil)
+
+		results, err := searchStore.Post().SearchPostsInTeamForUserAfter(paramsList, userId, teamId, nil, 20)
+		require.NoError(t, err)
+		require.Equal(t, model.SEARCH_ENGINE_DATABASE, results.SearchEngine)
+		require.Equal(t, []string{pinned.Id}, results.Order)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	spanlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	"github.com/mattermost/mattermost-server/v5/services/tracing"
+)
+
+// startSearchSpan opens the span of a query to the search engine, or to one of its optional
+// searchers, as a child of the span found in the context of the store. It's a no-op unless a
+// global tracer is set.
+func (s *SearchStore) startSearchSpan(searcher interface{}, operation string) opentracing.Span {
+	if !opentracing.IsGlobalTracerRegistered() {
+		return opentracing.NoopTracer{}.StartSpan(operation)
+	}
+
+	ctx := s.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span, _ := tracing.StartSpanWithParentByContext(ctx, "SearchEngine."+operation)
+	ext.Component.Set(span, "searchengine")
+	if engine, ok := searcher.(searchengine.SearchEngineInterface); ok {
+		span.SetTag("search.engine", engine.GetName())
+	}
+	return span
+}
+
+// finishSearchSpan records the number of results of the query, or its error.
+func finishSearchSpan(span opentracing.Span, count int, appErr *model.AppError) {
+	if appErr != nil {
+		span.LogFields(spanlog.Error(appErr))
+		ext.Error.Set(span, true)
+	} else {
+		span.SetTag("db.rows", count)
+	}
+	span.Finish()
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	searchenginemocks "github.com/mattermost/mattermost-server/v5/services/searchengine/mocks"
+	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+)
+
+func TestSearchEngineSpans(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	defer opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Name: "town-square"}
+	parent := tracer.StartSpan("web:ServeHTTP")
+
+	setup := func(engineErr *model.AppError) *SearchStore {
+		channelStore := &mocks.ChannelStore{}
+		channelStore.On("GetChannelsByIds", []string{channel.Id}, false).Return([]*model.Channel{channel}, nil)
+		channelStore.On("AutocompleteInTeam", channel.TeamId, "town", false).Return(&model.ChannelList{}, nil)
+
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(channelStore)
+		baseStore.On("Post").Return(&mocks.PostStore{})
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+		baseStore.On("Context").Return(opentracing.ContextWithSpan(context.Background(), parent))
+
+		engine := &searchenginemocks.SearchEngineInterface{}
+		engine.On("IsActive").Return(true)
+		engine.On("GetName").Return("bleve")
+		engine.On("IsAutocompletionEnabled").Return(true)
+		if engineErr != nil {
+			engine.On("SearchChannels", channel.TeamId, "town").Return(nil, engineErr)
+		} else {
+			engine.On("SearchChannels", channel.TeamId, "town").Return([]string{channel.Id}, nil)
+		}
+
+		broker := searchengine.NewBroker(cfg, nil)
+		broker.RegisterBleveEngine(engine)
+
+		return NewSearchLayer(baseStore, broker, cfg)
+	}
+
+	t.Run("reports the queries as children of the store span", func(t *testing.T) {
+		tracer.Reset()
+
+		_, err := setup(nil).Channel().AutocompleteInTeam(channel.TeamId, "town", false)
+		require.NoError(t, err)
+
+		spans := tracer.FinishedSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "SearchEngine.SearchChannels", spans[0].OperationName)
+		assert.Equal(t, parent.(*mocktracer.MockSpan).SpanContext.SpanID, spans[0].ParentID)
+		assert.Equal(t, "bleve", spans[0].Tag("search.engine"))
+		assert.Equal(t, 1, spans[0].Tag("db.rows"))
+	})
+
+	t.Run("marks the failed queries", func(t *testing.T) {
+		tracer.Reset()
+
+		_, err := setup(model.NewAppError("SearchChannels", "bleveengine.search_channels.error", nil, "", http.StatusInternalServerError)).Channel().AutocompleteInTeam(channel.TeamId, "town", false)
+		require.Error(t, err)
+
+		spans := tracer.FinishedSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, true, spans[0].Tag("error"))
+		assert.Nil(t, spans[0].Tag("db.rows"))
+	})
+}
+
+func TestSearchEngineSpansWithoutContext(t *testing.T) {
+	baseStore := &mocks.Store{}
+	baseStore.On("Context").Return(nil)
+
+	assert.NotPanics(t, func() {
+		span := (&SearchStore{Store: baseStore}).startSearchSpan(nil, "SearchChannels")
+		finishSearchSpan(span, 0, nil)
+	})
+}
@@ -48,7 +48,9 @@ func (s *SearchUserStore) Search(teamId, term string, options *model.UserSearchO
 
 			sanitizedTerm := sanitizeSearchTerm(term)
 
+			span := s.rootStore.startSearchSpan(engine, "SearchUsersInTeam")
 			usersIds, err := engine.SearchUsersInTeam(teamId, listOfAllowedChannels, sanitizedTerm, options)
+			finishSearchSpan(span, len(usersIds), err)
 			if err != nil {
 				mlog.Error("Encountered error on Search", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
 				continue
@@ -106,9 +108,13 @@ func (s *SearchUserStore) autocompleteUsersInChannelByEngine(engine searchengine
 	nuchanIds := []string{}
 	sanitizedTerm := sanitizeSearchTerm(term)
 	if channelId != "" && options.ListOfAllowedChannels != nil && !strings.Contains(strings.Join(options.ListOfAllowedChannels, "."), channelId) {
+		span := s.rootStore.startSearchSpan(engine, "SearchUsersInTeam")
 		nuchanIds, err = engine.SearchUsersInTeam(teamId, options.ListOfAllowedChannels, sanitizedTerm, options)
+		finishSearchSpan(span, len(nuchanIds), err)
 	} else {
+		span := s.rootStore.startSearchSpan(engine, "SearchUsersInChannel")
 		uchanIds, nuchanIds, err = engine.SearchUsersInChannel(teamId, channelId, options.ListOfAllowedChannels, sanitizedTerm, options)
+		finishSearchSpan(span, len(uchanIds)+len(nuchanIds), err)
 	}
 	if err != nil {
 		return nil, err
@@ -4,6 +4,7 @@
 package searchtest
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -23,6 +24,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchPostsWithPagination,
 		Tags: []string{ENGINE_ELASTICSEARCH, ENGINE_BLEVE},
 	},
+	{
+		Name: "Should be able to search posts using a cursor",
+		Fn:   testSearchPostsWithCursor,
+		Tags: []string{ENGINE_POSTGRES, ENGINE_MYSQL},
+	},
 	{
 		Name: "Should return pinned and unpinned posts",
 		Fn:   testSearchReturnPinnedAndUnpinned,
@@ -261,6 +267,26 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testShouldNotReturnLinksEmbeddedInMarkdown,
 		Tags: []string{ENGINE_POSTGRES, ENGINE_ELASTICSEARCH},
 	},
+	{
+		Name: "Should return highlights only when requested",
+		Fn:   testSearchPostsWithHighlights,
+		Tags: []string{ENGINE_ELASTICSEARCH, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should only match similar terms when the search is fuzzy",
+		Fn:   testSearchPostsWithFuzziness,
+		Tags: []string{ENGINE_ELASTICSEARCH, ENGINE_BLEVE},
+	},
+	{
+		Name: "Should suggest the channels, users and terms the user has access to",
+		Fn:   testSuggestTerms,
+		Tags: []string{ENGINE_ALL},
+	},
+	{
+		Name: "Should be able to search the posts of all the teams",
+		Fn:   testSearchAllTeams,
+		Tags: []string{ENGINE_ALL},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -323,6 +349,32 @@ func testSearchPostsWithPagination(t *testing.T, th *SearchTestHelper) {
 	th.checkPostInSearchResults(t, p1.Id, results.Posts)
 }
 
+func testSearchPostsWithCursor(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "channel test", "", model.POST_DEFAULT, 10000, false)
+	require.Nil(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "channel test", "", model.POST_DEFAULT, 20000, false)
+	require.Nil(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "channel test", "", model.POST_DEFAULT, 20000, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	params := &model.SearchParams{Terms: "test"}
+	results, err := th.Store.Post().SearchPostsInTeamForUserAfter([]*model.SearchParams{params}, th.User.Id, th.Team.Id, nil, 2)
+	require.Nil(t, err)
+
+	require.Len(t, results.Posts, 2)
+	th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	require.NotNil(t, results.NextCursor)
+
+	results, err = th.Store.Post().SearchPostsInTeamForUserAfter([]*model.SearchParams{params}, th.User.Id, th.Team.Id, results.NextCursor, 2)
+	require.Nil(t, err)
+
+	require.Len(t, results.Posts, 1)
+	th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	require.Nil(t, results.NextCursor)
+}
+
 func testSearchReturnPinnedAndUnpinned(t *testing.T, th *SearchTestHelper) {
 	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "channel test unpinned", "", model.POST_DEFAULT, 0, false)
 	require.Nil(t, err)
@@ -1812,3 +1864,127 @@ func testShouldNotReturnLinksEmbeddedInMarkdown(t *testing.T, th *SearchTestHelp
 
 	require.Len(t, results.Posts, 0)
 }
+
+func testSearchPostsWithHighlights(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "the highlighted test message", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	params := &model.SearchParams{Terms: "highlighted"}
+	results, err := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+	require.Nil(t, err)
+	require.Len(t, results.Posts, 1)
+	require.Nil(t, results.Highlights)
+
+	params.IncludeHighlights = true
+	results, err = th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+	require.Nil(t, err)
+	require.Len(t, results.Posts, 1)
+	th.checkPostInSearchResults(t, p1.Id, results.Posts)
+	require.Len(t, results.Highlights[p1.Id], 1)
+	require.Contains(t, results.Highlights[p1.Id][0], model.POST_SEARCH_HIGHLIGHT_PRE_TAG+"highlighted"+model.POST_SEARCH_HIGHLIGHT_POST_TAG)
+}
+
+func testSearchPostsWithFuzziness(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "did you receive the message", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	params := &model.SearchParams{Terms: "mesage"}
+	results, err := th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+	require.Nil(t, err)
+	require.Empty(t, results.Posts)
+
+	params.Fuzziness = 1
+	results, err = th.Store.Post().SearchPostsInTeamForUser([]*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+	require.Nil(t, err)
+	require.Len(t, results.Posts, 1)
+	th.checkPostInSearchResults(t, p1.Id, results.Posts)
+}
+
+func testSuggestTerms(t *testing.T, th *SearchTestHelper) {
+	_, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "the deployment is done", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	_, err = th.createPost(th.User.Id, th.ChannelPrivate.Id, "Deploying the fix", "", model.POST_DEFAULT, 0, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	getTerms := func(suggestions []*model.SearchSuggestion, suggestionType string) []string {
+		terms := []string{}
+		for _, suggestion := range suggestions {
+			if suggestion.Type == suggestionType {
+				terms = append(terms, suggestion.Term)
+			}
+		}
+		return terms
+	}
+
+	t.Run("Should suggest the terms of the posts in the user's channels", func(t *testing.T) {
+		suggestions, err := th.Store.Post().SuggestTerms(th.User.Id, th.Team.Id, "deploy", 10)
+		require.Nil(t, err)
+		require.ElementsMatch(t, []string{"deployment", "deploying"}, getTerms(suggestions, model.SEARCH_SUGGESTION_TYPE_TERM))
+
+		suggestions, err = th.Store.Post().SuggestTerms(th.User2.Id, th.Team.Id, "deploy", 10)
+		require.Nil(t, err)
+		require.Equal(t, []string{"deploying"}, getTerms(suggestions, model.SEARCH_SUGGESTION_TYPE_TERM))
+	})
+
+	t.Run("Should suggest the channels the user is a member of", func(t *testing.T) {
+		suggestions, err := th.Store.Post().SuggestTerms(th.User2.Id, th.Team.Id, "channel", 10)
+		require.Nil(t, err)
+		require.Equal(t, []string{th.ChannelPrivate.Name}, getTerms(suggestions, model.SEARCH_SUGGESTION_TYPE_CHANNEL))
+	})
+
+	t.Run("Should suggest the members of the team", func(t *testing.T) {
+		suggestions, err := th.Store.Post().SuggestTerms(th.User.Id, th.Team.Id, "basicusername", 10)
+		require.Nil(t, err)
+		require.ElementsMatch(t, []string{th.User.Username, th.User2.Username}, getTerms(suggestions, model.SEARCH_SUGGESTION_TYPE_USER))
+	})
+
+	t.Run("Should respect the limit", func(t *testing.T) {
+		suggestions, err := th.Store.Post().SuggestTerms(th.User.Id, th.Team.Id, "basicusername", 1)
+		require.Nil(t, err)
+		require.Len(t, suggestions, 1)
+	})
+}
+
+func testSearchAllTeams(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "cross team test", "", model.POST_DEFAULT, 1000, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+	p2, err := th.createPost(th.UserAnotherTeam.Id, th.ChannelAnotherTeam.Id, "another team test", "", model.POST_DEFAULT, 2000, false)
+	require.Nil(t, err)
+	defer th.deleteUserPosts(th.UserAnotherTeam.Id)
+
+	t.Run("Should refuse an unauthorized search", func(t *testing.T) {
+		_, err := th.Store.Post().SearchAllTeams(th.User.Id, "test", model.SearchAllTeamsOptions{PerPage: 20})
+		require.NotNil(t, err)
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("Should search the channels the user isn't a member of", func(t *testing.T) {
+		results, err := th.Store.Post().SearchAllTeams(th.User.Id, "test", model.SearchAllTeamsOptions{PerPage: 20})
+		require.Nil(t, err)
+
+		require.Len(t, results.Posts, 2)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("Should paginate the results", func(t *testing.T) {
+		results, err := th.Store.Post().SearchAllTeams(th.User.Id, "test", model.SearchAllTeamsOptions{PerPage: 1})
+		require.Nil(t, err)
+		require.Equal(t, []string{p2.Id}, results.Order)
+
+		results, err = th.Store.Post().SearchAllTeams(th.User.Id, "test", model.SearchAllTeamsOptions{Page: 1, PerPage: 1})
+		require.Nil(t, err)
+		require.Equal(t, []string{p1.Id}, results.Order)
+	})
+
+	t.Run("Should filter the users by username", func(t *testing.T) {
+		results, err := th.Store.Post().SearchAllTeams(th.User.Id, "test from:"+th.UserAnotherTeam.Username, model.SearchAllTeamsOptions{PerPage: 20})
+		require.Nil(t, err)
+		require.Equal(t, []string{p2.Id}, results.Order)
+	})
+}
@@ -37,7 +37,7 @@ func (s SqlAuditStore) createIndexesIfNotExists() {
 
 func (s SqlAuditStore) Save(audit *model.Audit) error {
 	audit.Id = model.NewId()
-	audit.CreateAt = model.GetMillis()
+	audit.CreateAt = s.getMillis()
 
 	if err := s.GetMaster().Insert(audit); err != nil {
 		return errors.Wrapf(err, "failed to save Audit with userId=%s and action=%s", audit.UserId, audit.Action)
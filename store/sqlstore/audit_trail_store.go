@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type SqlAuditTrailStore struct {
+	SqlStore
+}
+
+func newSqlAuditTrailStore(sqlStore SqlStore) store.AuditTrailStore {
+	s := &SqlAuditTrailStore{sqlStore}
+
+	for _, db := range sqlStore.GetAllConns() {
+		table := db.AddTableWithName(model.AuditTrailEntry{}, "AuditTrail").SetKeys(false, "Id")
+		table.ColMap("Id").SetMaxSize(26)
+		table.ColMap("ActorId").SetMaxSize(26)
+		table.ColMap("EntityType").SetMaxSize(model.AUDIT_TRAIL_ENTITY_TYPE_MAX_LENGTH)
+		table.ColMap("EntityId").SetMaxSize(model.AUDIT_TRAIL_ENTITY_ID_MAX_LENGTH)
+		table.ColMap("Operation").SetMaxSize(model.AUDIT_TRAIL_OPERATION_MAX_LENGTH)
+	}
+
+	return s
+}
+
+func (s SqlAuditTrailStore) createIndexesIfNotExists() {
+	s.CreateCompositeIndexIfNotExists("idx_audittrail_entity", "AuditTrail", []string{"EntityType", "EntityId", "CreateAt"})
+	s.CreateIndexIfNotExists("idx_audittrail_actor_id", "AuditTrail", "ActorId")
+}
+
+// Record inserts the entry ahead of the change, in a transaction kept open while the change is
+// made: an entry that can't be saved rejects the change before it's made, and the entry is only
+// committed once the change succeeded. The entity id is set within the transaction when the
+// change created the entity, the entry never being updated once committed.
+func (s SqlAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	entry.PreSave()
+	if err := entry.IsValid(); err != nil {
+		return err
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	entityId := entry.EntityId
+	if err := transaction.Insert(entry); err != nil {
+		return errors.Wrapf(err, "failed to save AuditTrailEntry with operation=%s", entry.Operation)
+	}
+
+	if !change() {
+		return nil
+	}
+
+	if entry.EntityId != entityId {
+		if _, err := transaction.Update(entry); err != nil {
+			return errors.Wrapf(err, "failed to set the entity of AuditTrailEntry with id=%s", entry.Id)
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+	return nil
+}
+
+// GetByEntity returns the entries of an entity, oldest first.
+func (s SqlAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+	if limit > 1000 {
+		return nil, store.NewErrOutOfBounds(limit)
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("AuditTrail").
+		Where(sq.Eq{"EntityType": entityType, "EntityId": entityId}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "audit_trail_get_by_entity_tosql")
+	}
+
+	entries := []*model.AuditTrailEntry{}
+	if _, err := s.GetReplica().Select(&entries, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AuditTrailEntry list for entityType=%s and entityId=%s", entityType, entityId)
+	}
+	return entries, nil
+}
@@ -169,13 +169,15 @@ func (us SqlBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error)
 func (us SqlBotStore) Save(bot *model.Bot) (*model.Bot, error) {
 	bot = bot.Clone()
 	bot.PreSave()
+	bot.CreateAt = us.getMillis()
+	bot.UpdateAt = bot.CreateAt
 
 	if err := bot.IsValid(); err != nil { // TODO: change to return error in v6.
 		return nil, err
 	}
 
 	if err := us.GetMaster().Insert(botFromModel(bot)); err != nil {
-		return nil, errors.Wrapf(err, "insert: user_id=%s", bot.UserId)
+		return nil, wrapSqlError(err, "Bot", bot.UserId, fmt.Sprintf("insert: user_id=%s", bot.UserId))
 	}
 
 	return bot, nil
@@ -187,6 +189,7 @@ func (us SqlBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 	bot = bot.Clone()
 
 	bot.PreUpdate()
+	bot.UpdateAt = us.getMillis()
 	if err := bot.IsValid(); err != nil { // TODO: needs to return error in v6
 		return nil, err
 	}
@@ -4,6 +4,7 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sort"
@@ -67,6 +68,14 @@ func NewChannelMemberFromModel(cm *model.ChannelMember) *channelMember {
 	}
 }
 
+// channelMemberRemoval records the last time a user was removed from a channel, for the
+// clients syncing the members of the channel to learn about it.
+type channelMemberRemoval struct {
+	ChannelId string
+	UserId    string
+	RemoveAt  int64
+}
+
 type channelMemberWithSchemeRoles struct {
 	ChannelId                     string
 	UserId                        string
@@ -381,6 +390,10 @@ func newSqlChannelStore(sqlStore SqlStore, metrics einterfaces.MetricsInterface)
 		tablem.ColMap("Roles").SetMaxSize(64)
 		tablem.ColMap("NotifyProps").SetMaxSize(2000)
 
+		tableRemovals := db.AddTableWithName(channelMemberRemoval{}, "ChannelMemberRemovals").SetKeys(false, "ChannelId", "UserId")
+		tableRemovals.ColMap("ChannelId").SetMaxSize(26)
+		tableRemovals.ColMap("UserId").SetMaxSize(26)
+
 		tablePublicChannels := db.AddTableWithName(publicChannel{}, "PublicChannels").SetKeys(false, "Id")
 		tablePublicChannels.ColMap("Id").SetMaxSize(26)
 		tablePublicChannels.ColMap("TeamId").SetMaxSize(26)
@@ -628,6 +641,8 @@ func (s SqlChannelStore) saveChannelT(transaction *gorp.Transaction, channel *mo
 	}
 
 	channel.PreSave()
+	channel.CreateAt = s.getMillis()
+	channel.UpdateAt = channel.CreateAt
 	if err := channel.IsValid(); err != nil { // TODO: this needs to return plain error in v6.
 		return nil, err // we just pass through the error as-is for now.
 	}
@@ -677,6 +692,7 @@ func (s SqlChannelStore) Update(channel *model.Channel) (*model.Channel, error)
 
 func (s SqlChannelStore) updateChannelT(transaction *gorp.Transaction, channel *model.Channel) (*model.Channel, error) {
 	channel.PreUpdate()
+	channel.UpdateAt = s.getMillis()
 
 	if channel.DeleteAt != 0 {
 		return nil, store.NewErrInvalidInput("Channel", "DeleteAt", channel.DeleteAt)
@@ -729,6 +745,83 @@ func (s SqlChannelStore) GetChannelUnread(channelId, userId string) (*model.Chan
 	return &unreadChannel, nil
 }
 
+// GetUnreadCountsForUser returns the unread counts of the user in each of the channels they are a
+// member of, by channel id, counting the posts newer than the channel was last viewed in a single
+// query. The posts of the user and the join and leave messages aren't counted. Every post of the
+// other user of a direct channel, root post or reply, is a mention; elsewhere the mentions are
+// the ones counted as the posts were created.
+func (s SqlChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	return s.getUnreadCounts(s.GetReplica(), userId, nil)
+}
+
+// getUnreadCounts counts the unreads of the user in the given channels, or in all of its
+// channels when channelIds is nil.
+func (s SqlChannelStore) getUnreadCounts(db gorp.SqlExecutor, userId string, channelIds []string) (map[string]*model.ChannelUnreadCounts, error) {
+	where := sq.Eq{"cm.UserId": userId, "c.DeleteAt": 0}
+	if channelIds != nil {
+		where["cm.ChannelId"] = channelIds
+	}
+
+	postTypes := make([]interface{}, len(joinLeaveTypes))
+	for i, postType := range joinLeaveTypes {
+		postTypes[i] = postType
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Select(
+			"cm.ChannelId ChannelId",
+			"c.Type ChannelType",
+			"cm.MentionCount MentionCount",
+			"cm.NotifyProps NotifyProps",
+			"COUNT(p.Id) MsgCount",
+			"COALESCE(SUM(CASE WHEN p.RootId = '' THEN 1 ELSE 0 END), 0) MsgCountRoot",
+		).
+		From("ChannelMembers cm").
+		Join("Channels c ON c.Id = cm.ChannelId").
+		LeftJoin(`Posts p ON p.ChannelId = cm.ChannelId
+			AND p.CreateAt > cm.LastViewedAt
+			AND p.DeleteAt = 0
+			AND p.UserId != cm.UserId
+			AND p.Type NOT IN (`+sq.Placeholders(len(joinLeaveTypes))+`)`, postTypes...).
+		Where(where).
+		GroupBy("cm.ChannelId", "c.Type", "cm.MentionCount", "cm.NotifyProps").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "unread_counts_tosql")
+	}
+
+	var rows []struct {
+		ChannelId    string
+		ChannelType  string
+		MentionCount int64
+		NotifyProps  model.StringMap
+		MsgCount     int64
+		MsgCountRoot int64
+	}
+	if _, err := db.Select(&rows, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to count the unread Posts of userId=%s", userId)
+	}
+
+	counts := make(map[string]*model.ChannelUnreadCounts, len(rows))
+	for _, row := range rows {
+		unread := &model.ChannelUnreadCounts{
+			ChannelId:    row.ChannelId,
+			MsgCount:     row.MsgCount,
+			MsgCountRoot: row.MsgCountRoot,
+			MentionCount: row.MentionCount,
+			Muted:        row.NotifyProps[model.MARK_UNREAD_NOTIFY_PROP] == model.CHANNEL_MARK_UNREAD_MENTION,
+		}
+		if row.ChannelType == model.CHANNEL_DIRECT {
+			unread.MentionCount = row.MsgCount
+		}
+		if unread.Muted {
+			unread.MsgCount, unread.MsgCountRoot = 0, 0
+		}
+		counts[row.ChannelId] = unread
+	}
+	return counts, nil
+}
+
 func (s SqlChannelStore) InvalidateChannel(id string) {
 }
 
@@ -740,28 +833,23 @@ func (s SqlChannelStore) InvalidateChannelByName(teamId, name string) {
 }
 
 func (s SqlChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
-	return s.get(id, false, allowFromCache)
+	return s.GetCtx(context.Background(), id, allowFromCache)
 }
 
-func (s SqlChannelStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
-	pl := model.NewPostList()
+// GetCtx is Get aborting the query once ctx is done.
+func (s SqlChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	return s.get(ctx, id, false, true)
+}
 
-	var posts []*model.Post
-	if _, err := s.GetReplica().Select(&posts, "SELECT *, (SELECT count(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) as ReplyCount  FROM Posts p WHERE IsPinned = true AND ChannelId = :ChannelId AND DeleteAt = 0 ORDER BY CreateAt ASC", map[string]interface{}{"ChannelId": channelId}); err != nil {
-		return nil, errors.Wrap(err, "failed to find Posts")
-	}
-	for _, post := range posts {
-		pl.AddPost(post)
-		pl.AddOrder(post.Id)
-	}
-	return pl, nil
+func (s SqlChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	return s.get(context.Background(), id, false, includeDeleted)
 }
 
 func (s SqlChannelStore) GetFromMaster(id string) (*model.Channel, error) {
-	return s.get(id, true, false)
+	return s.get(context.Background(), id, true, true)
 }
 
-func (s SqlChannelStore) get(id string, master bool, allowFromCache bool) (*model.Channel, error) {
+func (s SqlChannelStore) get(ctx context.Context, id string, master bool, includeDeleted bool) (*model.Channel, error) {
 	var db *gorp.DbMap
 
 	if master {
@@ -770,17 +858,20 @@ func (s SqlChannelStore) get(id string, master bool, allowFromCache bool) (*mode
 		db = s.GetReplica()
 	}
 
-	obj, err := db.Get(model.Channel{}, id)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to find channel with id = %s", id)
+	query := "SELECT * FROM Channels WHERE Id = :Id"
+	if !includeDeleted {
+		query += " AND DeleteAt = 0"
 	}
 
-	if obj == nil {
-		return nil, store.NewErrNotFound("Channel", id)
+	var channel model.Channel
+	if err := selectOneContext(ctx, db, &channel, query, map[string]interface{}{"Id": id}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Channel", id)
+		}
+		return nil, errors.Wrapf(err, "failed to find channel with id = %s", id)
 	}
 
-	ch := obj.(*model.Channel)
-	return ch, nil
+	return &channel, nil
 }
 
 // Delete records the given deleted timestamp to the channel in question.
@@ -923,10 +1014,20 @@ func (s SqlChannelStore) PermanentDeleteMembersByChannel(channelId string) error
 		return errors.Wrapf(err, "failed to delete Channel with channelId=%s", channelId)
 	}
 
+	_, err = s.GetMaster().Exec("DELETE FROM ChannelMemberRemovals WHERE ChannelId = :ChannelId", map[string]interface{}{"ChannelId": channelId})
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ChannelMemberRemovals with channelId=%s", channelId)
+	}
+
 	return nil
 }
 
 func (s SqlChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	return s.GetChannelsCtx(context.Background(), teamId, userId, includeDeleted, lastDeleteAt)
+}
+
+// GetChannelsCtx is GetChannels aborting the query once ctx is done.
+func (s SqlChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
 	query := s.getQueryBuilder().
 		Select("Channels.*").
 		From("Channels, ChannelMembers").
@@ -962,7 +1063,7 @@ func (s SqlChannelStore) GetChannels(teamId string, userId string, includeDelete
 		return nil, errors.Wrapf(err, "getchannels_tosql")
 	}
 
-	_, err = s.GetReplica().Select(channels, sql, args...)
+	err = selectContext(ctx, s.GetReplica(), channels, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get channels with TeamId=%s and UserId=%s", teamId, userId)
 	}
@@ -1424,6 +1525,7 @@ func (s SqlChannelStore) saveMultipleMembersT(transaction *gorp.Transaction, mem
 		users[member.UserId] = true
 
 		member.PreSave()
+		member.LastUpdateAt = s.getMillis()
 		if err := member.IsValid(); err != nil { // TODO: this needs to return plain error in v6.
 			return nil, err
 		}
@@ -1564,6 +1666,7 @@ func (s SqlChannelStore) saveMemberT(transaction *gorp.Transaction, member *mode
 func (s SqlChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	for _, member := range members {
 		member.PreUpdate()
+		member.LastUpdateAt = s.getMillis()
 
 		if err := member.IsValid(); err != nil {
 			return nil, err
@@ -1609,6 +1712,123 @@ func (s SqlChannelStore) UpdateMember(member *model.ChannelMember) (*model.Chann
 	return updatedMembers[0], nil
 }
 
+// SaveMemberMultiple saves the members with a single statement, updating the roles, scheme
+// flags and notify props of those that already exist while keeping their read state. It returns
+// the members that were created separately from those that were updated, both as stored.
+func (s SqlChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	if len(members) == 0 {
+		return []*model.ChannelMember{}, []*model.ChannelMember{}, nil
+	}
+
+	// A statement can't upsert the same row twice, so only the last of duplicated members is kept.
+	keys := []string{}
+	membersByKey := map[string]*model.ChannelMember{}
+	for _, member := range members {
+		member.PreSave()
+		member.LastUpdateAt = s.getMillis()
+		if err := member.IsValid(); err != nil {
+			return nil, nil, err
+		}
+
+		key := member.ChannelId + member.UserId
+		if _, ok := membersByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		membersByKey[key] = member
+	}
+
+	channelIds := []string{}
+	userIds := []string{}
+	seenChannelIds := map[string]bool{}
+	seenUserIds := map[string]bool{}
+	for _, key := range keys {
+		member := membersByKey[key]
+		if !seenChannelIds[member.ChannelId] {
+			seenChannelIds[member.ChannelId] = true
+			channelIds = append(channelIds, member.ChannelId)
+		}
+		if !seenUserIds[member.UserId] {
+			seenUserIds[member.UserId] = true
+			userIds = append(userIds, member.UserId)
+			defer s.InvalidateAllChannelMembersForUser(member.UserId)
+		}
+	}
+
+	channelKeys, params := MapStringsToQueryParams(channelIds, "Channel")
+	userKeys, userParams := MapStringsToQueryParams(userIds, "User")
+	for key, value := range userParams {
+		params[key] = value
+	}
+	membersClause := "ChannelMembers.ChannelId IN " + channelKeys + " AND ChannelMembers.UserId IN " + userKeys
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	var existing []struct {
+		ChannelId string
+		UserId    string
+	}
+	if _, err := transaction.Select(&existing, "SELECT ChannelId, UserId FROM ChannelMembers WHERE "+membersClause, params); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find existing ChannelMembers")
+	}
+	existingKeys := map[string]bool{}
+	for _, member := range existing {
+		existingKeys[member.ChannelId+member.UserId] = true
+	}
+
+	query := s.getQueryBuilder().Insert("ChannelMembers").Columns(channelMemberSliceColumns()...)
+	for _, key := range keys {
+		query = query.Values(channelMemberToSlice(membersByKey[key])...)
+	}
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE Roles = VALUES(Roles), NotifyProps = VALUES(NotifyProps), LastUpdateAt = VALUES(LastUpdateAt), SchemeUser = VALUES(SchemeUser), SchemeAdmin = VALUES(SchemeAdmin), SchemeGuest = VALUES(SchemeGuest)")
+	} else {
+		query = query.Suffix("ON CONFLICT (ChannelId, UserId) DO UPDATE SET Roles = EXCLUDED.Roles, NotifyProps = EXCLUDED.NotifyProps, LastUpdateAt = EXCLUDED.LastUpdateAt, SchemeUser = EXCLUDED.SchemeUser, SchemeAdmin = EXCLUDED.SchemeAdmin, SchemeGuest = EXCLUDED.SchemeGuest")
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "channel_members_tosql")
+	}
+	if _, err := transaction.Exec(sql, args...); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to upsert ChannelMembers")
+	}
+
+	var dbMembers channelMemberWithSchemeRolesList
+	if _, err := transaction.Select(&dbMembers, CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY+"WHERE "+membersClause, params); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get ChannelMembers")
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	savedByKey := map[string]*model.ChannelMember{}
+	for _, dbMember := range dbMembers {
+		member := dbMember.ToModel()
+		savedByKey[member.ChannelId+member.UserId] = member
+	}
+
+	created := []*model.ChannelMember{}
+	updated := []*model.ChannelMember{}
+	for _, key := range keys {
+		member, ok := savedByKey[key]
+		if !ok {
+			continue
+		}
+		if existingKeys[key] {
+			updated = append(updated, member)
+		} else {
+			created = append(created, member)
+		}
+	}
+
+	return created, updated, nil
+}
+
 func (s SqlChannelStore) GetMembers(channelId string, offset, limit int) (*model.ChannelMembers, error) {
 	var dbMembers channelMemberWithSchemeRolesList
 	_, err := s.GetReplica().Select(&dbMembers, CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY+"WHERE ChannelId = :ChannelId LIMIT :Limit OFFSET :Offset", map[string]interface{}{"ChannelId": channelId, "Limit": limit, "Offset": offset})
@@ -1619,6 +1839,93 @@ func (s SqlChannelStore) GetMembers(channelId string, offset, limit int) (*model
 	return dbMembers.ToModel(), nil
 }
 
+func (s SqlChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	params := map[string]interface{}{"ChannelId": channelId, "Since": since}
+
+	var dbMembers channelMemberWithSchemeRolesList
+	if _, err := s.GetReplica().Select(&dbMembers, CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY+"WHERE ChannelMembers.ChannelId = :ChannelId AND ChannelMembers.LastUpdateAt >= :Since", params); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ChannelMembers with channelId=%s", channelId)
+	}
+
+	// The users added again since they were removed are among the members.
+	removedUserIds := []string{}
+	if _, err := s.GetReplica().Select(&removedUserIds, `
+		SELECT
+			r.UserId
+		FROM
+			ChannelMemberRemovals r
+		WHERE
+			r.ChannelId = :ChannelId
+			AND r.RemoveAt >= :Since
+			AND NOT EXISTS (
+				SELECT 1 FROM ChannelMembers cm WHERE cm.ChannelId = r.ChannelId AND cm.UserId = r.UserId
+			)`, params); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ChannelMemberRemovals with channelId=%s", channelId)
+	}
+
+	return &model.ChannelMembersSince{
+		Members:        *dbMembers.ToModel(),
+		RemovedUserIds: removedUserIds,
+	}, nil
+}
+
+// GetMembersPaged sorts the members by username, or by role from the admins to the guests and
+// then by username. The page is first selected from the narrow ChannelMembers and Users keys
+// before looking up the full rows of its members only, so that deep pages don't load the rows
+// they skip.
+func (s SqlChannelStore) GetMembersPaged(channelId string, page, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+	var orderBy string
+	switch sort {
+	case "", model.CHANNEL_SORT_BY_USERNAME:
+		orderBy = "Users.Username, ChannelMembers.UserId"
+	case model.CHANNEL_SORT_BY_ROLE:
+		orderBy = "ChannelMembers.SchemeAdmin DESC, ChannelMembers.SchemeGuest, Users.Username, ChannelMembers.UserId"
+	default:
+		return nil, 0, store.NewErrInvalidInput("ChannelMember", "sort", sort)
+	}
+	if page < 0 || perPage <= 0 {
+		return nil, 0, store.NewErrInvalidInput("ChannelMember", "page", fmt.Sprintf("page=%d, perPage=%d", page, perPage))
+	}
+
+	count, err := s.GetReplica().SelectInt(`
+		SELECT
+			COUNT(*)
+		FROM
+			ChannelMembers
+		INNER JOIN
+			Users ON Users.Id = ChannelMembers.UserId
+		WHERE
+			ChannelMembers.ChannelId = :ChannelId`, map[string]interface{}{"ChannelId": channelId})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to count ChannelMembers with channelId=%s", channelId)
+	}
+
+	var dbMembers channelMemberWithSchemeRolesList
+	_, err = s.GetReplica().Select(&dbMembers, CHANNEL_MEMBERS_WITH_SCHEME_SELECT_QUERY+`
+		INNER JOIN (
+			SELECT
+				ChannelMembers.UserId
+			FROM
+				ChannelMembers
+			INNER JOIN
+				Users ON Users.Id = ChannelMembers.UserId
+			WHERE
+				ChannelMembers.ChannelId = :ChannelId
+			ORDER BY `+orderBy+`
+			LIMIT :Limit OFFSET :Offset
+		) MemberPage ON MemberPage.UserId = ChannelMembers.UserId
+		INNER JOIN
+			Users ON Users.Id = ChannelMembers.UserId
+		WHERE
+			ChannelMembers.ChannelId = :ChannelId
+		ORDER BY `+orderBy, map[string]interface{}{"ChannelId": channelId, "Limit": perPage, "Offset": page * perPage})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get ChannelMembers with channelId=%s", channelId)
+	}
+
+	return dbMembers.ToModel(), count, nil
+}
+
 func (s SqlChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
 	var dbMembersTimezone []model.StringMap
 	_, err := s.GetReplica().Select(&dbMembersTimezone, `
@@ -1716,7 +2023,7 @@ func (s SqlChannelStore) GetMemberForPost(postId string, userId string) (*model.
 		AND
 			Posts.Id = :PostId`
 	if err := s.GetReplica().SelectOne(&dbMember, query, map[string]interface{}{"UserId": userId, "PostId": postId}); err != nil {
-		return nil, errors.Wrapf(err, "failed to get ChannelMember with postId=%s and userId=%s", postId, userId)
+		return nil, wrapSqlError(err, "ChannelMember", postId, fmt.Sprintf("failed to get ChannelMember with postId=%s and userId=%s", postId, userId))
 	}
 	return dbMember.ToModel(), nil
 }
@@ -2007,14 +2314,53 @@ func (s SqlChannelStore) RemoveMembers(channelId string, userIds []string) error
 	if err != nil {
 		return errors.Wrap(err, "failed to delete SidebarChannels")
 	}
+
+	now := s.getMillis()
+	insert := s.getQueryBuilder().Insert("ChannelMemberRemovals").Columns("ChannelId", "UserId", "RemoveAt")
+	for _, userId := range userIds {
+		insert = insert.Values(channelId, userId, now)
+	}
+	query, args, err = s.upsertChannelMemberRemovals(insert).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_tosql")
+	}
+	if _, err = s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to save ChannelMemberRemovals")
+	}
 	return nil
 }
 
+// upsertChannelMemberRemovals updates the time a user was last removed from the channel when
+// the user was already removed before.
+func (s SqlChannelStore) upsertChannelMemberRemovals(insert sq.InsertBuilder) sq.InsertBuilder {
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		return insert.Suffix("ON DUPLICATE KEY UPDATE RemoveAt = VALUES(RemoveAt)")
+	}
+	return insert.Suffix("ON CONFLICT (ChannelId, UserId) DO UPDATE SET RemoveAt = EXCLUDED.RemoveAt")
+}
+
 func (s SqlChannelStore) RemoveMember(channelId string, userId string) error {
 	return s.RemoveMembers(channelId, []string{userId})
 }
 
 func (s SqlChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	deactivated := s.getQueryBuilder().
+		Select("ChannelMembers.ChannelId", "ChannelMembers.UserId", fmt.Sprint(s.getMillis())).
+		From("ChannelMembers").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelId}).
+		Where(sq.NotEq{"Users.DeleteAt": 0})
+	insertQuery, args, err := s.upsertChannelMemberRemovals(s.getQueryBuilder().
+		Insert("ChannelMemberRemovals").
+		Columns("ChannelId", "UserId", "RemoveAt").
+		Select(deactivated)).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "channel_tosql")
+	}
+	if _, err = s.GetMaster().Exec(insertQuery, args...); err != nil {
+		return errors.Wrapf(err, "failed to save ChannelMemberRemovals with channelId=%s", channelId)
+	}
+
 	query := `
 		DELETE
 		FROM
@@ -2032,7 +2378,7 @@ func (s SqlChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
 			ChannelMembers.ChannelId = :ChannelId
 	`
 
-	_, err := s.GetMaster().Exec(query, map[string]interface{}{"ChannelId": channelId})
+	_, err = s.GetMaster().Exec(query, map[string]interface{}{"ChannelId": channelId})
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete ChannelMembers with channelId=%s", channelId)
 	}
@@ -2048,7 +2394,7 @@ func (s SqlChannelStore) PermanentDeleteMembersByUser(userId string) error {
 
 func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
 	var threadsToUpdate []string
-	now := model.GetMillis()
+	now := s.getMillis()
 	if updateThreads {
 		var err error
 		threadsToUpdate, err = s.Thread().CollectThreadsWithNewerReplies(userId, channelIds, now)
@@ -2141,21 +2487,95 @@ func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string,
 	return times, nil
 }
 
+// UpdateLastViewedAtMulti marks the channels as read by the user up to the last post created at
+// or before timestamp, leaving the posts created afterwards unread, and returns the unread counts
+// of the channels once updated. The mention count is cleared for the channels left without any
+// unread post, since the mentions of the remaining ones can't be told apart.
+func (s SqlChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	if len(channelIds) == 0 {
+		return map[string]*model.ChannelUnreadCounts{}, nil
+	}
+
+	keys, props := MapStringsToQueryParams(channelIds, "Channel")
+	joinLeavePostTypes, postTypeProps := MapStringsToQueryParams(joinLeaveTypes, "PostType")
+	for key, value := range postTypeProps {
+		props[key] = value
+	}
+	props["UserId"] = userId
+	props["Timestamp"] = timestamp
+	props["UpdateAt"] = s.getMillis()
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	// The counts are updated apart from LastViewedAt since MySQL assigns the columns in turn,
+	// where PostgreSQL computes all of them from the previous values.
+	if _, err := transaction.Exec(`UPDATE
+			ChannelMembers
+		SET
+			LastViewedAt = COALESCE((
+				SELECT MAX(p.CreateAt) FROM Posts p
+				WHERE p.ChannelId = ChannelMembers.ChannelId
+					AND p.CreateAt > ChannelMembers.LastViewedAt
+					AND p.CreateAt <= :Timestamp
+			), LastViewedAt),
+			LastUpdateAt = :UpdateAt
+		WHERE
+			UserId = :UserId
+			AND ChannelId IN `+keys, props); err != nil {
+		return nil, errors.Wrapf(err, "failed to update the LastViewedAt of ChannelMembers with userId=%s and channelId in %v", userId, channelIds)
+	}
+
+	// The message count is computed from the total of the channel as in UpdateLastViewedAtPost,
+	// counting only the posts left unread.
+	unreadPosts := `(SELECT COUNT(*) FROM Posts p
+		WHERE p.ChannelId = ChannelMembers.ChannelId
+			AND p.CreateAt > ChannelMembers.LastViewedAt
+			AND p.Type NOT IN ` + joinLeavePostTypes + `
+			AND p.DeleteAt = 0)`
+	if _, err := transaction.Exec(`UPDATE
+			ChannelMembers
+		SET
+			MsgCount = (SELECT TotalMsgCount FROM Channels WHERE Channels.Id = ChannelMembers.ChannelId) - `+unreadPosts+`,
+			MentionCount = CASE WHEN `+unreadPosts+` = 0 THEN 0 ELSE MentionCount END
+		WHERE
+			UserId = :UserId
+			AND ChannelId IN `+keys, props); err != nil {
+		return nil, errors.Wrapf(err, "failed to update the counts of ChannelMembers with userId=%s and channelId in %v", userId, channelIds)
+	}
+
+	counts, err := s.getUnreadCounts(transaction, userId, channelIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+	return counts, nil
+}
+
+// joinLeaveTypes are the types of the posts that aren't counted as unread, which correspond to the
+// ones checked by Post.IsJoinLeaveMessage.
+var joinLeaveTypes = []string{
+	model.POST_JOIN_LEAVE,
+	model.POST_ADD_REMOVE,
+	model.POST_JOIN_CHANNEL,
+	model.POST_LEAVE_CHANNEL,
+	model.POST_JOIN_TEAM,
+	model.POST_LEAVE_TEAM,
+	model.POST_ADD_TO_CHANNEL,
+	model.POST_REMOVE_FROM_CHANNEL,
+	model.POST_ADD_TO_TEAM,
+	model.POST_REMOVE_FROM_TEAM,
+}
+
 // CountPostsAfter returns the number of posts in the given channel created after but not including the given timestamp. If given a non-empty user ID, only counts posts made by that user.
 func (s SqlChannelStore) CountPostsAfter(channelId string, timestamp int64, userId string) (int, error) {
-	joinLeavePostTypes, params := MapStringsToQueryParams([]string{
-		// These types correspond to the ones checked by Post.IsJoinLeaveMessage
-		model.POST_JOIN_LEAVE,
-		model.POST_ADD_REMOVE,
-		model.POST_JOIN_CHANNEL,
-		model.POST_LEAVE_CHANNEL,
-		model.POST_JOIN_TEAM,
-		model.POST_LEAVE_TEAM,
-		model.POST_ADD_TO_CHANNEL,
-		model.POST_REMOVE_FROM_CHANNEL,
-		model.POST_ADD_TO_TEAM,
-		model.POST_REMOVE_FROM_TEAM,
-	}, "PostType")
+	joinLeavePostTypes, params := MapStringsToQueryParams(joinLeaveTypes, "PostType")
 
 	query := `
 	SELECT count(*)
@@ -2207,7 +2627,7 @@ func (s SqlChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID s
 		"lastViewedAt": unreadDate,
 		"userId":       userID,
 		"channelId":    unreadPost.ChannelId,
-		"updatedAt":    model.GetMillis(),
+		"updatedAt":    s.getMillis(),
 	}
 
 	// msg count uses the value from channels to prevent counting on older channels where no. of messages can be high.
@@ -2258,7 +2678,7 @@ func (s SqlChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID s
 }
 
 func (s SqlChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
-	now := model.GetMillis()
+	now := s.getMillis()
 	var threadsToUpdate []string
 	if updateThreads {
 		var err error
@@ -2326,7 +2746,7 @@ func (s SqlChannelStore) GetForPost(postId string) (*model.Channel, error) {
 		WHERE
 			Channels.Id = Posts.ChannelId
 			AND Posts.Id = :PostId`, map[string]interface{}{"PostId": postId}); err != nil {
-		return nil, errors.Wrapf(err, "failed to get Channel with postId=%s", postId)
+		return nil, wrapSqlError(err, "Channel", postId, fmt.Sprintf("failed to get Channel with postId=%s", postId))
 
 	}
 	return channel, nil
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// Clock tells the store the current time, which it stamps the rows it writes with and compares
+// the expiry times against. Tests replace it to control the time seen by the store.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SetClock replaces the clock of the store. A nil clock restores the system clock.
+func (ss *SqlSupplier) SetClock(clock Clock) {
+	ss.clockMutex.Lock()
+	defer ss.clockMutex.Unlock()
+	ss.clock = clock
+}
+
+func (ss *SqlSupplier) getClock() Clock {
+	ss.clockMutex.RLock()
+	defer ss.clockMutex.RUnlock()
+
+	if ss.clock == nil {
+		return systemClock{}
+	}
+	return ss.clock
+}
+
+// getMillis returns the current time of the clock of the store, in milliseconds like
+// model.GetMillis.
+func (ss *SqlSupplier) getMillis() int64 {
+	return model.GetMillisForTime(ss.getClock().Now())
+}
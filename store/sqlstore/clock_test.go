@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSqlSupplierClock(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		supplier := ss.(*SqlSupplier)
+		defer supplier.SetClock(nil)
+
+		now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		supplier.SetClock(fixedClock(now))
+
+		session, err := ss.Session().Save(&model.Session{
+			UserId:    model.NewId(),
+			ExpiresAt: model.GetMillisForTime(now.Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		defer ss.Session().Remove(session.Id)
+
+		t.Run("stamps the rows with the clock", func(t *testing.T) {
+			assert.Equal(t, model.GetMillisForTime(now), session.CreateAt)
+			assert.Equal(t, session.CreateAt, session.LastActivityAt)
+		})
+
+		isExpired := func() bool {
+			expired, err := ss.Session().GetSessionsExpired(24*60*60*1000, false, false)
+			require.NoError(t, err)
+			for _, expiredSession := range expired {
+				if expiredSession.Id == session.Id {
+					return true
+				}
+			}
+			return false
+		}
+
+		t.Run("compares the expiry times against the clock", func(t *testing.T) {
+			assert.False(t, isExpired())
+
+			supplier.SetClock(fixedClock(now.Add(2 * time.Hour)))
+			assert.True(t, isExpired())
+		})
+
+		t.Run("restores the system clock", func(t *testing.T) {
+			supplier.SetClock(nil)
+			assert.False(t, isExpired())
+		})
+	})
+}
@@ -30,13 +30,17 @@ func newSqlClusterDiscoveryStore(sqlStore SqlStore) store.ClusterDiscoveryStore
 }
 
 func (s sqlClusterDiscoveryStore) Save(ClusterDiscovery *model.ClusterDiscovery) error {
+	if ClusterDiscovery.CreateAt == 0 {
+		ClusterDiscovery.CreateAt = s.getMillis()
+		ClusterDiscovery.LastPingAt = ClusterDiscovery.CreateAt
+	}
 	ClusterDiscovery.PreSave()
 	if err := ClusterDiscovery.IsValid(); err != nil {
 		return err
 	}
 
 	if err := s.GetMaster().Insert(ClusterDiscovery); err != nil {
-		return errors.Wrap(err, "failed to save ClusterDiscovery")
+		return wrapSqlError(err, "ClusterDiscovery", ClusterDiscovery.Id, "failed to save ClusterDiscovery")
 	}
 	return nil
 }
@@ -92,7 +96,7 @@ func (s sqlClusterDiscoveryStore) GetAll(ClusterDiscoveryType, clusterName strin
 		From("ClusterDiscovery").
 		Where(sq.Eq{"Type": ClusterDiscoveryType}).
 		Where(sq.Eq{"ClusterName": clusterName}).
-		Where(sq.Gt{"LastPingAt": model.GetMillis() - model.CDS_OFFLINE_AFTER_MILLIS})
+		Where(sq.Gt{"LastPingAt": s.getMillis() - model.CDS_OFFLINE_AFTER_MILLIS})
 
 	queryString, args, err := query.ToSql()
 	if err != nil {
@@ -109,7 +113,7 @@ func (s sqlClusterDiscoveryStore) GetAll(ClusterDiscoveryType, clusterName strin
 func (s sqlClusterDiscoveryStore) SetLastPingAt(ClusterDiscovery *model.ClusterDiscovery) error {
 	query := s.getQueryBuilder().
 		Update("ClusterDiscovery").
-		Set("LastPingAt", model.GetMillis()).
+		Set("LastPingAt", s.getMillis()).
 		Where(sq.Eq{"Type": ClusterDiscovery.Type}).
 		Where(sq.Eq{"ClusterName": ClusterDiscovery.ClusterName}).
 		Where(sq.Eq{"Hostname": ClusterDiscovery.Hostname})
@@ -128,7 +132,7 @@ func (s sqlClusterDiscoveryStore) SetLastPingAt(ClusterDiscovery *model.ClusterD
 func (s sqlClusterDiscoveryStore) Cleanup() error {
 	query := s.getQueryBuilder().
 		Delete("ClusterDiscovery").
-		Where(sq.Lt{"LastPingAt": model.GetMillis() - model.CDS_OFFLINE_AFTER_MILLIS})
+		Where(sq.Lt{"LastPingAt": s.getMillis() - model.CDS_OFFLINE_AFTER_MILLIS})
 
 	queryString, args, err := query.ToSql()
 	if err != nil {
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"reflect"
+
+	"github.com/mattermost/gorp"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// cockroachDialect is the Postgres dialect with the auto increment columns CockroachDB
+// supports: SERIAL columns are backed by sequences only depending on the serial_normalization
+// session setting, so they are declared with unique_rowid() explicitly instead.
+type cockroachDialect struct {
+	gorp.PostgresDialect
+}
+
+func (d cockroachDialect) ToSqlType(val reflect.Type, maxsize int, isAutoIncr bool) string {
+	if isAutoIncr {
+		return "INT8"
+	}
+	return d.PostgresDialect.ToSqlType(val, maxsize, isAutoIncr)
+}
+
+func (d cockroachDialect) AutoIncrStr() string {
+	return "DEFAULT unique_rowid()"
+}
+
+// sqlDriverName returns the database/sql driver connecting to the given database. CockroachDB
+// speaks the Postgres wire protocol, so it's reached through the Postgres driver.
+func sqlDriverName(driverName string) string {
+	if driverName == model.DATABASE_DRIVER_COCKROACH {
+		return model.DATABASE_DRIVER_POSTGRES
+	}
+	return driverName
+}
+
+func (ss *SqlSupplier) isCockroach() bool {
+	return *ss.settings.DriverName == model.DATABASE_DRIVER_COCKROACH
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mattermost/gorp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestCockroachDialect(t *testing.T) {
+	dialect := cockroachDialect{}
+
+	assert.Equal(t, "INT8", dialect.ToSqlType(reflect.TypeOf(int64(0)), 0, true))
+	assert.Equal(t, "DEFAULT unique_rowid()", dialect.AutoIncrStr())
+	assert.Equal(t, gorp.PostgresDialect{}.ToSqlType(reflect.TypeOf(""), 26, false), dialect.ToSqlType(reflect.TypeOf(""), 26, false))
+	assert.Equal(t, model.DATABASE_DRIVER_POSTGRES, sqlDriverName(model.DATABASE_DRIVER_COCKROACH))
+	assert.Equal(t, model.DATABASE_DRIVER_MYSQL, sqlDriverName(model.DATABASE_DRIVER_MYSQL))
+}
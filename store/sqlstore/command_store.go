@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
@@ -59,12 +60,14 @@ func (s SqlCommandStore) Save(command *model.Command) (*model.Command, error) {
 	}
 
 	command.PreSave()
+	command.CreateAt = s.getMillis()
+	command.UpdateAt = command.CreateAt
 	if err := command.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(command); err != nil {
-		return nil, errors.Wrapf(err, "insert: command_id=%s", command.Id)
+		return nil, wrapSqlError(err, "Command", command.Id, fmt.Sprintf("insert: command_id=%s", command.Id))
 	}
 
 	return command, nil
@@ -174,7 +177,7 @@ func (s SqlCommandStore) PermanentDeleteByUser(userId string) error {
 }
 
 func (s SqlCommandStore) Update(cmd *model.Command) (*model.Command, error) {
-	cmd.UpdateAt = model.GetMillis()
+	cmd.UpdateAt = s.getMillis()
 
 	if err := cmd.IsValid(); err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -44,13 +45,16 @@ func (s SqlCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.Comm
 		return nil, store.NewErrInvalidInput("CommandWebhook", "id", webhook.Id)
 	}
 
+	if webhook.CreateAt == 0 {
+		webhook.CreateAt = s.getMillis()
+	}
 	webhook.PreSave()
 	if err := webhook.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(webhook); err != nil {
-		return nil, errors.Wrapf(err, "save: id=%s", webhook.Id)
+		return nil, wrapSqlError(err, "CommandWebhook", webhook.Id, fmt.Sprintf("save: id=%s", webhook.Id))
 	}
 
 	return webhook, nil
@@ -59,7 +63,7 @@ func (s SqlCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.Comm
 func (s SqlCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
 	var webhook model.CommandWebhook
 
-	exptime := model.GetMillis() - model.COMMAND_WEBHOOK_LIFETIME
+	exptime := s.getMillis() - model.COMMAND_WEBHOOK_LIFETIME
 
 	query := s.getQueryBuilder().
 		Select("*").
@@ -105,7 +109,7 @@ func (s SqlCommandWebhookStore) TryUse(id string, limit int) error {
 
 func (s SqlCommandWebhookStore) Cleanup() {
 	mlog.Debug("Cleaning up command webhook store.")
-	exptime := model.GetMillis() - model.COMMAND_WEBHOOK_LIFETIME
+	exptime := s.getMillis() - model.COMMAND_WEBHOOK_LIFETIME
 
 	query := s.getQueryBuilder().
 		Delete("CommandWebhooks").
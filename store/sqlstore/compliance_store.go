@@ -39,12 +39,13 @@ func (s SqlComplianceStore) createIndexesIfNotExists() {
 
 func (s SqlComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
 	compliance.PreSave()
+	compliance.CreateAt = s.getMillis()
 	if err := compliance.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(compliance); err != nil {
-		return nil, errors.Wrap(err, "failed to save Compliance")
+		return nil, wrapSqlError(err, "Compliance", compliance.Id, "failed to save Compliance")
 	}
 	return compliance, nil
 }
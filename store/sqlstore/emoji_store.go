@@ -4,8 +4,9 @@
 package sqlstore
 
 import (
-	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -46,12 +47,14 @@ func (es SqlEmojiStore) createIndexesIfNotExists() {
 
 func (es SqlEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	emoji.PreSave()
+	emoji.CreateAt = es.getMillis()
+	emoji.UpdateAt = emoji.CreateAt
 	if err := emoji.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := es.GetMaster().Insert(emoji); err != nil {
-		return nil, errors.Wrap(err, "error saving emoji")
+		return nil, wrapSqlError(err, "Emoji", emoji.Name, "error saving emoji")
 	}
 
 	return emoji, nil
@@ -145,6 +148,72 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	return emojis, nil
 }
 
+func (es SqlEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	usages := make(map[string]*model.EmojiUsage, len(emojiNames))
+	for _, name := range emojiNames {
+		usages[name] = &model.EmojiUsage{Name: name}
+	}
+	if len(emojiNames) == 0 {
+		return usages, nil
+	}
+
+	var counts []struct {
+		Name   string
+		Usages int64
+	}
+
+	keys, params := MapStringsToQueryParams(emojiNames, "EmojiName")
+	if _, err := es.GetReplica().Select(&counts,
+		`SELECT
+			Reactions.EmojiName AS Name,
+			COUNT(*) AS Usages
+		FROM
+			Reactions
+			INNER JOIN Posts ON Posts.Id = Reactions.PostId
+		WHERE
+			Reactions.EmojiName IN `+keys+`
+			AND Posts.DeleteAt = 0
+		GROUP BY Reactions.EmojiName`, params); err != nil {
+		return nil, errors.Wrapf(err, "could not count the reactions of emojis %v", emojiNames)
+	}
+	for _, count := range counts {
+		usages[count.Name].ReactionCount = count.Usages
+	}
+
+	if !includeMessages {
+		return usages, nil
+	}
+
+	// The names are matched with the pattern of their shortcode, as within ":emoji_name:".
+	names := make([]string, 0, len(usages))
+	params = map[string]interface{}{}
+	for name := range usages {
+		key := strconv.Itoa(len(names))
+		names = append(names, "SELECT :Name"+key+" AS Name, :Pattern"+key+" AS Pattern")
+		params["Name"+key] = name
+		params["Pattern"+key] = "%:" + sanitizeSearchTerm(name, "*") + ":%"
+	}
+
+	counts = nil
+	if _, err := es.GetReplica().Select(&counts,
+		`SELECT
+			Names.Name,
+			COUNT(*) AS Usages
+		FROM
+			(`+strings.Join(names, " UNION ALL ")+`) AS Names
+			INNER JOIN Posts ON Posts.Message LIKE Names.Pattern ESCAPE '*'
+		WHERE
+			Posts.DeleteAt = 0
+		GROUP BY Names.Name`, params); err != nil {
+		return nil, errors.Wrapf(err, "could not count the messages using emojis %v", emojiNames)
+	}
+	for _, count := range counts {
+		usages[count.Name].MessageCount = count.Usages
+	}
+
+	return usages, nil
+}
+
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(what, key string, addToCache bool) (*model.Emoji, error) {
 	var emoji *model.Emoji
@@ -158,11 +227,7 @@ func (es SqlEmojiStore) getBy(what, key string, addToCache bool) (*model.Emoji,
 			`+what+` = :Key
 			AND DeleteAt = 0`, map[string]string{"Key": key})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, store.NewErrNotFound("Emoji", fmt.Sprintf("%s=%s", what, key))
-		}
-
-		return nil, errors.Wrapf(err, "could not get emoji by %s with value %s", what, key)
+		return nil, wrapSqlError(err, "Emoji", fmt.Sprintf("%s=%s", what, key), fmt.Sprintf("could not get emoji by %s with value %s", what, key))
 	}
 
 	return emoji, nil
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestWrapSqlError(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, wrapSqlError(nil, "Emoji", "id", "failed"))
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		err := wrapSqlError(errors.Wrap(sql.ErrNoRows, "select"), "Emoji", "id", "failed")
+		assert.True(t, errors.Is(err, &store.ErrNotFound{}))
+		assert.True(t, errors.Is(err, store.NewErrNotFound("Emoji", "")))
+		assert.True(t, errors.Is(err, store.NewErrNotFound("Emoji", "id")))
+		assert.False(t, errors.Is(err, store.NewErrNotFound("Role", "")))
+		assert.False(t, errors.Is(err, store.NewErrNotFound("Emoji", "other")))
+		assert.False(t, errors.Is(err, &store.ErrConflict{}))
+	})
+
+	t.Run("unique violations", func(t *testing.T) {
+		for _, driverErr := range []error{
+			&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"},
+			&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"},
+		} {
+			err := wrapSqlError(driverErr, "Emoji", "name", "failed")
+			assert.True(t, errors.Is(err, &store.ErrConflict{}))
+			assert.True(t, errors.Is(err, &store.ErrConflict{Resource: "Emoji"}))
+			assert.False(t, errors.Is(err, &store.ErrConflict{Resource: "Role"}))
+			assert.True(t, errors.Is(err, driverErr))
+			assert.Contains(t, err.Error(), "failed")
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		driverErr := &pq.Error{Code: "23503", Message: "foreign key violation"}
+		err := wrapSqlError(driverErr, "Emoji", "id", "failed")
+		assert.False(t, errors.Is(err, &store.ErrConflict{}))
+		assert.False(t, errors.Is(err, &store.ErrNotFound{}))
+		assert.True(t, errors.Is(err, driverErr))
+		assert.Equal(t, "failed: pq: foreign key violation", err.Error())
+	})
+}
+
+func TestQueryTimeout(t *testing.T) {
+	// sleepQueries run for longer than the timeout of the tests.
+	sleepQueries := map[string]string{
+		model.DATABASE_DRIVER_POSTGRES: "SELECT pg_sleep(5)",
+		model.DATABASE_DRIVER_MYSQL:    "SELECT SLEEP(5)",
+		model.DATABASE_DRIVER_SQLITE:   "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT COUNT(*) FROM c",
+	}
+
+	for driver, query := range sleepQueries {
+		driver, query := driver, query
+		t.Run(driver, func(t *testing.T) {
+			t.Parallel()
+			settings := storetest.MakeSqlSettings(driver)
+			defer storetest.CleanupSqlSettings(settings)
+			*settings.QueryTimeout = 1
+
+			supplier, err := NewSqlSupplier(*settings, nil)
+			require.Nil(t, err)
+			defer supplier.Close()
+
+			t.Run("cancels the queries running longer than the timeout", func(t *testing.T) {
+				start := time.Now()
+				_, err := supplier.GetMaster().Exec(query)
+				assert.Less(t, int64(time.Since(start)), int64(4*time.Second))
+
+				assert.True(t, isQueryTimeout(err))
+			})
+
+			t.Run("reports the timeouts of the store methods", func(t *testing.T) {
+				master := supplier.GetMaster()
+				master.QueryTimeout = time.Nanosecond
+				defer func() { master.QueryTimeout = time.Second }()
+
+				_, err := supplier.System().Get()
+				assert.True(t, errors.Is(err, &store.ErrQueryTimeout{}))
+				var timeoutErr *store.ErrQueryTimeout
+				assert.True(t, errors.As(err, &timeoutErr))
+			})
+
+			t.Run("overrides the timeout per call", func(t *testing.T) {
+				_, err := supplier.GetMasterWithTimeout(10 * time.Millisecond).Exec(query)
+				assert.True(t, isQueryTimeout(err))
+				assert.Equal(t, time.Second, supplier.GetMaster().QueryTimeout)
+			})
+		})
+	}
+}
+
+func TestErrInvalidInputIs(t *testing.T) {
+	err := errors.Wrap(store.NewErrInvalidInput("User", "email", "a@b"), "failed")
+	assert.True(t, errors.Is(err, &store.ErrInvalidInput{}))
+	assert.True(t, errors.Is(err, &store.ErrInvalidInput{Entity: "User"}))
+	assert.True(t, errors.Is(err, &store.ErrInvalidInput{Entity: "User", Field: "email"}))
+	assert.False(t, errors.Is(err, &store.ErrInvalidInput{Entity: "User", Field: "username"}))
+	assert.False(t, errors.Is(err, &store.ErrInvalidInput{Entity: "Team"}))
+}
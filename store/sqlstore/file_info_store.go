@@ -6,6 +6,8 @@ package sqlstore
 import (
 	"database/sql"
 	"fmt"
+	"regexp"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
@@ -76,18 +78,24 @@ func (fs SqlFileInfoStore) createIndexesIfNotExists() {
 }
 
 func (fs SqlFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	if info.CreateAt == 0 {
+		info.CreateAt = fs.getMillis()
+	}
 	info.PreSave()
 	if err := info.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := fs.GetMaster().Insert(info); err != nil {
-		return nil, errors.Wrap(err, "failed to save FileInfo")
+		return nil, wrapSqlError(err, "FileInfo", info.Id, "failed to save FileInfo")
 	}
 	return info, nil
 }
 
 func (fs SqlFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	if info.CreateAt == 0 {
+		info.CreateAt = fs.getMillis()
+	}
 	info.PreSave()
 	if err := info.IsValid(); err != nil {
 		return nil, err
@@ -99,7 +107,7 @@ func (fs SqlFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error)
 	}
 	if n == 0 {
 		if err = fs.GetMaster().Insert(info); err != nil {
-			return nil, errors.Wrap(err, "failed to save FileInfo")
+			return nil, wrapSqlError(err, "FileInfo", info.Id, "failed to save FileInfo")
 		}
 	}
 	return info, nil
@@ -128,6 +136,26 @@ func (fs SqlFileInfoStore) Get(id string) (*model.FileInfo, error) {
 	return info, nil
 }
 
+func (fs SqlFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Where(sq.Eq{"Id": ids}).
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("CreateAt DESC")
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var infos []*model.FileInfo
+	if _, err := fs.GetReplica().Select(&infos, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos")
+	}
+	return infos, nil
+}
+
 func (fs SqlFileInfoStore) GetWithOptions(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 	if perPage < 0 {
 		return nil, store.NewErrLimitExceeded("perPage", perPage, "value used in pagination while getting FileInfos")
@@ -340,7 +368,7 @@ func (fs SqlFileInfoStore) DeleteForPost(postId string) (string, error) {
 			SET
 				DeleteAt = :DeleteAt
 			WHERE
-				PostId = :PostId`, map[string]interface{}{"DeleteAt": model.GetMillis(), "PostId": postId}); err != nil {
+				PostId = :PostId`, map[string]interface{}{"DeleteAt": fs.getMillis(), "PostId": postId}); err != nil {
 		return "", errors.Wrapf(err, "failed to update FileInfo with postId=%s", postId)
 	}
 	return postId, nil
@@ -393,3 +421,120 @@ func (fs SqlFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
 
 	return rowsAffected, nil
 }
+
+var fileSearchPhrase = regexp.MustCompile(`"([^"]+)"`)
+
+// getFileSearchTerms splits the terms of a search into the quoted phrases and words to look
+// for in the file names.
+func getFileSearchTerms(terms string) []string {
+	searchTerms := []string{}
+	for _, phrase := range fileSearchPhrase.FindAllStringSubmatch(terms, -1) {
+		searchTerms = append(searchTerms, phrase[1])
+	}
+	searchTerms = append(searchTerms, strings.Fields(fileSearchPhrase.ReplaceAllString(terms, " "))...)
+	return searchTerms
+}
+
+func fileNameLikeClauses(terms string, like string) []sq.Sqlizer {
+	clauses := []sq.Sqlizer{}
+	for _, term := range getFileSearchTerms(terms) {
+		term = sanitizeSearchTerm(strings.ToLower(term), "*")
+		if term == "" {
+			continue
+		}
+		clauses = append(clauses, sq.Expr("LOWER(FileInfo.Name) "+like+" ? ESCAPE '*'", "%"+term+"%"))
+	}
+	return clauses
+}
+
+// Search looks for the files attached to the posts of the channels the user is a member of.
+// Only the file names are searched, the content extracted from the files is left to the
+// search engines.
+func (fs SqlFileInfoStore) Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Join("Posts ON FileInfo.PostId = Posts.Id").
+		Where(sq.Eq{"FileInfo.DeleteAt": 0}).
+		OrderBy("FileInfo.CreateAt DESC").
+		Limit(uint64(perPage)).
+		Offset(uint64(page * perPage))
+
+	channelsQuery := `Posts.ChannelId IN (
+		SELECT Channels.Id FROM Channels, ChannelMembers
+		WHERE Channels.Id = ChannelMembers.ChannelId
+			AND (Channels.TeamId = ? OR Channels.TeamId = '')
+			AND ChannelMembers.UserId = ?`
+	if len(paramsList) == 0 || !paramsList[0].IncludeDeletedChannels {
+		channelsQuery += " AND Channels.DeleteAt = 0"
+	}
+	query = query.Where(sq.Expr(channelsQuery+")", teamId, userId))
+
+	termClauses := []sq.Sqlizer{}
+	for i, params := range paramsList {
+		// Channels, users and date filters are global to the query,
+		// so we only need to process them once
+		if i == 0 {
+			if len(params.InChannels) > 0 {
+				query = query.Where(sq.Eq{"Posts.ChannelId": params.InChannels})
+			}
+			if len(params.ExcludedChannels) > 0 {
+				query = query.Where(sq.NotEq{"Posts.ChannelId": params.ExcludedChannels})
+			}
+			if len(params.FromUsers) > 0 {
+				query = query.Where(sq.Eq{"FileInfo.CreatorId": params.FromUsers})
+			}
+			if len(params.ExcludedUsers) > 0 {
+				query = query.Where(sq.NotEq{"FileInfo.CreatorId": params.ExcludedUsers})
+			}
+
+			if params.OnDate != "" {
+				before, after := params.GetOnDateMillis()
+				query = query.Where(sq.GtOrEq{"FileInfo.CreateAt": before}).Where(sq.LtOrEq{"FileInfo.CreateAt": after})
+			} else {
+				if params.AfterDate != "" {
+					query = query.Where(sq.GtOrEq{"FileInfo.CreateAt": params.GetAfterDateMillis()})
+				}
+				if params.BeforeDate != "" {
+					query = query.Where(sq.LtOrEq{"FileInfo.CreateAt": params.GetBeforeDateMillis()})
+				}
+			}
+		}
+
+		// Files don't have hashtags
+		if params.IsHashtag {
+			continue
+		}
+
+		termClauses = append(termClauses, fileNameLikeClauses(params.Terms, "LIKE")...)
+		for _, clause := range fileNameLikeClauses(params.ExcludedTerms, "NOT LIKE") {
+			query = query.Where(clause)
+		}
+	}
+
+	if len(termClauses) > 0 {
+		if len(paramsList) > 0 && paramsList[0].OrTerms {
+			query = query.Where(sq.Or(termClauses))
+		} else {
+			query = query.Where(sq.And(termClauses))
+		}
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "file_info_tosql")
+	}
+
+	var infos []*model.FileInfo
+	if _, err := fs.GetReplica().Select(&infos, queryString, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to search FileInfos for userId=%s and teamId=%s", userId, teamId)
+	}
+
+	fileInfoList := model.NewFileInfoList()
+	for _, info := range infos {
+		fileInfoList.AddFileInfo(info)
+		fileInfoList.AddOrder(info.Id)
+	}
+
+	return fileInfoList, nil
+}
@@ -101,7 +101,7 @@ func (s *SqlGroupStore) Create(group *model.Group) (*model.Group, *model.AppErro
 	}
 
 	group.Id = model.NewId()
-	group.CreateAt = model.GetMillis()
+	group.CreateAt = s.getMillis()
 	group.UpdateAt = group.CreateAt
 
 	if err := s.GetMaster().Insert(group); err != nil {
@@ -219,7 +219,7 @@ func (s *SqlGroupStore) Update(group *model.Group) (*model.Group, *model.AppErro
 
 	// Reset these properties, don't update them based on input
 	group.CreateAt = retrievedGroup.CreateAt
-	group.UpdateAt = model.GetMillis()
+	group.UpdateAt = s.getMillis()
 
 	if err := group.IsValidForUpdate(); err != nil {
 		return nil, err
@@ -248,7 +248,7 @@ func (s *SqlGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
 		return nil, model.NewAppError("SqlGroupStore.GroupDelete", "store.select_error", nil, err.Error(), http.StatusInternalServerError)
 	}
 
-	time := model.GetMillis()
+	time := s.getMillis()
 	group.DeleteAt = time
 	group.UpdateAt = time
 
@@ -394,7 +394,7 @@ func (s *SqlGroupStore) UpsertMember(groupID string, userID string) (*model.Grou
 	member := &model.GroupMember{
 		GroupId:  groupID,
 		UserId:   userID,
-		CreateAt: model.GetMillis(),
+		CreateAt: s.getMillis(),
 	}
 
 	if err := member.IsValid(); err != nil {
@@ -444,7 +444,7 @@ func (s *SqlGroupStore) DeleteMember(groupID string, userID string) (*model.Grou
 		return nil, model.NewAppError("SqlGroupStore.GroupDeleteMember", "store.select_error", nil, "group_id="+groupID+"user_id="+userID+","+err.Error(), http.StatusInternalServerError)
 	}
 
-	retrievedMember.DeleteAt = model.GetMillis()
+	retrievedMember.DeleteAt = s.getMillis()
 
 	if _, err := s.GetMaster().Update(retrievedMember); err != nil {
 		return nil, model.NewAppError("SqlGroupStore.GroupDeleteMember", "store.update_error", nil, err.Error(), http.StatusInternalServerError)
@@ -467,7 +467,7 @@ func (s *SqlGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable)
 
 	// Reset values that shouldn't be updatable by parameter
 	groupSyncable.DeleteAt = 0
-	groupSyncable.CreateAt = model.GetMillis()
+	groupSyncable.CreateAt = s.getMillis()
 	groupSyncable.UpdateAt = groupSyncable.CreateAt
 
 	var insertErr error
@@ -672,7 +672,7 @@ func (s *SqlGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable)
 
 	// Reset these properties, don't update them based on input
 	groupSyncable.CreateAt = retrievedGroupSyncable.CreateAt
-	groupSyncable.UpdateAt = model.GetMillis()
+	groupSyncable.UpdateAt = s.getMillis()
 
 	switch groupSyncable.Type {
 	case model.GroupSyncableTypeTeam:
@@ -703,7 +703,7 @@ func (s *SqlGroupStore) DeleteGroupSyncable(groupID string, syncableID string, s
 		return nil, model.NewAppError("SqlGroupStore.GroupDeleteGroupSyncable", "store.sql_group.group_syncable_already_deleted", nil, "group_id="+groupID+"syncable_id="+syncableID, http.StatusBadRequest)
 	}
 
-	time := model.GetMillis()
+	time := s.getMillis()
 	groupSyncable.DeleteAt = time
 	groupSyncable.UpdateAt = time
 
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const (
+	// CONN_VALIDATION_INTERVAL is how often the connections are validated in the background.
+	CONN_VALIDATION_INTERVAL = 30 * time.Second
+	// CONN_VALIDATION_MAX_FAILURES is how many background validations may fail in a row before
+	// HealthCheck reports the supplier as unhealthy.
+	CONN_VALIDATION_MAX_FAILURES = 3
+)
+
+// ErrUnhealthy is returned by HealthCheck once CONN_VALIDATION_MAX_FAILURES background
+// validations of the connections failed in a row, until one of them succeeds again.
+var ErrUnhealthy = errors.New("the database connections failed their last validations")
+
+// HealthCheck runs SqlSettings.ConnValidationQuery on the master and on every replica, failing
+// with the first connection that doesn't answer. Each query gives up once ctx is done, or after
+// DB_PING_TIMEOUT_SECS if ctx has no earlier deadline, so a dead connection can't block it.
+func (ss *SqlSupplier) HealthCheck(ctx context.Context) error {
+	if ss.isShuttingDown() {
+		return ErrShuttingDown
+	}
+
+	if failures := atomic.LoadInt32(&ss.connValidationFailures); failures >= CONN_VALIDATION_MAX_FAILURES {
+		return errors.Wrapf(ErrUnhealthy, "%d validations failed in a row", failures)
+	}
+
+	return ss.validateConnections(ctx)
+}
+
+func (ss *SqlSupplier) validateConnections(ctx context.Context) error {
+	query := connValidationQuery(ss.settings)
+
+	if err := healthCheckConn(ctx, ss.master, query); err != nil {
+		return errors.Wrap(err, "failed to reach master database")
+	}
+	for i, replica := range ss.replicas {
+		if err := healthCheckConn(ctx, replica, query); err != nil {
+			return errors.Wrapf(err, "failed to reach replica-%v database", i)
+		}
+	}
+	for i, replica := range ss.searchReplicas {
+		if err := healthCheckConn(ctx, replica, query); err != nil {
+			return errors.Wrapf(err, "failed to reach search-replica-%v database", i)
+		}
+	}
+
+	return nil
+}
+
+func connValidationQuery(settings *model.SqlSettings) string {
+	if settings.ConnValidationQuery != nil {
+		return *settings.ConnValidationQuery
+	}
+	return model.SQL_SETTINGS_DEFAULT_CONN_VALIDATION_QUERY
+}
+
+func healthCheckConn(ctx context.Context, db *gorp.DbMap, query string) error {
+	ctx, cancel := context.WithTimeout(ctx, DB_PING_TIMEOUT_SECS*time.Second)
+	defer cancel()
+
+	_, err := db.Db.ExecContext(ctx, query)
+	return err
+}
+
+// startConnValidation validates the connections every interval until the supplier shuts down.
+func (ss *SqlSupplier) startConnValidation(interval time.Duration) {
+	ss.connValidationStop = make(chan struct{})
+	ss.connValidationDone = make(chan struct{})
+
+	go func() {
+		defer close(ss.connValidationDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ss.runConnValidation()
+			case <-ss.connValidationStop:
+				return
+			}
+		}
+	}()
+}
+
+func (ss *SqlSupplier) stopConnValidation() {
+	if ss.connValidationStop == nil {
+		return
+	}
+	close(ss.connValidationStop)
+	<-ss.connValidationDone
+}
+
+// runConnValidation validates the connections, counting the validations failing in a row.
+func (ss *SqlSupplier) runConnValidation() {
+	if err := ss.validateConnections(context.Background()); err != nil {
+		failures := atomic.AddInt32(&ss.connValidationFailures, 1)
+		mlog.Warn("Failed to validate the database connections", mlog.Int("failures", int(failures)), mlog.Err(err))
+		return
+	}
+
+	if failures := atomic.SwapInt32(&ss.connValidationFailures, 0); failures >= CONN_VALIDATION_MAX_FAILURES {
+		mlog.Info("The database connections are valid again", mlog.Int("failures", int(failures)))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestHealthCheck(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		t.Run("succeeds on a reachable database", func(t *testing.T) {
+			assert.NoError(t, ss.HealthCheck(context.Background()))
+		})
+
+		t.Run("respects the context cancellation", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := ss.HealthCheck(ctx)
+			assert.True(t, errors.Is(err, context.Canceled), err)
+		})
+	})
+}
+
+func TestConnValidation(t *testing.T) {
+	newSupplier := func(t *testing.T) *SqlSupplier {
+		settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+		t.Cleanup(func() { storetest.CleanupSqlSettings(settings) })
+
+		supplier, err := NewSqlSupplier(*settings, nil)
+		require.NoError(t, err)
+		t.Cleanup(supplier.Close)
+		return supplier
+	}
+
+	t.Run("runs the configured validation query", func(t *testing.T) {
+		supplier := newSupplier(t)
+		_, err := supplier.GetMaster().Exec("CREATE TABLE ConnValidations (Id INTEGER)")
+		require.NoError(t, err)
+		supplier.settings.ConnValidationQuery = model.NewString("INSERT INTO ConnValidations (Id) VALUES (1)")
+
+		require.NoError(t, supplier.HealthCheck(context.Background()))
+		supplier.runConnValidation()
+
+		count, err := supplier.GetMaster().SelectInt("SELECT COUNT(*) FROM ConnValidations")
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("reports the supplier unhealthy after repeated failures", func(t *testing.T) {
+		supplier := newSupplier(t)
+		supplier.settings.ConnValidationQuery = model.NewString("SELECT * FROM NoSuchTable")
+
+		for i := 1; i < CONN_VALIDATION_MAX_FAILURES; i++ {
+			supplier.runConnValidation()
+		}
+		err := supplier.HealthCheck(context.Background())
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrUnhealthy), err)
+
+		supplier.runConnValidation()
+		supplier.settings.ConnValidationQuery = model.NewString(model.SQL_SETTINGS_DEFAULT_CONN_VALIDATION_QUERY)
+		err = supplier.HealthCheck(context.Background())
+		assert.True(t, errors.Is(err, ErrUnhealthy), err)
+
+		supplier.runConnValidation()
+		assert.NoError(t, supplier.HealthCheck(context.Background()))
+	})
+}
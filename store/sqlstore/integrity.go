@@ -4,12 +4,18 @@
 package sqlstore
 
 import (
+	"time"
+
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 
 	sq "github.com/Masterminds/squirrel"
 )
 
+// integrityCheckQueryTimeout is the timeout of the queries of the integrity checks, which scan
+// whole tables and may take much longer than the regular queries.
+const integrityCheckQueryTimeout = 30 * time.Minute
+
 type relationalCheckConfig struct {
 	parentName         string
 	parentIdAttr       string
@@ -54,7 +60,7 @@ func getOrphanedRecords(ss *SqlSupplier, cfg relationalCheckConfig) ([]model.Orp
 
 	query, args, _ := main.ToSql()
 
-	_, err := ss.GetMaster().Select(&records, query, args...)
+	_, err := ss.GetMasterWithTimeout(integrityCheckQueryTimeout).Select(&records, query, args...)
 
 	return records, err
 }
@@ -40,7 +40,7 @@ func (jss SqlJobStore) createIndexesIfNotExists() {
 
 func (jss SqlJobStore) Save(job *model.Job) (*model.Job, error) {
 	if err := jss.GetMaster().Insert(job); err != nil {
-		return nil, errors.Wrap(err, "failed to save Job")
+		return nil, wrapSqlError(err, "Job", job.Id, "failed to save Job")
 	}
 	return job, nil
 }
@@ -48,7 +48,7 @@ func (jss SqlJobStore) Save(job *model.Job) (*model.Job, error) {
 func (jss SqlJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
 	query, args, err := jss.getQueryBuilder().
 		Update("Jobs").
-		Set("LastActivityAt", model.GetMillis()).
+		Set("LastActivityAt", jss.getMillis()).
 		Set("Status", job.Status).
 		Set("Data", job.DataToJson()).
 		Set("Progress", job.Progress).
@@ -78,7 +78,7 @@ func (jss SqlJobStore) UpdateStatus(id string, status string) (*model.Job, error
 	job := &model.Job{
 		Id:             id,
 		Status:         status,
-		LastActivityAt: model.GetMillis(),
+		LastActivityAt: jss.getMillis(),
 	}
 
 	if _, err := jss.GetMaster().UpdateColumns(func(col *gorp.ColumnMap) bool {
@@ -93,12 +93,12 @@ func (jss SqlJobStore) UpdateStatus(id string, status string) (*model.Job, error
 func (jss SqlJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
 	builder := jss.getQueryBuilder().
 		Update("Jobs").
-		Set("LastActivityAt", model.GetMillis()).
+		Set("LastActivityAt", jss.getMillis()).
 		Set("Status", newStatus).
 		Where(sq.Eq{"Id": id, "Status": currentStatus})
 
 	if newStatus == model.JOB_STATUS_IN_PROGRESS {
-		builder = builder.Set("StartAt", model.GetMillis())
+		builder = builder.Set("StartAt", jss.getMillis())
 	}
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -120,6 +120,83 @@ func (jss SqlJobStore) UpdateStatusOptimistically(id string, currentStatus strin
 	return true, nil
 }
 
+// claimCandidatesLimit is the number of pending jobs ClaimPending tries to claim before looking
+// for the pending jobs again, the others possibly having been claimed by other nodes meanwhile.
+const claimCandidatesLimit = 10
+
+// ClaimPending claims a job with an UPDATE conditioned on the job still being pending, which
+// the database applies atomically, so that only one of the concurrent claims of a job affects
+// a row. The losers move on to the next pending job. A single UPDATE ... RETURNING can't be used
+// since MySQL doesn't support it and the data recording the node is encoded by the application.
+func (jss SqlJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	query, args, err := jss.getQueryBuilder().
+		Select("*").
+		From("Jobs").
+		Where(sq.Eq{"Type": jobType, "Status": model.JOB_STATUS_PENDING}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(claimCandidatesLimit).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "job_tosql")
+	}
+
+	for {
+		var candidates []*model.Job
+		// The master is read for the jobs claimed by other nodes not to be tried again.
+		if _, err = jss.GetMaster().Select(&candidates, query, args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to find pending Jobs with type=%s", jobType)
+		}
+		if len(candidates) == 0 {
+			return nil, store.NewErrNotFound("Job", fmt.Sprintf("<status, type>=<%s, %s>", model.JOB_STATUS_PENDING, jobType))
+		}
+
+		for _, job := range candidates {
+			claimed, err := jss.claim(job, nodeId)
+			if err != nil {
+				return nil, err
+			}
+			if claimed {
+				return job, nil
+			}
+		}
+	}
+}
+
+func (jss SqlJobStore) claim(job *model.Job, nodeId string) (bool, error) {
+	if job.Data == nil {
+		job.Data = make(map[string]string)
+	}
+	job.Data[model.JOB_DATA_NODE_ID] = nodeId
+	now := jss.getMillis()
+
+	query, args, err := jss.getQueryBuilder().
+		Update("Jobs").
+		Set("Status", model.JOB_STATUS_IN_PROGRESS).
+		Set("StartAt", now).
+		Set("LastActivityAt", now).
+		Set("Data", job.DataToJson()).
+		Where(sq.Eq{"Id": job.Id, "Status": model.JOB_STATUS_PENDING}).ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "job_tosql")
+	}
+
+	sqlResult, err := jss.GetMaster().Exec(query, args...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim Job with id=%s", job.Id)
+	}
+	rows, err := sqlResult.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+	if rows != 1 {
+		return false, nil
+	}
+
+	job.Status = model.JOB_STATUS_IN_PROGRESS
+	job.StartAt = now
+	job.LastActivityAt = now
+	return true, nil
+}
+
 func (jss SqlJobStore) Get(id string) (*model.Job, error) {
 	query, args, err := jss.getQueryBuilder().
 		Select("*").
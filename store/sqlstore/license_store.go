@@ -41,6 +41,7 @@ func (ls SqlLicenseStore) createIndexesIfNotExists() {
 // updated.
 func (ls SqlLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
 	license.PreSave()
+	license.CreateAt = ls.getMillis()
 	if err := license.IsValid(); err != nil {
 		return nil, err
 	}
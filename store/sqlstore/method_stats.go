@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MethodStats are the statistics of the calls to a store method since the supplier was created
+// or its statistics were last reset.
+type MethodStats struct {
+	Calls         int64
+	Errors        int64
+	TotalDuration time.Duration
+}
+
+// methodCounters are updated atomically, so that recording a call doesn't take a lock.
+type methodCounters struct {
+	calls    int64
+	errors   int64
+	duration int64
+}
+
+// methodStats holds the *methodCounters of the store methods by name. The sync.Map is lock free
+// once the counters of a method exist, which is the case of every call but the first.
+type methodStats struct {
+	counters sync.Map
+}
+
+func (s *methodStats) observe(method string, success bool, elapsed time.Duration) {
+	value, ok := s.counters.Load(method)
+	if !ok {
+		value, _ = s.counters.LoadOrStore(method, &methodCounters{})
+	}
+	counters := value.(*methodCounters)
+
+	atomic.AddInt64(&counters.calls, 1)
+	if !success {
+		atomic.AddInt64(&counters.errors, 1)
+	}
+	atomic.AddInt64(&counters.duration, int64(elapsed))
+}
+
+func (s *methodStats) snapshot() map[string]MethodStats {
+	stats := make(map[string]MethodStats)
+	s.counters.Range(func(key, value interface{}) bool {
+		counters := value.(*methodCounters)
+		current := MethodStats{
+			Calls:         atomic.LoadInt64(&counters.calls),
+			Errors:        atomic.LoadInt64(&counters.errors),
+			TotalDuration: time.Duration(atomic.LoadInt64(&counters.duration)),
+		}
+		if current.Calls > 0 {
+			stats[key.(string)] = current
+		}
+		return true
+	})
+	return stats
+}
+
+// reset zeroes the counters in place rather than dropping them, for the calls recorded
+// concurrently not to be lost.
+func (s *methodStats) reset() {
+	s.counters.Range(func(_, value interface{}) bool {
+		counters := value.(*methodCounters)
+		atomic.StoreInt64(&counters.calls, 0)
+		atomic.StoreInt64(&counters.errors, 0)
+		atomic.StoreInt64(&counters.duration, 0)
+		return true
+	})
+}
+
+// ObserveStoreMethod implements timerlayer.StatsRecorder, recording a call to a store method
+// named as in PostStore.GetPostsAfter that took elapsed seconds.
+func (ss *SqlSupplier) ObserveStoreMethod(method string, success bool, elapsed float64) {
+	ss.methodStats.observe(method, success, time.Duration(elapsed*float64(time.Second)))
+}
+
+// Stats returns the number of calls, failed calls and total duration of every store method
+// called since the supplier was created or ResetStats was last called. Only the calls made
+// through the timer layer the supplier is the StatsRecorder of are counted.
+func (ss *SqlSupplier) Stats() map[string]MethodStats {
+	return ss.methodStats.snapshot()
+}
+
+// ResetStats discards the statistics of the store methods recorded so far.
+func (ss *SqlSupplier) ResetStats() {
+	ss.methodStats.reset()
+}
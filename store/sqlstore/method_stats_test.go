@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlSupplierStats(t *testing.T) {
+	t.Run("counts the calls, errors and duration by method", func(t *testing.T) {
+		ss := &SqlSupplier{}
+		ss.ObserveStoreMethod("PostStore.Get", true, 0.5)
+		ss.ObserveStoreMethod("PostStore.Get", false, 0.25)
+		ss.ObserveStoreMethod("UserStore.Get", true, 1)
+
+		assert.Equal(t, map[string]MethodStats{
+			"PostStore.Get": {Calls: 2, Errors: 1, TotalDuration: 750 * time.Millisecond},
+			"UserStore.Get": {Calls: 1, TotalDuration: time.Second},
+		}, ss.Stats())
+	})
+
+	t.Run("resets the statistics", func(t *testing.T) {
+		ss := &SqlSupplier{}
+		ss.ObserveStoreMethod("PostStore.Get", false, 0.5)
+		ss.ResetStats()
+		assert.Empty(t, ss.Stats())
+
+		ss.ObserveStoreMethod("PostStore.Get", true, 0.5)
+		assert.Equal(t, map[string]MethodStats{
+			"PostStore.Get": {Calls: 1, TotalDuration: 500 * time.Millisecond},
+		}, ss.Stats())
+	})
+
+	t.Run("counts the concurrent calls", func(t *testing.T) {
+		ss := &SqlSupplier{}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					ss.ObserveStoreMethod("PostStore.Get", j%2 == 0, 0.001)
+				}
+			}()
+		}
+		wg.Wait()
+
+		stats := ss.Stats()["PostStore.Get"]
+		assert.Equal(t, int64(1000), stats.Calls)
+		assert.Equal(t, int64(500), stats.Errors)
+		assert.Equal(t, time.Second, stats.TotalDuration)
+	})
+}
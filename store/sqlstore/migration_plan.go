@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// MigrationStep describes one of the schema upgrades run by upgradeDatabase.
+type MigrationStep struct {
+	FromVersion string
+	Version     string
+	Description string
+	// Destructive is set for the steps dropping tables or columns, deleting rows or overwriting
+	// values, which can't be undone by restoring the previous server version.
+	Destructive bool
+}
+
+// migrationSteps lists the upgrades in the order upgradeDatabase runs them, and must be kept in
+// sync with the upgradeDatabaseToVersion functions.
+var migrationSteps = []MigrationStep{
+	{VERSION_3_0_0, VERSION_3_1_0, "Add OutgoingWebhooks.ContentType", false},
+	{VERSION_3_1_0, VERSION_3_2_0, "Add TeamMembers.DeleteAt", false},
+	{VERSION_3_2_0, VERSION_3_3_0, "Move Users.ThemeProps to Preferences, add the OAuth columns, drop OAuthAccessData.AuthCode, Users.LastActivityAt and Users.LastPingAt", true},
+	{VERSION_3_3_0, VERSION_3_4_0, "Add Status.Manual and Status.ActiveChannel", false},
+	{VERSION_3_4_0, VERSION_3_5_0, "Rewrite the user, team and channel roles, add Posts.FileIds and resize Channels.Purpose", false},
+	{VERSION_3_5_0, VERSION_3_6_0, "Add Posts.HasReactions, Teams.Description and Users.Position, drop Status.ActiveChannel", true},
+	{VERSION_3_6_0, VERSION_3_7_0, "Add Posts.EditAt", false},
+	{VERSION_3_7_0, VERSION_3_8_0, "Add Posts.IsPinned", false},
+	{VERSION_3_8_0, VERSION_3_9_0, "Add OAuthAccessData.Scope, drop the PasswordRecovery table", true},
+	{VERSION_3_9_0, VERSION_3_10_0, "Record the schema version", false},
+	{VERSION_3_10_0, VERSION_4_0_0, "Record the schema version", false},
+	{VERSION_4_0_0, VERSION_4_1_0, "Resize Users.Roles, drop the JobStatuses table", true},
+	{VERSION_4_1_0, VERSION_4_2_0, "Record the schema version", false},
+	{VERSION_4_2_0, VERSION_4_3_0, "Record the schema version", false},
+	{VERSION_4_3_0, VERSION_4_4_0, "Add UserAccessTokens.IsActive", false},
+	{VERSION_4_4_0, VERSION_4_5_0, "Record the schema version", false},
+	{VERSION_4_5_0, VERSION_4_6_0, "Add IncomingWebhooks.Username and IncomingWebhooks.IconURL", false},
+	{VERSION_4_6_0, VERSION_4_7_0, "Resize Users.Position and OAuthAuthData.State, drop ChannelMemberHistory.Email and ChannelMemberHistory.Username", true},
+	{VERSION_4_7_0, VERSION_4_7_1, "Drop ChannelMemberHistory.Email", true},
+	{VERSION_4_7_1, VERSION_4_7_2, "Drop the idx_channels_displayname index", false},
+	{VERSION_4_7_2, VERSION_4_8_0, "Record the schema version", false},
+	{VERSION_4_8_0, VERSION_4_8_1, "Drop the idx_channels_displayname index", false},
+	{VERSION_4_8_1, VERSION_4_9_0, "Add Teams.LastTeamIconUpdate and Users.Timezone", false},
+	{VERSION_4_9_0, VERSION_4_10_0, "Drop the duplicate MySQL indexes, lower case the SAML AuthData", false},
+	{VERSION_4_10_0, VERSION_5_0_0, "Add the scheme and BuiltIn role columns and IncomingWebhooks.ChannelLocked, reset the SchemeManaged roles", true},
+	{VERSION_5_0_0, VERSION_5_1_0, "Record the schema version", false},
+	{VERSION_5_1_0, VERSION_5_2_0, "Add OutgoingWebhooks.Username and OutgoingWebhooks.IconURL", false},
+	{VERSION_5_2_0, VERSION_5_3_0, "Record the schema version", false},
+	{VERSION_5_3_0, VERSION_5_4_0, "Resize the webhook descriptions, fill the PublicChannels table", false},
+	{VERSION_5_4_0, VERSION_5_5_0, "Record the schema version", false},
+	{VERSION_5_5_0, VERSION_5_6_0, "Add PluginKeyValueStore.ExpireAt, move the accepted terms of service to UserTermsOfService, drop the lower case user indexes", false},
+	{VERSION_5_6_0, VERSION_5_7_0, "Record the schema version", false},
+	{VERSION_5_7_0, VERSION_5_8_0, "Change the types and defaults of the webhook columns and PluginKeyValueStore.ExpireAt", false},
+	{VERSION_5_8_0, VERSION_5_9_0, "Record the schema version", false},
+	{VERSION_5_9_0, VERSION_5_10_0, "Add Channels.GroupConstrained and Teams.GroupConstrained with the group sync indexes", false},
+	{VERSION_5_10_0, VERSION_5_11_0, "Set an InviteId on the teams without one", false},
+	{VERSION_5_11_0, VERSION_5_12_0, "Add the guest scheme columns, delete the personal access token sessions", true},
+	{VERSION_5_12_0, VERSION_5_13_0, "Delete the plugins jobs", true},
+	{VERSION_5_13_0, VERSION_5_14_0, "Record the schema version", false},
+	{VERSION_5_14_0, VERSION_5_15_0, "Record the schema version", false},
+	{VERSION_5_15_0, VERSION_5_16_0, "Resize Tokens.Extra, change the types of the guest and group columns", false},
+	{VERSION_5_16_0, VERSION_5_17_0, "Record the schema version", false},
+	{VERSION_5_17_0, VERSION_5_18_0, "Record the schema version", false},
+	{VERSION_5_18_0, VERSION_5_19_0, "Record the schema version", false},
+	{VERSION_5_19_0, VERSION_5_20_0, "Add Bots.LastIconUpdate and the SchemeAdmin columns of GroupTeams and GroupChannels", false},
+	{VERSION_5_20_0, VERSION_5_21_0, "Record the schema version", false},
+	{VERSION_5_21_0, VERSION_5_22_0, "Add the scheme indexes", false},
+	{VERSION_5_22_0, VERSION_5_23_0, "Record the schema version", false},
+	{VERSION_5_23_0, VERSION_5_24_0, "Add UserGroups.AllowReference, clear UserGroups.Name, change the primary key of Reactions", true},
+	{VERSION_5_24_0, VERSION_5_25_0, "Record the schema version", false},
+	{VERSION_5_25_0, VERSION_5_26_0, "Add Sessions.ExpiredNotify", false},
+	{VERSION_5_26_0, VERSION_5_27_0, "Record the schema version", false},
+	{VERSION_5_27_0, VERSION_5_28_0, "Add Commands.PluginId, resize the Teams and IncomingWebhooks columns", false},
+	{VERSION_5_28_0, VERSION_5_28_1, "Add FileInfo.MiniPreview", false},
+	{VERSION_5_28_1, VERSION_5_29_0, "Resize the sidebar category ids, add Threads.ChannelId", false},
+}
+
+// MigrationPlan returns the schema upgrades NewSqlSupplier would run on the database, in order,
+// without running them. The plan is empty for a fresh database, whose schema is created at the
+// current version, and for a database already at the current version or at a newer one of the
+// same major version. The column additions done on every start regardless of the schema
+// version aren't part of the plan.
+func (ss *SqlSupplier) MigrationPlan() ([]MigrationStep, error) {
+	return planMigrations(ss.GetCurrentSchemaVersion(), model.CurrentVersion)
+}
+
+// PlanMigrations connects to the master database of the settings and returns its MigrationPlan,
+// without upgrading the schema as NewSqlSupplier does.
+func PlanMigrations(settings model.SqlSettings) ([]MigrationStep, error) {
+	supplier := &SqlSupplier{settings: &settings}
+	supplier.queryObserver = newQueryObserver(&settings, nil)
+
+	master, err := setupConnection("master", *settings.DataSource, &settings)
+	if err != nil {
+		return nil, maskDataSourceError(err)
+	}
+	defer master.Db.Close()
+	supplier.master = master
+
+	return supplier.MigrationPlan()
+}
+
+func planMigrations(currentSchemaVersionString, currentModelVersionString string) ([]MigrationStep, error) {
+	currentModelVersion, err := semver.Parse(currentModelVersionString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse current model version %s", currentModelVersionString)
+	}
+
+	if currentSchemaVersionString == "" {
+		return []MigrationStep{}, nil
+	}
+
+	currentSchemaVersion, err := semver.New(currentSchemaVersionString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse database schema version %s", currentSchemaVersionString)
+	}
+
+	if err := checkSchemaVersion(*currentSchemaVersion, currentModelVersion); err != nil {
+		return nil, err
+	}
+
+	plan := []MigrationStep{}
+	version := currentSchemaVersionString
+	for _, step := range migrationSteps {
+		if step.FromVersion == version {
+			plan = append(plan, step)
+			version = step.Version
+		}
+	}
+
+	return plan, nil
+}
+
+// checkSchemaVersion returns an error if the schema can't be upgraded by this server, either
+// because it's older than the oldest supported version or because it's from a newer major
+// version than the server's.
+func checkSchemaVersion(currentSchemaVersion, currentModelVersion semver.Version) error {
+	nextUnsupportedMajorVersion := semver.Version{
+		Major: currentModelVersion.Major + 1,
+	}
+
+	oldestSupportedVersion, err := semver.Parse(OLDEST_SUPPORTED_VERSION)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse oldest supported version %s", OLDEST_SUPPORTED_VERSION)
+	}
+
+	// Upgrades prior to the oldest supported version are not supported.
+	if currentSchemaVersion.LT(oldestSupportedVersion) {
+		return errors.Errorf("Database schema version %s is no longer supported. This Mattermost server supports automatic upgrades from schema version %s through schema version %s. Please manually upgrade to at least version %s before continuing.", currentSchemaVersion, oldestSupportedVersion, currentModelVersion, oldestSupportedVersion)
+	}
+
+	// Allow forwards compatibility only within the same major version.
+	if currentSchemaVersion.GTE(nextUnsupportedMajorVersion) {
+		return errors.Errorf("Database schema version %s is not supported. This Mattermost server supports only >=%s, <%s. Please upgrade to at least version %s before continuing.", currentSchemaVersion, currentModelVersion, nextUnsupportedMajorVersion, nextUnsupportedMajorVersion)
+	} else if currentSchemaVersion.GT(currentModelVersion) {
+		mlog.Warn("The database schema version and model versions do not match", mlog.String("schema_version", currentSchemaVersion.String()), mlog.String("model_version", currentModelVersion.String()))
+	}
+
+	return nil
+}
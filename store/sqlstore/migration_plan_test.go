@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestMigrationSteps(t *testing.T) {
+	require.NotEmpty(t, migrationSteps)
+	assert.Equal(t, OLDEST_SUPPORTED_VERSION, migrationSteps[0].FromVersion)
+	assert.Equal(t, CURRENT_SCHEMA_VERSION, migrationSteps[len(migrationSteps)-1].Version)
+	for i := 1; i < len(migrationSteps); i++ {
+		assert.Equal(t, migrationSteps[i-1].Version, migrationSteps[i].FromVersion)
+	}
+}
+
+func TestMigrationPlan(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		supplier := ss.(*SqlSupplier)
+		defer saveSchemaVersion(supplier, CURRENT_SCHEMA_VERSION)
+
+		t.Run("up to date", func(t *testing.T) {
+			plan, err := supplier.MigrationPlan()
+			require.NoError(t, err)
+			assert.Empty(t, plan)
+		})
+
+		t.Run("pending upgrades", func(t *testing.T) {
+			saveSchemaVersion(supplier, VERSION_5_27_0)
+			plan, err := supplier.MigrationPlan()
+			require.NoError(t, err)
+			assert.Equal(t, []MigrationStep{
+				{VERSION_5_27_0, VERSION_5_28_0, "Add Commands.PluginId, resize the Teams and IncomingWebhooks columns", false},
+				{VERSION_5_28_0, VERSION_5_28_1, "Add FileInfo.MiniPreview", false},
+				{VERSION_5_28_1, VERSION_5_29_0, "Resize the sidebar category ids, add Threads.ChannelId", false},
+			}, plan)
+			assert.Equal(t, VERSION_5_27_0, supplier.GetCurrentSchemaVersion())
+		})
+
+		t.Run("destructive upgrades", func(t *testing.T) {
+			saveSchemaVersion(supplier, VERSION_5_11_0)
+			plan, err := supplier.MigrationPlan()
+			require.NoError(t, err)
+			require.NotEmpty(t, plan)
+			assert.True(t, plan[0].Destructive)
+			assert.True(t, plan[1].Destructive)
+			assert.False(t, plan[2].Destructive)
+		})
+	})
+}
+
+func TestPlanMigrations(t *testing.T) {
+	t.Run("fresh database", func(t *testing.T) {
+		plan, err := planMigrations("", "5.8.0")
+		require.NoError(t, err)
+		assert.Empty(t, plan)
+	})
+
+	t.Run("invalid schema version", func(t *testing.T) {
+		_, err := planMigrations("invalid", "5.8.0")
+		require.EqualError(t, err, "failed to parse database schema version invalid: No Major.Minor.Patch elements found")
+	})
+
+	t.Run("unsupported schema version", func(t *testing.T) {
+		_, err := planMigrations("2.0.0", "5.8.0")
+		require.EqualError(t, err, "Database schema version 2.0.0 is no longer supported. This Mattermost server supports automatic upgrades from schema version 3.0.0 through schema version 5.8.0. Please manually upgrade to at least version 3.0.0 before continuing.")
+	})
+
+	t.Run("next major version", func(t *testing.T) {
+		_, err := planMigrations("6.0.0", "5.8.0")
+		require.Error(t, err)
+	})
+
+	t.Run("oldest supported version", func(t *testing.T) {
+		plan, err := planMigrations(OLDEST_SUPPORTED_VERSION, "5.8.0")
+		require.NoError(t, err)
+		assert.Equal(t, migrationSteps, plan)
+	})
+
+	t.Run("later minor version", func(t *testing.T) {
+		plan, err := planMigrations("5.99.0", "5.8.0")
+		require.NoError(t, err)
+		assert.Empty(t, plan)
+	})
+}
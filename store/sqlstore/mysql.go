@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+
+	"github.com/mattermost/gorp"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// mysqlDialect is the MySQL dialect creating the tables with an explicit collation on top of
+// the character set, so that they don't inherit the collation defaulted by the server, which
+// decides how case and accents compare.
+type mysqlDialect struct {
+	gorp.MySQLDialect
+	Collation string
+}
+
+func newMySQLDialect(settings *model.SqlSettings) mysqlDialect {
+	charset := model.SQL_SETTINGS_DEFAULT_MYSQL_CHARSET
+	if settings.MySQLCharset != nil {
+		charset = *settings.MySQLCharset
+	}
+
+	return mysqlDialect{
+		MySQLDialect: gorp.MySQLDialect{Engine: "InnoDB", Encoding: charset},
+		Collation:    mysqlCollation(charset),
+	}
+}
+
+func (d mysqlDialect) CreateTableSuffix() string {
+	return fmt.Sprintf("%s collate=%s", d.MySQLDialect.CreateTableSuffix(), d.Collation)
+}
+
+// mysqlCollation returns the case insensitive collation of a MySQL character set, such as
+// utf8mb4_general_ci for utf8mb4.
+func mysqlCollation(charset string) string {
+	return charset + "_general_ci"
+}
+
+// mysqlConvertTableStatement returns the statement converting a table and all of its text
+// columns to a character set and its collation.
+func mysqlConvertTableStatement(table, charset string) string {
+	return fmt.Sprintf("ALTER TABLE %s CONVERT TO CHARACTER SET %s COLLATE %s", table, charset, mysqlCollation(charset))
+}
+
+// checkMySQLCollations logs a warning for every table whose collation, or the collation of one
+// of its columns, isn't the one of SqlSettings.MySQLCharset. Such tables were usually created
+// with the utf8 character set, which truncates the text at the first 4 byte character such as
+// an emoji, and are converted with the statement logged alongside the warning. Converting a
+// table rewrites it and locks it meanwhile, so it is left to the administrators.
+func (ss *SqlSupplier) checkMySQLCollations() {
+	dialect := newMySQLDialect(ss.settings)
+
+	var tables []string
+	if _, err := ss.GetMaster().Select(&tables,
+		`SELECT
+			TABLE_NAME
+		FROM
+			information_schema.TABLES
+		WHERE
+			TABLE_SCHEMA = DATABASE()
+			AND TABLE_TYPE = 'BASE TABLE'
+			AND TABLE_COLLATION != ?
+		UNION SELECT
+			TABLE_NAME
+		FROM
+			information_schema.COLUMNS
+		WHERE
+			TABLE_SCHEMA = DATABASE()
+			AND COLLATION_NAME IS NOT NULL
+			AND COLLATION_NAME != ?
+		ORDER BY TABLE_NAME`, dialect.Collation, dialect.Collation); err != nil {
+		mlog.Warn("Failed to check the collation of the database tables", mlog.Err(err))
+		return
+	}
+
+	for _, table := range tables {
+		mlog.Warn("Table has an unexpected collation, which can make the string comparisons case sensitive and truncate the emojis. Convert it during a maintenance window.",
+			mlog.String("table", table),
+			mlog.String("expected_collation", dialect.Collation),
+			mlog.String("statement", mysqlConvertTableStatement(table, dialect.Encoding)),
+		)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestMySQLDialect(t *testing.T) {
+	t.Run("defaults to utf8mb4", func(t *testing.T) {
+		dialect := newMySQLDialect(&model.SqlSettings{})
+
+		assert.Equal(t, " engine=InnoDB charset=utf8mb4 collate=utf8mb4_general_ci", dialect.CreateTableSuffix())
+	})
+
+	t.Run("uses the configured character set", func(t *testing.T) {
+		dialect := newMySQLDialect(&model.SqlSettings{MySQLCharset: model.NewString("utf8")})
+
+		assert.Equal(t, " engine=InnoDB charset=utf8 collate=utf8_general_ci", dialect.CreateTableSuffix())
+	})
+}
+
+func TestMySQLConvertTableStatement(t *testing.T) {
+	assert.Equal(t, "ALTER TABLE Posts CONVERT TO CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci", mysqlConvertTableStatement("Posts", "utf8mb4"))
+}
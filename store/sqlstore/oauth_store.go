@@ -67,18 +67,21 @@ func (as SqlOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	}
 
 	app.PreSave()
+	app.CreateAt = as.getMillis()
+	app.UpdateAt = app.CreateAt
 	if err := app.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := as.GetMaster().Insert(app); err != nil {
-		return nil, errors.Wrap(err, "failed to save OAuthApp")
+		return nil, wrapSqlError(err, "OAuthApp", app.Id, "failed to save OAuthApp")
 	}
 	return app, nil
 }
 
 func (as SqlOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
 	app.PreUpdate()
+	app.UpdateAt = as.getMillis()
 
 	if err := app.IsValid(); err != nil {
 		return nil, err
@@ -174,7 +177,7 @@ func (as SqlOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.Acc
 	}
 
 	if err := as.GetMaster().Insert(accessData); err != nil {
-		return nil, errors.Wrap(err, "failed to save AccessData")
+		return nil, wrapSqlError(err, "AccessData", accessData.Token, "failed to save AccessData")
 	}
 	return accessData, nil
 }
@@ -183,7 +186,7 @@ func (as SqlOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
 	accessData := model.AccessData{}
 
 	if err := as.GetReplica().SelectOne(&accessData, "SELECT * FROM OAuthAccessData WHERE Token = :Token", map[string]interface{}{"Token": token}); err != nil {
-		return nil, errors.Wrapf(err, "failed to get OAuthAccessData with token=%s", token)
+		return nil, wrapSqlError(err, "AccessData", token, fmt.Sprintf("failed to get OAuthAccessData with token=%s", token))
 	}
 	return &accessData, nil
 }
@@ -203,7 +206,7 @@ func (as SqlOAuthStore) GetAccessDataByRefreshToken(token string) (*model.Access
 	accessData := model.AccessData{}
 
 	if err := as.GetReplica().SelectOne(&accessData, "SELECT * FROM OAuthAccessData WHERE RefreshToken = :Token", map[string]interface{}{"Token": token}); err != nil {
-		return nil, errors.Wrapf(err, "failed to find OAuthAccessData with refreshToken=%s", token)
+		return nil, wrapSqlError(err, "AccessData", token, fmt.Sprintf("failed to find OAuthAccessData with refreshToken=%s", token))
 	}
 	return &accessData, nil
 }
@@ -249,13 +252,16 @@ func (as SqlOAuthStore) RemoveAllAccessData() error {
 }
 
 func (as SqlOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	if authData.CreateAt == 0 {
+		authData.CreateAt = as.getMillis()
+	}
 	authData.PreSave()
 	if err := authData.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := as.GetMaster().Insert(authData); err != nil {
-		return nil, errors.Wrap(err, "failed to save AuthData")
+		return nil, wrapSqlError(err, "AuthData", authData.Code, "failed to save AuthData")
 	}
 	return authData, nil
 }
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// orphanParent is a table the records reference through the given column.
+type orphanParent struct {
+	table  string
+	column string
+}
+
+// orphanClass describes the records of a table orphaned when any of their parents is missing.
+// The records are identified by the key columns.
+type orphanClass struct {
+	table   string
+	keys    []string
+	parents []orphanParent
+	filter  sq.Sqlizer
+}
+
+var orphanClasses = map[string]orphanClass{
+	model.ORPHAN_TYPE_CHANNEL_MEMBER: {
+		table:   "ChannelMembers",
+		keys:    []string{"ChannelId", "UserId"},
+		parents: []orphanParent{{"Channels", "ChannelId"}, {"Users", "UserId"}},
+	},
+	model.ORPHAN_TYPE_FILE_INFO: {
+		table:   "FileInfo",
+		keys:    []string{"Id"},
+		parents: []orphanParent{{"Posts", "PostId"}},
+		// The files uploaded but not posted yet are attached to no post.
+		filter: sq.NotEq{"FileInfo.PostId": ""},
+	},
+	model.ORPHAN_TYPE_REACTION: {
+		table:   "Reactions",
+		keys:    []string{"PostId", "UserId", "EmojiName"},
+		parents: []orphanParent{{"Posts", "PostId"}, {"Users", "UserId"}},
+	},
+	model.ORPHAN_TYPE_PREFERENCE: {
+		table:   "Preferences",
+		keys:    []string{"UserId", "Category", "Name"},
+		parents: []orphanParent{{"Users", "UserId"}},
+	},
+}
+
+func getOrphanClass(entityType string) (orphanClass, error) {
+	class, ok := orphanClasses[entityType]
+	if !ok {
+		return orphanClass{}, store.NewErrInvalidInput("Orphans", "entityType", entityType)
+	}
+	return class, nil
+}
+
+func (ss *SqlSupplier) orphansQuery(class orphanClass, limit int) sq.SelectBuilder {
+	missing := sq.Or{}
+	for _, parent := range class.parents {
+		missing = append(missing, sq.Expr("NOT EXISTS (SELECT 1 FROM "+parent.table+" WHERE "+parent.table+".Id = "+class.table+"."+parent.column+")"))
+	}
+
+	query := ss.getQueryBuilder().
+		Select(class.keys...).
+		From(class.table).
+		Where(missing).
+		OrderBy(class.keys...).
+		Limit(uint64(limit))
+	if class.filter != nil {
+		query = query.Where(class.filter)
+	}
+	return query
+}
+
+// selectOrphanKeys returns the values of the key columns of the orphaned records.
+func selectOrphanKeys(executor gorp.SqlExecutor, class orphanClass, query sq.SelectBuilder) ([][]string, error) {
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "orphans_tosql")
+	}
+
+	rows, err := executor.Query(queryString, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the orphaned %s", class.table)
+	}
+	defer rows.Close()
+
+	var keys [][]string
+	for rows.Next() {
+		key := make([]string, len(class.keys))
+		dest := make([]interface{}, len(key))
+		for i := range key {
+			dest[i] = &key[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan the orphaned %s", class.table)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to find the orphaned %s", class.table)
+	}
+	return keys, nil
+}
+
+// FindOrphans returns the ids of up to limit records of the given model.OrphanTypes class
+// referencing a record that doesn't exist anymore. The records identified by several columns,
+// such as the channel members, have their ids joined with colons.
+func (ss *SqlSupplier) FindOrphans(entityType string, limit int) ([]string, error) {
+	class, err := getOrphanClass(entityType)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		return nil, store.NewErrInvalidInput("Orphans", "limit", limit)
+	}
+
+	keys, err := selectOrphanKeys(ss.GetMasterWithTimeout(integrityCheckQueryTimeout), class, ss.orphansQuery(class, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.Join(key, ":"))
+	}
+	return ids, nil
+}
+
+// DeleteOrphans deletes the orphaned records of the given model.OrphanTypes class, up to
+// batchSize of them per transaction, and returns how many were deleted. The records are found
+// again within each transaction, for the records referenced again in the meantime to be kept.
+func (ss *SqlSupplier) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	class, err := getOrphanClass(entityType)
+	if err != nil {
+		return 0, err
+	}
+	if batchSize < 1 {
+		return 0, store.NewErrInvalidInput("Orphans", "batchSize", batchSize)
+	}
+
+	var deleted int64
+	for {
+		var found int
+		var count int64
+		err := ss.WithRetryableTransaction(func(transaction *gorp.Transaction) error {
+			keys, err := selectOrphanKeys(transaction, class, ss.orphansQuery(class, batchSize))
+			if err != nil {
+				return err
+			}
+			found, count = len(keys), 0
+			if found == 0 {
+				return nil
+			}
+
+			count, err = ss.deleteOrphanKeys(transaction, class, keys)
+			return err
+		})
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += count
+		if found < batchSize || count == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+func (ss *SqlSupplier) deleteOrphanKeys(transaction *gorp.Transaction, class orphanClass, keys [][]string) (int64, error) {
+	where := sq.Or{}
+	for _, key := range keys {
+		match := sq.Eq{}
+		for i, column := range class.keys {
+			match[column] = key[i]
+		}
+		where = append(where, match)
+	}
+
+	query, args, err := ss.getQueryBuilder().Delete(class.table).Where(where).ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "delete_orphans_tosql")
+	}
+
+	result, err := transaction.Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete the orphaned %s", class.table)
+	}
+	return result.RowsAffected()
+}
@@ -102,7 +102,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			Where(sq.Eq{"PluginId": kv.PluginId}).
 			Where(sq.Eq{"PKey": kv.Key}).
 			Where(sq.NotEq{"ExpireAt": int(0)}).
-			Where(sq.Lt{"ExpireAt": model.GetMillis()})
+			Where(sq.Lt{"ExpireAt": ps.getMillis()})
 
 		queryString, args, err := query.ToSql()
 		if err != nil {
@@ -125,7 +125,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			}
 		}
 	} else {
-		currentTime := model.GetMillis()
+		currentTime := ps.getMillis()
 
 		// Update if oldValue is not nil
 		query := ps.getQueryBuilder().
@@ -216,7 +216,7 @@ func (ps SqlPluginStore) CompareAndDelete(kv *model.PluginKeyValue, oldValue []b
 		Where(sq.Eq{"PValue": oldValue}).
 		Where(sq.Or{
 			sq.Eq{"ExpireAt": int(0)},
-			sq.Gt{"ExpireAt": model.GetMillis()},
+			sq.Gt{"ExpireAt": ps.getMillis()},
 		})
 
 	queryString, args, err := query.ToSql()
@@ -261,7 +261,7 @@ func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byt
 }
 
 func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, error) {
-	currentTime := model.GetMillis()
+	currentTime := ps.getMillis()
 	query := ps.getQueryBuilder().Select("PluginId, PKey, PValue, ExpireAt").
 		From("PluginKeyValueStore").
 		Where(sq.Eq{"PluginId": pluginId}).
@@ -318,7 +318,7 @@ func (ps SqlPluginStore) DeleteAllForPlugin(pluginId string) error {
 }
 
 func (ps SqlPluginStore) DeleteAllExpired() error {
-	currentTime := model.GetMillis()
+	currentTime := ps.getMillis()
 	query := ps.getQueryBuilder().
 		Delete("PluginKeyValueStore").
 		Where(sq.NotEq{"ExpireAt": 0}).
@@ -352,7 +352,7 @@ func (ps SqlPluginStore) List(pluginId string, offset int, limit int) ([]string,
 		Where(sq.Eq{"PluginId": pluginId}).
 		Where(sq.Or{
 			sq.Eq{"ExpireAt": int(0)},
-			sq.Gt{"ExpireAt": model.GetMillis()},
+			sq.Gt{"ExpireAt": ps.getMillis()},
 		}).
 		OrderBy("PKey").
 		Limit(uint64(limit)).
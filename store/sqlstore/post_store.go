@@ -4,12 +4,17 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
+	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/gorp"
@@ -25,16 +30,31 @@ import (
 
 type SqlPostStore struct {
 	SqlStore
-	metrics           einterfaces.MetricsInterface
-	maxPostSizeOnce   sync.Once
-	maxPostSizeCached int
+	metrics                einterfaces.MetricsInterface
+	maxPostSizeOnce        sync.Once
+	maxPostSizeCached      int
+	trigramSearchOnce      sync.Once
+	trigramSearchSupported bool
 }
 
+// fuzzySearchSimilarityThresholds maps the fuzziness of a search to the minimum trigram
+// similarity between a term and the words of a message for the message to match. The
+// thresholds roughly match the edit distances: one typo in "recieve" still leaves half of its
+// trigrams in "receive".
+var fuzzySearchSimilarityThresholds = map[int]float64{
+	1: 0.5,
+	2: 0.3,
+}
+
+// maxQueryParameters is the number of bind parameters a single statement may hold on
+// both Postgres and MySQL.
+const maxQueryParameters = 65535
+
 func (s *SqlPostStore) ClearCaches() {
 }
 
 func postSliceColumns() []string {
-	return []string{"Id", "CreateAt", "UpdateAt", "EditAt", "DeleteAt", "IsPinned", "UserId", "ChannelId", "RootId", "ParentId", "OriginalId", "Message", "Type", "Props", "Hashtags", "Filenames", "FileIds", "HasReactions"}
+	return []string{"Id", "CreateAt", "UpdateAt", "EditAt", "DeleteAt", "IsPinned", "PinnedAt", "UserId", "ChannelId", "RootId", "ParentId", "OriginalId", "Message", "Type", "Props", "Hashtags", "Filenames", "FileIds", "HasReactions"}
 }
 
 func postToSlice(post *model.Post) []interface{} {
@@ -45,6 +65,7 @@ func postToSlice(post *model.Post) []interface{} {
 		post.EditAt,
 		post.DeleteAt,
 		post.IsPinned,
+		post.PinnedAt,
 		post.UserId,
 		post.ChannelId,
 		post.RootId,
@@ -96,6 +117,7 @@ func (s *SqlPostStore) createIndexesIfNotExists() {
 	s.CreateIndexIfNotExists("idx_posts_is_pinned", "Posts", "IsPinned")
 
 	s.CreateCompositeIndexIfNotExists("idx_posts_channel_id_update_at", "Posts", []string{"ChannelId", "UpdateAt"})
+	s.CreateCompositeIndexIfNotExists("idx_posts_channel_id_pinned_at", "Posts", []string{"ChannelId", "PinnedAt"})
 	s.CreateCompositeIndexIfNotExists("idx_posts_channel_id_delete_at_create_at", "Posts", []string{"ChannelId", "DeleteAt", "CreateAt"})
 
 	s.CreateFullTextIndexIfNotExists("idx_posts_message_txt", "Posts", "Message")
@@ -111,6 +133,9 @@ func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, er
 		if len(post.Id) > 0 {
 			return nil, idx, store.NewErrInvalidInput("Post", "id", post.Id)
 		}
+		if post.CreateAt == 0 {
+			post.CreateAt = s.getMillis()
+		}
 		post.PreSave()
 		maxPostSize := s.GetMaxPostSize()
 		if err := post.IsValid(maxPostSize); err != nil {
@@ -150,15 +175,6 @@ func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, er
 		}
 	}
 
-	builder := s.getQueryBuilder().Insert("Posts").Columns(postSliceColumns()...)
-	for _, post := range posts {
-		builder = builder.Values(postToSlice(post)...)
-	}
-	query, args, err := builder.ToSql()
-	if err != nil {
-		return nil, -1, errors.Wrap(err, "post_tosql")
-	}
-
 	transaction, err := s.GetMaster().Begin()
 	if err != nil {
 		return posts, -1, errors.Wrap(err, "begin_transaction")
@@ -166,31 +182,60 @@ func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, er
 
 	defer finalizeTransaction(transaction)
 
-	if _, err = transaction.Exec(query, args...); err != nil {
-		return nil, -1, errors.Wrap(err, "failed to save Post")
+	batchSize := s.insertBatchSize()
+	for start := 0; start < len(posts); start += batchSize {
+		end := start + batchSize
+		if end > len(posts) {
+			end = len(posts)
+		}
+
+		builder := s.getQueryBuilder().Insert("Posts").Columns(postSliceColumns()...)
+		for _, post := range posts[start:end] {
+			builder = builder.Values(postToSlice(post)...)
+		}
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return nil, -1, errors.Wrap(err, "post_tosql")
+		}
+
+		if _, err = transaction.Exec(query, args...); err != nil {
+			return nil, -1, errors.Wrap(err, "failed to save Post")
+		}
 	}
 
 	if err = s.updateThreadsFromPosts(transaction, posts); err != nil {
 		mlog.Error("Error updating posts, thread update failed", mlog.Err(err))
 	}
 
-	if err = transaction.Commit(); err != nil {
-		// don't need to rollback here since the transaction is already closed
-		return posts, -1, errors.Wrap(err, "commit_transaction")
+	// The rows are updated in the order of their ids, for concurrent saves touching the same
+	// channels or threads to lock them in the same order rather than deadlock.
+	channelIds := make([]string, 0, len(channelNewPosts))
+	for channelId := range channelNewPosts {
+		channelIds = append(channelIds, channelId)
 	}
-
-	for channelId, count := range channelNewPosts {
-		if _, err = s.GetMaster().Exec("UPDATE Channels SET LastPostAt = GREATEST(:LastPostAt, LastPostAt), TotalMsgCount = TotalMsgCount + :Count WHERE Id = :ChannelId", map[string]interface{}{"LastPostAt": maxDateNewPosts[channelId], "ChannelId": channelId, "Count": count}); err != nil {
-			mlog.Error("Error updating Channel LastPostAt.", mlog.Err(err))
+	sort.Strings(channelIds)
+	for _, channelId := range channelIds {
+		if _, err = transaction.Exec("UPDATE Channels SET LastPostAt = GREATEST(:LastPostAt, LastPostAt), TotalMsgCount = TotalMsgCount + :Count WHERE Id = :ChannelId", map[string]interface{}{"LastPostAt": maxDateNewPosts[channelId], "ChannelId": channelId, "Count": channelNewPosts[channelId]}); err != nil {
+			return nil, -1, errors.Wrapf(err, "failed to update Channel with channelId=%s", channelId)
 		}
 	}
 
+	sortedRootIds := make([]string, 0, len(rootIds))
 	for rootId := range rootIds {
-		if _, err = s.GetMaster().Exec("UPDATE Posts SET UpdateAt = :UpdateAt WHERE Id = :RootId", map[string]interface{}{"UpdateAt": maxDateRootIds[rootId], "RootId": rootId}); err != nil {
-			mlog.Error("Error updating Post UpdateAt.", mlog.Err(err))
+		sortedRootIds = append(sortedRootIds, rootId)
+	}
+	sort.Strings(sortedRootIds)
+	for _, rootId := range sortedRootIds {
+		if _, err = transaction.Exec("UPDATE Posts SET UpdateAt = :UpdateAt WHERE Id = :RootId", map[string]interface{}{"UpdateAt": maxDateRootIds[rootId], "RootId": rootId}); err != nil {
+			return nil, -1, errors.Wrapf(err, "failed to update Post with rootId=%s", rootId)
 		}
 	}
 
+	if err = transaction.Commit(); err != nil {
+		// don't need to rollback here since the transaction is already closed
+		return posts, -1, errors.Wrap(err, "commit_transaction")
+	}
+
 	unknownRepliesPosts := []*model.Post{}
 	for _, post := range posts {
 		if len(post.RootId) == 0 {
@@ -212,6 +257,20 @@ func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, er
 	return posts, -1, nil
 }
 
+// insertBatchSize returns the number of posts inserted per statement, capped so that
+// a statement never exceeds the bind parameter limit of the database.
+func (s *SqlPostStore) insertBatchSize() int {
+	batchSize := model.SQL_SETTINGS_DEFAULT_INSERT_BATCH_SIZE
+	if settings := s.getSettings(); settings != nil && settings.InsertBatchSize != nil && *settings.InsertBatchSize > 0 {
+		batchSize = *settings.InsertBatchSize
+	}
+
+	if maxBatchSize := maxQueryParameters / len(postSliceColumns()); batchSize > maxBatchSize {
+		batchSize = maxBatchSize
+	}
+	return batchSize
+}
+
 func (s *SqlPostStore) Save(post *model.Post) (*model.Post, error) {
 	posts, _, err := s.SaveMultiple([]*model.Post{post})
 	if err != nil {
@@ -257,7 +316,8 @@ func (s *SqlPostStore) populateReplyCount(posts []*model.Post) error {
 }
 
 func (s *SqlPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
-	newPost.UpdateAt = model.GetMillis()
+	newPost.UpdateAt = s.getMillis()
+	newPost.SetPinnedAt(oldPost, newPost.UpdateAt)
 	newPost.PreCommit()
 
 	oldPost.DeleteAt = newPost.UpdateAt
@@ -276,7 +336,7 @@ func (s *SqlPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.
 		return nil, errors.Wrapf(err, "failed to update Post with id=%s", newPost.Id)
 	}
 
-	time := model.GetMillis()
+	time := s.getMillis()
 	s.GetMaster().Exec("UPDATE Channels SET LastPostAt = :LastPostAt  WHERE Id = :ChannelId AND LastPostAt < :LastPostAt", map[string]interface{}{"LastPostAt": time, "ChannelId": newPost.ChannelId})
 
 	if len(newPost.RootId) > 0 {
@@ -291,10 +351,11 @@ func (s *SqlPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.
 }
 
 func (s *SqlPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
-	updateAt := model.GetMillis()
+	updateAt := s.getMillis()
 	maxPostSize := s.GetMaxPostSize()
 	for idx, post := range posts {
 		post.UpdateAt = updateAt
+		post.SetPinnedAt(post, updateAt)
 		if appErr := post.IsValid(maxPostSize); appErr != nil {
 			return nil, idx, appErr
 		}
@@ -334,6 +395,33 @@ func (s *SqlPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 	return posts[0], nil
 }
 
+// GetPinnedPosts relies on PinnedAt being set on the pinned posts only, which the index on the
+// channel and PinnedAt serves in order.
+func (s *SqlPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+	pl := model.NewPostList()
+
+	var posts []*model.Post
+	if _, err := s.GetReplica().Select(&posts, `
+		SELECT
+			*, (SELECT count(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) as ReplyCount
+		FROM
+			Posts p
+		WHERE
+			ChannelId = :ChannelId
+			AND PinnedAt > 0
+			AND DeleteAt = 0
+		ORDER BY PinnedAt DESC, Id DESC`, map[string]interface{}{"ChannelId": channelId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find pinned Posts with channelId=%s", channelId)
+	}
+
+	for _, post := range posts {
+		pl.AddPost(post)
+		pl.AddOrder(post.Id)
+	}
+
+	return pl, nil
+}
+
 func (s *SqlPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
 	pl := model.NewPostList()
 
@@ -464,8 +552,26 @@ func (s *SqlPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, e
 }
 
 func (s *SqlPostStore) GetSingle(id string) (*model.Post, error) {
+	return s.GetSingleCtx(context.Background(), id)
+}
+
+// GetSingleCtx is GetSingle aborting the query once ctx is done.
+func (s *SqlPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	return s.getPost(ctx, id, false)
+}
+
+func (s *SqlPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+	return s.getPost(context.Background(), id, includeDeleted)
+}
+
+func (s *SqlPostStore) getPost(ctx context.Context, id string, includeDeleted bool) (*model.Post, error) {
+	query := "SELECT * FROM Posts WHERE Id = :Id"
+	if !includeDeleted {
+		query += " AND DeleteAt = 0"
+	}
+
 	var post model.Post
-	err := s.GetReplica().SelectOne(&post, "SELECT * FROM Posts WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": id})
+	err := selectOneContext(ctx, s.GetReplica(), &post, query, map[string]interface{}{"Id": id})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, store.NewErrNotFound("Post", id)
@@ -616,7 +722,107 @@ func (s *SqlPostStore) PermanentDeleteByChannel(channelId string) error {
 	return nil
 }
 
-func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, _ bool) (*model.PostList, error) {
+// MoveThread moves the root post and the replies of a thread to the target channel in a single
+// transaction, updating the last post time and the message count of both channels. The files
+// and the reactions reference the posts, so they follow them. Moving a thread to a channel of
+// another team is rejected unless allowCrossTeam is set. It returns the moved posts.
+func (s *SqlPostStore) MoveThread(rootId, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	var root model.Post
+	if err := transaction.SelectOne(&root, "SELECT * FROM Posts WHERE Id = :Id AND DeleteAt = 0", map[string]interface{}{"Id": rootId}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Post", rootId)
+		}
+		return nil, errors.Wrapf(err, "failed to get Post with id=%s", rootId)
+	}
+	if root.RootId != "" {
+		return nil, store.NewErrInvalidInput("Post", "RootId", root.RootId)
+	}
+	sourceChannelId := root.ChannelId
+	if sourceChannelId == targetChannelId {
+		return nil, store.NewErrInvalidInput("Post", "ChannelId", targetChannelId)
+	}
+
+	var channels []*model.Channel
+	if _, err := transaction.Select(&channels, "SELECT * FROM Channels WHERE Id IN (:SourceChannelId, :TargetChannelId)", map[string]interface{}{"SourceChannelId": sourceChannelId, "TargetChannelId": targetChannelId}); err != nil {
+		return nil, errors.Wrap(err, "failed to get Channels")
+	}
+	var source, target *model.Channel
+	for _, channel := range channels {
+		if channel.Id == sourceChannelId {
+			source = channel
+		} else {
+			target = channel
+		}
+	}
+	if target == nil {
+		return nil, store.NewErrNotFound("Channel", targetChannelId)
+	}
+	if target.DeleteAt != 0 {
+		return nil, store.NewErrInvalidInput("Channel", "DeleteAt", target.DeleteAt)
+	}
+	if source != nil && source.TeamId != target.TeamId && !allowCrossTeam {
+		return nil, store.NewErrInvalidInput("Channel", "TeamId", target.TeamId)
+	}
+
+	var posts []*model.Post
+	if _, err := transaction.Select(&posts, "SELECT * FROM Posts WHERE Id = :RootId OR RootId = :RootId ORDER BY CreateAt", map[string]interface{}{"RootId": rootId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find the Posts of the thread with rootId=%s", rootId)
+	}
+
+	// The message counts of the channels don't include the join and leave messages.
+	count := 0
+	lastPostAt := int64(0)
+	for _, post := range posts {
+		if !post.IsJoinLeaveMessage() {
+			count++
+		}
+		if post.DeleteAt == 0 && post.CreateAt > lastPostAt {
+			lastPostAt = post.CreateAt
+		}
+	}
+
+	updateAt := s.getMillis()
+	if _, err := transaction.Exec("UPDATE Posts SET ChannelId = :ChannelId, UpdateAt = :UpdateAt WHERE Id = :RootId OR RootId = :RootId", map[string]interface{}{"ChannelId": targetChannelId, "UpdateAt": updateAt, "RootId": rootId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to move the Posts of the thread with rootId=%s", rootId)
+	}
+	if _, err := transaction.Exec("UPDATE Threads SET ChannelId = :ChannelId WHERE PostId = :RootId", map[string]interface{}{"ChannelId": targetChannelId, "RootId": rootId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to move the Thread with rootId=%s", rootId)
+	}
+
+	if _, err := transaction.Exec(`UPDATE Channels SET
+			LastPostAt = COALESCE((SELECT MAX(CreateAt) FROM Posts WHERE ChannelId = :ChannelId AND DeleteAt = 0), CreateAt),
+			TotalMsgCount = CASE WHEN TotalMsgCount > :Count THEN TotalMsgCount - :Count ELSE 0 END
+		WHERE Id = :ChannelId`, map[string]interface{}{"ChannelId": sourceChannelId, "Count": count}); err != nil {
+		return nil, errors.Wrapf(err, "failed to update Channel with channelId=%s", sourceChannelId)
+	}
+	if _, err := transaction.Exec("UPDATE Channels SET LastPostAt = CASE WHEN LastPostAt < :LastPostAt THEN :LastPostAt ELSE LastPostAt END, TotalMsgCount = TotalMsgCount + :Count WHERE Id = :ChannelId", map[string]interface{}{"LastPostAt": lastPostAt, "ChannelId": targetChannelId, "Count": count}); err != nil {
+		return nil, errors.Wrapf(err, "failed to update Channel with channelId=%s", targetChannelId)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	for _, post := range posts {
+		post.ChannelId = targetChannelId
+		post.UpdateAt = updateAt
+	}
+
+	return posts, nil
+}
+
+func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.GetPostsCtx(context.Background(), options, allowFromCache)
+}
+
+// GetPostsCtx is GetPosts aborting the queries once ctx is done.
+func (s *SqlPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, _ bool) (*model.PostList, error) {
 	if options.PerPage > 1000 {
 		return nil, store.NewErrInvalidInput("Post", "<options.PerPage>", options.PerPage)
 	}
@@ -624,13 +830,13 @@ func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, _ bool) (*model.P
 
 	rpc := make(chan store.StoreResult, 1)
 	go func() {
-		posts, err := s.getRootPosts(options.ChannelId, offset, options.PerPage, options.SkipFetchThreads)
+		posts, err := s.getRootPosts(ctx, options.ChannelId, offset, options.PerPage, options.SkipFetchThreads)
 		rpc <- store.StoreResult{Data: posts, NErr: err}
 		close(rpc)
 	}()
 	cpc := make(chan store.StoreResult, 1)
 	go func() {
-		posts, err := s.getParentsPosts(options.ChannelId, offset, options.PerPage, options.SkipFetchThreads)
+		posts, err := s.getParentsPosts(ctx, options.ChannelId, offset, options.PerPage, options.SkipFetchThreads)
 		cpc <- store.StoreResult{Data: posts, NErr: err}
 		close(cpc)
 	}()
@@ -665,6 +871,11 @@ func (s *SqlPostStore) GetPosts(options model.GetPostsOptions, _ bool) (*model.P
 }
 
 func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	return s.GetPostsSinceCtx(context.Background(), options, allowFromCache)
+}
+
+// GetPostsSinceCtx is GetPostsSince aborting the query once ctx is done.
+func (s *SqlPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 	var posts []*model.Post
 
 	replyCountQuery1 := ""
@@ -717,7 +928,7 @@ func (s *SqlPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFr
 		(SELECT *` + replyCountQuery1 + ` FROM Posts p1 WHERE id in (SELECT rootid FROM cte))
 		ORDER BY CreateAt DESC`
 	}
-	_, err := s.GetReplica().Select(&posts, query, map[string]interface{}{"ChannelId": options.ChannelId, "Time": options.Time})
+	err := selectContext(ctx, s.GetReplica(), &posts, query, map[string]interface{}{"ChannelId": options.ChannelId, "Time": options.Time})
 
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
@@ -798,44 +1009,266 @@ func (s *SqlPostStore) getPostsAround(before bool, options model.GetPostsOptions
 	}
 
 	if len(posts) > 0 {
-		rootIds := []string{}
-		for _, post := range posts {
-			rootIds = append(rootIds, post.Id)
-			if post.RootId != "" {
-				rootIds = append(rootIds, post.RootId)
-			}
+		parents, err = s.getThreadsForPosts(posts, options.ChannelId, options.SkipFetchThreads)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := model.NewPostList()
+
+	// We need to flip the order if we selected backwards
+	if before {
+		for _, p := range posts {
+			list.AddPost(p)
+			list.AddOrder(p.Id)
+		}
+	} else {
+		l := len(posts)
+		for i := range posts {
+			list.AddPost(posts[l-i-1])
+			list.AddOrder(posts[l-i-1].Id)
+		}
+	}
+
+	for _, p := range parents {
+		list.AddPost(p)
+	}
+
+	return list, nil
+}
+
+func (s *SqlPostStore) GetPostWithContext(postId string, before, after int) (*model.PostList, error) {
+	if before < 0 {
+		return nil, store.NewErrInvalidInput("Post", "before", before)
+	}
+
+	if after < 0 {
+		return nil, store.NewErrInvalidInput("Post", "after", after)
+	}
+
+	// The post and the posts around it are selected together, the position of the post being
+	// read from it in the subqueries.
+	around := `SELECT
+			p.*,
+			(SELECT COUNT(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0) AS ReplyCount
+		FROM
+			Posts p,
+			(SELECT ChannelId, CreateAt FROM Posts WHERE Id = :Id) Target
+		WHERE
+			p.ChannelId = Target.ChannelId
+			AND p.DeleteAt = 0`
+
+	var posts []*model.Post
+	if _, err := s.GetReplica().Select(&posts,
+		`SELECT * FROM (`+around+`
+			AND p.Id = :Id) PostItself
+		UNION ALL
+		SELECT * FROM (`+around+`
+			AND (p.CreateAt < Target.CreateAt OR (p.CreateAt = Target.CreateAt AND p.Id < :Id))
+			ORDER BY p.CreateAt DESC, p.Id DESC
+			LIMIT :Before) PostsBefore
+		UNION ALL
+		SELECT * FROM (`+around+`
+			AND (p.CreateAt > Target.CreateAt OR (p.CreateAt = Target.CreateAt AND p.Id > :Id))
+			ORDER BY p.CreateAt ASC, p.Id ASC
+			LIMIT :After) PostsAfter`, map[string]interface{}{"Id": postId, "Before": before, "After": after}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the context of Post with id=%s", postId)
+	}
+
+	var post *model.Post
+	for _, p := range posts {
+		if p.Id == postId {
+			post = p
+		}
+	}
+	if post == nil {
+		return nil, store.NewErrNotFound("Post", postId)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt == posts[j].CreateAt {
+			return posts[i].Id > posts[j].Id
 		}
-		rootQuery := s.getQueryBuilder().Select("p.*")
-		idQuery := sq.Or{
-			sq.Eq{"Id": rootIds},
+		return posts[i].CreateAt > posts[j].CreateAt
+	})
+
+	list := model.NewPostList()
+	for _, p := range posts {
+		list.AddPost(p)
+		list.AddOrder(p.Id)
+	}
+
+	if post.RootId != "" {
+		thread, err := s.getThreadsForPosts([]*model.Post{post}, post.ChannelId, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range thread {
+			if _, ok := list.Posts[p.Id]; !ok {
+				list.AddPost(p)
+			}
 		}
-		rootQuery = rootQuery.Column(sq.Alias(replyCountSubQuery, "ReplyCount"))
-		if !options.SkipFetchThreads {
-			idQuery = append(idQuery, sq.Eq{"RootId": rootIds}) // preserve original behaviour
+	}
+
+	return list, nil
+}
+
+// getThreadsForPosts returns the root posts of the given posts, along with the rest of their
+// threads unless skipFetchThreads is set.
+func (s *SqlPostStore) getThreadsForPosts(posts []*model.Post, channelId string, skipFetchThreads bool) ([]*model.Post, error) {
+	var parents []*model.Post
+
+	rootIds := []string{}
+	for _, post := range posts {
+		rootIds = append(rootIds, post.Id)
+		if post.RootId != "" {
+			rootIds = append(rootIds, post.RootId)
 		}
+	}
 
-		rootQuery = rootQuery.From("Posts p").
-			Where(sq.And{
-				idQuery,
-				sq.Eq{"ChannelId": options.ChannelId},
-				sq.Eq{"DeleteAt": 0},
-			}).
-			OrderBy("CreateAt DESC")
+	replyCountSubQuery := s.getQueryBuilder().Select("COUNT(Posts.Id)").From("Posts").Where(sq.Expr("Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0"))
+	rootQuery := s.getQueryBuilder().Select("p.*")
+	idQuery := sq.Or{
+		sq.Eq{"Id": rootIds},
+	}
+	rootQuery = rootQuery.Column(sq.Alias(replyCountSubQuery, "ReplyCount"))
+	if !skipFetchThreads {
+		idQuery = append(idQuery, sq.Eq{"RootId": rootIds}) // preserve original behaviour
+	}
 
-		rootQueryString, rootArgs, err := rootQuery.ToSql()
+	rootQuery = rootQuery.From("Posts p").
+		Where(sq.And{
+			idQuery,
+			sq.Eq{"ChannelId": channelId},
+			sq.Eq{"DeleteAt": 0},
+		}).
+		OrderBy("CreateAt DESC")
 
+	rootQueryString, rootArgs, err := rootQuery.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "post_tosql")
+	}
+	_, err = s.GetMaster().Select(&parents, rootQueryString, rootArgs...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", channelId)
+	}
+
+	return parents, nil
+}
+
+// encodePostCursor builds the opaque cursor pointing right after the given post.
+func encodePostCursor(post *model.Post) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(post.CreateAt, 10) + ":" + post.Id))
+}
+
+func decodePostCursor(cursor string) (int64, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", store.NewErrInvalidInput("Post", "<options.Cursor>", cursor)
+	}
+
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 || !model.IsValidId(parts[1]) {
+		return 0, "", store.NewErrInvalidInput("Post", "<options.Cursor>", cursor)
+	}
+
+	createAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", store.NewErrInvalidInput("Post", "<options.Cursor>", cursor)
+	}
+	return createAt, parts[1], nil
+}
+
+// GetPostsBeforeCursor returns the page of posts created before the cursor, or before
+// options.PostId on the first page, and the cursor of the next page. The returned cursor
+// is empty once there are no more posts.
+func (s *SqlPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	return s.getPostsAroundCursor(true, options)
+}
+
+// GetPostsAfterCursor returns the page of posts created after the cursor, or after
+// options.PostId on the first page, and the cursor of the next page. The returned cursor
+// is empty once there are no more posts.
+func (s *SqlPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	return s.getPostsAroundCursor(false, options)
+}
+
+// getPostsAroundCursor seeks by (CreateAt, Id) rather than skipping rows with an offset,
+// so that every page costs the same. Posts sharing the same CreateAt are ordered by Id,
+// which keeps pages from overlapping or skipping posts.
+func (s *SqlPostStore) getPostsAroundCursor(before bool, options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	if options.PerPage <= 0 || options.PerPage > 1000 {
+		return nil, "", store.NewErrInvalidInput("Post", "<options.PerPage>", options.PerPage)
+	}
+
+	var createAt int64
+	var postId string
+	if options.Cursor != "" {
+		var err error
+		if createAt, postId, err = decodePostCursor(options.Cursor); err != nil {
+			return nil, "", err
+		}
+	} else {
+		var err error
+		createAt, err = s.GetReplica().SelectInt("SELECT CreateAt FROM Posts WHERE Id = :PostId", map[string]interface{}{"PostId": options.PostId})
 		if err != nil {
-			return nil, errors.Wrap(err, "post_tosql")
+			return nil, "", errors.Wrapf(err, "failed to get Post with id=%s", options.PostId)
 		}
-		_, err = s.GetMaster().Select(&parents, rootQueryString, rootArgs...)
+		if createAt == 0 {
+			return nil, "", store.NewErrNotFound("Post", options.PostId)
+		}
+		postId = options.PostId
+	}
+
+	var seek sq.Sqlizer
+	var sort string
+	if before {
+		seek = sq.Or{sq.Lt{"CreateAt": createAt}, sq.And{sq.Eq{"CreateAt": createAt}, sq.Lt{"Id": postId}}}
+		sort = "DESC"
+	} else {
+		seek = sq.Or{sq.Gt{"CreateAt": createAt}, sq.And{sq.Eq{"CreateAt": createAt}, sq.Gt{"Id": postId}}}
+		sort = "ASC"
+	}
+	table := "Posts p"
+	// See getPostsAround.
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		table += " USE INDEX(idx_posts_channel_id_delete_at_create_at)"
+	}
+
+	replyCountSubQuery := s.getQueryBuilder().Select("COUNT(Posts.Id)").From("Posts").Where(sq.Expr("Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0"))
+	query := s.getQueryBuilder().Select("p.*").
+		Column(sq.Alias(replyCountSubQuery, "ReplyCount")).
+		From(table).
+		Where(sq.And{
+			seek,
+			sq.Eq{"ChannelId": options.ChannelId},
+			sq.Eq{"DeleteAt": int(0)},
+		}).
+		OrderBy("ChannelId", "DeleteAt", "CreateAt "+sort, "Id "+sort).
+		Limit(uint64(options.PerPage))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "post_tosql")
+	}
+
+	var posts []*model.Post
+	if _, err = s.GetMaster().Select(&posts, queryString, args...); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
+	}
+
+	var parents []*model.Post
+	if len(posts) > 0 {
+		parents, err = s.getThreadsForPosts(posts, options.ChannelId, options.SkipFetchThreads)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
+			return nil, "", err
 		}
 	}
 
 	list := model.NewPostList()
 
-	// We need to flip the order if we selected backwards
+	// The list is always ordered from the newest post to the oldest one
 	if before {
 		for _, p := range posts {
 			list.AddPost(p)
@@ -853,7 +1286,72 @@ func (s *SqlPostStore) getPostsAround(before bool, options model.GetPostsOptions
 		list.AddPost(p)
 	}
 
-	return list, nil
+	var nextCursor string
+	if len(posts) == options.PerPage {
+		nextCursor = encodePostCursor(posts[len(posts)-1])
+	}
+
+	return list, nextCursor, nil
+}
+
+// GetRecentPostsForUser joins the posts with the memberships of the user in a single query and
+// seeks by (CreateAt, Id) as getPostsAroundCursor does, leaving out the archived channels and,
+// when options.ExcludeMuted is set, the channels the user muted.
+func (s *SqlPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	if options.PerPage <= 0 || options.PerPage > 1000 {
+		return nil, "", store.NewErrInvalidInput("Post", "<options.PerPage>", options.PerPage)
+	}
+
+	where := sq.And{
+		sq.Eq{"cm.UserId": options.UserId},
+		sq.Eq{"c.DeleteAt": int(0)},
+		sq.Eq{"p.DeleteAt": int(0)},
+	}
+	if options.Cursor != "" {
+		createAt, postId, err := decodePostCursor(options.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		where = append(where, sq.Or{sq.Lt{"p.CreateAt": createAt}, sq.And{sq.Eq{"p.CreateAt": createAt}, sq.Lt{"p.Id": postId}}})
+	}
+	if options.ExcludeMuted {
+		// The notify props are stored as the JSON encoding of the map, which has no spaces.
+		muted := `%"` + model.MARK_UNREAD_NOTIFY_PROP + `":"` + model.CHANNEL_MARK_UNREAD_MENTION + `"%`
+		where = append(where, sq.NotLike{"cm.NotifyProps": muted})
+	}
+
+	replyCountSubQuery := s.getQueryBuilder().Select("COUNT(Posts.Id)").From("Posts").Where(sq.Expr("Posts.RootId = (CASE WHEN p.RootId = '' THEN p.Id ELSE p.RootId END) AND Posts.DeleteAt = 0"))
+	query := s.getQueryBuilder().Select("p.*").
+		Column(sq.Alias(replyCountSubQuery, "ReplyCount")).
+		From("Posts p").
+		Join("ChannelMembers cm ON cm.ChannelId = p.ChannelId").
+		Join("Channels c ON c.Id = p.ChannelId").
+		Where(where).
+		OrderBy("p.CreateAt DESC", "p.Id DESC").
+		Limit(uint64(options.PerPage))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "post_tosql")
+	}
+
+	var posts []*model.Post
+	if _, err = s.GetReplica().Select(&posts, queryString, args...); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to find the recent Posts of userId=%s", options.UserId)
+	}
+
+	list := model.NewPostList()
+	for _, p := range posts {
+		list.AddPost(p)
+		list.AddOrder(p.Id)
+	}
+
+	var nextCursor string
+	if len(posts) == options.PerPage {
+		nextCursor = encodePostCursor(posts[len(posts)-1])
+	}
+
+	return list, nextCursor, nil
 }
 
 func (s *SqlPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
@@ -950,7 +1448,7 @@ func (s *SqlPostStore) GetPostAfterTime(channelId string, time int64) (*model.Po
 	return post, nil
 }
 
-func (s *SqlPostStore) getRootPosts(channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getRootPosts(ctx context.Context, channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
 	var posts []*model.Post
 	var fetchQuery string
 	if skipFetchThreads {
@@ -958,16 +1456,16 @@ func (s *SqlPostStore) getRootPosts(channelId string, offset int, limit int, ski
 	} else {
 		fetchQuery = "SELECT * FROM Posts WHERE ChannelId = :ChannelId AND DeleteAt = 0 ORDER BY CreateAt DESC LIMIT :Limit OFFSET :Offset"
 	}
-	_, err := s.GetReplica().Select(&posts, fetchQuery, map[string]interface{}{"ChannelId": channelId, "Offset": offset, "Limit": limit})
+	err := selectContext(ctx, s.GetReplica(), &posts, fetchQuery, map[string]interface{}{"ChannelId": channelId, "Offset": offset, "Limit": limit})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
 	return posts, nil
 }
 
-func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getParentsPosts(ctx context.Context, channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
 	if s.DriverName() == model.DATABASE_DRIVER_POSTGRES {
-		return s.getParentsPostsPostgreSQL(channelId, offset, limit, skipFetchThreads)
+		return s.getParentsPostsPostgreSQL(ctx, channelId, offset, limit, skipFetchThreads)
 	}
 
 	// query parent Ids first
@@ -989,7 +1487,7 @@ func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int,
 			LIMIT :Limit OFFSET :Offset) q
 		WHERE q.RootId != ''`
 
-	_, err := s.GetReplica().Select(&roots, rootQuery, map[string]interface{}{"ChannelId": channelId, "Offset": offset, "Limit": limit})
+	err := selectContext(ctx, s.GetReplica(), &roots, rootQuery, map[string]interface{}{"ChannelId": channelId, "Offset": offset, "Limit": limit})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
 	}
@@ -1013,7 +1511,7 @@ func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int,
 		whereStatement += " OR p.RootId IN (" + placeholderString + ")"
 	}
 	var posts []*model.Post
-	_, err = s.GetReplica().Select(&posts, `
+	err = selectContext(ctx, s.GetReplica(), &posts, `
 		SELECT p.*`+replyCountQuery+`
 		FROM
 			Posts p
@@ -1029,7 +1527,7 @@ func (s *SqlPostStore) getParentsPosts(channelId string, offset int, limit int,
 	return posts, nil
 }
 
-func (s *SqlPostStore) getParentsPostsPostgreSQL(channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
+func (s *SqlPostStore) getParentsPostsPostgreSQL(ctx context.Context, channelId string, offset int, limit int, skipFetchThreads bool) ([]*model.Post, error) {
 	var posts []*model.Post
 	replyCountQuery := ""
 	onStatement := "q1.RootId = q2.Id"
@@ -1038,7 +1536,7 @@ func (s *SqlPostStore) getParentsPostsPostgreSQL(channelId string, offset int, l
 	} else {
 		onStatement += " OR q1.RootId = q2.RootId"
 	}
-	_, err := s.GetReplica().Select(&posts,
+	err := selectContext(ctx, s.GetReplica(), &posts,
 		`SELECT q2.*`+replyCountQuery+`
         FROM
             Posts q2
@@ -1182,7 +1680,7 @@ func (s *SqlPostStore) buildSearchUserFilterClause(users []string, paramPrefix s
 	return "AND Id IN (" + clause + ")", queryParams
 }
 
-func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedUsers []string, queryParams map[string]interface{}, userByUsername bool) (string, map[string]interface{}) {
+func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedUsers []string, queryParams map[string]interface{}, userByUsername bool, allTeams bool) (string, map[string]interface{}) {
 	if len(fromUsers) == 0 && len(excludedUsers) == 0 {
 		return "", queryParams
 	}
@@ -1199,6 +1697,19 @@ func (s *SqlPostStore) buildSearchPostFilterClause(fromUsers []string, excludedU
 				AND Users.Id = TeamMembers.UserId
 				FROM_USER_FILTER
 				EXCLUDED_USER_FILTER)`
+	if allTeams {
+		// The filters are appended as AND clauses.
+		filterQuery = `
+		AND UserId IN (
+			SELECT
+				Id
+			FROM
+				Users
+			WHERE
+				1 = 1
+				FROM_USER_FILTER
+				EXCLUDED_USER_FILTER)`
+	}
 
 	fromUserClause, queryParams := s.buildSearchUserFilterClause(fromUsers, "FromUser", false, queryParams, userByUsername)
 	filterQuery = strings.Replace(filterQuery, "FROM_USER_FILTER", fromUserClause, 1)
@@ -1214,16 +1725,24 @@ func (s *SqlPostStore) Search(teamId string, userId string, params *model.Search
 }
 
 func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, error) {
+	return s.searchInScope(teamId, userId, params, channelsByName, userByUsername, false, 100, nil)
+}
+
+// searchInScope searches the posts of the team, unless allTeams is set, returning at most limit
+// of them. Only the posts sorted after searchAfter are returned when it's set.
+func (s *SqlPostStore) searchInScope(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool, allTeams bool, limit int, searchAfter *model.PostSearchCursor) (*model.PostList, error) {
 	queryParams := map[string]interface{}{
 		"TeamId": teamId,
 		"UserId": userId,
+		"Limit":  limit,
 	}
 
 	list := model.NewPostList()
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 {
+		len(params.OnDate) == 0 && len(params.AfterDate) == 0 && len(params.BeforeDate) == 0 &&
+		!params.PinnedOnly {
 		return list, nil
 	}
 
@@ -1239,6 +1758,16 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		userIdPart = ""
 	}
 
+	teamIdPart := "AND (TeamId = :TeamId OR TeamId = '')"
+	if allTeams {
+		teamIdPart = ""
+	}
+
+	pinnedPart := ""
+	if params.PinnedOnly {
+		pinnedPart = "AND PinnedAt > 0"
+	}
+
 	searchQuery := `
 			SELECT
 				* ,(SELECT COUNT(Posts.Id) FROM Posts WHERE Posts.RootId = (CASE WHEN q2.RootId = '' THEN q2.Id ELSE q2.RootId END) AND Posts.DeleteAt = 0) as ReplyCount
@@ -1247,6 +1776,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 			WHERE
 				DeleteAt = 0
 				AND Type NOT LIKE '` + model.POST_SYSTEM_MESSAGE_PREFIX + `%'
+				` + pinnedPart + `
 				POST_FILTER
 				AND ChannelId IN (
 					SELECT
@@ -1256,15 +1786,24 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 						ChannelMembers
 					WHERE
 						Id = ChannelId
-							AND (TeamId = :TeamId OR TeamId = '')
+							` + teamIdPart + `
 							` + userIdPart + `
 							` + deletedQueryPart + `
 							IN_CHANNEL_FILTER
 							EXCLUDED_CHANNEL_FILTER)
 				CREATEDATE_CLAUSE
 				SEARCH_CLAUSE
-				ORDER BY CreateAt DESC
-			LIMIT 100`
+				SEARCH_AFTER_CLAUSE
+				ORDER BY CreateAt DESC, Id DESC
+			LIMIT :Limit`
+
+	searchAfterClause := ""
+	if searchAfter != nil {
+		searchAfterClause = "AND (CreateAt < :SearchAfterCreateAt OR (CreateAt = :SearchAfterCreateAt AND Id < :SearchAfterId))"
+		queryParams["SearchAfterCreateAt"] = searchAfter.CreateAt
+		queryParams["SearchAfterId"] = searchAfter.Id
+	}
+	searchQuery = strings.Replace(searchQuery, "SEARCH_AFTER_CLAUSE", searchAfterClause, 1)
 
 	inChannelClause, queryParams := s.buildSearchChannelFilterClause(params.InChannels, "InChannel", false, queryParams, channelsByName)
 	searchQuery = strings.Replace(searchQuery, "IN_CHANNEL_FILTER", inChannelClause, 1)
@@ -1272,7 +1811,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	excludedChannelClause, queryParams := s.buildSearchChannelFilterClause(params.ExcludedChannels, "ExcludedChannel", true, queryParams, channelsByName)
 	searchQuery = strings.Replace(searchQuery, "EXCLUDED_CHANNEL_FILTER", excludedChannelClause, 1)
 
-	postFilterClause, queryParams := s.buildSearchPostFilterClause(params.FromUsers, params.ExcludedUsers, queryParams, userByUsername)
+	postFilterClause, queryParams := s.buildSearchPostFilterClause(params.FromUsers, params.ExcludedUsers, queryParams, userByUsername, allTeams)
 	searchQuery = strings.Replace(searchQuery, "POST_FILTER", postFilterClause, 1)
 
 	createDateFilterClause, queryParams := s.buildCreateDateFilterClause(params, queryParams)
@@ -1318,6 +1857,9 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		}
 
 		searchClause := fmt.Sprintf("AND to_tsvector('english', %s) @@  to_tsquery('english', :Terms)", searchType)
+		if params.Fuzziness > 0 && searchType == "Message" && strings.TrimSpace(terms) != "" && s.isTrigramSearchSupported() {
+			searchClause = buildFuzzySearchClause(terms, excludedTerms, params, queryParams)
+		}
 		searchQuery = strings.Replace(searchQuery, "SEARCH_CLAUSE", searchClause, 1)
 	} else if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		if searchType == "Message" {
@@ -1377,6 +1919,62 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	return list, nil
 }
 
+// buildFuzzySearchClause builds a search clause matching the messages either containing the terms
+// or words similar enough to them. It relies on the trigram similarity of the pg_trgm extension.
+// Wildcard and excluded terms are matched exactly.
+func buildFuzzySearchClause(terms, excludedTerms string, params *model.SearchParams, queryParams map[string]interface{}) string {
+	fuzziness := params.Fuzziness
+	if fuzziness > model.SEARCH_MAX_FUZZINESS {
+		fuzziness = model.SEARCH_MAX_FUZZINESS
+	}
+	queryParams["FuzzyThreshold"] = fuzzySearchSimilarityThresholds[fuzziness]
+
+	termClauses := []string{}
+	for i, term := range strings.Fields(terms) {
+		termKey := fmt.Sprintf("FuzzyTerm%d", i)
+		queryParams[termKey] = term
+		termClause := fmt.Sprintf("to_tsvector('english', Message) @@ to_tsquery('english', :%s)", termKey)
+
+		if !strings.HasSuffix(term, ":*") {
+			wordKey := fmt.Sprintf("FuzzyWord%d", i)
+			queryParams[wordKey] = strings.ToLower(strings.Trim(term, `"`))
+			termClause = fmt.Sprintf("(%s OR word_similarity(:%s, Message) >= :FuzzyThreshold)", termClause, wordKey)
+		}
+		termClauses = append(termClauses, termClause)
+	}
+
+	operator := " AND "
+	if params.OrTerms {
+		operator = " OR "
+	}
+	searchClause := "AND (" + strings.Join(termClauses, operator) + ")"
+
+	if excludedTerms != "" {
+		queryParams["FuzzyExcludedTerms"] = strings.Join(strings.Fields(excludedTerms), " | ")
+		searchClause += " AND NOT to_tsvector('english', Message) @@ to_tsquery('english', :FuzzyExcludedTerms)"
+	}
+
+	return searchClause
+}
+
+// isTrigramSearchSupported returns whether the pg_trgm extension, required by the fuzzy
+// searches, is installed in the database.
+func (s *SqlPostStore) isTrigramSearchSupported() bool {
+	s.trigramSearchOnce.Do(func() {
+		count, err := s.GetReplica().SelectInt("SELECT COUNT(*) FROM pg_extension WHERE extname = 'pg_trgm'")
+		if err != nil {
+			mlog.Warn("Unable to check if the pg_trgm extension is installed.", mlog.Err(err))
+			return
+		}
+
+		s.trigramSearchSupported = count > 0
+		if !s.trigramSearchSupported {
+			mlog.Info("The pg_trgm extension is not installed, fuzzy searches will only match the exact terms.")
+		}
+	})
+	return s.trigramSearchSupported
+}
+
 func removeMysqlStopWordsFromTerms(terms string) (string, error) {
 	stopWords := make([]string, len(searchlayer.MYSQL_STOP_WORDS))
 	copy(stopWords, searchlayer.MYSQL_STOP_WORDS)
@@ -1510,6 +2108,47 @@ func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCoun
 	return rows, nil
 }
 
+// AnalyticsCountByDay returns the number of posts of the team, or of all the teams if teamId is
+// empty, created between startTime and endTime inclusive, by day. Days are bucketed in the time zone at
+// timeZoneOffset seconds from UTC, and are named YYYY-MM-DD, newest first. Days without posts are
+// omitted.
+func (s *SqlPostStore) AnalyticsCountByDay(teamId string, startTime, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	// Buckets are computed from the timestamps rather than with the date functions of the
+	// databases, so that they don't depend on the time zone of the database session.
+	dayExpr := "(Posts.CreateAt + :Offset) / 86400000"
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		dayExpr = "(Posts.CreateAt + :Offset) DIV 86400000"
+	}
+
+	query := "SELECT " + dayExpr + " AS DayNumber, COUNT(Posts.Id) AS Value FROM Posts"
+	if teamId != "" {
+		query += " INNER JOIN Channels ON Posts.ChannelId = Channels.Id AND Channels.TeamId = :TeamId"
+	}
+	query += " WHERE Posts.CreateAt >= :StartTime AND Posts.CreateAt <= :EndTime GROUP BY DayNumber ORDER BY DayNumber DESC"
+
+	var days []struct {
+		DayNumber int64
+		Value     int64
+	}
+	if _, err := s.GetReplica().Select(&days, query, map[string]interface{}{
+		"TeamId":    teamId,
+		"StartTime": startTime,
+		"EndTime":   endTime,
+		"Offset":    int64(timeZoneOffset) * 1000,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to count Posts by day with teamId=%s", teamId)
+	}
+
+	rows := make([]*model.AnalyticsRow, 0, len(days))
+	for _, day := range days {
+		rows = append(rows, &model.AnalyticsRow{
+			Name:  time.Unix(day.DayNumber*24*60*60, 0).UTC().Format("2006-01-02"),
+			Value: float64(day.Value),
+		})
+	}
+	return rows, nil
+}
+
 func (s *SqlPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
 	query :=
 		`SELECT
@@ -1566,6 +2205,84 @@ func (s *SqlPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	return posts, nil
 }
 
+// GetPostsByProp returns the posts of the channel having the given string value for the given
+// prop, newest first. On Postgres the filter uses the GIN index on the Props column when
+// SqlSettings.UseJSONBProps is enabled.
+func (s *SqlPostStore) GetPostsByProp(channelId, key, value string) ([]*model.Post, error) {
+	params := map[string]interface{}{
+		"ChannelId": channelId,
+		"Filter":    model.StringInterfaceToJson(map[string]interface{}{key: value}),
+	}
+
+	var propClause string
+	switch s.DriverName() {
+	case model.DATABASE_DRIVER_POSTGRES:
+		if s.useJSONBProps() {
+			propClause = " AND Props @> :Filter::jsonb"
+		} else {
+			propClause = " AND Props::jsonb @> :Filter::jsonb"
+		}
+	case model.DATABASE_DRIVER_MYSQL:
+		propClause = " AND JSON_CONTAINS(Props, :Filter)"
+	}
+
+	query := `SELECT * FROM Posts WHERE ChannelId = :ChannelId AND DeleteAt = 0` + propClause + ` ORDER BY CreateAt DESC`
+
+	var posts []*model.Post
+	if _, err := s.GetReplica().Select(&posts, query, params); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s and prop=%s", channelId, key)
+	}
+
+	if propClause == "" {
+		// SQLite may be built without JSON support, so filter the posts of the channel here instead.
+		filtered := make([]*model.Post, 0, len(posts))
+		for _, post := range posts {
+			if propValue, ok := post.GetProp(key).(string); ok && propValue == value {
+				filtered = append(filtered, post)
+			}
+		}
+		posts = filtered
+	}
+
+	return posts, nil
+}
+
+func (s *SqlPostStore) useJSONBProps() bool {
+	settings := s.getSettings()
+	return settings.UseJSONBProps != nil && *settings.UseJSONBProps
+}
+
+// migratePropsToJSONB converts the Props column to jsonb and indexes it when
+// SqlSettings.UseJSONBProps is enabled on Postgres. The column is left as is otherwise,
+// including when the setting is disabled again after the conversion.
+func (s *SqlPostStore) migratePropsToJSONB() {
+	if s.DriverName() != model.DATABASE_DRIVER_POSTGRES || !s.useJSONBProps() {
+		return
+	}
+
+	dataType, err := s.GetMaster().SelectStr("SELECT data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'posts' AND column_name = 'props'")
+	if err != nil {
+		mlog.Critical("Failed to get the data type of Posts.Props", mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_ALTER_COLUMN)
+	}
+
+	if dataType != "jsonb" {
+		mlog.Info("Converting Posts.Props to jsonb. This may take a while on large databases.")
+		if _, err := s.GetMaster().ExecNoTimeout("ALTER TABLE posts ALTER COLUMN props TYPE jsonb USING (CASE WHEN props = '' THEN '{}' ELSE props END)::jsonb"); err != nil {
+			mlog.Critical("Failed to convert Posts.Props to jsonb", mlog.Err(err))
+			time.Sleep(time.Second)
+			os.Exit(EXIT_ALTER_COLUMN)
+		}
+	}
+
+	if _, err := s.GetMaster().ExecNoTimeout("CREATE INDEX IF NOT EXISTS idx_posts_props_gin ON posts USING gin (props jsonb_path_ops)"); err != nil {
+		mlog.Critical("Failed to create index", mlog.String("index_name", "idx_posts_props_gin"), mlog.Err(err))
+		time.Sleep(time.Second)
+		os.Exit(EXIT_CREATE_INDEX_POSTGRES)
+	}
+}
+
 func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
 	var posts []*model.PostForIndexing
 	_, err := s.GetSearchReplica().Select(&posts,
@@ -1851,6 +2568,60 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 	return posts, nil
 }
 
+// GetPostsBatched calls fn with the posts of the channel, including the deleted ones, in batches
+// of at most batchSize posts ordered by CreateAt and then by Id. The batches are read with a
+// cursor on the last post of the previous batch rather than an offset, so that the posts added
+// during the iteration don't shift the batches, and only the posts created before the iteration
+// started are read so that it ends on a busy channel. The iteration stops at the first error
+// returned by fn.
+func (s *SqlPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	if batchSize <= 0 {
+		return errors.Errorf("invalid batch size %d", batchSize)
+	}
+
+	params := map[string]interface{}{
+		"ChannelId":     channelId,
+		"MaxCreateAt":   model.GetMillis(),
+		"AfterCreateAt": int64(-1),
+		"AfterId":       "",
+		"Limit":         batchSize,
+	}
+	for {
+		var posts []*model.Post
+		_, err := s.GetReplica().Select(&posts, `
+			SELECT
+				*
+			FROM
+				Posts
+			WHERE
+				ChannelId = :ChannelId
+				AND CreateAt <= :MaxCreateAt
+				AND (CreateAt > :AfterCreateAt OR (CreateAt = :AfterCreateAt AND Id > :AfterId))
+			ORDER BY
+				CreateAt, Id
+			LIMIT :Limit`, params)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find Posts with channelId=%s", channelId)
+		}
+
+		if len(posts) == 0 {
+			return nil
+		}
+
+		if err := fn(posts); err != nil {
+			return err
+		}
+
+		if len(posts) < batchSize {
+			return nil
+		}
+
+		last := posts[len(posts)-1]
+		params["AfterCreateAt"] = last.CreateAt
+		params["AfterId"] = last.Id
+	}
+}
+
 func (s *SqlPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
 	// Since we don't support paging for DB search, we just return nothing for later pages
 	if page > 0 {
@@ -1897,6 +2668,215 @@ func (s *SqlPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams
 	return model.MakePostSearchResults(posts, nil), nil
 }
 
+// SearchPostsInTeamForUserAfter searches the posts like SearchPostsInTeamForUser, returning the
+// perPage posts following searchAfter by descending CreateAt and Id. The cursor of the next page
+// is set as long as a full page is returned.
+func (s *SqlPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+
+	pchan := make(chan store.StoreResult, len(paramsList))
+
+	for _, params := range paramsList {
+		params.Terms = removeNonAlphaNumericUnquotedTerms(params.Terms, " ")
+
+		wg.Add(1)
+
+		go func(params *model.SearchParams) {
+			defer wg.Done()
+			postList, err := s.searchInScope(teamId, userId, params, false, false, false, perPage, searchAfter)
+			pchan <- store.StoreResult{Data: postList, NErr: err}
+		}(params)
+	}
+
+	wg.Wait()
+	close(pchan)
+
+	posts := model.NewPostList()
+
+	for result := range pchan {
+		if result.NErr != nil {
+			return nil, result.NErr
+		}
+		data := result.Data.(*model.PostList)
+		posts.Extend(data)
+	}
+
+	// Each search returns its first posts after the cursor, so the first of their union make
+	// the page.
+	sort.Slice(posts.Order, func(i, j int) bool {
+		a, b := posts.Posts[posts.Order[i]], posts.Posts[posts.Order[j]]
+		if a.CreateAt != b.CreateAt {
+			return a.CreateAt > b.CreateAt
+		}
+		return a.Id > b.Id
+	})
+
+	page := model.NewPostList()
+	for i := 0; i < len(posts.Order) && i < perPage; i++ {
+		page.AddPost(posts.Posts[posts.Order[i]])
+		page.AddOrder(posts.Order[i])
+	}
+
+	results := model.MakePostSearchResults(page, nil)
+	if perPage > 0 && len(page.Order) == perPage {
+		last := page.Posts[page.Order[len(page.Order)-1]]
+		results.NextCursor = &model.PostSearchCursor{CreateAt: last.CreateAt, Id: last.Id}
+	}
+
+	return results, nil
+}
+
+// SearchAllTeams searches the posts of all the teams, whatever the channels the user is a member
+// of. The channels and users of the terms are matched by name since they can't be resolved within
+// a team.
+func (s *SqlPostStore) SearchAllTeams(userId, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), opts.TimeZoneOffset)
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	// Each search returns its most recent posts, so fetching all of them up to the requested page
+	// is enough to paginate their union.
+	limit := (opts.Page + 1) * opts.PerPage
+
+	var wg sync.WaitGroup
+
+	pchan := make(chan store.StoreResult, len(paramsList))
+
+	for _, params := range paramsList {
+		// Don't allow users to search for "*"
+		if params.Terms == "*" {
+			continue
+		}
+		params.Terms = removeNonAlphaNumericUnquotedTerms(params.Terms, " ")
+		params.OrTerms = opts.IsOrSearch
+		params.IncludeDeletedChannels = opts.IncludeDeletedChannels
+		params.SearchWithoutUserId = true
+
+		wg.Add(1)
+
+		go func(params *model.SearchParams) {
+			defer wg.Done()
+			postList, err := s.searchInScope("", userId, params, true, true, true, limit, nil)
+			pchan <- store.StoreResult{Data: postList, NErr: err}
+		}(params)
+	}
+
+	wg.Wait()
+	close(pchan)
+
+	posts := model.NewPostList()
+
+	for result := range pchan {
+		if result.NErr != nil {
+			return nil, result.NErr
+		}
+		data := result.Data.(*model.PostList)
+		posts.Extend(data)
+	}
+
+	posts.SortByCreateAt()
+
+	page := model.NewPostList()
+	for i := opts.Page * opts.PerPage; i < len(posts.Order) && i < limit; i++ {
+		page.AddPost(posts.Posts[posts.Order[i]])
+		page.AddOrder(posts.Order[i])
+	}
+
+	return model.MakePostSearchResults(page, nil), nil
+}
+
+// SuggestTerms completes the prefix with the names of the channels the user is a member of, the
+// usernames of the members of the team and the terms used in the recent posts of the channels
+// the user is a member of.
+func (s *SqlPostStore) SuggestTerms(userId, teamId, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	likePrefix := sanitizeSearchTerm(strings.ToLower(strings.TrimSpace(prefix)), "*")
+	if likePrefix == "" {
+		return []*model.SearchSuggestion{}, nil
+	}
+
+	channelsQuery := s.getQueryBuilder().
+		Select("Channels.Name", "Channels.DisplayName").
+		From("Channels").
+		Join("ChannelMembers ON Channels.Id = ChannelMembers.ChannelId").
+		Where(sq.Eq{"ChannelMembers.UserId": userId, "Channels.DeleteAt": 0, "Channels.Type": []string{model.CHANNEL_OPEN, model.CHANNEL_PRIVATE}}).
+		Where(sq.Or{sq.Eq{"Channels.TeamId": teamId}, sq.Eq{"Channels.TeamId": ""}}).
+		Where(sq.Or{
+			sq.Expr("LOWER(Channels.Name) LIKE ? ESCAPE '*'", likePrefix+"%"),
+			sq.Expr("LOWER(Channels.DisplayName) LIKE ? ESCAPE '*'", likePrefix+"%"),
+		}).
+		OrderBy("Channels.Name").
+		Limit(uint64(limit))
+
+	var channels []*model.Channel
+	if err := s.selectSuggestions(channelsQuery, &channels); err != nil {
+		return nil, errors.Wrapf(err, "failed to suggest channels for userId=%s and teamId=%s", userId, teamId)
+	}
+	channelSuggestions := []*model.SearchSuggestion{}
+	for _, channel := range channels {
+		channelSuggestions = append(channelSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_CHANNEL, Term: channel.Name, DisplayName: channel.DisplayName})
+	}
+
+	usersQuery := s.getQueryBuilder().
+		Select("Users.Username").
+		From("Users").
+		Join("TeamMembers ON Users.Id = TeamMembers.UserId").
+		Where(sq.Eq{"TeamMembers.TeamId": teamId, "TeamMembers.DeleteAt": 0, "Users.DeleteAt": 0}).
+		Where(sq.Expr("Users.Username LIKE ? ESCAPE '*'", likePrefix+"%")).
+		OrderBy("Users.Username").
+		Limit(uint64(limit))
+
+	var usernames []string
+	if err := s.selectSuggestions(usersQuery, &usernames); err != nil {
+		return nil, errors.Wrapf(err, "failed to suggest usernames for teamId=%s", teamId)
+	}
+	userSuggestions := []*model.SearchSuggestion{}
+	for _, username := range usernames {
+		userSuggestions = append(userSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_USER, Term: username})
+	}
+
+	postsQuery := s.getQueryBuilder().
+		Select("Posts.Message").
+		From("Posts").
+		Where(sq.Expr(`Posts.ChannelId IN (
+			SELECT Channels.Id FROM Channels, ChannelMembers
+			WHERE Channels.Id = ChannelMembers.ChannelId
+				AND (Channels.TeamId = ? OR Channels.TeamId = '')
+				AND ChannelMembers.UserId = ?
+				AND Channels.DeleteAt = 0)`, teamId, userId)).
+		Where(sq.Eq{"Posts.DeleteAt": 0, "Posts.Type": ""}).
+		Where(sq.Or{
+			sq.Expr("LOWER(Posts.Message) LIKE ? ESCAPE '*'", likePrefix+"%"),
+			sq.Expr("LOWER(Posts.Message) LIKE ? ESCAPE '*'", "% "+likePrefix+"%"),
+		}).
+		OrderBy("Posts.CreateAt DESC").
+		Limit(model.SEARCH_SUGGESTION_POSTS_SAMPLE_SIZE)
+
+	var messages []string
+	if err := s.selectSuggestions(postsQuery, &messages); err != nil {
+		return nil, errors.Wrapf(err, "failed to suggest terms for userId=%s and teamId=%s", userId, teamId)
+	}
+	termSuggestions := []*model.SearchSuggestion{}
+	for _, term := range model.ExtractSuggestedTerms(messages, prefix, limit) {
+		termSuggestions = append(termSuggestions, &model.SearchSuggestion{Type: model.SEARCH_SUGGESTION_TYPE_TERM, Term: term})
+	}
+
+	return model.MergeSearchSuggestions(limit, channelSuggestions, userSuggestions, termSuggestions), nil
+}
+
+func (s *SqlPostStore) selectSuggestions(query sq.SelectBuilder, result interface{}) error {
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "post_tosql")
+	}
+	_, err = s.GetReplica().Select(result, queryString, args...)
+	return err
+}
+
 func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, error) {
 	query := s.getQueryBuilder().Select("MIN(min_createat) min_createat").
 		Suffix(`FROM (
@@ -1960,7 +2940,7 @@ func (s *SqlPostStore) updateThreadsFromPosts(transaction *gorp.Transaction, pos
 	if len(rootIds) == 0 {
 		return nil
 	}
-	now := model.GetMillis()
+	now := s.getMillis()
 	threadsByRootsSql, threadsByRootsArgs, _ := s.getQueryBuilder().Select("*").From("Threads").Where(sq.Eq{"PostId": rootIds}).ToSql()
 	var threadsByRoots []*model.Thread
 	if _, err := transaction.Select(&threadsByRoots, threadsByRootsSql, threadsByRootsArgs...); err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store/searchtest"
 	"github.com/mattermost/mattermost-server/v5/store/storetest"
 )
@@ -62,3 +63,36 @@ func TestMysqlStopWords(t *testing.T) {
 		})
 	}
 }
+
+func TestPostStoreInsertBatchSize(t *testing.T) {
+	maxBatchSize := maxQueryParameters / len(postSliceColumns())
+
+	insertBatchSizeTests := []struct {
+		Name     string
+		Settings *model.SqlSettings
+		Expected int
+	}{
+		{
+			Name:     "Should use the default when not configured",
+			Settings: &model.SqlSettings{},
+			Expected: model.SQL_SETTINGS_DEFAULT_INSERT_BATCH_SIZE,
+		},
+		{
+			Name:     "Should use the configured batch size",
+			Settings: &model.SqlSettings{InsertBatchSize: model.NewInt(50)},
+			Expected: 50,
+		},
+		{
+			Name:     "Should cap the batch size to the parameter limit",
+			Settings: &model.SqlSettings{InsertBatchSize: model.NewInt(maxBatchSize + 1)},
+			Expected: maxBatchSize,
+		},
+	}
+
+	for _, tc := range insertBatchSizeTests {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &SqlPostStore{SqlStore: &SqlSupplier{settings: tc.Settings}}
+			require.Equal(t, tc.Expected, s.insertBatchSize())
+		})
+	}
+}
@@ -6,6 +6,7 @@ package sqlstore
 import (
 	"fmt"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/gorp"
@@ -80,6 +81,95 @@ func (s SqlPreferenceStore) Save(preferences *model.Preferences) error {
 	return nil
 }
 
+// SaveMultiple upserts the preferences of a user with a single statement, deleting the other
+// preferences of the user when deleteOmitted is set, and returns all the preferences of the user
+// as stored. An empty set is left as it is, not knowing the user.
+func (s SqlPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	if len(preferences) == 0 {
+		return model.Preferences{}, nil
+	}
+
+	// A statement can't upsert the same row twice, so only the last of duplicated preferences is kept.
+	userId := preferences[0].UserId
+	keys := []string{}
+	preferencesByKey := map[string]model.Preference{}
+	for _, preference := range preferences {
+		preference.PreUpdate()
+		if err := preference.IsValid(); err != nil {
+			return nil, err
+		}
+		if preference.UserId != userId {
+			return nil, store.NewErrInvalidInput("Preference", "UserId", preference.UserId)
+		}
+
+		key := preference.Category + ":" + preference.Name
+		if _, ok := preferencesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		preferencesByKey[key] = preference
+	}
+
+	query := s.getQueryBuilder().Insert("Preferences").Columns("UserId", "Category", "Name", "Value")
+	for _, key := range keys {
+		preference := preferencesByKey[key]
+		query = query.Values(preference.UserId, preference.Category, preference.Name, preference.Value)
+	}
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE Value = VALUES(Value)")
+	} else {
+		query = query.Suffix("ON CONFLICT (UserId, Category, Name) DO UPDATE SET Value = EXCLUDED.Value")
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "preferences_tosql")
+	}
+
+	transaction, err := s.GetMaster().Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	if deleteOmitted {
+		kept := sq.Or{}
+		for _, key := range keys {
+			preference := preferencesByKey[key]
+			kept = append(kept, sq.Eq{"Category": preference.Category, "Name": preference.Name})
+		}
+		keptSql, keptArgs, err := kept.ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "preferences_tosql")
+		}
+		deleteSql, deleteArgs, err := s.getQueryBuilder().
+			Delete("Preferences").
+			Where(sq.Eq{"UserId": userId}).
+			Where(sq.Expr("NOT "+keptSql, keptArgs...)).
+			ToSql()
+		if err != nil {
+			return nil, errors.Wrap(err, "preferences_tosql")
+		}
+		if _, err := transaction.Exec(deleteSql, deleteArgs...); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete the omitted Preferences with userId=%s", userId)
+		}
+	}
+
+	if _, err := transaction.Exec(sql, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to upsert Preferences with userId=%s", userId)
+	}
+
+	var saved model.Preferences
+	if _, err := transaction.Select(&saved, "SELECT * FROM Preferences WHERE UserId = :UserId", map[string]interface{}{"UserId": userId}); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Preferences with userId=%s", userId)
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return saved, nil
+}
+
 func (s SqlPreferenceStore) save(transaction *gorp.Transaction, preference *model.Preference) error {
 	preference.PreUpdate()
 
@@ -160,7 +250,7 @@ func (s SqlPreferenceStore) Get(userId string, category string, name string) (*m
 			UserId = :UserId
 			AND Category = :Category
 			AND Name = :Name`, map[string]interface{}{"UserId": userId, "Category": category, "Name": name}); err != nil {
-		return nil, errors.Wrapf(err, "failed to find Preference with userId=%s, category=%s, name=%s", userId, category, name)
+		return nil, wrapSqlError(err, "Preference", name, fmt.Sprintf("failed to find Preference with userId=%s, category=%s, name=%s", userId, category, name))
 	}
 	return preference, nil
 }
@@ -55,10 +55,11 @@ func TestDeleteUnusedFeatures(t *testing.T) {
 		err := ss.Preference().Save(&features)
 		require.Nil(t, err)
 
-		ss.Preference().(*SqlPreferenceStore).deleteUnusedFeatures()
+		sqlPreferenceStore := &SqlPreferenceStore{SqlStore: ss.(*SqlSupplier)}
+		sqlPreferenceStore.deleteUnusedFeatures()
 
 		//make sure features with value "false" have actually been deleted from the database
-		if val, err := ss.Preference().(*SqlPreferenceStore).GetReplica().SelectInt(`SELECT COUNT(*)
+		if val, err := sqlPreferenceStore.GetReplica().SelectInt(`SELECT COUNT(*)
                             FROM Preferences
                     WHERE Category = :Category
                     AND Value = :Val
@@ -69,7 +70,7 @@ func TestDeleteUnusedFeatures(t *testing.T) {
 		}
 		//
 		// make sure features with value "true" remain saved
-		if val, err := ss.Preference().(*SqlPreferenceStore).GetReplica().SelectInt(`SELECT COUNT(*)
+		if val, err := sqlPreferenceStore.GetReplica().SelectInt(`SELECT COUNT(*)
                             FROM Preferences
                     WHERE Category = :Category
                     AND Value = :Val
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+const PREPARED_STATEMENT_CACHE_SIZE = 256
+
+type preparedStatementKey struct {
+	db    *sql.DB
+	query string
+}
+
+type preparedStatement struct {
+	key     preparedStatementKey
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+// preparedStatementCache keeps the most recently used prepared statements. Statements are
+// keyed by the database they were prepared on, so replacing the database of a connection
+// never reuses a stale statement, while database/sql transparently prepares them again on
+// the new connections of a pool.
+type preparedStatementCache struct {
+	mut     sync.Mutex
+	size    int
+	entries map[preparedStatementKey]*list.Element
+	order   *list.List
+}
+
+func newPreparedStatementCache(size int) *preparedStatementCache {
+	return &preparedStatementCache{
+		size:    size,
+		entries: make(map[preparedStatementKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// acquire returns the statement prepared for the query, preparing it if needed. The
+// statement must be released once the caller is done with it.
+func (c *preparedStatementCache) acquire(db *sql.DB, query string) (*preparedStatement, error) {
+	key := preparedStatementKey{db: db, query: query}
+
+	c.mut.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*preparedStatement)
+		entry.refs++
+		c.mut.Unlock()
+		return entry, nil
+	}
+	c.mut.Unlock()
+
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	// Another caller may have prepared the same query in the meantime.
+	if elem, ok := c.entries[key]; ok {
+		stmt.Close()
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*preparedStatement)
+		entry.refs++
+		return entry, nil
+	}
+
+	entry := &preparedStatement{key: key, stmt: stmt, refs: 1}
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		c.evict(c.order.Back())
+	}
+
+	return entry, nil
+}
+
+func (c *preparedStatementCache) release(entry *preparedStatement) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// evict removes the entry from the cache, closing its statement unless it's still in use.
+func (c *preparedStatementCache) evict(elem *list.Element) {
+	entry := c.order.Remove(elem).(*preparedStatement)
+	delete(c.entries, entry.key)
+
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// clear evicts every statement, e.g. before closing the databases.
+func (c *preparedStatementCache) clear() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back())
+	}
+}
+
+func (ss *SqlSupplier) preparedStatementsEnabled() bool {
+	return ss.settings.EnablePreparedStatements != nil && *ss.settings.EnablePreparedStatements
+}
+
+// PreparedExec executes the query on the master database through a cached prepared
+// statement when SqlSettings.EnablePreparedStatements is set. The query must use the
+// placeholders of the driver, as built by the query builder, rather than named parameters.
+func (ss *SqlSupplier) PreparedExec(query string, args ...interface{}) (sql.Result, error) {
+	master := ss.GetMaster()
+	if !ss.preparedStatementsEnabled() {
+		return master.Exec(query, args...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), master.QueryTimeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() { ss.queryObserver.observe(query, formatQueryArgs(args), time.Since(start)) }()
+
+	entry, err := ss.preparedStatements.acquire(master.Db, query)
+	if err != nil {
+		mlog.Debug("Failed to prepare statement, executing it directly.", mlog.Err(err))
+		return master.Db.ExecContext(ctx, query, args...)
+	}
+	defer ss.preparedStatements.release(entry)
+
+	return entry.stmt.ExecContext(ctx, args...)
+}
+
+// PreparedQuery runs the query on a replica through a cached prepared statement when
+// SqlSettings.EnablePreparedStatements is set, appending the rows to holder, a pointer to
+// a slice of struct pointers, like db.Select. See PreparedExec.
+func (ss *SqlSupplier) PreparedQuery(holder interface{}, query string, args ...interface{}) error {
+	replica := ss.GetReplica()
+	if !ss.preparedStatementsEnabled() {
+		return selectContext(context.Background(), replica, holder, query, args...)
+	}
+
+	sliceValue, err := selectHolderSlice(holder)
+	if err != nil {
+		return err
+	}
+
+	// The rows are read before returning, so that the timeout also covers scanning them.
+	ctx, cancel := context.WithTimeout(context.Background(), replica.QueryTimeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() { ss.queryObserver.observe(query, formatQueryArgs(args), time.Since(start)) }()
+
+	var rows *sql.Rows
+	if entry, prepareErr := ss.preparedStatements.acquire(replica.Db, query); prepareErr != nil {
+		mlog.Debug("Failed to prepare statement, executing it directly.", mlog.Err(prepareErr))
+		rows, err = replica.Db.QueryContext(ctx, query, args...)
+	} else {
+		defer ss.preparedStatements.release(entry)
+		rows, err = entry.stmt.QueryContext(ctx, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRows(replica, rows, sliceValue)
+}
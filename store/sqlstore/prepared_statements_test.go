@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestPreparedStatements(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+	settings.EnablePreparedStatements = model.NewBool(true)
+
+	supplier, err := NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	t.Run("should reuse cached statements", func(t *testing.T) {
+		for _, name := range []string{"TestPrepared1", "TestPrepared2"} {
+			_, err := supplier.PreparedExec("INSERT INTO Systems (Name, Value) VALUES (?, ?)", name, "1")
+			require.Nil(t, err)
+		}
+		assert.Len(t, supplier.preparedStatements.entries, 1)
+
+		var systems []*model.System
+		err := supplier.PreparedQuery(&systems, "SELECT Name, Value FROM Systems WHERE Name LIKE ? ORDER BY Name", "TestPrepared%")
+		require.Nil(t, err)
+		require.Len(t, systems, 2)
+		assert.Equal(t, "TestPrepared1", systems[0].Name)
+		assert.Equal(t, "TestPrepared2", systems[1].Name)
+		assert.Len(t, supplier.preparedStatements.entries, 2)
+	})
+
+	t.Run("should fall back to executing invalid statements directly", func(t *testing.T) {
+		_, err := supplier.PreparedExec("INSERT INTO UnknownTable (Name) VALUES (?)", "name")
+		assert.NotNil(t, err)
+		assert.Len(t, supplier.preparedStatements.entries, 2)
+	})
+
+	t.Run("should time out queries running longer than the query timeout", func(t *testing.T) {
+		replica := supplier.GetReplica()
+		queryTimeout := replica.QueryTimeout
+		replica.QueryTimeout = time.Nanosecond
+		defer func() { replica.QueryTimeout = queryTimeout }()
+
+		var systems []*model.System
+		err := supplier.PreparedQuery(&systems, "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT x AS Name FROM c")
+		assert.True(t, isQueryTimeout(err), err)
+	})
+
+	t.Run("should drop the cached statements when recycling the connections", func(t *testing.T) {
+		require.NotEmpty(t, supplier.preparedStatements.entries)
+		supplier.RecycleDBConnections(0)
+		assert.Empty(t, supplier.preparedStatements.entries)
+	})
+}
+
+func TestPreparedStatementCacheEviction(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	db := supplier.GetMaster().Db
+	cache := newPreparedStatementCache(1)
+
+	first, err := cache.acquire(db, "SELECT 1")
+	require.Nil(t, err)
+
+	second, err := cache.acquire(db, "SELECT 2")
+	require.Nil(t, err)
+	assert.Len(t, cache.entries, 1)
+
+	// The evicted statement is only closed once released.
+	assert.True(t, first.evicted)
+	var value int
+	require.Nil(t, first.stmt.QueryRow().Scan(&value))
+	assert.Equal(t, 1, value)
+	cache.release(first)
+	assert.NotNil(t, first.stmt.QueryRow().Scan(&value))
+
+	cache.release(second)
+	cache.clear()
+	assert.Empty(t, cache.entries)
+	assert.NotNil(t, second.stmt.QueryRow().Scan(&value))
+}
@@ -7,7 +7,6 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
-	"time"
 
 	"github.com/pkg/errors"
 )
@@ -74,7 +73,7 @@ func (s SqlProductNoticesStore) View(userId string, notices []string) error {
 		return errors.Wrapf(err, "failed to get ProductNoticeViewState with userId=%s", userId)
 	}
 
-	now := time.Now().UTC().Unix()
+	now := s.getMillis() / 1000
 
 	// update existing records
 	for i := range noticeStates {
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+const queryTracePrefix = "sql-trace:"
+
+// QueryMetricsCollector records the duration of every query executed by the store,
+// labeled by the store method issuing it, e.g. PostStore.GetPostsAfter.
+type QueryMetricsCollector interface {
+	ObserveStoreQueryDuration(method string, elapsed float64)
+}
+
+var sqlstorePackage = reflect.TypeOf(SqlSupplier{}).PkgPath() + "."
+
+// queryObserver is notified by gorp once each query completes. It records the query
+// duration, logs slow queries and, when tracing is enabled, logs every query. A span is
+// also reported for every query while a tracer is set, and the statements of the store
+// methods matching statementPrefix are logged.
+type queryObserver struct {
+	mut       sync.RWMutex
+	collector QueryMetricsCollector
+	tracer    opentracing.Tracer
+	threshold time.Duration
+	trace     bool
+
+	statementPrefix string
+	redactArgs      bool
+
+	// context returns the context of the store, holding the span of the store method.
+	context func() context.Context
+}
+
+func newQueryObserver(settings *model.SqlSettings, collector QueryMetricsCollector) *queryObserver {
+	threshold := model.SQL_SETTINGS_DEFAULT_SLOW_QUERY_THRESHOLD_MILLISECONDS
+	if settings.SlowQueryThresholdMilliseconds != nil {
+		threshold = *settings.SlowQueryThresholdMilliseconds
+	}
+
+	return &queryObserver{
+		collector: collector,
+		threshold: time.Duration(threshold) * time.Millisecond,
+		trace:     settings.Trace != nil && *settings.Trace,
+	}
+}
+
+func (o *queryObserver) setCollector(collector QueryMetricsCollector) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	o.collector = collector
+}
+
+func (o *queryObserver) setTracer(tracer opentracing.Tracer) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	o.tracer = tracer
+}
+
+func (o *queryObserver) setStatementPrefix(prefix string) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	o.statementPrefix = prefix
+}
+
+func (o *queryObserver) setRedactArgs(redact bool) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	o.redactArgs = redact
+}
+
+// Printf implements gorp.GorpLogger. gorp traces each query with its prefix, the query,
+// the arguments and the elapsed time, in that order.
+func (o *queryObserver) Printf(format string, v ...interface{}) {
+	if o.trace {
+		(&TraceOnAdapter{}).Printf(format, v...)
+	}
+
+	if len(v) != 4 {
+		return
+	}
+	query, _ := v[1].(string)
+	args, _ := v[2].(string)
+	elapsed, ok := v[3].(time.Duration)
+	if !ok {
+		return
+	}
+
+	o.observe(query, func() string { return args }, elapsed)
+}
+
+// observe records the duration of a query, logging it when slow. The arguments of the query
+// are only formatted when the statement is logged.
+func (o *queryObserver) observe(query string, args func() string, elapsed time.Duration) {
+	o.mut.RLock()
+	collector, tracer := o.collector, o.tracer
+	statementPrefix, redactArgs := o.statementPrefix, o.redactArgs
+	o.mut.RUnlock()
+
+	slow := o.threshold > 0 && elapsed >= o.threshold
+	if collector == nil && tracer == nil && !slow && statementPrefix == "" {
+		return
+	}
+
+	method := queryCaller()
+	if collector != nil {
+		collector.ObserveStoreQueryDuration(method, elapsed.Seconds())
+	}
+	if tracer != nil {
+		o.traceQuery(tracer, method, query, elapsed)
+	}
+	if slow {
+		mlog.Warn("Slow SQL query", mlog.String("method", method), mlog.Duration("elapsed", elapsed), mlog.String("query", query))
+	}
+	if statementPrefix != "" && strings.HasPrefix(method, statementPrefix) {
+		logStatement(method, query, args(), elapsed, redactArgs)
+	}
+}
+
+// formatQueryArgs formats the arguments of a query the way gorp does when tracing it.
+func formatQueryArgs(args []interface{}) func() string {
+	return func() string {
+		formatted := make([]string, 0, len(args))
+		for i, arg := range args {
+			if valuer, ok := arg.(driver.Valuer); ok {
+				if value, err := valuer.Value(); err == nil {
+					arg = value
+				}
+			}
+			if str, ok := arg.(string); ok {
+				formatted = append(formatted, fmt.Sprintf("%d:%q", i+1, str))
+			} else {
+				formatted = append(formatted, fmt.Sprintf("%d:%v", i+1, arg))
+			}
+		}
+		return strings.Join(formatted, " ")
+	}
+}
+
+// logStatement logs a statement along with its arguments. When redacted, the arguments are left
+// out and the literals inlined in the statement are replaced with placeholders.
+func logStatement(method, query, args string, elapsed time.Duration, redactArgs bool) {
+	fields := []mlog.Field{mlog.String("method", method), mlog.Duration("elapsed", elapsed)}
+	if redactArgs {
+		fields = append(fields, mlog.String("query", sanitizeStatement(query)))
+	} else {
+		fields = append(fields, mlog.String("query", strings.Join(strings.Fields(query), " ")), mlog.String("args", args))
+	}
+	mlog.Debug("SQL statement", fields...)
+}
+
+// queryCaller returns the outermost store method found on the stack, falling back to the
+// innermost unexported one for queries issued by the supplier itself.
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	method := ""
+	for {
+		frame, more := frames.Next()
+		if name, ok := storeMethodName(frame.Function); ok {
+			exported := unicode.IsUpper([]rune(name[strings.Index(name, ".")+1:])[0])
+			if exported || method == "" {
+				method = name
+			}
+		} else if method != "" {
+			break
+		}
+		if !more {
+			break
+		}
+	}
+
+	if method == "" {
+		return "unknown"
+	}
+	return method
+}
+
+// storeMethodName turns a function name such as
+// github.com/mattermost/mattermost-server/v5/store/sqlstore.(*SqlPostStore).GetPostsAfter.func1
+// into PostStore.GetPostsAfter.
+func storeMethodName(function string) (string, bool) {
+	if !strings.HasPrefix(function, sqlstorePackage) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(function, sqlstorePackage)
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "Sql") || parts[1] == "" {
+		return "", false
+	}
+
+	return strings.TrimPrefix(parts[0], "Sql") + "." + parts[1], true
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+func TestStoreMethodName(t *testing.T) {
+	storeMethodNameTests := []struct {
+		Name     string
+		Function string
+		Expected string
+		Ok       bool
+	}{
+		{
+			Name:     "Should name pointer receiver methods",
+			Function: sqlstorePackage + "(*SqlPostStore).GetPostsAfter",
+			Expected: "PostStore.GetPostsAfter",
+			Ok:       true,
+		},
+		{
+			Name:     "Should name value receiver methods",
+			Function: sqlstorePackage + "SqlTermsOfServiceStore.Get",
+			Expected: "TermsOfServiceStore.Get",
+			Ok:       true,
+		},
+		{
+			Name:     "Should name closures after their method",
+			Function: sqlstorePackage + "(*SqlPostStore).GetPosts.func1",
+			Expected: "PostStore.GetPosts",
+			Ok:       true,
+		},
+		{
+			Name:     "Should ignore functions",
+			Function: sqlstorePackage + "setupConnection",
+		},
+		{
+			Name:     "Should ignore other packages",
+			Function: "github.com/mattermost/gorp.(*DbMap).Exec",
+		},
+	}
+
+	for _, tc := range storeMethodNameTests {
+		t.Run(tc.Name, func(t *testing.T) {
+			name, ok := storeMethodName(tc.Function)
+			assert.Equal(t, tc.Ok, ok)
+			assert.Equal(t, tc.Expected, name)
+		})
+	}
+}
+
+// SqlStatementTestStore issues the queries of the store methods named StatementTestStore.*.
+type SqlStatementTestStore struct {
+	observer *queryObserver
+}
+
+func (s *SqlStatementTestStore) GetMember(query, args string) {
+	s.observer.Printf("%s%s [%s] (%v)", "[gorp]", query, args, time.Millisecond)
+}
+
+func (s *SqlStatementTestStore) Save(query, args string) {
+	s.observer.Printf("%s%s [%s] (%v)", "[gorp]", query, args, time.Millisecond)
+}
+
+func TestTraceStatements(t *testing.T) {
+	logger, capture := mlog.NewCapturingLogger(mlog.LevelDebug)
+	mlog.InitGlobalLogger(logger)
+	defer mlog.DisableZap()
+
+	observer := &queryObserver{}
+	s := &SqlStatementTestStore{observer: observer}
+	query := "SELECT * FROM ChannelMembers\n\tWHERE ChannelId = :ChannelId AND Roles = 'admin'"
+
+	t.Run("should not log the statements by default", func(t *testing.T) {
+		capture.Reset()
+		s.GetMember(query, `1:"channel1"`)
+		assert.Empty(t, capture.Entries())
+	})
+
+	t.Run("should log the statements of the matching methods", func(t *testing.T) {
+		capture.Reset()
+		observer.setStatementPrefix("StatementTestStore.Get")
+		s.GetMember(query, `1:"channel1"`)
+		s.Save(query, `1:"channel2"`)
+
+		entries := capture.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "SQL statement", entries[0].Message)
+		assert.Equal(t, "debug", entries[0].Level)
+		assert.Equal(t, "StatementTestStore.GetMember", entries[0].Fields["method"])
+		assert.Equal(t, "SELECT * FROM ChannelMembers WHERE ChannelId = :ChannelId AND Roles = 'admin'", entries[0].Fields["query"])
+		assert.Equal(t, `1:"channel1"`, entries[0].Fields["args"])
+	})
+
+	t.Run("should redact the arguments", func(t *testing.T) {
+		capture.Reset()
+		observer.setRedactArgs(true)
+		defer observer.setRedactArgs(false)
+		s.GetMember(query, `1:"channel1"`)
+
+		entries := capture.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "SELECT * FROM ChannelMembers WHERE ChannelId = :ChannelId AND Roles = ?", entries[0].Fields["query"])
+		assert.NotContains(t, entries[0].Fields, "args")
+	})
+
+	t.Run("should stop logging with an empty prefix", func(t *testing.T) {
+		capture.Reset()
+		observer.setStatementPrefix("")
+		s.GetMember(query, `1:"channel1"`)
+		assert.Empty(t, capture.Entries())
+	})
+}
+
+func TestFormatQueryArgs(t *testing.T) {
+	assert.Equal(t, `1:"a" 2:3 3:true`, formatQueryArgs([]interface{}{"a", 3, true})())
+	assert.Equal(t, "", formatQueryArgs(nil)())
+}
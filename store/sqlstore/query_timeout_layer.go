@@ -0,0 +1,2711 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package sqlstore
+
+import (
+	"context"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// withQueryTimeouts wraps the stores for their methods to return the errors of the queries
+// that timed out as ErrQueryTimeout, see MapQueryTimeout.
+func (s SqlSupplierStores) withQueryTimeouts() SqlSupplierStores {
+	return SqlSupplierStores{
+		audit:                &queryTimeoutAuditStore{AuditStore: s.audit},
+		auditTrail:           &queryTimeoutAuditTrailStore{AuditTrailStore: s.auditTrail},
+		bot:                  &queryTimeoutBotStore{BotStore: s.bot},
+		channel:              &queryTimeoutChannelStore{ChannelStore: s.channel},
+		channelMemberHistory: &queryTimeoutChannelMemberHistoryStore{ChannelMemberHistoryStore: s.channelMemberHistory},
+		clusterDiscovery:     &queryTimeoutClusterDiscoveryStore{ClusterDiscoveryStore: s.clusterDiscovery},
+		command:              &queryTimeoutCommandStore{CommandStore: s.command},
+		commandWebhook:       &queryTimeoutCommandWebhookStore{CommandWebhookStore: s.commandWebhook},
+		compliance:           &queryTimeoutComplianceStore{ComplianceStore: s.compliance},
+		emoji:                &queryTimeoutEmojiStore{EmojiStore: s.emoji},
+		fileInfo:             &queryTimeoutFileInfoStore{FileInfoStore: s.fileInfo},
+		group:                &queryTimeoutGroupStore{GroupStore: s.group},
+		job:                  &queryTimeoutJobStore{JobStore: s.job},
+		license:              &queryTimeoutLicenseStore{LicenseStore: s.license},
+		linkMetadata:         &queryTimeoutLinkMetadataStore{LinkMetadataStore: s.linkMetadata},
+		oAuth:                &queryTimeoutOAuthStore{OAuthStore: s.oAuth},
+		plugin:               &queryTimeoutPluginStore{PluginStore: s.plugin},
+		post:                 &queryTimeoutPostStore{PostStore: s.post},
+		preference:           &queryTimeoutPreferenceStore{PreferenceStore: s.preference},
+		productNotices:       &queryTimeoutProductNoticesStore{ProductNoticesStore: s.productNotices},
+		reaction:             &queryTimeoutReactionStore{ReactionStore: s.reaction},
+		role:                 &queryTimeoutRoleStore{RoleStore: s.role},
+		scheme:               &queryTimeoutSchemeStore{SchemeStore: s.scheme},
+		session:              &queryTimeoutSessionStore{SessionStore: s.session},
+		status:               &queryTimeoutStatusStore{StatusStore: s.status},
+		system:               &queryTimeoutSystemStore{SystemStore: s.system},
+		team:                 &queryTimeoutTeamStore{TeamStore: s.team},
+		termsOfService:       &queryTimeoutTermsOfServiceStore{TermsOfServiceStore: s.termsOfService},
+		thread:               &queryTimeoutThreadStore{ThreadStore: s.thread},
+		token:                &queryTimeoutTokenStore{TokenStore: s.token},
+		uploadSession:        &queryTimeoutUploadSessionStore{UploadSessionStore: s.uploadSession},
+		user:                 &queryTimeoutUserStore{UserStore: s.user},
+		userAccessToken:      &queryTimeoutUserAccessTokenStore{UserAccessTokenStore: s.userAccessToken},
+		userTermsOfService:   &queryTimeoutUserTermsOfServiceStore{UserTermsOfServiceStore: s.userTermsOfService},
+		webhook:              &queryTimeoutWebhookStore{WebhookStore: s.webhook},
+	}
+}
+
+type queryTimeoutAuditStore struct {
+	store.AuditStore
+}
+
+type queryTimeoutAuditTrailStore struct {
+	store.AuditTrailStore
+}
+
+type queryTimeoutBotStore struct {
+	store.BotStore
+}
+
+type queryTimeoutChannelStore struct {
+	store.ChannelStore
+}
+
+type queryTimeoutChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+}
+
+type queryTimeoutClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+}
+
+type queryTimeoutCommandStore struct {
+	store.CommandStore
+}
+
+type queryTimeoutCommandWebhookStore struct {
+	store.CommandWebhookStore
+}
+
+type queryTimeoutComplianceStore struct {
+	store.ComplianceStore
+}
+
+type queryTimeoutEmojiStore struct {
+	store.EmojiStore
+}
+
+type queryTimeoutFileInfoStore struct {
+	store.FileInfoStore
+}
+
+type queryTimeoutGroupStore struct {
+	store.GroupStore
+}
+
+type queryTimeoutJobStore struct {
+	store.JobStore
+}
+
+type queryTimeoutLicenseStore struct {
+	store.LicenseStore
+}
+
+type queryTimeoutLinkMetadataStore struct {
+	store.LinkMetadataStore
+}
+
+type queryTimeoutOAuthStore struct {
+	store.OAuthStore
+}
+
+type queryTimeoutPluginStore struct {
+	store.PluginStore
+}
+
+type queryTimeoutPostStore struct {
+	store.PostStore
+}
+
+type queryTimeoutPreferenceStore struct {
+	store.PreferenceStore
+}
+
+type queryTimeoutProductNoticesStore struct {
+	store.ProductNoticesStore
+}
+
+type queryTimeoutReactionStore struct {
+	store.ReactionStore
+}
+
+type queryTimeoutRoleStore struct {
+	store.RoleStore
+}
+
+type queryTimeoutSchemeStore struct {
+	store.SchemeStore
+}
+
+type queryTimeoutSessionStore struct {
+	store.SessionStore
+}
+
+type queryTimeoutStatusStore struct {
+	store.StatusStore
+}
+
+type queryTimeoutSystemStore struct {
+	store.SystemStore
+}
+
+type queryTimeoutTeamStore struct {
+	store.TeamStore
+}
+
+type queryTimeoutTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+}
+
+type queryTimeoutThreadStore struct {
+	store.ThreadStore
+}
+
+type queryTimeoutTokenStore struct {
+	store.TokenStore
+}
+
+type queryTimeoutUploadSessionStore struct {
+	store.UploadSessionStore
+}
+
+type queryTimeoutUserStore struct {
+	store.UserStore
+}
+
+type queryTimeoutUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+}
+
+type queryTimeoutUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+}
+
+type queryTimeoutWebhookStore struct {
+	store.WebhookStore
+}
+
+func (s *queryTimeoutAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
+	result, err := s.AuditStore.Get(user_id, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutAuditStore) PermanentDeleteByUser(userId string) error {
+	err := s.AuditStore.PermanentDeleteByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutAuditStore) Save(audit *model.Audit) error {
+	err := s.AuditStore.Save(audit)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+	result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	err := s.AuditTrailStore.Record(entry, change)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
+	result, err := s.BotStore.Get(userId, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+	result, err := s.BotStore.GetAll(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutBotStore) PermanentDelete(userId string) error {
+	err := s.BotStore.PermanentDelete(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+	result, err := s.BotStore.Save(bot)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+	result, err := s.BotStore.Update(bot)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, error) {
+	result, err := s.ChannelStore.AnalyticsDeletedTypeCount(teamId, channelType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, error) {
+	result, err := s.ChannelStore.AnalyticsTypeCount(teamId, channelType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.AutocompleteInTeam(teamId, term, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) ClearAllCustomRoleAssignments() error {
+	err := s.ChannelStore.ClearAllCustomRoleAssignments()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+	err := s.ChannelStore.ClearSidebarOnTeamLeave(userId, teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) CountPostsAfter(channelId string, timestamp int64, userId string) (int, error) {
+	result, err := s.ChannelStore.CountPostsAfter(channelId, timestamp, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+	result, err := s.ChannelStore.CreateDirectChannel(userId, otherUserId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+	err := s.ChannelStore.CreateInitialSidebarCategories(userId, teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.CreateSidebarCategory(userId, teamId, newCategory)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) Delete(channelId string, time int64) error {
+	err := s.ChannelStore.Delete(channelId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) DeleteSidebarCategory(categoryId string) error {
+	err := s.ChannelStore.DeleteSidebarCategory(categoryId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	err := s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.Get(id, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAll(teamId string) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetAll(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+	result, err := s.ChannelStore.GetAllChannelMembersForUser(userId, allowFromCache, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, error) {
+	result, err := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, error) {
+	result, err := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+	result, err := s.ChannelStore.GetAllChannelsCount(opts)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, error) {
+	result, err := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, error) {
+	result, err := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetByName(team_id, name, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetByNameIncludeDeleted(team_id, name, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetByNames(team_id, names, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
+	result, err := s.ChannelStore.GetChannelCounts(teamId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, error) {
+	result, err := s.ChannelStore.GetChannelMembersForExport(userId, teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
+	result, err := s.ChannelStore.GetChannelMembersTimezones(channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, error) {
+	result, err := s.ChannelStore.GetChannelUnread(channelId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannels(teamId, userId, includeDeleted, lastDeleteAt)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannelsBatchForIndexing(startTime, endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+	result, err := s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannelsByScheme(schemeId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetDeleted(team_id, offset, limit, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetDeletedByName(team_id, name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetForPost(postId string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetForPost(postId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetFromMaster(id string) (*model.Channel, error) {
+	result, err := s.ChannelStore.GetFromMaster(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetGuestCount(channelId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.GetMember(channelId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetMemberCount(channelId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMemberCountsByGroup(channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+	result, err := s.ChannelStore.GetMemberCountsByGroup(channelID, includeTimezones)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.GetMemberForPost(postId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembers(channelId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersByIds(channelId, userIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersForUser(teamId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, error) {
+	result, err := s.ChannelStore.GetMembersForUserWithPagination(teamId, userId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+	result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	result, err := s.ChannelStore.GetMembersSince(channelId, since)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPrivateChannelsForTeam(teamId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamId, channelIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetPublicChannelsForTeam(teamId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, error) {
+	result, err := s.ChannelStore.GetSidebarCategories(userId, teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetSidebarCategory(categoryId string) (*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.GetSidebarCategory(categoryId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetSidebarCategoryOrder(userId string, teamId string) ([]string, error) {
+	result, err := s.ChannelStore.GetSidebarCategoryOrder(userId, teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.GetTeamChannels(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) GroupSyncedChannelCount() (int64, error) {
+	result, err := s.ChannelStore.GroupSyncedChannelCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+	err := s.ChannelStore.IncrementMentionCount(channelId, userId, updateThreads)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+	result, err := s.ChannelStore.MigrateChannelMembers(fromChannelId, fromUserId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) MigratePublicChannels() error {
+	err := s.ChannelStore.MigratePublicChannels()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) PermanentDelete(channelId string) error {
+	err := s.ChannelStore.PermanentDelete(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) PermanentDeleteByTeam(teamId string) error {
+	err := s.ChannelStore.PermanentDeleteByTeam(teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+	err := s.ChannelStore.PermanentDeleteMembersByChannel(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) PermanentDeleteMembersByUser(userId string) error {
+	err := s.ChannelStore.PermanentDeleteMembersByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+	err := s.ChannelStore.RemoveAllDeactivatedMembers(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) RemoveMember(channelId string, userId string) error {
+	err := s.ChannelStore.RemoveMember(channelId, userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) RemoveMembers(channelId string, userIds []string) error {
+	err := s.ChannelStore.RemoveMembers(channelId, userIds)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) ResetAllChannelSchemes() error {
+	err := s.ChannelStore.ResetAllChannelSchemes()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) Restore(channelId string, time int64) error {
+	err := s.ChannelStore.Restore(channelId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	result, err := s.ChannelStore.Save(channel, maxChannelsPerTeam)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	result, err := s.ChannelStore.SaveDirectChannel(channel, member1, member2)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.SaveMember(member)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	result, err := s.ChannelStore.SaveMultipleMembers(members)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, error) {
+	result, resultVar1, err := s.ChannelStore.SearchAllChannels(term, opts)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchArchivedInTeam(teamId string, term string, userId string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchArchivedInTeam(teamId, term, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchForUserInTeam(userId, teamId, term, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchGroupChannels(userId, term)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchInTeam(teamId, term, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, error) {
+	result, err := s.ChannelStore.SearchMore(userId, teamId, term)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+	err := s.ChannelStore.SetDeleteAt(channelId, deleteAt, updateAt)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	result, err := s.ChannelStore.Update(channel)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAt(channelIds, userId, updateThreads)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+	result, err := s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, updateThreads)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	result, err := s.ChannelStore.UpdateMember(member)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+	err := s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	result, err := s.ChannelStore.UpdateMultipleMembers(members)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+	result, err := s.ChannelStore.UpdateSidebarCategories(userId, teamId, categories)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+	err := s.ChannelStore.UpdateSidebarCategoryOrder(userId, teamId, categoryOrder)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+	err := s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+	err := s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, error) {
+	result, err := s.ChannelStore.UserBelongsToChannels(userId, channelIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
+	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+	err := s.ChannelMemberHistoryStore.LogJoinEvent(userId, channelId, joinTime)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userId, channelId, leaveTime)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) Cleanup() error {
+	err := s.ClusterDiscoveryStore.Cleanup()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+	err := s.ClusterDiscoveryStore.Save(discovery)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+	result, err := s.CommandStore.AnalyticsCommandCount(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) Delete(commandId string, time int64) error {
+	err := s.CommandStore.Delete(commandId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) Get(id string) (*model.Command, error) {
+	result, err := s.CommandStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) GetByTeam(teamId string) ([]*model.Command, error) {
+	result, err := s.CommandStore.GetByTeam(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, error) {
+	result, err := s.CommandStore.GetByTrigger(teamId, trigger)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) PermanentDeleteByTeam(teamId string) error {
+	err := s.CommandStore.PermanentDeleteByTeam(teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) PermanentDeleteByUser(userId string) error {
+	err := s.CommandStore.PermanentDeleteByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+	result, err := s.CommandStore.Save(webhook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandStore) Update(hook *model.Command) (*model.Command, error) {
+	result, err := s.CommandStore.Update(hook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+	result, err := s.CommandWebhookStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+	result, err := s.CommandWebhookStore.Save(webhook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutCommandWebhookStore) TryUse(id string, limit int) error {
+	err := s.CommandWebhookStore.TryUse(id, limit)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, error) {
+	result, err := s.ComplianceStore.ComplianceExport(compliance)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) Get(id string) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+	result, err := s.ComplianceStore.GetAll(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, error) {
+	result, err := s.ComplianceStore.MessageExport(after, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Save(compliance)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+	result, err := s.ComplianceStore.Update(compliance)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+	err := s.EmojiStore.Delete(emoji, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+	result, err := s.EmojiStore.Get(id, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+	result, err := s.EmojiStore.GetByName(name, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.GetMultipleByName(names)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	result, err := s.EmojiStore.Save(emoji)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+	err := s.FileInfoStore.AttachToPost(fileId, postId, creatorId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) DeleteForPost(postId string) (string, error) {
+	result, err := s.FileInfoStore.DeleteForPost(postId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) Get(id string) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetByIds(ids)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetByPath(path)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetForPost(postId, readFromMaster, includeDeleted, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetForUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) PermanentDelete(fileId string) error {
+	err := s.FileInfoStore.PermanentDelete(fileId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteBatch(endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+	result, err := s.FileInfoStore.PermanentDeleteByUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Save(info)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) SetContent(fileId string, content string) error {
+	err := s.FileInfoStore.SetContent(fileId, content)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+	result, err := s.FileInfoStore.Upsert(info)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	result, err := s.JobStore.ClaimPending(jobType, nodeId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) Delete(id string) (string, error) {
+	result, err := s.JobStore.Delete(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) Get(id string) (*model.Job, error) {
+	result, err := s.JobStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByStatus(status)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByType(jobType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllByTypePage(jobType, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+	result, err := s.JobStore.GetAllPage(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) Save(job *model.Job) (*model.Job, error) {
+	result, err := s.JobStore.Save(job)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+	result, err := s.JobStore.UpdateStatus(id, status)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+	result, err := s.LicenseStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+	result, err := s.LicenseStore.Save(license)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+	result, err := s.LinkMetadataStore.Get(url, timestamp)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+	result, err := s.LinkMetadataStore.Save(linkMetadata)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) DeleteApp(id string) error {
+	err := s.OAuthStore.DeleteApp(id)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessData(token)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
+	result, err := s.OAuthStore.GetAccessDataByUserForApp(userId, clientId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetApp(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetAppByUser(userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetApps(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+	result, err := s.OAuthStore.GetAuthData(code)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+	result, err := s.OAuthStore.GetAuthorizedApps(userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
+	result, err := s.OAuthStore.GetPreviousAccessData(userId, clientId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) RemoveAccessData(token string) error {
+	err := s.OAuthStore.RemoveAccessData(token)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) RemoveAllAccessData() error {
+	err := s.OAuthStore.RemoveAllAccessData()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) RemoveAuthData(code string) error {
+	err := s.OAuthStore.RemoveAuthData(code)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	result, err := s.OAuthStore.SaveAccessData(accessData)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.SaveApp(app)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	result, err := s.OAuthStore.SaveAuthData(authData)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	result, err := s.OAuthStore.UpdateApp(app)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) Delete(pluginId string, key string) error {
+	err := s.PluginStore.Delete(pluginId, key)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) DeleteAllExpired() error {
+	err := s.PluginStore.DeleteAllExpired()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) DeleteAllForPlugin(PluginId string) error {
+	err := s.PluginStore.DeleteAllForPlugin(PluginId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+	result, err := s.PluginStore.Get(pluginId, key)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
+	result, err := s.PluginStore.List(pluginId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	result, err := s.PluginStore.SetWithOptions(pluginId, key, value, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+	result, err := s.PostStore.AnalyticsPostCount(teamId, mustHaveFile, mustHaveHashtag)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Delete(postId string, time int64, deleteByID string) error {
+	err := s.PostStore.Delete(postId, time, deleteByID)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+	result, err := s.PostStore.Get(id, skipFetchThreads)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPosts(userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userId, channelId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userId, teamId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetOldest() (*model.Post, error) {
+	result, err := s.PostStore.GetOldest()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetOldestEntityCreationTime() (int64, error) {
+	result, err := s.PostStore.GetOldestEntityCreationTime()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+	result, err := s.PostStore.GetPinnedPosts(channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+	result, err := s.PostStore.GetPost(id, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+	result, err := s.PostStore.GetPostAfterTime(channelId, time)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+	result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+	result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostWithContext(postId, before, after)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPosts(options, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsAfter(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsBefore(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsByIds(postIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsByProp(channelId, key, value)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+	result, err := s.PostStore.GetPostsCreatedAt(channelId, time)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsSince(options, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+	result, err := s.PostStore.GetRepliesForExport(parentId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetSingle(id string) (*model.Post, error) {
+	result, err := s.PostStore.GetSingle(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	result, err := s.PostStore.GetSingleCtx(ctx, id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Overwrite(post)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) PermanentDeleteByChannel(channelId string) error {
+	err := s.PostStore.PermanentDeleteByChannel(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) PermanentDeleteByUser(userId string) error {
+	err := s.PostStore.PermanentDeleteByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Save(post *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Save(post)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+	result, err := s.PostStore.Search(teamId, userId, params)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchPostsInTeamForUser(paramsList, userId, teamId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	result, err := s.PostStore.Update(newPost, oldPost)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) Delete(userId string, category string, name string) error {
+	err := s.PreferenceStore.Delete(userId, category, name)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) DeleteCategory(userId string, category string) error {
+	err := s.PreferenceStore.DeleteCategory(userId, category)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+	result, err := s.PreferenceStore.Get(userId, category, name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+	result, err := s.PreferenceStore.GetAll(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+	result, err := s.PreferenceStore.GetCategory(userId, category)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) PermanentDeleteByUser(userId string) error {
+	err := s.PreferenceStore.PermanentDeleteByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) Save(preferences *model.Preferences) error {
+	err := s.PreferenceStore.Save(preferences)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutProductNoticesStore) Clear(notices []string) error {
+	err := s.ProductNoticesStore.Clear(notices)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+	result, err := s.ProductNoticesStore.GetViews(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutProductNoticesStore) View(userId string, notices []string) error {
+	err := s.ProductNoticesStore.View(userId, notices)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	result, err := s.ReactionStore.Delete(reaction)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
+	result, err := s.ReactionStore.GetForPost(postId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	result, err := s.ReactionStore.GetForPosts(postIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	result, err := s.ReactionStore.Save(reaction)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) Delete(roleId string) (*model.Role, error) {
+	result, err := s.RoleStore.Delete(roleId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) Get(roleId string) (*model.Role, error) {
+	result, err := s.RoleStore.Get(roleId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) GetAll() ([]*model.Role, error) {
+	result, err := s.RoleStore.GetAll()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) GetByName(name string) (*model.Role, error) {
+	result, err := s.RoleStore.GetByName(name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+	result, err := s.RoleStore.GetByNames(names)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) PermanentDeleteAll() error {
+	err := s.RoleStore.PermanentDeleteAll()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutRoleStore) Save(role *model.Role) (*model.Role, error) {
+	result, err := s.RoleStore.Save(role)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) CountByScope(scope string) (int64, error) {
+	result, err := s.SchemeStore.CountByScope(scope)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Delete(schemeId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) Get(schemeId string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Get(schemeId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+	result, err := s.SchemeStore.GetByName(schemeName)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) PermanentDeleteAll() error {
+	err := s.SchemeStore.PermanentDeleteAll()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	result, err := s.SchemeStore.Save(scheme)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) AnalyticsSessionCount() (int64, error) {
+	result, err := s.SessionStore.AnalyticsSessionCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
+	result, err := s.SessionStore.Get(sessionIdOrToken)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) GetSessions(userId string) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessions(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, error) {
+	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+	err := s.SessionStore.PermanentDeleteSessionsByUser(teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) Remove(sessionIdOrToken string) error {
+	err := s.SessionStore.Remove(sessionIdOrToken)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) RemoveAllSessions() error {
+	err := s.SessionStore.RemoveAllSessions()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) Save(session *model.Session) (*model.Session, error) {
+	result, err := s.SessionStore.Save(session)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+	result, err := s.SessionStore.UpdateDeviceId(id, deviceId, expiresAt)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+	err := s.SessionStore.UpdateExpiresAt(sessionId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+	err := s.SessionStore.UpdateLastActivityAt(sessionId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateProps(session *model.Session) error {
+	err := s.SessionStore.UpdateProps(session)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+	result, err := s.SessionStore.UpdateRoles(userId, roles)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) Get(userId string) (*model.Status, error) {
+	result, err := s.StatusStore.Get(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+	result, err := s.StatusStore.GetByIds(userIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) GetTotalActiveUsersCount() (int64, error) {
+	result, err := s.StatusStore.GetTotalActiveUsersCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) ResetAll() error {
+	err := s.StatusStore.ResetAll()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) SaveOrUpdate(status *model.Status) error {
+	err := s.StatusStore.SaveOrUpdate(status)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+	err := s.StatusStore.UpdateLastActivityAt(userId, lastActivityAt)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) Get() (model.StringMap, error) {
+	result, err := s.SystemStore.Get()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) GetByName(name string) (*model.System, error) {
+	result, err := s.SystemStore.GetByName(name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	result, err := s.SystemStore.InsertIfExists(system)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	result, err := s.SystemStore.PermanentDeleteByName(name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) Save(system *model.System) error {
+	err := s.SystemStore.Save(system)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) SaveOrUpdate(system *model.System) error {
+	err := s.SystemStore.SaveOrUpdate(system)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+	err := s.SystemStore.SaveOrUpdateWithWarnMetricHandling(system)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutSystemStore) Update(system *model.System) error {
+	err := s.SystemStore.Update(system)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, error) {
+	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
+	result, err := s.TeamStore.AnalyticsPrivateTeamCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) AnalyticsPublicTeamCount() (int64, error) {
+	result, err := s.TeamStore.AnalyticsPublicTeamCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) AnalyticsTeamCount(includeDeleted bool) (int64, error) {
+	result, err := s.TeamStore.AnalyticsTeamCount(includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) ClearAllCustomRoleAssignments() error {
+	err := s.TeamStore.ClearAllCustomRoleAssignments()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) Get(id string) (*model.Team, error) {
+	result, err := s.TeamStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	result, err := s.TeamStore.GetActiveMemberCount(teamId, restrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAll() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAll()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, error) {
+	result, err := s.TeamStore.GetAllForExportAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPage(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPrivateTeamListing()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPrivateTeamPageListing(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllPublicTeamPageListing(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllTeamListing()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetAllTeamPageListing(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetByInviteId(inviteId string) (*model.Team, error) {
+	result, err := s.TeamStore.GetByInviteId(inviteId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetByName(name string) (*model.Team, error) {
+	result, err := s.TeamStore.GetByName(name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetByNames(name)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, error) {
+	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, error) {
+	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMember(teamId, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetMembers(teamId string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMembers(teamId, offset, limit, teamMembersGetOptions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetMembersByIds(teamId, userIds, restrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, error) {
+	result, err := s.TeamStore.GetTeamMembersForExport(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetTeamsByScheme(schemeId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
+	result, err := s.TeamStore.GetTeamsByUserId(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamsForUser(ctx context.Context, userId string) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetTeamsForUser(ctx, userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.GetTeamsForUserWithPagination(userId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	result, err := s.TeamStore.GetTotalMemberCount(teamId, restrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, error) {
+	result, err := s.TeamStore.GetUserTeamIds(userId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) GroupSyncedTeamCount() (int64, error) {
+	result, err := s.TeamStore.GroupSyncedTeamCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+	result, err := s.TeamStore.MigrateTeamMembers(fromTeamId, fromUserId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) PermanentDelete(teamId string) error {
+	err := s.TeamStore.PermanentDelete(teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) RemoveAllMembersByTeam(teamId string) error {
+	err := s.TeamStore.RemoveAllMembersByTeam(teamId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) RemoveAllMembersByUser(userId string) error {
+	err := s.TeamStore.RemoveAllMembersByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) RemoveMember(teamId string, userId string) error {
+	err := s.TeamStore.RemoveMember(teamId, userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) RemoveMembers(teamId string, userIds []string) error {
+	err := s.TeamStore.RemoveMembers(teamId, userIds)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) ResetAllTeamSchemes() error {
+	err := s.TeamStore.ResetAllTeamSchemes()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) Save(team *model.Team) (*model.Team, error) {
+	result, err := s.TeamStore.Save(team)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	result, err := s.TeamStore.SaveMember(member, maxUsersPerTeam)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SearchAll(term string, opts *model.TeamSearch) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchAll(term, opts)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SearchAllPaged(term string, opts *model.TeamSearch) ([]*model.Team, int64, error) {
+	result, resultVar1, err := s.TeamStore.SearchAllPaged(term, opts)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SearchOpen(term string) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchOpen(term)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) SearchPrivate(term string) ([]*model.Team, error) {
+	result, err := s.TeamStore.SearchPrivate(term)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) Update(team *model.Team) (*model.Team, error) {
+	result, err := s.TeamStore.Update(team)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+	err := s.TeamStore.UpdateLastTeamIconUpdate(teamId, curTime)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+	result, err := s.TeamStore.UpdateMember(member)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+	err := s.TeamStore.UpdateMembersRole(teamID, userIDs)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+	result, err := s.TeamStore.UpdateMultipleMembers(members)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, error) {
+	result, err := s.TeamStore.UserBelongsToTeams(userId, teamIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+	result, err := s.TermsOfServiceStore.Save(termsOfService)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) CollectThreadsWithNewerReplies(userId string, channelIds []string, timestamp int64) ([]string, error) {
+	result, err := s.ThreadStore.CollectThreadsWithNewerReplies(userId, channelIds, timestamp)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+	err := s.ThreadStore.CreateMembershipIfNeeded(userId, postId, following)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) Delete(postId string) error {
+	err := s.ThreadStore.Delete(postId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+	err := s.ThreadStore.DeleteMembershipForUser(userId, postId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) Get(id string) (*model.Thread, error) {
+	result, err := s.ThreadStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) GetMembershipForUser(userId string, postId string) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.GetMembershipForUser(userId, postId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) GetMembershipsForUser(userId string) ([]*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.GetMembershipsForUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+	result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+	err := s.ThreadStore.MarkAllAsRead(userId, timestamp)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+	err := s.ThreadStore.MarkAsRead(userId, threadId, timestamp)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+	result, err := s.ThreadStore.Save(thread)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.SaveMembership(membership)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+	result, resultVar1, err := s.ThreadStore.SaveMultiple(thread)
+	return result, resultVar1, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+	result, err := s.ThreadStore.Update(thread)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	result, err := s.ThreadStore.UpdateMembership(membership)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+	err := s.ThreadStore.UpdateUnreadsByChannel(userId, changedThreads, timestamp)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTokenStore) Delete(token string) error {
+	err := s.TokenStore.Delete(token)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTokenStore) GetByToken(token string) (*model.Token, error) {
+	result, err := s.TokenStore.GetByToken(token)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTokenStore) RemoveAllTokensByType(tokenType string) error {
+	err := s.TokenStore.RemoveAllTokensByType(tokenType)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutTokenStore) Save(recovery *model.Token) error {
+	err := s.TokenStore.Save(recovery)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUploadSessionStore) Delete(id string) error {
+	err := s.UploadSessionStore.Delete(id)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUploadSessionStore) Get(id string) (*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.GetForUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+	result, err := s.UploadSessionStore.Save(session)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUploadSessionStore) Update(session *model.UploadSession) error {
+	err := s.UploadSessionStore.Update(session)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.AnalyticsActiveCount(time, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+	result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsGetGuestCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetGuestCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+	result, err := s.UserStore.AnalyticsGetSystemAdminCount()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) AutocompleteUsersInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+	result, err := s.UserStore.AutocompleteUsersInChannel(teamId, channelId, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) ClearAllCustomRoleAssignments() error {
+	err := s.UserStore.ClearAllCustomRoleAssignments()
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) Count(options model.UserCountOptions) (int64, error) {
+	result, err := s.UserStore.Count(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) DeactivateGuests() ([]string, error) {
+	result, err := s.UserStore.DeactivateGuests()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) DemoteUserToGuest(userID string) error {
+	err := s.UserStore.DemoteUserToGuest(userID)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) Get(id string) (*model.User, error) {
+	result, err := s.UserStore.Get(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAll() ([]*model.User, error) {
+	result, err := s.UserStore.GetAll()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllAfter(limit, afterId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllNotInAuthService(authServices)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllProfiles(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, error) {
+	result, err := s.UserStore.GetAllProfilesInChannel(channelId, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+	result, err := s.UserStore.GetAllUsingAuthService(authService)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, error) {
+	result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userId, channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+	result, err := s.UserStore.GetByAuth(authData, authService)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetByEmail(email string) (*model.User, error) {
+	result, err := s.UserStore.GetByEmail(email)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetByUsername(username string) (*model.User, error) {
+	result, err := s.UserStore.GetByUsername(username)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+	result, err := s.UserStore.GetChannelGroupUsers(channelID)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+	result, err := s.UserStore.GetForLogin(loginId, allowSignInWithUsername, allowSignInWithEmail)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetKnownUsers(userID string) ([]string, error) {
+	result, err := s.UserStore.GetKnownUsers(userID)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetNewUsersForTeam(teamId, offset, limit, viewRestrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, error) {
+	result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userId, channelIds)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfileByIds(userIds, options, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfiles(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesInChannel(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesInChannelByStatus(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesNotInChannel(teamId, channelId, groupConstrained, offset, limit, viewRestrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesNotInTeam(teamId, groupConstrained, offset, limit, viewRestrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+	result, err := s.UserStore.GetProfilesWithoutTeam(options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamId, offset, limit, viewRestrictions)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+	result, err := s.UserStore.GetSystemAdminProfiles()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+	result, err := s.UserStore.GetTeamGroupUsers(teamID)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetUnreadCount(userId string) (int64, error) {
+	result, err := s.UserStore.GetUnreadCount(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, error) {
+	result, err := s.UserStore.GetUnreadCountForChannel(userId, channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+	result, err := s.UserStore.GetUser(id, includeDeleted)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
+	result, err := s.UserStore.GetUsersBatchForIndexing(startTime, endTime, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) InferSystemInstallDate() (int64, error) {
+	result, err := s.UserStore.InferSystemInstallDate()
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) PermanentDelete(userId string) error {
+	err := s.UserStore.PermanentDelete(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) PromoteGuestToUser(userID string) error {
+	err := s.UserStore.PromoteGuestToUser(userID)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) ResetLastPictureUpdate(userId string) error {
+	err := s.UserStore.ResetLastPictureUpdate(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) Save(user *model.User) (*model.User, error) {
+	result, err := s.UserStore.Save(user)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.Search(teamId, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchInChannel(channelId, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchInGroup(groupID, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchNotInChannel(teamId, channelId, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchNotInTeam(notInTeamId, term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	result, err := s.UserStore.SearchWithoutTeam(term, options)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	result, err := s.UserStore.Update(user, allowRoleUpdate)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	result, err := s.UserStore.UpdateAuthData(userId, service, authData, email, resetMfa)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+	err := s.UserStore.UpdateFailedPasswordAttempts(userId, attempts)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateLastPictureUpdate(userId string) error {
+	err := s.UserStore.UpdateLastPictureUpdate(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateMfaActive(userId string, active bool) error {
+	err := s.UserStore.UpdateMfaActive(userId, active)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateMfaSecret(userId string, secret string) error {
+	err := s.UserStore.UpdateMfaSecret(userId, secret)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdatePassword(userId string, newPassword string) error {
+	err := s.UserStore.UpdatePassword(userId, newPassword)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) UpdateUpdateAt(userId string) (int64, error) {
+	result, err := s.UserStore.UpdateUpdateAt(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserStore) VerifyEmail(userId string, email string) (string, error) {
+	result, err := s.UserStore.VerifyEmail(userId, email)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) Delete(tokenId string) error {
+	err := s.UserAccessTokenStore.Delete(tokenId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) DeleteAllForUser(userId string) error {
+	err := s.UserAccessTokenStore.DeleteAllForUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Get(tokenId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.GetByUser(userId, page, perPage)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Save(token)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+	result, err := s.UserAccessTokenStore.Search(term)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+	err := s.UserAccessTokenStore.UpdateTokenDisable(tokenId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+	err := s.UserAccessTokenStore.UpdateTokenEnable(tokenId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+	err := s.UserTermsOfServiceStore.Delete(userId, termsOfServiceId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, error) {
+	result, err := s.UserTermsOfServiceStore.GetByUser(userId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+	result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) AnalyticsIncomingCount(teamId string) (int64, error) {
+	result, err := s.WebhookStore.AnalyticsIncomingCount(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, error) {
+	result, err := s.WebhookStore.AnalyticsOutgoingCount(teamId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+	err := s.WebhookStore.DeleteIncoming(webhookId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+	err := s.WebhookStore.DeleteOutgoing(webhookId, time)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByChannel(channelId)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByTeam(teamId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingByTeamByUser(teamId, userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingList(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.GetIncomingListByUser(userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoing(id)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByChannel(channelId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelId, userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByTeam(teamId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamId, userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingList(offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.GetOutgoingListByUser(userId, offset, limit)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+	err := s.WebhookStore.PermanentDeleteIncomingByChannel(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+	err := s.WebhookStore.PermanentDeleteIncomingByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+	err := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+	err := s.WebhookStore.PermanentDeleteOutgoingByUser(userId)
+	return MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.SaveIncoming(webhook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.SaveOutgoing(webhook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	result, err := s.WebhookStore.UpdateIncoming(webhook)
+	return result, MapQueryTimeout(err)
+}
+
+func (s *queryTimeoutWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	result, err := s.WebhookStore.UpdateOutgoing(hook)
+	return result, MapQueryTimeout(err)
+}
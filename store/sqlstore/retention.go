@@ -0,0 +1,241 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// PermanentDeleteBatchForRetention permanently deletes up to limit posts, channels and users
+// each that were soft deleted more than retentionDays ago, and returns how many were deleted.
+// It's meant to be called until it returns 0, every batch running in its own transactions to
+// keep the locks short.
+//
+// The reactions, file infos and threads of the deleted posts are deleted along with them.
+// Channels and users are only deleted once none of their posts remain, so their posts are
+// deleted first, up to limit per call, before their memberships and the rows they own.
+func (ss *SqlSupplier) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	if retentionDays < 1 {
+		return 0, store.NewErrInvalidInput("Retention", "retentionDays", retentionDays)
+	}
+	if limit < 1 {
+		return 0, store.NewErrInvalidInput("Retention", "limit", limit)
+	}
+
+	endTime := ss.getMillis() - int64(retentionDays)*24*60*60*1000
+
+	var deleted int64
+	for _, deleteBatch := range []func(int64, int) (int64, error){
+		ss.permanentDeleteDeletedPosts,
+		ss.permanentDeleteDeletedChannels,
+		ss.permanentDeleteDeletedUsers,
+	} {
+		count, err := deleteBatch(endTime, limit)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+func (ss *SqlSupplier) permanentDeleteDeletedPosts(endTime int64, limit int) (int64, error) {
+	postIds, err := ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Posts").
+		Where(sq.And{
+			sq.Gt{"DeleteAt": 0},
+			sq.Lt{"DeleteAt": endTime},
+		}).
+		Limit(uint64(limit)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find deleted Posts")
+	}
+
+	return ss.permanentDeletePosts(postIds)
+}
+
+// permanentDeleteDeletedChannels deletes the posts of the expired channels, and then the
+// channels left without posts.
+func (ss *SqlSupplier) permanentDeleteDeletedChannels(endTime int64, limit int) (int64, error) {
+	channelIds, err := ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Channels").
+		Where(sq.And{
+			sq.Gt{"DeleteAt": 0},
+			sq.Lt{"DeleteAt": endTime},
+		}).
+		Limit(uint64(limit)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find deleted Channels")
+	}
+	if len(channelIds) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := ss.permanentDeletePostsWhere(sq.Eq{"ChannelId": channelIds}, limit)
+	if err != nil {
+		return deleted, err
+	}
+
+	channelIds, err = ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Channels").
+		Where(sq.Eq{"Id": channelIds}).
+		Where("NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.ChannelId = Channels.Id)"))
+	if err != nil {
+		return deleted, errors.Wrap(err, "failed to find drained Channels")
+	}
+	if len(channelIds) == 0 {
+		return deleted, nil
+	}
+
+	count, err := ss.deleteInTransaction("Channels", "Id", channelIds, []dependentRows{
+		{"ChannelMembers", "ChannelId"},
+		{"SidebarChannels", "ChannelId"},
+		{"PublicChannels", "Id"},
+	})
+	return deleted + count, err
+}
+
+// permanentDeleteDeletedUsers deletes the posts of the expired users, and then the users left
+// without posts.
+func (ss *SqlSupplier) permanentDeleteDeletedUsers(endTime int64, limit int) (int64, error) {
+	userIds, err := ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Users").
+		Where(sq.And{
+			sq.Gt{"DeleteAt": 0},
+			sq.Lt{"DeleteAt": endTime},
+		}).
+		Limit(uint64(limit)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find deleted Users")
+	}
+	if len(userIds) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := ss.permanentDeletePostsWhere(sq.Eq{"UserId": userIds}, limit)
+	if err != nil {
+		return deleted, err
+	}
+
+	userIds, err = ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Users").
+		Where(sq.Eq{"Id": userIds}).
+		Where("NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.UserId = Users.Id)"))
+	if err != nil {
+		return deleted, errors.Wrap(err, "failed to find drained Users")
+	}
+	if len(userIds) == 0 {
+		return deleted, nil
+	}
+
+	count, err := ss.deleteInTransaction("Users", "Id", userIds, []dependentRows{
+		{"Sessions", "UserId"},
+		{"UserAccessTokens", "UserId"},
+		{"OAuthAuthData", "UserId"},
+		{"OAuthAccessData", "UserId"},
+		{"Preferences", "UserId"},
+		{"ChannelMembers", "UserId"},
+		{"TeamMembers", "UserId"},
+		{"GroupMembers", "UserId"},
+		{"ThreadMemberships", "UserId"},
+		{"Status", "UserId"},
+		{"Bots", "UserId"},
+	})
+	return deleted + count, err
+}
+
+func (ss *SqlSupplier) permanentDeletePostsWhere(where sq.Sqlizer, limit int) (int64, error) {
+	postIds, err := ss.selectIds(ss.getQueryBuilder().
+		Select("Id").
+		From("Posts").
+		Where(where).
+		Limit(uint64(limit)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to find Posts")
+	}
+
+	return ss.permanentDeletePosts(postIds)
+}
+
+func (ss *SqlSupplier) permanentDeletePosts(postIds []string) (int64, error) {
+	if len(postIds) == 0 {
+		return 0, nil
+	}
+
+	return ss.deleteInTransaction("Posts", "Id", postIds, []dependentRows{
+		{"Reactions", "PostId"},
+		{"FileInfo", "PostId"},
+		{"ThreadMemberships", "PostId"},
+		{"Threads", "PostId"},
+	})
+}
+
+type dependentRows struct {
+	table  string
+	column string
+}
+
+// deleteInTransaction deletes the rows of the table with the given ids along with the rows
+// referencing them, returning the number of rows deleted from the table.
+func (ss *SqlSupplier) deleteInTransaction(table, idColumn string, ids []string, dependents []dependentRows) (int64, error) {
+	var deleted int64
+	err := ss.WithRetryableTransaction(func(transaction *gorp.Transaction) error {
+		for _, dependent := range dependents {
+			if _, err := ss.deleteByIds(transaction, dependent.table, dependent.column, ids); err != nil {
+				return errors.Wrapf(err, "failed to delete %s", dependent.table)
+			}
+		}
+
+		var err error
+		deleted, err = ss.deleteByIds(transaction, table, idColumn, ids)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete %s", table)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func (ss *SqlSupplier) deleteByIds(transaction *gorp.Transaction, table, column string, ids []string) (int64, error) {
+	query, args, err := ss.getQueryBuilder().Delete(table).Where(sq.Eq{column: ids}).ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "delete_tosql")
+	}
+
+	result, err := transaction.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (ss *SqlSupplier) selectIds(builder sq.SelectBuilder) ([]string, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "select_tosql")
+	}
+
+	var ids []string
+	if _, err := ss.GetMaster().Select(&ids, query, args...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestPermanentDeleteBatchForRetention(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		supplier := ss.(*SqlSupplier)
+		expired := model.GetMillis() - 31*24*60*60*1000
+		recent := model.GetMillis() - 24*60*60*1000
+
+		softDelete := func(table, id string, deleteAt int64) {
+			_, err := supplier.GetMaster().Exec("UPDATE "+table+" SET DeleteAt = :DeleteAt WHERE Id = :Id", map[string]interface{}{"DeleteAt": deleteAt, "Id": id})
+			require.NoError(t, err)
+		}
+		count := func(table, column, id string) int64 {
+			count, err := supplier.GetMaster().SelectInt("SELECT COUNT(*) FROM "+table+" WHERE "+column+" = :Id", map[string]interface{}{"Id": id})
+			require.NoError(t, err)
+			return count
+		}
+		savePost := func(channelId, userId string, deleteAt int64) *model.Post {
+			post := &model.Post{ChannelId: channelId, UserId: userId, Message: "zz" + model.NewId(), DeleteAt: deleteAt}
+			post.PreSave()
+			require.NoError(t, supplier.GetMaster().Insert(post))
+			return post
+		}
+
+		user := createUser(ss)
+		expiredUser := createUser(ss)
+		softDelete("Users", expiredUser.Id, expired)
+		channel := createChannel(ss, model.NewId(), user.Id)
+		expiredChannel := createChannel(ss, model.NewId(), user.Id)
+		softDelete("Channels", expiredChannel.Id, expired)
+		createChannelMember(ss, expiredChannel.Id, user.Id)
+		createChannelMember(ss, channel.Id, expiredUser.Id)
+
+		livePost := savePost(channel.Id, user.Id, 0)
+		recentPost := savePost(channel.Id, user.Id, recent)
+		expiredPost := savePost(channel.Id, user.Id, expired)
+		expiredChannelPosts := []*model.Post{savePost(expiredChannel.Id, user.Id, 0), savePost(expiredChannel.Id, user.Id, 0)}
+		expiredUserPost := savePost(channel.Id, expiredUser.Id, 0)
+
+		require.NoError(t, supplier.GetMaster().Insert(&model.Reaction{UserId: user.Id, PostId: expiredPost.Id, EmojiName: "smile", CreateAt: model.GetMillis()}))
+		_, err := ss.FileInfo().Save(&model.FileInfo{CreatorId: user.Id, PostId: expiredPost.Id, Path: "file.txt"})
+		require.NoError(t, err)
+
+		t.Run("rejects invalid arguments", func(t *testing.T) {
+			_, err := ss.PermanentDeleteBatchForRetention(0, 10)
+			assert.IsType(t, &store.ErrInvalidInput{}, err)
+			_, err = ss.PermanentDeleteBatchForRetention(30, 0)
+			assert.IsType(t, &store.ErrInvalidInput{}, err)
+		})
+
+		t.Run("deletes in bounded batches until done", func(t *testing.T) {
+			var batches int
+			for {
+				deleted, err := ss.PermanentDeleteBatchForRetention(30, 1)
+				require.NoError(t, err)
+				if deleted == 0 {
+					break
+				}
+				batches++
+				require.Less(t, batches, 10)
+			}
+			assert.Greater(t, batches, 1)
+		})
+
+		t.Run("keeps live and recently deleted rows", func(t *testing.T) {
+			assert.Equal(t, int64(1), count("Posts", "Id", livePost.Id))
+			assert.Equal(t, int64(1), count("Posts", "Id", recentPost.Id))
+			assert.Equal(t, int64(1), count("Channels", "Id", channel.Id))
+			assert.Equal(t, int64(1), count("Users", "Id", user.Id))
+		})
+
+		t.Run("cascades to the dependent rows", func(t *testing.T) {
+			assert.Zero(t, count("Posts", "Id", expiredPost.Id))
+			assert.Zero(t, count("Reactions", "PostId", expiredPost.Id))
+			assert.Zero(t, count("FileInfo", "PostId", expiredPost.Id))
+
+			assert.Zero(t, count("Channels", "Id", expiredChannel.Id))
+			assert.Zero(t, count("ChannelMembers", "ChannelId", expiredChannel.Id))
+			for _, post := range expiredChannelPosts {
+				assert.Zero(t, count("Posts", "Id", post.Id))
+			}
+
+			assert.Zero(t, count("Users", "Id", expiredUser.Id))
+			assert.Zero(t, count("ChannelMembers", "UserId", expiredUser.Id))
+			assert.Zero(t, count("Posts", "Id", expiredUserPost.Id))
+		})
+	})
+}
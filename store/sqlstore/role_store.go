@@ -119,7 +119,7 @@ func (s *SqlRoleStore) Save(role *model.Role) (*model.Role, error) {
 	}
 
 	dbRole := NewRoleFromModel(role)
-	dbRole.UpdateAt = model.GetMillis()
+	dbRole.UpdateAt = s.getMillis()
 	if rowsChanged, err := s.GetMaster().Update(dbRole); err != nil {
 		return nil, errors.Wrap(err, "failed to update Role")
 	} else if rowsChanged != 1 {
@@ -138,11 +138,11 @@ func (s *SqlRoleStore) createRole(role *model.Role, transaction *gorp.Transactio
 	dbRole := NewRoleFromModel(role)
 
 	dbRole.Id = model.NewId()
-	dbRole.CreateAt = model.GetMillis()
+	dbRole.CreateAt = s.getMillis()
 	dbRole.UpdateAt = dbRole.CreateAt
 
 	if err := transaction.Insert(dbRole); err != nil {
-		return nil, errors.Wrap(err, "failed to save Role")
+		return nil, wrapSqlError(err, "Role", role.Name, "failed to save Role")
 	}
 
 	return dbRole.ToModel(), nil
@@ -237,7 +237,7 @@ func (s *SqlRoleStore) Delete(roleId string) (*model.Role, error) {
 		return nil, errors.Wrapf(err, "failed to get Role with id=%s", roleId)
 	}
 
-	time := model.GetMillis()
+	time := s.getMillis()
 	role.DeleteAt = time
 	role.UpdateAt = time
 
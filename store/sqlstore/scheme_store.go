@@ -68,7 +68,7 @@ func (s *SqlSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
 		return nil, store.NewErrInvalidInput("Scheme", "<any>", fmt.Sprintf("%v", scheme))
 	}
 
-	scheme.UpdateAt = model.GetMillis()
+	scheme.UpdateAt = s.getMillis()
 
 	rowsChanged, err := s.GetMaster().Update(scheme)
 	if err != nil {
@@ -121,7 +121,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			SchemeManaged: true,
 		}
 
-		savedRole, err := s.SqlStore.Role().(*SqlRoleStore).createRole(teamAdminRole, transaction)
+		savedRole, err := (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(teamAdminRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -135,7 +135,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			SchemeManaged: true,
 		}
 
-		savedRole, err = s.SqlStore.Role().(*SqlRoleStore).createRole(teamUserRole, transaction)
+		savedRole, err = (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(teamUserRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -149,7 +149,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			SchemeManaged: true,
 		}
 
-		savedRole, err = s.SqlStore.Role().(*SqlRoleStore).createRole(teamGuestRole, transaction)
+		savedRole, err = (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(teamGuestRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -169,7 +169,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			channelAdminRole.Permissions = []string{}
 		}
 
-		savedRole, err := s.SqlStore.Role().(*SqlRoleStore).createRole(channelAdminRole, transaction)
+		savedRole, err := (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(channelAdminRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -187,7 +187,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			channelUserRole.Permissions = filterModerated(channelUserRole.Permissions)
 		}
 
-		savedRole, err = s.SqlStore.Role().(*SqlRoleStore).createRole(channelUserRole, transaction)
+		savedRole, err = (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(channelUserRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -205,7 +205,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 			channelGuestRole.Permissions = filterModerated(channelGuestRole.Permissions)
 		}
 
-		savedRole, err = s.SqlStore.Role().(*SqlRoleStore).createRole(channelGuestRole, transaction)
+		savedRole, err = (&SqlRoleStore{SqlStore: s.SqlStore}).createRole(channelGuestRole, transaction)
 		if err != nil {
 			return nil, err
 		}
@@ -216,7 +216,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 	if len(scheme.Name) == 0 {
 		scheme.Name = model.NewId()
 	}
-	scheme.CreateAt = model.GetMillis()
+	scheme.CreateAt = s.getMillis()
 	scheme.UpdateAt = scheme.CreateAt
 
 	// Validate the scheme
@@ -225,7 +225,7 @@ func (s *SqlSchemeStore) createScheme(scheme *model.Scheme, transaction *gorp.Tr
 	}
 
 	if err := transaction.Insert(scheme); err != nil {
-		return nil, errors.Wrap(err, "failed to save Scheme")
+		return nil, wrapSqlError(err, "Scheme", scheme.Name, "failed to save Scheme")
 	}
 
 	return scheme, nil
@@ -306,7 +306,7 @@ func (s *SqlSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
 	}
 	inQuery := strings.Join(inQueryList, ", ")
 
-	time := model.GetMillis()
+	time := s.getMillis()
 	queryArgs["UpdateAt"] = time
 	queryArgs["DeleteAt"] = time
 
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+)
+
+// namedParameterRegexp matches the ":Name" parameters of the queries, like gorp does.
+var namedParameterRegexp = regexp.MustCompile(`:[[:word:]]+`)
+
+// selectContext is the equivalent of db.Select for a pointer to a slice of struct pointers,
+// running the query with ctx so that cancelling it aborts the query in the driver. The query
+// still times out after the query timeout of db. As with db.Select, a single map argument is
+// expanded into the named parameters of the query.
+func selectContext(ctx context.Context, db *gorp.DbMap, holder interface{}, query string, args ...interface{}) error {
+	sliceValue, err := selectHolderSlice(holder)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		if params, ok := args[0].(map[string]interface{}); ok {
+			query, args = expandNamedParameters(db, query, params)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, db.QueryTimeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRows(db, rows, sliceValue)
+}
+
+// selectHolderSlice returns the slice a select holder points to, checking that it's a
+// pointer to a slice of struct pointers.
+func selectHolderSlice(holder interface{}) (reflect.Value, error) {
+	sliceValue := reflect.ValueOf(holder)
+	if sliceValue.Kind() != reflect.Ptr || sliceValue.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, errors.Errorf("select holder must be a pointer to a slice, got %T", holder)
+	}
+	sliceValue = sliceValue.Elem()
+	elemType := sliceValue.Type().Elem()
+	if elemType.Kind() != reflect.Ptr || elemType.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errors.Errorf("select holder must be a pointer to a slice of struct pointers, got %T", holder)
+	}
+
+	return sliceValue, nil
+}
+
+// scanRows appends the rows to the slice of struct pointers, converting the columns with
+// the type converter of db.
+func scanRows(db *gorp.DbMap, rows *sql.Rows, sliceValue reflect.Value) error {
+	structType := sliceValue.Type().Elem().Elem()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fieldIndexes := columnFieldIndexes(structType, columns)
+
+	for rows.Next() {
+		elem := reflect.New(structType)
+		dest := make([]interface{}, len(columns))
+		var scanners []gorp.CustomScanner
+		for i, index := range fieldIndexes {
+			if index == nil {
+				dest[i] = new(interface{})
+				continue
+			}
+
+			target := elem.Elem().FieldByIndex(index).Addr().Interface()
+			if scanner, ok := db.TypeConverter.FromDb(target); ok {
+				dest[i] = scanner.Holder
+				scanners = append(scanners, scanner)
+			} else {
+				dest[i] = target
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		for _, scanner := range scanners {
+			if err := scanner.Bind(); err != nil {
+				return err
+			}
+		}
+
+		sliceValue.Set(reflect.Append(sliceValue, elem))
+	}
+
+	return rows.Err()
+}
+
+// selectOneContext is the equivalent of db.SelectOne for a pointer to a struct, returning
+// sql.ErrNoRows if no row is found.
+func selectOneContext(ctx context.Context, db *gorp.DbMap, holder interface{}, query string, args ...interface{}) error {
+	holderValue := reflect.ValueOf(holder)
+	if holderValue.Kind() != reflect.Ptr || holderValue.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("select holder must be a pointer to a struct, got %T", holder)
+	}
+
+	list := reflect.New(reflect.SliceOf(holderValue.Type()))
+	if err := selectContext(ctx, db, list.Interface(), query, args...); err != nil {
+		return err
+	}
+
+	switch list.Elem().Len() {
+	case 0:
+		return sql.ErrNoRows
+	case 1:
+		holderValue.Elem().Set(list.Elem().Index(0).Elem())
+		return nil
+	default:
+		return errors.Errorf("multiple rows returned for: %s", query)
+	}
+}
+
+func expandNamedParameters(db *gorp.DbMap, query string, params map[string]interface{}) (string, []interface{}) {
+	var args []interface{}
+	query = namedParameterRegexp.ReplaceAllStringFunc(query, func(parameter string) string {
+		value, ok := params[parameter[1:]]
+		if !ok {
+			return parameter
+		}
+
+		args = append(args, value)
+		return db.Dialect.BindVar(len(args) - 1)
+	})
+
+	return query, args
+}
+
+// columnFieldIndexes maps the columns to the fields of the struct by their case insensitive
+// name, or the name in their db tag, leaving the columns without a field nil.
+func columnFieldIndexes(structType reflect.Type, columns []string) [][]int {
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		column = strings.ToLower(column)
+		field, found := structType.FieldByNameFunc(func(name string) bool {
+			field, _ := structType.FieldByName(name)
+			if tag := strings.Split(field.Tag.Get("db"), ",")[0]; tag != "" && tag != "-" {
+				name = tag
+			}
+			return strings.ToLower(name) == column
+		})
+		if found {
+			indexes[i] = field.Index
+		}
+	}
+
+	return indexes
+}
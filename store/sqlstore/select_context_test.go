@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestSelectContext(t *testing.T) {
+	StoreTest(t, func(t *testing.T, ss store.Store) {
+		supplier := ss.(*SqlSupplier)
+
+		user := createUser(ss)
+		channel := createChannel(ss, model.NewId(), user.Id)
+		post := &model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "zz" + model.NewId()}
+		post.AddProp("key", "value")
+		post.PreSave()
+		require.NoError(t, supplier.GetMaster().Insert(post))
+
+		t.Run("reads the rows like a gorp select", func(t *testing.T) {
+			var posts []*model.Post
+			err := selectContext(context.Background(), supplier.GetMaster(), &posts, "SELECT * FROM Posts WHERE Id = :Id", map[string]interface{}{"Id": post.Id})
+			require.NoError(t, err)
+			require.Len(t, posts, 1)
+			assert.Equal(t, post.Message, posts[0].Message)
+			assert.Equal(t, "value", posts[0].GetProp("key"))
+
+			var single model.Post
+			err = selectOneContext(context.Background(), supplier.GetMaster(), &single, "SELECT * FROM Posts WHERE Id = :Id", map[string]interface{}{"Id": post.Id})
+			require.NoError(t, err)
+			assert.Equal(t, posts[0], &single)
+		})
+
+		t.Run("reads the posts and channels with a context", func(t *testing.T) {
+			ctx := context.Background()
+
+			single, err := ss.Post().GetSingleCtx(ctx, post.Id)
+			require.NoError(t, err)
+			assert.Equal(t, post.Id, single.Id)
+
+			list, err := ss.Post().GetPostsCtx(ctx, model.GetPostsOptions{ChannelId: channel.Id, PerPage: 10}, false)
+			require.NoError(t, err)
+			assert.Equal(t, []string{post.Id}, list.Order)
+
+			list, err = ss.Post().GetPostsSinceCtx(ctx, model.GetPostsSinceOptions{ChannelId: channel.Id, Time: post.UpdateAt - 1}, false)
+			require.NoError(t, err)
+			assert.Equal(t, []string{post.Id}, list.Order)
+
+			found, err := ss.Channel().GetCtx(ctx, channel.Id, false)
+			require.NoError(t, err)
+			assert.Equal(t, channel.Name, found.Name)
+
+			_, err = ss.Channel().GetCtx(ctx, model.NewId(), false)
+			var nfErr *store.ErrNotFound
+			assert.True(t, errors.As(err, &nfErr))
+		})
+
+		t.Run("stops once the context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := ss.Post().GetSingleCtx(ctx, post.Id)
+			assert.True(t, errors.Is(err, context.Canceled), err)
+			_, err = ss.Post().GetPostsCtx(ctx, model.GetPostsOptions{ChannelId: channel.Id, PerPage: 10}, false)
+			assert.True(t, errors.Is(err, context.Canceled), err)
+			_, err = ss.Channel().GetCtx(ctx, channel.Id, false)
+			assert.True(t, errors.Is(err, context.Canceled), err)
+		})
+
+		t.Run("aborts a running query", func(t *testing.T) {
+			var query string
+			switch supplier.DriverName() {
+			case model.DATABASE_DRIVER_POSTGRES:
+				query = "SELECT pg_sleep(10)"
+			case model.DATABASE_DRIVER_MYSQL:
+				query = "SELECT SLEEP(10)"
+			default:
+				query = "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 1000000000) SELECT COUNT(*) FROM c"
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			var rows []*struct{}
+			err := selectContext(ctx, supplier.GetMaster(), &rows, query)
+			assert.Error(t, err)
+			assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+		})
+	})
+}
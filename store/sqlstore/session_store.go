@@ -40,6 +40,10 @@ func newSqlSessionStore(sqlStore SqlStore) store.SessionStore {
 	return us
 }
 
+func sessionSliceColumns() []string {
+	return []string{"Id", "Token", "CreateAt", "ExpiresAt", "LastActivityAt", "UserId", "DeviceId", "Roles", "IsOAuth", "ExpiredNotify", "Props"}
+}
+
 func (me SqlSessionStore) createIndexesIfNotExists() {
 	me.CreateIndexIfNotExists("idx_sessions_user_id", "Sessions", "UserId")
 	me.CreateIndexIfNotExists("idx_sessions_token", "Sessions", "Token")
@@ -53,9 +57,11 @@ func (me SqlSessionStore) Save(session *model.Session) (*model.Session, error) {
 		return nil, store.NewErrInvalidInput("Session", "id", session.Id)
 	}
 	session.PreSave()
+	session.CreateAt = me.getMillis()
+	session.LastActivityAt = session.CreateAt
 
 	if err := me.GetMaster().Insert(session); err != nil {
-		return nil, errors.Wrapf(err, "failed to save Session with id=%s", session.Id)
+		return nil, wrapSqlError(err, "Session", session.Id, fmt.Sprintf("failed to save Session with id=%s", session.Id))
 	}
 
 	teamMembers, err := me.Team().GetTeamsForUser(context.Background(), session.UserId)
@@ -74,9 +80,20 @@ func (me SqlSessionStore) Save(session *model.Session) (*model.Session, error) {
 }
 
 func (me SqlSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
+	query, args, err := me.getQueryBuilder().
+		Select(sessionSliceColumns()...).
+		From("Sessions").
+		Where(sq.Or{sq.Eq{"Token": sessionIdOrToken}, sq.Eq{"Id": sessionIdOrToken}}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessions_tosql")
+	}
+
 	var sessions []*model.Session
 
-	if _, err := me.GetReplica().Select(&sessions, "SELECT * FROM Sessions WHERE Token = :Token OR Id = :Id LIMIT 1", map[string]interface{}{"Token": sessionIdOrToken, "Id": sessionIdOrToken}); err != nil {
+	// Sessions are looked up on every request, so the query is prepared once.
+	if err = me.PreparedQuery(&sessions, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Sessions with sessionIdOrToken=%s", sessionIdOrToken)
 	} else if len(sessions) == 0 {
 		return nil, store.NewErrNotFound("Session", fmt.Sprintf("sessionIdOrToken=%s", sessionIdOrToken))
@@ -134,7 +151,7 @@ func (me SqlSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*mode
 
 	var sessions []*model.Session
 
-	_, err := me.GetReplica().Select(&sessions, query, map[string]interface{}{"UserId": userId, "ExpiresAt": model.GetMillis()})
+	_, err := me.GetReplica().Select(&sessions, query, map[string]interface{}{"UserId": userId, "ExpiresAt": me.getMillis()})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Sessions with userId=%s", userId)
 	}
@@ -142,7 +159,7 @@ func (me SqlSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*mode
 }
 
 func (me SqlSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
-	now := model.GetMillis()
+	now := me.getMillis()
 	builder := me.getQueryBuilder().
 		Select("*").
 		From("Sessions").
@@ -221,7 +238,16 @@ func (me SqlSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
 }
 
 func (me SqlSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
-	_, err := me.GetMaster().Exec("UPDATE Sessions SET LastActivityAt = :LastActivityAt WHERE Id = :Id", map[string]interface{}{"LastActivityAt": time, "Id": sessionId})
+	query, args, err := me.getQueryBuilder().
+		Update("Sessions").
+		Set("LastActivityAt", time).
+		Where(sq.Eq{"Id": sessionId}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "sessions_tosql")
+	}
+
+	_, err = me.PreparedExec(query, args...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update Session with id=%s", sessionId)
 	}
@@ -272,7 +298,7 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, error) {
 		FROM
 			Sessions
 		WHERE ExpiresAt > :Time`
-	count, err := me.GetReplica().SelectInt(query, map[string]interface{}{"Time": model.GetMillis()})
+	count, err := me.GetReplica().SelectInt(query, map[string]interface{}{"Time": me.getMillis()})
 	if err != nil {
 		return int64(0), errors.Wrap(err, "failed to count Sessions")
 	}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	dbsql "database/sql"
+	"database/sql/driver"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SHUTDOWN_TIMEOUT is how long Close waits for the running queries to finish.
+	SHUTDOWN_TIMEOUT = 15 * time.Second
+	// SHUTDOWN_POLL_INTERVAL is how often Shutdown checks whether the running queries finished.
+	SHUTDOWN_POLL_INTERVAL = 10 * time.Millisecond
+)
+
+// ErrShuttingDown is returned by the queries started once the supplier is shutting down.
+var ErrShuttingDown = errors.New("the sql store is shutting down")
+
+// shutdownConnector fails every connection attempt, so that the queries run on the connection
+// served while shutting down fail right away.
+type shutdownConnector struct{}
+
+func (shutdownConnector) Connect(context.Context) (driver.Conn, error) { return nil, ErrShuttingDown }
+func (shutdownConnector) Driver() driver.Driver                        { return shutdownConnector{} }
+func (shutdownConnector) Open(string) (driver.Conn, error)             { return nil, ErrShuttingDown }
+
+func (ss *SqlSupplier) isShuttingDown() bool {
+	return atomic.LoadInt32(&ss.shuttingDown) == 1
+}
+
+// Shutdown stops accepting new queries, which fail with ErrShuttingDown, and waits for the
+// running queries and transactions to finish before closing the database connections. If ctx is
+// done first, the connections are closed anyway, aborting what's still running, and the context
+// error is returned. Calling Shutdown again has no effect.
+func (ss *SqlSupplier) Shutdown(ctx context.Context) error {
+	ss.shutdownMutex.Lock()
+	defer ss.shutdownMutex.Unlock()
+
+	if ss.isShuttingDown() {
+		return nil
+	}
+
+	// The queries keep being built for the dialect and the tables of the master.
+	shutdownConn := *ss.master
+	shutdownConn.Db = dbsql.OpenDB(shutdownConnector{})
+	ss.shutdownConn = &shutdownConn
+	atomic.StoreInt32(&ss.shuttingDown, 1)
+	ss.stopConnValidation()
+
+	err := ss.waitForQueries(ctx)
+
+	ss.preparedStatements.clear()
+	ss.closeConnections()
+
+	return err
+}
+
+// waitForQueries waits until none of the connections is in use.
+func (ss *SqlSupplier) waitForQueries(ctx context.Context) error {
+	ticker := time.NewTicker(SHUTDOWN_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		inUse := 0
+		for _, db := range append(append([]*gorp.DbMap{ss.master}, ss.replicas...), ss.searchReplicas...) {
+			inUse += db.Db.Stats().InUse
+		}
+		if inUse == 0 {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "%d database connections still in use", inUse)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestShutdown(t *testing.T) {
+	newSupplier := func(t *testing.T) *SqlSupplier {
+		settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+		t.Cleanup(func() { storetest.CleanupSqlSettings(settings) })
+
+		supplier, err := NewSqlSupplier(*settings, nil)
+		require.NoError(t, err)
+		return supplier
+	}
+
+	t.Run("waits for the running transactions", func(t *testing.T) {
+		supplier := newSupplier(t)
+
+		transaction, err := supplier.GetMaster().Begin()
+		require.NoError(t, err)
+		_, err = transaction.SelectInt("SELECT COUNT(*) FROM Users")
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			done <- supplier.Shutdown(context.Background())
+		}()
+
+		require.Eventually(t, supplier.isShuttingDown, time.Second, time.Millisecond)
+		_, err = supplier.GetMaster().SelectInt("SELECT COUNT(*) FROM Users")
+		assert.True(t, errors.Is(err, ErrShuttingDown), err)
+		assert.True(t, errors.Is(supplier.HealthCheck(context.Background()), ErrShuttingDown))
+
+		select {
+		case <-done:
+			require.Fail(t, "shut down before the transaction finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		_, err = transaction.SelectInt("SELECT COUNT(*) FROM Users")
+		require.NoError(t, err, "the running transaction should not be aborted")
+		require.NoError(t, transaction.Commit())
+		require.NoError(t, <-done)
+
+		assert.NoError(t, supplier.Shutdown(context.Background()), "shutting down again should do nothing")
+	})
+
+	t.Run("gives up once the context is done", func(t *testing.T) {
+		supplier := newSupplier(t)
+
+		transaction, err := supplier.GetMaster().Begin()
+		require.NoError(t, err)
+		defer transaction.Rollback()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = supplier.Shutdown(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+		assert.Error(t, supplier.master.Db.Ping(), "the connections should be closed")
+	})
+}
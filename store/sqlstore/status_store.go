@@ -107,7 +107,7 @@ func (s SqlStatusStore) ResetAll() error {
 }
 
 func (s SqlStatusStore) GetTotalActiveUsersCount() (int64, error) {
-	time := model.GetMillis() - (1000 * 60 * 60 * 24)
+	time := s.getMillis() - (1000 * 60 * 60 * 24)
 	count, err := s.GetReplica().SelectInt("SELECT COUNT(UserId) FROM Status WHERE LastActivityAt > :Time", map[string]interface{}{"Time": time})
 	if err != nil {
 		return count, errors.Wrap(err, "failed to count active users")
@@ -116,7 +116,16 @@ func (s SqlStatusStore) GetTotalActiveUsersCount() (int64, error) {
 }
 
 func (s SqlStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
-	if _, err := s.GetMaster().Exec("UPDATE Status SET LastActivityAt = :Time WHERE UserId = :UserId", map[string]interface{}{"UserId": userId, "Time": lastActivityAt}); err != nil {
+	query, args, err := s.getQueryBuilder().
+		Update("Status").
+		Set("LastActivityAt", lastActivityAt).
+		Where(sq.Eq{"UserId": userId}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "status_tosql")
+	}
+
+	if _, err := s.PreparedExec(query, args...); err != nil {
 		return errors.Wrapf(err, "failed to update last activity for userId=%s", userId)
 	}
 
@@ -4,10 +4,14 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 	"github.com/mattermost/gorp"
+	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
 )
 
@@ -43,6 +47,7 @@ type SqlStore interface {
 	DriverName() string
 	GetCurrentSchemaVersion() string
 	GetMaster() *gorp.DbMap
+	GetMasterWithTimeout(timeout time.Duration) *gorp.DbMap
 	GetSearchReplica() *gorp.DbMap
 	GetReplica() *gorp.DbMap
 	GetDbVersion() (string, error)
@@ -103,5 +108,10 @@ type SqlStore interface {
 	TermsOfService() store.TermsOfServiceStore
 	UserTermsOfService() store.UserTermsOfServiceStore
 	LinkMetadata() store.LinkMetadataStore
+	PreparedExec(query string, args ...interface{}) (sql.Result, error)
+	PreparedQuery(holder interface{}, query string, args ...interface{}) error
+	WithRetryableTransaction(f func(*gorp.Transaction) error) error
 	getQueryBuilder() sq.StatementBuilderType
+	getSettings() *model.SqlSettings
+	getMillis() int64
 }
@@ -114,7 +114,11 @@ func initStores() {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			st.SqlSupplier = NewSqlSupplier(*st.SqlSettings, nil)
+			var err error
+			st.SqlSupplier, err = NewSqlSupplier(*st.SqlSettings, nil)
+			if err != nil {
+				panic(err)
+			}
 			st.Store = st.SqlSupplier
 			st.Store.DropAllTables()
 			st.Store.MarkSystemRanUnitTests()
@@ -7,7 +7,6 @@ import (
 	"context"
 	dbsql "database/sql"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -20,6 +19,9 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/lib/pq"
 	"github.com/mattermost/gorp"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -30,8 +32,8 @@ import (
 const (
 	INDEX_TYPE_FULL_TEXT = "full_text"
 	INDEX_TYPE_DEFAULT   = "default"
-	DB_PING_ATTEMPTS     = 18
 	DB_PING_TIMEOUT_SECS = 10
+	DB_PING_MIN_BACKOFF  = time.Second
 )
 
 const (
@@ -76,10 +78,11 @@ type SqlSupplierStores struct {
 	user                 store.UserStore
 	bot                  store.BotStore
 	audit                store.AuditStore
-	cluster              store.ClusterDiscoveryStore
+	auditTrail           store.AuditTrailStore
+	clusterDiscovery     store.ClusterDiscoveryStore
 	compliance           store.ComplianceStore
 	session              store.SessionStore
-	oauth                store.OAuthStore
+	oAuth                store.OAuthStore
 	system               store.SystemStore
 	webhook              store.WebhookStore
 	command              store.CommandStore
@@ -98,27 +101,48 @@ type SqlSupplierStores struct {
 	channelMemberHistory store.ChannelMemberHistoryStore
 	role                 store.RoleStore
 	scheme               store.SchemeStore
-	TermsOfService       store.TermsOfServiceStore
+	termsOfService       store.TermsOfServiceStore
 	productNotices       store.ProductNoticesStore
 	group                store.GroupStore
-	UserTermsOfService   store.UserTermsOfServiceStore
+	userTermsOfService   store.UserTermsOfServiceStore
 	linkMetadata         store.LinkMetadataStore
 }
 
 type SqlSupplier struct {
 	// rrCounter and srCounter should be kept first.
 	// See https://github.com/mattermost/mattermost-server/v5/pull/7281
-	rrCounter      int64
-	srCounter      int64
-	master         *gorp.DbMap
-	replicas       []*gorp.DbMap
-	searchReplicas []*gorp.DbMap
-	stores         SqlSupplierStores
-	settings       *model.SqlSettings
-	lockedToMaster bool
-	context        context.Context
-	license        *model.License
-	licenseMutex   sync.RWMutex
+	rrCounter          int64
+	srCounter          int64
+	master             *gorp.DbMap
+	replicas           []*gorp.DbMap
+	searchReplicas     []*gorp.DbMap
+	stores             SqlSupplierStores
+	settings           *model.SqlSettings
+	lockedToMaster     bool
+	context            context.Context
+	license            *model.License
+	licenseMutex       sync.RWMutex
+	queryObserver      *queryObserver
+	preparedStatements *preparedStatementCache
+	methodStats        methodStats
+
+	transactionErrorInjector      TransactionErrorInjector
+	transactionErrorInjectorMutex sync.RWMutex
+
+	// shuttingDown is set atomically by Shutdown, after which the queries are run on
+	// shutdownConn rather than on the live connections.
+	shuttingDown  int32
+	shutdownConn  *gorp.DbMap
+	shutdownMutex sync.Mutex
+
+	clock      Clock
+	clockMutex sync.RWMutex
+
+	// connValidationFailures counts atomically the periodic validations of the connections that
+	// failed in a row.
+	connValidationFailures int32
+	connValidationStop     chan struct{}
+	connValidationDone     chan struct{}
 }
 
 type TraceOnAdapter struct{}
@@ -131,14 +155,27 @@ func (t *TraceOnAdapter) Printf(format string, v ...interface{}) {
 	mlog.Debug(newString)
 }
 
-func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInterface) *SqlSupplier {
+// NewSqlSupplier connects to the configured databases, retrying with an exponential backoff
+// while they are unreachable, and upgrades the schema. An error is returned once
+// SqlSettings.ConnMaxRetries retries have failed.
+func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInterface) (*SqlSupplier, error) {
 	supplier := &SqlSupplier{
 		rrCounter: 0,
 		srCounter: 0,
 		settings:  &settings,
 	}
 
-	supplier.initConnection()
+	var collector QueryMetricsCollector
+	if metrics != nil {
+		collector = metrics
+	}
+	supplier.queryObserver = newQueryObserver(&settings, collector)
+	supplier.queryObserver.context = supplier.Context
+	supplier.preparedStatements = newPreparedStatementCache(PREPARED_STATEMENT_CACHE_SIZE)
+
+	if err := supplier.initConnection(); err != nil {
+		return nil, maskDataSourceError(err)
+	}
 
 	supplier.stores.team = newSqlTeamStore(supplier)
 	supplier.stores.channel = newSqlChannelStore(supplier, metrics)
@@ -146,10 +183,11 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.user = newSqlUserStore(supplier, metrics)
 	supplier.stores.bot = newSqlBotStore(supplier, metrics)
 	supplier.stores.audit = newSqlAuditStore(supplier)
-	supplier.stores.cluster = newSqlClusterDiscoveryStore(supplier)
+	supplier.stores.auditTrail = newSqlAuditTrailStore(supplier)
+	supplier.stores.clusterDiscovery = newSqlClusterDiscoveryStore(supplier)
 	supplier.stores.compliance = newSqlComplianceStore(supplier)
 	supplier.stores.session = newSqlSessionStore(supplier)
-	supplier.stores.oauth = newSqlOAuthStore(supplier)
+	supplier.stores.oAuth = newSqlOAuthStore(supplier)
 	supplier.stores.system = newSqlSystemStore(supplier)
 	supplier.stores.webhook = newSqlWebhookStore(supplier, metrics)
 	supplier.stores.command = newSqlCommandStore(supplier)
@@ -166,8 +204,8 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.userAccessToken = newSqlUserAccessTokenStore(supplier)
 	supplier.stores.channelMemberHistory = newSqlChannelMemberHistoryStore(supplier)
 	supplier.stores.plugin = newSqlPluginStore(supplier)
-	supplier.stores.TermsOfService = newSqlTermsOfServiceStore(supplier, metrics)
-	supplier.stores.UserTermsOfService = newSqlUserTermsOfServiceStore(supplier)
+	supplier.stores.termsOfService = newSqlTermsOfServiceStore(supplier, metrics)
+	supplier.stores.userTermsOfService = newSqlUserTermsOfServiceStore(supplier)
 	supplier.stores.linkMetadata = newSqlLinkMetadataStore(supplier)
 	supplier.stores.reaction = newSqlReactionStore(supplier)
 	supplier.stores.role = newSqlRoleStore(supplier)
@@ -188,16 +226,22 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 		os.Exit(EXIT_GENERIC_FAILURE)
 	}
 
+	if supplier.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		supplier.checkMySQLCollations()
+	}
+
 	supplier.stores.team.(*SqlTeamStore).createIndexesIfNotExists()
 	supplier.stores.channel.(*SqlChannelStore).createIndexesIfNotExists()
+	supplier.stores.post.(*SqlPostStore).migratePropsToJSONB()
 	supplier.stores.post.(*SqlPostStore).createIndexesIfNotExists()
 	supplier.stores.thread.(*SqlThreadStore).createIndexesIfNotExists()
 	supplier.stores.user.(*SqlUserStore).createIndexesIfNotExists()
 	supplier.stores.bot.(*SqlBotStore).createIndexesIfNotExists()
 	supplier.stores.audit.(*SqlAuditStore).createIndexesIfNotExists()
+	supplier.stores.auditTrail.(*SqlAuditTrailStore).createIndexesIfNotExists()
 	supplier.stores.compliance.(*SqlComplianceStore).createIndexesIfNotExists()
 	supplier.stores.session.(*SqlSessionStore).createIndexesIfNotExists()
-	supplier.stores.oauth.(*SqlOAuthStore).createIndexesIfNotExists()
+	supplier.stores.oAuth.(*SqlOAuthStore).createIndexesIfNotExists()
 	supplier.stores.system.(*SqlSystemStore).createIndexesIfNotExists()
 	supplier.stores.webhook.(*SqlWebhookStore).createIndexesIfNotExists()
 	supplier.stores.command.(*SqlCommandStore).createIndexesIfNotExists()
@@ -212,42 +256,32 @@ func NewSqlSupplier(settings model.SqlSettings, metrics einterfaces.MetricsInter
 	supplier.stores.job.(*SqlJobStore).createIndexesIfNotExists()
 	supplier.stores.userAccessToken.(*SqlUserAccessTokenStore).createIndexesIfNotExists()
 	supplier.stores.plugin.(*SqlPluginStore).createIndexesIfNotExists()
-	supplier.stores.TermsOfService.(SqlTermsOfServiceStore).createIndexesIfNotExists()
+	supplier.stores.termsOfService.(SqlTermsOfServiceStore).createIndexesIfNotExists()
 	supplier.stores.productNotices.(SqlProductNoticesStore).createIndexesIfNotExists()
-	supplier.stores.UserTermsOfService.(SqlUserTermsOfServiceStore).createIndexesIfNotExists()
+	supplier.stores.userTermsOfService.(SqlUserTermsOfServiceStore).createIndexesIfNotExists()
 	supplier.stores.linkMetadata.(*SqlLinkMetadataStore).createIndexesIfNotExists()
 	supplier.stores.group.(*SqlGroupStore).createIndexesIfNotExists()
 	supplier.stores.scheme.(*SqlSchemeStore).createIndexesIfNotExists()
 	supplier.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
-	return supplier
+	// The stores are handed out wrapped from here on, for the callers to get the query timeouts
+	// as ErrQueryTimeout whatever layers they stack on the supplier.
+	supplier.stores = supplier.stores.withQueryTimeouts()
+
+	supplier.startConnValidation(CONN_VALIDATION_INTERVAL)
+
+	return supplier, nil
 }
 
-func setupConnection(con_type string, dataSource string, settings *model.SqlSettings) *gorp.DbMap {
-	db, err := dbsql.Open(*settings.DriverName, dataSource)
+func setupConnection(con_type string, dataSource string, settings *model.SqlSettings) (*gorp.DbMap, error) {
+	db, err := dbsql.Open(sqlDriverName(*settings.DriverName), dataSource)
 	if err != nil {
-		mlog.Critical("Failed to open SQL connection to err.", mlog.Err(err))
-		time.Sleep(time.Second)
-		os.Exit(EXIT_DB_OPEN)
+		return nil, errors.Wrapf(maskDataSourceError(err), "failed to open SQL connection to %s database", con_type)
 	}
 
-	for i := 0; i < DB_PING_ATTEMPTS; i++ {
-		mlog.Info("Pinging SQL", mlog.String("database", con_type))
-		ctx, cancel := context.WithTimeout(context.Background(), DB_PING_TIMEOUT_SECS*time.Second)
-		defer cancel()
-		err = db.PingContext(ctx)
-		if err == nil {
-			break
-		} else {
-			if i == DB_PING_ATTEMPTS-1 {
-				mlog.Critical("Failed to ping DB, server will exit.", mlog.Err(err))
-				time.Sleep(time.Second)
-				os.Exit(EXIT_PING)
-			} else {
-				mlog.Error("Failed to ping DB", mlog.Err(err), mlog.Int("retrying in seconds", DB_PING_TIMEOUT_SECS))
-				time.Sleep(DB_PING_TIMEOUT_SECS * time.Second)
-			}
-		}
+	if err = pingWithRetries(db, con_type, settings); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	db.SetMaxIdleConns(*settings.MaxIdleConns)
@@ -261,20 +295,52 @@ func setupConnection(con_type string, dataSource string, settings *model.SqlSett
 	if *settings.DriverName == model.DATABASE_DRIVER_SQLITE {
 		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.SqliteDialect{}, QueryTimeout: connectionTimeout}
 	} else if *settings.DriverName == model.DATABASE_DRIVER_MYSQL {
-		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.MySQLDialect{Engine: "InnoDB", Encoding: "UTF8MB4"}, QueryTimeout: connectionTimeout}
+		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: newMySQLDialect(settings), QueryTimeout: connectionTimeout}
 	} else if *settings.DriverName == model.DATABASE_DRIVER_POSTGRES {
 		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: gorp.PostgresDialect{}, QueryTimeout: connectionTimeout}
+	} else if *settings.DriverName == model.DATABASE_DRIVER_COCKROACH {
+		dbmap = &gorp.DbMap{Db: db, TypeConverter: mattermConverter{}, Dialect: cockroachDialect{}, QueryTimeout: connectionTimeout}
 	} else {
-		mlog.Critical("Failed to create dialect specific driver")
-		time.Sleep(time.Second)
-		os.Exit(EXIT_NO_DRIVER)
+		db.Close()
+		return nil, errors.Errorf("failed to create dialect specific driver %s", *settings.DriverName)
 	}
 
-	if settings.Trace != nil && *settings.Trace {
-		dbmap.TraceOn("sql-trace:", &TraceOnAdapter{})
+	return dbmap, nil
+}
+
+// pingWithRetries pings the database until it answers, doubling the delay between attempts
+// up to SqlSettings.ConnRetryMaxDelay.
+func pingWithRetries(db *dbsql.DB, con_type string, settings *model.SqlSettings) error {
+	maxRetries := model.SQL_SETTINGS_DEFAULT_CONN_MAX_RETRIES
+	if settings.ConnMaxRetries != nil {
+		maxRetries = *settings.ConnMaxRetries
+	}
+	maxDelay := time.Duration(model.SQL_SETTINGS_DEFAULT_CONN_RETRY_MAX_DELAY) * time.Second
+	if settings.ConnRetryMaxDelay != nil {
+		maxDelay = time.Duration(*settings.ConnRetryMaxDelay) * time.Second
 	}
 
-	return dbmap
+	delay := DB_PING_MIN_BACKOFF
+	for attempt := 1; ; attempt++ {
+		mlog.Info("Pinging SQL", mlog.String("database", con_type), mlog.Int("attempt", attempt))
+		ctx, cancel := context.WithTimeout(context.Background(), DB_PING_TIMEOUT_SECS*time.Second)
+		err := maskDataSourceError(db.PingContext(ctx))
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if attempt > maxRetries {
+			return errors.Wrapf(err, "failed to ping %s database after %d attempts", con_type, attempt)
+		}
+
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		mlog.Error("Failed to ping DB", mlog.String("database", con_type), mlog.Err(err), mlog.Duration("retrying in", delay))
+		time.Sleep(delay)
+		delay *= 2
+	}
 }
 
 func (ss *SqlSupplier) SetContext(context context.Context) {
@@ -285,25 +351,82 @@ func (ss *SqlSupplier) Context() context.Context {
 	return ss.context
 }
 
-func (ss *SqlSupplier) initConnection() {
-	ss.master = setupConnection("master", *ss.settings.DataSource, ss.settings)
+func (ss *SqlSupplier) initConnection() error {
+	var err error
+	ss.master, err = setupConnection("master", *ss.settings.DataSource, ss.settings)
+	if err != nil {
+		return err
+	}
 
 	if len(ss.settings.DataSourceReplicas) > 0 {
 		ss.replicas = make([]*gorp.DbMap, len(ss.settings.DataSourceReplicas))
 		for i, replica := range ss.settings.DataSourceReplicas {
-			ss.replicas[i] = setupConnection(fmt.Sprintf("replica-%v", i), replica, ss.settings)
+			if ss.replicas[i], err = setupConnection(fmt.Sprintf("replica-%v", i), replica, ss.settings); err != nil {
+				ss.closeConnections()
+				return err
+			}
 		}
 	}
 
 	if len(ss.settings.DataSourceSearchReplicas) > 0 {
 		ss.searchReplicas = make([]*gorp.DbMap, len(ss.settings.DataSourceSearchReplicas))
 		for i, replica := range ss.settings.DataSourceSearchReplicas {
-			ss.searchReplicas[i] = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, ss.settings)
+			if ss.searchReplicas[i], err = setupConnection(fmt.Sprintf("search-replica-%v", i), replica, ss.settings); err != nil {
+				ss.closeConnections()
+				return err
+			}
 		}
 	}
+
+	for _, db := range ss.GetAllConns() {
+		db.TraceOn(queryTracePrefix, ss.queryObserver)
+	}
+
+	return nil
+}
+
+// closeConnections closes the connections opened so far by initConnection.
+func (ss *SqlSupplier) closeConnections() {
+	for _, db := range append(append([]*gorp.DbMap{ss.master}, ss.replicas...), ss.searchReplicas...) {
+		if db != nil {
+			db.Db.Close()
+		}
+	}
+}
+
+// SetQueryMetricsCollector replaces the collector recording the duration of the queries,
+// e.g. to inspect them from tests. A nil collector stops recording.
+func (ss *SqlSupplier) SetQueryMetricsCollector(collector QueryMetricsCollector) {
+	ss.queryObserver.setCollector(collector)
+}
+
+// SetTracer reports a span for every query to the tracer, as a child of the span found in
+// the context of the store, e.g. to inspect them from tests. A nil tracer stops tracing.
+func (ss *SqlSupplier) SetTracer(tracer opentracing.Tracer) {
+	ss.queryObserver.setTracer(tracer)
 }
 
+// TraceStatements logs at the debug level the statements executed by the store methods whose
+// name starts with the prefix, such as "PostStore." or "ChannelStore.GetMember", along with
+// their arguments. An empty prefix stops logging the statements. It can be called at any time,
+// e.g. to debug a store method on a running server.
+func (ss *SqlSupplier) TraceStatements(methodNamePrefix string) {
+	ss.queryObserver.setStatementPrefix(methodNamePrefix)
+}
+
+// RedactTracedStatements leaves the arguments out of the statements logged by TraceStatements,
+// along with the literals inlined in the statements, for the values not to be logged.
+func (ss *SqlSupplier) RedactTracedStatements(redact bool) {
+	ss.queryObserver.setRedactArgs(redact)
+}
+
+// DriverName returns the SQL dialect of the database. CockroachDB runs the same queries as
+// Postgres, so it's reported as model.DATABASE_DRIVER_POSTGRES, and the few statements it
+// doesn't support check isCockroach instead.
 func (ss *SqlSupplier) DriverName() string {
+	if ss.isCockroach() {
+		return model.DATABASE_DRIVER_POSTGRES
+	}
 	return *ss.settings.DriverName
 }
 
@@ -314,7 +437,9 @@ func (ss *SqlSupplier) GetCurrentSchemaVersion() string {
 
 func (ss *SqlSupplier) GetDbVersion() (string, error) {
 	var sqlVersion string
-	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
+	if ss.isCockroach() {
+		sqlVersion = `SELECT version()`
+	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
 		sqlVersion = `SHOW server_version`
 	} else if ss.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		sqlVersion = `SELECT version()`
@@ -334,10 +459,25 @@ func (ss *SqlSupplier) GetDbVersion() (string, error) {
 }
 
 func (ss *SqlSupplier) GetMaster() *gorp.DbMap {
+	if ss.isShuttingDown() {
+		return ss.shutdownConn
+	}
 	return ss.master
 }
 
+// GetMasterWithTimeout returns the master database with the query timeout overridden, for the
+// long-running maintenance queries that are expected to exceed SqlSettings.QueryTimeout.
+func (ss *SqlSupplier) GetMasterWithTimeout(timeout time.Duration) *gorp.DbMap {
+	master := *ss.GetMaster()
+	master.QueryTimeout = timeout
+	return &master
+}
+
 func (ss *SqlSupplier) GetSearchReplica() *gorp.DbMap {
+	if ss.isShuttingDown() {
+		return ss.shutdownConn
+	}
+
 	ss.licenseMutex.RLock()
 	license := ss.license
 	ss.licenseMutex.RUnlock()
@@ -354,6 +494,10 @@ func (ss *SqlSupplier) GetSearchReplica() *gorp.DbMap {
 }
 
 func (ss *SqlSupplier) GetReplica() *gorp.DbMap {
+	if ss.isShuttingDown() {
+		return ss.shutdownConn
+	}
+
 	ss.licenseMutex.RLock()
 	license := ss.license
 	ss.licenseMutex.RUnlock()
@@ -843,7 +987,9 @@ func (ss *SqlSupplier) AlterPrimaryKey(tableName string, columnNames []string) b
 	}
 	// alter primary key
 	var alterQuery string
-	if ss.DriverName() == model.DATABASE_DRIVER_MYSQL {
+	if ss.isCockroach() {
+		alterQuery = "ALTER TABLE " + tableName + " ALTER PRIMARY KEY USING COLUMNS (" + strings.ToLower(primaryKey) + ")"
+	} else if ss.DriverName() == model.DATABASE_DRIVER_MYSQL {
 		alterQuery = "ALTER TABLE " + tableName + " DROP PRIMARY KEY, ADD PRIMARY KEY (" + primaryKey + ")"
 	} else if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
 		alterQuery = "ALTER TABLE " + tableName + " DROP CONSTRAINT " + strings.ToLower(tableName) + "_pkey, ADD PRIMARY KEY (" + strings.ToLower(primaryKey) + ")"
@@ -1003,14 +1149,7 @@ func (ss *SqlSupplier) RemoveIndexIfExists(indexName string, tableName string) b
 }
 
 func IsUniqueConstraintError(err error, indexName []string) bool {
-	unique := false
-	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-		unique = true
-	}
-
-	if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
-		unique = true
-	}
+	unique := isUniqueViolation(err)
 
 	field := false
 	for _, contain := range indexName {
@@ -1023,6 +1162,61 @@ func IsUniqueConstraintError(err error, indexName []string) bool {
 	return unique && field
 }
 
+// isUniqueViolation reports whether err is the violation of any unique constraint.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+// isQueryTimeout reports whether err is a query cancelled for running longer than its timeout,
+// either by the context or by the database itself.
+func isQueryTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "57014" {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 3024
+}
+
+// MapQueryTimeout returns an ErrQueryTimeout for the errors of the queries that timed out, and
+// the other errors as they are. The supplier applies it to the errors of every method of its
+// stores, so that the timeouts are reported the same wherever they occur.
+func MapQueryTimeout(err error) error {
+	var timeoutErr *store.ErrQueryTimeout
+	if err == nil || !isQueryTimeout(err) || errors.As(err, &timeoutErr) {
+		return err
+	}
+	return store.NewErrQueryTimeout(err)
+}
+
+// wrapSqlError converts the errors of the database driver into the typed errors of the store,
+// returning an ErrNotFound for sql.ErrNoRows and an ErrConflict for the unique constraint
+// violations, so that the callers can tell them apart with errors.Is or errors.As. The other
+// errors are wrapped with the message. The timeouts are left to MapQueryTimeout.
+func wrapSqlError(err error, resource, id, message string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, dbsql.ErrNoRows):
+		return store.NewErrNotFound(resource, id)
+	case isUniqueViolation(err):
+		return store.NewErrConflict(resource, errors.Wrap(err, message), "")
+	default:
+		return errors.Wrap(err, message)
+	}
+}
+
 func (ss *SqlSupplier) GetAllConns() []*gorp.DbMap {
 	all := make([]*gorp.DbMap, len(ss.replicas)+1)
 	copy(all, ss.replicas)
@@ -1045,12 +1239,17 @@ func (ss *SqlSupplier) RecycleDBConnections(d time.Duration) {
 	for _, conn := range ss.GetAllConns() {
 		conn.Db.SetConnMaxLifetime(originalDuration)
 	}
+	// Drop the statements prepared on the recycled connections.
+	ss.preparedStatements.clear()
 }
 
+// Close shuts the supplier down, giving the running queries SHUTDOWN_TIMEOUT to finish.
 func (ss *SqlSupplier) Close() {
-	ss.master.Db.Close()
-	for _, replica := range ss.replicas {
-		replica.Db.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+	defer cancel()
+
+	if err := ss.Shutdown(ctx); err != nil {
+		mlog.Warn("Closed the database connections before the running queries finished", mlog.Err(err))
 	}
 }
 
@@ -1090,8 +1289,12 @@ func (ss *SqlSupplier) Audit() store.AuditStore {
 	return ss.stores.audit
 }
 
+func (ss *SqlSupplier) AuditTrail() store.AuditTrailStore {
+	return ss.stores.auditTrail
+}
+
 func (ss *SqlSupplier) ClusterDiscovery() store.ClusterDiscoveryStore {
-	return ss.stores.cluster
+	return ss.stores.clusterDiscovery
 }
 
 func (ss *SqlSupplier) Compliance() store.ComplianceStore {
@@ -1099,7 +1302,7 @@ func (ss *SqlSupplier) Compliance() store.ComplianceStore {
 }
 
 func (ss *SqlSupplier) OAuth() store.OAuthStore {
-	return ss.stores.oauth
+	return ss.stores.oAuth
 }
 
 func (ss *SqlSupplier) System() store.SystemStore {
@@ -1175,7 +1378,7 @@ func (ss *SqlSupplier) Role() store.RoleStore {
 }
 
 func (ss *SqlSupplier) TermsOfService() store.TermsOfServiceStore {
-	return ss.stores.TermsOfService
+	return ss.stores.termsOfService
 }
 
 func (ss *SqlSupplier) ProductNotices() store.ProductNoticesStore {
@@ -1183,7 +1386,7 @@ func (ss *SqlSupplier) ProductNotices() store.ProductNoticesStore {
 }
 
 func (ss *SqlSupplier) UserTermsOfService() store.UserTermsOfServiceStore {
-	return ss.stores.UserTermsOfService
+	return ss.stores.userTermsOfService
 }
 
 func (ss *SqlSupplier) Scheme() store.SchemeStore {
@@ -1202,6 +1405,10 @@ func (ss *SqlSupplier) DropAllTables() {
 	ss.master.TruncateTables()
 }
 
+func (ss *SqlSupplier) getSettings() *model.SqlSettings {
+	return ss.settings
+}
+
 func (ss *SqlSupplier) getQueryBuilder() sq.StatementBuilderType {
 	builder := sq.StatementBuilder.PlaceholderFormat(sq.Question)
 	if ss.DriverName() == model.DATABASE_DRIVER_POSTGRES {
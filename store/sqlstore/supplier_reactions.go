@@ -30,6 +30,9 @@ func newSqlReactionStore(sqlStore SqlStore) store.ReactionStore {
 }
 
 func (s *SqlReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	if reaction.CreateAt == 0 {
+		reaction.CreateAt = s.getMillis()
+	}
 	reaction.PreSave()
 	if err := reaction.IsValid(); err != nil {
 		return nil, err
@@ -40,16 +43,14 @@ func (s *SqlReactionStore) Save(reaction *model.Reaction) (*model.Reaction, erro
 		return nil, errors.Wrap(err, "begin_transaction")
 	}
 	defer finalizeTransaction(transaction)
-	err = saveReactionAndUpdatePost(transaction, reaction)
-	if err != nil {
-		// We don't consider duplicated save calls as an error
-		if !IsUniqueConstraintError(err, []string{"reactions_pkey", "PRIMARY"}) {
-			return nil, errors.Wrap(err, "failed while saving reaction or updating post")
-		}
-	} else {
-		if err := transaction.Commit(); err != nil {
-			return nil, errors.Wrap(err, "commit_transaction")
-		}
+
+	// Saving the same reaction again, concurrently or not, isn't an error: the reaction is
+	// only saved once.
+	if err := s.saveReactionAndUpdatePost(transaction, reaction); err != nil {
+		return nil, errors.Wrap(err, "failed while saving reaction or updating post")
+	}
+	if err := transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
 	}
 
 	return reaction, nil
@@ -62,7 +63,7 @@ func (s *SqlReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, er
 	}
 	defer finalizeTransaction(transaction)
 
-	if err := deleteReactionAndUpdatePost(transaction, reaction); err != nil {
+	if err := s.deleteReactionAndUpdatePost(transaction, reaction); err != nil {
 		return nil, errors.Wrap(err, "deleteReactionAndUpdatePost")
 	}
 
@@ -108,6 +109,39 @@ func (s *SqlReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction,
 	return reactions, nil
 }
 
+// GetForPosts returns the reactions of the posts, grouped by post, in a single query. Every
+// post gets an entry, empty if it has no reactions, and the reactions of a post are sorted by
+// creation time and then by emoji name.
+func (s *SqlReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	reactionsByPost := make(map[string][]*model.Reaction, len(postIds))
+	if len(postIds) == 0 {
+		return reactionsByPost, nil
+	}
+
+	keys, params := MapStringsToQueryParams(postIds, "postId")
+	var reactions []*model.Reaction
+
+	if _, err := s.GetReplica().Select(&reactions, `SELECT
+				*
+			FROM
+				Reactions
+			WHERE
+				PostId IN `+keys+`
+			ORDER BY
+				CreateAt, EmojiName, UserId`, params); err != nil {
+		return nil, errors.Wrap(err, "failed to get Reactions")
+	}
+
+	for _, postId := range postIds {
+		reactionsByPost[postId] = []*model.Reaction{}
+	}
+	for _, reaction := range reactions {
+		reactionsByPost[reaction.PostId] = append(reactionsByPost[reaction.PostId], reaction)
+	}
+
+	return reactionsByPost, nil
+}
+
 func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 	var reactions []*model.Reaction
 
@@ -135,7 +169,7 @@ func (s *SqlReactionStore) DeleteAllWithEmojiName(emojiName string) error {
 		_, err := s.GetMaster().Exec(UPDATE_POST_HAS_REACTIONS_ON_DELETE_QUERY,
 			map[string]interface{}{
 				"PostId":   reaction.PostId,
-				"UpdateAt": model.GetMillis(),
+				"UpdateAt": s.getMillis(),
 			})
 		if err != nil {
 			mlog.Warn("Unable to update Post.HasReactions while removing reactions",
@@ -167,15 +201,39 @@ func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int
 	return rowsAffected, nil
 }
 
-func saveReactionAndUpdatePost(transaction *gorp.Transaction, reaction *model.Reaction) error {
-	if err := transaction.Insert(reaction); err != nil {
+// saveReactionAndUpdatePost inserts the reaction unless it already exists, in a single
+// statement so that concurrent saves can't duplicate it, and updates the post when it does.
+func (s *SqlReactionStore) saveReactionAndUpdatePost(transaction *gorp.Transaction, reaction *model.Reaction) error {
+	query := s.getQueryBuilder().
+		Insert("Reactions").
+		Columns("UserId", "PostId", "EmojiName", "CreateAt").
+		Values(reaction.UserId, reaction.PostId, reaction.EmojiName, reaction.CreateAt)
+	if s.DriverName() == model.DATABASE_DRIVER_MYSQL {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE EmojiName = EmojiName")
+	} else {
+		query = query.Suffix("ON CONFLICT (PostId, UserId, EmojiName) DO NOTHING")
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "reaction_tosql")
+	}
+	result, err := transaction.Exec(sql, args...)
+	if err != nil {
 		return err
 	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return nil
+	}
 
-	return updatePostForReactionsOnInsert(transaction, reaction.PostId)
+	return s.updatePostForReactionsOnInsert(transaction, reaction.PostId)
 }
 
-func deleteReactionAndUpdatePost(transaction *gorp.Transaction, reaction *model.Reaction) error {
+func (s *SqlReactionStore) deleteReactionAndUpdatePost(transaction *gorp.Transaction, reaction *model.Reaction) error {
 	if _, err := transaction.Exec(
 		`DELETE FROM
 			Reactions
@@ -187,7 +245,7 @@ func deleteReactionAndUpdatePost(transaction *gorp.Transaction, reaction *model.
 		return err
 	}
 
-	return updatePostForReactionsOnDelete(transaction, reaction.PostId)
+	return s.updatePostForReactionsOnDelete(transaction, reaction.PostId)
 }
 
 const (
@@ -200,13 +258,13 @@ const (
 			Id = :PostId`
 )
 
-func updatePostForReactionsOnDelete(transaction *gorp.Transaction, postId string) error {
-	updateAt := model.GetMillis()
+func (s *SqlReactionStore) updatePostForReactionsOnDelete(transaction *gorp.Transaction, postId string) error {
+	updateAt := s.getMillis()
 	_, err := transaction.Exec(UPDATE_POST_HAS_REACTIONS_ON_DELETE_QUERY, map[string]interface{}{"PostId": postId, "UpdateAt": updateAt})
 	return err
 }
 
-func updatePostForReactionsOnInsert(transaction *gorp.Transaction, postId string) error {
+func (s *SqlReactionStore) updatePostForReactionsOnInsert(transaction *gorp.Transaction, postId string) error {
 	_, err := transaction.Exec(
 		`UPDATE
 			Posts
@@ -215,7 +273,7 @@ func updatePostForReactionsOnInsert(transaction *gorp.Transaction, postId string
 			UpdateAt = :UpdateAt
 		WHERE
 			Id = :PostId`,
-		map[string]interface{}{"PostId": postId, "UpdateAt": model.GetMillis()})
+		map[string]interface{}{"PostId": postId, "UpdateAt": s.getMillis()})
 
 	return err
 }
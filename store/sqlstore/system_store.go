@@ -6,13 +6,12 @@ package sqlstore
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
-	"github.com/mattermost/mattermost-server/v5/utils"
 
 	"github.com/pkg/errors"
 )
@@ -38,7 +37,7 @@ func (s SqlSystemStore) createIndexesIfNotExists() {
 
 func (s SqlSystemStore) Save(system *model.System) error {
 	if err := s.GetMaster().Insert(system); err != nil {
-		return errors.Wrapf(err, "failed to save system property with name=%s", system.Name)
+		return wrapSqlError(err, "System", system.Name, fmt.Sprintf("failed to save system property with name=%s", system.Name))
 	}
 	return nil
 }
@@ -68,7 +67,7 @@ func (s SqlSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System)
 	}
 
 	if strings.HasPrefix(system.Name, model.WARN_METRIC_STATUS_STORE_PREFIX) && (system.Value == model.WARN_METRIC_STATUS_RUNONCE || system.Value == model.WARN_METRIC_STATUS_LIMIT_REACHED) {
-		if err := s.SaveOrUpdate(&model.System{Name: model.SYSTEM_WARN_METRIC_LAST_RUN_TIMESTAMP_KEY, Value: strconv.FormatInt(utils.MillisFromTime(time.Now()), 10)}); err != nil {
+		if err := s.SaveOrUpdate(&model.System{Name: model.SYSTEM_WARN_METRIC_LAST_RUN_TIMESTAMP_KEY, Value: strconv.FormatInt(s.getMillis(), 10)}); err != nil {
 			return errors.Wrapf(err, "failed to save system property with name=%s", model.SYSTEM_WARN_METRIC_LAST_RUN_TIMESTAMP_KEY)
 		}
 	}
@@ -99,7 +98,7 @@ func (s SqlSystemStore) Get() (model.StringMap, error) {
 func (s SqlSystemStore) GetByName(name string) (*model.System, error) {
 	var system model.System
 	if err := s.GetMaster().SelectOne(&system, "SELECT * FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": name}); err != nil {
-		return nil, errors.Wrapf(err, "failed to get system property with name=%s", system.Name)
+		return nil, wrapSqlError(err, "System", name, fmt.Sprintf("failed to get system property with name=%s", name))
 	}
 
 	return &system, nil
@@ -255,6 +255,8 @@ func (s SqlTeamStore) Save(team *model.Team) (*model.Team, error) {
 	}
 
 	team.PreSave()
+	team.CreateAt = s.getMillis()
+	team.UpdateAt = team.CreateAt
 
 	if err := team.IsValid(); err != nil {
 		return nil, err
@@ -275,6 +277,7 @@ func (s SqlTeamStore) Save(team *model.Team) (*model.Team, error) {
 func (s SqlTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 	team.PreUpdate()
+	team.UpdateAt = s.getMillis()
 
 	if err := team.IsValid(); err != nil {
 		return nil, err
@@ -292,7 +295,7 @@ func (s SqlTeamStore) Update(team *model.Team) (*model.Team, error) {
 
 	oldTeam := oldResult.(*model.Team)
 	team.CreateAt = oldTeam.CreateAt
-	team.UpdateAt = model.GetMillis()
+	team.UpdateAt = s.getMillis()
 
 	count, err := s.GetMaster().Update(team)
 	if err != nil {
@@ -1120,6 +1123,41 @@ func (s SqlTeamStore) GetActiveMemberCount(teamId string, restrictions *model.Vi
 	return count, nil
 }
 
+// GetTeamStats returns the number of members of the team along with the number of those that
+// are active, counted in a single query. It applies the view restrictions as
+// GetTotalMemberCount and GetActiveMemberCount do.
+func (s SqlTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"count(DISTINCT TeamMembers.UserId) TotalMemberCount",
+			"count(DISTINCT CASE WHEN Users.DeleteAt = 0 THEN TeamMembers.UserId END) ActiveMemberCount",
+		).
+		From("TeamMembers, Users").
+		Where("TeamMembers.DeleteAt = 0").
+		Where("TeamMembers.UserId = Users.Id").
+		Where(sq.Eq{"TeamMembers.TeamId": teamId})
+
+	query = applyTeamMemberViewRestrictionsFilterForStats(query, teamId, restrictions)
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "team_tosql")
+	}
+
+	var counts struct {
+		TotalMemberCount  int64
+		ActiveMemberCount int64
+	}
+	if err := s.GetReplica().SelectOne(&counts, queryString, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to count TeamMembers")
+	}
+
+	return &model.TeamStats{
+		TeamId:            teamId,
+		TotalMemberCount:  counts.TotalMemberCount,
+		ActiveMemberCount: counts.ActiveMemberCount,
+	}, nil
+}
+
 // GetMembersByIds returns a list of members from the database that matches the teamId and the list of userIds passed as parameters.
 // Expects a restrictions parameter of type ViewUsersRestrictions that defines a set of Teams and Channels that are visible to the caller of the query, and applies restrictions with a filtered result.
 func (s SqlTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
@@ -519,3 +519,39 @@ func testTeamMemberWithSchemeRolesToModel(t *testing.T) {
 		assert.Equal(t, "", m.ExplicitRoles)
 	})
 }
+
+// BenchmarkTeamStats compares counting the members of a team with a query per count, as the
+// team stats used to, with GetTeamStats counting all of them at once.
+func BenchmarkTeamStats(b *testing.B) {
+	for _, st := range storeTypes {
+		teamId := model.NewId()
+		for i := 0; i < 100; i++ {
+			user, err := st.Store.User().Save(&model.User{Email: storetest.MakeEmail(), Username: model.NewId()})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := st.Store.Team().SaveMember(&model.TeamMember{TeamId: teamId, UserId: user.Id}, -1); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.Run(st.Name+"/query per count", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := st.Store.Team().GetTotalMemberCount(teamId, nil); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := st.Store.Team().GetActiveMemberCount(teamId, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(st.Name+"/GetTeamStats", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := st.Store.Team().GetTeamStats(teamId, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -40,13 +40,14 @@ func (s SqlTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*mod
 	}
 
 	termsOfService.PreSave()
+	termsOfService.CreateAt = s.getMillis()
 
 	if err := termsOfService.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(termsOfService); err != nil {
-		return nil, errors.Wrapf(err, "could not save a new TermsOfService")
+		return nil, wrapSqlError(err, "TermsOfService", termsOfService.Id, "could not save a new TermsOfService")
 	}
 
 	return termsOfService, nil
@@ -5,11 +5,10 @@ package sqlstore
 
 import (
 	"database/sql"
-	"time"
+	"fmt"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/store"
-	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/pkg/errors"
 
 	sq "github.com/Masterminds/squirrel"
@@ -108,42 +107,72 @@ func (s *SqlThreadStore) Get(id string) (*model.Thread, error) {
 	return &thread, nil
 }
 
-func (s *SqlThreadStore) GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+func (s *SqlThreadStore) GetThreadsForUser(userId, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
 	type JoinedThread struct {
-		PostId       string
-		ReplyCount   int64
-		LastReplyAt  int64
-		LastViewedAt int64
-		Participants model.StringArray
+		PostId        string
+		ReplyCount    int64
+		LastReplyAt   int64
+		LastViewedAt  int64
+		UnreadReplies int64
+		Participants  model.StringArray
 		model.Post
 	}
 	var threads []*JoinedThread
 
 	fetchConditions := sq.And{
-		sq.Eq{"Posts.UserId": userId},
+		sq.Eq{"ThreadMemberships.UserId": userId},
 		sq.Eq{"ThreadMemberships.Following": true},
 	}
+	if teamId != "" {
+		fetchConditions = append(fetchConditions, sq.Eq{"Channels.TeamId": []string{teamId, ""}})
+	}
 	if !opts.Deleted {
-		fetchConditions = sq.And{fetchConditions, sq.Eq{"Posts.DeleteAt": 0}}
+		fetchConditions = append(fetchConditions, sq.Eq{"Posts.DeleteAt": 0})
 	}
 	if opts.Since > 0 {
-		fetchConditions = sq.And{fetchConditions, sq.GtOrEq{"Threads.LastReplyAt": opts.Since}}
+		fetchConditions = append(fetchConditions, sq.GtOrEq{"Threads.LastReplyAt": opts.Since})
+	}
+
+	// The threads of the channels the user left are kept followed, but aren't returned.
+	fromThreads := func(builder sq.SelectBuilder) sq.SelectBuilder {
+		return builder.
+			From("Threads").
+			Join("Posts ON Posts.Id = Threads.PostId").
+			Join("ThreadMemberships ON ThreadMemberships.PostId = Threads.PostId").
+			Join("ChannelMembers ON ChannelMembers.ChannelId = Threads.ChannelId AND ChannelMembers.UserId = ThreadMemberships.UserId").
+			Join("Channels ON Channels.Id = Threads.ChannelId").
+			Where(fetchConditions)
+	}
+
+	query, args, _ := fromThreads(s.getQueryBuilder().Select("COUNT(Threads.PostId)")).ToSql()
+	total, err := s.GetReplica().SelectInt(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to count threads for user id=%s", userId)
 	}
+
 	pageSize := uint64(30)
-	if opts.PageSize == 0 {
+	if opts.PageSize != 0 {
 		pageSize = opts.PageSize
 	}
-	query, args, _ := s.getQueryBuilder().
+	builder := fromThreads(s.getQueryBuilder().
 		Select("Threads.*, Posts.*, ThreadMemberships.LastViewed as LastViewedAt").
-		From("Threads").
-		LeftJoin("Posts ON Posts.Id = Threads.PostId").
-		LeftJoin("ThreadMemberships ON ThreadMemberships.PostId = Threads.PostId").
-		OrderBy("Threads.LastReplyAt DESC").
-		Offset(pageSize * opts.Page).
-		Limit(pageSize).
-		Where(fetchConditions).ToSql()
-	_, err := s.GetReplica().Select(&threads, query, args...)
-	if err != nil {
+		Column("(SELECT COUNT(Replies.Id) FROM Posts Replies WHERE Replies.RootId = Threads.PostId AND Replies.CreateAt > ThreadMemberships.LastViewed AND Replies.DeleteAt = 0) AS UnreadReplies")).
+		OrderBy("Threads.LastReplyAt DESC", "Threads.PostId DESC").
+		Limit(pageSize)
+	if opts.Before != "" {
+		before, err := s.Get(opts.Before)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.Where(sq.Or{
+			sq.Lt{"Threads.LastReplyAt": before.LastReplyAt},
+			sq.And{sq.Eq{"Threads.LastReplyAt": before.LastReplyAt}, sq.Lt{"Threads.PostId": before.PostId}},
+		})
+	} else {
+		builder = builder.Offset(pageSize * opts.Page)
+	}
+	query, args, _ = builder.ToSql()
+	if _, err := s.GetReplica().Select(&threads, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to get threads for user id=%s", userId)
 	}
 
@@ -171,7 +200,7 @@ func (s *SqlThreadStore) GetThreadsForUser(userId string, opts model.GetUserThre
 	}
 
 	result := &model.Threads{
-		Total:   0,
+		Total:   total,
 		Threads: nil,
 	}
 
@@ -191,12 +220,13 @@ func (s *SqlThreadStore) GetThreadsForUser(userId string, opts model.GetUserThre
 			participants = append(participants, participant)
 		}
 		result.Threads = append(result.Threads, &model.ThreadResponse{
-			PostId:       thread.PostId,
-			ReplyCount:   thread.ReplyCount,
-			LastReplyAt:  thread.LastReplyAt,
-			LastViewedAt: thread.LastViewedAt,
-			Participants: participants,
-			Post:         &thread.Post,
+			PostId:        thread.PostId,
+			ReplyCount:    thread.ReplyCount,
+			LastReplyAt:   thread.LastReplyAt,
+			LastViewedAt:  thread.LastViewedAt,
+			UnreadReplies: thread.UnreadReplies,
+			Participants:  participants,
+			Post:          &thread.Post,
 		})
 	}
 
@@ -230,7 +260,7 @@ func (s *SqlThreadStore) Delete(threadId string) error {
 
 func (s *SqlThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
 	if err := s.GetMaster().Insert(membership); err != nil {
-		return nil, errors.Wrapf(err, "failed to save thread membership with postid=%s userid=%s", membership.PostId, membership.UserId)
+		return nil, wrapSqlError(err, "ThreadMembership", membership.PostId, fmt.Sprintf("failed to save thread membership with postid=%s userid=%s", membership.PostId, membership.UserId))
 	}
 
 	return membership, nil
@@ -275,7 +305,7 @@ func (s *SqlThreadStore) DeleteMembershipForUser(userId string, postId string) e
 
 func (s *SqlThreadStore) CreateMembershipIfNeeded(userId, postId string, following bool) error {
 	membership, err := s.GetMembershipForUser(userId, postId)
-	now := utils.MillisFromTime(time.Now())
+	now := s.getMillis()
 	if err == nil {
 		if !membership.Following || membership.Following != following {
 			membership.Following = following
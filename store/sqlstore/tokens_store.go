@@ -40,7 +40,7 @@ func (s SqlTokenStore) Save(token *model.Token) error {
 	}
 
 	if err := s.GetMaster().Insert(token); err != nil {
-		return errors.Wrap(err, "failed to save Token")
+		return wrapSqlError(err, "Token", token.Token, "failed to save Token")
 	}
 	return nil
 }
@@ -68,7 +68,7 @@ func (s SqlTokenStore) GetByToken(tokenString string) (*model.Token, error) {
 
 func (s SqlTokenStore) Cleanup() {
 	mlog.Debug("Cleaning up token store.")
-	deltime := model.GetMillis() - model.MAX_TOKEN_EXIPRY_TIME
+	deltime := s.getMillis() - model.MAX_TOKEN_EXIPRY_TIME
 	if _, err := s.GetMaster().Exec("DELETE FROM Tokens WHERE CreateAt < :DelTime", map[string]interface{}{"DelTime": deltime}); err != nil {
 		mlog.Error("Unable to cleanup token store.")
 	}
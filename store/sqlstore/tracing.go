@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+// statementLiterals matches the string and number literals of a statement, along with the
+// Postgres placeholders to keep them as they are.
+var statementLiterals = regexp.MustCompile(`\$\d+|'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// sanitizeStatement replaces the literals of a statement with placeholders, for the values
+// inlined by the queries not to be reported, and collapses its whitespace.
+func sanitizeStatement(query string) string {
+	query = statementLiterals.ReplaceAllStringFunc(query, func(literal string) string {
+		if strings.HasPrefix(literal, "$") {
+			return literal
+		}
+		return "?"
+	})
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// traceQuery reports the span of a query that just completed, named after the store method
+// issuing it.
+func (o *queryObserver) traceQuery(tracer opentracing.Tracer, method, query string, elapsed time.Duration) {
+	finished := time.Now()
+	options := []opentracing.StartSpanOption{opentracing.StartTime(finished.Add(-elapsed))}
+	if o.context != nil {
+		if ctx := o.context(); ctx != nil {
+			if parent := opentracing.SpanFromContext(ctx); parent != nil {
+				options = append(options, opentracing.ChildOf(parent.Context()))
+			}
+		}
+	}
+
+	span := tracer.StartSpan(method, options...)
+	ext.Component.Set(span, "sqlstore")
+	ext.DBType.Set(span, "sql")
+	ext.DBStatement.Set(span, sanitizeStatement(query))
+	span.FinishWithOptions(opentracing.FinishOptions{FinishTime: finished})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeStatement(t *testing.T) {
+	sanitizeStatementTests := []struct {
+		Name      string
+		Statement string
+		Expected  string
+	}{
+		{
+			Name:      "Should keep the placeholders",
+			Statement: "SELECT * FROM Posts WHERE ChannelId = $1 AND CreateAt > :CreateAt AND Id = ?",
+			Expected:  "SELECT * FROM Posts WHERE ChannelId = $1 AND CreateAt > :CreateAt AND Id = ?",
+		},
+		{
+			Name:      "Should replace the inlined literals",
+			Statement: "SELECT * FROM Users WHERE Username IN ('alice', 'o''brien') AND DeleteAt = 0 LIMIT 10",
+			Expected:  "SELECT * FROM Users WHERE Username IN (?, ?) AND DeleteAt = ? LIMIT ?",
+		},
+		{
+			Name:      "Should keep the numbers of the identifiers",
+			Statement: "SELECT p1.Id FROM Posts p1 WHERE p1.UpdateAt > 1.5",
+			Expected:  "SELECT p1.Id FROM Posts p1 WHERE p1.UpdateAt > ?",
+		},
+		{
+			Name:      "Should collapse the whitespace",
+			Statement: "SELECT\n\t\tId\n\tFROM   Teams",
+			Expected:  "SELECT Id FROM Teams",
+		},
+	}
+
+	for _, tc := range sanitizeStatementTests {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, sanitizeStatement(tc.Statement))
+		})
+	}
+}
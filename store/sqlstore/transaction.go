@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+const (
+	TRANSACTION_MAX_RETRIES       = 5
+	TRANSACTION_RETRY_MIN_BACKOFF = 10 * time.Millisecond
+	TRANSACTION_RETRY_MAX_BACKOFF = 500 * time.Millisecond
+)
+
+// retryableSQLStates are the SQLSTATEs of the transactions aborted because they conflicted
+// with concurrent ones, and which are expected to succeed when run again.
+var retryableSQLStates = map[pq.ErrorCode]bool{
+	"40001": true, // serialization_failure, also returned by CockroachDB for any conflict.
+	"40P01": true, // deadlock_detected
+}
+
+// mySQLDeadlockErrorNumber is the number of the ER_LOCK_DEADLOCK error, returned by MySQL to
+// the transaction it rolled back to break a deadlock. Lock wait timeouts only roll back the
+// statement, so they aren't retried.
+const mySQLDeadlockErrorNumber = 1213
+
+// TransactionErrorInjector is called before committing every attempt of a retryable
+// transaction, attempts being numbered from 1. Returning an error makes the attempt fail with
+// it instead of committing.
+type TransactionErrorInjector func(attempt int) error
+
+// SetTransactionErrorInjector makes the transactions run by WithRetryableTransaction fail
+// with the errors returned by the injector, so that tests can exercise the retries
+// deterministically. A nil injector stops injecting errors.
+func (ss *SqlSupplier) SetTransactionErrorInjector(injector TransactionErrorInjector) {
+	ss.transactionErrorInjectorMutex.Lock()
+	defer ss.transactionErrorInjectorMutex.Unlock()
+	ss.transactionErrorInjector = injector
+}
+
+func (ss *SqlSupplier) injectTransactionError(attempt int) error {
+	ss.transactionErrorInjectorMutex.RLock()
+	defer ss.transactionErrorInjectorMutex.RUnlock()
+	if ss.transactionErrorInjector == nil {
+		return nil
+	}
+	return ss.transactionErrorInjector(attempt)
+}
+
+func isRetryableTransactionError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return retryableSQLStates[pqErr.Code]
+	}
+
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mySQLDeadlockErrorNumber
+}
+
+// WithRetryableTransaction runs f in a transaction on the master and commits it. When the
+// transaction is aborted because of a conflict with a concurrent one, e.g. a serialization
+// failure under serializable isolation or a deadlock, it's run again from the start, up to
+// TRANSACTION_MAX_RETRIES times and waiting a jittered, exponentially increasing delay between
+// attempts. Other errors are returned right away. f is expected not to have side effects
+// outside of the transaction, since it can be called several times.
+func (ss *SqlSupplier) WithRetryableTransaction(f func(*gorp.Transaction) error) error {
+	backoff := TRANSACTION_RETRY_MIN_BACKOFF
+	for attempt := 1; ; attempt++ {
+		err := ss.runTransaction(f, attempt)
+		if err == nil || !isRetryableTransactionError(err) || attempt > TRANSACTION_MAX_RETRIES {
+			return err
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		mlog.Debug("Retrying aborted transaction", mlog.Int("attempt", attempt), mlog.Duration("delay", delay), mlog.Err(err))
+		time.Sleep(delay)
+
+		backoff *= 2
+		if backoff > TRANSACTION_RETRY_MAX_BACKOFF {
+			backoff = TRANSACTION_RETRY_MAX_BACKOFF
+		}
+	}
+}
+
+func (ss *SqlSupplier) runTransaction(f func(*gorp.Transaction) error, attempt int) error {
+	transaction, err := ss.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransaction(transaction)
+
+	if err := f(transaction); err != nil {
+		return err
+	}
+
+	if err := ss.injectTransactionError(attempt); err != nil {
+		return err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
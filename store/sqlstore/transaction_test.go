@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestWithRetryableTransaction(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	serializationFailure := errors.Wrap(&pq.Error{Code: "40001"}, "failed to save")
+	deadlock := &pq.Error{Code: "40P01"}
+	mysqlDeadlock := errors.Wrap(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}, "failed to save")
+
+	var attempts int
+	var names []string
+	save := func(transaction *gorp.Transaction) error {
+		attempts++
+		name := model.NewId()
+		names = append(names, name)
+		_, err := transaction.Exec("INSERT INTO Systems (Name, Value) VALUES (:Name, 'value')", map[string]interface{}{"Name": name})
+		return err
+	}
+	saved := func(name string) bool {
+		count, err := supplier.GetMaster().SelectInt("SELECT COUNT(*) FROM Systems WHERE Name = :Name", map[string]interface{}{"Name": name})
+		require.NoError(t, err)
+		return count == 1
+	}
+	failAttempts := func(errs ...error) {
+		attempts = 0
+		names = nil
+		supplier.SetTransactionErrorInjector(func(attempt int) error {
+			if attempt <= len(errs) {
+				return errs[attempt-1]
+			}
+			return nil
+		})
+	}
+	defer supplier.SetTransactionErrorInjector(nil)
+
+	t.Run("commits without retrying when the transaction succeeds", func(t *testing.T) {
+		failAttempts()
+		require.NoError(t, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, 1, attempts)
+		assert.True(t, saved(names[0]))
+	})
+
+	t.Run("retries the retryable errors, discarding the failed attempts", func(t *testing.T) {
+		failAttempts(serializationFailure, deadlock)
+		require.NoError(t, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, 3, attempts)
+		assert.False(t, saved(names[0]))
+		assert.False(t, saved(names[1]))
+		assert.True(t, saved(names[2]))
+	})
+
+	t.Run("retries the MySQL deadlocks", func(t *testing.T) {
+		failAttempts(mysqlDeadlock)
+		require.NoError(t, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, 2, attempts)
+		assert.False(t, saved(names[0]))
+		assert.True(t, saved(names[1]))
+	})
+
+	t.Run("gives up after the maximum number of retries", func(t *testing.T) {
+		errs := make([]error, TRANSACTION_MAX_RETRIES+1)
+		for i := range errs {
+			errs[i] = serializationFailure
+		}
+		failAttempts(errs...)
+		assert.Equal(t, serializationFailure, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, TRANSACTION_MAX_RETRIES+1, attempts)
+	})
+
+	t.Run("returns other errors right away", func(t *testing.T) {
+		otherErr := &pq.Error{Code: "23505"}
+		failAttempts(otherErr)
+		assert.Equal(t, otherErr, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, 1, attempts)
+		assert.False(t, saved(names[0]))
+	})
+
+	t.Run("returns other MySQL errors right away", func(t *testing.T) {
+		duplicateErr := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+		failAttempts(duplicateErr)
+		assert.Equal(t, duplicateErr, supplier.WithRetryableTransaction(save))
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("returns the errors of the closure", func(t *testing.T) {
+		failAttempts()
+		closureErr := errors.New("failed")
+		err := supplier.WithRetryableTransaction(func(transaction *gorp.Transaction) error {
+			attempts++
+			return closureErr
+		})
+		assert.Equal(t, closureErr, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestWithRetryableTransactionMySQLDeadlock(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_MYSQL)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := NewSqlSupplier(*settings, nil)
+	require.NoError(t, err)
+	defer supplier.Close()
+
+	_, err = supplier.GetMaster().Exec("CREATE TABLE DeadlockTest (Id INT PRIMARY KEY, Value INT NOT NULL)")
+	require.NoError(t, err)
+	defer supplier.GetMaster().Exec("DROP TABLE DeadlockTest")
+	_, err = supplier.GetMaster().Exec("INSERT INTO DeadlockTest (Id, Value) VALUES (1, 0), (2, 0)")
+	require.NoError(t, err)
+
+	// Each transaction locks a row, then waits for the other one to lock the other row before
+	// trying to lock it, so that MySQL rolls one of them back to break the deadlock.
+	var locked sync.WaitGroup
+	locked.Add(2)
+	var attempts int32
+	update := func(first, second int) func(*gorp.Transaction) error {
+		var once sync.Once
+		return func(transaction *gorp.Transaction) error {
+			atomic.AddInt32(&attempts, 1)
+			if _, err := transaction.Exec("UPDATE DeadlockTest SET Value = Value + 1 WHERE Id = ?", first); err != nil {
+				return err
+			}
+			once.Do(func() {
+				locked.Done()
+				locked.Wait()
+			})
+			_, err := transaction.Exec("UPDATE DeadlockTest SET Value = Value + 1 WHERE Id = ?", second)
+			return err
+		}
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- supplier.WithRetryableTransaction(update(1, 2)) }()
+	go func() { errs <- supplier.WithRetryableTransaction(update(2, 1)) }()
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+
+	assert.Greater(t, atomic.LoadInt32(&attempts), int32(2), "the transaction rolled back should have been retried")
+	for _, id := range []int{1, 2} {
+		value, err := supplier.GetMaster().SelectInt("SELECT Value FROM DeadlockTest WHERE Id = ?", id)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, value)
+	}
+}
@@ -85,6 +85,8 @@ const (
 	EXIT_TEAM_INVITEID_MIGRATION_FAILED = 1006
 )
 
+const postsPinnedAtBackfillBatchSize = 1000
+
 // upgradeDatabase attempts to migrate the schema to the latest supported version.
 // The value of model.CurrentVersion is accepted as a parameter for unit testing, but it is not
 // used to stop migrations at that version.
@@ -94,15 +96,6 @@ func upgradeDatabase(sqlStore SqlStore, currentModelVersionString string) error
 		return errors.Wrapf(err, "failed to parse current model version %s", currentModelVersionString)
 	}
 
-	nextUnsupportedMajorVersion := semver.Version{
-		Major: currentModelVersion.Major + 1,
-	}
-
-	oldestSupportedVersion, err := semver.Parse(OLDEST_SUPPORTED_VERSION)
-	if err != nil {
-		return errors.Wrapf(err, "failed to parse oldest supported version %s", OLDEST_SUPPORTED_VERSION)
-	}
-
 	var currentSchemaVersion *semver.Version
 	currentSchemaVersionString := sqlStore.GetCurrentSchemaVersion()
 	if currentSchemaVersionString != "" {
@@ -123,16 +116,8 @@ func upgradeDatabase(sqlStore SqlStore, currentModelVersionString string) error
 		return nil
 	}
 
-	// Upgrades prior to the oldest supported version are not supported.
-	if currentSchemaVersion.LT(oldestSupportedVersion) {
-		return errors.Errorf("Database schema version %s is no longer supported. This Mattermost server supports automatic upgrades from schema version %s through schema version %s. Please manually upgrade to at least version %s before continuing.", *currentSchemaVersion, oldestSupportedVersion, currentModelVersion, oldestSupportedVersion)
-	}
-
-	// Allow forwards compatibility only within the same major version.
-	if currentSchemaVersion.GTE(nextUnsupportedMajorVersion) {
-		return errors.Errorf("Database schema version %s is not supported. This Mattermost server supports only >=%s, <%s. Please upgrade to at least version %s before continuing.", *currentSchemaVersion, currentModelVersion, nextUnsupportedMajorVersion, nextUnsupportedMajorVersion)
-	} else if currentSchemaVersion.GT(currentModelVersion) {
-		mlog.Warn("The database schema version and model versions do not match", mlog.String("schema_version", currentSchemaVersion.String()), mlog.String("model_version", currentModelVersion.String()))
+	if err := checkSchemaVersion(*currentSchemaVersion, currentModelVersion); err != nil {
+		return err
 	}
 
 	// Otherwise, apply any necessary migrations. Note that these methods currently invoke
@@ -871,10 +856,37 @@ func upgradeDatabaseToVersion530(sqlStore SqlStore) {
 
 	sqlStore.CreateColumnIfNotExistsNoDefault("FileInfo", "Content", "longtext", "text")
 
+	if sqlStore.CreateColumnIfNotExists("Posts", "PinnedAt", "bigint(20)", "bigint", "0") {
+		backfillPostsPinnedAt(sqlStore)
+	}
+
 	// saveSchemaVersion(sqlStore, VERSION_5_30_0)
 	// }
 }
 
+// backfillPostsPinnedAt sets PinnedAt on the posts pinned before it existed, in batches of ids
+// for no statement to lock the whole table. The time a post was pinned wasn't recorded, so
+// they get the time they were last updated instead, and their order is only approximate.
+func backfillPostsPinnedAt(sqlStore SqlStore) {
+	lastId := ""
+	for {
+		var ids []string
+		if _, err := sqlStore.GetMaster().Select(&ids, "SELECT Id FROM Posts WHERE IsPinned = true AND PinnedAt = 0 AND Id > :LastId ORDER BY Id LIMIT :Limit", map[string]interface{}{"LastId": lastId, "Limit": postsPinnedAtBackfillBatchSize}); err != nil {
+			mlog.Error("Error finding the pinned Posts without PinnedAt", mlog.Err(err))
+			return
+		}
+		if len(ids) == 0 {
+			return
+		}
+
+		if _, err := sqlStore.GetMaster().Exec("UPDATE Posts SET PinnedAt = UpdateAt WHERE IsPinned = true AND PinnedAt = 0 AND Id > :FromId AND Id <= :ToId", map[string]interface{}{"FromId": lastId, "ToId": ids[len(ids)-1]}); err != nil {
+			mlog.Error("Error setting PinnedAt on the pinned Posts", mlog.Err(err))
+			return
+		}
+		lastId = ids[len(ids)-1]
+	}
+}
+
 func precheckMigrationToVersion528(sqlStore SqlStore) error {
 	teamsQuery, _, err := sqlStore.getQueryBuilder().Select(`COALESCE(SUM(CASE
 				WHEN CHAR_LENGTH(SchemeId) > 26 THEN 1
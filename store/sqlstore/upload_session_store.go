@@ -44,12 +44,15 @@ func (us SqlUploadSessionStore) Save(session *model.UploadSession) (*model.Uploa
 	if session == nil {
 		return nil, errors.New("SqlUploadSessionStore.Save: session should not be nil")
 	}
+	if session.CreateAt == 0 {
+		session.CreateAt = us.getMillis()
+	}
 	session.PreSave()
 	if err := session.IsValid(); err != nil {
 		return nil, errors.Wrap(err, "SqlUploadSessionStore.Save: validation failed")
 	}
 	if err := us.GetMaster().Insert(session); err != nil {
-		return nil, errors.Wrap(err, "SqlUploadSessionStore.Save: failed to insert")
+		return nil, wrapSqlError(err, "UploadSession", session.Id, "SqlUploadSessionStore.Save: failed to insert")
 	}
 	return session, nil
 }
@@ -45,7 +45,7 @@ func (s SqlUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.User
 	}
 
 	if err := s.GetMaster().Insert(token); err != nil {
-		return nil, errors.Wrap(err, "failed to save UserAccessToken")
+		return nil, wrapSqlError(err, "UserAccessToken", token.Id, "failed to save UserAccessToken")
 	}
 	return token, nil
 }
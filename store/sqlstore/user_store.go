@@ -104,6 +104,9 @@ func (us SqlUserStore) Save(user *model.User) (*model.User, error) {
 	}
 
 	user.PreSave()
+	user.CreateAt = us.getMillis()
+	user.UpdateAt = user.CreateAt
+	user.LastPasswordUpdate = user.CreateAt
 	if err := user.IsValid(); err != nil {
 		return nil, err
 	}
@@ -122,7 +125,7 @@ func (us SqlUserStore) Save(user *model.User) (*model.User, error) {
 }
 
 func (us SqlUserStore) DeactivateGuests() ([]string, error) {
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 	updateQuery := us.getQueryBuilder().Update("Users").
 		Set("UpdateAt", curTime).
 		Set("DeleteAt", curTime).
@@ -157,6 +160,7 @@ func (us SqlUserStore) DeactivateGuests() ([]string, error) {
 
 func (us SqlUserStore) Update(user *model.User, trustedUpdateData bool) (*model.UserUpdate, error) {
 	user.PreUpdate()
+	user.UpdateAt = us.getMillis()
 
 	if err := user.IsValid(); err != nil {
 		return nil, err
@@ -225,7 +229,7 @@ func (us SqlUserStore) Update(user *model.User, trustedUpdateData bool) (*model.
 }
 
 func (us SqlUserStore) UpdateLastPictureUpdate(userId string) error {
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET LastPictureUpdate = :Time, UpdateAt = :Time WHERE Id = :UserId", map[string]interface{}{"Time": curTime, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -235,7 +239,7 @@ func (us SqlUserStore) UpdateLastPictureUpdate(userId string) error {
 }
 
 func (us SqlUserStore) ResetLastPictureUpdate(userId string) error {
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET LastPictureUpdate = :PictureUpdateTime, UpdateAt = :UpdateTime WHERE Id = :UserId", map[string]interface{}{"PictureUpdateTime": 0, "UpdateTime": curTime, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -245,7 +249,7 @@ func (us SqlUserStore) ResetLastPictureUpdate(userId string) error {
 }
 
 func (us SqlUserStore) UpdateUpdateAt(userId string) (int64, error) {
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET UpdateAt = :Time WHERE Id = :UserId", map[string]interface{}{"Time": curTime, "UserId": userId}); err != nil {
 		return curTime, errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -255,7 +259,7 @@ func (us SqlUserStore) UpdateUpdateAt(userId string) (int64, error) {
 }
 
 func (us SqlUserStore) UpdatePassword(userId, hashedPassword string) error {
-	updateAt := model.GetMillis()
+	updateAt := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET Password = :Password, LastPasswordUpdate = :LastPasswordUpdate, UpdateAt = :UpdateAt, AuthData = NULL, AuthService = '', FailedAttempts = 0 WHERE Id = :UserId", map[string]interface{}{"Password": hashedPassword, "LastPasswordUpdate": updateAt, "UpdateAt": updateAt, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -264,6 +268,17 @@ func (us SqlUserStore) UpdatePassword(userId, hashedPassword string) error {
 	return nil
 }
 
+// UpdatePasswordHash replaces the hash of the password of the user by another hash of the same
+// password, leaving the time of the last password update as is. The hash isn't replaced if it's
+// no longer oldHash, such as when the password was changed in the meantime.
+func (us SqlUserStore) UpdatePasswordHash(userId, oldHash, newHash string) error {
+	if _, err := us.GetMaster().Exec("UPDATE Users SET Password = :NewHash WHERE Id = :UserId AND Password = :OldHash", map[string]interface{}{"NewHash": newHash, "UserId": userId, "OldHash": oldHash}); err != nil {
+		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
+	}
+
+	return nil
+}
+
 func (us SqlUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
 	if _, err := us.GetMaster().Exec("UPDATE Users SET FailedAttempts = :FailedAttempts WHERE Id = :UserId", map[string]interface{}{"FailedAttempts": attempts, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -273,7 +288,7 @@ func (us SqlUserStore) UpdateFailedPasswordAttempts(userId string, attempts int)
 }
 
 func (us SqlUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
-	updateAt := model.GetMillis()
+	updateAt := us.getMillis()
 
 	query := `
 			UPDATE
@@ -306,7 +321,7 @@ func (us SqlUserStore) UpdateAuthData(userId string, service string, authData *s
 }
 
 func (us SqlUserStore) UpdateMfaSecret(userId, secret string) error {
-	updateAt := model.GetMillis()
+	updateAt := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET MfaSecret = :Secret, UpdateAt = :UpdateAt WHERE Id = :UserId", map[string]interface{}{"Secret": secret, "UpdateAt": updateAt, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -316,7 +331,7 @@ func (us SqlUserStore) UpdateMfaSecret(userId, secret string) error {
 }
 
 func (us SqlUserStore) UpdateMfaActive(userId string, active bool) error {
-	updateAt := model.GetMillis()
+	updateAt := us.getMillis()
 
 	if _, err := us.GetMaster().Exec("UPDATE Users SET MfaActive = :Active, UpdateAt = :UpdateAt WHERE Id = :UserId", map[string]interface{}{"Active": active, "UpdateAt": updateAt, "UserId": userId}); err != nil {
 		return errors.Wrapf(err, "failed to update User with userId=%s", userId)
@@ -326,7 +341,14 @@ func (us SqlUserStore) UpdateMfaActive(userId string, active bool) error {
 }
 
 func (us SqlUserStore) Get(id string) (*model.User, error) {
+	return us.GetUser(id, true)
+}
+
+func (us SqlUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
 	query := us.usersQuery.Where("Id = ?", id)
+	if !includeDeleted {
+		query = query.Where("u.DeleteAt = 0")
+	}
 	queryString, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "users_get_tosql")
@@ -1187,7 +1209,7 @@ func (us SqlUserStore) GetForLogin(loginId string, allowSignInWithUsername, allo
 }
 
 func (us SqlUserStore) VerifyEmail(userId, email string) (string, error) {
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 	if _, err := us.GetMaster().Exec("UPDATE Users SET Email = lower(:email), EmailVerified = true, UpdateAt = :Time WHERE Id = :UserId", map[string]interface{}{"email": email, "Time": curTime, "UserId": userId}); err != nil {
 		return "", errors.Wrapf(err, "failed to update Users with userId=%s and email=%s", userId, email)
 	}
@@ -1248,7 +1270,7 @@ func (us SqlUserStore) Count(options model.UserCountOptions) (int64, error) {
 
 func (us SqlUserStore) AnalyticsActiveCount(timePeriod int64, options model.UserCountOptions) (int64, error) {
 
-	time := model.GetMillis() - timePeriod
+	time := us.getMillis() - timePeriod
 	query := us.getQueryBuilder().Select("COUNT(*)").From("Status AS s").Where("LastActivityAt > :Time", map[string]interface{}{"Time": time})
 
 	if !options.IncludeBotAccounts {
@@ -1816,7 +1838,7 @@ func (us SqlUserStore) PromoteGuestToUser(userId string) error {
 		}
 	}
 
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 	query := us.getQueryBuilder().Update("Users").
 		Set("Roles", strings.Join(roles, " ")).
 		Set("UpdateAt", curTime).
@@ -1888,7 +1910,7 @@ func (us SqlUserStore) DemoteUserToGuest(userId string) error {
 		}
 	}
 
-	curTime := model.GetMillis()
+	curTime := us.getMillis()
 	query := us.getQueryBuilder().Update("Users").
 		Set("Roles", strings.Join(newRoles, " ")).
 		Set("UpdateAt", curTime).
@@ -47,6 +47,7 @@ func (s SqlUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOf
 
 func (s SqlUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
 	userTermsOfService.PreSave()
+	userTermsOfService.CreateAt = s.getMillis()
 
 	if err := userTermsOfService.IsValid(); err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/pkg/errors"
 
@@ -82,12 +83,14 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 	}
 
 	webhook.PreSave()
+	webhook.CreateAt = s.getMillis()
+	webhook.UpdateAt = webhook.CreateAt
 	if err := webhook.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(webhook); err != nil {
-		return nil, errors.Wrapf(err, "failed to save IncomingWebhook with id=%s", webhook.Id)
+		return nil, wrapSqlError(err, "IncomingWebhook", webhook.Id, fmt.Sprintf("failed to save IncomingWebhook with id=%s", webhook.Id))
 	}
 
 	return webhook, nil
@@ -95,7 +98,7 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 }
 
 func (s SqlWebhookStore) UpdateIncoming(hook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
-	hook.UpdateAt = model.GetMillis()
+	hook.UpdateAt = s.getMillis()
 
 	if _, err := s.GetMaster().Update(hook); err != nil {
 		return nil, errors.Wrapf(err, "failed to update IncomingWebhook with id=%s", hook.Id)
@@ -218,12 +221,14 @@ func (s SqlWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.Ou
 	}
 
 	webhook.PreSave()
+	webhook.CreateAt = s.getMillis()
+	webhook.UpdateAt = webhook.CreateAt
 	if err := webhook.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if err := s.GetMaster().Insert(webhook); err != nil {
-		return nil, errors.Wrapf(err, "failed to save OutgoingWebhook with id=%s", webhook.Id)
+		return nil, wrapSqlError(err, "OutgoingWebhook", webhook.Id, fmt.Sprintf("failed to save OutgoingWebhook with id=%s", webhook.Id))
 	}
 
 	return webhook, nil
@@ -373,7 +378,7 @@ func (s SqlWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) erro
 }
 
 func (s SqlWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
-	hook.UpdateAt = model.GetMillis()
+	hook.UpdateAt = s.getMillis()
 
 	if _, err := s.GetMaster().Update(hook); err != nil {
 		return nil, errors.Wrapf(err, "failed to update OutgoingWebhook with id=%s", hook.Id)
@@ -28,6 +28,7 @@ type Store interface {
 	User() UserStore
 	Bot() BotStore
 	Audit() AuditStore
+	AuditTrail() AuditTrailStore
 	ClusterDiscovery() ClusterDiscoveryStore
 	Compliance() ComplianceStore
 	Session() SessionStore
@@ -67,6 +68,13 @@ type Store interface {
 	TotalReadDbConnections() int
 	TotalSearchDbConnections() int
 	CheckIntegrity() <-chan model.IntegrityCheckResult
+	PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error)
+	// FindOrphans returns the ids of up to limit orphaned records of one of model.OrphanTypes.
+	FindOrphans(entityType string, limit int) ([]string, error)
+	// DeleteOrphans deletes the orphaned records of one of model.OrphanTypes, in transactions
+	// of up to batchSize records, and returns how many were deleted.
+	DeleteOrphans(entityType string, batchSize int) (int64, error)
+	HealthCheck(ctx context.Context) error
 	SetContext(context context.Context)
 	Context() context.Context
 }
@@ -103,6 +111,8 @@ type TeamStore interface {
 	GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error)
 	GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error)
 	GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error)
+	// GetTeamStats returns the total and active member counts of the team in a single query.
+	GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error)
 	GetTeamsForUser(ctx context.Context, userId string) ([]*model.TeamMember, error)
 	GetTeamsForUserWithPagination(userId string, page, perPage int) ([]*model.TeamMember, error)
 	GetChannelUnreadsForAllTeams(excludeTeamId, userId string) ([]*model.ChannelUnread, error)
@@ -140,6 +150,11 @@ type ChannelStore interface {
 	UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error
 	ClearSidebarOnTeamLeave(userId, teamId string) error
 	Get(id string, allowFromCache bool) (*model.Channel, error)
+	// GetCtx is Get aborting the query once ctx is done, as are the other Ctx variants.
+	GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error)
+	// GetChannel returns the channel, reporting an archived channel as not found unless
+	// includeDeleted is set.
+	GetChannel(id string, includeDeleted bool) (*model.Channel, error)
 	InvalidateChannel(id string)
 	InvalidateChannelByName(teamId, name string)
 	GetFromMaster(id string) (*model.Channel, error)
@@ -154,6 +169,7 @@ type ChannelStore interface {
 	GetDeletedByName(team_id string, name string) (*model.Channel, error)
 	GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error)
 	GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error)
+	GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error)
 	GetAllChannels(page, perPage int, opts ChannelSearchOpts) (*model.ChannelListWithTeamData, error)
 	GetAllChannelsCount(opts ChannelSearchOpts) (int64, error)
 	GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error)
@@ -166,10 +182,17 @@ type ChannelStore interface {
 	GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error)
 	GetForPost(postId string) (*model.Channel, error)
 	SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error)
+	SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error)
 	SaveMember(member *model.ChannelMember) (*model.ChannelMember, error)
 	UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error)
 	UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error)
 	GetMembers(channelId string, offset, limit int) (*model.ChannelMembers, error)
+	// GetMembersSince returns the members of the channel added or updated at or after since, and
+	// the users removed from it at or after since that aren't members again.
+	GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error)
+	// GetMembersPaged returns a page of the members of a channel sorted by username or by role,
+	// along with the total number of members.
+	GetMembersPaged(channelId string, page, perPage int, sort string) (*model.ChannelMembers, int64, error)
 	GetMember(channelId string, userId string) (*model.ChannelMember, error)
 	GetChannelMembersTimezones(channelId string) ([]model.StringMap, error)
 	GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, error)
@@ -186,12 +209,14 @@ type ChannelStore interface {
 	GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error)
 	InvalidateGuestCount(channelId string)
 	GetGuestCount(channelId string, allowFromCache bool) (int64, error)
-	GetPinnedPosts(channelId string) (*model.PostList, error)
 	RemoveMember(channelId string, userId string) error
 	RemoveMembers(channelId string, userIds []string) error
 	PermanentDeleteMembersByUser(userId string) error
 	PermanentDeleteMembersByChannel(channelId string) error
 	UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error)
+	// UpdateLastViewedAtMulti marks the channels as read up to the last post created at or before
+	// timestamp in a single transaction, returning their unread counts afterwards.
+	UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error)
 	UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error)
 	CountPostsAfter(channelId string, timestamp int64, userId string) (int, error)
 	IncrementMentionCount(channelId string, userId string, updateThreads bool) error
@@ -209,6 +234,7 @@ type ChannelStore interface {
 	GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, error)
 	AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, error)
 	GetChannelUnread(channelId, userId string) (*model.ChannelUnread, error)
+	GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error)
 	ClearCaches()
 	GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, error)
 	MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error)
@@ -251,7 +277,9 @@ type ThreadStore interface {
 	Save(thread *model.Thread) (*model.Thread, error)
 	Update(thread *model.Thread) (*model.Thread, error)
 	Get(id string) (*model.Thread, error)
-	GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error)
+	// GetThreadsForUser returns the threads the user follows in the channels the user is a member
+	// of, limited to those of the team and of the direct and group messages unless teamId is empty.
+	GetThreadsForUser(userId, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error)
 	Delete(postId string) error
 
 	MarkAllAsRead(userId string, timestamp int64) error
@@ -273,17 +301,39 @@ type PostStore interface {
 	Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error)
 	Get(id string, skipFetchThreads bool) (*model.PostList, error)
 	GetSingle(id string) (*model.Post, error)
+	// GetSingleCtx is GetSingle aborting the query once ctx is done, as are the other Ctx variants.
+	GetSingleCtx(ctx context.Context, id string) (*model.Post, error)
+	// GetPost returns the post, reporting a deleted post as not found unless includeDeleted is set.
+	GetPost(id string, includeDeleted bool) (*model.Post, error)
 	Delete(postId string, time int64, deleteByID string) error
 	PermanentDeleteByUser(userId string) error
 	PermanentDeleteByChannel(channelId string) error
+	// MoveThread moves the root post and the replies of a thread to the target channel, which
+	// must be of the same team unless allowCrossTeam is set, and returns the moved posts.
+	MoveThread(rootId, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error)
 	GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error)
+	GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error)
 	GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error)
 	// @openTracingParams userId, teamId, offset, limit
 	GetFlaggedPostsForTeam(userId, teamId string, offset int, limit int) (*model.PostList, error)
 	GetFlaggedPostsForChannel(userId, channelId string, offset int, limit int) (*model.PostList, error)
+	// GetPinnedPosts returns the pinned posts of the channel, ordered from the most recently
+	// pinned.
+	GetPinnedPosts(channelId string) (*model.PostList, error)
 	GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error)
 	GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error)
+	GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error)
+	GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error)
+	// GetRecentPostsForUser returns the page of the most recent posts, ordered from the newest,
+	// across the channels the user is a member of, and the cursor of the next page. The returned
+	// cursor is empty once there are no more posts.
+	GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error)
+	// GetPostWithContext returns the post along with up to before posts created before it and
+	// after posts created after it in its channel, ordered from the newest, and the root and the
+	// other replies of its thread if it's a reply, which are left out of the order.
+	GetPostWithContext(postId string, before, after int) (*model.PostList, error)
 	GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error)
+	GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error)
 	GetPostAfterTime(channelId string, time int64) (*model.Post, error)
 	GetPostIdAfterTime(channelId string, time int64) (string, error)
 	GetPostIdBeforeTime(channelId string, time int64) (string, error)
@@ -291,6 +341,7 @@ type PostStore interface {
 	Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error)
 	AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error)
 	AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error)
+	AnalyticsCountByDay(teamId string, startTime, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error)
 	AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error)
 	ClearCaches()
 	InvalidateLastPostTimeCache(channelId string)
@@ -298,6 +349,7 @@ type PostStore interface {
 	Overwrite(post *model.Post) (*model.Post, error)
 	OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error)
 	GetPostsByIds(postIds []string) ([]*model.Post, error)
+	GetPostsByProp(channelId, key, value string) ([]*model.Post, error)
 	GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
@@ -305,7 +357,15 @@ type PostStore interface {
 	GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error)
 	GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error)
+	// GetPostsBatched calls fn with all the posts of the channel in batches of at most batchSize
+	// posts, oldest first, so that the caller never holds all of them at once.
+	GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error
 	SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error)
+	// SearchPostsInTeamForUserAfter pages through the results of a search with a cursor rather
+	// than an offset, returning the page following searchAfter, or the first one if it's nil.
+	SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error)
+	SuggestTerms(userId, teamId, prefix string, limit int) ([]*model.SearchSuggestion, error)
+	SearchAllTeams(userId, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 }
 
@@ -315,11 +375,15 @@ type UserStore interface {
 	UpdateLastPictureUpdate(userId string) error
 	ResetLastPictureUpdate(userId string) error
 	UpdatePassword(userId, newPassword string) error
+	UpdatePasswordHash(userId, oldHash, newHash string) error
 	UpdateUpdateAt(userId string) (int64, error)
 	UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error)
 	UpdateMfaSecret(userId, secret string) error
 	UpdateMfaActive(userId string, active bool) error
 	Get(id string) (*model.User, error)
+	// GetUser returns the user, reporting a deactivated user as not found unless includeDeleted
+	// is set.
+	GetUser(id string, includeDeleted bool) (*model.User, error)
 	GetAll() ([]*model.User, error)
 	ClearCaches()
 	InvalidateProfilesInChannelCacheByUser(userId string)
@@ -413,6 +477,15 @@ type AuditStore interface {
 	PermanentDeleteByUser(userId string) error
 }
 
+// AuditTrailStore holds the record of the changes made to the store. The entries are only ever
+// inserted, along with the changes that succeeded.
+type AuditTrailStore interface {
+	// Record makes a change, saving its entry if the change succeeded. The change reports whether
+	// it succeeded, and may set the id of the entity of the entry once it's known.
+	Record(entry *model.AuditTrailEntry, change func() bool) error
+	GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error)
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -517,6 +590,7 @@ type CommandWebhookStore interface {
 
 type PreferenceStore interface {
 	Save(preferences *model.Preferences) error
+	SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error)
 	GetCategory(userId string, category string) (model.Preferences, error)
 	Get(userId string, category string, name string) (*model.Preference, error)
 	GetAll(userId string) (model.Preferences, error)
@@ -548,6 +622,10 @@ type EmojiStore interface {
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, time int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
+	// GetUsageCounts returns the usage of each of the named emojis, keyed by name, with zero
+	// counts for the unused ones. The usages within the post messages are only counted when
+	// includeMessages is set, since matching them scans the posts.
+	GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error)
 }
 
 type StatusStore interface {
@@ -563,6 +641,7 @@ type FileInfoStore interface {
 	Save(info *model.FileInfo) (*model.FileInfo, error)
 	Upsert(info *model.FileInfo) (*model.FileInfo, error)
 	Get(id string) (*model.FileInfo, error)
+	GetByIds(ids []string) ([]*model.FileInfo, error)
 	GetByPath(path string) (*model.FileInfo, error)
 	GetForPost(postId string, readFromMaster, includeDeleted, allowFromCache bool) ([]*model.FileInfo, error)
 	GetForUser(userId string) ([]*model.FileInfo, error)
@@ -574,6 +653,7 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(userId string) (int64, error)
 	SetContent(fileId, content string) error
+	Search(paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error)
 	ClearCaches()
 }
 
@@ -592,6 +672,9 @@ type ReactionStore interface {
 	DeleteAllWithEmojiName(emojiName string) error
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	BulkGetForPosts(postIds []string) ([]*model.Reaction, error)
+	// GetForPosts returns the reactions of the posts grouped by post, with an empty entry for
+	// the posts without reactions.
+	GetForPosts(postIds []string) (map[string][]*model.Reaction, error)
 }
 
 type JobStore interface {
@@ -599,6 +682,10 @@ type JobStore interface {
 	UpdateOptimistically(job *model.Job, currentStatus string) (bool, error)
 	UpdateStatus(id string, status string) (*model.Job, error)
 	UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error)
+	// ClaimPending moves the oldest pending job of the type in progress on behalf of the node
+	// and returns it. When several nodes claim concurrently, each job is claimed by one of them
+	// only. It returns a store.ErrNotFound when no job of the type is pending.
+	ClaimPending(jobType string, nodeId string) (*model.Job, error)
 	Get(id string) (*model.Job, error)
 	GetAllPage(offset int, limit int) ([]*model.Job, error)
 	GetAllByType(jobType string) ([]*model.Job, error)
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestAuditTrailStore(t *testing.T, ss store.Store) {
+	t.Run("Record", func(t *testing.T) { testAuditTrailStoreRecord(t, ss) })
+	t.Run("GetByEntity", func(t *testing.T) { testAuditTrailStoreGetByEntity(t, ss) })
+}
+
+func testAuditTrailStoreRecord(t *testing.T, ss store.Store) {
+	t.Run("saves the entry of a successful change", func(t *testing.T) {
+		entry := &model.AuditTrailEntry{
+			ActorId:    model.NewId(),
+			EntityType: "Channel",
+			EntityId:   model.NewId(),
+			Operation:  "ChannelStore.Update",
+		}
+		changed := false
+		err := ss.AuditTrail().Record(entry, func() bool {
+			changed = true
+			return true
+		})
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.NotEmpty(t, entry.Id)
+		assert.NotZero(t, entry.CreateAt)
+
+		entries, err := ss.AuditTrail().GetByEntity("Channel", entry.EntityId, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.AuditTrailEntry{entry}, entries)
+	})
+
+	t.Run("sets the entity id known once the change is made", func(t *testing.T) {
+		entry := &model.AuditTrailEntry{EntityType: "Post", Operation: "PostStore.Save"}
+		postId := model.NewId()
+		err := ss.AuditTrail().Record(entry, func() bool {
+			entry.EntityId = postId
+			return true
+		})
+		require.NoError(t, err)
+
+		entries, err := ss.AuditTrail().GetByEntity("Post", postId, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, entry.Id, entries[0].Id)
+	})
+
+	t.Run("doesn't save the entry of a failed change", func(t *testing.T) {
+		entry := &model.AuditTrailEntry{EntityType: "User", EntityId: model.NewId(), Operation: "UserStore.Update"}
+		err := ss.AuditTrail().Record(entry, func() bool { return false })
+		require.NoError(t, err)
+
+		entries, err := ss.AuditTrail().GetByEntity("User", entry.EntityId, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("doesn't make the change of an invalid entry", func(t *testing.T) {
+		changed := false
+		err := ss.AuditTrail().Record(&model.AuditTrailEntry{EntityType: "Channel"}, func() bool {
+			changed = true
+			return true
+		})
+		require.Error(t, err, "the operation should be required")
+		assert.False(t, changed)
+	})
+}
+
+func testAuditTrailStoreGetByEntity(t *testing.T, ss store.Store) {
+	entityId := model.NewId()
+	var saved []*model.AuditTrailEntry
+	for i, operation := range []string{"UserStore.Save", "UserStore.Update", "UserStore.UpdatePassword"} {
+		entry := &model.AuditTrailEntry{
+			CreateAt:   int64(1000 + i),
+			EntityType: "User",
+			EntityId:   entityId,
+			Operation:  operation,
+		}
+		require.NoError(t, ss.AuditTrail().Record(entry, func() bool { return true }))
+		saved = append(saved, entry)
+	}
+	err := ss.AuditTrail().Record(&model.AuditTrailEntry{EntityType: "Team", EntityId: entityId, Operation: "TeamStore.Update"}, func() bool { return true })
+	require.NoError(t, err)
+
+	entries, err := ss.AuditTrail().GetByEntity("User", entityId, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, saved, entries)
+
+	entries, err = ss.AuditTrail().GetByEntity("User", entityId, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, saved[1:2], entries)
+
+	entries, err = ss.AuditTrail().GetByEntity("User", model.NewId(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = ss.AuditTrail().GetByEntity("User", entityId, 0, 1001)
+	var oobErr *store.ErrOutOfBounds
+	assert.True(t, errors.As(err, &oobErr))
+}
@@ -355,6 +355,10 @@ func testBotStoreSave(t *testing.T, ss store.Store) {
 		actualNewBot, nErr := ss.Bot().Get(bot.UserId, false)
 		require.Nil(t, nErr)
 		require.Equal(t, bot, actualNewBot)
+
+		// Verify a second bot for the same user conflicts.
+		_, nErr = ss.Bot().Save(bot)
+		require.True(t, errors.Is(nErr, &store.ErrConflict{Resource: "Bot"}), nErr)
 	})
 }
 
@@ -43,7 +43,10 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("CreateDirectChannel", func(t *testing.T) { testChannelStoreCreateDirectChannel(t, ss) })
 	t.Run("Update", func(t *testing.T) { testChannelStoreUpdate(t, ss) })
 	t.Run("GetChannelUnread", func(t *testing.T) { testGetChannelUnread(t, ss) })
+	t.Run("GetUnreadCountsForUser", func(t *testing.T) { testGetUnreadCountsForUser(t, ss) })
+	t.Run("UpdateLastViewedAtMulti", func(t *testing.T) { testUpdateLastViewedAtMulti(t, ss) })
 	t.Run("Get", func(t *testing.T) { testChannelStoreGet(t, ss, s) })
+	t.Run("GetChannel", func(t *testing.T) { testChannelStoreGetChannel(t, ss) })
 	t.Run("GetChannelsByIds", func(t *testing.T) { testChannelStoreGetChannelsByIds(t, ss) })
 	t.Run("GetForPost", func(t *testing.T) { testChannelStoreGetForPost(t, ss) })
 	t.Run("Restore", func(t *testing.T) { testChannelStoreRestore(t, ss) })
@@ -55,6 +58,7 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("ChannelMemberStore", func(t *testing.T) { testChannelMemberStore(t, ss) })
 	t.Run("SaveMember", func(t *testing.T) { testChannelSaveMember(t, ss) })
 	t.Run("SaveMultipleMembers", func(t *testing.T) { testChannelSaveMultipleMembers(t, ss) })
+	t.Run("SaveMemberMultiple", func(t *testing.T) { testChannelSaveMemberMultiple(t, ss) })
 	t.Run("UpdateMember", func(t *testing.T) { testChannelUpdateMember(t, ss) })
 	t.Run("UpdateMultipleMembers", func(t *testing.T) { testChannelUpdateMultipleMembers(t, ss) })
 	t.Run("RemoveMember", func(t *testing.T) { testChannelRemoveMember(t, ss) })
@@ -82,8 +86,11 @@ func TestChannelStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("SearchInTeam", func(t *testing.T) { testChannelStoreSearchInTeam(t, ss, s) })
 	t.Run("SearchArchivedInTeam", func(t *testing.T) { testChannelStoreSearchArchivedInTeam(t, ss, s) })
 	t.Run("SearchForUserInTeam", func(t *testing.T) { testChannelStoreSearchForUserInTeam(t, ss) })
+	t.Run("AutocompleteInTeamForSearch", func(t *testing.T) { testChannelStoreAutocompleteInTeamForSearch(t, ss) })
 	t.Run("SearchAllChannels", func(t *testing.T) { testChannelStoreSearchAllChannels(t, ss) })
 	t.Run("GetMembersByIds", func(t *testing.T) { testChannelStoreGetMembersByIds(t, ss) })
+	t.Run("GetMembersPaged", func(t *testing.T) { testChannelStoreGetMembersPaged(t, ss) })
+	t.Run("GetMembersSince", func(t *testing.T) { testChannelStoreGetMembersSince(t, ss) })
 	t.Run("SearchGroupChannels", func(t *testing.T) { testChannelStoreSearchGroupChannels(t, ss) })
 	t.Run("AnalyticsDeletedTypeCount", func(t *testing.T) { testChannelStoreAnalyticsDeletedTypeCount(t, ss) })
 	t.Run("GetPinnedPosts", func(t *testing.T) { testChannelStoreGetPinnedPosts(t, ss) })
@@ -362,6 +369,146 @@ func testGetChannelUnread(t *testing.T, ss store.Store) {
 	require.EqualValues(t, 10, ch2.MsgCount, "wrong MsgCount for channel 2")
 }
 
+func testGetUnreadCountsForUser(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	lastViewedAt := model.GetMillis() - 10000
+
+	saveChannel := func(channelType string, notifyProps model.StringMap) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, Name: model.NewId(), DisplayName: "Name", Type: channelType}, -1)
+		require.Nil(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: notifyProps, LastViewedAt: lastViewedAt, MentionCount: 2})
+		require.Nil(t, err)
+		return channel
+	}
+	savePost := func(post *model.Post) *model.Post {
+		if post.UserId == "" {
+			post.UserId = otherUserId
+		}
+		if post.CreateAt == 0 {
+			post.CreateAt = lastViewedAt + 1000
+		}
+		post.Message = "message"
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		return post
+	}
+
+	channel := saveChannel(model.CHANNEL_OPEN, model.GetDefaultChannelNotifyProps())
+	root := savePost(&model.Post{ChannelId: channel.Id})
+	savePost(&model.Post{ChannelId: channel.Id, RootId: root.Id, ParentId: root.Id})
+	savePost(&model.Post{ChannelId: channel.Id})
+	// Neither the read posts, the deleted ones, the ones of the user nor the join messages are unread.
+	savePost(&model.Post{ChannelId: channel.Id, CreateAt: lastViewedAt - 1000})
+	savePost(&model.Post{ChannelId: channel.Id, DeleteAt: lastViewedAt + 2000})
+	savePost(&model.Post{ChannelId: channel.Id, UserId: userId})
+	savePost(&model.Post{ChannelId: channel.Id, Type: model.POST_JOIN_CHANNEL})
+
+	mutedProps := model.GetDefaultChannelNotifyProps()
+	mutedProps[model.MARK_UNREAD_NOTIFY_PROP] = model.CHANNEL_MARK_UNREAD_MENTION
+	muted := saveChannel(model.CHANNEL_OPEN, mutedProps)
+	savePost(&model.Post{ChannelId: muted.Id})
+
+	empty := saveChannel(model.CHANNEL_PRIVATE, model.GetDefaultChannelNotifyProps())
+
+	direct, err := ss.Channel().SaveDirectChannel(
+		&model.Channel{Name: model.GetDMNameFromIds(userId, otherUserId), DisplayName: "Direct", Type: model.CHANNEL_DIRECT},
+		&model.ChannelMember{UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps(), LastViewedAt: lastViewedAt},
+		&model.ChannelMember{UserId: otherUserId, NotifyProps: model.GetDefaultChannelNotifyProps()},
+	)
+	require.Nil(t, err)
+	directRoot := savePost(&model.Post{ChannelId: direct.Id})
+	savePost(&model.Post{ChannelId: direct.Id, RootId: directRoot.Id, ParentId: directRoot.Id})
+	savePost(&model.Post{ChannelId: direct.Id})
+	savePost(&model.Post{ChannelId: direct.Id, UserId: userId})
+
+	archived := saveChannel(model.CHANNEL_OPEN, model.GetDefaultChannelNotifyProps())
+	savePost(&model.Post{ChannelId: archived.Id})
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	counts, err := ss.Channel().GetUnreadCountsForUser(userId)
+	require.Nil(t, err)
+	require.Len(t, counts, 4)
+
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: channel.Id, MsgCount: 3, MsgCountRoot: 2, MentionCount: 2}, counts[channel.Id])
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: muted.Id, MentionCount: 2, Muted: true}, counts[muted.Id], "the posts of a muted channel shouldn't be unread")
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: empty.Id, MentionCount: 2}, counts[empty.Id], "a channel without posts should have no unread posts")
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: direct.Id, MsgCount: 3, MsgCountRoot: 2, MentionCount: 3}, counts[direct.Id], "every post of the other user of a direct channel should be a mention")
+	assert.NotContains(t, counts, archived.Id)
+
+	counts, err = ss.Channel().GetUnreadCountsForUser(model.NewId())
+	require.Nil(t, err)
+	assert.Empty(t, counts)
+}
+
+func testUpdateLastViewedAtMulti(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	now := model.GetMillis()
+
+	saveChannel := func() *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{TeamId: model.NewId(), Name: model.NewId(), DisplayName: "Name", Type: model.CHANNEL_OPEN}, -1)
+		require.Nil(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: userId, NotifyProps: model.GetDefaultChannelNotifyProps(), LastViewedAt: now - 10000, MentionCount: 2})
+		require.Nil(t, err)
+		return channel
+	}
+	savePost := func(channelId string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: otherUserId, Message: "message", CreateAt: createAt})
+		require.Nil(t, err)
+		return post
+	}
+	requireMember := func(channelId string, lastViewedAt, unread, mentionCount int64) {
+		channel, err := ss.Channel().Get(channelId, false)
+		require.Nil(t, err)
+		member, err := ss.Channel().GetMember(channelId, userId)
+		require.Nil(t, err)
+		assert.Equal(t, lastViewedAt, member.LastViewedAt)
+		assert.Equal(t, channel.TotalMsgCount-unread, member.MsgCount)
+		assert.Equal(t, mentionCount, member.MentionCount)
+	}
+
+	// The first channel gets a post after the time it's marked read at, the second one doesn't.
+	channel1 := saveChannel()
+	savePost(channel1.Id, now-3000)
+	read1 := savePost(channel1.Id, now-2000)
+	savePost(channel1.Id, now)
+	channel2 := saveChannel()
+	read2 := savePost(channel2.Id, now-1500)
+
+	counts, err := ss.Channel().UpdateLastViewedAtMulti([]string{channel1.Id, channel2.Id}, userId, now-1000)
+	require.Nil(t, err)
+	require.Len(t, counts, 2)
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: channel1.Id, MsgCount: 1, MsgCountRoot: 1, MentionCount: 2}, counts[channel1.Id])
+	assert.Equal(t, &model.ChannelUnreadCounts{ChannelId: channel2.Id}, counts[channel2.Id])
+
+	requireMember(channel1.Id, read1.CreateAt, 1, 2)
+	requireMember(channel2.Id, read2.CreateAt, 0, 0)
+
+	t.Run("doesn't mark the channels unread again", func(t *testing.T) {
+		_, err := ss.Channel().UpdateLastViewedAtMulti([]string{channel1.Id, channel2.Id}, userId, now-5000)
+		require.Nil(t, err)
+
+		requireMember(channel1.Id, read1.CreateAt, 1, 2)
+		requireMember(channel2.Id, read2.CreateAt, 0, 0)
+	})
+
+	t.Run("marks the remaining posts read", func(t *testing.T) {
+		counts, err := ss.Channel().UpdateLastViewedAtMulti([]string{channel1.Id}, userId, now)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]*model.ChannelUnreadCounts{channel1.Id: {ChannelId: channel1.Id}}, counts)
+
+		requireMember(channel1.Id, now, 0, 0)
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		counts, err := ss.Channel().UpdateLastViewedAtMulti([]string{}, userId, now)
+		require.Nil(t, err)
+		assert.Empty(t, counts)
+	})
+}
+
 func testChannelStoreGet(t *testing.T, ss store.Store, s SqlSupplier) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
@@ -434,6 +581,41 @@ func testChannelStoreGet(t *testing.T, ss store.Store, s SqlSupplier) {
 	s.GetMaster().Exec("TRUNCATE Channels")
 }
 
+func testChannelStoreGetChannel(t *testing.T, ss store.Store) {
+	o1, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Name",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+
+	channel, err := ss.Channel().GetChannel(o1.Id, false)
+	require.Nil(t, err)
+	require.Equal(t, o1.Id, channel.Id)
+
+	require.Nil(t, ss.Channel().Delete(o1.Id, model.GetMillis()))
+
+	t.Run("excludes the archived channel by default", func(t *testing.T) {
+		_, err := ss.Channel().GetChannel(o1.Id, false)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("includes the archived channel when requested", func(t *testing.T) {
+		channel, err := ss.Channel().GetChannel(o1.Id, true)
+		require.Nil(t, err)
+		require.Equal(t, o1.Id, channel.Id)
+		require.NotZero(t, channel.DeleteAt)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := ss.Channel().GetChannel(model.NewId(), true)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
 func testChannelStoreGetChannelsByIds(t *testing.T, ss store.Store) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
@@ -537,6 +719,9 @@ func testChannelStoreGetForPost(t *testing.T, ss store.Store) {
 	channel, chanErr := ss.Channel().GetForPost(p1.Id)
 	require.Nil(t, chanErr, chanErr)
 	require.Equal(t, o1.Id, channel.Id, "incorrect channel returned")
+
+	_, chanErr = ss.Channel().GetForPost(model.NewId())
+	require.True(t, errors.Is(chanErr, &store.ErrNotFound{}), chanErr)
 }
 
 func testChannelStoreRestore(t *testing.T, ss store.Store) {
@@ -1394,6 +1579,84 @@ func testChannelSaveMember(t *testing.T, ss store.Store) {
 	})
 }
 
+func testChannelSaveMemberMultiple(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{Username: model.NewId(), Email: MakeEmail()})
+	require.Nil(t, err)
+	u2, err := ss.User().Save(&model.User{Username: model.NewId(), Email: MakeEmail()})
+	require.Nil(t, err)
+
+	team, err := ss.Team().Save(&model.Team{DisplayName: "Name", Name: "zz" + model.NewId(), Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.Nil(t, err)
+	c1, nErr := ss.Channel().Save(&model.Channel{DisplayName: "DisplayName", Name: "z-z-z" + model.NewId() + "b", Type: model.CHANNEL_OPEN, TeamId: team.Id}, -1)
+	require.Nil(t, nErr)
+	c2, nErr := ss.Channel().Save(&model.Channel{DisplayName: "DisplayName", Name: "z-z-z" + model.NewId() + "b", Type: model.CHANNEL_OPEN, TeamId: team.Id}, -1)
+	require.Nil(t, nErr)
+
+	t.Run("rejects invalid members", func(t *testing.T) {
+		m := &model.ChannelMember{ChannelId: "wrong", UserId: u1.Id, NotifyProps: model.GetDefaultChannelNotifyProps()}
+		_, _, nErr := ss.Channel().SaveMemberMultiple([]*model.ChannelMember{m})
+		require.NotNil(t, nErr)
+		var appErr *model.AppError
+		require.True(t, errors.As(nErr, &appErr))
+		require.Equal(t, "model.channel_member.is_valid.channel_id.app_error", appErr.Id)
+	})
+
+	t.Run("creates new members", func(t *testing.T) {
+		created, updated, nErr := ss.Channel().SaveMemberMultiple([]*model.ChannelMember{
+			{ChannelId: c1.Id, UserId: u1.Id, SchemeUser: true, NotifyProps: model.GetDefaultChannelNotifyProps()},
+			{ChannelId: c2.Id, UserId: u1.Id, SchemeUser: true, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		})
+		require.Nil(t, nErr)
+		assert.Empty(t, updated)
+		require.Len(t, created, 2)
+		assert.Equal(t, c1.Id, created[0].ChannelId)
+		assert.Equal(t, c2.Id, created[1].ChannelId)
+		assert.Equal(t, "channel_user", created[0].Roles)
+
+		count, nErr := ss.Channel().GetMemberCount(c1.Id, false)
+		require.Nil(t, nErr)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("updates existing members and keeps their read state", func(t *testing.T) {
+		member, nErr := ss.Channel().GetMember(c1.Id, u1.Id)
+		require.Nil(t, nErr)
+		member.MsgCount = 10
+		_, nErr = ss.Channel().UpdateMember(member)
+		require.Nil(t, nErr)
+
+		notifyProps := model.GetDefaultChannelNotifyProps()
+		notifyProps[model.DESKTOP_NOTIFY_PROP] = model.CHANNEL_NOTIFY_NONE
+		created, updated, nErr := ss.Channel().SaveMemberMultiple([]*model.ChannelMember{
+			{ChannelId: c1.Id, UserId: u1.Id, SchemeUser: true, SchemeAdmin: true, NotifyProps: notifyProps},
+			{ChannelId: c1.Id, UserId: u2.Id, SchemeUser: true, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		})
+		require.Nil(t, nErr)
+		require.Len(t, created, 1)
+		assert.Equal(t, u2.Id, created[0].UserId)
+		require.Len(t, updated, 1)
+		assert.Equal(t, u1.Id, updated[0].UserId)
+		assert.Equal(t, "channel_user channel_admin", updated[0].Roles)
+		assert.Equal(t, model.CHANNEL_NOTIFY_NONE, updated[0].NotifyProps[model.DESKTOP_NOTIFY_PROP])
+		assert.Equal(t, int64(10), updated[0].MsgCount)
+
+		count, nErr := ss.Channel().GetMemberCount(c1.Id, false)
+		require.Nil(t, nErr)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("keeps the last of duplicated members", func(t *testing.T) {
+		created, updated, nErr := ss.Channel().SaveMemberMultiple([]*model.ChannelMember{
+			{ChannelId: c2.Id, UserId: u2.Id, SchemeUser: true, NotifyProps: model.GetDefaultChannelNotifyProps()},
+			{ChannelId: c2.Id, UserId: u2.Id, SchemeUser: true, SchemeAdmin: true, NotifyProps: model.GetDefaultChannelNotifyProps()},
+		})
+		require.Nil(t, nErr)
+		assert.Empty(t, updated)
+		require.Len(t, created, 1)
+		assert.Equal(t, "channel_user channel_admin", created[0].Roles)
+	})
+}
+
 func testChannelSaveMultipleMembers(t *testing.T, ss store.Store) {
 	u1, err := ss.User().Save(&model.User{Username: model.NewId(), Email: MakeEmail()})
 	require.Nil(t, err)
@@ -4335,6 +4598,7 @@ func testChannelStoreGetMemberForPost(t *testing.T, ss store.Store) {
 
 	_, err = ss.Channel().GetMemberForPost(p1.Id, model.NewId())
 	require.NotNil(t, err, "shouldn't have returned a member")
+	require.True(t, errors.Is(err, &store.ErrNotFound{}), err)
 }
 
 func testGetMemberCount(t *testing.T, ss store.Store) {
@@ -5161,6 +5425,68 @@ func testChannelStoreSearchInTeam(t *testing.T, ss store.Store, s SqlSupplier) {
 	}
 }
 
+func testChannelStoreAutocompleteInTeamForSearch(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	teamId := model.NewId()
+	otherTeamId := model.NewId()
+
+	save := func(teamId, displayName, channelType string, members ...string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: displayName,
+			Name:        "zz" + model.NewId() + "b",
+			Type:        channelType,
+		}, -1)
+		require.Nil(t, err)
+		for _, member := range members {
+			_, err = ss.Channel().SaveMember(&model.ChannelMember{
+				ChannelId:   channel.Id,
+				UserId:      member,
+				NotifyProps: model.GetDefaultChannelNotifyProps(),
+			})
+			require.Nil(t, err)
+		}
+		return channel
+	}
+
+	open := save(teamId, "Town Square", model.CHANNEL_OPEN, userId, otherUserId)
+	private := save(teamId, "Town Private", model.CHANNEL_PRIVATE, userId)
+	save(teamId, "Town Hidden", model.CHANNEL_PRIVATE, otherUserId)
+	save(teamId, "Town Unjoined", model.CHANNEL_OPEN, otherUserId)
+	save(otherTeamId, "Town Elsewhere", model.CHANNEL_OPEN, userId)
+	save(teamId, "Off Topic", model.CHANNEL_OPEN, userId)
+	archived := save(teamId, "Town Archived", model.CHANNEL_OPEN, userId)
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	autocompleteAndCheck := func(t *testing.T, term string, includeDeleted bool, expected []*model.Channel) {
+		channels, err := ss.Channel().AutocompleteInTeamForSearch(teamId, userId, term, includeDeleted)
+		require.Nil(t, err)
+
+		expectedIds := []string{}
+		for _, channel := range expected {
+			expectedIds = append(expectedIds, channel.Id)
+		}
+		ids := []string{}
+		for _, channel := range *channels {
+			ids = append(ids, channel.Id)
+		}
+		assert.ElementsMatch(t, expectedIds, ids)
+	}
+
+	t.Run("should only return the channels of the team the user is a member of", func(t *testing.T) {
+		autocompleteAndCheck(t, "town", false, []*model.Channel{open, private})
+	})
+
+	t.Run("should leave out the private channels the user can't see", func(t *testing.T) {
+		autocompleteAndCheck(t, "hidden", false, []*model.Channel{})
+	})
+
+	t.Run("should include the archived channels when requested", func(t *testing.T) {
+		autocompleteAndCheck(t, "town", true, []*model.Channel{open, private, archived})
+	})
+}
+
 func testChannelStoreSearchForUserInTeam(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	teamId := model.NewId()
@@ -5546,6 +5872,182 @@ func testChannelStoreGetMembersByIds(t *testing.T, ss store.Store) {
 	require.NotNil(t, nErr, "empty user ids - should have failed")
 }
 
+func testChannelStoreGetMembersSince(t *testing.T, ss store.Store) {
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Channel",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+
+	saveMember := func(userId string) {
+		_, err := ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.Nil(t, err)
+	}
+	userIds := func(members model.ChannelMembers) []string {
+		ids := []string{}
+		for _, member := range members {
+			ids = append(ids, member.UserId)
+		}
+		return ids
+	}
+
+	unchanged := model.NewId()
+	removedBefore := model.NewId()
+	updated := model.NewId()
+	removed := model.NewId()
+	readded := model.NewId()
+	for _, userId := range []string{unchanged, removedBefore, updated, removed, readded} {
+		saveMember(userId)
+	}
+	require.Nil(t, ss.Channel().RemoveMember(channel.Id, removedBefore))
+
+	time.Sleep(10 * time.Millisecond)
+	since := model.GetMillis()
+	time.Sleep(10 * time.Millisecond)
+
+	added := model.NewId()
+	saveMember(added)
+	member, err := ss.Channel().GetMember(channel.Id, updated)
+	require.Nil(t, err)
+	member.NotifyProps[model.DESKTOP_NOTIFY_PROP] = model.CHANNEL_NOTIFY_MENTION
+	_, err = ss.Channel().UpdateMember(member)
+	require.Nil(t, err)
+	require.Nil(t, ss.Channel().RemoveMember(channel.Id, removed))
+	require.Nil(t, ss.Channel().RemoveMember(channel.Id, readded))
+	saveMember(readded)
+
+	t.Run("returns the changes since the time", func(t *testing.T) {
+		changes, err := ss.Channel().GetMembersSince(channel.Id, since)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{added, updated, readded}, userIds(changes.Members))
+		assert.Equal(t, []string{removed}, changes.RemovedUserIds)
+	})
+
+	t.Run("returns every member and removal since the beginning", func(t *testing.T) {
+		changes, err := ss.Channel().GetMembersSince(channel.Id, 0)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []string{unchanged, updated, readded, added}, userIds(changes.Members))
+		assert.ElementsMatch(t, []string{removedBefore, removed}, changes.RemovedUserIds)
+	})
+
+	t.Run("returns nothing after the last change", func(t *testing.T) {
+		changes, err := ss.Channel().GetMembersSince(channel.Id, model.GetMillis()+1)
+		require.Nil(t, err)
+		assert.Empty(t, changes.Members)
+		assert.Empty(t, changes.RemovedUserIds)
+	})
+
+	t.Run("records the removal of the deactivated users", func(t *testing.T) {
+		user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: "u" + model.NewId()})
+		require.Nil(t, err)
+		saveMember(user.Id)
+		user.DeleteAt = model.GetMillis()
+		_, err = ss.User().Update(user, true)
+		require.Nil(t, err)
+
+		removedAt := model.GetMillis()
+		require.Nil(t, ss.Channel().RemoveAllDeactivatedMembers(channel.Id))
+
+		changes, err := ss.Channel().GetMembersSince(channel.Id, removedAt)
+		require.Nil(t, err)
+		assert.Equal(t, []string{user.Id}, changes.RemovedUserIds)
+	})
+
+	t.Run("forgets the removals of a deleted channel", func(t *testing.T) {
+		require.Nil(t, ss.Channel().PermanentDeleteMembersByChannel(channel.Id))
+
+		changes, err := ss.Channel().GetMembersSince(channel.Id, 0)
+		require.Nil(t, err)
+		assert.Empty(t, changes.Members)
+		assert.Empty(t, changes.RemovedUserIds)
+	})
+}
+
+func testChannelStoreGetMembersPaged(t *testing.T, ss store.Store) {
+	channel, nErr := ss.Channel().Save(&model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Paged",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, nErr)
+
+	prefix := model.NewId()[:10]
+	var userIds []string
+	for _, name := range []string{"guest", "admin", "member"} {
+		user, err := ss.User().Save(&model.User{Username: name + prefix, Email: MakeEmail()})
+		require.Nil(t, err)
+		defer func() { require.Nil(t, ss.User().PermanentDelete(user.Id)) }()
+		userIds = append(userIds, user.Id)
+	}
+	guestId, adminId, memberId := userIds[0], userIds[1], userIds[2]
+
+	for _, member := range []*model.ChannelMember{
+		{ChannelId: channel.Id, UserId: guestId, SchemeGuest: true},
+		{ChannelId: channel.Id, UserId: adminId, SchemeUser: true, SchemeAdmin: true},
+		{ChannelId: channel.Id, UserId: memberId, SchemeUser: true},
+	} {
+		member.NotifyProps = model.GetDefaultChannelNotifyProps()
+		_, err := ss.Channel().SaveMember(member)
+		require.Nil(t, err)
+	}
+
+	memberUserIds := func(members *model.ChannelMembers) []string {
+		var ids []string
+		for _, member := range *members {
+			ids = append(ids, member.UserId)
+		}
+		return ids
+	}
+
+	t.Run("sorts by username", func(t *testing.T) {
+		members, count, err := ss.Channel().GetMembersPaged(channel.Id, 0, 10, model.CHANNEL_SORT_BY_USERNAME)
+		require.Nil(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, []string{adminId, guestId, memberId}, memberUserIds(members))
+
+		members, _, err = ss.Channel().GetMembersPaged(channel.Id, 0, 10, "")
+		require.Nil(t, err)
+		assert.Equal(t, []string{adminId, guestId, memberId}, memberUserIds(members))
+	})
+
+	t.Run("sorts by role", func(t *testing.T) {
+		members, _, err := ss.Channel().GetMembersPaged(channel.Id, 0, 10, model.CHANNEL_SORT_BY_ROLE)
+		require.Nil(t, err)
+		assert.Equal(t, []string{adminId, memberId, guestId}, memberUserIds(members))
+		assert.Contains(t, (*members)[0].Roles, model.CHANNEL_ADMIN_ROLE_ID)
+	})
+
+	t.Run("pages through the members", func(t *testing.T) {
+		members, count, err := ss.Channel().GetMembersPaged(channel.Id, 0, 2, model.CHANNEL_SORT_BY_ROLE)
+		require.Nil(t, err)
+		assert.Equal(t, int64(3), count, "the count should not depend on the page")
+		assert.Equal(t, []string{adminId, memberId}, memberUserIds(members))
+
+		members, count, err = ss.Channel().GetMembersPaged(channel.Id, 1, 2, model.CHANNEL_SORT_BY_ROLE)
+		require.Nil(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, []string{guestId}, memberUserIds(members))
+
+		members, _, err = ss.Channel().GetMembersPaged(channel.Id, 2, 2, model.CHANNEL_SORT_BY_ROLE)
+		require.Nil(t, err)
+		assert.Empty(t, *members)
+	})
+
+	t.Run("rejects an unknown sort", func(t *testing.T) {
+		_, _, err := ss.Channel().GetMembersPaged(channel.Id, 0, 10, "status")
+		var invalidErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invalidErr))
+		assert.Equal(t, "sort", invalidErr.Field)
+	})
+}
+
 func testChannelStoreSearchGroupChannels(t *testing.T, ss store.Store) {
 	// Users
 	u1 := &model.User{}
@@ -5799,7 +6301,7 @@ func testChannelStoreGetPinnedPosts(t *testing.T, ss store.Store) {
 	})
 	require.Nil(t, err)
 
-	pl, errGet := ss.Channel().GetPinnedPosts(o1.Id)
+	pl, errGet := ss.Post().GetPinnedPosts(o1.Id)
 	require.Nil(t, errGet, errGet)
 	require.NotNil(t, pl.Posts[p1.Id], "didn't return relevant pinned posts")
 
@@ -5820,7 +6322,7 @@ func testChannelStoreGetPinnedPosts(t *testing.T, ss store.Store) {
 	})
 	require.Nil(t, err)
 
-	pl, errGet = ss.Channel().GetPinnedPosts(o2.Id)
+	pl, errGet = ss.Post().GetPinnedPosts(o2.Id)
 	require.Nil(t, errGet, errGet)
 	require.Empty(t, pl.Posts, "wasn't supposed to return posts")
 
@@ -5857,13 +6359,79 @@ func testChannelStoreGetPinnedPosts(t *testing.T, ss store.Store) {
 		require.Nil(t, err)
 		time.Sleep(time.Millisecond)
 
-		posts, err := ss.Channel().GetPinnedPosts(channelId)
+		posts, err := ss.Post().GetPinnedPosts(channelId)
 		require.Nil(t, err)
 		require.Len(t, posts.Posts, 3)
 		require.Equal(t, posts.Posts[post1.Id].ReplyCount, int64(1))
 		require.Equal(t, posts.Posts[post2.Id].ReplyCount, int64(0))
 		require.Equal(t, posts.Posts[post3.Id].ReplyCount, int64(1))
 	})
+
+	savePosts := func(t *testing.T) (string, []*model.Post) {
+		channelId := model.NewId()
+		userId := model.NewId()
+
+		posts := make([]*model.Post, 3)
+		for i := range posts {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: channelId,
+				UserId:    userId,
+				Message:   "message",
+			})
+			require.Nil(t, err)
+			posts[i] = post
+			time.Sleep(time.Millisecond)
+		}
+		return channelId, posts
+	}
+	setPinned := func(t *testing.T, posts []*model.Post, i int, pinned bool) {
+		newPost := posts[i].Clone()
+		newPost.IsPinned = pinned
+		updated, err := ss.Post().Update(newPost, posts[i].Clone())
+		require.Nil(t, err)
+		posts[i] = updated
+		time.Sleep(time.Millisecond)
+	}
+	requireOrder := func(t *testing.T, channelId string, posts []*model.Post, expected ...int) {
+		pl, err := ss.Post().GetPinnedPosts(channelId)
+		require.Nil(t, err)
+		order := []string{}
+		for _, i := range expected {
+			order = append(order, posts[i].Id)
+		}
+		require.Equal(t, order, pl.Order)
+	}
+
+	t.Run("ordered from the most recently pinned", func(t *testing.T) {
+		channelId, posts := savePosts(t)
+
+		setPinned(t, posts, 0, true)
+		setPinned(t, posts, 2, true)
+		setPinned(t, posts, 1, true)
+		requireOrder(t, channelId, posts, 1, 2, 0)
+
+		// Updating a pinned post keeps the time it was pinned at.
+		pinnedAt := posts[1].PinnedAt
+		posts[1].Message = "edited"
+		setPinned(t, posts, 1, true)
+		assert.Equal(t, pinnedAt, posts[1].PinnedAt)
+		requireOrder(t, channelId, posts, 1, 2, 0)
+	})
+
+	t.Run("unpinned and pinned again", func(t *testing.T) {
+		channelId, posts := savePosts(t)
+
+		setPinned(t, posts, 0, true)
+		setPinned(t, posts, 2, true)
+		setPinned(t, posts, 1, true)
+
+		setPinned(t, posts, 2, false)
+		assert.Zero(t, posts[2].PinnedAt)
+		requireOrder(t, channelId, posts, 1, 0)
+
+		setPinned(t, posts, 2, true)
+		requireOrder(t, channelId, posts, 2, 1, 0)
+	})
 }
 
 func testChannelStoreGetPinnedPostCount(t *testing.T, ss store.Store) {
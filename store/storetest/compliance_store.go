@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"encoding/json"
 	"testing"
 	"time"
@@ -69,6 +70,9 @@ func testComplianceStore(t *testing.T, ss store.Store) {
 	require.Nil(t, err)
 	time.Sleep(100 * time.Millisecond)
 
+	_, err = ss.Compliance().Save(compliance2)
+	require.True(t, errors.Is(err, &store.ErrConflict{Resource: "Compliance"}), err)
+
 	compliances, _ := ss.Compliance().GetAll(0, 1000)
 
 	require.Equal(t, model.COMPLIANCE_STATUS_RUNNING, compliances[0].Status)
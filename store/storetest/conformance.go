@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+type storeSuite struct {
+	name string
+	f    func(*testing.T, store.Store)
+}
+
+type sqlStoreSuite struct {
+	name string
+	f    func(*testing.T, store.Store, SqlSupplier)
+}
+
+var storeSuites = []storeSuite{
+	{"AuditStore", TestAuditStore},
+	{"AuditTrailStore", TestAuditTrailStore},
+	{"ChannelMemberHistoryStore", TestChannelMemberHistoryStore},
+	{"ClusterDiscoveryStore", TestClusterDiscoveryStore},
+	{"CommandStore", TestCommandStore},
+	{"CommandWebhookStore", TestCommandWebhookStore},
+	{"ComplianceStore", TestComplianceStore},
+	{"EmojiStore", TestEmojiStore},
+	{"FileInfoStore", TestFileInfoStore},
+	{"GroupStore", TestGroupStore},
+	{"JobStore", TestJobStore},
+	{"LicenseStore", TestLicenseStore},
+	{"LinkMetadataStore", TestLinkMetadataStore},
+	{"OAuthStore", TestOAuthStore},
+	{"Orphans", TestOrphans},
+	{"PreferenceStore", TestPreferenceStore},
+	{"ProductNoticesStore", TestProductNoticesStore},
+	{"ReactionStore", TestReactionStore},
+	{"SchemeStore", TestSchemeStore},
+	{"SessionStore", TestSessionStore},
+	{"StatusStore", TestStatusStore},
+	{"SystemStore", TestSystemStore},
+	{"TeamStore", TestTeamStore},
+	{"TermsOfServiceStore", TestTermsOfServiceStore},
+	{"UploadSessionStore", TestUploadSessionStore},
+	{"UserAccessTokenStore", TestUserAccessTokenStore},
+	{"UserTermsOfServiceStore", TestUserTermsOfServiceStore},
+	{"WebhookStore", TestWebhookStore},
+}
+
+// sqlStoreSuites inspect or modify the database directly in some of their tests, so they can only
+// run against stores that also expose the underlying SQL connection.
+var sqlStoreSuites = []sqlStoreSuite{
+	{"BotStore", TestBotStore},
+	{"ChannelStore", TestChannelStore},
+	{"ChannelStoreCategories", TestChannelStoreCategories},
+	{"PluginStore", TestPluginStore},
+	{"PostStore", TestPostStore},
+	{"RoleStore", TestRoleStore},
+	{"ThreadStore", TestThreadStore},
+	{"UserStore", TestUserStore},
+}
+
+// RunStoreTests runs the whole conformance suite against the given store, so that store
+// implementations living outside of this repository can be checked against the same contract as
+// the SQL store. The suites that need direct access to the database are run only if the store
+// implements SqlSupplier, and are reported as skipped otherwise. The cleanup function, if any, is
+// called once all the suites have run.
+func RunStoreTests(t *testing.T, ss store.Store, cleanup func()) {
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	for _, suite := range storeSuites {
+		suite := suite
+		t.Run(suite.name, func(t *testing.T) {
+			suite.f(t, ss)
+		})
+	}
+
+	s, isSqlSupplier := ss.(SqlSupplier)
+	for _, suite := range sqlStoreSuites {
+		suite := suite
+		t.Run(suite.name, func(t *testing.T) {
+			if !isSqlSupplier {
+				t.Skipf("%s requires a store implementing storetest.SqlSupplier to access the database directly", suite.name)
+			}
+			suite.f(t, ss, s)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ func TestEmojiStore(t *testing.T, ss store.Store) {
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, ss) })
+	t.Run("EmojiGetUsageCounts", func(t *testing.T) { testEmojiGetUsageCounts(t, ss) })
 }
 
 func testEmojiSaveDelete(t *testing.T, ss store.Store) {
@@ -40,6 +42,7 @@ func testEmojiSaveDelete(t *testing.T, ss store.Store) {
 	}
 	_, err = ss.Emoji().Save(&emoji2)
 	require.NotNil(t, err, "shouldn't be able to save emoji with duplicate name")
+	assert.True(t, errors.Is(err, &store.ErrConflict{Resource: "Emoji"}), err)
 
 	err = ss.Emoji().Delete(emoji1, time.Now().Unix())
 	require.Nil(t, err)
@@ -88,6 +91,9 @@ func testEmojiGet(t *testing.T, ss store.Store) {
 		_, err := ss.Emoji().Get(emoji.Id, true)
 		require.Nilf(t, err, "failed to get emoji with id %v", emoji.Id)
 	}
+
+	_, err := ss.Emoji().Get(model.NewId(), false)
+	assert.True(t, errors.Is(err, &store.ErrNotFound{}), err)
 }
 
 func testEmojiGetByName(t *testing.T, ss store.Store) {
@@ -302,3 +308,67 @@ func testEmojiSearch(t *testing.T, ss store.Store) {
 		assert.Equal(t, shouldFind[i], found, emoji.Name)
 	}
 }
+
+func testEmojiGetUsageCounts(t *testing.T, ss store.Store) {
+	used := model.NewId()
+	removed := model.NewId()
+	unused := model.NewId()
+
+	newPost := func(message string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Message:   message,
+		})
+		require.Nil(t, err)
+		return post
+	}
+	react := func(post *model.Post, emojiName string) *model.Reaction {
+		reaction, err := ss.Reaction().Save(&model.Reaction{
+			UserId:    model.NewId(),
+			PostId:    post.Id,
+			EmojiName: emojiName,
+		})
+		require.Nil(t, err)
+		return reaction
+	}
+
+	post := newPost("hello :" + used + ":")
+	react(post, used)
+	react(post, used)
+	reaction := react(post, removed)
+	_, err := ss.Reaction().Delete(reaction)
+	require.Nil(t, err)
+
+	deletedPost := newPost("bye :" + used + ": :" + removed + ":")
+	react(deletedPost, used)
+	react(deletedPost, removed)
+	err = ss.Post().Delete(deletedPost.Id, model.GetMillis(), "")
+	require.Nil(t, err)
+
+	t.Run("reactions only", func(t *testing.T) {
+		usages, err := ss.Emoji().GetUsageCounts([]string{used, removed, unused}, false)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]*model.EmojiUsage{
+			used:    {Name: used, ReactionCount: 2},
+			removed: {Name: removed},
+			unused:  {Name: unused},
+		}, usages)
+	})
+
+	t.Run("with messages", func(t *testing.T) {
+		usages, err := ss.Emoji().GetUsageCounts([]string{used, removed, unused}, true)
+		require.Nil(t, err)
+		assert.Equal(t, map[string]*model.EmojiUsage{
+			used:    {Name: used, ReactionCount: 2, MessageCount: 1},
+			removed: {Name: removed},
+			unused:  {Name: unused},
+		}, usages)
+	})
+
+	t.Run("no emojis", func(t *testing.T) {
+		usages, err := ss.Emoji().GetUsageCounts([]string{}, true)
+		require.Nil(t, err)
+		assert.Empty(t, usages)
+	})
+}
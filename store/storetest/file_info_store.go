@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -22,6 +23,8 @@ func TestFileInfoStore(t *testing.T, ss store.Store) {
 	t.Run("FileInfoGetForPost", func(t *testing.T) { testFileInfoGetForPost(t, ss) })
 	t.Run("FileInfoGetForUser", func(t *testing.T) { testFileInfoGetForUser(t, ss) })
 	t.Run("FileInfoGetWithOptions", func(t *testing.T) { testFileInfoGetWithOptions(t, ss) })
+	t.Run("FileInfoGetByIds", func(t *testing.T) { testFileInfoGetByIds(t, ss) })
+	t.Run("FileInfoSearch", func(t *testing.T) { testFileInfoSearch(t, ss) })
 	t.Run("FileInfoAttachToPost", func(t *testing.T) { testFileInfoAttachToPost(t, ss) })
 	t.Run("FileInfoDeleteForPost", func(t *testing.T) { testFileInfoDeleteForPost(t, ss) })
 	t.Run("FileInfoPermanentDelete", func(t *testing.T) { testFileInfoPermanentDelete(t, ss) })
@@ -47,6 +50,9 @@ func testFileInfoSaveGet(t *testing.T, ss store.Store) {
 	require.Nil(t, err)
 	require.Equal(t, info.Id, rinfo.Id)
 
+	_, err = ss.FileInfo().Save(info)
+	require.True(t, errors.Is(err, &store.ErrConflict{Resource: "FileInfo"}), err)
+
 	info2, err := ss.FileInfo().Save(&model.FileInfo{
 		CreatorId: model.NewId(),
 		Path:      "file.txt",
@@ -401,6 +407,123 @@ func (a byFileInfoId) Len() int           { return len(a) }
 func (a byFileInfoId) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byFileInfoId) Less(i, j int) bool { return a[i].Id < a[j].Id }
 
+func testFileInfoGetByIds(t *testing.T, ss store.Store) {
+	info1, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file1.txt",
+		CreateAt:  1000,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(info1.Id)
+
+	info2, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file2.txt",
+		CreateAt:  2000,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(info2.Id)
+
+	deletedInfo, err := ss.FileInfo().Save(&model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "file3.txt",
+		DeleteAt:  123,
+	})
+	require.Nil(t, err)
+	defer ss.FileInfo().PermanentDelete(deletedInfo.Id)
+
+	infos, err := ss.FileInfo().GetByIds([]string{info1.Id, info2.Id, deletedInfo.Id, model.NewId()})
+	require.Nil(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, info2.Id, infos[0].Id)
+	assert.Equal(t, info1.Id, infos[1].Id)
+}
+
+func testFileInfoSearch(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	makeChannel := func(member bool) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Channel",
+			Name:        "zz" + model.NewId() + "b",
+			Type:        model.CHANNEL_OPEN,
+		}, -1)
+		require.Nil(t, err)
+		if member {
+			_, err = ss.Channel().SaveMember(&model.ChannelMember{
+				ChannelId:   channel.Id,
+				UserId:      userId,
+				NotifyProps: model.GetDefaultChannelNotifyProps(),
+			})
+			require.Nil(t, err)
+		}
+		return channel
+	}
+
+	makeFile := func(channel *model.Channel, creatorId, name string, createAt int64) *model.FileInfo {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: creatorId})
+		require.Nil(t, err)
+		info, err := ss.FileInfo().Save(&model.FileInfo{
+			CreatorId: creatorId,
+			PostId:    post.Id,
+			Path:      name,
+			Name:      name,
+			CreateAt:  createAt,
+		})
+		require.Nil(t, err)
+		return info
+	}
+
+	channel := makeChannel(true)
+	otherChannel := makeChannel(true)
+	notMemberChannel := makeChannel(false)
+
+	report := makeFile(channel, userId, "Quarterly Report.pdf", 1000)
+	draft := makeFile(channel, otherUserId, "report_draft.docx", 2000)
+	picture := makeFile(otherChannel, userId, "picture 100%.png", 3000)
+	makeFile(notMemberChannel, otherUserId, "secret report.pdf", 4000)
+	defer ss.FileInfo().PermanentDeleteByUser(userId)
+	defer ss.FileInfo().PermanentDeleteByUser(otherUserId)
+
+	search := func(params ...*model.SearchParams) []string {
+		results, err := ss.FileInfo().Search(params, userId, teamId, 0, 20)
+		require.Nil(t, err)
+		return results.Order
+	}
+
+	t.Run("only in the channels of the user", func(t *testing.T) {
+		assert.Equal(t, []string{draft.Id, report.Id}, search(&model.SearchParams{Terms: "report"}))
+	})
+
+	t.Run("quoted phrases", func(t *testing.T) {
+		assert.Equal(t, []string{report.Id}, search(&model.SearchParams{Terms: `"quarterly report"`}))
+	})
+
+	t.Run("all or any of the terms", func(t *testing.T) {
+		assert.Equal(t, []string{report.Id}, search(&model.SearchParams{Terms: "report pdf"}))
+		assert.Equal(t, []string{picture.Id, draft.Id, report.Id}, search(&model.SearchParams{Terms: "report png", OrTerms: true}))
+	})
+
+	t.Run("escapes the like wildcards", func(t *testing.T) {
+		assert.Equal(t, []string{picture.Id}, search(&model.SearchParams{Terms: "100%"}))
+		assert.Empty(t, search(&model.SearchParams{Terms: "re_ort"}))
+	})
+
+	t.Run("excluded terms", func(t *testing.T) {
+		assert.Equal(t, []string{report.Id}, search(&model.SearchParams{Terms: "report", ExcludedTerms: "draft"}))
+	})
+
+	t.Run("channel, user and date filters", func(t *testing.T) {
+		assert.Equal(t, []string{picture.Id}, search(&model.SearchParams{InChannels: []string{otherChannel.Id}}))
+		assert.Equal(t, []string{picture.Id, report.Id}, search(&model.SearchParams{FromUsers: []string{userId}}))
+		assert.Equal(t, []string{draft.Id}, search(&model.SearchParams{Terms: "report", ExcludedUsers: []string{userId}}))
+		assert.Equal(t, []string{report.Id}, search(&model.SearchParams{Terms: "report", ExcludedChannels: []string{otherChannel.Id}, FromUsers: []string{userId}}))
+	})
+}
+
 func testFileInfoAttachToPost(t *testing.T, ss store.Store) {
 	t.Run("should attach files", func(t *testing.T) {
 		userId := model.NewId()
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	"time"
@@ -26,6 +27,7 @@ func TestJobStore(t *testing.T, ss store.Store) {
 	t.Run("GetCountByStatusAndType", func(t *testing.T) { testJobStoreGetCountByStatusAndType(t, ss) })
 	t.Run("JobUpdateOptimistically", func(t *testing.T) { testJobUpdateOptimistically(t, ss) })
 	t.Run("JobUpdateStatusUpdateStatusOptimistically", func(t *testing.T) { testJobUpdateStatusUpdateStatusOptimistically(t, ss) })
+	t.Run("JobClaimPending", func(t *testing.T) { testJobClaimPending(t, ss) })
 	t.Run("JobDelete", func(t *testing.T) { testJobDelete(t, ss) })
 }
 
@@ -50,6 +52,9 @@ func testJobSaveGet(t *testing.T, ss store.Store) {
 	require.Nil(t, err)
 	require.Equal(t, job.Id, received.Id, "received incorrect job after save")
 	require.Equal(t, "12345", received.Data["Total"])
+
+	_, err = ss.Job().Save(job)
+	require.True(t, errors.Is(err, &store.ErrConflict{Resource: "Job"}), err)
 }
 
 func testJobGetAllByType(t *testing.T, ss store.Store) {
@@ -488,6 +493,87 @@ func testJobUpdateStatusUpdateStatusOptimistically(t *testing.T, ss store.Store)
 	require.Greater(t, received.LastActivityAt, lastUpdateAt)
 }
 
+func testJobClaimPending(t *testing.T, ss store.Store) {
+	t.Run("claims the oldest pending job", func(t *testing.T) {
+		jobType := model.NewId()
+		jobs := []*model.Job{
+			{Id: model.NewId(), Type: jobType, CreateAt: 1000, Status: model.JOB_STATUS_SUCCESS},
+			{Id: model.NewId(), Type: jobType, CreateAt: 1001, Status: model.JOB_STATUS_PENDING, Data: map[string]string{"key": "value"}},
+			{Id: model.NewId(), Type: jobType, CreateAt: 1002, Status: model.JOB_STATUS_PENDING},
+			{Id: model.NewId(), Type: model.NewId(), CreateAt: 999, Status: model.JOB_STATUS_PENDING},
+		}
+		for _, job := range jobs {
+			_, err := ss.Job().Save(job)
+			require.Nil(t, err)
+			defer ss.Job().Delete(job.Id)
+		}
+
+		claimed, err := ss.Job().ClaimPending(jobType, "node1")
+		require.Nil(t, err)
+		assert.Equal(t, jobs[1].Id, claimed.Id)
+		assert.Equal(t, model.JOB_STATUS_IN_PROGRESS, claimed.Status)
+		assert.NotZero(t, claimed.StartAt)
+
+		received, err := ss.Job().Get(jobs[1].Id)
+		require.Nil(t, err)
+		assert.Equal(t, model.JOB_STATUS_IN_PROGRESS, received.Status)
+		assert.Equal(t, claimed.StartAt, received.StartAt)
+		assert.Equal(t, map[string]string{"key": "value", model.JOB_DATA_NODE_ID: "node1"}, received.Data)
+
+		claimed, err = ss.Job().ClaimPending(jobType, "node2")
+		require.Nil(t, err)
+		assert.Equal(t, jobs[2].Id, claimed.Id)
+		assert.Equal(t, "node2", claimed.Data[model.JOB_DATA_NODE_ID])
+
+		_, err = ss.Job().ClaimPending(jobType, "node1")
+		var nfErr *store.ErrNotFound
+		assert.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("claims each job once when claimed concurrently", func(t *testing.T) {
+		jobType := model.NewId()
+		const jobCount = 20
+		const nodeCount = 8
+		for i := 0; i < jobCount; i++ {
+			job, err := ss.Job().Save(&model.Job{Id: model.NewId(), Type: jobType, CreateAt: int64(1000 + i), Status: model.JOB_STATUS_PENDING})
+			require.Nil(t, err)
+			defer ss.Job().Delete(job.Id)
+		}
+
+		var mut sync.Mutex
+		claims := make(map[string][]string)
+		var wg sync.WaitGroup
+		for i := 0; i < nodeCount; i++ {
+			wg.Add(1)
+			go func(nodeId string) {
+				defer wg.Done()
+				for {
+					job, err := ss.Job().ClaimPending(jobType, nodeId)
+					if err != nil {
+						var nfErr *store.ErrNotFound
+						assert.True(t, errors.As(err, &nfErr), "unexpected error %v", err)
+						return
+					}
+					mut.Lock()
+					claims[job.Id] = append(claims[job.Id], nodeId)
+					mut.Unlock()
+				}
+			}(model.NewId())
+		}
+		wg.Wait()
+
+		require.Len(t, claims, jobCount)
+		for jobId, nodeIds := range claims {
+			require.Len(t, nodeIds, 1, "job %s was claimed more than once", jobId)
+
+			job, err := ss.Job().Get(jobId)
+			require.Nil(t, err)
+			assert.Equal(t, model.JOB_STATUS_IN_PROGRESS, job.Status)
+			assert.Equal(t, nodeIds[0], job.Data[model.JOB_DATA_NODE_ID])
+		}
+	})
+}
+
 func testJobDelete(t *testing.T, ss store.Store) {
 	job, err := ss.Job().Save(&model.Job{Id: model.NewId()})
 	require.Nil(t, err)
@@ -0,0 +1,829 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+var _ store.Store = (*MemoryStore)(nil)
+
+// MemoryStore is a store keeping the users, teams, channels and posts in memory, along with the
+// team and channel members, for the tests that don't need a database. It only implements the
+// common methods of these stores: the other methods, and the other stores, fail with a
+// store.ErrNotImplemented.
+type MemoryStore struct {
+	notSupportedStore
+
+	mut            sync.RWMutex
+	users          map[string]*model.User
+	teams          map[string]*model.Team
+	teamMembers    map[string]map[string]*model.TeamMember
+	channels       map[string]*model.Channel
+	channelMembers map[string]map[string]*model.ChannelMember
+	posts          map[string]*model.Post
+	context        context.Context
+}
+
+// NewMemoryStore creates an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{context: context.Background()}
+	s.DropAllTables()
+	return s
+}
+
+func (s *MemoryStore) User() store.UserStore {
+	return &memoryUserStore{MemoryStore: s}
+}
+
+func (s *MemoryStore) Team() store.TeamStore {
+	return &memoryTeamStore{MemoryStore: s}
+}
+
+func (s *MemoryStore) Channel() store.ChannelStore {
+	return &memoryChannelStore{MemoryStore: s}
+}
+
+func (s *MemoryStore) Post() store.PostStore {
+	return &memoryPostStore{MemoryStore: s}
+}
+
+func (s *MemoryStore) MarkSystemRanUnitTests() {}
+
+func (s *MemoryStore) Close() {}
+
+func (s *MemoryStore) LockToMaster() {}
+
+func (s *MemoryStore) UnlockFromMaster() {}
+
+// DropAllTables empties the store.
+func (s *MemoryStore) DropAllTables() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.users = map[string]*model.User{}
+	s.teams = map[string]*model.Team{}
+	s.teamMembers = map[string]map[string]*model.TeamMember{}
+	s.channels = map[string]*model.Channel{}
+	s.channelMembers = map[string]map[string]*model.ChannelMember{}
+	s.posts = map[string]*model.Post{}
+}
+
+func (s *MemoryStore) RecycleDBConnections(d time.Duration) {}
+
+func (s *MemoryStore) GetCurrentSchemaVersion() string {
+	return model.CurrentVersion
+}
+
+func (s *MemoryStore) GetDbVersion() (string, error) {
+	return "memory", nil
+}
+
+func (s *MemoryStore) TotalMasterDbConnections() int {
+	return 0
+}
+
+func (s *MemoryStore) TotalReadDbConnections() int {
+	return 0
+}
+
+func (s *MemoryStore) TotalSearchDbConnections() int {
+	return 0
+}
+
+// CheckIntegrity has nothing to check, the store holding no references between tables.
+func (s *MemoryStore) CheckIntegrity() <-chan model.IntegrityCheckResult {
+	results := make(chan model.IntegrityCheckResult)
+	close(results)
+	return results
+}
+
+func (s *MemoryStore) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return 0, store.NewErrNotImplemented("PermanentDeleteBatchForRetention is not supported")
+}
+
+func (s *MemoryStore) FindOrphans(entityType string, limit int) ([]string, error) {
+	return nil, store.NewErrNotImplemented("FindOrphans is not supported")
+}
+
+func (s *MemoryStore) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return 0, store.NewErrNotImplemented("DeleteOrphans is not supported")
+}
+
+func (s *MemoryStore) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) SetContext(context context.Context) {
+	s.context = context
+}
+
+func (s *MemoryStore) Context() context.Context {
+	return s.context
+}
+
+type memoryUserStore struct {
+	notSupportedUserStore
+	*MemoryStore
+}
+
+// findUser returns the user matching the predicate. It must be called with the lock held.
+func (s *memoryUserStore) findUser(match func(*model.User) bool) *model.User {
+	for _, user := range s.users {
+		if match(user) {
+			return user
+		}
+	}
+	return nil
+}
+
+// checkUnique fails if another user has the same email or username. It must be called with the
+// lock held.
+func (s *memoryUserStore) checkUnique(user *model.User) error {
+	if other := s.findUser(func(u *model.User) bool { return u.Id != user.Id && u.Email == user.Email }); other != nil {
+		return store.NewErrInvalidInput("User", "email", user.Email)
+	}
+	if other := s.findUser(func(u *model.User) bool { return u.Id != user.Id && u.Username == user.Username }); other != nil {
+		return store.NewErrInvalidInput("User", "username", user.Username)
+	}
+	return nil
+}
+
+func (s *memoryUserStore) Save(user *model.User) (*model.User, error) {
+	if len(user.Id) > 0 {
+		return nil, store.NewErrInvalidInput("User", "id", user.Id)
+	}
+
+	user.PreSave()
+	if err := user.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if err := s.checkUnique(user); err != nil {
+		return nil, err
+	}
+	saved := *user
+	s.users[user.Id] = &saved
+	return user, nil
+}
+
+func (s *memoryUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	user.PreUpdate()
+	if err := user.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	old, ok := s.users[user.Id]
+	if !ok {
+		return nil, store.NewErrNotFound("User", user.Id)
+	}
+	if err := s.checkUnique(user); err != nil {
+		return nil, err
+	}
+
+	user.CreateAt = old.CreateAt
+	user.AuthData = old.AuthData
+	user.AuthService = old.AuthService
+	user.Password = old.Password
+	user.LastPasswordUpdate = old.LastPasswordUpdate
+	user.LastPictureUpdate = old.LastPictureUpdate
+	user.EmailVerified = old.EmailVerified
+	user.FailedAttempts = old.FailedAttempts
+	user.MfaSecret = old.MfaSecret
+	user.MfaActive = old.MfaActive
+	if !allowRoleUpdate {
+		user.Roles = old.Roles
+	}
+
+	saved := *user
+	s.users[user.Id] = &saved
+	oldCopy := *old
+	return &model.UserUpdate{New: user, Old: &oldCopy}, nil
+}
+
+func (s *memoryUserStore) Get(id string) (*model.User, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, store.NewErrNotFound("User", id)
+	}
+	found := *user
+	return &found, nil
+}
+
+func (s *memoryUserStore) GetAll() ([]*model.User, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	users := make([]*model.User, 0, len(s.users))
+	for _, user := range s.users {
+		found := *user
+		users = append(users, &found)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users, nil
+}
+
+// GetProfileByIds returns the users of the ids, ignoring the options.
+func (s *memoryUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	users := []*model.User{}
+	for _, id := range userIds {
+		if user, ok := s.users[id]; ok {
+			found := *user
+			found.Sanitize(map[string]bool{})
+			users = append(users, &found)
+		}
+	}
+	return users, nil
+}
+
+func (s *memoryUserStore) GetByEmail(email string) (*model.User, error) {
+	email = strings.ToLower(email)
+
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	user := s.findUser(func(u *model.User) bool { return u.Email == email })
+	if user == nil {
+		return nil, store.NewErrNotFound("User", "email="+email)
+	}
+	found := *user
+	return &found, nil
+}
+
+func (s *memoryUserStore) GetByUsername(username string) (*model.User, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	user := s.findUser(func(u *model.User) bool { return u.Username == username })
+	if user == nil {
+		return nil, store.NewErrNotFound("User", "username="+username)
+	}
+	found := *user
+	return &found, nil
+}
+
+func (s *memoryUserStore) PermanentDelete(userId string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.users, userId)
+	return nil
+}
+
+type memoryTeamStore struct {
+	notSupportedTeamStore
+	*MemoryStore
+}
+
+func (s *memoryTeamStore) Save(team *model.Team) (*model.Team, error) {
+	if len(team.Id) > 0 {
+		return nil, store.NewErrInvalidInput("Team", "id", team.Id)
+	}
+
+	team.PreSave()
+	if err := team.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for _, other := range s.teams {
+		if other.Name == team.Name {
+			return nil, store.NewErrInvalidInput("Team", "id", team.Id)
+		}
+	}
+	saved := *team
+	s.teams[team.Id] = &saved
+	return team, nil
+}
+
+func (s *memoryTeamStore) Update(team *model.Team) (*model.Team, error) {
+	team.PreUpdate()
+	if err := team.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	old, ok := s.teams[team.Id]
+	if !ok {
+		return nil, store.NewErrNotFound("Team", team.Id)
+	}
+	team.CreateAt = old.CreateAt
+	saved := *team
+	s.teams[team.Id] = &saved
+	return team, nil
+}
+
+func (s *memoryTeamStore) Get(id string) (*model.Team, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	team, ok := s.teams[id]
+	if !ok {
+		return nil, store.NewErrNotFound("Team", id)
+	}
+	found := *team
+	return &found, nil
+}
+
+func (s *memoryTeamStore) GetByName(name string) (*model.Team, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	for _, team := range s.teams {
+		if team.Name == name {
+			found := *team
+			return &found, nil
+		}
+	}
+	return nil, store.NewErrNotFound("Team", "name="+name)
+}
+
+func (s *memoryTeamStore) GetAll() ([]*model.Team, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	teams := make([]*model.Team, 0, len(s.teams))
+	for _, team := range s.teams {
+		found := *team
+		teams = append(teams, &found)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].DisplayName < teams[j].DisplayName })
+	return teams, nil
+}
+
+func (s *memoryTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	teams := []*model.Team{}
+	for teamId, members := range s.teamMembers {
+		if member, ok := members[userId]; ok && member.DeleteAt == 0 {
+			if team, ok := s.teams[teamId]; ok {
+				found := *team
+				teams = append(teams, &found)
+			}
+		}
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].DisplayName < teams[j].DisplayName })
+	return teams, nil
+}
+
+// PermanentDelete deletes the team along with its members.
+func (s *memoryTeamStore) PermanentDelete(teamId string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.teams, teamId)
+	delete(s.teamMembers, teamId)
+	return nil
+}
+
+func (s *memoryTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	if err := member.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	members := s.teamMembers[member.TeamId]
+	if _, ok := members[member.UserId]; ok {
+		return nil, store.NewErrConflict("TeamMember", nil, "teamId="+member.TeamId+", userId="+member.UserId)
+	}
+	if maxUsersPerTeam >= 0 {
+		count := 0
+		for _, other := range members {
+			if other.DeleteAt == 0 {
+				count++
+			}
+		}
+		if count >= maxUsersPerTeam {
+			return nil, store.NewErrLimitExceeded("TeamMember", count, "teamId="+member.TeamId)
+		}
+	}
+
+	if members == nil {
+		members = map[string]*model.TeamMember{}
+		s.teamMembers[member.TeamId] = members
+	}
+	saved := *member
+	members[member.UserId] = &saved
+	return member, nil
+}
+
+func (s *memoryTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+	member.PreUpdate()
+	if err := member.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.teamMembers[member.TeamId][member.UserId]; !ok {
+		return nil, store.NewErrNotFound("TeamMember", "teamId="+member.TeamId+", userId="+member.UserId)
+	}
+	saved := *member
+	s.teamMembers[member.TeamId][member.UserId] = &saved
+	return member, nil
+}
+
+func (s *memoryTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	member, ok := s.teamMembers[teamId][userId]
+	if !ok {
+		return nil, store.NewErrNotFound("TeamMember", "teamId="+teamId+", userId="+userId)
+	}
+	found := *member
+	return &found, nil
+}
+
+func (s *memoryTeamStore) RemoveMember(teamId string, userId string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.teamMembers[teamId], userId)
+	return nil
+}
+
+type memoryChannelStore struct {
+	notSupportedChannelStore
+	*MemoryStore
+}
+
+func (s *memoryChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+	if len(channel.Id) > 0 {
+		return nil, store.NewErrInvalidInput("Channel", "Id", channel.Id)
+	}
+
+	channel.PreSave()
+	if err := channel.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var count int64
+	for _, other := range s.channels {
+		if other.TeamId != channel.TeamId {
+			continue
+		}
+		if other.Name == channel.Name {
+			dupChannel := *other
+			return &dupChannel, store.NewErrConflict("Channel", nil, "id="+channel.Id)
+		}
+		if other.DeleteAt == 0 && (other.Type == model.CHANNEL_OPEN || other.Type == model.CHANNEL_PRIVATE) {
+			count++
+		}
+	}
+	if maxChannelsPerTeam >= 0 && channel.TeamId != "" && count >= maxChannelsPerTeam {
+		return nil, store.NewErrLimitExceeded("channels_per_team", int(count), "teamId="+channel.TeamId)
+	}
+
+	s.channels[channel.Id] = channel.DeepCopy()
+	return channel, nil
+}
+
+func (s *memoryChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+	channel.PreUpdate()
+	if err := channel.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	old, ok := s.channels[channel.Id]
+	if !ok {
+		return nil, store.NewErrNotFound("Channel", channel.Id)
+	}
+	for _, other := range s.channels {
+		if other.Id != channel.Id && other.TeamId == channel.TeamId && other.Name == channel.Name {
+			return nil, store.NewErrConflict("Channel", nil, "id="+channel.Id)
+		}
+	}
+	channel.CreateAt = old.CreateAt
+	s.channels[channel.Id] = channel.DeepCopy()
+	return channel, nil
+}
+
+func (s *memoryChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	channel, ok := s.channels[id]
+	if !ok {
+		return nil, store.NewErrNotFound("Channel", id)
+	}
+	return channel.DeepCopy(), nil
+}
+
+func (s *memoryChannelStore) setDeleteAt(channelId string, deleteAt int64) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	channel, ok := s.channels[channelId]
+	if !ok {
+		return store.NewErrNotFound("Channel", channelId)
+	}
+	channel.DeleteAt = deleteAt
+	channel.UpdateAt = model.GetMillis()
+	return nil
+}
+
+func (s *memoryChannelStore) Delete(channelId string, time int64) error {
+	return s.setDeleteAt(channelId, time)
+}
+
+func (s *memoryChannelStore) Restore(channelId string, time int64) error {
+	return s.setDeleteAt(channelId, 0)
+}
+
+// PermanentDelete deletes the channel along with its members and posts.
+func (s *memoryChannelStore) PermanentDelete(channelId string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.channels, channelId)
+	delete(s.channelMembers, channelId)
+	for id, post := range s.posts {
+		if post.ChannelId == channelId {
+			delete(s.posts, id)
+		}
+	}
+	return nil
+}
+
+func (s *memoryChannelStore) GetByName(teamId string, name string, allowFromCache bool) (*model.Channel, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	for _, channel := range s.channels {
+		if channel.TeamId == teamId && channel.Name == name && channel.DeleteAt == 0 {
+			return channel.DeepCopy(), nil
+		}
+	}
+	return nil, store.NewErrNotFound("Channel", "name="+name)
+}
+
+// GetChannels returns the channels of the team the user is a member of, along with their direct
+// and group channels.
+func (s *memoryChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	channels := model.ChannelList{}
+	for id, members := range s.channelMembers {
+		if _, ok := members[userId]; !ok {
+			continue
+		}
+		channel, ok := s.channels[id]
+		if !ok || (channel.TeamId != teamId && channel.TeamId != "") {
+			continue
+		}
+		if channel.DeleteAt != 0 && !includeDeleted {
+			continue
+		}
+		channels = append(channels, channel.DeepCopy())
+	}
+	if len(channels) == 0 {
+		return nil, store.NewErrNotFound("Channel", "userId="+userId)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].DisplayName < channels[j].DisplayName })
+	return &channels, nil
+}
+
+func (s *memoryChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	channels := model.ChannelList{}
+	for _, channel := range s.channels {
+		if channel.TeamId == teamId && channel.Type != model.CHANNEL_DIRECT {
+			channels = append(channels, channel.DeepCopy())
+		}
+	}
+	if len(channels) == 0 {
+		return nil, store.NewErrNotFound("Channel", "teamId="+teamId)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].DisplayName < channels[j].DisplayName })
+	return &channels, nil
+}
+
+func (s *memoryChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	member.PreSave()
+	if err := member.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.channels[member.ChannelId]; !ok {
+		return nil, store.NewErrNotFound("Channel", member.ChannelId)
+	}
+	members := s.channelMembers[member.ChannelId]
+	if _, ok := members[member.UserId]; ok {
+		return nil, store.NewErrConflict("ChannelMembers", nil, "channelId="+member.ChannelId+", userId="+member.UserId)
+	}
+	if members == nil {
+		members = map[string]*model.ChannelMember{}
+		s.channelMembers[member.ChannelId] = members
+	}
+	saved := *member
+	members[member.UserId] = &saved
+	return member, nil
+}
+
+func (s *memoryChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+	member.PreUpdate()
+	if err := member.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.channelMembers[member.ChannelId][member.UserId]; !ok {
+		return nil, store.NewErrNotFound("ChannelMember", "channelId="+member.ChannelId+", userId="+member.UserId)
+	}
+	saved := *member
+	s.channelMembers[member.ChannelId][member.UserId] = &saved
+	return member, nil
+}
+
+func (s *memoryChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	member, ok := s.channelMembers[channelId][userId]
+	if !ok {
+		return nil, store.NewErrNotFound("ChannelMember", "channelId="+channelId+", userId="+userId)
+	}
+	found := *member
+	return &found, nil
+}
+
+func (s *memoryChannelStore) RemoveMember(channelId string, userId string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	delete(s.channelMembers[channelId], userId)
+	return nil
+}
+
+type memoryPostStore struct {
+	notSupportedPostStore
+	*MemoryStore
+}
+
+// Save saves the post and updates the last post time and the message count of its channel.
+func (s *memoryPostStore) Save(post *model.Post) (*model.Post, error) {
+	if len(post.Id) > 0 {
+		return nil, store.NewErrInvalidInput("Post", "id", post.Id)
+	}
+
+	post.PreSave()
+	if err := post.IsValid(model.POST_MESSAGE_MAX_RUNES_V2); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if channel, ok := s.channels[post.ChannelId]; ok {
+		if post.CreateAt > channel.LastPostAt {
+			channel.LastPostAt = post.CreateAt
+		}
+		channel.TotalMsgCount++
+	}
+	s.posts[post.Id] = post.Clone()
+	return post, nil
+}
+
+func (s *memoryPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	newPost.UpdateAt = model.GetMillis()
+	newPost.PreCommit()
+	if err := newPost.IsValid(model.POST_MESSAGE_MAX_RUNES_V2); err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.posts[newPost.Id]; !ok {
+		return nil, store.NewErrNotFound("Post", newPost.Id)
+	}
+	s.posts[newPost.Id] = newPost.Clone()
+	return newPost, nil
+}
+
+// Get returns the post along with the other posts of its thread, unless skipFetchThreads is set.
+func (s *memoryPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	post, ok := s.posts[id]
+	if !ok || post.DeleteAt != 0 {
+		return nil, store.NewErrNotFound("Post", id)
+	}
+
+	list := model.NewPostList()
+	list.AddPost(post.Clone())
+	list.AddOrder(id)
+	if skipFetchThreads {
+		return list, nil
+	}
+
+	rootId := post.RootId
+	if rootId == "" {
+		rootId = post.Id
+	}
+	for _, other := range s.posts {
+		if other.DeleteAt == 0 && (other.Id == rootId || other.RootId == rootId) {
+			list.AddPost(other.Clone())
+		}
+	}
+	return list, nil
+}
+
+func (s *memoryPostStore) GetSingle(id string) (*model.Post, error) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	post, ok := s.posts[id]
+	if !ok || post.DeleteAt != 0 {
+		return nil, store.NewErrNotFound("Post", id)
+	}
+	return post.Clone(), nil
+}
+
+// Delete marks the post as deleted along with its replies.
+func (s *memoryPostStore) Delete(postId string, time int64, deleteByID string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if _, ok := s.posts[postId]; !ok {
+		return store.NewErrNotFound("Post", postId)
+	}
+	for _, post := range s.posts {
+		if post.Id == postId || post.RootId == postId {
+			post.DeleteAt = time
+			post.UpdateAt = time
+			post.AddProp(model.POST_PROPS_DELETE_BY, deleteByID)
+		}
+	}
+	return nil
+}
+
+// GetPosts returns a page of the posts of the channel, newest first, ignoring the threads.
+func (s *memoryPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	if options.PerPage > 1000 {
+		return nil, store.NewErrInvalidInput("Post", "<options.PerPage>", options.PerPage)
+	}
+
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	posts := []*model.Post{}
+	for _, post := range s.posts {
+		if post.ChannelId == options.ChannelId && post.DeleteAt == 0 {
+			posts = append(posts, post)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].CreateAt > posts[j].CreateAt })
+
+	list := model.NewPostList()
+	for i := options.Page * options.PerPage; i < len(posts) && i < (options.Page+1)*options.PerPage; i++ {
+		list.AddPost(posts[i].Clone())
+		list.AddOrder(posts[i].Id)
+	}
+	return list, nil
+}
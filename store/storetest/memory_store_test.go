@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ss := NewMemoryStore()
+
+	user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.NoError(t, err)
+	_, err = ss.User().Save(&model.User{Email: MakeEmail(), Username: user.Username})
+	var invErr *store.ErrInvalidInput
+	require.True(t, errors.As(err, &invErr), "the username should be unique")
+
+	team, err := ss.Team().Save(&model.Team{DisplayName: "Team", Name: "z-z-z" + model.NewId() + "b", Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.NoError(t, err)
+	_, err = ss.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: user.Id}, -1)
+	require.NoError(t, err)
+
+	channel, err := ss.Channel().Save(&model.Channel{TeamId: team.Id, DisplayName: "Channel", Name: "z-z-z" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.NoError(t, err)
+	_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: user.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+	require.NoError(t, err)
+
+	t.Run("reads the saved entities", func(t *testing.T) {
+		found, err := ss.User().GetByUsername(user.Username)
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, found.Id)
+
+		teams, err := ss.Team().GetTeamsByUserId(user.Id)
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, team.Id, teams[0].Id)
+
+		channels, err := ss.Channel().GetChannels(team.Id, user.Id, false, 0)
+		require.NoError(t, err)
+		require.Len(t, *channels, 1)
+		assert.Equal(t, channel.Id, (*channels)[0].Id)
+
+		_, err = ss.Channel().Get(model.NewId(), true)
+		var nfErr *store.ErrNotFound
+		assert.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("returns copies of the entities", func(t *testing.T) {
+		found, err := ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		found.DisplayName = "Changed"
+
+		found, err = ss.Team().Get(team.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Team", found.DisplayName)
+	})
+
+	t.Run("saves the posts and their threads", func(t *testing.T) {
+		root, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "root"})
+		require.NoError(t, err)
+		reply, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "reply", RootId: root.Id, ParentId: root.Id, CreateAt: root.CreateAt + 1})
+		require.NoError(t, err)
+
+		list, err := ss.Post().Get(reply.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{reply.Id}, list.Order)
+		assert.Len(t, list.Posts, 2)
+
+		list, err = ss.Post().GetPosts(model.GetPostsOptions{ChannelId: channel.Id, PerPage: 1}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{reply.Id}, list.Order)
+
+		saved, err := ss.Channel().Get(channel.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), saved.TotalMsgCount)
+
+		require.NoError(t, ss.Post().Delete(root.Id, model.GetMillis(), user.Id))
+		_, err = ss.Post().GetSingle(reply.Id)
+		assert.Error(t, err, "the replies should be deleted with their root")
+	})
+
+	t.Run("fails the other methods as not implemented", func(t *testing.T) {
+		_, err := ss.User().GetNewUsersForTeam(team.Id, 0, 10, nil)
+		var niErr *store.ErrNotImplemented
+		assert.True(t, errors.As(err, &niErr))
+
+		_, err = ss.Session().Get("token")
+		assert.True(t, errors.As(err, &niErr))
+
+		_, appErr := ss.Group().Get(model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	t.Run("empties the store", func(t *testing.T) {
+		ss.DropAllTables()
+		_, err := ss.User().Get(user.Id)
+		assert.Error(t, err)
+	})
+}
@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+
 	model "github.com/mattermost/mattermost-server/v5/model"
 	store "github.com/mattermost/mattermost-server/v5/store"
 	mock "github.com/stretchr/testify/mock"
@@ -510,6 +512,29 @@ func (_m *ChannelStore) GetByNames(team_id string, names []string, allowFromCach
 	return r0, r1
 }
 
+// GetChannel provides a mock function with given fields: id, includeDeleted
+func (_m *ChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	ret := _m.Called(id, includeDeleted)
+
+	var r0 *model.Channel
+	if rf, ok := ret.Get(0).(func(string, bool) *model.Channel); ok {
+		r0 = rf(id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelCounts provides a mock function with given fields: teamId, userId
 func (_m *ChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
 	ret := _m.Called(teamId, userId)
@@ -694,6 +719,52 @@ func (_m *ChannelStore) GetChannelsByScheme(schemeId string, offset int, limit i
 	return r0, r1
 }
 
+// GetChannelsCtx provides a mock function with given fields: ctx, teamId, userId, includeDeleted, lastDeleteAt
+func (_m *ChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	ret := _m.Called(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+
+	var r0 *model.ChannelList
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, int) *model.ChannelList); ok {
+		r0 = rf(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, int) error); ok {
+		r1 = rf(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCtx provides a mock function with given fields: ctx, id, allowFromCache
+func (_m *ChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	ret := _m.Called(ctx, id, allowFromCache)
+
+	var r0 *model.Channel
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.Channel); ok {
+		r0 = rf(ctx, id, allowFromCache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, id, allowFromCache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeleted provides a mock function with given fields: team_id, offset, limit, userId
 func (_m *ChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
 	ret := _m.Called(team_id, offset, limit, userId)
@@ -1003,6 +1074,59 @@ func (_m *ChannelStore) GetMembersForUserWithPagination(teamId string, userId st
 	return r0, r1
 }
 
+// GetMembersPaged provides a mock function with given fields: channelId, page, perPage, sort
+func (_m *ChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+	ret := _m.Called(channelId, page, perPage, sort)
+
+	var r0 *model.ChannelMembers
+	if rf, ok := ret.Get(0).(func(string, int, int, string) *model.ChannelMembers); ok {
+		r0 = rf(channelId, page, perPage, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMembers)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(string, int, int, string) int64); ok {
+		r1 = rf(channelId, page, perPage, sort)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, int, int, string) error); ok {
+		r2 = rf(channelId, page, perPage, sort)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMembersSince provides a mock function with given fields: channelId, since
+func (_m *ChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	ret := _m.Called(channelId, since)
+
+	var r0 *model.ChannelMembersSince
+	if rf, ok := ret.Get(0).(func(string, int64) *model.ChannelMembersSince); ok {
+		r0 = rf(channelId, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMembersSince)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(channelId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMoreChannels provides a mock function with given fields: teamId, userId, offset, limit
 func (_m *ChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
 	ret := _m.Called(teamId, userId, offset, limit)
@@ -1047,29 +1171,6 @@ func (_m *ChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool
 	return r0, r1
 }
 
-// GetPinnedPosts provides a mock function with given fields: channelId
-func (_m *ChannelStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
-	ret := _m.Called(channelId)
-
-	var r0 *model.PostList
-	if rf, ok := ret.Get(0).(func(string) *model.PostList); ok {
-		r0 = rf(channelId)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.PostList)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(channelId)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // GetPrivateChannelsForTeam provides a mock function with given fields: teamId, offset, limit
 func (_m *ChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
 	ret := _m.Called(teamId, offset, limit)
@@ -1231,6 +1332,29 @@ func (_m *ChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, erro
 	return r0, r1
 }
 
+// GetUnreadCountsForUser provides a mock function with given fields: userId
+func (_m *ChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	ret := _m.Called(userId)
+
+	var r0 map[string]*model.ChannelUnreadCounts
+	if rf, ok := ret.Get(0).(func(string) map[string]*model.ChannelUnreadCounts); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.ChannelUnreadCounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GroupSyncedChannelCount provides a mock function with given fields:
 func (_m *ChannelStore) GroupSyncedChannelCount() (int64, error) {
 	ret := _m.Called()
@@ -1547,6 +1671,38 @@ func (_m *ChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelM
 	return r0, r1
 }
 
+// SaveMemberMultiple provides a mock function with given fields: members
+func (_m *ChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	ret := _m.Called(members)
+
+	var r0 []*model.ChannelMember
+	if rf, ok := ret.Get(0).(func([]*model.ChannelMember) []*model.ChannelMember); ok {
+		r0 = rf(members)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMember)
+		}
+	}
+
+	var r1 []*model.ChannelMember
+	if rf, ok := ret.Get(1).(func([]*model.ChannelMember) []*model.ChannelMember); ok {
+		r1 = rf(members)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*model.ChannelMember)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]*model.ChannelMember) error); ok {
+		r2 = rf(members)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SaveMultipleMembers provides a mock function with given fields: members
 func (_m *ChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	ret := _m.Called(members)
@@ -1775,6 +1931,29 @@ func (_m *ChannelStore) UpdateLastViewedAt(channelIds []string, userId string, u
 	return r0, r1
 }
 
+// UpdateLastViewedAtMulti provides a mock function with given fields: channelIds, userId, timestamp
+func (_m *ChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	ret := _m.Called(channelIds, userId, timestamp)
+
+	var r0 map[string]*model.ChannelUnreadCounts
+	if rf, ok := ret.Get(0).(func([]string, string, int64) map[string]*model.ChannelUnreadCounts); ok {
+		r0 = rf(channelIds, userId, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.ChannelUnreadCounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, string, int64) error); ok {
+		r1 = rf(channelIds, userId, timestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLastViewedAtPost provides a mock function with given fields: unreadPost, userID, mentionCount, updateThreads
 func (_m *ChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
 	ret := _m.Called(unreadPost, userID, mentionCount, updateThreads)
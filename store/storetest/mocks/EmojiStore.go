@@ -120,6 +120,29 @@ func (_m *EmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error)
 	return r0, r1
 }
 
+// GetUsageCounts provides a mock function with given fields: emojiNames, includeMessages
+func (_m *EmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	ret := _m.Called(emojiNames, includeMessages)
+
+	var r0 map[string]*model.EmojiUsage
+	if rf, ok := ret.Get(0).(func([]string, bool) map[string]*model.EmojiUsage); ok {
+		r0 = rf(emojiNames, includeMessages)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.EmojiUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string, bool) error); ok {
+		r1 = rf(emojiNames, includeMessages)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: emoji
 func (_m *EmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 	ret := _m.Called(emoji)
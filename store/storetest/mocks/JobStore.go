@@ -14,6 +14,29 @@ type JobStore struct {
 	mock.Mock
 }
 
+// ClaimPending provides a mock function with given fields: jobType, nodeId
+func (_m *JobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	ret := _m.Called(jobType, nodeId)
+
+	var r0 *model.Job
+	if rf, ok := ret.Get(0).(func(string, string) *model.Job); ok {
+		r0 = rf(jobType, nodeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(jobType, nodeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *JobStore) Delete(id string) (string, error) {
 	ret := _m.Called(id)
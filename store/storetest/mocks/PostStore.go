@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	context "context"
+
 	model "github.com/mattermost/mattermost-server/v5/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,6 +16,29 @@ type PostStore struct {
 	mock.Mock
 }
 
+// AnalyticsCountByDay provides a mock function with given fields: teamId, startTime, endTime, timeZoneOffset
+func (_m *PostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	ret := _m.Called(teamId, startTime, endTime, timeZoneOffset)
+
+	var r0 []*model.AnalyticsRow
+	if rf, ok := ret.Get(0).(func(string, int64, int64, int) []*model.AnalyticsRow); ok {
+		r0 = rf(teamId, startTime, endTime, timeZoneOffset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AnalyticsRow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64, int) error); ok {
+		r1 = rf(teamId, startTime, endTime, timeZoneOffset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AnalyticsPostCount provides a mock function with given fields: teamId, mustHaveFile, mustHaveHashtag
 func (_m *PostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
 	ret := _m.Called(teamId, mustHaveFile, mustHaveHashtag)
@@ -310,6 +335,52 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterId string) ([]*mod
 	return r0, r1
 }
 
+// GetPinnedPosts provides a mock function with given fields: channelId
+func (_m *PostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+	ret := _m.Called(channelId)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(string) *model.PostList); ok {
+		r0 = rf(channelId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPost provides a mock function with given fields: id, includeDeleted
+func (_m *PostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+	ret := _m.Called(id, includeDeleted)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(string, bool) *model.Post); ok {
+		r0 = rf(id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostAfterTime provides a mock function with given fields: channelId, time
 func (_m *PostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
 	ret := _m.Called(channelId, time)
@@ -375,6 +446,29 @@ func (_m *PostStore) GetPostIdBeforeTime(channelId string, time int64) (string,
 	return r0, r1
 }
 
+// GetPostWithContext provides a mock function with given fields: postId, before, after
+func (_m *PostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+	ret := _m.Called(postId, before, after)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(string, int, int) *model.PostList); ok {
+		r0 = rf(postId, before, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(postId, before, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPosts provides a mock function with given fields: options, allowFromCache
 func (_m *PostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 	ret := _m.Called(options, allowFromCache)
@@ -421,6 +515,36 @@ func (_m *PostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostLi
 	return r0, r1
 }
 
+// GetPostsAfterCursor provides a mock function with given fields: options
+func (_m *PostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	ret := _m.Called(options)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(model.GetPostsCursorOptions) *model.PostList); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(model.GetPostsCursorOptions) string); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(model.GetPostsCursorOptions) error); ok {
+		r2 = rf(options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetPostsBatchForIndexing provides a mock function with given fields: startTime, endTime, limit
 func (_m *PostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
 	ret := _m.Called(startTime, endTime, limit)
@@ -444,6 +568,20 @@ func (_m *PostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, li
 	return r0, r1
 }
 
+// GetPostsBatched provides a mock function with given fields: channelId, batchSize, fn
+func (_m *PostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	ret := _m.Called(channelId, batchSize, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, func([]*model.Post) error) error); ok {
+		r0 = rf(channelId, batchSize, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPostsBefore provides a mock function with given fields: options
 func (_m *PostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
 	ret := _m.Called(options)
@@ -467,6 +605,36 @@ func (_m *PostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostL
 	return r0, r1
 }
 
+// GetPostsBeforeCursor provides a mock function with given fields: options
+func (_m *PostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	ret := _m.Called(options)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(model.GetPostsCursorOptions) *model.PostList); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(model.GetPostsCursorOptions) string); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(model.GetPostsCursorOptions) error); ok {
+		r2 = rf(options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetPostsByIds provides a mock function with given fields: postIds
 func (_m *PostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	ret := _m.Called(postIds)
@@ -490,6 +658,29 @@ func (_m *PostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	return r0, r1
 }
 
+// GetPostsByProp provides a mock function with given fields: channelId, key, value
+func (_m *PostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+	ret := _m.Called(channelId, key, value)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, string, string) []*model.Post); ok {
+		r0 = rf(channelId, key, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(channelId, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostsCreatedAt provides a mock function with given fields: channelId, time
 func (_m *PostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
 	ret := _m.Called(channelId, time)
@@ -513,6 +704,29 @@ func (_m *PostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.P
 	return r0, r1
 }
 
+// GetPostsCtx provides a mock function with given fields: ctx, options, allowFromCache
+func (_m *PostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	ret := _m.Called(ctx, options, allowFromCache)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetPostsOptions, bool) *model.PostList); ok {
+		r0 = rf(ctx, options, allowFromCache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetPostsOptions, bool) error); ok {
+		r1 = rf(ctx, options, allowFromCache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostsSince provides a mock function with given fields: options, allowFromCache
 func (_m *PostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
 	ret := _m.Called(options, allowFromCache)
@@ -536,6 +750,59 @@ func (_m *PostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFrom
 	return r0, r1
 }
 
+// GetPostsSinceCtx provides a mock function with given fields: ctx, options, allowFromCache
+func (_m *PostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	ret := _m.Called(ctx, options, allowFromCache)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetPostsSinceOptions, bool) *model.PostList); ok {
+		r0 = rf(ctx, options, allowFromCache)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetPostsSinceOptions, bool) error); ok {
+		r1 = rf(ctx, options, allowFromCache)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecentPostsForUser provides a mock function with given fields: options
+func (_m *PostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	ret := _m.Called(options)
+
+	var r0 *model.PostList
+	if rf, ok := ret.Get(0).(func(model.GetRecentPostsOptions) *model.PostList); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(model.GetRecentPostsOptions) string); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(model.GetRecentPostsOptions) error); ok {
+		r2 = rf(options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRepliesForExport provides a mock function with given fields: parentId
 func (_m *PostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
 	ret := _m.Called(parentId)
@@ -582,11 +849,57 @@ func (_m *PostStore) GetSingle(id string) (*model.Post, error) {
 	return r0, r1
 }
 
+// GetSingleCtx provides a mock function with given fields: ctx, id
+func (_m *PostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Post
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateLastPostTimeCache provides a mock function with given fields: channelId
 func (_m *PostStore) InvalidateLastPostTimeCache(channelId string) {
 	_m.Called(channelId)
 }
 
+// MoveThread provides a mock function with given fields: rootId, targetChannelId, allowCrossTeam
+func (_m *PostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	ret := _m.Called(rootId, targetChannelId, allowCrossTeam)
+
+	var r0 []*model.Post
+	if rf, ok := ret.Get(0).(func(string, string, bool) []*model.Post); ok {
+		r0 = rf(rootId, targetChannelId, allowCrossTeam)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, bool) error); ok {
+		r1 = rf(rootId, targetChannelId, allowCrossTeam)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Overwrite provides a mock function with given fields: post
 func (_m *PostStore) Overwrite(post *model.Post) (*model.Post, error) {
 	ret := _m.Called(post)
@@ -765,6 +1078,29 @@ func (_m *PostStore) Search(teamId string, userId string, params *model.SearchPa
 	return r0, r1
 }
 
+// SearchAllTeams provides a mock function with given fields: userId, terms, opts
+func (_m *PostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	ret := _m.Called(userId, terms, opts)
+
+	var r0 *model.PostSearchResults
+	if rf, ok := ret.Get(0).(func(string, string, model.SearchAllTeamsOptions) *model.PostSearchResults); ok {
+		r0 = rf(userId, terms, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostSearchResults)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, model.SearchAllTeamsOptions) error); ok {
+		r1 = rf(userId, terms, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchPostsInTeamForUser provides a mock function with given fields: paramsList, userId, teamId, page, perPage
 func (_m *PostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
 	ret := _m.Called(paramsList, userId, teamId, page, perPage)
@@ -788,6 +1124,52 @@ func (_m *PostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams,
 	return r0, r1
 }
 
+// SearchPostsInTeamForUserAfter provides a mock function with given fields: paramsList, userId, teamId, searchAfter, perPage
+func (_m *PostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	ret := _m.Called(paramsList, userId, teamId, searchAfter, perPage)
+
+	var r0 *model.PostSearchResults
+	if rf, ok := ret.Get(0).(func([]*model.SearchParams, string, string, *model.PostSearchCursor, int) *model.PostSearchResults); ok {
+		r0 = rf(paramsList, userId, teamId, searchAfter, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostSearchResults)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]*model.SearchParams, string, string, *model.PostSearchCursor, int) error); ok {
+		r1 = rf(paramsList, userId, teamId, searchAfter, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuggestTerms provides a mock function with given fields: userId, teamId, prefix, limit
+func (_m *PostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	ret := _m.Called(userId, teamId, prefix, limit)
+
+	var r0 []*model.SearchSuggestion
+	if rf, ok := ret.Get(0).(func(string, string, string, int) []*model.SearchSuggestion); ok {
+		r0 = rf(userId, teamId, prefix, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SearchSuggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, int) error); ok {
+		r1 = rf(userId, teamId, prefix, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: newPost, oldPost
 func (_m *PostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
 	ret := _m.Called(newPost, oldPost)
@@ -173,3 +173,26 @@ func (_m *PreferenceStore) Save(preferences *model.Preferences) error {
 
 	return r0
 }
+
+// SaveMultiple provides a mock function with given fields: preferences, deleteOmitted
+func (_m *PreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	ret := _m.Called(preferences, deleteOmitted)
+
+	var r0 model.Preferences
+	if rf, ok := ret.Get(0).(func(model.Preferences, bool) model.Preferences); ok {
+		r0 = rf(preferences, deleteOmitted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Preferences)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(model.Preferences, bool) error); ok {
+		r1 = rf(preferences, deleteOmitted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
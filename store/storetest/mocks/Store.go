@@ -36,6 +36,22 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditTrail provides a mock function with given fields:
+func (_m *Store) AuditTrail() store.AuditTrailStore {
+	ret := _m.Called()
+
+	var r0 store.AuditTrailStore
+	if rf, ok := ret.Get(0).(func() store.AuditTrailStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditTrailStore)
+		}
+	}
+
+	return r0
+}
+
 // Bot provides a mock function with given fields:
 func (_m *Store) Bot() store.BotStore {
 	ret := _m.Called()
@@ -185,6 +201,27 @@ func (_m *Store) Context() context.Context {
 	return r0
 }
 
+// DeleteOrphans provides a mock function with given fields: entityType, batchSize
+func (_m *Store) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	ret := _m.Called(entityType, batchSize)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, int) int64); ok {
+		r0 = rf(entityType, batchSize)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(entityType, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DropAllTables provides a mock function with given fields:
 func (_m *Store) DropAllTables() {
 	_m.Called()
@@ -222,6 +259,29 @@ func (_m *Store) FileInfo() store.FileInfoStore {
 	return r0
 }
 
+// FindOrphans provides a mock function with given fields: entityType, limit
+func (_m *Store) FindOrphans(entityType string, limit int) ([]string, error) {
+	ret := _m.Called(entityType, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, int) []string); ok {
+		r0 = rf(entityType, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(entityType, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCurrentSchemaVersion provides a mock function with given fields:
 func (_m *Store) GetCurrentSchemaVersion() string {
 	ret := _m.Called()
@@ -273,6 +333,20 @@ func (_m *Store) Group() store.GroupStore {
 	return r0
 }
 
+// HealthCheck provides a mock function with given fields: ctx
+func (_m *Store) HealthCheck(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Job provides a mock function with given fields:
 func (_m *Store) Job() store.JobStore {
 	ret := _m.Called()
@@ -347,6 +421,27 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// PermanentDeleteBatchForRetention provides a mock function with given fields: retentionDays, limit
+func (_m *Store) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	ret := _m.Called(retentionDays, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int, int) int64); ok {
+		r0 = rf(retentionDays, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(retentionDays, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
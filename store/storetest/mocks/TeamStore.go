@@ -554,6 +554,29 @@ func (_m *TeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMember
 	return r0, r1
 }
 
+// GetTeamStats provides a mock function with given fields: teamId, restrictions
+func (_m *TeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	ret := _m.Called(teamId, restrictions)
+
+	var r0 *model.TeamStats
+	if rf, ok := ret.Get(0).(func(string, *model.ViewUsersRestrictions) *model.TeamStats); ok {
+		r0 = rf(teamId, restrictions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *model.ViewUsersRestrictions) error); ok {
+		r1 = rf(teamId, restrictions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTeamsByScheme provides a mock function with given fields: schemeId, offset, limit
 func (_m *TeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
 	ret := _m.Called(schemeId, offset, limit)
@@ -148,13 +148,13 @@ func (_m *ThreadStore) GetMembershipsForUser(userId string) ([]*model.ThreadMemb
 	return r0, r1
 }
 
-// GetThreadsForUser provides a mock function with given fields: userId, opts
-func (_m *ThreadStore) GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
-	ret := _m.Called(userId, opts)
+// GetThreadsForUser provides a mock function with given fields: userId, teamId, opts
+func (_m *ThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+	ret := _m.Called(userId, teamId, opts)
 
 	var r0 *model.Threads
-	if rf, ok := ret.Get(0).(func(string, model.GetUserThreadsOpts) *model.Threads); ok {
-		r0 = rf(userId, opts)
+	if rf, ok := ret.Get(0).(func(string, string, model.GetUserThreadsOpts) *model.Threads); ok {
+		r0 = rf(userId, teamId, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Threads)
@@ -162,8 +162,8 @@ func (_m *ThreadStore) GetThreadsForUser(userId string, opts model.GetUserThread
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, model.GetUserThreadsOpts) error); ok {
-		r1 = rf(userId, opts)
+	if rf, ok := ret.Get(1).(func(string, string, model.GetUserThreadsOpts) error); ok {
+		r1 = rf(userId, teamId, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -944,6 +944,29 @@ func (_m *UserStore) GetUnreadCountForChannel(userId string, channelId string) (
 	return r0, r1
 }
 
+// GetUser provides a mock function with given fields: id, includeDeleted
+func (_m *UserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+	ret := _m.Called(id, includeDeleted)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(string, bool) *model.User); ok {
+		r0 = rf(id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsersBatchForIndexing provides a mock function with given fields: startTime, endTime, limit
 func (_m *UserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
 	ret := _m.Called(startTime, endTime, limit)
@@ -1320,6 +1343,20 @@ func (_m *UserStore) UpdatePassword(userId string, newPassword string) error {
 	return r0
 }
 
+// UpdatePasswordHash provides a mock function with given fields: userId, oldHash, newHash
+func (_m *UserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	ret := _m.Called(userId, oldHash, newHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(userId, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUpdateAt provides a mock function with given fields: userId
 func (_m *UserStore) UpdateUpdateAt(userId string) (int64, error) {
 	ret := _m.Called(userId)
@@ -0,0 +1,5620 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package storetest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+// notSupportedStore provides sub stores failing every call with a store.ErrNotImplemented, or an AppError
+// for the methods still returning one. The memory store falls back to them for the entities it
+// doesn't hold.
+type notSupportedStore struct{}
+
+func (notSupportedStore) Audit() store.AuditStore {
+	return notSupportedAuditStore{}
+}
+
+func (notSupportedStore) AuditTrail() store.AuditTrailStore {
+	return notSupportedAuditTrailStore{}
+}
+
+func (notSupportedStore) Bot() store.BotStore {
+	return notSupportedBotStore{}
+}
+
+func (notSupportedStore) Channel() store.ChannelStore {
+	return notSupportedChannelStore{}
+}
+
+func (notSupportedStore) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return notSupportedChannelMemberHistoryStore{}
+}
+
+func (notSupportedStore) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return notSupportedClusterDiscoveryStore{}
+}
+
+func (notSupportedStore) Command() store.CommandStore {
+	return notSupportedCommandStore{}
+}
+
+func (notSupportedStore) CommandWebhook() store.CommandWebhookStore {
+	return notSupportedCommandWebhookStore{}
+}
+
+func (notSupportedStore) Compliance() store.ComplianceStore {
+	return notSupportedComplianceStore{}
+}
+
+func (notSupportedStore) Emoji() store.EmojiStore {
+	return notSupportedEmojiStore{}
+}
+
+func (notSupportedStore) FileInfo() store.FileInfoStore {
+	return notSupportedFileInfoStore{}
+}
+
+func (notSupportedStore) Group() store.GroupStore {
+	return notSupportedGroupStore{}
+}
+
+func (notSupportedStore) Job() store.JobStore {
+	return notSupportedJobStore{}
+}
+
+func (notSupportedStore) License() store.LicenseStore {
+	return notSupportedLicenseStore{}
+}
+
+func (notSupportedStore) LinkMetadata() store.LinkMetadataStore {
+	return notSupportedLinkMetadataStore{}
+}
+
+func (notSupportedStore) OAuth() store.OAuthStore {
+	return notSupportedOAuthStore{}
+}
+
+func (notSupportedStore) Plugin() store.PluginStore {
+	return notSupportedPluginStore{}
+}
+
+func (notSupportedStore) Post() store.PostStore {
+	return notSupportedPostStore{}
+}
+
+func (notSupportedStore) Preference() store.PreferenceStore {
+	return notSupportedPreferenceStore{}
+}
+
+func (notSupportedStore) ProductNotices() store.ProductNoticesStore {
+	return notSupportedProductNoticesStore{}
+}
+
+func (notSupportedStore) Reaction() store.ReactionStore {
+	return notSupportedReactionStore{}
+}
+
+func (notSupportedStore) Role() store.RoleStore {
+	return notSupportedRoleStore{}
+}
+
+func (notSupportedStore) Scheme() store.SchemeStore {
+	return notSupportedSchemeStore{}
+}
+
+func (notSupportedStore) Session() store.SessionStore {
+	return notSupportedSessionStore{}
+}
+
+func (notSupportedStore) Status() store.StatusStore {
+	return notSupportedStatusStore{}
+}
+
+func (notSupportedStore) System() store.SystemStore {
+	return notSupportedSystemStore{}
+}
+
+func (notSupportedStore) Team() store.TeamStore {
+	return notSupportedTeamStore{}
+}
+
+func (notSupportedStore) TermsOfService() store.TermsOfServiceStore {
+	return notSupportedTermsOfServiceStore{}
+}
+
+func (notSupportedStore) Thread() store.ThreadStore {
+	return notSupportedThreadStore{}
+}
+
+func (notSupportedStore) Token() store.TokenStore {
+	return notSupportedTokenStore{}
+}
+
+func (notSupportedStore) UploadSession() store.UploadSessionStore {
+	return notSupportedUploadSessionStore{}
+}
+
+func (notSupportedStore) User() store.UserStore {
+	return notSupportedUserStore{}
+}
+
+func (notSupportedStore) UserAccessToken() store.UserAccessTokenStore {
+	return notSupportedUserAccessTokenStore{}
+}
+
+func (notSupportedStore) UserTermsOfService() store.UserTermsOfServiceStore {
+	return notSupportedUserTermsOfServiceStore{}
+}
+
+func (notSupportedStore) Webhook() store.WebhookStore {
+	return notSupportedWebhookStore{}
+}
+
+type notSupportedAuditStore struct{}
+
+type notSupportedAuditTrailStore struct{}
+
+type notSupportedBotStore struct{}
+
+type notSupportedChannelStore struct{}
+
+type notSupportedChannelMemberHistoryStore struct{}
+
+type notSupportedClusterDiscoveryStore struct{}
+
+type notSupportedCommandStore struct{}
+
+type notSupportedCommandWebhookStore struct{}
+
+type notSupportedComplianceStore struct{}
+
+type notSupportedEmojiStore struct{}
+
+type notSupportedFileInfoStore struct{}
+
+type notSupportedGroupStore struct{}
+
+type notSupportedJobStore struct{}
+
+type notSupportedLicenseStore struct{}
+
+type notSupportedLinkMetadataStore struct{}
+
+type notSupportedOAuthStore struct{}
+
+type notSupportedPluginStore struct{}
+
+type notSupportedPostStore struct{}
+
+type notSupportedPreferenceStore struct{}
+
+type notSupportedProductNoticesStore struct{}
+
+type notSupportedReactionStore struct{}
+
+type notSupportedRoleStore struct{}
+
+type notSupportedSchemeStore struct{}
+
+type notSupportedSessionStore struct{}
+
+type notSupportedStatusStore struct{}
+
+type notSupportedSystemStore struct{}
+
+type notSupportedTeamStore struct{}
+
+type notSupportedTermsOfServiceStore struct{}
+
+type notSupportedThreadStore struct{}
+
+type notSupportedTokenStore struct{}
+
+type notSupportedUploadSessionStore struct{}
+
+type notSupportedUserStore struct{}
+
+type notSupportedUserAccessTokenStore struct{}
+
+type notSupportedUserTermsOfServiceStore struct{}
+
+type notSupportedWebhookStore struct{}
+
+func (s notSupportedAuditStore) Get(user_id string, offset int, limit int) (model.Audits, error) {
+
+	var result model.Audits
+
+	err := store.NewErrNotImplemented("AuditStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedAuditStore) PermanentDeleteByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("AuditStore.PermanentDeleteByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedAuditStore) Save(audit *model.Audit) error {
+
+	err := store.NewErrNotImplemented("AuditStore.Save is not supported")
+
+	return err
+
+}
+
+func (s notSupportedAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+
+	var result []*model.AuditTrailEntry
+
+	err := store.NewErrNotImplemented("AuditTrailStore.GetByEntity is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+
+	err := store.NewErrNotImplemented("AuditTrailStore.Record is not supported")
+
+	return err
+
+}
+
+func (s notSupportedBotStore) Get(userId string, includeDeleted bool) (*model.Bot, error) {
+
+	var result *model.Bot
+
+	err := store.NewErrNotImplemented("BotStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+
+	var result []*model.Bot
+
+	err := store.NewErrNotImplemented("BotStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedBotStore) PermanentDelete(userId string) error {
+
+	err := store.NewErrNotImplemented("BotStore.PermanentDelete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+
+	var result *model.Bot
+
+	err := store.NewErrNotImplemented("BotStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+
+	var result *model.Bot
+
+	err := store.NewErrNotImplemented("BotStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) AnalyticsDeletedTypeCount(teamId string, channelType string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.AnalyticsDeletedTypeCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) AnalyticsTypeCount(teamId string, channelType string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.AnalyticsTypeCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) AutocompleteInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.AutocompleteInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) AutocompleteInTeamForSearch(teamId string, userId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.AutocompleteInTeamForSearch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) ClearAllCustomRoleAssignments() error {
+
+	err := store.NewErrNotImplemented("ChannelStore.ClearAllCustomRoleAssignments is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) ClearCaches() {
+
+}
+
+func (s notSupportedChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.ClearSidebarOnTeamLeave is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) CountPostsAfter(channelId string, timestamp int64, userId string) (int, error) {
+
+	var result int
+
+	err := store.NewErrNotImplemented("ChannelStore.CountPostsAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) CreateDirectChannel(userId *model.User, otherUserId *model.User) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.CreateDirectChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) CreateInitialSidebarCategories(userId string, teamId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.CreateInitialSidebarCategories is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) CreateSidebarCategory(userId string, teamId string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+
+	var result *model.SidebarCategoryWithChannels
+
+	err := store.NewErrNotImplemented("ChannelStore.CreateSidebarCategory is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) Delete(channelId string, time int64) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) DeleteSidebarCategory(categoryId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.DeleteSidebarCategory is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) DeleteSidebarChannelsByPreferences(preferences *model.Preferences) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.DeleteSidebarChannelsByPreferences is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAll(teamId string) ([]*model.Channel, error) {
+
+	var result []*model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllChannelMembersForUser(userId string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+
+	var result map[string]string
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllChannelMembersForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelId string, allowFromCache bool) (map[string]model.StringMap, error) {
+
+	var result map[string]model.StringMap
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllChannelMembersNotifyPropsForChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, error) {
+
+	var result *model.ChannelListWithTeamData
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllChannelsCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllChannelsForExportAfter(limit int, afterId string) ([]*model.ChannelForExport, error) {
+
+	var result []*model.ChannelForExport
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllChannelsForExportAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterId string) ([]*model.DirectChannelForExport, error) {
+
+	var result []*model.DirectChannelForExport
+
+	err := store.NewErrNotImplemented("ChannelStore.GetAllDirectChannelsForExportAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetByName(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetByNameIncludeDeleted(team_id string, name string, allowFromCache bool) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetByNameIncludeDeleted is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetByNames(team_id string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+
+	var result []*model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetByNames is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelCounts(teamId string, userId string) (*model.ChannelCounts, error) {
+
+	var result *model.ChannelCounts
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelCounts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelMembersForExport(userId string, teamId string) ([]*model.ChannelMemberForExport, error) {
+
+	var result []*model.ChannelMemberForExport
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelMembersForExport is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
+
+	var result []model.StringMap
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelMembersTimezones is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelUnread(channelId string, userId string) (*model.ChannelUnread, error) {
+
+	var result *model.ChannelUnread
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelUnread is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannels(teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.Channel, error) {
+
+	var result []*model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelsBatchForIndexing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+
+	var result []*model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelsByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelsByScheme(schemeId string, offset int, limit int) (model.ChannelList, error) {
+
+	var result model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelsByScheme is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetChannelsCtx is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetCtx is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetDeleted(team_id string, offset int, limit int, userId string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetDeleted is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetDeletedByName(team_id string, name string) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetDeletedByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetForPost(postId string) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetForPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetFromMaster(id string) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.GetFromMaster is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetGuestCount(channelId string, allowFromCache bool) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GetGuestCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMember(channelId string, userId string) (*model.ChannelMember, error) {
+
+	var result *model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMemberCount(channelId string, allowFromCache bool) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMemberCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMemberCountFromCache(channelId string) int64 {
+
+	var result int64
+
+	return result
+
+}
+
+func (s notSupportedChannelStore) GetMemberCountsByGroup(channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+
+	var result []*model.ChannelMemberCountByGroup
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMemberCountsByGroup is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMemberForPost(postId string, userId string) (*model.ChannelMember, error) {
+
+	var result *model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMemberForPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMembers(channelId string, offset int, limit int) (*model.ChannelMembers, error) {
+
+	var result *model.ChannelMembers
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMembersByIds(channelId string, userIds []string) (*model.ChannelMembers, error) {
+
+	var result *model.ChannelMembers
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembersByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMembersForUser(teamId string, userId string) (*model.ChannelMembers, error) {
+
+	var result *model.ChannelMembers
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembersForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMembersForUserWithPagination(teamId string, userId string, page int, perPage int) (*model.ChannelMembers, error) {
+
+	var result *model.ChannelMembers
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembersForUserWithPagination is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
+
+	var result *model.ChannelMembers
+	var resultVar1 int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembersPaged is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+
+	var result *model.ChannelMembersSince
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMembersSince is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetMoreChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GetPinnedPostCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetPrivateChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetPrivateChannelsForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetPublicChannelsByIdsForTeam(teamId string, channelIds []string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetPublicChannelsByIdsForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetPublicChannelsForTeam(teamId string, offset int, limit int) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetPublicChannelsForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetSidebarCategories(userId string, teamId string) (*model.OrderedSidebarCategories, error) {
+
+	var result *model.OrderedSidebarCategories
+
+	err := store.NewErrNotImplemented("ChannelStore.GetSidebarCategories is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetSidebarCategory(categoryId string) (*model.SidebarCategoryWithChannels, error) {
+
+	var result *model.SidebarCategoryWithChannels
+
+	err := store.NewErrNotImplemented("ChannelStore.GetSidebarCategory is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetSidebarCategoryOrder(userId string, teamId string) ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("ChannelStore.GetSidebarCategoryOrder is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetTeamChannels(teamId string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.GetTeamChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+
+	var result map[string]*model.ChannelUnreadCounts
+
+	err := store.NewErrNotImplemented("ChannelStore.GetUnreadCountsForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) GroupSyncedChannelCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelStore.GroupSyncedChannelCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) IncrementMentionCount(channelId string, userId string, updateThreads bool) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.IncrementMentionCount is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) InvalidateAllChannelMembersForUser(userId string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidateChannel(id string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidateChannelByName(teamId string, name string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidateGuestCount(channelId string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidateMemberCount(channelId string) {
+
+}
+
+func (s notSupportedChannelStore) InvalidatePinnedPostCount(channelId string) {
+
+}
+
+func (s notSupportedChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
+
+	var result bool
+
+	return result
+
+}
+
+func (s notSupportedChannelStore) MigrateChannelMembers(fromChannelId string, fromUserId string) (map[string]string, error) {
+
+	var result map[string]string
+
+	err := store.NewErrNotImplemented("ChannelStore.MigrateChannelMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) MigratePublicChannels() error {
+
+	err := store.NewErrNotImplemented("ChannelStore.MigratePublicChannels is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) PermanentDelete(channelId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.PermanentDelete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) PermanentDeleteByTeam(teamId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.PermanentDeleteByTeam is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) PermanentDeleteMembersByChannel(channelId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.PermanentDeleteMembersByChannel is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) PermanentDeleteMembersByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.PermanentDeleteMembersByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) RemoveAllDeactivatedMembers(channelId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.RemoveAllDeactivatedMembers is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) RemoveMember(channelId string, userId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.RemoveMember is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) RemoveMembers(channelId string, userIds []string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.RemoveMembers is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) ResetAllChannelSchemes() error {
+
+	err := store.NewErrNotImplemented("ChannelStore.ResetAllChannelSchemes is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) Restore(channelId string, time int64) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.Restore is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) Save(channel *model.Channel, maxChannelsPerTeam int64) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SaveDirectChannel(channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.SaveDirectChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SaveMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	var result *model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.SaveMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+
+	var result []*model.ChannelMember
+	var resultVar1 []*model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.SaveMemberMultiple is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	var result []*model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.SaveMultipleMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (*model.ChannelListWithTeamData, int64, error) {
+
+	var result *model.ChannelListWithTeamData
+	var resultVar1 int64
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchAllChannels is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedChannelStore) SearchArchivedInTeam(teamId string, term string, userId string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchArchivedInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SearchForUserInTeam(userId string, teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchForUserInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SearchGroupChannels(userId string, term string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchGroupChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SearchInTeam(teamId string, term string, includeDeleted bool) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SearchMore(userId string, teamId string, term string) (*model.ChannelList, error) {
+
+	var result *model.ChannelList
+
+	err := store.NewErrNotImplemented("ChannelStore.SearchMore is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) SetDeleteAt(channelId string, deleteAt int64, updateAt int64) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.SetDeleteAt is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) Update(channel *model.Channel) (*model.Channel, error) {
+
+	var result *model.Channel
+
+	err := store.NewErrNotImplemented("ChannelStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateLastViewedAt(channelIds []string, userId string, updateThreads bool) (map[string]int64, error) {
+
+	var result map[string]int64
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateLastViewedAt is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+
+	var result map[string]*model.ChannelUnreadCounts
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateLastViewedAtMulti is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, updateThreads bool) (*model.ChannelUnreadAt, error) {
+
+	var result *model.ChannelUnreadAt
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateLastViewedAtPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateMember(member *model.ChannelMember) (*model.ChannelMember, error) {
+
+	var result *model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateMembersRole(channelID string, userIDs []string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateMembersRole is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+
+	var result []*model.ChannelMember
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateMultipleMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateSidebarCategories(userId string, teamId string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, error) {
+
+	var result []*model.SidebarCategoryWithChannels
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateSidebarCategories is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelStore) UpdateSidebarCategoryOrder(userId string, teamId string, categoryOrder []string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateSidebarCategoryOrder is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamId string) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateSidebarChannelCategoryOnMove is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) UpdateSidebarChannelsByPreferences(preferences *model.Preferences) error {
+
+	err := store.NewErrNotImplemented("ChannelStore.UpdateSidebarChannelsByPreferences is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelStore) UserBelongsToChannels(userId string, channelIds []string) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("ChannelStore.UserBelongsToChannels is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelId string) ([]*model.ChannelMemberHistoryResult, error) {
+
+	var result []*model.ChannelMemberHistoryResult
+
+	err := store.NewErrNotImplemented("ChannelMemberHistoryStore.GetUsersInChannelDuring is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedChannelMemberHistoryStore) LogJoinEvent(userId string, channelId string, joinTime int64) error {
+
+	err := store.NewErrNotImplemented("ChannelMemberHistoryStore.LogJoinEvent is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelMemberHistoryStore) LogLeaveEvent(userId string, channelId string, leaveTime int64) error {
+
+	err := store.NewErrNotImplemented("ChannelMemberHistoryStore.LogLeaveEvent is not supported")
+
+	return err
+
+}
+
+func (s notSupportedChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ChannelMemberHistoryStore.PermanentDeleteBatch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) Cleanup() error {
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.Cleanup is not supported")
+
+	return err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.Delete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.Exists is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+
+	var result []*model.ClusterDiscovery
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.Save is not supported")
+
+	return err
+
+}
+
+func (s notSupportedClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+
+	err := store.NewErrNotImplemented("ClusterDiscoveryStore.SetLastPingAt is not supported")
+
+	return err
+
+}
+
+func (s notSupportedCommandStore) AnalyticsCommandCount(teamId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("CommandStore.AnalyticsCommandCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandStore) Delete(commandId string, time int64) error {
+
+	err := store.NewErrNotImplemented("CommandStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedCommandStore) Get(id string) (*model.Command, error) {
+
+	var result *model.Command
+
+	err := store.NewErrNotImplemented("CommandStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandStore) GetByTeam(teamId string) ([]*model.Command, error) {
+
+	var result []*model.Command
+
+	err := store.NewErrNotImplemented("CommandStore.GetByTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandStore) GetByTrigger(teamId string, trigger string) (*model.Command, error) {
+
+	var result *model.Command
+
+	err := store.NewErrNotImplemented("CommandStore.GetByTrigger is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandStore) PermanentDeleteByTeam(teamId string) error {
+
+	err := store.NewErrNotImplemented("CommandStore.PermanentDeleteByTeam is not supported")
+
+	return err
+
+}
+
+func (s notSupportedCommandStore) PermanentDeleteByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("CommandStore.PermanentDeleteByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+
+	var result *model.Command
+
+	err := store.NewErrNotImplemented("CommandStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandStore) Update(hook *model.Command) (*model.Command, error) {
+
+	var result *model.Command
+
+	err := store.NewErrNotImplemented("CommandStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandWebhookStore) Cleanup() {
+
+}
+
+func (s notSupportedCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+
+	var result *model.CommandWebhook
+
+	err := store.NewErrNotImplemented("CommandWebhookStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+
+	var result *model.CommandWebhook
+
+	err := store.NewErrNotImplemented("CommandWebhookStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedCommandWebhookStore) TryUse(id string, limit int) error {
+
+	err := store.NewErrNotImplemented("CommandWebhookStore.TryUse is not supported")
+
+	return err
+
+}
+
+func (s notSupportedComplianceStore) ComplianceExport(compliance *model.Compliance) ([]*model.CompliancePost, error) {
+
+	var result []*model.CompliancePost
+
+	err := store.NewErrNotImplemented("ComplianceStore.ComplianceExport is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedComplianceStore) Get(id string) (*model.Compliance, error) {
+
+	var result *model.Compliance
+
+	err := store.NewErrNotImplemented("ComplianceStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+
+	var result model.Compliances
+
+	err := store.NewErrNotImplemented("ComplianceStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedComplianceStore) MessageExport(after int64, limit int) ([]*model.MessageExport, error) {
+
+	var result []*model.MessageExport
+
+	err := store.NewErrNotImplemented("ComplianceStore.MessageExport is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+
+	var result *model.Compliance
+
+	err := store.NewErrNotImplemented("ComplianceStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+
+	var result *model.Compliance
+
+	err := store.NewErrNotImplemented("ComplianceStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) Delete(emoji *model.Emoji, time int64) error {
+
+	err := store.NewErrNotImplemented("EmojiStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji, error) {
+
+	var result *model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) GetByName(name string, allowFromCache bool) (*model.Emoji, error) {
+
+	var result *model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+
+	var result []*model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.GetList is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji, error) {
+
+	var result []*model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.GetMultipleByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+
+	var result map[string]*model.EmojiUsage
+
+	err := store.NewErrNotImplemented("EmojiStore.GetUsageCounts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+
+	var result *model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+
+	var result []*model.Emoji
+
+	err := store.NewErrNotImplemented("EmojiStore.Search is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) AttachToPost(fileId string, postId string, creatorId string) error {
+
+	err := store.NewErrNotImplemented("FileInfoStore.AttachToPost is not supported")
+
+	return err
+
+}
+
+func (s notSupportedFileInfoStore) ClearCaches() {
+
+}
+
+func (s notSupportedFileInfoStore) DeleteForPost(postId string) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("FileInfoStore.DeleteForPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) Get(id string) (*model.FileInfo, error) {
+
+	var result *model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+
+	var result []*model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.GetByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+
+	var result *model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.GetByPath is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) GetForPost(postId string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+
+	var result []*model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.GetForPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) GetForUser(userId string) ([]*model.FileInfo, error) {
+
+	var result []*model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.GetForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+
+	var result []*model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.GetWithOptions is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) InvalidateFileInfosForPostCache(postId string, deleted bool) {
+
+}
+
+func (s notSupportedFileInfoStore) PermanentDelete(fileId string) error {
+
+	err := store.NewErrNotImplemented("FileInfoStore.PermanentDelete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedFileInfoStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("FileInfoStore.PermanentDeleteBatch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) PermanentDeleteByUser(userId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("FileInfoStore.PermanentDeleteByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, error) {
+
+	var result *model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+
+	var result *model.FileInfoList
+
+	err := store.NewErrNotImplemented("FileInfoStore.Search is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedFileInfoStore) SetContent(fileId string, content string) error {
+
+	err := store.NewErrNotImplemented("FileInfoStore.SetContent is not supported")
+
+	return err
+
+}
+
+func (s notSupportedFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo, error) {
+
+	var result *model.FileInfo
+
+	err := store.NewErrNotImplemented("FileInfoStore.Upsert is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, *model.AppError) {
+
+	var result []string
+
+	err := model.NewAppError("GroupStore.AdminRoleGroupsForSyncableMember", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+
+	var result []*model.UserWithGroups
+
+	err := model.NewAppError("GroupStore.ChannelMembersMinusGroupMembers", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) ChannelMembersToAdd(since int64, channelID *string) ([]*model.UserChannelIDPair, *model.AppError) {
+
+	var result []*model.UserChannelIDPair
+
+	err := model.NewAppError("GroupStore.ChannelMembersToAdd", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, *model.AppError) {
+
+	var result []*model.ChannelMember
+
+	err := model.NewAppError("GroupStore.ChannelMembersToRemove", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.CountChannelMembersMinusGroupMembers", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) CountGroupsByChannel(channelId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.CountGroupsByChannel", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) CountGroupsByTeam(teamId string, opts model.GroupSearchOpts) (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.CountGroupsByTeam", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.CountTeamMembersMinusGroupMembers", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) Create(group *model.Group) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.Create", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+
+	var result *model.GroupSyncable
+
+	err := model.NewAppError("GroupStore.CreateGroupSyncable", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) Delete(groupID string) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.Delete", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+
+	var result *model.GroupSyncable
+
+	err := model.NewAppError("GroupStore.DeleteGroupSyncable", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+
+	var result *model.GroupMember
+
+	err := model.NewAppError("GroupStore.DeleteMember", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) DistinctGroupMemberCount() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.DistinctGroupMemberCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) Get(groupID string) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.Get", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+
+	var result []*model.Group
+
+	err := model.NewAppError("GroupStore.GetAllBySource", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+
+	var result []*model.GroupSyncable
+
+	err := model.NewAppError("GroupStore.GetAllGroupSyncablesByGroupId", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+
+	var result []*model.Group
+
+	err := model.NewAppError("GroupStore.GetByIDs", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.GetByName", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.GetByRemoteID", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetByUser(userId string) ([]*model.Group, *model.AppError) {
+
+	var result []*model.Group
+
+	err := model.NewAppError("GroupStore.GetByUser", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+
+	var result *model.GroupSyncable
+
+	err := model.NewAppError("GroupStore.GetGroupSyncable", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+
+	var result []*model.Group
+
+	err := model.NewAppError("GroupStore.GetGroups", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	var result map[string][]*model.GroupWithSchemeAdmin
+
+	err := model.NewAppError("GroupStore.GetGroupsAssociatedToChannelsByTeam", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetGroupsByChannel(channelId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	var result []*model.GroupWithSchemeAdmin
+
+	err := model.NewAppError("GroupStore.GetGroupsByChannel", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, *model.AppError) {
+
+	var result []*model.GroupWithSchemeAdmin
+
+	err := model.NewAppError("GroupStore.GetGroupsByTeam", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetMemberCount(groupID string) (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GetMemberCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+
+	var result []*model.User
+
+	err := model.NewAppError("GroupStore.GetMemberUsers", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, *model.AppError) {
+
+	var result []*model.User
+
+	err := model.NewAppError("GroupStore.GetMemberUsersInTeam", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, *model.AppError) {
+
+	var result []*model.User
+
+	err := model.NewAppError("GroupStore.GetMemberUsersNotInChannel", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GetMemberUsersPage(groupID string, page int, perPage int) ([]*model.User, *model.AppError) {
+
+	var result []*model.User
+
+	err := model.NewAppError("GroupStore.GetMemberUsersPage", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GroupChannelCount() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GroupChannelCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GroupCount() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GroupCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GroupCountWithAllowReference() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GroupCountWithAllowReference", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GroupMemberCount() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GroupMemberCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) GroupTeamCount() (int64, *model.AppError) {
+
+	var result int64
+
+	err := model.NewAppError("GroupStore.GroupTeamCount", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) PermanentDeleteMembersByUser(userId string) *model.AppError {
+
+	err := model.NewAppError("GroupStore.PermanentDeleteMembersByUser", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return err
+
+}
+
+func (s notSupportedGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, *model.AppError) {
+
+	var result []string
+
+	err := model.NewAppError("GroupStore.PermittedSyncableAdmins", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, *model.AppError) {
+
+	var result []*model.UserWithGroups
+
+	err := model.NewAppError("GroupStore.TeamMembersMinusGroupMembers", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]*model.UserTeamIDPair, *model.AppError) {
+
+	var result []*model.UserTeamIDPair
+
+	err := model.NewAppError("GroupStore.TeamMembersToAdd", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, *model.AppError) {
+
+	var result []*model.TeamMember
+
+	err := model.NewAppError("GroupStore.TeamMembersToRemove", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) Update(group *model.Group) (*model.Group, *model.AppError) {
+
+	var result *model.Group
+
+	err := model.NewAppError("GroupStore.Update", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+
+	var result *model.GroupSyncable
+
+	err := model.NewAppError("GroupStore.UpdateGroupSyncable", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+
+	var result *model.GroupMember
+
+	err := model.NewAppError("GroupStore.UpsertMember", "store.not_supported.app_error", nil, "", http.StatusNotImplemented)
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.ClaimPending is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) Delete(id string) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("JobStore.Delete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) Get(id string) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetAllByStatus(status string) ([]*model.Job, error) {
+
+	var result []*model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetAllByStatus is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetAllByType(jobType string) ([]*model.Job, error) {
+
+	var result []*model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetAllByType is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetAllByTypePage(jobType string, offset int, limit int) ([]*model.Job, error) {
+
+	var result []*model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetAllByTypePage is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetAllPage(offset int, limit int) ([]*model.Job, error) {
+
+	var result []*model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetAllPage is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("JobStore.GetCountByStatusAndType is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetNewestJobByStatusAndType is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.GetNewestJobByStatusesAndType is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) Save(job *model.Job) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("JobStore.UpdateOptimistically is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+
+	var result *model.Job
+
+	err := store.NewErrNotImplemented("JobStore.UpdateStatus is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("JobStore.UpdateStatusOptimistically is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedLicenseStore) Get(id string) (*model.LicenseRecord, error) {
+
+	var result *model.LicenseRecord
+
+	err := store.NewErrNotImplemented("LicenseStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedLicenseStore) Save(license *model.LicenseRecord) (*model.LicenseRecord, error) {
+
+	var result *model.LicenseRecord
+
+	err := store.NewErrNotImplemented("LicenseStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+
+	var result *model.LinkMetadata
+
+	err := store.NewErrNotImplemented("LinkMetadataStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+
+	var result *model.LinkMetadata
+
+	err := store.NewErrNotImplemented("LinkMetadataStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) DeleteApp(id string) error {
+
+	err := store.NewErrNotImplemented("OAuthStore.DeleteApp is not supported")
+
+	return err
+
+}
+
+func (s notSupportedOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+
+	var result *model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAccessData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+
+	var result *model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAccessDataByRefreshToken is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetAccessDataByUserForApp(userId string, clientId string) ([]*model.AccessData, error) {
+
+	var result []*model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAccessDataByUserForApp is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+
+	var result *model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.GetApp is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetAppByUser(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	var result []*model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAppByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+
+	var result []*model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.GetApps is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+
+	var result *model.AuthData
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAuthData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetAuthorizedApps(userId string, offset int, limit int) ([]*model.OAuthApp, error) {
+
+	var result []*model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.GetAuthorizedApps is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) GetPreviousAccessData(userId string, clientId string) (*model.AccessData, error) {
+
+	var result *model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.GetPreviousAccessData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) PermanentDeleteAuthDataByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("OAuthStore.PermanentDeleteAuthDataByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedOAuthStore) RemoveAccessData(token string) error {
+
+	err := store.NewErrNotImplemented("OAuthStore.RemoveAccessData is not supported")
+
+	return err
+
+}
+
+func (s notSupportedOAuthStore) RemoveAllAccessData() error {
+
+	err := store.NewErrNotImplemented("OAuthStore.RemoveAllAccessData is not supported")
+
+	return err
+
+}
+
+func (s notSupportedOAuthStore) RemoveAuthData(code string) error {
+
+	err := store.NewErrNotImplemented("OAuthStore.RemoveAuthData is not supported")
+
+	return err
+
+}
+
+func (s notSupportedOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	var result *model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.SaveAccessData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	var result *model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.SaveApp is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+
+	var result *model.AuthData
+
+	err := store.NewErrNotImplemented("OAuthStore.SaveAuthData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+
+	var result *model.AccessData
+
+	err := store.NewErrNotImplemented("OAuthStore.UpdateAccessData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+
+	var result *model.OAuthApp
+
+	err := store.NewErrNotImplemented("OAuthStore.UpdateApp is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("PluginStore.CompareAndDelete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("PluginStore.CompareAndSet is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) Delete(pluginId string, key string) error {
+
+	err := store.NewErrNotImplemented("PluginStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPluginStore) DeleteAllExpired() error {
+
+	err := store.NewErrNotImplemented("PluginStore.DeleteAllExpired is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPluginStore) DeleteAllForPlugin(PluginId string) error {
+
+	err := store.NewErrNotImplemented("PluginStore.DeleteAllForPlugin is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPluginStore) Get(pluginId string, key string) (*model.PluginKeyValue, error) {
+
+	var result *model.PluginKeyValue
+
+	err := store.NewErrNotImplemented("PluginStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) List(pluginId string, page int, perPage int) ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("PluginStore.List is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+
+	var result *model.PluginKeyValue
+
+	err := store.NewErrNotImplemented("PluginStore.SaveOrUpdate is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPluginStore) SetWithOptions(pluginId string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("PluginStore.SetWithOptions is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+
+	var result []*model.AnalyticsRow
+
+	err := store.NewErrNotImplemented("PostStore.AnalyticsCountByDay is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) AnalyticsPostCount(teamId string, mustHaveFile bool, mustHaveHashtag bool) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("PostStore.AnalyticsPostCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+
+	var result model.AnalyticsRows
+
+	err := store.NewErrNotImplemented("PostStore.AnalyticsPostCountsByDay is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
+
+	var result model.AnalyticsRows
+
+	err := store.NewErrNotImplemented("PostStore.AnalyticsUserCountsWithPostsByDay is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) ClearCaches() {
+
+}
+
+func (s notSupportedPostStore) Delete(postId string, time int64, deleteByID string) error {
+
+	err := store.NewErrNotImplemented("PostStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPostStore) Get(id string, skipFetchThreads bool) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string) ([]*model.DirectPostForExport, error) {
+
+	var result []*model.DirectPostForExport
+
+	err := store.NewErrNotImplemented("PostStore.GetDirectPostParentsForExportAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetEtag(channelId string, allowFromCache bool) string {
+
+	var result string
+
+	return result
+
+}
+
+func (s notSupportedPostStore) GetFlaggedPosts(userId string, offset int, limit int) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetFlaggedPosts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetFlaggedPostsForChannel(userId string, channelId string, offset int, limit int) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetFlaggedPostsForChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetFlaggedPostsForTeam(userId string, teamId string, offset int, limit int) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetFlaggedPostsForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetMaxPostSize() int {
+
+	var result int
+
+	return result
+
+}
+
+func (s notSupportedPostStore) GetOldest() (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetOldest is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetOldestEntityCreationTime() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("PostStore.GetOldestEntityCreationTime is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetParentsForExportAfter(limit int, afterId string) ([]*model.PostForExport, error) {
+
+	var result []*model.PostForExport
+
+	err := store.NewErrNotImplemented("PostStore.GetParentsForExportAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPinnedPosts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetPostAfterTime is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("PostStore.GetPostIdAfterTime is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("PostStore.GetPostIdBeforeTime is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostWithContext is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPosts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+
+	var result *model.PostList
+	var resultVar1 string
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsAfterCursor is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+
+	var result []*model.PostForIndexing
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsBatchForIndexing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsBatched is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsBefore is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+
+	var result *model.PostList
+	var resultVar1 string
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsBeforeCursor is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+
+	var result []*model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+
+	var result []*model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsByProp is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsCreatedAt(channelId string, time int64) ([]*model.Post, error) {
+
+	var result []*model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsCreatedAt is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsCtx is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsSince(options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsSince is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.GetPostsSinceCtx is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+
+	var result *model.PostList
+	var resultVar1 string
+
+	err := store.NewErrNotImplemented("PostStore.GetRecentPostsForUser is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
+
+	var result []*model.ReplyForExport
+
+	err := store.NewErrNotImplemented("PostStore.GetRepliesForExport is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetSingle(id string) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetSingle is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.GetSingleCtx is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) InvalidateLastPostTimeCache(channelId string) {
+
+}
+
+func (s notSupportedPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+
+	var result []*model.Post
+
+	err := store.NewErrNotImplemented("PostStore.MoveThread is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) Overwrite(post *model.Post) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.Overwrite is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	var result []*model.Post
+	var resultVar1 int
+
+	err := store.NewErrNotImplemented("PostStore.OverwriteMultiple is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("PostStore.PermanentDeleteBatch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) PermanentDeleteByChannel(channelId string) error {
+
+	err := store.NewErrNotImplemented("PostStore.PermanentDeleteByChannel is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPostStore) PermanentDeleteByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("PostStore.PermanentDeleteByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPostStore) Save(post *model.Post) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+
+	var result []*model.Post
+	var resultVar1 int
+
+	err := store.NewErrNotImplemented("PostStore.SaveMultiple is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
+
+	var result *model.PostList
+
+	err := store.NewErrNotImplemented("PostStore.Search is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+
+	var result *model.PostSearchResults
+
+	err := store.NewErrNotImplemented("PostStore.SearchAllTeams is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) SearchPostsInTeamForUser(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.PostSearchResults, error) {
+
+	var result *model.PostSearchResults
+
+	err := store.NewErrNotImplemented("PostStore.SearchPostsInTeamForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+
+	var result *model.PostSearchResults
+
+	err := store.NewErrNotImplemented("PostStore.SearchPostsInTeamForUserAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+
+	var result []*model.SearchSuggestion
+
+	err := store.NewErrNotImplemented("PostStore.SuggestTerms is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPostStore) Update(newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+
+	var result *model.Post
+
+	err := store.NewErrNotImplemented("PostStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("PreferenceStore.CleanupFlagsBatch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPreferenceStore) Delete(userId string, category string, name string) error {
+
+	err := store.NewErrNotImplemented("PreferenceStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPreferenceStore) DeleteCategory(userId string, category string) error {
+
+	err := store.NewErrNotImplemented("PreferenceStore.DeleteCategory is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+
+	err := store.NewErrNotImplemented("PreferenceStore.DeleteCategoryAndName is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPreferenceStore) Get(userId string, category string, name string) (*model.Preference, error) {
+
+	var result *model.Preference
+
+	err := store.NewErrNotImplemented("PreferenceStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPreferenceStore) GetAll(userId string) (model.Preferences, error) {
+
+	var result model.Preferences
+
+	err := store.NewErrNotImplemented("PreferenceStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPreferenceStore) GetCategory(userId string, category string) (model.Preferences, error) {
+
+	var result model.Preferences
+
+	err := store.NewErrNotImplemented("PreferenceStore.GetCategory is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedPreferenceStore) PermanentDeleteByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("PreferenceStore.PermanentDeleteByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPreferenceStore) Save(preferences *model.Preferences) error {
+
+	err := store.NewErrNotImplemented("PreferenceStore.Save is not supported")
+
+	return err
+
+}
+
+func (s notSupportedPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+
+	var result model.Preferences
+
+	err := store.NewErrNotImplemented("PreferenceStore.SaveMultiple is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedProductNoticesStore) Clear(notices []string) error {
+
+	err := store.NewErrNotImplemented("ProductNoticesStore.Clear is not supported")
+
+	return err
+
+}
+
+func (s notSupportedProductNoticesStore) ClearOldNotices(currentNotices *model.ProductNotices) error {
+
+	err := store.NewErrNotImplemented("ProductNoticesStore.ClearOldNotices is not supported")
+
+	return err
+
+}
+
+func (s notSupportedProductNoticesStore) GetViews(userId string) ([]model.ProductNoticeViewState, error) {
+
+	var result []model.ProductNoticeViewState
+
+	err := store.NewErrNotImplemented("ProductNoticesStore.GetViews is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedProductNoticesStore) View(userId string, notices []string) error {
+
+	err := store.NewErrNotImplemented("ProductNoticesStore.View is not supported")
+
+	return err
+
+}
+
+func (s notSupportedReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+
+	var result []*model.Reaction
+
+	err := store.NewErrNotImplemented("ReactionStore.BulkGetForPosts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+
+	var result *model.Reaction
+
+	err := store.NewErrNotImplemented("ReactionStore.Delete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+
+	err := store.NewErrNotImplemented("ReactionStore.DeleteAllWithEmojiName is not supported")
+
+	return err
+
+}
+
+func (s notSupportedReactionStore) GetForPost(postId string, allowFromCache bool) ([]*model.Reaction, error) {
+
+	var result []*model.Reaction
+
+	err := store.NewErrNotImplemented("ReactionStore.GetForPost is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+
+	var result map[string][]*model.Reaction
+
+	err := store.NewErrNotImplemented("ReactionStore.GetForPosts is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("ReactionStore.PermanentDeleteBatch is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+
+	var result *model.Reaction
+
+	err := store.NewErrNotImplemented("ReactionStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+
+	var result []*model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.AllChannelSchemeRoles is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+
+	var result map[string]*model.RolePermissions
+
+	err := store.NewErrNotImplemented("RoleStore.ChannelHigherScopedPermissions is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+
+	var result []*model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.ChannelRolesUnderTeamRole is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) Delete(roleId string) (*model.Role, error) {
+
+	var result *model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.Delete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) Get(roleId string) (*model.Role, error) {
+
+	var result *model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) GetAll() ([]*model.Role, error) {
+
+	var result []*model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) GetByName(name string) (*model.Role, error) {
+
+	var result *model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+
+	var result []*model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.GetByNames is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedRoleStore) PermanentDeleteAll() error {
+
+	err := store.NewErrNotImplemented("RoleStore.PermanentDeleteAll is not supported")
+
+	return err
+
+}
+
+func (s notSupportedRoleStore) Save(role *model.Role) (*model.Role, error) {
+
+	var result *model.Role
+
+	err := store.NewErrNotImplemented("RoleStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) CountByScope(scope string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("SchemeStore.CountByScope is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("SchemeStore.CountWithoutPermission is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
+
+	var result *model.Scheme
+
+	err := store.NewErrNotImplemented("SchemeStore.Delete is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) Get(schemeId string) (*model.Scheme, error) {
+
+	var result *model.Scheme
+
+	err := store.NewErrNotImplemented("SchemeStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+
+	var result []*model.Scheme
+
+	err := store.NewErrNotImplemented("SchemeStore.GetAllPage is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+
+	var result *model.Scheme
+
+	err := store.NewErrNotImplemented("SchemeStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSchemeStore) PermanentDeleteAll() error {
+
+	err := store.NewErrNotImplemented("SchemeStore.PermanentDeleteAll is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+
+	var result *model.Scheme
+
+	err := store.NewErrNotImplemented("SchemeStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) AnalyticsSessionCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("SessionStore.AnalyticsSessionCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) Cleanup(expiryTime int64, batchSize int64) {
+
+}
+
+func (s notSupportedSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
+
+	var result *model.Session
+
+	err := store.NewErrNotImplemented("SessionStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) GetSessions(userId string) ([]*model.Session, error) {
+
+	var result []*model.Session
+
+	err := store.NewErrNotImplemented("SessionStore.GetSessions is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+
+	var result []*model.Session
+
+	err := store.NewErrNotImplemented("SessionStore.GetSessionsExpired is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, error) {
+
+	var result []*model.Session
+
+	err := store.NewErrNotImplemented("SessionStore.GetSessionsWithActiveDeviceIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) PermanentDeleteSessionsByUser(teamId string) error {
+
+	err := store.NewErrNotImplemented("SessionStore.PermanentDeleteSessionsByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) Remove(sessionIdOrToken string) error {
+
+	err := store.NewErrNotImplemented("SessionStore.Remove is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) RemoveAllSessions() error {
+
+	err := store.NewErrNotImplemented("SessionStore.RemoveAllSessions is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) Save(session *model.Session) (*model.Session, error) {
+
+	var result *model.Session
+
+	err := store.NewErrNotImplemented("SessionStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) UpdateDeviceId(id string, deviceId string, expiresAt int64) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateDeviceId is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateExpiredNotify is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) UpdateExpiresAt(sessionId string, time int64) error {
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateExpiresAt is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) UpdateLastActivityAt(sessionId string, time int64) error {
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateLastActivityAt is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) UpdateProps(session *model.Session) error {
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateProps is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSessionStore) UpdateRoles(userId string, roles string) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("SessionStore.UpdateRoles is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedStatusStore) Get(userId string) (*model.Status, error) {
+
+	var result *model.Status
+
+	err := store.NewErrNotImplemented("StatusStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+
+	var result []*model.Status
+
+	err := store.NewErrNotImplemented("StatusStore.GetByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedStatusStore) GetTotalActiveUsersCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("StatusStore.GetTotalActiveUsersCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedStatusStore) ResetAll() error {
+
+	err := store.NewErrNotImplemented("StatusStore.ResetAll is not supported")
+
+	return err
+
+}
+
+func (s notSupportedStatusStore) SaveOrUpdate(status *model.Status) error {
+
+	err := store.NewErrNotImplemented("StatusStore.SaveOrUpdate is not supported")
+
+	return err
+
+}
+
+func (s notSupportedStatusStore) UpdateLastActivityAt(userId string, lastActivityAt int64) error {
+
+	err := store.NewErrNotImplemented("StatusStore.UpdateLastActivityAt is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSystemStore) Get() (model.StringMap, error) {
+
+	var result model.StringMap
+
+	err := store.NewErrNotImplemented("SystemStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSystemStore) GetByName(name string) (*model.System, error) {
+
+	var result *model.System
+
+	err := store.NewErrNotImplemented("SystemStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+
+	var result *model.System
+
+	err := store.NewErrNotImplemented("SystemStore.InsertIfExists is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+
+	var result *model.System
+
+	err := store.NewErrNotImplemented("SystemStore.PermanentDeleteByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedSystemStore) Save(system *model.System) error {
+
+	err := store.NewErrNotImplemented("SystemStore.Save is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSystemStore) SaveOrUpdate(system *model.System) error {
+
+	err := store.NewErrNotImplemented("SystemStore.SaveOrUpdate is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model.System) error {
+
+	err := store.NewErrNotImplemented("SystemStore.SaveOrUpdateWithWarnMetricHandling is not supported")
+
+	return err
+
+}
+
+func (s notSupportedSystemStore) Update(system *model.System) error {
+
+	err := store.NewErrNotImplemented("SystemStore.Update is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.AnalyticsGetTeamCountForScheme is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.AnalyticsPrivateTeamCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) AnalyticsPublicTeamCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.AnalyticsPublicTeamCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) AnalyticsTeamCount(includeDeleted bool) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.AnalyticsTeamCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) ClearAllCustomRoleAssignments() error {
+
+	err := store.NewErrNotImplemented("TeamStore.ClearAllCustomRoleAssignments is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) ClearCaches() {
+
+}
+
+func (s notSupportedTeamStore) Get(id string) (*model.Team, error) {
+
+	var result *model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetActiveMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.GetActiveMemberCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAll() ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.TeamForExport, error) {
+
+	var result []*model.TeamForExport
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllForExportAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllPage(offset int, limit int) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllPage is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllPrivateTeamListing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllPrivateTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllPrivateTeamPageListing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllPublicTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllPublicTeamPageListing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllTeamListing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetAllTeamPageListing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetByInviteId(inviteId string) (*model.Team, error) {
+
+	var result *model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetByInviteId is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetByName(name string) (*model.Team, error) {
+
+	var result *model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetByName is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetByNames is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string, userId string) ([]*model.ChannelUnread, error) {
+
+	var result []*model.ChannelUnread
+
+	err := store.NewErrNotImplemented("TeamStore.GetChannelUnreadsForAllTeams is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetChannelUnreadsForTeam(teamId string, userId string) ([]*model.ChannelUnread, error) {
+
+	var result []*model.ChannelUnread
+
+	err := store.NewErrNotImplemented("TeamStore.GetChannelUnreadsForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetMember(teamId string, userId string) (*model.TeamMember, error) {
+
+	var result *model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.GetMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetMembers(teamId string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.GetMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetMembersByIds(teamId string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.GetMembersByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamMembersForExport(userId string) ([]*model.TeamMemberForExport, error) {
+
+	var result []*model.TeamMemberForExport
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamMembersForExport is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+
+	var result *model.TeamStats
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamStats is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamsByScheme(schemeId string, offset int, limit int) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamsByScheme is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamsByUserId is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamsForUser(ctx context.Context, userId string) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamsForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTeamsForUserWithPagination(userId string, page int, perPage int) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.GetTeamsForUserWithPagination is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetTotalMemberCount(teamId string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.GetTotalMemberCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GetUserTeamIds(userId string, allowFromCache bool) ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("TeamStore.GetUserTeamIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) GroupSyncedTeamCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("TeamStore.GroupSyncedTeamCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) InvalidateAllTeamIdsForUser(userId string) {
+
+}
+
+func (s notSupportedTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
+
+	var result map[string]string
+
+	err := store.NewErrNotImplemented("TeamStore.MigrateTeamMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) PermanentDelete(teamId string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.PermanentDelete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) RemoveAllMembersByTeam(teamId string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.RemoveAllMembersByTeam is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) RemoveAllMembersByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.RemoveAllMembersByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) RemoveMember(teamId string, userId string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.RemoveMember is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) RemoveMembers(teamId string, userIds []string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.RemoveMembers is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) ResetAllTeamSchemes() error {
+
+	err := store.NewErrNotImplemented("TeamStore.ResetAllTeamSchemes is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) Save(team *model.Team) (*model.Team, error) {
+
+	var result *model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+
+	var result *model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.SaveMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.SaveMultipleMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) SearchAll(term string, opts *model.TeamSearch) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.SearchAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) SearchAllPaged(term string, opts *model.TeamSearch) ([]*model.Team, int64, error) {
+
+	var result []*model.Team
+	var resultVar1 int64
+
+	err := store.NewErrNotImplemented("TeamStore.SearchAllPaged is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedTeamStore) SearchOpen(term string) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.SearchOpen is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) SearchPrivate(term string) ([]*model.Team, error) {
+
+	var result []*model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.SearchPrivate is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) Update(team *model.Team) (*model.Team, error) {
+
+	var result *model.Team
+
+	err := store.NewErrNotImplemented("TeamStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime int64) error {
+
+	err := store.NewErrNotImplemented("TeamStore.UpdateLastTeamIconUpdate is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) UpdateMember(member *model.TeamMember) (*model.TeamMember, error) {
+
+	var result *model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.UpdateMember is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) UpdateMembersRole(teamID string, userIDs []string) error {
+
+	err := store.NewErrNotImplemented("TeamStore.UpdateMembersRole is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+
+	var result []*model.TeamMember
+
+	err := store.NewErrNotImplemented("TeamStore.UpdateMultipleMembers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTeamStore) UserBelongsToTeams(userId string, teamIds []string) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("TeamStore.UserBelongsToTeams is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+
+	var result *model.TermsOfService
+
+	err := store.NewErrNotImplemented("TermsOfServiceStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+
+	var result *model.TermsOfService
+
+	err := store.NewErrNotImplemented("TermsOfServiceStore.GetLatest is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+
+	var result *model.TermsOfService
+
+	err := store.NewErrNotImplemented("TermsOfServiceStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) CollectThreadsWithNewerReplies(userId string, channelIds []string, timestamp int64) ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("ThreadStore.CollectThreadsWithNewerReplies is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) CreateMembershipIfNeeded(userId string, postId string, following bool) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.CreateMembershipIfNeeded is not supported")
+
+	return err
+
+}
+
+func (s notSupportedThreadStore) Delete(postId string) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedThreadStore) DeleteMembershipForUser(userId string, postId string) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.DeleteMembershipForUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedThreadStore) Get(id string) (*model.Thread, error) {
+
+	var result *model.Thread
+
+	err := store.NewErrNotImplemented("ThreadStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) GetMembershipForUser(userId string, postId string) (*model.ThreadMembership, error) {
+
+	var result *model.ThreadMembership
+
+	err := store.NewErrNotImplemented("ThreadStore.GetMembershipForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) GetMembershipsForUser(userId string) ([]*model.ThreadMembership, error) {
+
+	var result []*model.ThreadMembership
+
+	err := store.NewErrNotImplemented("ThreadStore.GetMembershipsForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+
+	var result *model.Threads
+
+	err := store.NewErrNotImplemented("ThreadStore.GetThreadsForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) MarkAllAsRead(userId string, timestamp int64) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.MarkAllAsRead is not supported")
+
+	return err
+
+}
+
+func (s notSupportedThreadStore) MarkAsRead(userId string, threadId string, timestamp int64) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.MarkAsRead is not supported")
+
+	return err
+
+}
+
+func (s notSupportedThreadStore) Save(thread *model.Thread) (*model.Thread, error) {
+
+	var result *model.Thread
+
+	err := store.NewErrNotImplemented("ThreadStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) SaveMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+
+	var result *model.ThreadMembership
+
+	err := store.NewErrNotImplemented("ThreadStore.SaveMembership is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.Thread, int, error) {
+
+	var result []*model.Thread
+	var resultVar1 int
+
+	err := store.NewErrNotImplemented("ThreadStore.SaveMultiple is not supported")
+
+	return result, resultVar1, err
+
+}
+
+func (s notSupportedThreadStore) Update(thread *model.Thread) (*model.Thread, error) {
+
+	var result *model.Thread
+
+	err := store.NewErrNotImplemented("ThreadStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+
+	var result *model.ThreadMembership
+
+	err := store.NewErrNotImplemented("ThreadStore.UpdateMembership is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedThreadStore) UpdateUnreadsByChannel(userId string, changedThreads []string, timestamp int64) error {
+
+	err := store.NewErrNotImplemented("ThreadStore.UpdateUnreadsByChannel is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTokenStore) Cleanup() {
+
+}
+
+func (s notSupportedTokenStore) Delete(token string) error {
+
+	err := store.NewErrNotImplemented("TokenStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTokenStore) GetByToken(token string) (*model.Token, error) {
+
+	var result *model.Token
+
+	err := store.NewErrNotImplemented("TokenStore.GetByToken is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedTokenStore) RemoveAllTokensByType(tokenType string) error {
+
+	err := store.NewErrNotImplemented("TokenStore.RemoveAllTokensByType is not supported")
+
+	return err
+
+}
+
+func (s notSupportedTokenStore) Save(recovery *model.Token) error {
+
+	err := store.NewErrNotImplemented("TokenStore.Save is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUploadSessionStore) Delete(id string) error {
+
+	err := store.NewErrNotImplemented("UploadSessionStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUploadSessionStore) Get(id string) (*model.UploadSession, error) {
+
+	var result *model.UploadSession
+
+	err := store.NewErrNotImplemented("UploadSessionStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUploadSessionStore) GetForUser(userId string) ([]*model.UploadSession, error) {
+
+	var result []*model.UploadSession
+
+	err := store.NewErrNotImplemented("UploadSessionStore.GetForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+
+	var result *model.UploadSession
+
+	err := store.NewErrNotImplemented("UploadSessionStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUploadSessionStore) Update(session *model.UploadSession) error {
+
+	err := store.NewErrNotImplemented("UploadSessionStore.Update is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) AnalyticsActiveCount(time int64, options model.UserCountOptions) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsActiveCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsActiveCountForPeriod is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+
+	var result bool
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsGetExternalUsers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AnalyticsGetGuestCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsGetGuestCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsGetInactiveUsersCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.AnalyticsGetSystemAdminCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) AutocompleteUsersInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+
+	var result *model.UserAutocompleteInChannel
+
+	err := store.NewErrNotImplemented("UserStore.AutocompleteUsersInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) ClearAllCustomRoleAssignments() error {
+
+	err := store.NewErrNotImplemented("UserStore.ClearAllCustomRoleAssignments is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) ClearCaches() {
+
+}
+
+func (s notSupportedUserStore) Count(options model.UserCountOptions) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.Count is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) DeactivateGuests() ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("UserStore.DeactivateGuests is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) DemoteUserToGuest(userID string) error {
+
+	err := store.NewErrNotImplemented("UserStore.DemoteUserToGuest is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) Get(id string) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAll() ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAllAfter(limit int, afterId string) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAllAfter is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAllNotInAuthService is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAllProfiles is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAllProfilesInChannel(channelId string, allowFromCache bool) (map[string]*model.User, error) {
+
+	var result map[string]*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAllProfilesInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetAllUsingAuthService is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetAnyUnreadPostCountForChannel(userId string, channelId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.GetAnyUnreadPostCountForChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetByAuth is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetByEmail(email string) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetByEmail is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetByUsername(username string) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetByUsername is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetChannelGroupUsers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetEtagForAllProfiles() string {
+
+	var result string
+
+	return result
+
+}
+
+func (s notSupportedUserStore) GetEtagForProfiles(teamId string) string {
+
+	var result string
+
+	return result
+
+}
+
+func (s notSupportedUserStore) GetEtagForProfilesNotInTeam(teamId string) string {
+
+	var result string
+
+	return result
+
+}
+
+func (s notSupportedUserStore) GetForLogin(loginId string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetForLogin is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetKnownUsers(userID string) ([]string, error) {
+
+	var result []string
+
+	err := store.NewErrNotImplemented("UserStore.GetKnownUsers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetNewUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetNewUsersForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfileByGroupChannelIdsForUser(userId string, channelIds []string) (map[string][]*model.User, error) {
+
+	var result map[string][]*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfileByGroupChannelIdsForUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfileByIds(userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfileByIds is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfiles is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesByUsernames is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesInChannelByStatus is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesNotInChannel(teamId string, channelId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesNotInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesNotInTeam(teamId string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesNotInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetProfilesWithoutTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetRecentlyActiveUsersForTeam(teamId string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetRecentlyActiveUsersForTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+
+	var result map[string]*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetSystemAdminProfiles is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetTeamGroupUsers is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetUnreadCount(userId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.GetUnreadCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetUnreadCountForChannel(userId string, channelId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.GetUnreadCountForChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.GetUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) GetUsersBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.UserForIndexing, error) {
+
+	var result []*model.UserForIndexing
+
+	err := store.NewErrNotImplemented("UserStore.GetUsersBatchForIndexing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) InferSystemInstallDate() (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.InferSystemInstallDate is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) InvalidateProfileCacheForUser(userId string) {
+
+}
+
+func (s notSupportedUserStore) InvalidateProfilesInChannelCache(channelId string) {
+
+}
+
+func (s notSupportedUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
+
+}
+
+func (s notSupportedUserStore) PermanentDelete(userId string) error {
+
+	err := store.NewErrNotImplemented("UserStore.PermanentDelete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) PromoteGuestToUser(userID string) error {
+
+	err := store.NewErrNotImplemented("UserStore.PromoteGuestToUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) ResetLastPictureUpdate(userId string) error {
+
+	err := store.NewErrNotImplemented("UserStore.ResetLastPictureUpdate is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) Save(user *model.User) (*model.User, error) {
+
+	var result *model.User
+
+	err := store.NewErrNotImplemented("UserStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) Search(teamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.Search is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) SearchInChannel(channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.SearchInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.SearchInGroup is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) SearchNotInChannel(teamId string, channelId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.SearchNotInChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) SearchNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.SearchNotInTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+
+	var result []*model.User
+
+	err := store.NewErrNotImplemented("UserStore.SearchWithoutTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) Update(user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+
+	var result *model.UserUpdate
+
+	err := store.NewErrNotImplemented("UserStore.Update is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) UpdateAuthData(userId string, service string, authData *string, email string, resetMfa bool) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("UserStore.UpdateAuthData is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) UpdateFailedPasswordAttempts(userId string, attempts int) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdateFailedPasswordAttempts is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdateLastPictureUpdate(userId string) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdateLastPictureUpdate is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdateMfaActive(userId string, active bool) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdateMfaActive is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdateMfaSecret(userId string, secret string) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdateMfaSecret is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdatePassword(userId string, newPassword string) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdatePassword is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+
+	err := store.NewErrNotImplemented("UserStore.UpdatePasswordHash is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserStore) UpdateUpdateAt(userId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("UserStore.UpdateUpdateAt is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserStore) VerifyEmail(userId string, email string) (string, error) {
+
+	var result string
+
+	err := store.NewErrNotImplemented("UserStore.VerifyEmail is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) Delete(tokenId string) error {
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserAccessTokenStore) DeleteAllForUser(userId string) error {
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.DeleteAllForUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserAccessTokenStore) Get(tokenId string) (*model.UserAccessToken, error) {
+
+	var result *model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.Get is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+
+	var result []*model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.GetAll is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+
+	var result *model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.GetByToken is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) GetByUser(userId string, page int, perPage int) ([]*model.UserAccessToken, error) {
+
+	var result []*model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.GetByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+
+	var result *model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+
+	var result []*model.UserAccessToken
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.Search is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserAccessTokenStore) UpdateTokenDisable(tokenId string) error {
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.UpdateTokenDisable is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserAccessTokenStore) UpdateTokenEnable(tokenId string) error {
+
+	err := store.NewErrNotImplemented("UserAccessTokenStore.UpdateTokenEnable is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserTermsOfServiceStore) Delete(userId string, termsOfServiceId string) error {
+
+	err := store.NewErrNotImplemented("UserTermsOfServiceStore.Delete is not supported")
+
+	return err
+
+}
+
+func (s notSupportedUserTermsOfServiceStore) GetByUser(userId string) (*model.UserTermsOfService, error) {
+
+	var result *model.UserTermsOfService
+
+	err := store.NewErrNotImplemented("UserTermsOfServiceStore.GetByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+
+	var result *model.UserTermsOfService
+
+	err := store.NewErrNotImplemented("UserTermsOfServiceStore.Save is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) AnalyticsIncomingCount(teamId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("WebhookStore.AnalyticsIncomingCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, error) {
+
+	var result int64
+
+	err := store.NewErrNotImplemented("WebhookStore.AnalyticsOutgoingCount is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) ClearCaches() {
+
+}
+
+func (s notSupportedWebhookStore) DeleteIncoming(webhookId string, time int64) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.DeleteIncoming is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) DeleteOutgoing(webhookId string, time int64) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.DeleteOutgoing is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+
+	var result *model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncoming is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetIncomingByChannel(channelId string) ([]*model.IncomingWebhook, error) {
+
+	var result []*model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncomingByChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetIncomingByTeam(teamId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	var result []*model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncomingByTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetIncomingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	var result []*model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncomingByTeamByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	var result []*model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncomingList is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetIncomingListByUser(userId string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+
+	var result []*model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetIncomingListByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+
+	var result *model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingByChannel(channelId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingByChannel is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingByChannelByUser(channelId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingByChannelByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingByTeam(teamId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingByTeam is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingByTeamByUser(teamId string, userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingByTeamByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingList is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) GetOutgoingListByUser(userId string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+
+	var result []*model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.GetOutgoingListByUser is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) InvalidateWebhookCache(webhook string) {
+
+}
+
+func (s notSupportedWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.PermanentDeleteIncomingByChannel is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) PermanentDeleteIncomingByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.PermanentDeleteIncomingByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) PermanentDeleteOutgoingByChannel(channelId string) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.PermanentDeleteOutgoingByChannel is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) PermanentDeleteOutgoingByUser(userId string) error {
+
+	err := store.NewErrNotImplemented("WebhookStore.PermanentDeleteOutgoingByUser is not supported")
+
+	return err
+
+}
+
+func (s notSupportedWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	var result *model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.SaveIncoming is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	var result *model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.SaveOutgoing is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	var result *model.IncomingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.UpdateIncoming is not supported")
+
+	return result, err
+
+}
+
+func (s notSupportedWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+
+	var result *model.OutgoingWebhook
+
+	err := store.NewErrNotImplemented("WebhookStore.UpdateOutgoing is not supported")
+
+	return result, err
+
+}
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -131,6 +132,10 @@ func testOAuthStoreSaveAccessData(t *testing.T, ss store.Store) {
 
 	_, err = ss.OAuth().SaveAccessData(&a1)
 	require.Nil(t, err)
+
+	// Saving the same token twice conflicts
+	_, err = ss.OAuth().SaveAccessData(&a1)
+	require.True(t, errors.Is(err, &store.ErrConflict{Resource: "AccessData"}), err)
 }
 
 func testOAuthUpdateAccessData(t *testing.T, ss store.Store) {
@@ -176,6 +181,7 @@ func testOAuthStoreGetAccessData(t *testing.T, ss store.Store) {
 
 	_, err = ss.OAuth().GetAccessData("invalidToken")
 	require.NotNil(t, err, "Should have failed. There is no data with an invalid token")
+	require.True(t, errors.Is(err, &store.ErrNotFound{}), err)
 
 	ra1, err := ss.OAuth().GetAccessData(a1.Token)
 	require.Nil(t, err)
@@ -190,6 +196,7 @@ func testOAuthStoreGetAccessData(t *testing.T, ss store.Store) {
 	// Try to get the Access data using an invalid refresh token
 	_, err = ss.OAuth().GetAccessDataByRefreshToken(a1.Token)
 	require.NotNil(t, err, "Should have failed. There is no data with an invalid token")
+	require.True(t, errors.Is(err, &store.ErrNotFound{}), err)
 
 	// Get the Access Data using the refresh token
 	ra1, err = ss.OAuth().GetAccessDataByRefreshToken(a1.RefreshToken)
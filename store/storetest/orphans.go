@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+)
+
+func TestOrphans(t *testing.T, ss store.Store) {
+	user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.NoError(t, err)
+	team, err := ss.Team().Save(&model.Team{DisplayName: "Team", Name: "zz" + model.NewId(), Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.NoError(t, err)
+
+	saveChannel := func() *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{TeamId: team.Id, DisplayName: "Channel", Name: "zz" + model.NewId(), Type: model.CHANNEL_OPEN}, -1)
+		require.NoError(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: user.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.NoError(t, err)
+		return channel
+	}
+	channel := saveChannel()
+	deletedChannel := saveChannel()
+	require.NoError(t, ss.Channel().PermanentDelete(deletedChannel.Id))
+
+	post, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: user.Id, Message: "message"})
+	require.NoError(t, err)
+	deletedPostId := model.NewId()
+
+	saveFileInfo := func(postId string) *model.FileInfo {
+		info, err := ss.FileInfo().Save(&model.FileInfo{CreatorId: user.Id, PostId: postId, Path: "file.txt"})
+		require.NoError(t, err)
+		return info
+	}
+	orphanedInfo := saveFileInfo(deletedPostId)
+	saveFileInfo(post.Id)
+	saveFileInfo("")
+
+	for _, postId := range []string{post.Id, deletedPostId} {
+		_, err = ss.Reaction().Save(&model.Reaction{UserId: user.Id, PostId: postId, EmojiName: "smile"})
+		require.NoError(t, err)
+	}
+
+	deletedUserId := model.NewId()
+	require.NoError(t, ss.Preference().Save(&model.Preferences{
+		{UserId: user.Id, Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, Name: "name", Value: "value"},
+		{UserId: deletedUserId, Category: model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, Name: "name", Value: "value"},
+	}))
+
+	orphans := map[string]string{
+		model.ORPHAN_TYPE_CHANNEL_MEMBER: deletedChannel.Id + ":" + user.Id,
+		model.ORPHAN_TYPE_FILE_INFO:      orphanedInfo.Id,
+		model.ORPHAN_TYPE_REACTION:       deletedPostId + ":" + user.Id + ":smile",
+		model.ORPHAN_TYPE_PREFERENCE:     deletedUserId + ":" + model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS + ":name",
+	}
+
+	t.Run("FindOrphans", func(t *testing.T) {
+		for entityType, id := range orphans {
+			ids, err := ss.FindOrphans(entityType, 10000)
+			require.NoError(t, err)
+			assert.Contains(t, ids, id, entityType)
+		}
+
+		ids, err := ss.FindOrphans(model.ORPHAN_TYPE_FILE_INFO, 1)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+
+		_, err = ss.FindOrphans("unknown", 10)
+		var invErr *store.ErrInvalidInput
+		assert.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("DeleteOrphans", func(t *testing.T) {
+		for entityType := range orphans {
+			deleted, err := ss.DeleteOrphans(entityType, 1)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, deleted, int64(1), entityType)
+
+			ids, err := ss.FindOrphans(entityType, 10000)
+			require.NoError(t, err)
+			assert.Empty(t, ids, entityType)
+		}
+
+		_, err := ss.Channel().GetMember(channel.Id, user.Id)
+		assert.NoError(t, err, "the members of the existing channels should be kept")
+		infos, err := ss.FileInfo().GetForPost(post.Id, true, false, false)
+		require.NoError(t, err)
+		assert.Len(t, infos, 1)
+		reactions, err := ss.Reaction().GetForPost(post.Id, false)
+		require.NoError(t, err)
+		assert.Len(t, reactions, 1)
+		_, err = ss.Preference().Get(user.Id, model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS, "name")
+		assert.NoError(t, err)
+
+		_, err = ss.DeleteOrphans(model.ORPHAN_TYPE_REACTION, 0)
+		var invErr *store.ErrInvalidInput
+		assert.True(t, errors.As(err, &invErr))
+	})
+}
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -23,20 +24,26 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("SaveAndUpdateChannelMsgCounts", func(t *testing.T) { testPostStoreSaveChannelMsgCounts(t, ss) })
 	t.Run("Get", func(t *testing.T) { testPostStoreGet(t, ss) })
 	t.Run("GetSingle", func(t *testing.T) { testPostStoreGetSingle(t, ss) })
+	t.Run("GetPost", func(t *testing.T) { testPostStoreGetPost(t, ss) })
 	t.Run("Update", func(t *testing.T) { testPostStoreUpdate(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testPostStoreDelete(t, ss) })
 	t.Run("Delete1Level", func(t *testing.T) { testPostStoreDelete1Level(t, ss) })
+	t.Run("MoveThread", func(t *testing.T) { testPostStoreMoveThread(t, ss) })
 	t.Run("Delete2Level", func(t *testing.T) { testPostStoreDelete2Level(t, ss) })
 	t.Run("PermDelete1Level", func(t *testing.T) { testPostStorePermDelete1Level(t, ss) })
 	t.Run("PermDelete1Level2", func(t *testing.T) { testPostStorePermDelete1Level2(t, ss) })
 	t.Run("GetWithChildren", func(t *testing.T) { testPostStoreGetWithChildren(t, ss) })
 	t.Run("GetPostsWithDetails", func(t *testing.T) { testPostStoreGetPostsWithDetails(t, ss) })
 	t.Run("GetPostsBeforeAfter", func(t *testing.T) { testPostStoreGetPostsBeforeAfter(t, ss) })
+	t.Run("GetPostsBeforeAfterCursor", func(t *testing.T) { testPostStoreGetPostsBeforeAfterCursor(t, ss) })
+	t.Run("GetPostWithContext", func(t *testing.T) { testPostStoreGetPostWithContext(t, ss) })
+	t.Run("GetRecentPostsForUser", func(t *testing.T) { testPostStoreGetRecentPostsForUser(t, ss) })
 	t.Run("GetPostsSince", func(t *testing.T) { testPostStoreGetPostsSince(t, ss) })
 	t.Run("GetPosts", func(t *testing.T) { testPostStoreGetPosts(t, ss) })
 	t.Run("GetPostBeforeAfter", func(t *testing.T) { testPostStoreGetPostBeforeAfter(t, ss) })
 	t.Run("UserCountsWithPostsByDay", func(t *testing.T) { testUserCountsWithPostsByDay(t, ss) })
 	t.Run("PostCountsByDay", func(t *testing.T) { testPostCountsByDay(t, ss) })
+	t.Run("AnalyticsCountByDay", func(t *testing.T) { testPostStoreAnalyticsCountByDay(t, ss) })
 	t.Run("GetFlaggedPostsForTeam", func(t *testing.T) { testPostStoreGetFlaggedPostsForTeam(t, ss, s) })
 	t.Run("GetFlaggedPosts", func(t *testing.T) { testPostStoreGetFlaggedPosts(t, ss) })
 	t.Run("GetFlaggedPostsForChannel", func(t *testing.T) { testPostStoreGetFlaggedPostsForChannel(t, ss) })
@@ -44,6 +51,8 @@ func TestPostStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("Overwrite", func(t *testing.T) { testPostStoreOverwrite(t, ss) })
 	t.Run("OverwriteMultiple", func(t *testing.T) { testPostStoreOverwriteMultiple(t, ss) })
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, ss) })
+	t.Run("GetPostsByProp", func(t *testing.T) { testPostStoreGetPostsByProp(t, ss) })
+	t.Run("GetPostsBatched", func(t *testing.T) { testPostStoreGetPostsBatched(t, ss) })
 	t.Run("GetPostsBatchForIndexing", func(t *testing.T) { testPostStoreGetPostsBatchForIndexing(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testPostStorePermanentDeleteBatch(t, ss) })
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, ss) })
@@ -443,6 +452,40 @@ func testPostStoreGetSingle(t *testing.T, ss store.Store) {
 	require.NotNil(t, err, "Missing id should have failed")
 }
 
+func testPostStoreGetPost(t *testing.T, ss store.Store) {
+	o1, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "zz" + model.NewId() + "b",
+	})
+	require.Nil(t, err)
+
+	post, err := ss.Post().GetPost(o1.Id, false)
+	require.Nil(t, err)
+	require.Equal(t, o1.Id, post.Id)
+
+	require.Nil(t, ss.Post().Delete(o1.Id, model.GetMillis(), ""))
+
+	t.Run("excludes the deleted post by default", func(t *testing.T) {
+		_, err := ss.Post().GetPost(o1.Id, false)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("includes the deleted post when requested", func(t *testing.T) {
+		post, err := ss.Post().GetPost(o1.Id, true)
+		require.Nil(t, err)
+		require.Equal(t, o1.Id, post.Id)
+		require.NotZero(t, post.DeleteAt)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := ss.Post().GetPost(model.NewId(), true)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
 func testPostStoreUpdate(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -575,6 +618,121 @@ func testPostStoreDelete(t *testing.T, ss store.Store) {
 	require.Equal(t, 0, strings.Index(etag2, model.CurrentVersion+"."), "Invalid Etag")
 }
 
+func testPostStoreMoveThread(t *testing.T, ss store.Store) {
+	teamId := model.NewId()
+	newChannel := func(teamId string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Channel",
+			Name:        "zz" + model.NewId() + "b",
+			Type:        model.CHANNEL_OPEN,
+		}, -1)
+		require.Nil(t, err)
+		return channel
+	}
+	newPost := func(channelId, rootId string) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    model.NewId(),
+			RootId:    rootId,
+			ParentId:  rootId,
+			Message:   "zz" + model.NewId() + "b",
+		})
+		require.Nil(t, err)
+		return post
+	}
+
+	source := newChannel(teamId)
+	target := newChannel(teamId)
+	other := newPost(source.Id, "")
+	newPost(target.Id, "")
+	root := newPost(source.Id, "")
+	reply1 := newPost(source.Id, root.Id)
+	reply2 := newPost(source.Id, root.Id)
+
+	file, err := ss.FileInfo().Save(&model.FileInfo{CreatorId: root.UserId, PostId: root.Id, Path: "file.txt"})
+	require.Nil(t, err)
+	_, err = ss.Reaction().Save(&model.Reaction{UserId: reply1.UserId, PostId: reply1.Id, EmojiName: "smile"})
+	require.Nil(t, err)
+
+	source, err = ss.Channel().Get(source.Id, false)
+	require.Nil(t, err)
+	target, err = ss.Channel().Get(target.Id, false)
+	require.Nil(t, err)
+
+	t.Run("should move the thread along with its files and reactions", func(t *testing.T) {
+		moved, err := ss.Post().MoveThread(root.Id, target.Id, false)
+		require.Nil(t, err)
+		require.Len(t, moved, 3)
+		for _, post := range moved {
+			assert.Equal(t, target.Id, post.ChannelId)
+		}
+
+		list, err := ss.Post().Get(root.Id, false)
+		require.Nil(t, err)
+		require.Len(t, list.Posts, 3)
+		for _, id := range []string{root.Id, reply1.Id, reply2.Id} {
+			assert.Equal(t, target.Id, list.Posts[id].ChannelId)
+		}
+
+		_, err = ss.Post().GetSingle(other.Id)
+		require.Nil(t, err)
+		list, err = ss.Post().GetPosts(model.GetPostsOptions{ChannelId: source.Id, PerPage: 10}, false)
+		require.Nil(t, err)
+		assert.Equal(t, []string{other.Id}, list.Order)
+
+		files, err := ss.FileInfo().GetForPost(root.Id, true, false, false)
+		require.Nil(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, file.Id, files[0].Id)
+		reactions, err := ss.Reaction().GetForPost(reply1.Id, false)
+		require.Nil(t, err)
+		assert.Len(t, reactions, 1)
+	})
+
+	t.Run("should update the channels", func(t *testing.T) {
+		movedSource, err := ss.Channel().Get(source.Id, false)
+		require.Nil(t, err)
+		assert.Equal(t, source.TotalMsgCount-3, movedSource.TotalMsgCount)
+		assert.Equal(t, other.CreateAt, movedSource.LastPostAt)
+
+		movedTarget, err := ss.Channel().Get(target.Id, false)
+		require.Nil(t, err)
+		assert.Equal(t, target.TotalMsgCount+3, movedTarget.TotalMsgCount)
+		assert.Equal(t, reply2.CreateAt, movedTarget.LastPostAt)
+	})
+
+	t.Run("should reject the invalid moves", func(t *testing.T) {
+		var invErr *store.ErrInvalidInput
+		var nfErr *store.ErrNotFound
+
+		_, err := ss.Post().MoveThread(reply1.Id, source.Id, false)
+		assert.True(t, errors.As(err, &invErr), "only the root of a thread can be moved")
+
+		_, err = ss.Post().MoveThread(root.Id, target.Id, false)
+		assert.True(t, errors.As(err, &invErr), "the thread is already in the channel")
+
+		_, err = ss.Post().MoveThread(model.NewId(), source.Id, false)
+		assert.True(t, errors.As(err, &nfErr))
+
+		_, err = ss.Post().MoveThread(root.Id, model.NewId(), false)
+		assert.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("should only move across teams when allowed", func(t *testing.T) {
+		otherTeamChannel := newChannel(model.NewId())
+
+		_, err := ss.Post().MoveThread(root.Id, otherTeamChannel.Id, false)
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+		assert.Equal(t, "TeamId", invErr.Field)
+
+		moved, err := ss.Post().MoveThread(root.Id, otherTeamChannel.Id, true)
+		require.Nil(t, err)
+		assert.Len(t, moved, 3)
+	})
+}
+
 func testPostStoreDelete1Level(t *testing.T, ss store.Store) {
 	o1 := &model.Post{}
 	o1.ChannelId = model.NewId()
@@ -1182,6 +1340,316 @@ func testPostStoreGetPostsBeforeAfter(t *testing.T, ss store.Store) {
 	})
 }
 
+func testPostStoreGetPostWithContext(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	otherChannelId := model.NewId()
+	userId := model.NewId()
+	createAt := model.GetMillis()
+
+	// The posts of the other channel are interleaved with those of the channel.
+	save := func(channelId, rootId string, offset int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			RootId:    rootId,
+			Message:   "message",
+			CreateAt:  createAt + offset,
+		})
+		require.Nil(t, err)
+		return post
+	}
+
+	var posts []*model.Post
+	for i := int64(0); i < 6; i++ {
+		posts = append(posts, save(channelId, "", 10*i))
+		save(otherChannelId, "", 10*i+5)
+	}
+	reply := save(channelId, posts[0].Id, 100)
+	sibling := save(channelId, posts[0].Id, 110)
+	deleted := save(channelId, "", 120)
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), userId))
+
+	t.Run("should return error if negative before or after are passed", func(t *testing.T) {
+		_, err := ss.Post().GetPostWithContext(posts[2].Id, -1, 2)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+
+		_, err = ss.Post().GetPostWithContext(posts[2].Id, 2, -1)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+	})
+
+	t.Run("should return error if the post doesn't exist", func(t *testing.T) {
+		_, err := ss.Post().GetPostWithContext(model.NewId(), 2, 2)
+		assert.IsType(t, &store.ErrNotFound{}, err)
+
+		_, err = ss.Post().GetPostWithContext(deleted.Id, 2, 2)
+		assert.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("should return the posts around a post of the channel", func(t *testing.T) {
+		postList, err := ss.Post().GetPostWithContext(posts[3].Id, 2, 1)
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[4].Id, posts[3].Id, posts[2].Id, posts[1].Id}, postList.Order)
+		assert.Len(t, postList.Posts, 4)
+	})
+
+	t.Run("should return a post at the start of the history of the channel", func(t *testing.T) {
+		postList, err := ss.Post().GetPostWithContext(posts[0].Id, 3, 2)
+		require.Nil(t, err)
+		assert.Equal(t, []string{posts[2].Id, posts[1].Id, posts[0].Id}, postList.Order)
+		assert.Len(t, postList.Posts, 3)
+		assert.Equal(t, int64(2), postList.Posts[posts[0].Id].ReplyCount)
+	})
+
+	t.Run("should return the thread of a reply", func(t *testing.T) {
+		postList, err := ss.Post().GetPostWithContext(reply.Id, 1, 1)
+		require.Nil(t, err)
+		assert.Equal(t, []string{sibling.Id, reply.Id, posts[5].Id}, postList.Order)
+		assert.Contains(t, postList.Posts, posts[0].Id, "the root should be returned")
+		assert.Len(t, postList.Posts, 4)
+	})
+}
+
+func testPostStoreGetPostsBeforeAfterCursor(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	userId := model.NewId()
+	createAt := model.GetMillis()
+
+	// Posts are saved in pairs sharing the same CreateAt, so pages have to break ties.
+	var posts []*model.Post
+	for i := 0; i < 10; i++ {
+		post, err := ss.Post().Save(&model.Post{
+			ChannelId: channelId,
+			UserId:    userId,
+			Message:   "message",
+			CreateAt:  createAt + int64(i/2),
+		})
+		require.Nil(t, err)
+
+		posts = append(posts, post)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt == posts[j].CreateAt {
+			return posts[i].Id < posts[j].Id
+		}
+		return posts[i].CreateAt < posts[j].CreateAt
+	})
+
+	t.Run("should return error if invalid PerPage or Cursor options are passed", func(t *testing.T) {
+		postList, cursor, err := ss.Post().GetPostsAfterCursor(model.GetPostsCursorOptions{ChannelId: channelId, PostId: posts[0].Id, PerPage: -1})
+		assert.Nil(t, postList)
+		assert.Empty(t, cursor)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+
+		postList, cursor, err = ss.Post().GetPostsAfterCursor(model.GetPostsCursorOptions{ChannelId: channelId, Cursor: "invalid", PerPage: 10})
+		assert.Nil(t, postList)
+		assert.Empty(t, cursor)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+	})
+
+	t.Run("should return error if the post doesn't exist", func(t *testing.T) {
+		postList, _, err := ss.Post().GetPostsBeforeCursor(model.GetPostsCursorOptions{ChannelId: channelId, PostId: model.NewId(), PerPage: 10})
+		assert.Nil(t, postList)
+		assert.IsType(t, &store.ErrNotFound{}, err)
+	})
+
+	t.Run("should page through posts before a post", func(t *testing.T) {
+		options := model.GetPostsCursorOptions{ChannelId: channelId, PostId: posts[9].Id, PerPage: 3}
+
+		var order []string
+		pages := 0
+		for {
+			postList, cursor, err := ss.Post().GetPostsBeforeCursor(options)
+			require.Nil(t, err)
+			require.Less(t, pages, 4, "pagination should have ended")
+			pages++
+
+			order = append(order, postList.Order...)
+			if cursor == "" {
+				break
+			}
+			options.Cursor = cursor
+		}
+
+		// The last full page is followed by an empty one.
+		assert.Equal(t, 4, pages)
+
+		assert.Equal(t, []string{
+			posts[8].Id, posts[7].Id, posts[6].Id,
+			posts[5].Id, posts[4].Id, posts[3].Id,
+			posts[2].Id, posts[1].Id, posts[0].Id,
+		}, order)
+	})
+
+	t.Run("should page through posts after a post", func(t *testing.T) {
+		options := model.GetPostsCursorOptions{ChannelId: channelId, PostId: posts[0].Id, PerPage: 3}
+
+		var order []string
+		pages := 0
+		for {
+			postList, cursor, err := ss.Post().GetPostsAfterCursor(options)
+			require.Nil(t, err)
+			require.Less(t, pages, 4, "pagination should have ended")
+			pages++
+
+			order = append(order, postList.Order...)
+			if cursor == "" {
+				break
+			}
+			options.Cursor = cursor
+		}
+
+		// The last full page is followed by an empty one.
+		assert.Equal(t, 4, pages)
+
+		assert.Equal(t, []string{
+			posts[3].Id, posts[2].Id, posts[1].Id,
+			posts[6].Id, posts[5].Id, posts[4].Id,
+			posts[9].Id, posts[8].Id, posts[7].Id,
+		}, order)
+	})
+}
+
+func testPostStoreGetRecentPostsForUser(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	teamId := model.NewId()
+	createAt := model.GetMillis()
+
+	// Two posts per channel across the channels of the user, interleaved in time.
+	var channels []*model.Channel
+	var posts []*model.Post
+	for i := 0; i < 8; i++ {
+		channel, err := ss.Channel().Save(&model.Channel{
+			TeamId:      teamId,
+			DisplayName: "Channel",
+			Name:        "zz" + model.NewId() + "b",
+			Type:        model.CHANNEL_OPEN,
+		}, -1)
+		require.Nil(t, err)
+		channels = append(channels, channel)
+
+		notifyProps := model.GetDefaultChannelNotifyProps()
+		if i == 7 {
+			notifyProps[model.MARK_UNREAD_NOTIFY_PROP] = model.CHANNEL_MARK_UNREAD_MENTION
+		}
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userId,
+			NotifyProps: notifyProps,
+		})
+		require.Nil(t, err)
+
+		for j := 0; j < 2; j++ {
+			post, err := ss.Post().Save(&model.Post{
+				ChannelId: channel.Id,
+				UserId:    model.NewId(),
+				Message:   "message",
+				CreateAt:  createAt + int64(j*8+i),
+			})
+			require.Nil(t, err)
+			posts = append(posts, post)
+		}
+	}
+
+	// The archived channel, the deleted post and the channel the user isn't a member of are left out.
+	archived := channels[6]
+	require.Nil(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+	deleted := posts[0]
+	require.Nil(t, ss.Post().Delete(deleted.Id, model.GetMillis(), ""))
+
+	other, err := ss.Channel().Save(&model.Channel{
+		TeamId:      teamId,
+		DisplayName: "Other",
+		Name:        "zz" + model.NewId() + "b",
+		Type:        model.CHANNEL_OPEN,
+	}, -1)
+	require.Nil(t, err)
+	_, err = ss.Post().Save(&model.Post{
+		ChannelId: other.Id,
+		UserId:    model.NewId(),
+		Message:   "message",
+		CreateAt:  createAt + 100,
+	})
+	require.Nil(t, err)
+
+	expected := func(excludeMuted bool) []string {
+		var visible []*model.Post
+		for _, post := range posts {
+			if post.Id == deleted.Id || post.ChannelId == archived.Id || (excludeMuted && post.ChannelId == channels[7].Id) {
+				continue
+			}
+			visible = append(visible, post)
+		}
+		sort.Slice(visible, func(i, j int) bool {
+			return visible[i].CreateAt > visible[j].CreateAt
+		})
+
+		var order []string
+		for _, post := range visible {
+			order = append(order, post.Id)
+		}
+		return order
+	}
+
+	getAll := func(options model.GetRecentPostsOptions) ([]string, int) {
+		var order []string
+		pages := 0
+		for {
+			postList, cursor, err := ss.Post().GetRecentPostsForUser(options)
+			require.Nil(t, err)
+			require.Less(t, pages, 10, "pagination should have ended")
+			pages++
+
+			order = append(order, postList.Order...)
+			if cursor == "" {
+				break
+			}
+			options.Cursor = cursor
+		}
+		return order, pages
+	}
+
+	t.Run("should return error if invalid PerPage or Cursor options are passed", func(t *testing.T) {
+		postList, cursor, err := ss.Post().GetRecentPostsForUser(model.GetRecentPostsOptions{UserId: userId, PerPage: 0})
+		assert.Nil(t, postList)
+		assert.Empty(t, cursor)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+
+		postList, cursor, err = ss.Post().GetRecentPostsForUser(model.GetRecentPostsOptions{UserId: userId, Cursor: "invalid", PerPage: 10})
+		assert.Nil(t, postList)
+		assert.Empty(t, cursor)
+		assert.IsType(t, &store.ErrInvalidInput{}, err)
+	})
+
+	t.Run("should return the most recent posts of the channels of the user", func(t *testing.T) {
+		postList, cursor, err := ss.Post().GetRecentPostsForUser(model.GetRecentPostsOptions{UserId: userId, PerPage: 3})
+		require.Nil(t, err)
+		assert.NotEmpty(t, cursor)
+		assert.Equal(t, expected(false)[:3], postList.Order)
+		assert.Len(t, postList.Posts, 3)
+	})
+
+	t.Run("should page through the posts of every channel", func(t *testing.T) {
+		order, pages := getAll(model.GetRecentPostsOptions{UserId: userId, PerPage: 4})
+
+		// The 13 posts fill 4 pages, the last one partly.
+		assert.Equal(t, 4, pages)
+		assert.Equal(t, expected(false), order)
+	})
+
+	t.Run("should exclude the muted channels", func(t *testing.T) {
+		order, _ := getAll(model.GetRecentPostsOptions{UserId: userId, PerPage: 4, ExcludeMuted: true})
+		assert.Equal(t, expected(true), order)
+	})
+
+	t.Run("should return nothing for a user without channels", func(t *testing.T) {
+		postList, cursor, err := ss.Post().GetRecentPostsForUser(model.GetRecentPostsOptions{UserId: model.NewId(), PerPage: 10})
+		require.Nil(t, err)
+		assert.Empty(t, cursor)
+		assert.Empty(t, postList.Order)
+	})
+}
+
 func testPostStoreGetPostsSince(t *testing.T, ss store.Store) {
 	t.Run("should return posts created after the given time", func(t *testing.T) {
 		channelId := model.NewId()
@@ -1691,6 +2159,75 @@ func testPostCountsByDay(t *testing.T, ss store.Store) {
 	assert.Equal(t, int64(6), r2)
 }
 
+func testPostStoreAnalyticsCountByDay(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{DisplayName: "DisplayName", Name: "zz" + model.NewId() + "b", Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.Nil(t, err)
+	channel, nErr := ss.Channel().Save(&model.Channel{TeamId: team.Id, DisplayName: "Channel", Name: "zz" + model.NewId() + "b", Type: model.CHANNEL_OPEN}, -1)
+	require.Nil(t, nErr)
+
+	day := int64(24 * 60 * 60 * 1000)
+	// 2020-03-10T00:00:00Z
+	start := int64(1583798400000)
+	end := start + 3*day - 1
+	for _, createAt := range []int64{
+		start - 1,
+		start,
+		start + day - 1,
+		start + day,
+		start + 2*day + day/2,
+		end,
+		end + 1,
+	} {
+		_, nErr = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: model.NewId(), Message: "zz" + model.NewId(), CreateAt: createAt})
+		require.Nil(t, nErr)
+	}
+
+	t.Run("counts the posts of the range by day", func(t *testing.T) {
+		rows, err := ss.Post().AnalyticsCountByDay(team.Id, start, end, 0)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.AnalyticsRow{
+			{Name: "2020-03-12", Value: 2},
+			{Name: "2020-03-11", Value: 1},
+			{Name: "2020-03-10", Value: 2},
+		}, rows)
+	})
+
+	t.Run("buckets the days in the time zone", func(t *testing.T) {
+		rows, err := ss.Post().AnalyticsCountByDay(team.Id, start, end, -60*60)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.AnalyticsRow{
+			{Name: "2020-03-12", Value: 2},
+			{Name: "2020-03-10", Value: 2},
+			{Name: "2020-03-09", Value: 1},
+		}, rows)
+
+		rows, err = ss.Post().AnalyticsCountByDay(team.Id, start, end, 60*60)
+		require.Nil(t, err)
+		assert.Equal(t, []*model.AnalyticsRow{
+			{Name: "2020-03-13", Value: 1},
+			{Name: "2020-03-12", Value: 1},
+			{Name: "2020-03-11", Value: 2},
+			{Name: "2020-03-10", Value: 1},
+		}, rows)
+	})
+
+	t.Run("returns nothing for empty ranges", func(t *testing.T) {
+		rows, err := ss.Post().AnalyticsCountByDay(team.Id, end+2, end+day, 0)
+		require.Nil(t, err)
+		assert.Empty(t, rows)
+
+		rows, err = ss.Post().AnalyticsCountByDay(team.Id, end, start, 0)
+		require.Nil(t, err)
+		assert.Empty(t, rows)
+	})
+
+	t.Run("counts the posts of another team separately", func(t *testing.T) {
+		rows, err := ss.Post().AnalyticsCountByDay(model.NewId(), start, end, 0)
+		require.Nil(t, err)
+		assert.Empty(t, rows)
+	})
+}
+
 func testPostStoreGetFlaggedPostsForTeam(t *testing.T, ss store.Store, s SqlSupplier) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -2361,6 +2898,134 @@ func testPostStoreGetPostsByIds(t *testing.T, ss store.Store) {
 	require.Len(t, posts, 3, "Expected 3 posts in results. Got %v", len(posts))
 }
 
+func testPostStoreGetPostsByProp(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	createAt := model.GetMillis()
+	savePost := func(props model.StringInterface, deleted bool) *model.Post {
+		createAt++
+		post := &model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "zz" + model.NewId(), CreateAt: createAt}
+		post.SetProps(props)
+		post, err := ss.Post().Save(post)
+		require.Nil(t, err)
+		if deleted {
+			require.Nil(t, ss.Post().Delete(post.Id, model.GetMillis(), ""))
+		}
+		return post
+	}
+
+	tagged1 := savePost(model.StringInterface{"tag": "release", "other": "x"}, false)
+	tagged2 := savePost(model.StringInterface{"tag": "release"}, false)
+	savePost(model.StringInterface{"tag": "draft"}, false)
+	savePost(model.StringInterface{"tag": []string{"release"}}, false)
+	savePost(model.StringInterface{}, false)
+	savePost(model.StringInterface{"tag": "release"}, true)
+
+	otherChannelPost := &model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId()}
+	otherChannelPost.AddProp("tag", "release")
+	_, err := ss.Post().Save(otherChannelPost)
+	require.Nil(t, err)
+
+	t.Run("returns the posts with the prop value, newest first", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsByProp(channelId, "tag", "release")
+		require.Nil(t, err)
+		require.Len(t, posts, 2)
+		assert.Equal(t, tagged2.Id, posts[0].Id)
+		assert.Equal(t, tagged1.Id, posts[1].Id)
+		assert.Equal(t, "x", posts[1].GetProp("other"))
+	})
+
+	t.Run("returns nothing for an unknown prop", func(t *testing.T) {
+		posts, err := ss.Post().GetPostsByProp(channelId, "unknown", "release")
+		require.Nil(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("handles quotes in the prop key", func(t *testing.T) {
+		post := savePost(model.StringInterface{`it's "quoted"`: "yes"}, false)
+
+		posts, err := ss.Post().GetPostsByProp(channelId, `it's "quoted"`, "yes")
+		require.Nil(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, post.Id, posts[0].Id)
+	})
+}
+
+func testPostStoreGetPostsBatched(t *testing.T, ss store.Store) {
+	channelId := model.NewId()
+	savePost := func(createAt int64) *model.Post {
+		post, err := ss.Post().Save(&model.Post{ChannelId: channelId, UserId: model.NewId(), Message: "zz" + model.NewId(), CreateAt: createAt})
+		require.Nil(t, err)
+		return post
+	}
+
+	var expected []string
+	for _, createAt := range []int64{1000, 2000, 2000, 2000, 3000} {
+		expected = append(expected, savePost(createAt).Id)
+	}
+	sort.Strings(expected[1:4])
+	require.Nil(t, ss.Post().Delete(expected[4], model.GetMillis(), ""))
+
+	_, err := ss.Post().Save(&model.Post{ChannelId: model.NewId(), UserId: model.NewId(), Message: "zz" + model.NewId(), CreateAt: 1500})
+	require.Nil(t, err)
+
+	t.Run("reads the posts in order, in batches", func(t *testing.T) {
+		var batches [][]string
+		err := ss.Post().GetPostsBatched(channelId, 2, func(posts []*model.Post) error {
+			var ids []string
+			for _, post := range posts {
+				ids = append(ids, post.Id)
+			}
+			batches = append(batches, ids)
+			return nil
+		})
+		require.Nil(t, err)
+		assert.Equal(t, [][]string{expected[0:2], expected[2:4], expected[4:5]}, batches)
+	})
+
+	t.Run("reads the posts in a single batch", func(t *testing.T) {
+		calls := 0
+		err := ss.Post().GetPostsBatched(channelId, 5, func(posts []*model.Post) error {
+			calls++
+			assert.Len(t, posts, 5)
+			return nil
+		})
+		require.Nil(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ignores the posts saved during the iteration", func(t *testing.T) {
+		var ids []string
+		err := ss.Post().GetPostsBatched(channelId, 2, func(posts []*model.Post) error {
+			for _, post := range posts {
+				ids = append(ids, post.Id)
+			}
+			savePost(model.GetMillis() + 1000)
+			savePost(1500)
+			return nil
+		})
+		require.Nil(t, err)
+		assert.Equal(t, expected, ids)
+	})
+
+	t.Run("stops at the first error of the callback", func(t *testing.T) {
+		calls := 0
+		err := ss.Post().GetPostsBatched(channelId, 1, func(posts []*model.Post) error {
+			calls++
+			return errors.New("stop")
+		})
+		require.EqualError(t, err, "stop")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("returns nothing for an empty channel", func(t *testing.T) {
+		err := ss.Post().GetPostsBatched(model.NewId(), 2, func(posts []*model.Post) error {
+			assert.Fail(t, "unexpected batch")
+			return nil
+		})
+		require.Nil(t, err)
+	})
+}
+
 func testPostStoreGetPostsBatchForIndexing(t *testing.T, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
@@ -4,6 +4,8 @@
 package storetest
 
 import (
+	"errors"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,6 +17,7 @@ import (
 
 func TestPreferenceStore(t *testing.T, ss store.Store) {
 	t.Run("PreferenceSave", func(t *testing.T) { testPreferenceSave(t, ss) })
+	t.Run("PreferenceSaveMultiple", func(t *testing.T) { testPreferenceSaveMultiple(t, ss) })
 	t.Run("PreferenceGet", func(t *testing.T) { testPreferenceGet(t, ss) })
 	t.Run("PreferenceGetCategory", func(t *testing.T) { testPreferenceGetCategory(t, ss) })
 	t.Run("PreferenceGetAll", func(t *testing.T) { testPreferenceGetAll(t, ss) })
@@ -61,6 +64,96 @@ func testPreferenceSave(t *testing.T, ss store.Store) {
 	}
 }
 
+func testPreferenceSaveMultiple(t *testing.T, ss store.Store) {
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	category := model.PREFERENCE_CATEGORY_DIRECT_CHANNEL_SHOW
+	name1, name2, name3 := model.NewId(), model.NewId(), model.NewId()
+
+	other := model.Preferences{{UserId: otherUserId, Category: category, Name: name1, Value: "other"}}
+	require.Nil(t, ss.Preference().Save(&other))
+
+	sortPreferences := func(preferences model.Preferences) model.Preferences {
+		sort.Slice(preferences, func(i, j int) bool {
+			return preferences[i].Category+preferences[i].Name < preferences[j].Category+preferences[j].Name
+		})
+		return preferences
+	}
+
+	t.Run("should return an empty set without preferences", func(t *testing.T) {
+		saved, err := ss.Preference().SaveMultiple(model.Preferences{}, true)
+		require.Nil(t, err)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("should insert the new preferences", func(t *testing.T) {
+		saved, err := ss.Preference().SaveMultiple(model.Preferences{
+			{UserId: userId, Category: category, Name: name1, Value: "value1"},
+			{UserId: userId, Category: category, Name: name2, Value: "value2"},
+		}, false)
+		require.Nil(t, err)
+		assert.Equal(t, sortPreferences(model.Preferences{
+			{UserId: userId, Category: category, Name: name1, Value: "value1"},
+			{UserId: userId, Category: category, Name: name2, Value: "value2"},
+		}), sortPreferences(saved))
+	})
+
+	t.Run("should update the colliding preferences and keep the last duplicate", func(t *testing.T) {
+		saved, err := ss.Preference().SaveMultiple(model.Preferences{
+			{UserId: userId, Category: category, Name: name1, Value: "value1a"},
+			{UserId: userId, Category: category, Name: name3, Value: "value3"},
+			{UserId: userId, Category: category, Name: name1, Value: "value1b"},
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: name1, Value: "true"},
+		}, false)
+		require.Nil(t, err)
+		assert.Equal(t, sortPreferences(model.Preferences{
+			{UserId: userId, Category: category, Name: name1, Value: "value1b"},
+			{UserId: userId, Category: category, Name: name2, Value: "value2"},
+			{UserId: userId, Category: category, Name: name3, Value: "value3"},
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: name1, Value: "true"},
+		}), sortPreferences(saved))
+	})
+
+	t.Run("should delete the omitted preferences", func(t *testing.T) {
+		saved, err := ss.Preference().SaveMultiple(model.Preferences{
+			{UserId: userId, Category: category, Name: name2, Value: "value2a"},
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: name1, Value: "true"},
+		}, true)
+		require.Nil(t, err)
+		assert.Equal(t, sortPreferences(model.Preferences{
+			{UserId: userId, Category: category, Name: name2, Value: "value2a"},
+			{UserId: userId, Category: model.PREFERENCE_CATEGORY_FLAGGED_POST, Name: name1, Value: "true"},
+		}), sortPreferences(saved))
+
+		all, err := ss.Preference().GetAll(userId)
+		require.Nil(t, err)
+		assert.Equal(t, sortPreferences(saved), sortPreferences(all))
+
+		all, err = ss.Preference().GetAll(otherUserId)
+		require.Nil(t, err)
+		assert.Equal(t, other, all, "the preferences of the other users should be kept")
+	})
+
+	t.Run("should fail without saving anything", func(t *testing.T) {
+		_, err := ss.Preference().SaveMultiple(model.Preferences{
+			{UserId: userId, Category: category, Name: name3, Value: "value3"},
+			{UserId: otherUserId, Category: category, Name: name3, Value: "value3"},
+		}, true)
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr), "the preferences should belong to the same user")
+
+		_, err = ss.Preference().SaveMultiple(model.Preferences{
+			{UserId: userId, Category: category, Name: name3, Value: "value3"},
+			{UserId: userId, Category: "", Name: name3, Value: "value"},
+		}, true)
+		require.NotNil(t, err)
+
+		all, err := ss.Preference().GetAll(userId)
+		require.Nil(t, err)
+		assert.Len(t, all, 2)
+	})
+}
+
 func testPreferenceGet(t *testing.T, ss store.Store) {
 	userId := model.NewId()
 	category := model.PREFERENCE_CATEGORY_DIRECT_CHANNEL_SHOW
@@ -99,6 +192,7 @@ func testPreferenceGet(t *testing.T, ss store.Store) {
 	// make sure getting a missing preference fails
 	_, err = ss.Preference().Get(model.NewId(), model.NewId(), model.NewId())
 	require.NotNil(t, err, "no error on getting a missing preference")
+	require.True(t, errors.Is(err, &store.ErrNotFound{}), err)
 }
 
 func testPreferenceGetCategory(t *testing.T, ss store.Store) {
@@ -23,7 +23,9 @@ func TestReactionStore(t *testing.T, ss store.Store) {
 	t.Run("ReactionDeleteAllWithEmojiName", func(t *testing.T) { testReactionDeleteAllWithEmojiName(t, ss) })
 	t.Run("PermanentDeleteBatch", func(t *testing.T) { testReactionStorePermanentDeleteBatch(t, ss) })
 	t.Run("ReactionBulkGetForPosts", func(t *testing.T) { testReactionBulkGetForPosts(t, ss) })
+	t.Run("ReactionGetForPosts", func(t *testing.T) { testReactionGetForPosts(t, ss) })
 	t.Run("ReactionDeadlock", func(t *testing.T) { testReactionDeadlock(t, ss) })
+	t.Run("ReactionSaveConcurrently", func(t *testing.T) { testReactionSaveConcurrently(t, ss) })
 }
 
 func testReactionSave(t *testing.T, ss store.Store) {
@@ -432,6 +434,68 @@ func testReactionBulkGetForPosts(t *testing.T, ss store.Store) {
 
 }
 
+func testReactionGetForPosts(t *testing.T, ss store.Store) {
+	postId := model.NewId()
+	post2Id := model.NewId()
+	post3Id := model.NewId()
+	otherPostId := model.NewId()
+	userId := model.NewId()
+	createAt := model.GetMillis()
+
+	reactions := []*model.Reaction{
+		{UserId: userId, PostId: postId, EmojiName: "smile", CreateAt: createAt},
+		{UserId: userId, PostId: postId, EmojiName: "angry", CreateAt: createAt},
+		{UserId: userId, PostId: postId, EmojiName: "sad", CreateAt: createAt - 1},
+		{UserId: userId, PostId: post2Id, EmojiName: "smile", CreateAt: createAt},
+		{UserId: userId, PostId: otherPostId, EmojiName: "smile", CreateAt: createAt},
+	}
+	for _, reaction := range reactions {
+		_, err := ss.Reaction().Save(reaction)
+		require.Nil(t, err)
+	}
+
+	t.Run("groups the reactions by post", func(t *testing.T) {
+		returned, err := ss.Reaction().GetForPosts([]string{postId, post2Id, post3Id})
+		require.Nil(t, err)
+		require.Len(t, returned, 3)
+
+		var emojiNames []string
+		for _, reaction := range returned[postId] {
+			assert.Equal(t, postId, reaction.PostId)
+			emojiNames = append(emojiNames, reaction.EmojiName)
+		}
+		assert.Equal(t, []string{"sad", "angry", "smile"}, emojiNames)
+
+		require.Len(t, returned[post2Id], 1)
+		assert.Equal(t, "smile", returned[post2Id][0].EmojiName)
+		assert.NotContains(t, returned, otherPostId)
+	})
+
+	t.Run("returns an empty list for the posts without reactions", func(t *testing.T) {
+		returned, err := ss.Reaction().GetForPosts([]string{post3Id})
+		require.Nil(t, err)
+		require.Contains(t, returned, post3Id)
+		assert.NotNil(t, returned[post3Id])
+		assert.Empty(t, returned[post3Id])
+	})
+
+	t.Run("returns the same order every time", func(t *testing.T) {
+		first, err := ss.Reaction().GetForPosts([]string{postId})
+		require.Nil(t, err)
+		for i := 0; i < 5; i++ {
+			returned, err := ss.Reaction().GetForPosts([]string{postId})
+			require.Nil(t, err)
+			assert.Equal(t, first, returned)
+		}
+	})
+
+	t.Run("no posts", func(t *testing.T) {
+		returned, err := ss.Reaction().GetForPosts([]string{})
+		require.Nil(t, err)
+		assert.Empty(t, returned)
+	})
+}
+
 // testReactionDeadlock is a best-case attempt to recreate the deadlock scenario.
 // It at least deadlocks 2 times out of 5.
 func testReactionDeadlock(t *testing.T, ss store.Store) {
@@ -495,3 +559,46 @@ func testReactionDeadlock(t *testing.T, ss store.Store) {
 	}()
 	wg.Wait()
 }
+
+func testReactionSaveConcurrently(t *testing.T, ss store.Store) {
+	post, err := ss.Post().Save(&model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+	})
+	require.Nil(t, err)
+
+	userId := model.NewId()
+	emojiName := model.NewId()
+
+	const saves = 20
+	errs := make(chan error, saves)
+	var wg sync.WaitGroup
+	wg.Add(saves)
+	for i := 0; i < saves; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := ss.Reaction().Save(&model.Reaction{
+				UserId:    userId,
+				PostId:    post.Id,
+				EmojiName: emojiName,
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.Nil(t, err, "should've allowed saving the same reaction concurrently")
+	}
+
+	reactions, err := ss.Reaction().GetForPost(post.Id, false)
+	require.Nil(t, err)
+	require.Len(t, reactions, 1, "should've saved the reaction once")
+	assert.Equal(t, userId, reactions[0].UserId)
+	assert.Equal(t, emojiName, reactions[0].EmojiName)
+
+	postList, err := ss.Post().Get(post.Id, false)
+	require.Nil(t, err)
+	assert.True(t, postList.Posts[post.Id].HasReactions, "should've set HasReactions = true on post")
+}
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -96,7 +97,7 @@ func testRoleStoreSave(t *testing.T, ss store.Store) {
 	}
 
 	_, err = ss.Role().Save(r4)
-	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, &store.ErrConflict{Resource: "Role"}), err)
 }
 
 func testRoleStoreGetAll(t *testing.T, ss store.Store) {
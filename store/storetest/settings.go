@@ -6,6 +6,7 @@ package storetest
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"path"
@@ -15,6 +16,7 @@ import (
 	"github.com/go-sql-driver/mysql"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -77,6 +79,24 @@ func PostgreSQLSettings() *model.SqlSettings {
 	return databaseSettings("postgres", dsnUrl.String())
 }
 
+// SQLiteSettings returns the database settings to connect to a SQLite unittesting database.
+// The database is backed by a randomly named temporary file which is removed by CleanupSqlSettings.
+func SQLiteSettings() *model.SqlSettings {
+	file, err := ioutil.TempFile("", "mattermost_test_*.db")
+	if err != nil {
+		panic("failed to create temporary sqlite database file: " + err.Error())
+	}
+	file.Close()
+
+	settings := databaseSettings(model.DATABASE_DRIVER_SQLITE, file.Name())
+
+	// SQLite only supports a single writer, so avoid lock contention between pooled connections.
+	*settings.MaxIdleConns = 1
+	*settings.MaxOpenConns = 1
+
+	return settings
+}
+
 func mySQLRootDSN(dsn string) string {
 	rootPwd := getEnv("TEST_DATABASE_MYSQL_ROOT_PASSWD", defaultMysqlRootPWD)
 	cfg, err := mysql.ParseDSN(dsn)
@@ -186,15 +206,95 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 	case model.DATABASE_DRIVER_POSTGRES:
 		settings = PostgreSQLSettings()
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DATABASE_DRIVER_SQLITE:
+		settings = SQLiteSettings()
+		log("Created temporary " + driver + " database " + *settings.DataSource)
+		return settings
 	default:
 		panic("unsupported driver " + driver)
 	}
 
-	if err := execAsRoot(settings, "CREATE DATABASE "+dbName); err != nil {
-		panic("failed to create temporary database " + dbName + ": " + err.Error())
+	createDatabase(settings, dbName)
+
+	log("Created temporary " + driver + " database " + dbName)
+
+	return settings
+}
+
+// ReuseSqlSettings returns the sql settings of the unittesting database configured through
+// the environment, creating the database only if it doesn't exist yet. Unlike MakeSqlSettings,
+// the database is meant to be kept across runs and must not be passed to CleanupSqlSettings.
+func ReuseSqlSettings(driver string) *model.SqlSettings {
+	var settings *model.SqlSettings
+	var dbName string
+	var existsQuery string
+
+	switch driver {
+	case model.DATABASE_DRIVER_MYSQL:
+		settings = databaseSettings(driver, getEnv("TEST_DATABASE_MYSQL_DSN", defaultMysqlDSN))
+		dbName = mySQLDSNDatabase(*settings.DataSource)
+		existsQuery = "SELECT COUNT(*) FROM INFORMATION_SCHEMA.SCHEMATA WHERE SCHEMA_NAME = '" + dbName + "'"
+	case model.DATABASE_DRIVER_POSTGRES:
+		settings = databaseSettings(driver, getEnv("TEST_DATABASE_POSTGRESQL_DSN", defaultPostgresqlDSN))
+		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+		existsQuery = "SELECT COUNT(*) FROM pg_database WHERE datname = '" + dbName + "'"
+	case model.DATABASE_DRIVER_SQLITE:
+		settings = databaseSettings(driver, path.Join(os.TempDir(), "mattermost_test.db"))
+		*settings.MaxIdleConns = 1
+		*settings.MaxOpenConns = 1
+		log("Reusing " + driver + " database " + *settings.DataSource)
+		return settings
+	default:
+		panic("unsupported driver " + driver)
+	}
+
+	var count int
+	if err := queryRowAsRoot(settings, existsQuery, &count); err != nil {
+		panic("failed to check for database " + dbName + ": " + err.Error())
+	}
+
+	if count == 0 {
+		createDatabase(settings, dbName)
+		log("Created " + driver + " database " + dbName)
+	} else {
+		log("Reusing " + driver + " database " + dbName)
 	}
 
+	return settings
+}
+
+// queryRowAsRoot executes the given query as root against the testing database, scanning the single resulting row into dest.
+func queryRowAsRoot(settings *model.SqlSettings, query string, dest ...interface{}) error {
+	var dsn string
+	var driver = *settings.DriverName
+
 	switch driver {
+	case model.DATABASE_DRIVER_MYSQL:
+		dsn = mySQLRootDSN(*settings.DataSource)
+	case model.DATABASE_DRIVER_POSTGRES:
+		dsn = postgreSQLRootDSN(*settings.DataSource)
+	default:
+		return fmt.Errorf("unsupported driver %s", driver)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %s database as root", driver)
+	}
+	defer db.Close()
+	if err = db.QueryRow(query).Scan(dest...); err != nil {
+		return errors.Wrapf(err, "failed to query `%s` against %s database as root", query, driver)
+	}
+
+	return nil
+}
+
+func createDatabase(settings *model.SqlSettings, dbName string) {
+	if err := execAsRoot(settings, "CREATE DATABASE "+dbName); err != nil {
+		panic("failed to create database " + dbName + ": " + err.Error())
+	}
+
+	switch *settings.DriverName {
 	case model.DATABASE_DRIVER_MYSQL:
 		if err := execAsRoot(settings, "GRANT ALL PRIVILEGES ON "+dbName+".* TO 'mmuser'"); err != nil {
 			panic("failed to grant mmuser permission to " + dbName + ":" + err.Error())
@@ -204,12 +304,8 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 			panic("failed to grant mmuser permission to " + dbName + ":" + err.Error())
 		}
 	default:
-		panic("unsupported driver " + driver)
+		panic("unsupported driver " + *settings.DriverName)
 	}
-
-	log("Created temporary " + driver + " database " + dbName)
-
-	return settings
 }
 
 func CleanupSqlSettings(settings *model.SqlSettings) {
@@ -221,6 +317,12 @@ func CleanupSqlSettings(settings *model.SqlSettings) {
 		dbName = mySQLDSNDatabase(*settings.DataSource)
 	case model.DATABASE_DRIVER_POSTGRES:
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DATABASE_DRIVER_SQLITE:
+		if err := os.Remove(*settings.DataSource); err != nil && !os.IsNotExist(err) {
+			panic("failed to remove temporary database " + *settings.DataSource + ": " + err.Error())
+		}
+		log("Dropped temporary database " + *settings.DataSource)
+		return
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -4,8 +4,10 @@
 package storetest
 
 import (
+	"sort"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v5/model"
@@ -15,6 +17,7 @@ import (
 func TestStatusStore(t *testing.T, ss store.Store) {
 	t.Run("", func(t *testing.T) { testStatusStore(t, ss) })
 	t.Run("ActiveUserCount", func(t *testing.T) { testActiveUserCount(t, ss) })
+	t.Run("GetByIds", func(t *testing.T) { testStatusStoreGetByIds(t, ss) })
 }
 
 func testStatusStore(t *testing.T, ss store.Store) {
@@ -56,6 +59,41 @@ func testActiveUserCount(t *testing.T, ss store.Store) {
 	require.True(t, count > 0, "expected count > 0, got %d", count)
 }
 
+func testStatusStoreGetByIds(t *testing.T, ss store.Store) {
+	online := &model.Status{UserId: model.NewId(), Status: model.STATUS_ONLINE, Manual: false, LastActivityAt: 10}
+	require.Nil(t, ss.Status().SaveOrUpdate(online))
+	dnd := &model.Status{UserId: model.NewId(), Status: model.STATUS_DND, Manual: true, LastActivityAt: 20}
+	require.Nil(t, ss.Status().SaveOrUpdate(dnd))
+
+	t.Run("leaves out the users without a status", func(t *testing.T) {
+		statuses, err := ss.Status().GetByIds([]string{online.UserId, model.NewId(), dnd.UserId, model.NewId()})
+		require.Nil(t, err)
+		require.Len(t, statuses, 2)
+
+		sort.Sort(ByUserId(statuses))
+		expected := []*model.Status{online, dnd}
+		sort.Sort(ByUserId(expected))
+		for i, status := range statuses {
+			assert.Equal(t, expected[i].UserId, status.UserId)
+			assert.Equal(t, expected[i].Status, status.Status)
+			assert.Equal(t, expected[i].Manual, status.Manual)
+			assert.Equal(t, expected[i].LastActivityAt, status.LastActivityAt)
+		}
+	})
+
+	t.Run("without any user with a status", func(t *testing.T) {
+		statuses, err := ss.Status().GetByIds([]string{model.NewId(), model.NewId()})
+		require.Nil(t, err)
+		assert.Empty(t, statuses)
+	})
+
+	t.Run("without any user", func(t *testing.T) {
+		statuses, err := ss.Status().GetByIds([]string{})
+		require.Nil(t, err)
+		assert.Empty(t, statuses)
+	})
+}
+
 type ByUserId []*model.Status
 
 func (s ByUserId) Len() int           { return len(s) }
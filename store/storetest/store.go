@@ -21,6 +21,7 @@ type Store struct {
 	UserStore                 mocks.UserStore
 	BotStore                  mocks.BotStore
 	AuditStore                mocks.AuditStore
+	AuditTrailStore           mocks.AuditTrailStore
 	ClusterDiscoveryStore     mocks.ClusterDiscoveryStore
 	ComplianceStore           mocks.ComplianceStore
 	SessionStore              mocks.SessionStore
@@ -61,6 +62,7 @@ func (s *Store) User() store.UserStore                             { return &s.U
 func (s *Store) Bot() store.BotStore                               { return &s.BotStore }
 func (s *Store) ProductNotices() store.ProductNoticesStore         { return &s.ProductNoticesStore }
 func (s *Store) Audit() store.AuditStore                           { return &s.AuditStore }
+func (s *Store) AuditTrail() store.AuditTrailStore                 { return &s.AuditTrailStore }
 func (s *Store) ClusterDiscovery() store.ClusterDiscoveryStore     { return &s.ClusterDiscoveryStore }
 func (s *Store) Compliance() store.ComplianceStore                 { return &s.ComplianceStore }
 func (s *Store) Session() store.SessionStore                       { return &s.SessionStore }
@@ -104,6 +106,16 @@ func (s *Store) GetCurrentSchemaVersion() string       { return "" }
 func (s *Store) CheckIntegrity() <-chan model.IntegrityCheckResult {
 	return make(chan model.IntegrityCheckResult)
 }
+func (s *Store) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return 0, nil
+}
+func (s *Store) FindOrphans(entityType string, limit int) ([]string, error) {
+	return nil, nil
+}
+func (s *Store) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return 0, nil
+}
+func (s *Store) HealthCheck(ctx context.Context) error { return nil }
 
 func (s *Store) AssertExpectations(t mock.TestingT) bool {
 	return mock.AssertExpectationsForObjects(t,
@@ -113,6 +125,7 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.UserStore,
 		&s.BotStore,
 		&s.AuditStore,
+		&s.AuditTrailStore,
 		&s.ClusterDiscoveryStore,
 		&s.ComplianceStore,
 		&s.SessionStore,
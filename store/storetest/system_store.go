@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -42,6 +43,12 @@ func testSystemStore(t *testing.T, ss store.Store) {
 
 	rsystem, _ := ss.System().GetByName(system.Name)
 	require.Equal(t, system.Value, rsystem.Value)
+
+	_, err = ss.System().GetByName(model.NewId())
+	require.True(t, errors.Is(err, &store.ErrNotFound{}), err)
+
+	err = ss.System().Save(system)
+	require.True(t, errors.Is(err, &store.ErrConflict{Resource: "System"}), err)
 }
 
 func testSystemStoreSaveOrUpdate(t *testing.T, ss store.Store) {
@@ -59,6 +59,7 @@ func TestTeamStore(t *testing.T, ss store.Store) {
 	t.Run("GetTeamMember", func(t *testing.T) { testGetTeamMember(t, ss) })
 	t.Run("GetTeamMembersByIds", func(t *testing.T) { testGetTeamMembersByIds(t, ss) })
 	t.Run("MemberCount", func(t *testing.T) { testTeamStoreMemberCount(t, ss) })
+	t.Run("GetTeamStats", func(t *testing.T) { testTeamStoreGetTeamStats(t, ss) })
 	t.Run("GetChannelUnreadsForAllTeams", func(t *testing.T) { testGetChannelUnreadsForAllTeams(t, ss) })
 	t.Run("GetChannelUnreadsForTeam", func(t *testing.T) { testGetChannelUnreadsForTeam(t, ss) })
 	t.Run("UpdateLastTeamIconUpdate", func(t *testing.T) { testUpdateLastTeamIconUpdate(t, ss) })
@@ -2983,6 +2984,62 @@ func testTeamStoreMemberCount(t *testing.T, ss store.Store) {
 	require.Equal(t, 1, int(result), "wrong count")
 }
 
+func testTeamStoreGetTeamStats(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{Email: MakeEmail()})
+	require.Nil(t, err)
+	u2, err := ss.User().Save(&model.User{Email: MakeEmail(), DeleteAt: 1})
+	require.Nil(t, err)
+	u3, err := ss.User().Save(&model.User{Email: MakeEmail()})
+	require.Nil(t, err)
+
+	teamId := model.NewId()
+	otherTeamId := model.NewId()
+	for _, member := range []*model.TeamMember{
+		{TeamId: teamId, UserId: u1.Id},
+		{TeamId: teamId, UserId: u2.Id},
+		{TeamId: teamId, UserId: u3.Id, DeleteAt: 1},
+		// The members without a user aren't counted.
+		{TeamId: teamId, UserId: model.NewId()},
+		{TeamId: otherTeamId, UserId: u1.Id},
+	} {
+		_, nErr := ss.Team().SaveMember(member, -1)
+		require.Nil(t, nErr)
+	}
+
+	// requireStats checks the counts against the ones of the queries counting them apart.
+	requireStats := func(t *testing.T, restrictions *model.ViewUsersRestrictions, total, active int64) {
+		stats, err := ss.Team().GetTeamStats(teamId, restrictions)
+		require.Nil(t, err)
+		assert.Equal(t, &model.TeamStats{TeamId: teamId, TotalMemberCount: total, ActiveMemberCount: active}, stats)
+
+		totalMemberCount, err := ss.Team().GetTotalMemberCount(teamId, restrictions)
+		require.Nil(t, err)
+		assert.Equal(t, totalMemberCount, stats.TotalMemberCount)
+		activeMemberCount, err := ss.Team().GetActiveMemberCount(teamId, restrictions)
+		require.Nil(t, err)
+		assert.Equal(t, activeMemberCount, stats.ActiveMemberCount)
+	}
+
+	t.Run("without view restrictions", func(t *testing.T) {
+		requireStats(t, nil, 2, 1)
+	})
+
+	t.Run("with view restrictions by another team", func(t *testing.T) {
+		requireStats(t, &model.ViewUsersRestrictions{Teams: []string{otherTeamId}}, 1, 1)
+	})
+
+	t.Run("with view restrictions to not see anything", func(t *testing.T) {
+		requireStats(t, &model.ViewUsersRestrictions{Teams: []string{}, Channels: []string{}}, 0, 0)
+	})
+
+	t.Run("team without members", func(t *testing.T) {
+		stats, err := ss.Team().GetTeamStats(model.NewId(), nil)
+		require.Nil(t, err)
+		assert.Zero(t, stats.TotalMemberCount)
+		assert.Zero(t, stats.ActiveMemberCount)
+	})
+}
+
 func testGetChannelUnreadsForAllTeams(t *testing.T, ss store.Store) {
 	teamId1 := model.NewId()
 	teamId2 := model.NewId()
@@ -14,6 +14,7 @@ import (
 
 func TestThreadStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("ThreadStorePopulation", func(t *testing.T) { testThreadStorePopulation(t, ss) })
+	t.Run("GetThreadsForUser", func(t *testing.T) { testThreadStoreGetThreadsForUser(t, ss) })
 }
 
 func testThreadStorePopulation(t *testing.T, ss store.Store) {
@@ -310,3 +311,106 @@ func testThreadStorePopulation(t *testing.T, ss store.Store) {
 		}, time.Second, 10*time.Millisecond)
 	})
 }
+
+func testThreadStoreGetThreadsForUser(t *testing.T, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{DisplayName: "DisplayName", Name: "zz" + model.NewId(), Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.Nil(t, err)
+	otherTeam, err := ss.Team().Save(&model.Team{DisplayName: "DisplayName", Name: "zz" + model.NewId(), Email: MakeEmail(), Type: model.TEAM_OPEN})
+	require.Nil(t, err)
+
+	user, err := ss.User().Save(&model.User{Email: MakeEmail(), Username: model.NewId()})
+	require.Nil(t, err)
+	author := model.NewId()
+
+	makeChannel := func(teamId, channelType string) *model.Channel {
+		channel, err := ss.Channel().Save(&model.Channel{TeamId: teamId, DisplayName: model.NewId(), Name: model.NewId(), Type: channelType}, -1)
+		require.Nil(t, err)
+		_, err = ss.Channel().SaveMember(&model.ChannelMember{ChannelId: channel.Id, UserId: user.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.Nil(t, err)
+		return channel
+	}
+	// makeThread creates a thread of two replies, one of them read by the user, who follows
+	// the thread unless following is false.
+	makeThread := func(channel *model.Channel, lastReplyAt int64, following bool) *model.Post {
+		root, err := ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: author, Message: "root", CreateAt: lastReplyAt - 20})
+		require.Nil(t, err)
+		for _, createAt := range []int64{lastReplyAt - 10, lastReplyAt} {
+			_, err = ss.Post().Save(&model.Post{ChannelId: channel.Id, UserId: author, RootId: root.Id, Message: "reply", CreateAt: createAt})
+			require.Nil(t, err)
+		}
+
+		thread, err := ss.Thread().Get(root.Id)
+		require.Nil(t, err)
+		thread.LastReplyAt = lastReplyAt
+		_, err = ss.Thread().Update(thread)
+		require.Nil(t, err)
+
+		require.Nil(t, ss.Thread().CreateMembershipIfNeeded(user.Id, root.Id, following))
+		require.Nil(t, ss.Thread().MarkAsRead(user.Id, root.Id, lastReplyAt-10))
+		return root
+	}
+	threadIds := func(threads *model.Threads) []string {
+		ids := []string{}
+		for _, thread := range threads.Threads {
+			ids = append(ids, thread.PostId)
+		}
+		return ids
+	}
+
+	channel := makeChannel(team.Id, model.CHANNEL_OPEN)
+	otherTeamChannel := makeChannel(otherTeam.Id, model.CHANNEL_OPEN)
+	groupChannel := makeChannel("", model.CHANNEL_GROUP)
+	leftChannel := makeChannel(team.Id, model.CHANNEL_OPEN)
+
+	newest := makeThread(channel, 5000, true)
+	group := makeThread(groupChannel, 4000, true)
+	otherTeamThread := makeThread(otherTeamChannel, 3000, true)
+	oldest := makeThread(channel, 2000, true)
+	makeThread(channel, 6000, false)
+	makeThread(leftChannel, 7000, true)
+	require.Nil(t, ss.Channel().RemoveMember(leftChannel.Id, user.Id))
+
+	t.Run("returns the followed threads of the team and the group messages", func(t *testing.T) {
+		threads, err := ss.Thread().GetThreadsForUser(user.Id, team.Id, model.GetUserThreadsOpts{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{newest.Id, group.Id, oldest.Id}, threadIds(threads))
+		assert.EqualValues(t, 3, threads.Total)
+
+		thread := threads.Threads[0]
+		assert.EqualValues(t, 2, thread.ReplyCount)
+		assert.EqualValues(t, 5000, thread.LastReplyAt)
+		assert.EqualValues(t, 4990, thread.LastViewedAt)
+		assert.EqualValues(t, 1, thread.UnreadReplies)
+		assert.Equal(t, newest.Id, thread.Post.Id)
+	})
+
+	t.Run("returns the followed threads of every team without a team", func(t *testing.T) {
+		threads, err := ss.Thread().GetThreadsForUser(user.Id, "", model.GetUserThreadsOpts{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{newest.Id, group.Id, otherTeamThread.Id, oldest.Id}, threadIds(threads))
+	})
+
+	t.Run("paginates after the last thread of the previous page", func(t *testing.T) {
+		threads, err := ss.Thread().GetThreadsForUser(user.Id, team.Id, model.GetUserThreadsOpts{PageSize: 2})
+		require.Nil(t, err)
+		require.Equal(t, []string{newest.Id, group.Id}, threadIds(threads))
+		assert.EqualValues(t, 3, threads.Total)
+
+		threads, err = ss.Thread().GetThreadsForUser(user.Id, team.Id, model.GetUserThreadsOpts{PageSize: 2, Before: group.Id})
+		require.Nil(t, err)
+		assert.Equal(t, []string{oldest.Id}, threadIds(threads))
+	})
+
+	t.Run("leaves out the threads of a channel left after following them", func(t *testing.T) {
+		_, err := ss.Channel().SaveMember(&model.ChannelMember{ChannelId: leftChannel.Id, UserId: user.Id, NotifyProps: model.GetDefaultChannelNotifyProps()})
+		require.Nil(t, err)
+		threads, err := ss.Thread().GetThreadsForUser(user.Id, team.Id, model.GetUserThreadsOpts{})
+		require.Nil(t, err)
+		require.Len(t, threads.Threads, 4, "the thread should be back once the user joins the channel again")
+
+		require.Nil(t, ss.Channel().RemoveMember(leftChannel.Id, user.Id))
+		threads, err = ss.Thread().GetThreadsForUser(user.Id, team.Id, model.GetUserThreadsOpts{})
+		require.Nil(t, err)
+		assert.Equal(t, []string{newest.Id, group.Id, oldest.Id}, threadIds(threads))
+	})
+}
@@ -47,6 +47,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("UpdateUpdateAt", func(t *testing.T) { testUserStoreUpdateUpdateAt(t, ss) })
 	t.Run("UpdateFailedPasswordAttempts", func(t *testing.T) { testUserStoreUpdateFailedPasswordAttempts(t, ss) })
 	t.Run("Get", func(t *testing.T) { testUserStoreGet(t, ss) })
+	t.Run("GetUser", func(t *testing.T) { testUserStoreGetUser(t, ss) })
 	t.Run("GetAllUsingAuthService", func(t *testing.T) { testGetAllUsingAuthService(t, ss) })
 	t.Run("GetAllProfiles", func(t *testing.T) { testUserStoreGetAllProfiles(t, ss) })
 	t.Run("GetProfiles", func(t *testing.T) { testUserStoreGetProfiles(t, ss) })
@@ -64,6 +65,7 @@ func TestUserStore(t *testing.T, ss store.Store, s SqlSupplier) {
 	t.Run("GetByUsername", func(t *testing.T) { testUserStoreGetByUsername(t, ss) })
 	t.Run("GetForLogin", func(t *testing.T) { testUserStoreGetForLogin(t, ss) })
 	t.Run("UpdatePassword", func(t *testing.T) { testUserStoreUpdatePassword(t, ss) })
+	t.Run("UpdatePasswordHash", func(t *testing.T) { testUserStoreUpdatePasswordHash(t, ss) })
 	t.Run("Delete", func(t *testing.T) { testUserStoreDelete(t, ss) })
 	t.Run("UpdateAuthData", func(t *testing.T) { testUserStoreUpdateAuthData(t, ss) })
 	t.Run("UserUnreadCount", func(t *testing.T) { testUserUnreadCount(t, ss) })
@@ -2073,6 +2075,70 @@ func testUserStoreUpdatePassword(t *testing.T, ss store.Store) {
 	require.Equal(t, user.Password, hashedPassword, "Password was not updated correctly")
 }
 
+func testUserStoreGetUser(t *testing.T, ss store.Store) {
+	u1, err := ss.User().Save(&model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	user, err := ss.User().GetUser(u1.Id, false)
+	require.Nil(t, err)
+	require.Equal(t, u1.Id, user.Id)
+
+	u1.DeleteAt = model.GetMillis()
+	_, err = ss.User().Update(u1, true)
+	require.Nil(t, err)
+
+	t.Run("excludes the deactivated user by default", func(t *testing.T) {
+		_, err := ss.User().GetUser(u1.Id, false)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("includes the deactivated user when requested", func(t *testing.T) {
+		user, err := ss.User().GetUser(u1.Id, true)
+		require.Nil(t, err)
+		require.Equal(t, u1.Id, user.Id)
+		require.Equal(t, u1.DeleteAt, user.DeleteAt)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := ss.User().GetUser(model.NewId(), true)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testUserStoreUpdatePasswordHash(t *testing.T, ss store.Store) {
+	u1 := &model.User{}
+	u1.Email = MakeEmail()
+	u1.Password = "password"
+	_, err := ss.User().Save(u1)
+	require.Nil(t, err)
+	defer func() { require.Nil(t, ss.User().PermanentDelete(u1.Id)) }()
+
+	saved, err := ss.User().Get(u1.Id)
+	require.Nil(t, err)
+
+	newHash := model.HashPassword("password")
+	require.Nil(t, ss.User().UpdatePasswordHash(u1.Id, saved.Password, newHash))
+
+	user, err := ss.User().Get(u1.Id)
+	require.Nil(t, err)
+	assert.Equal(t, newHash, user.Password)
+	assert.Equal(t, saved.LastPasswordUpdate, user.LastPasswordUpdate)
+
+	t.Run("leaves a hash changed in the meantime", func(t *testing.T) {
+		require.Nil(t, ss.User().UpdatePasswordHash(u1.Id, saved.Password, model.HashPassword("password")))
+
+		user, err := ss.User().Get(u1.Id)
+		require.Nil(t, err)
+		assert.Equal(t, newHash, user.Password)
+	})
+}
+
 func testUserStoreDelete(t *testing.T, ss store.Store) {
 	u1 := &model.User{}
 	u1.Email = MakeEmail()
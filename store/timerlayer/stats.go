@@ -0,0 +1,10 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package timerlayer
+
+// StatsRecorder records every call to a store method along with whether it succeeded and its
+// duration in seconds, such as sqlstore.SqlSupplier does to report TimerLayer.Stats.
+type StatsRecorder interface {
+	ObserveStoreMethod(method string, success bool, elapsed float64)
+}
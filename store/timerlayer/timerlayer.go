@@ -18,7 +18,9 @@ import (
 type TimerLayer struct {
 	store.Store
 	Metrics                   einterfaces.MetricsInterface
+	Stats                     StatsRecorder
 	AuditStore                store.AuditStore
+	AuditTrailStore           store.AuditTrailStore
 	BotStore                  store.BotStore
 	ChannelStore              store.ChannelStore
 	ChannelMemberHistoryStore store.ChannelMemberHistoryStore
@@ -58,6 +60,10 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditTrail() store.AuditTrailStore {
+	return s.AuditTrailStore
+}
+
 func (s *TimerLayer) Bot() store.BotStore {
 	return s.BotStore
 }
@@ -195,6 +201,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditTrailStore struct {
+	store.AuditTrailStore
+	Root *TimerLayer
+}
+
 type TimerLayerBotStore struct {
 	store.BotStore
 	Root *TimerLayer
@@ -373,6 +384,9 @@ func (s *TimerLayerAuditStore) Get(user_id string, offset int, limit int) (model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("AuditStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -389,6 +403,9 @@ func (s *TimerLayerAuditStore) PermanentDeleteByUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.PermanentDeleteByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("AuditStore.PermanentDeleteByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -405,6 +422,47 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("AuditStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("AuditStore.Save", err == nil, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerAuditTrailStore) GetByEntity(entityType string, entityId string, offset int, limit int) ([]*model.AuditTrailEntry, error) {
+	start := timemodule.Now()
+
+	result, err := s.AuditTrailStore.GetByEntity(entityType, entityId, offset, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditTrailStore.GetByEntity", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("AuditTrailStore.GetByEntity", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditTrailStore) Record(entry *model.AuditTrailEntry, change func() bool) error {
+	start := timemodule.Now()
+
+	err := s.AuditTrailStore.Record(entry, change)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditTrailStore.Record", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("AuditTrailStore.Record", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -421,6 +479,9 @@ func (s *TimerLayerBotStore) Get(userId string, includeDeleted bool) (*model.Bot
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("BotStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -437,6 +498,9 @@ func (s *TimerLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("BotStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -453,6 +517,9 @@ func (s *TimerLayerBotStore) PermanentDelete(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.PermanentDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("BotStore.PermanentDelete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -469,6 +536,9 @@ func (s *TimerLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("BotStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -485,6 +555,9 @@ func (s *TimerLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("BotStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("BotStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -501,6 +574,9 @@ func (s *TimerLayerChannelStore) AnalyticsDeletedTypeCount(teamId string, channe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsDeletedTypeCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.AnalyticsDeletedTypeCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -517,6 +593,9 @@ func (s *TimerLayerChannelStore) AnalyticsTypeCount(teamId string, channelType s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AnalyticsTypeCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.AnalyticsTypeCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -533,6 +612,9 @@ func (s *TimerLayerChannelStore) AutocompleteInTeam(teamId string, term string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.AutocompleteInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -549,6 +631,9 @@ func (s *TimerLayerChannelStore) AutocompleteInTeamForSearch(teamId string, user
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.AutocompleteInTeamForSearch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.AutocompleteInTeamForSearch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -565,6 +650,9 @@ func (s *TimerLayerChannelStore) ClearAllCustomRoleAssignments() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.ClearAllCustomRoleAssignments", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -581,6 +669,9 @@ func (s *TimerLayerChannelStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId string) error {
@@ -596,6 +687,9 @@ func (s *TimerLayerChannelStore) ClearSidebarOnTeamLeave(userId string, teamId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ClearSidebarOnTeamLeave", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.ClearSidebarOnTeamLeave", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -612,6 +706,9 @@ func (s *TimerLayerChannelStore) CountPostsAfter(channelId string, timestamp int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CountPostsAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.CountPostsAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -628,6 +725,9 @@ func (s *TimerLayerChannelStore) CreateDirectChannel(userId *model.User, otherUs
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateDirectChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.CreateDirectChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -644,6 +744,9 @@ func (s *TimerLayerChannelStore) CreateInitialSidebarCategories(userId string, t
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateInitialSidebarCategories", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.CreateInitialSidebarCategories", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -660,6 +763,9 @@ func (s *TimerLayerChannelStore) CreateSidebarCategory(userId string, teamId str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.CreateSidebarCategory", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.CreateSidebarCategory", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -676,6 +782,9 @@ func (s *TimerLayerChannelStore) Delete(channelId string, time int64) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -692,6 +801,9 @@ func (s *TimerLayerChannelStore) DeleteSidebarCategory(categoryId string) error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.DeleteSidebarCategory", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.DeleteSidebarCategory", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -708,6 +820,9 @@ func (s *TimerLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.DeleteSidebarChannelsByPreferences", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.DeleteSidebarChannelsByPreferences", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -724,6 +839,9 @@ func (s *TimerLayerChannelStore) Get(id string, allowFromCache bool) (*model.Cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -740,6 +858,9 @@ func (s *TimerLayerChannelStore) GetAll(teamId string) ([]*model.Channel, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -756,6 +877,9 @@ func (s *TimerLayerChannelStore) GetAllChannelMembersForUser(userId string, allo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllChannelMembersForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -772,6 +896,9 @@ func (s *TimerLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(chann
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllChannelMembersNotifyPropsForChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -788,6 +915,9 @@ func (s *TimerLayerChannelStore) GetAllChannels(page int, perPage int, opts stor
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllChannels", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -804,6 +934,9 @@ func (s *TimerLayerChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpt
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllChannelsCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -820,6 +953,9 @@ func (s *TimerLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllChannelsForExportAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllChannelsForExportAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -836,6 +972,9 @@ func (s *TimerLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, a
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetAllDirectChannelsForExportAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetAllDirectChannelsForExportAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -852,6 +991,9 @@ func (s *TimerLayerChannelStore) GetByName(team_id string, name string, allowFro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -868,6 +1010,9 @@ func (s *TimerLayerChannelStore) GetByNameIncludeDeleted(team_id string, name st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNameIncludeDeleted", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetByNameIncludeDeleted", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -884,6 +1029,28 @@ func (s *TimerLayerChannelStore) GetByNames(team_id string, names []string, allo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetByNames", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetByNames", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetChannel(id string, includeDeleted bool) (*model.Channel, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetChannel(id, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannel", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -900,6 +1067,9 @@ func (s *TimerLayerChannelStore) GetChannelCounts(teamId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelCounts", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelCounts", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -916,6 +1086,9 @@ func (s *TimerLayerChannelStore) GetChannelMembersForExport(userId string, teamI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersForExport", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelMembersForExport", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -932,6 +1105,9 @@ func (s *TimerLayerChannelStore) GetChannelMembersTimezones(channelId string) ([
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelMembersTimezones", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelMembersTimezones", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -948,6 +1124,9 @@ func (s *TimerLayerChannelStore) GetChannelUnread(channelId string, userId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelUnread", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelUnread", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -964,6 +1143,9 @@ func (s *TimerLayerChannelStore) GetChannels(teamId string, userId string, inclu
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannels", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -980,6 +1162,9 @@ func (s *TimerLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, en
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsBatchForIndexing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelsBatchForIndexing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -996,6 +1181,9 @@ func (s *TimerLayerChannelStore) GetChannelsByIds(channelIds []string, includeDe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelsByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1012,6 +1200,47 @@ func (s *TimerLayerChannelStore) GetChannelsByScheme(schemeId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsByScheme", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelsByScheme", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetChannelsCtx(ctx context.Context, teamId string, userId string, includeDeleted bool, lastDeleteAt int) (*model.ChannelList, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetChannelsCtx(ctx, teamId, userId, includeDeleted, lastDeleteAt)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetChannelsCtx", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetChannelsCtx", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetCtx(ctx context.Context, id string, allowFromCache bool) (*model.Channel, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetCtx(ctx, id, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetCtx", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetCtx", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1028,6 +1257,9 @@ func (s *TimerLayerChannelStore) GetDeleted(team_id string, offset int, limit in
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeleted", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetDeleted", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1044,6 +1276,9 @@ func (s *TimerLayerChannelStore) GetDeletedByName(team_id string, name string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetDeletedByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetDeletedByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1060,6 +1295,9 @@ func (s *TimerLayerChannelStore) GetForPost(postId string) (*model.Channel, erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetForPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetForPost", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1076,6 +1314,9 @@ func (s *TimerLayerChannelStore) GetFromMaster(id string) (*model.Channel, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetFromMaster", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetFromMaster", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1092,6 +1333,9 @@ func (s *TimerLayerChannelStore) GetGuestCount(channelId string, allowFromCache
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetGuestCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetGuestCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1108,6 +1352,9 @@ func (s *TimerLayerChannelStore) GetMember(channelId string, userId string) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1124,6 +1371,9 @@ func (s *TimerLayerChannelStore) GetMemberCount(channelId string, allowFromCache
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1140,6 +1390,9 @@ func (s *TimerLayerChannelStore) GetMemberCountFromCache(channelId string) int64
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountFromCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMemberCountFromCache", true, elapsed)
+	}
 	return result
 }
 
@@ -1156,6 +1409,9 @@ func (s *TimerLayerChannelStore) GetMemberCountsByGroup(channelID string, includ
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberCountsByGroup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMemberCountsByGroup", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1172,6 +1428,9 @@ func (s *TimerLayerChannelStore) GetMemberForPost(postId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMemberForPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMemberForPost", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1188,6 +1447,9 @@ func (s *TimerLayerChannelStore) GetMembers(channelId string, offset int, limit
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1204,6 +1466,9 @@ func (s *TimerLayerChannelStore) GetMembersByIds(channelId string, userIds []str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembersByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1220,6 +1485,9 @@ func (s *TimerLayerChannelStore) GetMembersForUser(teamId string, userId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembersForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1236,13 +1504,16 @@ func (s *TimerLayerChannelStore) GetMembersForUserWithPagination(teamId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersForUserWithPagination", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembersForUserWithPagination", err == nil, elapsed)
+	}
 	return result, err
 }
 
-func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
+func (s *TimerLayerChannelStore) GetMembersPaged(channelId string, page int, perPage int, sort string) (*model.ChannelMembers, int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
+	result, resultVar1, err := s.ChannelStore.GetMembersPaged(channelId, page, perPage, sort)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1250,15 +1521,37 @@ func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, o
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMoreChannels", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersPaged", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembersPaged", err == nil, elapsed)
+	}
+	return result, resultVar1, err
+}
+
+func (s *TimerLayerChannelStore) GetMembersSince(channelId string, since int64) (*model.ChannelMembersSince, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetMembersSince(channelId, since)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersSince", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMembersSince", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
+func (s *TimerLayerChannelStore) GetMoreChannels(teamId string, userId string, offset int, limit int) (*model.ChannelList, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
+	result, err := s.ChannelStore.GetMoreChannels(teamId, userId, offset, limit)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1266,15 +1559,18 @@ func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromC
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCount", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMoreChannels", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetMoreChannels", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
+func (s *TimerLayerChannelStore) GetPinnedPostCount(channelId string, allowFromCache bool) (int64, error) {
 	start := timemodule.Now()
 
-	result, err := s.ChannelStore.GetPinnedPosts(channelId)
+	result, err := s.ChannelStore.GetPinnedPostCount(channelId, allowFromCache)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -1282,7 +1578,10 @@ func (s *TimerLayerChannelStore) GetPinnedPosts(channelId string) (*model.PostLi
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPinnedPostCount", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetPinnedPostCount", err == nil, elapsed)
 	}
 	return result, err
 }
@@ -1300,6 +1599,9 @@ func (s *TimerLayerChannelStore) GetPrivateChannelsForTeam(teamId string, offset
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPrivateChannelsForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetPrivateChannelsForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1316,6 +1618,9 @@ func (s *TimerLayerChannelStore) GetPublicChannelsByIdsForTeam(teamId string, ch
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsByIdsForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetPublicChannelsByIdsForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1332,6 +1637,9 @@ func (s *TimerLayerChannelStore) GetPublicChannelsForTeam(teamId string, offset
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetPublicChannelsForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetPublicChannelsForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1348,6 +1656,9 @@ func (s *TimerLayerChannelStore) GetSidebarCategories(userId string, teamId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetSidebarCategories", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetSidebarCategories", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1364,6 +1675,9 @@ func (s *TimerLayerChannelStore) GetSidebarCategory(categoryId string) (*model.S
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetSidebarCategory", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetSidebarCategory", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1380,6 +1694,9 @@ func (s *TimerLayerChannelStore) GetSidebarCategoryOrder(userId string, teamId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetSidebarCategoryOrder", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetSidebarCategoryOrder", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1396,6 +1713,28 @@ func (s *TimerLayerChannelStore) GetTeamChannels(teamId string) (*model.ChannelL
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetTeamChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetTeamChannels", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) GetUnreadCountsForUser(userId string) (map[string]*model.ChannelUnreadCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.GetUnreadCountsForUser(userId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetUnreadCountsForUser", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GetUnreadCountsForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1412,6 +1751,9 @@ func (s *TimerLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GroupSyncedChannelCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.GroupSyncedChannelCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1428,6 +1770,9 @@ func (s *TimerLayerChannelStore) IncrementMentionCount(channelId string, userId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IncrementMentionCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.IncrementMentionCount", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1444,6 +1789,9 @@ func (s *TimerLayerChannelStore) InvalidateAllChannelMembersForUser(userId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateAllChannelMembersForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateAllChannelMembersForUser", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelId string) {
@@ -1459,6 +1807,9 @@ func (s *TimerLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateCacheForChannelMembersNotifyProps", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
@@ -1474,6 +1825,9 @@ func (s *TimerLayerChannelStore) InvalidateChannel(id string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateChannel", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name string) {
@@ -1489,6 +1843,9 @@ func (s *TimerLayerChannelStore) InvalidateChannelByName(teamId string, name str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateChannelByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateChannelByName", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
@@ -1504,6 +1861,9 @@ func (s *TimerLayerChannelStore) InvalidateGuestCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateGuestCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateGuestCount", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
@@ -1519,6 +1879,9 @@ func (s *TimerLayerChannelStore) InvalidateMemberCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidateMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidateMemberCount", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
@@ -1534,6 +1897,9 @@ func (s *TimerLayerChannelStore) InvalidatePinnedPostCount(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.InvalidatePinnedPostCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.InvalidatePinnedPostCount", true, elapsed)
+	}
 }
 
 func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelId string) bool {
@@ -1549,6 +1915,9 @@ func (s *TimerLayerChannelStore) IsUserInChannelUseCache(userId string, channelI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.IsUserInChannelUseCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.IsUserInChannelUseCache", true, elapsed)
+	}
 	return result
 }
 
@@ -1565,6 +1934,9 @@ func (s *TimerLayerChannelStore) MigrateChannelMembers(fromChannelId string, fro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigrateChannelMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.MigrateChannelMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1581,6 +1953,9 @@ func (s *TimerLayerChannelStore) MigratePublicChannels() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.MigratePublicChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.MigratePublicChannels", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1597,6 +1972,9 @@ func (s *TimerLayerChannelStore) PermanentDelete(channelId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.PermanentDelete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1613,6 +1991,9 @@ func (s *TimerLayerChannelStore) PermanentDeleteByTeam(teamId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.PermanentDeleteByTeam", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1629,6 +2010,9 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByChannel(channelId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.PermanentDeleteMembersByChannel", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1645,6 +2029,9 @@ func (s *TimerLayerChannelStore) PermanentDeleteMembersByUser(userId string) err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.PermanentDeleteMembersByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.PermanentDeleteMembersByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1661,6 +2048,9 @@ func (s *TimerLayerChannelStore) RemoveAllDeactivatedMembers(channelId string) e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveAllDeactivatedMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.RemoveAllDeactivatedMembers", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1677,6 +2067,9 @@ func (s *TimerLayerChannelStore) RemoveMember(channelId string, userId string) e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.RemoveMember", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1693,6 +2086,9 @@ func (s *TimerLayerChannelStore) RemoveMembers(channelId string, userIds []strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.RemoveMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.RemoveMembers", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1709,6 +2105,9 @@ func (s *TimerLayerChannelStore) ResetAllChannelSchemes() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.ResetAllChannelSchemes", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.ResetAllChannelSchemes", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1725,6 +2124,9 @@ func (s *TimerLayerChannelStore) Restore(channelId string, time int64) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Restore", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.Restore", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1741,6 +2143,9 @@ func (s *TimerLayerChannelStore) Save(channel *model.Channel, maxChannelsPerTeam
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1757,6 +2162,9 @@ func (s *TimerLayerChannelStore) SaveDirectChannel(channel *model.Channel, membe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveDirectChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SaveDirectChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1773,9 +2181,31 @@ func (s *TimerLayerChannelStore) SaveMember(member *model.ChannelMember) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SaveMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) SaveMemberMultiple(members []*model.ChannelMember) ([]*model.ChannelMember, []*model.ChannelMember, error) {
+	start := timemodule.Now()
+
+	result, resultVar1, err := s.ChannelStore.SaveMemberMultiple(members)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMemberMultiple", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SaveMemberMultiple", err == nil, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	start := timemodule.Now()
 
@@ -1789,6 +2219,9 @@ func (s *TimerLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMem
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SaveMultipleMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SaveMultipleMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1805,6 +2238,9 @@ func (s *TimerLayerChannelStore) SearchAllChannels(term string, opts store.Chann
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchAllChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchAllChannels", err == nil, elapsed)
+	}
 	return result, resultVar1, err
 }
 
@@ -1821,6 +2257,9 @@ func (s *TimerLayerChannelStore) SearchArchivedInTeam(teamId string, term string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchArchivedInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchArchivedInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1837,6 +2276,9 @@ func (s *TimerLayerChannelStore) SearchForUserInTeam(userId string, teamId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchForUserInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchForUserInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1853,6 +2295,9 @@ func (s *TimerLayerChannelStore) SearchGroupChannels(userId string, term string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchGroupChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchGroupChannels", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1869,6 +2314,9 @@ func (s *TimerLayerChannelStore) SearchInTeam(teamId string, term string, includ
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1885,6 +2333,9 @@ func (s *TimerLayerChannelStore) SearchMore(userId string, teamId string, term s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SearchMore", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SearchMore", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1901,6 +2352,9 @@ func (s *TimerLayerChannelStore) SetDeleteAt(channelId string, deleteAt int64, u
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.SetDeleteAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.SetDeleteAt", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1917,6 +2371,9 @@ func (s *TimerLayerChannelStore) Update(channel *model.Channel) (*model.Channel,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1933,6 +2390,28 @@ func (s *TimerLayerChannelStore) UpdateLastViewedAt(channelIds []string, userId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateLastViewedAt", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelStore) UpdateLastViewedAtMulti(channelIds []string, userId string, timestamp int64) (map[string]*model.ChannelUnreadCounts, error) {
+	start := timemodule.Now()
+
+	result, err := s.ChannelStore.UpdateLastViewedAtMulti(channelIds, userId, timestamp)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAtMulti", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateLastViewedAtMulti", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1949,6 +2428,9 @@ func (s *TimerLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateLastViewedAtPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateLastViewedAtPost", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1965,6 +2447,9 @@ func (s *TimerLayerChannelStore) UpdateMember(member *model.ChannelMember) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -1981,6 +2466,9 @@ func (s *TimerLayerChannelStore) UpdateMembersRole(channelID string, userIDs []s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMembersRole", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateMembersRole", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -1997,6 +2485,9 @@ func (s *TimerLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelM
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateMultipleMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateMultipleMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2013,6 +2504,9 @@ func (s *TimerLayerChannelStore) UpdateSidebarCategories(userId string, teamId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateSidebarCategories", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateSidebarCategories", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2029,6 +2523,9 @@ func (s *TimerLayerChannelStore) UpdateSidebarCategoryOrder(userId string, teamI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateSidebarCategoryOrder", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateSidebarCategoryOrder", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2045,6 +2542,9 @@ func (s *TimerLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateSidebarChannelCategoryOnMove", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateSidebarChannelCategoryOnMove", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2061,6 +2561,9 @@ func (s *TimerLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UpdateSidebarChannelsByPreferences", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UpdateSidebarChannelsByPreferences", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2077,6 +2580,9 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userId string, channelIds
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.UserBelongsToChannels", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelStore.UserBelongsToChannels", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2093,6 +2599,9 @@ func (s *TimerLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetUsersInChannelDuring", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelMemberHistoryStore.GetUsersInChannelDuring", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2109,6 +2618,9 @@ func (s *TimerLayerChannelMemberHistoryStore) LogJoinEvent(userId string, channe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogJoinEvent", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelMemberHistoryStore.LogJoinEvent", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2125,6 +2637,9 @@ func (s *TimerLayerChannelMemberHistoryStore) LogLeaveEvent(userId string, chann
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.LogLeaveEvent", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelMemberHistoryStore.LogLeaveEvent", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2141,6 +2656,9 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.PermanentDeleteBatch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ChannelMemberHistoryStore.PermanentDeleteBatch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2157,6 +2675,9 @@ func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Cleanup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.Cleanup", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2173,6 +2694,9 @@ func (s *TimerLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscove
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2189,6 +2713,9 @@ func (s *TimerLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscove
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Exists", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.Exists", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2205,6 +2732,9 @@ func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2221,6 +2751,9 @@ func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.Save", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2237,6 +2770,9 @@ func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.Cluster
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.SetLastPingAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ClusterDiscoveryStore.SetLastPingAt", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2253,6 +2789,9 @@ func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamId string) (int64, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.AnalyticsCommandCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.AnalyticsCommandCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2269,6 +2808,9 @@ func (s *TimerLayerCommandStore) Delete(commandId string, time int64) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2285,6 +2827,9 @@ func (s *TimerLayerCommandStore) Get(id string) (*model.Command, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2301,6 +2846,9 @@ func (s *TimerLayerCommandStore) GetByTeam(teamId string) ([]*model.Command, err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.GetByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2317,6 +2865,9 @@ func (s *TimerLayerCommandStore) GetByTrigger(teamId string, trigger string) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.GetByTrigger", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.GetByTrigger", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2333,6 +2884,9 @@ func (s *TimerLayerCommandStore) PermanentDeleteByTeam(teamId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.PermanentDeleteByTeam", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2349,6 +2903,9 @@ func (s *TimerLayerCommandStore) PermanentDeleteByUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.PermanentDeleteByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.PermanentDeleteByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2365,6 +2922,9 @@ func (s *TimerLayerCommandStore) Save(webhook *model.Command) (*model.Command, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2381,6 +2941,9 @@ func (s *TimerLayerCommandStore) Update(hook *model.Command) (*model.Command, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2397,6 +2960,9 @@ func (s *TimerLayerCommandWebhookStore) Cleanup() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Cleanup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandWebhookStore.Cleanup", true, elapsed)
+	}
 }
 
 func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
@@ -2412,6 +2978,9 @@ func (s *TimerLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandWebhookStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2428,6 +2997,9 @@ func (s *TimerLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandWebhookStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2444,6 +3016,9 @@ func (s *TimerLayerCommandWebhookStore) TryUse(id string, limit int) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("CommandWebhookStore.TryUse", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("CommandWebhookStore.TryUse", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2460,6 +3035,9 @@ func (s *TimerLayerComplianceStore) ComplianceExport(compliance *model.Complianc
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.ComplianceExport", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.ComplianceExport", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2476,6 +3054,9 @@ func (s *TimerLayerComplianceStore) Get(id string) (*model.Compliance, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2492,6 +3073,9 @@ func (s *TimerLayerComplianceStore) GetAll(offset int, limit int) (model.Complia
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2508,6 +3092,9 @@ func (s *TimerLayerComplianceStore) MessageExport(after int64, limit int) ([]*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.MessageExport", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.MessageExport", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2524,6 +3111,9 @@ func (s *TimerLayerComplianceStore) Save(compliance *model.Compliance) (*model.C
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2540,6 +3130,9 @@ func (s *TimerLayerComplianceStore) Update(compliance *model.Compliance) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ComplianceStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ComplianceStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2556,6 +3149,9 @@ func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2572,6 +3168,9 @@ func (s *TimerLayerEmojiStore) Get(id string, allowFromCache bool) (*model.Emoji
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2588,6 +3187,9 @@ func (s *TimerLayerEmojiStore) GetByName(name string, allowFromCache bool) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2604,6 +3206,9 @@ func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetList", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.GetList", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2620,6 +3225,28 @@ func (s *TimerLayerEmojiStore) GetMultipleByName(names []string) ([]*model.Emoji
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetMultipleByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.GetMultipleByName", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmojiStore) GetUsageCounts(emojiNames []string, includeMessages bool) (map[string]*model.EmojiUsage, error) {
+	start := timemodule.Now()
+
+	result, err := s.EmojiStore.GetUsageCounts(emojiNames, includeMessages)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetUsageCounts", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.GetUsageCounts", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2636,6 +3263,9 @@ func (s *TimerLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2652,6 +3282,9 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.Search", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("EmojiStore.Search", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2668,6 +3301,9 @@ func (s *TimerLayerFileInfoStore) AttachToPost(fileId string, postId string, cre
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AttachToPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.AttachToPost", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2684,6 +3320,9 @@ func (s *TimerLayerFileInfoStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
@@ -2699,6 +3338,9 @@ func (s *TimerLayerFileInfoStore) DeleteForPost(postId string) (string, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.DeleteForPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.DeleteForPost", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2715,6 +3357,28 @@ func (s *TimerLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.Get", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInfo, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.GetByIds(ids)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByIds", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.GetByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2731,6 +3395,9 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetByPath", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.GetByPath", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2747,6 +3414,9 @@ func (s *TimerLayerFileInfoStore) GetForPost(postId string, readFromMaster bool,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.GetForPost", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2763,6 +3433,9 @@ func (s *TimerLayerFileInfoStore) GetForUser(userId string) ([]*model.FileInfo,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.GetForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2779,6 +3452,9 @@ func (s *TimerLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetWithOptions", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.GetWithOptions", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2795,6 +3471,9 @@ func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.InvalidateFileInfosForPostCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.InvalidateFileInfosForPostCache", true, elapsed)
+	}
 }
 
 func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) error {
@@ -2810,6 +3489,9 @@ func (s *TimerLayerFileInfoStore) PermanentDelete(fileId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.PermanentDelete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2826,6 +3508,9 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteBatch(endTime int64, limit int6
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteBatch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.PermanentDeleteBatch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2842,6 +3527,9 @@ func (s *TimerLayerFileInfoStore) PermanentDeleteByUser(userId string) (int64, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.PermanentDeleteByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.PermanentDeleteByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2858,6 +3546,28 @@ func (s *TimerLayerFileInfoStore) Save(info *model.FileInfo) (*model.FileInfo, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.Save", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) Search(paramsList []*model.SearchParams, userId string, teamId string, page int, perPage int) (*model.FileInfoList, error) {
+	start := timemodule.Now()
+
+	result, err := s.FileInfoStore.Search(paramsList, userId, teamId, page, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Search", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.Search", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2874,6 +3584,9 @@ func (s *TimerLayerFileInfoStore) SetContent(fileId string, content string) erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContent", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.SetContent", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -2890,6 +3603,9 @@ func (s *TimerLayerFileInfoStore) Upsert(info *model.FileInfo) (*model.FileInfo,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.Upsert", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("FileInfoStore.Upsert", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2906,6 +3622,9 @@ func (s *TimerLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.AdminRoleGroupsForSyncableMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.AdminRoleGroupsForSyncableMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2922,6 +3641,9 @@ func (s *TimerLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersMinusGroupMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.ChannelMembersMinusGroupMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2938,6 +3660,9 @@ func (s *TimerLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToAdd", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.ChannelMembersToAdd", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2954,6 +3679,9 @@ func (s *TimerLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.ChannelMembersToRemove", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.ChannelMembersToRemove", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2970,6 +3698,9 @@ func (s *TimerLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountChannelMembersMinusGroupMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.CountChannelMembersMinusGroupMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -2986,6 +3717,9 @@ func (s *TimerLayerGroupStore) CountGroupsByChannel(channelId string, opts model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.CountGroupsByChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3002,6 +3736,9 @@ func (s *TimerLayerGroupStore) CountGroupsByTeam(teamId string, opts model.Group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountGroupsByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.CountGroupsByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3018,6 +3755,9 @@ func (s *TimerLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CountTeamMembersMinusGroupMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.CountTeamMembersMinusGroupMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3034,6 +3774,9 @@ func (s *TimerLayerGroupStore) Create(group *model.Group) (*model.Group, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Create", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.Create", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3050,6 +3793,9 @@ func (s *TimerLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.CreateGroupSyncable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.CreateGroupSyncable", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3066,6 +3812,9 @@ func (s *TimerLayerGroupStore) Delete(groupID string) (*model.Group, *model.AppE
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3082,6 +3831,9 @@ func (s *TimerLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteGroupSyncable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.DeleteGroupSyncable", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3098,6 +3850,9 @@ func (s *TimerLayerGroupStore) DeleteMember(groupID string, userID string) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DeleteMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.DeleteMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3114,6 +3869,9 @@ func (s *TimerLayerGroupStore) DistinctGroupMemberCount() (int64, *model.AppErro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.DistinctGroupMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.DistinctGroupMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3130,6 +3888,9 @@ func (s *TimerLayerGroupStore) Get(groupID string) (*model.Group, *model.AppErro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3146,6 +3907,9 @@ func (s *TimerLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllBySource", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetAllBySource", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3162,6 +3926,9 @@ func (s *TimerLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetAllGroupSyncablesByGroupId", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetAllGroupSyncablesByGroupId", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3178,6 +3945,9 @@ func (s *TimerLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, *mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByIDs", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetByIDs", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3194,6 +3964,9 @@ func (s *TimerLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3210,6 +3983,9 @@ func (s *TimerLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByRemoteID", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetByRemoteID", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3226,6 +4002,9 @@ func (s *TimerLayerGroupStore) GetByUser(userId string) ([]*model.Group, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3242,6 +4021,9 @@ func (s *TimerLayerGroupStore) GetGroupSyncable(groupID string, syncableID strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupSyncable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetGroupSyncable", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3258,6 +4040,9 @@ func (s *TimerLayerGroupStore) GetGroups(page int, perPage int, opts model.Group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroups", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetGroups", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3274,6 +4059,9 @@ func (s *TimerLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamId string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsAssociatedToChannelsByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetGroupsAssociatedToChannelsByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3290,6 +4078,9 @@ func (s *TimerLayerGroupStore) GetGroupsByChannel(channelId string, opts model.G
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetGroupsByChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3306,6 +4097,9 @@ func (s *TimerLayerGroupStore) GetGroupsByTeam(teamId string, opts model.GroupSe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetGroupsByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetGroupsByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3322,6 +4116,9 @@ func (s *TimerLayerGroupStore) GetMemberCount(groupID string) (int64, *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3338,6 +4135,9 @@ func (s *TimerLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetMemberUsers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3354,6 +4154,9 @@ func (s *TimerLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetMemberUsersInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3370,6 +4173,9 @@ func (s *TimerLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersNotInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetMemberUsersNotInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3386,6 +4192,9 @@ func (s *TimerLayerGroupStore) GetMemberUsersPage(groupID string, page int, perP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GetMemberUsersPage", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GetMemberUsersPage", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3402,6 +4211,9 @@ func (s *TimerLayerGroupStore) GroupChannelCount() (int64, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupChannelCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GroupChannelCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3418,6 +4230,9 @@ func (s *TimerLayerGroupStore) GroupCount() (int64, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GroupCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3434,6 +4249,9 @@ func (s *TimerLayerGroupStore) GroupCountWithAllowReference() (int64, *model.App
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupCountWithAllowReference", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GroupCountWithAllowReference", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3450,6 +4268,9 @@ func (s *TimerLayerGroupStore) GroupMemberCount() (int64, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GroupMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3466,6 +4287,9 @@ func (s *TimerLayerGroupStore) GroupTeamCount() (int64, *model.AppError) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.GroupTeamCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.GroupTeamCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3482,6 +4306,9 @@ func (s *TimerLayerGroupStore) PermanentDeleteMembersByUser(userId string) *mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermanentDeleteMembersByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.PermanentDeleteMembersByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -3498,6 +4325,9 @@ func (s *TimerLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncab
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.PermittedSyncableAdmins", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.PermittedSyncableAdmins", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3514,6 +4344,9 @@ func (s *TimerLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, group
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersMinusGroupMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.TeamMembersMinusGroupMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3530,6 +4363,9 @@ func (s *TimerLayerGroupStore) TeamMembersToAdd(since int64, teamID *string) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToAdd", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.TeamMembersToAdd", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3546,6 +4382,9 @@ func (s *TimerLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.Tea
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.TeamMembersToRemove", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.TeamMembersToRemove", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3562,6 +4401,9 @@ func (s *TimerLayerGroupStore) Update(group *model.Group) (*model.Group, *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3578,6 +4420,9 @@ func (s *TimerLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyn
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpdateGroupSyncable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.UpdateGroupSyncable", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3594,6 +4439,28 @@ func (s *TimerLayerGroupStore) UpsertMember(groupID string, userID string) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("GroupStore.UpsertMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("GroupStore.UpsertMember", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerJobStore) ClaimPending(jobType string, nodeId string) (*model.Job, error) {
+	start := timemodule.Now()
+
+	result, err := s.JobStore.ClaimPending(jobType, nodeId)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.ClaimPending", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.ClaimPending", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3610,6 +4477,9 @@ func (s *TimerLayerJobStore) Delete(id string) (string, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3626,6 +4496,9 @@ func (s *TimerLayerJobStore) Get(id string) (*model.Job, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3642,6 +4515,9 @@ func (s *TimerLayerJobStore) GetAllByStatus(status string) ([]*model.Job, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByStatus", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetAllByStatus", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3658,6 +4534,9 @@ func (s *TimerLayerJobStore) GetAllByType(jobType string) ([]*model.Job, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByType", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetAllByType", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3674,6 +4553,9 @@ func (s *TimerLayerJobStore) GetAllByTypePage(jobType string, offset int, limit
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllByTypePage", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetAllByTypePage", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3690,6 +4572,9 @@ func (s *TimerLayerJobStore) GetAllPage(offset int, limit int) ([]*model.Job, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetAllPage", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetAllPage", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3706,6 +4591,9 @@ func (s *TimerLayerJobStore) GetCountByStatusAndType(status string, jobType stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetCountByStatusAndType", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetCountByStatusAndType", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3722,6 +4610,9 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusAndType(status string, jobType
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusAndType", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetNewestJobByStatusAndType", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3738,6 +4629,9 @@ func (s *TimerLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.GetNewestJobByStatusesAndType", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.GetNewestJobByStatusesAndType", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3754,6 +4648,9 @@ func (s *TimerLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3770,6 +4667,9 @@ func (s *TimerLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateOptimistically", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.UpdateOptimistically", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3786,6 +4686,9 @@ func (s *TimerLayerJobStore) UpdateStatus(id string, status string) (*model.Job,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatus", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.UpdateStatus", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3802,6 +4705,9 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("JobStore.UpdateStatusOptimistically", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("JobStore.UpdateStatusOptimistically", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3818,6 +4724,9 @@ func (s *TimerLayerLicenseStore) Get(id string) (*model.LicenseRecord, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("LicenseStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3834,6 +4743,9 @@ func (s *TimerLayerLicenseStore) Save(license *model.LicenseRecord) (*model.Lice
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LicenseStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("LicenseStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3850,6 +4762,9 @@ func (s *TimerLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.L
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("LinkMetadataStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3866,6 +4781,9 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("LinkMetadataStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("LinkMetadataStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3882,6 +4800,9 @@ func (s *TimerLayerOAuthStore) DeleteApp(id string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.DeleteApp", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.DeleteApp", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -3898,6 +4819,9 @@ func (s *TimerLayerOAuthStore) GetAccessData(token string) (*model.AccessData, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAccessData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3914,6 +4838,9 @@ func (s *TimerLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByRefreshToken", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAccessDataByRefreshToken", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3930,6 +4857,9 @@ func (s *TimerLayerOAuthStore) GetAccessDataByUserForApp(userId string, clientId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAccessDataByUserForApp", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAccessDataByUserForApp", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3946,6 +4876,9 @@ func (s *TimerLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApp", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetApp", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3962,6 +4895,9 @@ func (s *TimerLayerOAuthStore) GetAppByUser(userId string, offset int, limit int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAppByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAppByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3978,6 +4914,9 @@ func (s *TimerLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetApps", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetApps", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -3994,6 +4933,9 @@ func (s *TimerLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAuthData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4010,6 +4952,9 @@ func (s *TimerLayerOAuthStore) GetAuthorizedApps(userId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetAuthorizedApps", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetAuthorizedApps", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4026,6 +4971,9 @@ func (s *TimerLayerOAuthStore) GetPreviousAccessData(userId string, clientId str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.GetPreviousAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.GetPreviousAccessData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4042,6 +4990,9 @@ func (s *TimerLayerOAuthStore) PermanentDeleteAuthDataByUser(userId string) erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.PermanentDeleteAuthDataByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.PermanentDeleteAuthDataByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4058,6 +5009,9 @@ func (s *TimerLayerOAuthStore) RemoveAccessData(token string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.RemoveAccessData", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4074,6 +5028,9 @@ func (s *TimerLayerOAuthStore) RemoveAllAccessData() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAllAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.RemoveAllAccessData", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4090,6 +5047,9 @@ func (s *TimerLayerOAuthStore) RemoveAuthData(code string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.RemoveAuthData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.RemoveAuthData", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4106,6 +5066,9 @@ func (s *TimerLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.SaveAccessData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4122,6 +5085,9 @@ func (s *TimerLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveApp", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.SaveApp", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4138,6 +5104,9 @@ func (s *TimerLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.Au
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.SaveAuthData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.SaveAuthData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4154,6 +5123,9 @@ func (s *TimerLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateAccessData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.UpdateAccessData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4170,6 +5142,9 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("OAuthStore.UpdateApp", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("OAuthStore.UpdateApp", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4186,6 +5161,9 @@ func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.CompareAndDelete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4202,6 +5180,9 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSet", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.CompareAndSet", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4218,6 +5199,9 @@ func (s *TimerLayerPluginStore) Delete(pluginId string, key string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4234,6 +5218,9 @@ func (s *TimerLayerPluginStore) DeleteAllExpired() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllExpired", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.DeleteAllExpired", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4250,6 +5237,9 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.DeleteAllForPlugin", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.DeleteAllForPlugin", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4266,6 +5256,9 @@ func (s *TimerLayerPluginStore) Get(pluginId string, key string) (*model.PluginK
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4282,6 +5275,9 @@ func (s *TimerLayerPluginStore) List(pluginId string, page int, perPage int) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.List", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4298,6 +5294,9 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SaveOrUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.SaveOrUpdate", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4314,6 +5313,28 @@ func (s *TimerLayerPluginStore) SetWithOptions(pluginId string, key string, valu
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetWithOptions", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PluginStore.SetWithOptions", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) AnalyticsCountByDay(teamId string, startTime int64, endTime int64, timeZoneOffset int) ([]*model.AnalyticsRow, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.AnalyticsCountByDay(teamId, startTime, endTime, timeZoneOffset)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsCountByDay", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.AnalyticsCountByDay", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4330,6 +5351,9 @@ func (s *TimerLayerPostStore) AnalyticsPostCount(teamId string, mustHaveFile boo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.AnalyticsPostCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4346,6 +5370,9 @@ func (s *TimerLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsPostCountsByDay", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.AnalyticsPostCountsByDay", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4362,6 +5389,9 @@ func (s *TimerLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.AnalyticsUserCountsWithPostsByDay", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.AnalyticsUserCountsWithPostsByDay", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4378,6 +5408,9 @@ func (s *TimerLayerPostStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID string) error {
@@ -4393,6 +5426,9 @@ func (s *TimerLayerPostStore) Delete(postId string, time int64, deleteByID strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4409,6 +5445,9 @@ func (s *TimerLayerPostStore) Get(id string, skipFetchThreads bool) (*model.Post
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4425,6 +5464,9 @@ func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afte
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDirectPostParentsForExportAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetDirectPostParentsForExportAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4441,6 +5483,9 @@ func (s *TimerLayerPostStore) GetEtag(channelId string, allowFromCache bool) str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetEtag", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetEtag", true, elapsed)
+	}
 	return result
 }
 
@@ -4457,6 +5502,9 @@ func (s *TimerLayerPostStore) GetFlaggedPosts(userId string, offset int, limit i
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPosts", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetFlaggedPosts", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4473,6 +5521,9 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForChannel(userId string, channelId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetFlaggedPostsForChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4489,6 +5540,9 @@ func (s *TimerLayerPostStore) GetFlaggedPostsForTeam(userId string, teamId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetFlaggedPostsForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetFlaggedPostsForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4505,6 +5559,9 @@ func (s *TimerLayerPostStore) GetMaxPostSize() int {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetMaxPostSize", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetMaxPostSize", true, elapsed)
+	}
 	return result
 }
 
@@ -4521,6 +5578,9 @@ func (s *TimerLayerPostStore) GetOldest() (*model.Post, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldest", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetOldest", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4537,6 +5597,9 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetOldestEntityCreationTime", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetOldestEntityCreationTime", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4553,13 +5616,16 @@ func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterId string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetParentsForExportAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetParentsForExportAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
+func (s *TimerLayerPostStore) GetPinnedPosts(channelId string) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostAfterTime(channelId, time)
+	result, err := s.PostStore.GetPinnedPosts(channelId)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4567,15 +5633,18 @@ func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*m
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPinnedPosts", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPinnedPosts", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
+func (s *TimerLayerPostStore) GetPost(id string, includeDeleted bool) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
+	result, err := s.PostStore.GetPost(id, includeDeleted)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4583,15 +5652,18 @@ func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPost", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPost", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
+func (s *TimerLayerPostStore) GetPostAfterTime(channelId string, time int64) (*model.Post, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
+	result, err := s.PostStore.GetPostAfterTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4599,15 +5671,18 @@ func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostAfterTime", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostAfterTime", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+func (s *TimerLayerPostStore) GetPostIdAfterTime(channelId string, time int64) (string, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPosts(options, allowFromCache)
+	result, err := s.PostStore.GetPostIdAfterTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4615,15 +5690,18 @@ func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromC
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdAfterTime", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostIdAfterTime", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *TimerLayerPostStore) GetPostIdBeforeTime(channelId string, time int64) (string, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsAfter(options)
+	result, err := s.PostStore.GetPostIdBeforeTime(channelId, time)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4631,15 +5709,18 @@ func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*mod
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostIdBeforeTime", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostIdBeforeTime", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+func (s *TimerLayerPostStore) GetPostWithContext(postId string, before int, after int) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+	result, err := s.PostStore.GetPostWithContext(postId, before, after)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4647,15 +5728,113 @@ func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostWithContext", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostWithContext", err == nil, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+func (s *TimerLayerPostStore) GetPosts(options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
 	start := timemodule.Now()
 
-	result, err := s.PostStore.GetPostsBefore(options)
+	result, err := s.PostStore.GetPosts(options, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPosts", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPosts", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsAfter(options model.GetPostsOptions) (*model.PostList, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsAfter(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfter", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsAfter", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsAfterCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	start := timemodule.Now()
+
+	result, resultVar1, err := s.PostStore.GetPostsAfterCursor(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsAfterCursor", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsAfterCursor", err == nil, elapsed)
+	}
+	return result, resultVar1, err
+}
+
+func (s *TimerLayerPostStore) GetPostsBatchForIndexing(startTime int64, endTime int64, limit int) ([]*model.PostForIndexing, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, endTime, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatchForIndexing", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsBatchForIndexing", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsBatched(channelId string, batchSize int, fn func([]*model.Post) error) error {
+	start := timemodule.Now()
+
+	err := s.PostStore.GetPostsBatched(channelId, batchSize, fn)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBatched", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsBatched", err == nil, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*model.PostList, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsBefore(options)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -4665,9 +5844,31 @@ func (s *TimerLayerPostStore) GetPostsBefore(options model.GetPostsOptions) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBefore", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsBefore", err == nil, elapsed)
+	}
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostsBeforeCursor(options model.GetPostsCursorOptions) (*model.PostList, string, error) {
+	start := timemodule.Now()
+
+	result, resultVar1, err := s.PostStore.GetPostsBeforeCursor(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsBeforeCursor", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsBeforeCursor", err == nil, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
 	start := timemodule.Now()
 
@@ -4681,6 +5882,28 @@ func (s *TimerLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsByIds", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsByProp(channelId string, key string, value string) ([]*model.Post, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsByProp(channelId, key, value)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsByProp", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsByProp", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4697,6 +5920,28 @@ func (s *TimerLayerPostStore) GetPostsCreatedAt(channelId string, time int64) ([
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCreatedAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsCreatedAt", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetPostsCtx(ctx context.Context, options model.GetPostsOptions, allowFromCache bool) (*model.PostList, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsCtx(ctx, options, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsCtx", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsCtx", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4713,9 +5958,50 @@ func (s *TimerLayerPostStore) GetPostsSince(options model.GetPostsSinceOptions,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSince", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsSince", err == nil, elapsed)
+	}
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostsSinceCtx(ctx context.Context, options model.GetPostsSinceOptions, allowFromCache bool) (*model.PostList, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetPostsSinceCtx(ctx, options, allowFromCache)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsSinceCtx", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetPostsSinceCtx", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetRecentPostsForUser(options model.GetRecentPostsOptions) (*model.PostList, string, error) {
+	start := timemodule.Now()
+
+	result, resultVar1, err := s.PostStore.GetRecentPostsForUser(options)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRecentPostsForUser", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetRecentPostsForUser", err == nil, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.ReplyForExport, error) {
 	start := timemodule.Now()
 
@@ -4729,6 +6015,9 @@ func (s *TimerLayerPostStore) GetRepliesForExport(parentId string) ([]*model.Rep
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRepliesForExport", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetRepliesForExport", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4745,6 +6034,28 @@ func (s *TimerLayerPostStore) GetSingle(id string) (*model.Post, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingle", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetSingle", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetSingleCtx(ctx context.Context, id string) (*model.Post, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.GetSingleCtx(ctx, id)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetSingleCtx", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.GetSingleCtx", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4761,6 +6072,28 @@ func (s *TimerLayerPostStore) InvalidateLastPostTimeCache(channelId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.InvalidateLastPostTimeCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.InvalidateLastPostTimeCache", true, elapsed)
+	}
+}
+
+func (s *TimerLayerPostStore) MoveThread(rootId string, targetChannelId string, allowCrossTeam bool) ([]*model.Post, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.MoveThread(rootId, targetChannelId, allowCrossTeam)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.MoveThread", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.MoveThread", err == nil, elapsed)
+	}
+	return result, err
 }
 
 func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
@@ -4776,6 +6109,9 @@ func (s *TimerLayerPostStore) Overwrite(post *model.Post) (*model.Post, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Overwrite", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Overwrite", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4792,6 +6128,9 @@ func (s *TimerLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*model.P
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.OverwriteMultiple", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.OverwriteMultiple", err == nil, elapsed)
+	}
 	return result, resultVar1, err
 }
 
@@ -4808,6 +6147,9 @@ func (s *TimerLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteBatch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.PermanentDeleteBatch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4824,6 +6166,9 @@ func (s *TimerLayerPostStore) PermanentDeleteByChannel(channelId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.PermanentDeleteByChannel", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4840,6 +6185,9 @@ func (s *TimerLayerPostStore) PermanentDeleteByUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.PermanentDeleteByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.PermanentDeleteByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4856,6 +6204,9 @@ func (s *TimerLayerPostStore) Save(post *model.Post) (*model.Post, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4872,6 +6223,9 @@ func (s *TimerLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SaveMultiple", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.SaveMultiple", err == nil, elapsed)
+	}
 	return result, resultVar1, err
 }
 
@@ -4888,6 +6242,28 @@ func (s *TimerLayerPostStore) Search(teamId string, userId string, params *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Search", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Search", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) SearchAllTeams(userId string, terms string, opts model.SearchAllTeamsOptions) (*model.PostSearchResults, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.SearchAllTeams(userId, terms, opts)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchAllTeams", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.SearchAllTeams", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4904,6 +6280,47 @@ func (s *TimerLayerPostStore) SearchPostsInTeamForUser(paramsList []*model.Searc
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsInTeamForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.SearchPostsInTeamForUser", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) SearchPostsInTeamForUserAfter(paramsList []*model.SearchParams, userId string, teamId string, searchAfter *model.PostSearchCursor, perPage int) (*model.PostSearchResults, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.SearchPostsInTeamForUserAfter(paramsList, userId, teamId, searchAfter, perPage)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SearchPostsInTeamForUserAfter", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.SearchPostsInTeamForUserAfter", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) SuggestTerms(userId string, teamId string, prefix string, limit int) ([]*model.SearchSuggestion, error) {
+	start := timemodule.Now()
+
+	result, err := s.PostStore.SuggestTerms(userId, teamId, prefix, limit)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.SuggestTerms", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.SuggestTerms", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4920,6 +6337,9 @@ func (s *TimerLayerPostStore) Update(newPost *model.Post, oldPost *model.Post) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PostStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4936,6 +6356,9 @@ func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.CleanupFlagsBatch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -4952,6 +6375,9 @@ func (s *TimerLayerPreferenceStore) Delete(userId string, category string, name
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4968,6 +6394,9 @@ func (s *TimerLayerPreferenceStore) DeleteCategory(userId string, category strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.DeleteCategory", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -4984,6 +6413,9 @@ func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.DeleteCategoryAndName", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5000,6 +6432,9 @@ func (s *TimerLayerPreferenceStore) Get(userId string, category string, name str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5016,6 +6451,9 @@ func (s *TimerLayerPreferenceStore) GetAll(userId string) (model.Preferences, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5032,6 +6470,9 @@ func (s *TimerLayerPreferenceStore) GetCategory(userId string, category string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.GetCategory", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5048,6 +6489,9 @@ func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.PermanentDeleteByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5064,9 +6508,31 @@ func (s *TimerLayerPreferenceStore) Save(preferences *model.Preferences) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.Save", err == nil, elapsed)
+	}
 	return err
 }
 
+func (s *TimerLayerPreferenceStore) SaveMultiple(preferences model.Preferences, deleteOmitted bool) (model.Preferences, error) {
+	start := timemodule.Now()
+
+	result, err := s.PreferenceStore.SaveMultiple(preferences, deleteOmitted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.SaveMultiple", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("PreferenceStore.SaveMultiple", err == nil, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 	start := timemodule.Now()
 
@@ -5080,6 +6546,9 @@ func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ProductNoticesStore.Clear", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5096,6 +6565,9 @@ func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices *model.Pr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ProductNoticesStore.ClearOldNotices", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5112,6 +6584,9 @@ func (s *TimerLayerProductNoticesStore) GetViews(userId string) ([]model.Product
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ProductNoticesStore.GetViews", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5128,6 +6603,9 @@ func (s *TimerLayerProductNoticesStore) View(userId string, notices []string) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ProductNoticesStore.View", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5144,6 +6622,9 @@ func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.BulkGetForPosts", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5160,6 +6641,9 @@ func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5176,6 +6660,9 @@ func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.DeleteAllWithEmojiName", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5192,6 +6679,28 @@ func (s *TimerLayerReactionStore) GetForPost(postId string, allowFromCache bool)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.GetForPost", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetForPosts(postIds []string) (map[string][]*model.Reaction, error) {
+	start := timemodule.Now()
+
+	result, err := s.ReactionStore.GetForPosts(postIds)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPosts", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.GetForPosts", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5208,6 +6717,9 @@ func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.PermanentDeleteBatch", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5224,6 +6736,9 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ReactionStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5240,6 +6755,9 @@ func (s *TimerLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.AllChannelSchemeRoles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.AllChannelSchemeRoles", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5256,6 +6774,9 @@ func (s *TimerLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelHigherScopedPermissions", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.ChannelHigherScopedPermissions", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5272,6 +6793,9 @@ func (s *TimerLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.ChannelRolesUnderTeamRole", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.ChannelRolesUnderTeamRole", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5288,6 +6812,9 @@ func (s *TimerLayerRoleStore) Delete(roleId string) (*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5304,6 +6831,9 @@ func (s *TimerLayerRoleStore) Get(roleId string) (*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5320,6 +6850,9 @@ func (s *TimerLayerRoleStore) GetAll() ([]*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5336,6 +6869,9 @@ func (s *TimerLayerRoleStore) GetByName(name string) (*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5352,6 +6888,9 @@ func (s *TimerLayerRoleStore) GetByNames(names []string) ([]*model.Role, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.GetByNames", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.GetByNames", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5368,6 +6907,9 @@ func (s *TimerLayerRoleStore) PermanentDeleteAll() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.PermanentDeleteAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.PermanentDeleteAll", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5384,6 +6926,9 @@ func (s *TimerLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("RoleStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("RoleStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5400,6 +6945,9 @@ func (s *TimerLayerSchemeStore) CountByScope(scope string) (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountByScope", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.CountByScope", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5416,6 +6964,9 @@ func (s *TimerLayerSchemeStore) CountWithoutPermission(scope string, permissionI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.CountWithoutPermission", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.CountWithoutPermission", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5432,6 +6983,9 @@ func (s *TimerLayerSchemeStore) Delete(schemeId string) (*model.Scheme, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.Delete", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5448,6 +7002,9 @@ func (s *TimerLayerSchemeStore) Get(schemeId string) (*model.Scheme, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5464,6 +7021,9 @@ func (s *TimerLayerSchemeStore) GetAllPage(scope string, offset int, limit int)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetAllPage", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.GetAllPage", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5480,6 +7040,9 @@ func (s *TimerLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5496,6 +7059,9 @@ func (s *TimerLayerSchemeStore) PermanentDeleteAll() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.PermanentDeleteAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.PermanentDeleteAll", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5512,6 +7078,9 @@ func (s *TimerLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SchemeStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SchemeStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5528,6 +7097,9 @@ func (s *TimerLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.AnalyticsSessionCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.AnalyticsSessionCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5544,6 +7116,9 @@ func (s *TimerLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Cleanup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.Cleanup", true, elapsed)
+	}
 }
 
 func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, error) {
@@ -5559,6 +7134,9 @@ func (s *TimerLayerSessionStore) Get(sessionIdOrToken string) (*model.Session, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5575,6 +7153,9 @@ func (s *TimerLayerSessionStore) GetSessions(userId string) ([]*model.Session, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessions", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.GetSessions", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5591,6 +7172,9 @@ func (s *TimerLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobil
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsExpired", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.GetSessionsExpired", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5607,6 +7191,9 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userId string) (
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithActiveDeviceIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.GetSessionsWithActiveDeviceIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5623,6 +7210,9 @@ func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamId string) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.PermanentDeleteSessionsByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.PermanentDeleteSessionsByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5639,6 +7229,9 @@ func (s *TimerLayerSessionStore) Remove(sessionIdOrToken string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Remove", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.Remove", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5655,6 +7248,9 @@ func (s *TimerLayerSessionStore) RemoveAllSessions() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.RemoveAllSessions", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.RemoveAllSessions", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5671,6 +7267,9 @@ func (s *TimerLayerSessionStore) Save(session *model.Session) (*model.Session, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5687,6 +7286,9 @@ func (s *TimerLayerSessionStore) UpdateDeviceId(id string, deviceId string, expi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateDeviceId", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateDeviceId", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5703,6 +7305,9 @@ func (s *TimerLayerSessionStore) UpdateExpiredNotify(sessionid string, notified
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiredNotify", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateExpiredNotify", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5719,6 +7324,9 @@ func (s *TimerLayerSessionStore) UpdateExpiresAt(sessionId string, time int64) e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateExpiresAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateExpiresAt", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5735,6 +7343,9 @@ func (s *TimerLayerSessionStore) UpdateLastActivityAt(sessionId string, time int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateLastActivityAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateLastActivityAt", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5751,6 +7362,9 @@ func (s *TimerLayerSessionStore) UpdateProps(session *model.Session) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateProps", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateProps", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5767,6 +7381,9 @@ func (s *TimerLayerSessionStore) UpdateRoles(userId string, roles string) (strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.UpdateRoles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SessionStore.UpdateRoles", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5783,6 +7400,9 @@ func (s *TimerLayerStatusStore) Get(userId string) (*model.Status, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5799,6 +7419,9 @@ func (s *TimerLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.GetByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5815,6 +7438,9 @@ func (s *TimerLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.GetTotalActiveUsersCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.GetTotalActiveUsersCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5831,6 +7457,9 @@ func (s *TimerLayerStatusStore) ResetAll() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.ResetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.ResetAll", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5847,6 +7476,9 @@ func (s *TimerLayerStatusStore) SaveOrUpdate(status *model.Status) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.SaveOrUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.SaveOrUpdate", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5863,6 +7495,9 @@ func (s *TimerLayerStatusStore) UpdateLastActivityAt(userId string, lastActivity
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("StatusStore.UpdateLastActivityAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("StatusStore.UpdateLastActivityAt", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5879,6 +7514,9 @@ func (s *TimerLayerSystemStore) Get() (model.StringMap, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5895,6 +7533,9 @@ func (s *TimerLayerSystemStore) GetByName(name string) (*model.System, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5911,6 +7552,9 @@ func (s *TimerLayerSystemStore) InsertIfExists(system *model.System) (*model.Sys
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.InsertIfExists", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.InsertIfExists", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5927,6 +7571,9 @@ func (s *TimerLayerSystemStore) PermanentDeleteByName(name string) (*model.Syste
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.PermanentDeleteByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.PermanentDeleteByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -5943,6 +7590,9 @@ func (s *TimerLayerSystemStore) Save(system *model.System) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.Save", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5959,6 +7609,9 @@ func (s *TimerLayerSystemStore) SaveOrUpdate(system *model.System) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.SaveOrUpdate", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5975,6 +7628,9 @@ func (s *TimerLayerSystemStore) SaveOrUpdateWithWarnMetricHandling(system *model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.SaveOrUpdateWithWarnMetricHandling", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.SaveOrUpdateWithWarnMetricHandling", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -5991,6 +7647,9 @@ func (s *TimerLayerSystemStore) Update(system *model.System) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("SystemStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("SystemStore.Update", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6007,6 +7666,9 @@ func (s *TimerLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeId string) (i
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsGetTeamCountForScheme", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.AnalyticsGetTeamCountForScheme", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6023,6 +7685,9 @@ func (s *TimerLayerTeamStore) AnalyticsPrivateTeamCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPrivateTeamCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.AnalyticsPrivateTeamCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6039,6 +7704,9 @@ func (s *TimerLayerTeamStore) AnalyticsPublicTeamCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsPublicTeamCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.AnalyticsPublicTeamCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6055,6 +7723,9 @@ func (s *TimerLayerTeamStore) AnalyticsTeamCount(includeDeleted bool) (int64, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.AnalyticsTeamCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.AnalyticsTeamCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6071,6 +7742,9 @@ func (s *TimerLayerTeamStore) ClearAllCustomRoleAssignments() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.ClearAllCustomRoleAssignments", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6087,6 +7761,9 @@ func (s *TimerLayerTeamStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerTeamStore) Get(id string) (*model.Team, error) {
@@ -6102,6 +7779,9 @@ func (s *TimerLayerTeamStore) Get(id string) (*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6118,6 +7798,9 @@ func (s *TimerLayerTeamStore) GetActiveMemberCount(teamId string, restrictions *
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetActiveMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetActiveMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6134,6 +7817,9 @@ func (s *TimerLayerTeamStore) GetAll() ([]*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6150,6 +7836,9 @@ func (s *TimerLayerTeamStore) GetAllForExportAfter(limit int, afterId string) ([
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllForExportAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllForExportAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6166,6 +7855,9 @@ func (s *TimerLayerTeamStore) GetAllPage(offset int, limit int) ([]*model.Team,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPage", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllPage", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6182,6 +7874,9 @@ func (s *TimerLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamListing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllPrivateTeamListing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6198,6 +7893,9 @@ func (s *TimerLayerTeamStore) GetAllPrivateTeamPageListing(offset int, limit int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPrivateTeamPageListing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllPrivateTeamPageListing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6214,6 +7912,9 @@ func (s *TimerLayerTeamStore) GetAllPublicTeamPageListing(offset int, limit int)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllPublicTeamPageListing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllPublicTeamPageListing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6230,6 +7931,9 @@ func (s *TimerLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamListing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllTeamListing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6246,6 +7950,9 @@ func (s *TimerLayerTeamStore) GetAllTeamPageListing(offset int, limit int) ([]*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetAllTeamPageListing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetAllTeamPageListing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6262,6 +7969,9 @@ func (s *TimerLayerTeamStore) GetByInviteId(inviteId string) (*model.Team, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByInviteId", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetByInviteId", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6278,6 +7988,9 @@ func (s *TimerLayerTeamStore) GetByName(name string) (*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByName", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetByName", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6294,6 +8007,9 @@ func (s *TimerLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetByNames", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetByNames", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6310,6 +8026,9 @@ func (s *TimerLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForAllTeams", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetChannelUnreadsForAllTeams", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6326,6 +8045,9 @@ func (s *TimerLayerTeamStore) GetChannelUnreadsForTeam(teamId string, userId str
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetChannelUnreadsForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetChannelUnreadsForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6342,6 +8064,9 @@ func (s *TimerLayerTeamStore) GetMember(teamId string, userId string) (*model.Te
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6358,6 +8083,9 @@ func (s *TimerLayerTeamStore) GetMembers(teamId string, offset int, limit int, t
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6374,6 +8102,9 @@ func (s *TimerLayerTeamStore) GetMembersByIds(teamId string, userIds []string, r
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetMembersByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetMembersByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6390,6 +8121,28 @@ func (s *TimerLayerTeamStore) GetTeamMembersForExport(userId string) ([]*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamMembersForExport", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamMembersForExport", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerTeamStore) GetTeamStats(teamId string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, error) {
+	start := timemodule.Now()
+
+	result, err := s.TeamStore.GetTeamStats(teamId, restrictions)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamStats", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamStats", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6406,6 +8159,9 @@ func (s *TimerLayerTeamStore) GetTeamsByScheme(schemeId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByScheme", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamsByScheme", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6422,6 +8178,9 @@ func (s *TimerLayerTeamStore) GetTeamsByUserId(userId string) ([]*model.Team, er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsByUserId", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamsByUserId", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6438,6 +8197,9 @@ func (s *TimerLayerTeamStore) GetTeamsForUser(ctx context.Context, userId string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamsForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6454,6 +8216,9 @@ func (s *TimerLayerTeamStore) GetTeamsForUserWithPagination(userId string, page
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTeamsForUserWithPagination", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTeamsForUserWithPagination", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6470,6 +8235,9 @@ func (s *TimerLayerTeamStore) GetTotalMemberCount(teamId string, restrictions *m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetTotalMemberCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetTotalMemberCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6486,6 +8254,9 @@ func (s *TimerLayerTeamStore) GetUserTeamIds(userId string, allowFromCache bool)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GetUserTeamIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GetUserTeamIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6502,6 +8273,9 @@ func (s *TimerLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.GroupSyncedTeamCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.GroupSyncedTeamCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6518,6 +8292,9 @@ func (s *TimerLayerTeamStore) InvalidateAllTeamIdsForUser(userId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.InvalidateAllTeamIdsForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.InvalidateAllTeamIdsForUser", true, elapsed)
+	}
 }
 
 func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId string) (map[string]string, error) {
@@ -6533,6 +8310,9 @@ func (s *TimerLayerTeamStore) MigrateTeamMembers(fromTeamId string, fromUserId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.MigrateTeamMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.MigrateTeamMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6549,6 +8329,9 @@ func (s *TimerLayerTeamStore) PermanentDelete(teamId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.PermanentDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.PermanentDelete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6565,6 +8348,9 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByTeam(teamId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.RemoveAllMembersByTeam", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6581,6 +8367,9 @@ func (s *TimerLayerTeamStore) RemoveAllMembersByUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveAllMembersByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.RemoveAllMembersByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6597,6 +8386,9 @@ func (s *TimerLayerTeamStore) RemoveMember(teamId string, userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.RemoveMember", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6613,6 +8405,9 @@ func (s *TimerLayerTeamStore) RemoveMembers(teamId string, userIds []string) err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.RemoveMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.RemoveMembers", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6629,6 +8424,9 @@ func (s *TimerLayerTeamStore) ResetAllTeamSchemes() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.ResetAllTeamSchemes", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.ResetAllTeamSchemes", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6645,6 +8443,9 @@ func (s *TimerLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6661,6 +8462,9 @@ func (s *TimerLayerTeamStore) SaveMember(member *model.TeamMember, maxUsersPerTe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SaveMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6677,6 +8481,9 @@ func (s *TimerLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SaveMultipleMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SaveMultipleMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6693,6 +8500,9 @@ func (s *TimerLayerTeamStore) SearchAll(term string, opts *model.TeamSearch) ([]
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SearchAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6709,6 +8519,9 @@ func (s *TimerLayerTeamStore) SearchAllPaged(term string, opts *model.TeamSearch
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchAllPaged", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SearchAllPaged", err == nil, elapsed)
+	}
 	return result, resultVar1, err
 }
 
@@ -6725,6 +8538,9 @@ func (s *TimerLayerTeamStore) SearchOpen(term string) ([]*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchOpen", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SearchOpen", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6741,6 +8557,9 @@ func (s *TimerLayerTeamStore) SearchPrivate(term string) ([]*model.Team, error)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.SearchPrivate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.SearchPrivate", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6757,6 +8576,9 @@ func (s *TimerLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6773,6 +8595,9 @@ func (s *TimerLayerTeamStore) UpdateLastTeamIconUpdate(teamId string, curTime in
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateLastTeamIconUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.UpdateLastTeamIconUpdate", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6789,6 +8614,9 @@ func (s *TimerLayerTeamStore) UpdateMember(member *model.TeamMember) (*model.Tea
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMember", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.UpdateMember", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6805,6 +8633,9 @@ func (s *TimerLayerTeamStore) UpdateMembersRole(teamID string, userIDs []string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMembersRole", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.UpdateMembersRole", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6821,6 +8652,9 @@ func (s *TimerLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UpdateMultipleMembers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.UpdateMultipleMembers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6837,6 +8671,9 @@ func (s *TimerLayerTeamStore) UserBelongsToTeams(userId string, teamIds []string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TeamStore.UserBelongsToTeams", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TeamStore.UserBelongsToTeams", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6853,6 +8690,9 @@ func (s *TimerLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TermsOfServiceStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6869,6 +8709,9 @@ func (s *TimerLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.GetLatest", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TermsOfServiceStore.GetLatest", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6885,6 +8728,9 @@ func (s *TimerLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfServic
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TermsOfServiceStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TermsOfServiceStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6901,6 +8747,9 @@ func (s *TimerLayerThreadStore) CollectThreadsWithNewerReplies(userId string, ch
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.CollectThreadsWithNewerReplies", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.CollectThreadsWithNewerReplies", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6917,6 +8766,9 @@ func (s *TimerLayerThreadStore) CreateMembershipIfNeeded(userId string, postId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.CreateMembershipIfNeeded", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.CreateMembershipIfNeeded", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6933,6 +8785,9 @@ func (s *TimerLayerThreadStore) Delete(postId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6949,6 +8804,9 @@ func (s *TimerLayerThreadStore) DeleteMembershipForUser(userId string, postId st
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.DeleteMembershipForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.DeleteMembershipForUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -6965,6 +8823,9 @@ func (s *TimerLayerThreadStore) Get(id string) (*model.Thread, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6981,6 +8842,9 @@ func (s *TimerLayerThreadStore) GetMembershipForUser(userId string, postId strin
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.GetMembershipForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.GetMembershipForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -6997,13 +8861,16 @@ func (s *TimerLayerThreadStore) GetMembershipsForUser(userId string) ([]*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.GetMembershipsForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.GetMembershipsForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
-func (s *TimerLayerThreadStore) GetThreadsForUser(userId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
+func (s *TimerLayerThreadStore) GetThreadsForUser(userId string, teamId string, opts model.GetUserThreadsOpts) (*model.Threads, error) {
 	start := timemodule.Now()
 
-	result, err := s.ThreadStore.GetThreadsForUser(userId, opts)
+	result, err := s.ThreadStore.GetThreadsForUser(userId, teamId, opts)
 
 	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
 	if s.Root.Metrics != nil {
@@ -7013,6 +8880,9 @@ func (s *TimerLayerThreadStore) GetThreadsForUser(userId string, opts model.GetU
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.GetThreadsForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.GetThreadsForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7029,6 +8899,9 @@ func (s *TimerLayerThreadStore) MarkAllAsRead(userId string, timestamp int64) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.MarkAllAsRead", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.MarkAllAsRead", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7045,6 +8918,9 @@ func (s *TimerLayerThreadStore) MarkAsRead(userId string, threadId string, times
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.MarkAsRead", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.MarkAsRead", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7061,6 +8937,9 @@ func (s *TimerLayerThreadStore) Save(thread *model.Thread) (*model.Thread, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7077,6 +8956,9 @@ func (s *TimerLayerThreadStore) SaveMembership(membership *model.ThreadMembershi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.SaveMembership", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.SaveMembership", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7093,6 +8975,9 @@ func (s *TimerLayerThreadStore) SaveMultiple(thread []*model.Thread) ([]*model.T
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.SaveMultiple", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.SaveMultiple", err == nil, elapsed)
+	}
 	return result, resultVar1, err
 }
 
@@ -7109,6 +8994,9 @@ func (s *TimerLayerThreadStore) Update(thread *model.Thread) (*model.Thread, err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7125,6 +9013,9 @@ func (s *TimerLayerThreadStore) UpdateMembership(membership *model.ThreadMembers
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.UpdateMembership", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.UpdateMembership", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7141,6 +9032,9 @@ func (s *TimerLayerThreadStore) UpdateUnreadsByChannel(userId string, changedThr
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("ThreadStore.UpdateUnreadsByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("ThreadStore.UpdateUnreadsByChannel", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7157,6 +9051,9 @@ func (s *TimerLayerTokenStore) Cleanup() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Cleanup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TokenStore.Cleanup", true, elapsed)
+	}
 }
 
 func (s *TimerLayerTokenStore) Delete(token string) error {
@@ -7172,6 +9069,9 @@ func (s *TimerLayerTokenStore) Delete(token string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TokenStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7188,6 +9088,9 @@ func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.GetByToken", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TokenStore.GetByToken", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7204,6 +9107,9 @@ func (s *TimerLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.RemoveAllTokensByType", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TokenStore.RemoveAllTokensByType", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7220,6 +9126,9 @@ func (s *TimerLayerTokenStore) Save(recovery *model.Token) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("TokenStore.Save", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7236,6 +9145,9 @@ func (s *TimerLayerUploadSessionStore) Delete(id string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UploadSessionStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7252,6 +9164,9 @@ func (s *TimerLayerUploadSessionStore) Get(id string) (*model.UploadSession, err
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UploadSessionStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7268,6 +9183,9 @@ func (s *TimerLayerUploadSessionStore) GetForUser(userId string) ([]*model.Uploa
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.GetForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UploadSessionStore.GetForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7284,6 +9202,9 @@ func (s *TimerLayerUploadSessionStore) Save(session *model.UploadSession) (*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UploadSessionStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7300,6 +9221,9 @@ func (s *TimerLayerUploadSessionStore) Update(session *model.UploadSession) erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UploadSessionStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UploadSessionStore.Update", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7316,6 +9240,9 @@ func (s *TimerLayerUserStore) AnalyticsActiveCount(time int64, options model.Use
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsActiveCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7332,6 +9259,9 @@ func (s *TimerLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, end
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsActiveCountForPeriod", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsActiveCountForPeriod", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7348,6 +9278,9 @@ func (s *TimerLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetExternalUsers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsGetExternalUsers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7364,6 +9297,9 @@ func (s *TimerLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetGuestCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsGetGuestCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7380,6 +9316,9 @@ func (s *TimerLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetInactiveUsersCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsGetInactiveUsersCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7396,6 +9335,9 @@ func (s *TimerLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AnalyticsGetSystemAdminCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AnalyticsGetSystemAdminCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7412,6 +9354,9 @@ func (s *TimerLayerUserStore) AutocompleteUsersInChannel(teamId string, channelI
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.AutocompleteUsersInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.AutocompleteUsersInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7428,6 +9373,9 @@ func (s *TimerLayerUserStore) ClearAllCustomRoleAssignments() error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearAllCustomRoleAssignments", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.ClearAllCustomRoleAssignments", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7444,6 +9392,9 @@ func (s *TimerLayerUserStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
@@ -7459,6 +9410,9 @@ func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Count", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.Count", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7475,6 +9429,9 @@ func (s *TimerLayerUserStore) DeactivateGuests() ([]string, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DeactivateGuests", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.DeactivateGuests", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7491,6 +9448,9 @@ func (s *TimerLayerUserStore) DemoteUserToGuest(userID string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.DemoteUserToGuest", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.DemoteUserToGuest", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -7507,6 +9467,9 @@ func (s *TimerLayerUserStore) Get(id string) (*model.User, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7523,6 +9486,9 @@ func (s *TimerLayerUserStore) GetAll() ([]*model.User, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7539,6 +9505,9 @@ func (s *TimerLayerUserStore) GetAllAfter(limit int, afterId string) ([]*model.U
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllAfter", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAllAfter", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7555,6 +9524,9 @@ func (s *TimerLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllNotInAuthService", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAllNotInAuthService", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7571,6 +9543,9 @@ func (s *TimerLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfiles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAllProfiles", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7587,6 +9562,9 @@ func (s *TimerLayerUserStore) GetAllProfilesInChannel(channelId string, allowFro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllProfilesInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAllProfilesInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7603,6 +9581,9 @@ func (s *TimerLayerUserStore) GetAllUsingAuthService(authService string) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllUsingAuthService", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAllUsingAuthService", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7619,6 +9600,9 @@ func (s *TimerLayerUserStore) GetAnyUnreadPostCountForChannel(userId string, cha
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAnyUnreadPostCountForChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetAnyUnreadPostCountForChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7635,6 +9619,9 @@ func (s *TimerLayerUserStore) GetByAuth(authData *string, authService string) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByAuth", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetByAuth", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7651,6 +9638,9 @@ func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByEmail", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetByEmail", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7667,6 +9657,9 @@ func (s *TimerLayerUserStore) GetByUsername(username string) (*model.User, error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByUsername", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetByUsername", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7683,6 +9676,9 @@ func (s *TimerLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.U
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetChannelGroupUsers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetChannelGroupUsers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7699,6 +9695,9 @@ func (s *TimerLayerUserStore) GetEtagForAllProfiles() string {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForAllProfiles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetEtagForAllProfiles", true, elapsed)
+	}
 	return result
 }
 
@@ -7715,6 +9714,9 @@ func (s *TimerLayerUserStore) GetEtagForProfiles(teamId string) string {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfiles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetEtagForProfiles", true, elapsed)
+	}
 	return result
 }
 
@@ -7731,6 +9733,9 @@ func (s *TimerLayerUserStore) GetEtagForProfilesNotInTeam(teamId string) string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetEtagForProfilesNotInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetEtagForProfilesNotInTeam", true, elapsed)
+	}
 	return result
 }
 
@@ -7747,6 +9752,9 @@ func (s *TimerLayerUserStore) GetForLogin(loginId string, allowSignInWithUsernam
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetForLogin", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetForLogin", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7763,6 +9771,9 @@ func (s *TimerLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetKnownUsers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetKnownUsers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7779,6 +9790,9 @@ func (s *TimerLayerUserStore) GetNewUsersForTeam(teamId string, offset int, limi
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetNewUsersForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetNewUsersForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7795,6 +9809,9 @@ func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userId string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByGroupChannelIdsForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfileByGroupChannelIdsForUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7811,6 +9828,9 @@ func (s *TimerLayerUserStore) GetProfileByIds(userIds []string, options *store.U
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfileByIds", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfileByIds", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7827,6 +9847,9 @@ func (s *TimerLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*mod
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfiles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfiles", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7843,6 +9866,9 @@ func (s *TimerLayerUserStore) GetProfilesByUsernames(usernames []string, viewRes
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesByUsernames", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesByUsernames", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7859,6 +9885,9 @@ func (s *TimerLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7875,6 +9904,9 @@ func (s *TimerLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGe
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesInChannelByStatus", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesInChannelByStatus", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7891,6 +9923,9 @@ func (s *TimerLayerUserStore) GetProfilesNotInChannel(teamId string, channelId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesNotInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7907,6 +9942,9 @@ func (s *TimerLayerUserStore) GetProfilesNotInTeam(teamId string, groupConstrain
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesNotInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesNotInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7923,6 +9961,9 @@ func (s *TimerLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptio
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetProfilesWithoutTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetProfilesWithoutTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7939,6 +9980,9 @@ func (s *TimerLayerUserStore) GetRecentlyActiveUsersForTeam(teamId string, offse
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetRecentlyActiveUsersForTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetRecentlyActiveUsersForTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7955,6 +9999,9 @@ func (s *TimerLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetSystemAdminProfiles", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetSystemAdminProfiles", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7971,6 +10018,9 @@ func (s *TimerLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetTeamGroupUsers", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetTeamGroupUsers", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -7987,6 +10037,9 @@ func (s *TimerLayerUserStore) GetUnreadCount(userId string) (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetUnreadCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8003,6 +10056,28 @@ func (s *TimerLayerUserStore) GetUnreadCountForChannel(userId string, channelId
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUnreadCountForChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetUnreadCountForChannel", err == nil, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerUserStore) GetUser(id string, includeDeleted bool) (*model.User, error) {
+	start := timemodule.Now()
+
+	result, err := s.UserStore.GetUser(id, includeDeleted)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUser", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8019,6 +10094,9 @@ func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, endTime
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUsersBatchForIndexing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.GetUsersBatchForIndexing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8035,6 +10113,9 @@ func (s *TimerLayerUserStore) InferSystemInstallDate() (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InferSystemInstallDate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.InferSystemInstallDate", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8051,6 +10132,9 @@ func (s *TimerLayerUserStore) InvalidateProfileCacheForUser(userId string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfileCacheForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.InvalidateProfileCacheForUser", true, elapsed)
+	}
 }
 
 func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string) {
@@ -8066,6 +10150,9 @@ func (s *TimerLayerUserStore) InvalidateProfilesInChannelCache(channelId string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.InvalidateProfilesInChannelCache", true, elapsed)
+	}
 }
 
 func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId string) {
@@ -8081,6 +10168,9 @@ func (s *TimerLayerUserStore) InvalidateProfilesInChannelCacheByUser(userId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.InvalidateProfilesInChannelCacheByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.InvalidateProfilesInChannelCacheByUser", true, elapsed)
+	}
 }
 
 func (s *TimerLayerUserStore) PermanentDelete(userId string) error {
@@ -8096,6 +10186,9 @@ func (s *TimerLayerUserStore) PermanentDelete(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PermanentDelete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.PermanentDelete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8112,6 +10205,9 @@ func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.PromoteGuestToUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.PromoteGuestToUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8128,6 +10224,9 @@ func (s *TimerLayerUserStore) ResetLastPictureUpdate(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.ResetLastPictureUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.ResetLastPictureUpdate", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8144,6 +10243,9 @@ func (s *TimerLayerUserStore) Save(user *model.User) (*model.User, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8160,6 +10262,9 @@ func (s *TimerLayerUserStore) Search(teamId string, term string, options *model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Search", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.Search", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8176,6 +10281,9 @@ func (s *TimerLayerUserStore) SearchInChannel(channelId string, term string, opt
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.SearchInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8192,6 +10300,9 @@ func (s *TimerLayerUserStore) SearchInGroup(groupID string, term string, options
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchInGroup", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.SearchInGroup", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8208,6 +10319,9 @@ func (s *TimerLayerUserStore) SearchNotInChannel(teamId string, channelId string
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.SearchNotInChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8224,6 +10338,9 @@ func (s *TimerLayerUserStore) SearchNotInTeam(notInTeamId string, term string, o
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchNotInTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.SearchNotInTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8240,6 +10357,9 @@ func (s *TimerLayerUserStore) SearchWithoutTeam(term string, options *model.User
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.SearchWithoutTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.SearchWithoutTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8256,6 +10376,9 @@ func (s *TimerLayerUserStore) Update(user *model.User, allowRoleUpdate bool) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.Update", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.Update", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8272,6 +10395,9 @@ func (s *TimerLayerUserStore) UpdateAuthData(userId string, service string, auth
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateAuthData", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateAuthData", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8288,6 +10414,9 @@ func (s *TimerLayerUserStore) UpdateFailedPasswordAttempts(userId string, attemp
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateFailedPasswordAttempts", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateFailedPasswordAttempts", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8304,6 +10433,9 @@ func (s *TimerLayerUserStore) UpdateLastPictureUpdate(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateLastPictureUpdate", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateLastPictureUpdate", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8320,6 +10452,9 @@ func (s *TimerLayerUserStore) UpdateMfaActive(userId string, active bool) error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaActive", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateMfaActive", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8336,6 +10471,9 @@ func (s *TimerLayerUserStore) UpdateMfaSecret(userId string, secret string) erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateMfaSecret", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateMfaSecret", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8352,6 +10490,28 @@ func (s *TimerLayerUserStore) UpdatePassword(userId string, newPassword string)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdatePassword", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdatePassword", err == nil, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerUserStore) UpdatePasswordHash(userId string, oldHash string, newHash string) error {
+	start := timemodule.Now()
+
+	err := s.UserStore.UpdatePasswordHash(userId, oldHash, newHash)
+
+	elapsed := float64(timemodule.Since(start)) / float64(timemodule.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdatePasswordHash", success, elapsed)
+	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdatePasswordHash", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8368,6 +10528,9 @@ func (s *TimerLayerUserStore) UpdateUpdateAt(userId string) (int64, error) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.UpdateUpdateAt", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.UpdateUpdateAt", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8384,6 +10547,9 @@ func (s *TimerLayerUserStore) VerifyEmail(userId string, email string) (string,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.VerifyEmail", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserStore.VerifyEmail", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8400,6 +10566,9 @@ func (s *TimerLayerUserAccessTokenStore) Delete(tokenId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8416,6 +10585,9 @@ func (s *TimerLayerUserAccessTokenStore) DeleteAllForUser(userId string) error {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.DeleteAllForUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.DeleteAllForUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8432,6 +10604,9 @@ func (s *TimerLayerUserAccessTokenStore) Get(tokenId string) (*model.UserAccessT
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Get", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.Get", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8448,6 +10623,9 @@ func (s *TimerLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetAll", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.GetAll", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8464,6 +10642,9 @@ func (s *TimerLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByToken", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.GetByToken", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8480,6 +10661,9 @@ func (s *TimerLayerUserAccessTokenStore) GetByUser(userId string, page int, perP
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.GetByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8496,6 +10680,9 @@ func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*mo
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8512,6 +10699,9 @@ func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.Search", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.Search", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8528,6 +10718,9 @@ func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenId string) erro
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenDisable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.UpdateTokenDisable", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8544,6 +10737,9 @@ func (s *TimerLayerUserAccessTokenStore) UpdateTokenEnable(tokenId string) error
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateTokenEnable", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserAccessTokenStore.UpdateTokenEnable", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8560,6 +10756,9 @@ func (s *TimerLayerUserTermsOfServiceStore) Delete(userId string, termsOfService
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Delete", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserTermsOfServiceStore.Delete", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8576,6 +10775,9 @@ func (s *TimerLayerUserTermsOfServiceStore) GetByUser(userId string) (*model.Use
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.GetByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserTermsOfServiceStore.GetByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8592,6 +10794,9 @@ func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("UserTermsOfServiceStore.Save", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("UserTermsOfServiceStore.Save", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8608,6 +10813,9 @@ func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamId string) (int64, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsIncomingCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.AnalyticsIncomingCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8624,6 +10832,9 @@ func (s *TimerLayerWebhookStore) AnalyticsOutgoingCount(teamId string) (int64, e
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.AnalyticsOutgoingCount", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.AnalyticsOutgoingCount", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8640,6 +10851,9 @@ func (s *TimerLayerWebhookStore) ClearCaches() {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.ClearCaches", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.ClearCaches", true, elapsed)
+	}
 }
 
 func (s *TimerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) error {
@@ -8655,6 +10869,9 @@ func (s *TimerLayerWebhookStore) DeleteIncoming(webhookId string, time int64) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteIncoming", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.DeleteIncoming", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8671,6 +10888,9 @@ func (s *TimerLayerWebhookStore) DeleteOutgoing(webhookId string, time int64) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.DeleteOutgoing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.DeleteOutgoing", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8687,6 +10907,9 @@ func (s *TimerLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncoming", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncoming", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8703,6 +10926,9 @@ func (s *TimerLayerWebhookStore) GetIncomingByChannel(channelId string) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncomingByChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8719,6 +10945,9 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeam(teamId string, offset int, li
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncomingByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8735,6 +10964,9 @@ func (s *TimerLayerWebhookStore) GetIncomingByTeamByUser(teamId string, userId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingByTeamByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncomingByTeamByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8751,6 +10983,9 @@ func (s *TimerLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingList", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncomingList", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8767,6 +11002,9 @@ func (s *TimerLayerWebhookStore) GetIncomingListByUser(userId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetIncomingListByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetIncomingListByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8783,6 +11021,9 @@ func (s *TimerLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook,
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8799,6 +11040,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannel(channelId string, offset i
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingByChannel", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8815,6 +11059,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingByChannelByUser(channelId string, us
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByChannelByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingByChannelByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8831,6 +11078,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeam(teamId string, offset int, li
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeam", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingByTeam", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8847,6 +11097,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamId string, userId s
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingByTeamByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingByTeamByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8863,6 +11116,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*mode
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingList", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingList", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8879,6 +11135,9 @@ func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userId string, offset int
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingListByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.GetOutgoingListByUser", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8895,6 +11154,9 @@ func (s *TimerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.InvalidateWebhookCache", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.InvalidateWebhookCache", true, elapsed)
+	}
 }
 
 func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId string) error {
@@ -8910,6 +11172,9 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByChannel(channelId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.PermanentDeleteIncomingByChannel", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8926,6 +11191,9 @@ func (s *TimerLayerWebhookStore) PermanentDeleteIncomingByUser(userId string) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteIncomingByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.PermanentDeleteIncomingByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8942,6 +11210,9 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelId stri
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByChannel", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.PermanentDeleteOutgoingByChannel", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8958,6 +11229,9 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userId string) er
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingByUser", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.PermanentDeleteOutgoingByUser", err == nil, elapsed)
+	}
 	return err
 }
 
@@ -8974,6 +11248,9 @@ func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveIncoming", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.SaveIncoming", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -8990,6 +11267,9 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.SaveOutgoing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -9006,6 +11286,9 @@ func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook)
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateIncoming", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.UpdateIncoming", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -9022,6 +11305,9 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 		}
 		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoing", success, elapsed)
 	}
+	if s.Root.Stats != nil {
+		s.Root.Stats.ObserveStoreMethod("WebhookStore.UpdateOutgoing", err == nil, elapsed)
+	}
 	return result, err
 }
 
@@ -9029,6 +11315,10 @@ func (s *TimerLayer) Close() {
 	s.Store.Close()
 }
 
+func (s *TimerLayer) DeleteOrphans(entityType string, batchSize int) (int64, error) {
+	return s.Store.DeleteOrphans(entityType, batchSize)
+}
+
 func (s *TimerLayer) DropAllTables() {
 	s.Store.DropAllTables()
 }
@@ -9045,6 +11335,10 @@ func (s *TimerLayer) MarkSystemRanUnitTests() {
 	s.Store.MarkSystemRanUnitTests()
 }
 
+func (s *TimerLayer) PermanentDeleteBatchForRetention(retentionDays int, limit int) (int64, error) {
+	return s.Store.PermanentDeleteBatchForRetention(retentionDays, limit)
+}
+
 func (s *TimerLayer) SetContext(context context.Context) {
 	s.Store.SetContext(context)
 }
@@ -9072,6 +11366,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	}
 
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditTrailStore = &TimerLayerAuditTrailStore{AuditTrailStore: childStore.AuditTrail(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// RealClock is the system clock, as used by the store by default.
+type RealClock struct{}
+
+var _ sqlstore.Clock = RealClock{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a clock standing still at the time it's set to, until it's set again or advanced.
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+var _ sqlstore.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a clock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// SetClock makes the store see t as the current time, from the timestamps of the rows it writes
+// to the expiry times it compares against, until the clock is set again or reset with
+// ResetClock.
+func (h *MainHelper) SetClock(t time.Time) *FakeClock {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	if h.clock == nil {
+		h.clock = NewFakeClock(t)
+		h.SQLSupplier.SetClock(h.clock)
+		if h.ReplicaSQLSupplier != nil {
+			h.ReplicaSQLSupplier.SetClock(h.clock)
+		}
+	} else {
+		h.clock.Set(t)
+	}
+
+	return h.clock
+}
+
+// ResetClock makes the store use the system clock again.
+func (h *MainHelper) ResetClock() {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	h.clock = nil
+	h.SQLSupplier.SetClock(nil)
+	if h.ReplicaSQLSupplier != nil {
+		h.ReplicaSQLSupplier.SetClock(nil)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestFakeClock(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(now)
+	assert.Equal(t, now, clock.Now())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, now.Add(time.Minute), clock.Now())
+
+	clock.Set(now)
+	assert.Equal(t, now, clock.Now())
+}
+
+func TestSetClock(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		Store:       supplier,
+		SQLSupplier: supplier,
+	}
+	defer h.ResetClock()
+
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	h.SetClock(now)
+
+	token := model.NewToken(model.TOKEN_TYPE_OAUTH, "extra")
+	token.CreateAt = model.GetMillisForTime(now)
+	require.NoError(t, supplier.Token().Save(token))
+
+	t.Run("stamps the rows with the helper time", func(t *testing.T) {
+		user, err := supplier.User().Save(&model.User{
+			Email:    "success_" + model.NewId() + "@simulator.amazonses.com",
+			Username: "user-" + model.NewId(),
+		})
+		require.NoError(t, err)
+		defer supplier.User().PermanentDelete(user.Id)
+
+		assert.Equal(t, model.GetMillisForTime(now), user.CreateAt)
+	})
+
+	t.Run("expires the tokens once the clock moves past their lifetime", func(t *testing.T) {
+		h.SetClock(now.Add(time.Duration(model.MAX_TOKEN_EXIPRY_TIME-1) * time.Millisecond))
+		supplier.Token().Cleanup()
+		_, err := supplier.Token().GetByToken(token.Token)
+		require.NoError(t, err)
+
+		h.SetClock(now.Add(time.Duration(model.MAX_TOKEN_EXIPRY_TIME+1) * time.Millisecond))
+		supplier.Token().Cleanup()
+		_, err = supplier.Token().GetByToken(token.Token)
+		assert.Error(t, err)
+	})
+}
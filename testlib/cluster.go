@@ -4,16 +4,37 @@
 package testlib
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/einterfaces"
 	"github.com/mattermost/mattermost-server/v5/model"
 )
 
+const (
+	ClusterNodeAdded   = "node_added"
+	ClusterNodeRemoved = "node_removed"
+)
+
+// ClusterChangeListener is notified when a node joins or leaves the simulated cluster.
+type ClusterChangeListener func(event string, node *model.ClusterDiscovery)
+
 type FakeClusterInterface struct {
+	// CompressionThreshold is the size above which the data of the messages sent is compressed,
+	// as the cluster would send it. Zero leaves the messages uncompressed.
+	CompressionThreshold int
+	// Filter drops the duplicate and stale messages delivered through NotifyMsg, as the cluster
+	// would. Nil delivers them all.
+	Filter *model.ClusterMessageFilter
+
 	clusterMessageHandler einterfaces.ClusterMessageHandler
 	mut                   sync.RWMutex
 	messages              []*model.ClusterMessage
+	dropped               []*model.ClusterMessage
+	nodes                 []*model.ClusterDiscovery
+	changeListeners       []ClusterChangeListener
 }
 
 func (c *FakeClusterInterface) StartInterNodeCommunication() {}
@@ -34,15 +55,107 @@ func (c *FakeClusterInterface) IsLeader() bool { return false }
 
 func (c *FakeClusterInterface) GetMyClusterInfo() *model.ClusterInfo { return nil }
 
-func (c *FakeClusterInterface) GetClusterInfos() []*model.ClusterInfo { return nil }
+// GetClusterInfos returns the nodes of the simulated cluster, in the order they joined.
+func (c *FakeClusterInterface) GetClusterInfos() []*model.ClusterInfo {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+
+	var infos []*model.ClusterInfo
+	for _, node := range c.nodes {
+		infos = append(infos, &model.ClusterInfo{
+			Id:        node.Id,
+			Version:   model.CurrentVersion,
+			IpAddress: fmt.Sprintf("%s:%d", node.Hostname, node.Port),
+			Hostname:  node.Hostname,
+		})
+	}
+	return infos
+}
+
+// AddClusterChangeListener registers a listener notified by AddNode and RemoveNode.
+func (c *FakeClusterInterface) AddClusterChangeListener(listener ClusterChangeListener) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.changeListeners = append(c.changeListeners, listener)
+}
+
+// AddNode simulates a node joining the cluster.
+func (c *FakeClusterInterface) AddNode(node *model.ClusterDiscovery) {
+	c.mut.Lock()
+	c.nodes = append(c.nodes, node)
+	listeners := c.changeListeners
+	c.mut.Unlock()
+
+	for _, listener := range listeners {
+		listener(ClusterNodeAdded, node)
+	}
+}
+
+// RemoveNode simulates the node with the given id leaving the cluster.
+func (c *FakeClusterInterface) RemoveNode(id string) {
+	var removed *model.ClusterDiscovery
 
+	c.mut.Lock()
+	for i, node := range c.nodes {
+		if node.Id == id {
+			removed = node
+			c.nodes = append(c.nodes[:i], c.nodes[i+1:]...)
+			break
+		}
+	}
+	listeners := c.changeListeners
+	c.mut.Unlock()
+
+	if removed == nil {
+		return
+	}
+
+	for _, listener := range listeners {
+		listener(ClusterNodeRemoved, removed)
+	}
+}
+
+// SendClusterMessage records the message, compressed if it's above CompressionThreshold, once
+// given an id and a creation time.
 func (c *FakeClusterInterface) SendClusterMessage(message *model.ClusterMessage) {
+	message.PreSend()
+	if c.CompressionThreshold > 0 {
+		compressed := *message
+		if err := compressed.Compress(c.CompressionThreshold); err == nil {
+			message = &compressed
+		}
+	}
+
 	c.mut.Lock()
 	defer c.mut.Unlock()
 	c.messages = append(c.messages, message)
 }
 
-func (c *FakeClusterInterface) NotifyMsg(buf []byte) {}
+// NotifyMsg delivers a message received as JSON, such as one returned by GetMessages, to the
+// registered handler once decompressed, unless the Filter drops it.
+func (c *FakeClusterInterface) NotifyMsg(buf []byte) {
+	message := model.ClusterMessageFromJson(bytes.NewReader(buf))
+	if message == nil || message.Decompress() != nil || c.clusterMessageHandler == nil {
+		return
+	}
+	if c.Filter != nil && !c.Filter.Accept(message) {
+		c.mut.Lock()
+		c.dropped = append(c.dropped, message)
+		c.mut.Unlock()
+		return
+	}
+	c.clusterMessageHandler(message)
+}
+
+// GetDroppedMessages returns the messages dropped by the Filter, in the order they were
+// delivered.
+func (c *FakeClusterInterface) GetDroppedMessages() []*model.ClusterMessage {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+	dropped := make([]*model.ClusterMessage, len(c.dropped))
+	copy(dropped, c.dropped)
+	return dropped
+}
 
 func (c *FakeClusterInterface) GetClusterStats() ([]*model.ClusterStats, *model.AppError) {
 	return nil, nil
@@ -68,14 +181,36 @@ func (c *FakeClusterInterface) GetPluginStatuses() (model.PluginStatuses, *model
 	return nil, nil
 }
 
+// GetMessages returns the messages sent through SendClusterMessage, in the order they were sent.
 func (c *FakeClusterInterface) GetMessages() []*model.ClusterMessage {
 	c.mut.RLock()
 	defer c.mut.RUnlock()
-	return c.messages
+	messages := make([]*model.ClusterMessage, len(c.messages))
+	copy(messages, c.messages)
+	return messages
+}
+
+// WaitForMessages waits until at least n messages have been sent, returning them,
+// or fails once the timeout expires.
+func (c *FakeClusterInterface) WaitForMessages(n int, timeout time.Duration) ([]*model.ClusterMessage, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		messages := c.GetMessages()
+		if len(messages) >= n {
+			return messages, nil
+		}
+
+		if time.Now().After(deadline) {
+			return messages, fmt.Errorf("timed out waiting for %d cluster messages, got %d", n, len(messages))
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
 }
 
 func (c *FakeClusterInterface) ClearMessages() {
 	c.mut.Lock()
 	defer c.mut.Unlock()
 	c.messages = nil
+	c.dropped = nil
 }
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+func TestFakeClusterInterfaceMessages(t *testing.T) {
+	c := &FakeClusterInterface{}
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			c.SendClusterMessage(&model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: string(rune('a' + i))})
+		}
+	}()
+
+	messages, err := c.WaitForMessages(3, time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	assert.Equal(t, "a", messages[0].Data)
+	assert.Equal(t, "b", messages[1].Data)
+	assert.Equal(t, "c", messages[2].Data)
+
+	c.ClearMessages()
+	assert.Empty(t, c.GetMessages())
+
+	_, err = c.WaitForMessages(1, 20*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestFakeClusterInterfaceNodes(t *testing.T) {
+	c := &FakeClusterInterface{}
+
+	var events []string
+	c.AddClusterChangeListener(func(event string, node *model.ClusterDiscovery) {
+		events = append(events, event+":"+node.Id)
+	})
+
+	c.AddNode(&model.ClusterDiscovery{Id: "node1", Hostname: "host1", Port: 8074})
+	c.AddNode(&model.ClusterDiscovery{Id: "node2", Hostname: "host2", Port: 8074})
+
+	infos := c.GetClusterInfos()
+	require.Len(t, infos, 2)
+	assert.Equal(t, "node1", infos[0].Id)
+	assert.Equal(t, "host1:8074", infos[0].IpAddress)
+	assert.Equal(t, "node2", infos[1].Id)
+
+	c.RemoveNode("node1")
+	c.RemoveNode("unknown")
+
+	infos = c.GetClusterInfos()
+	require.Len(t, infos, 1)
+	assert.Equal(t, "node2", infos[0].Id)
+	assert.Equal(t, []string{"node_added:node1", "node_added:node2", "node_removed:node1"}, events)
+}
+
+func TestFakeClusterInterfaceCompression(t *testing.T) {
+	c := &FakeClusterInterface{CompressionThreshold: 100}
+
+	var received []*model.ClusterMessage
+	c.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBERS, func(msg *model.ClusterMessage) {
+		received = append(received, msg)
+	})
+
+	data := strings.Repeat("member ", 100)
+	sent := &model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBERS, Data: data}
+	c.SendClusterMessage(sent)
+	c.SendClusterMessage(&model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_CHANNEL_MEMBERS, Data: "small"})
+	assert.False(t, sent.Compressed)
+
+	messages := c.GetMessages()
+	require.Len(t, messages, 2)
+	assert.True(t, messages[0].Compressed)
+	assert.False(t, messages[1].Compressed)
+
+	for _, message := range messages {
+		c.NotifyMsg([]byte(message.ToJson()))
+	}
+	require.Len(t, received, 2)
+	assert.Equal(t, data, received[0].Data)
+	assert.Equal(t, "small", received[1].Data)
+}
+
+func TestFakeClusterInterfaceFilter(t *testing.T) {
+	settings := &model.ClusterSettings{}
+	settings.SetDefaults()
+	c := &FakeClusterInterface{Filter: model.NewClusterMessageFilterFromSettings(settings)}
+
+	var received []*model.ClusterMessage
+	c.RegisterClusterMessageHandler(model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, func(msg *model.ClusterMessage) {
+		received = append(received, msg)
+	})
+
+	c.SendClusterMessage(&model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user1"})
+	c.SendClusterMessage(&model.ClusterMessage{Event: model.CLUSTER_EVENT_INVALIDATE_CACHE_FOR_USER, Data: "user2", CreateAt: 1})
+
+	messages := c.GetMessages()
+	require.Len(t, messages, 2)
+	assert.NotEmpty(t, messages[0].Id)
+	assert.NotEqual(t, messages[0].Id, messages[1].Id)
+
+	// The first message is delivered twice, and the second one too late.
+	c.NotifyMsg([]byte(messages[0].ToJson()))
+	c.NotifyMsg([]byte(messages[0].ToJson()))
+	c.NotifyMsg([]byte(messages[1].ToJson()))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "user1", received[0].Data)
+	dropped := c.GetDroppedMessages()
+	require.Len(t, dropped, 2)
+	assert.Equal(t, "user1", dropped[0].Data)
+	assert.Equal(t, "user2", dropped[1].Data)
+
+	c.ClearMessages()
+	assert.Empty(t, c.GetDroppedMessages())
+}
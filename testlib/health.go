@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+// healthCheckInterval is the delay between two health checks while waiting for the store.
+const healthCheckInterval = 100 * time.Millisecond
+
+// HealthCheck checks that the database and the active search engines of the store answer.
+func (h *MainHelper) HealthCheck(ctx context.Context) error {
+	return h.GetStore().HealthCheck(ctx)
+}
+
+// WaitForStore blocks until the store passes its health check, returning the last health check
+// error if ctx is done first.
+func (h *MainHelper) WaitForStore(ctx context.Context) error {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		err := h.HealthCheck(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// SearchHealthStatus probes the active engines of the search engine broker right away, rather
+// than waiting for the background probes, and returns their health.
+func (h *MainHelper) SearchHealthStatus() map[string]searchengine.EngineHealth {
+	broker := h.GetSearchEngine()
+	broker.CheckHealth()
+
+	return broker.HealthStatus()
+}
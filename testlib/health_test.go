@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestWaitForStore(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+
+	h := &MainHelper{
+		Settings:    settings,
+		Store:       supplier,
+		SQLSupplier: supplier,
+	}
+
+	t.Run("returns once the store is healthy", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		assert.NoError(t, h.WaitForStore(ctx))
+	})
+
+	t.Run("gives up once the context is done", func(t *testing.T) {
+		supplier.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		assert.Error(t, h.WaitForStore(ctx))
+		assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	})
+}
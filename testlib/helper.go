@@ -4,16 +4,23 @@
 package testlib
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/cache"
 	"github.com/mattermost/mattermost-server/v5/services/searchengine"
 	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/auditlayer"
+	"github.com/mattermost/mattermost-server/v5/store/circuitbreakerlayer"
+	"github.com/mattermost/mattermost-server/v5/store/localcachelayer"
+	"github.com/mattermost/mattermost-server/v5/store/readonlylayer"
 	"github.com/mattermost/mattermost-server/v5/store/searchlayer"
 	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
 	"github.com/mattermost/mattermost-server/v5/store/storetest"
@@ -27,13 +34,70 @@ type MainHelper struct {
 	SQLSupplier      *sqlstore.SqlSupplier
 	ClusterInterface *FakeClusterInterface
 
+	// CircuitBreaker guards the test store when enabled through CircuitBreakerThreshold.
+	CircuitBreaker *circuitbreakerlayer.Breaker
+
+	// ReadOnlyStore wraps the test store when enabled through EnableReadOnlyLayer.
+	ReadOnlyStore *readonlylayer.ReadOnlyLayer
+
+	// ReplicaSQLSupplier issues all its queries against the read replica, when enabled.
+	ReplicaSQLSupplier *sqlstore.SqlSupplier
+
 	status           int
 	testResourcePath string
+
+	useTransactionPerTest bool
+	testTx                *testTransaction
+	reuseDatabase         bool
+	logCapture            *LogCapture
+	replicaSnapshot       *testTransaction
+	clock                 *FakeClock
 }
 
 type HelperOptions struct {
 	EnableStore     bool
 	EnableResources bool
+	// UseTransactionPerTest allows tests to isolate their store changes by
+	// calling BeginTestTx and RollbackTestTx. See BeginTestTx for limitations.
+	UseTransactionPerTest bool
+
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime override the connection pool
+	// settings of the test store. Zero values keep the defaults.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// EnableReadReplica configures the store with a read replica. The replica reads the test
+	// database through its own connections, and lag can be simulated with PauseReplication.
+	EnableReadReplica bool
+
+	// TruncateTables lists the tables emptied before running the suite when an
+	// existing database is reused through MM_TEST_DATABASE_REUSE.
+	TruncateTables []string
+
+	// CircuitBreakerThreshold wraps the test store with a circuit breaker tripping after
+	// the given number of consecutive failures. Zero leaves the store unguarded.
+	CircuitBreakerThreshold int
+
+	// EnableCache wraps the test store with the local cache layer, invalidating its caches
+	// through the fake cluster interface.
+	EnableCache bool
+
+	// EnableReadOnlyLayer wraps the test store with the read-only layer, switched on and off
+	// with SetStoreReadOnly.
+	EnableReadOnlyLayer bool
+
+	// EnableAuditLayer wraps the test store with the audit layer, outermost as on a server with
+	// the audit trail enabled.
+	EnableAuditLayer bool
+
+	// AssertSchemaUpToDate calls AssertSchemaUpToDate once the store is set up.
+	AssertSchemaUpToDate bool
+
+	// EnableMemoryStore sets up a storetest.MemoryStore when EnableStore is false, or the
+	// tests run in short mode, for the tests only using the common users, teams, channels and
+	// posts methods.
+	EnableMemoryStore bool
 }
 
 func NewMainHelper() *MainHelper {
@@ -50,18 +114,16 @@ func NewMainHelperWithOptions(options *HelperOptions) *MainHelper {
 	// Setup a global logger to catch tests logging outside of app context
 	// The global logger will be stomped by apps initializing but that's fine for testing.
 	// Ideally this won't happen.
-	mlog.InitGlobalLogger(mlog.NewLogger(&mlog.LoggerConfiguration{
-		EnableConsole: true,
-		ConsoleJson:   true,
-		ConsoleLevel:  "error",
-		EnableFile:    false,
-	}))
+	initGlobalLogger()
 
 	utils.TranslationsPreInit()
 
 	if options != nil {
 		if options.EnableStore && !testing.Short() {
-			mainHelper.setupStore()
+			mainHelper.setupStore(options)
+			mainHelper.useTransactionPerTest = options.UseTransactionPerTest
+		} else if options.EnableMemoryStore {
+			mainHelper.setupMemoryStore()
 		}
 
 		if options.EnableResources {
@@ -72,6 +134,15 @@ func NewMainHelperWithOptions(options *HelperOptions) *MainHelper {
 	return &mainHelper
 }
 
+func initGlobalLogger() {
+	mlog.InitGlobalLogger(mlog.NewLogger(&mlog.LoggerConfiguration{
+		EnableConsole: true,
+		ConsoleJson:   true,
+		ConsoleLevel:  "error",
+		EnableFile:    false,
+	}))
+}
+
 func (h *MainHelper) Main(m *testing.M) {
 	if h.testResourcePath != "" {
 		prevDir, err := os.Getwd()
@@ -95,23 +166,91 @@ func (h *MainHelper) Main(m *testing.M) {
 	h.status = m.Run()
 }
 
-func (h *MainHelper) setupStore() {
+func (h *MainHelper) setupStore(options *HelperOptions) {
 	driverName := os.Getenv("MM_SQLSETTINGS_DRIVERNAME")
 	if driverName == "" {
 		driverName = model.DATABASE_DRIVER_POSTGRES
 	}
 
-	h.Settings = storetest.MakeSqlSettings(driverName)
+	h.reuseDatabase = os.Getenv("MM_TEST_DATABASE_REUSE") == "true"
+	if h.reuseDatabase {
+		h.Settings = storetest.ReuseSqlSettings(driverName)
+	} else {
+		h.Settings = storetest.MakeSqlSettings(driverName)
+	}
+	if options.MaxOpenConns > 0 {
+		*h.Settings.MaxOpenConns = options.MaxOpenConns
+	}
+	if options.MaxIdleConns > 0 {
+		*h.Settings.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.ConnMaxLifetime > 0 {
+		*h.Settings.ConnMaxLifetimeMilliseconds = int(options.ConnMaxLifetime / time.Millisecond)
+	}
+	if options.EnableReadReplica {
+		h.Settings.DataSourceReplicas = []string{*h.Settings.DataSource}
+	}
 
 	config := &model.Config{}
 	config.SetDefaults()
 
 	h.SearchEngine = searchengine.NewBroker(config, nil)
 	h.ClusterInterface = &FakeClusterInterface{}
-	h.SQLSupplier = sqlstore.NewSqlSupplier(*h.Settings, nil)
+	supplier, err := sqlstore.NewSqlSupplier(*h.Settings, nil)
+	if err != nil {
+		panic("failed to initialize sql supplier: " + sqlstore.MaskDataSource(err.Error()))
+	}
+	h.SQLSupplier = supplier
+	if options.AssertSchemaUpToDate {
+		h.AssertSchemaUpToDate()
+	}
+	if options.EnableReadReplica {
+		h.setupReplica()
+	}
+	if h.reuseDatabase {
+		h.truncateTables(options.TruncateTables)
+	}
+	var testStore store.Store = h.SQLSupplier
+	if options.CircuitBreakerThreshold > 0 {
+		*h.Settings.CircuitBreakerThreshold = options.CircuitBreakerThreshold
+		h.CircuitBreaker = circuitbreakerlayer.NewBreaker(h.Settings)
+		testStore = circuitbreakerlayer.New(testStore, h.CircuitBreaker)
+	}
+	if options.EnableCache {
+		testStore = localcachelayer.NewLocalCacheLayer(testStore, nil, h.ClusterInterface, cache.NewProvider())
+	}
 	h.Store = searchlayer.NewSearchLayer(&TestStore{
-		h.SQLSupplier,
+		testStore,
 	}, h.SearchEngine, config)
+	if options.EnableReadOnlyLayer {
+		h.ReadOnlyStore = readonlylayer.New(h.Store)
+		h.Store = h.ReadOnlyStore
+	}
+	if options.EnableAuditLayer {
+		h.Store = auditlayer.New(h.Store, context.Background())
+	}
+}
+
+func (h *MainHelper) setupMemoryStore() {
+	config := &model.Config{}
+	config.SetDefaults()
+
+	h.SearchEngine = searchengine.NewBroker(config, nil)
+	h.ClusterInterface = &FakeClusterInterface{}
+	h.Store = searchlayer.NewSearchLayer(storetest.NewMemoryStore(), h.SearchEngine, config)
+}
+
+func (h *MainHelper) truncateTables(tables []string) {
+	statement := "TRUNCATE TABLE "
+	if h.SQLSupplier.DriverName() == model.DATABASE_DRIVER_SQLITE {
+		statement = "DELETE FROM "
+	}
+
+	for _, table := range tables {
+		if _, err := h.SQLSupplier.GetMaster().Exec(statement + table); err != nil {
+			panic("failed to truncate table " + table + ": " + err.Error())
+		}
+	}
 }
 
 func (h *MainHelper) setupResources() {
@@ -123,10 +262,19 @@ func (h *MainHelper) setupResources() {
 }
 
 func (h *MainHelper) Close() error {
+	if h.testTx != nil {
+		h.RollbackTestTx()
+	}
+	if h.replicaSnapshot != nil {
+		h.ResumeReplication()
+	}
+	if h.ReplicaSQLSupplier != nil {
+		h.ReplicaSQLSupplier.Close()
+	}
 	if h.SQLSupplier != nil {
 		h.SQLSupplier.Close()
 	}
-	if h.Settings != nil {
+	if h.Settings != nil && !h.reuseDatabase {
 		storetest.CleanupSqlSettings(h.Settings)
 	}
 	if h.testResourcePath != "" {
@@ -181,3 +329,33 @@ func (h *MainHelper) GetSearchEngine() *searchengine.Broker {
 
 	return h.SearchEngine
 }
+
+// UseOpenSearchEngine registers the given engine, typically a mock, as the OpenSearch
+// backend of the search engine broker, and selects it through SearchSettings.Backend.
+func (h *MainHelper) UseOpenSearchEngine(engine searchengine.SearchEngineInterface) {
+	config := &model.Config{}
+	config.SetDefaults()
+	*config.SearchSettings.Backend = model.SEARCH_BACKEND_OPENSEARCH
+
+	broker := h.GetSearchEngine()
+	broker.UpdateConfig(config)
+	broker.RegisterOpenSearchEngine(engine)
+}
+
+func (h *MainHelper) GetCircuitBreaker() *circuitbreakerlayer.Breaker {
+	if h.CircuitBreaker == nil {
+		panic("MainHelper not initialized with circuit breaker.")
+	}
+
+	return h.CircuitBreaker
+}
+
+// SetStoreReadOnly switches the read-only mode of the test store, rejecting its changes with a
+// store.ErrReadOnly error while on.
+func (h *MainHelper) SetStoreReadOnly(readOnly bool) {
+	if h.ReadOnlyStore == nil {
+		panic("MainHelper not initialized with read-only layer.")
+	}
+
+	h.ReadOnlyStore.SetReadOnly(readOnly)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// goroutineLeakTolerance is the number of goroutines a test may leave running, such as the
+// connection openers of the pools opened lazily while it runs.
+const goroutineLeakTolerance = 2
+
+// leakCheckTimeout is how long the goroutines and the connections of a test are given to wind
+// down once it ends.
+var leakCheckTimeout = 5 * time.Second
+
+// AssertNoLeaks fails the test if, once it ends, more goroutines are running or more database
+// connections are checked out than when AssertNoLeaks was called. The stacks of the goroutines
+// started since then are printed for diagnosis.
+//
+// The check runs as a cleanup of the test, so AssertNoLeaks should be called before the cleanups
+// closing the resources of the test are registered.
+func (h *MainHelper) AssertNoLeaks(t testing.TB) {
+	t.Helper()
+
+	goroutines := runtime.NumGoroutine()
+	existing := goroutineIds(goroutineStacks())
+	conns := h.connsInUse()
+
+	t.Cleanup(func() {
+		var leakedGoroutines, leakedConns int
+		deadline := time.Now().Add(leakCheckTimeout)
+		for {
+			leakedGoroutines = runtime.NumGoroutine() - goroutines
+			leakedConns = h.connsInUse() - conns
+			if (leakedGoroutines <= goroutineLeakTolerance && leakedConns <= 0) || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if leakedConns > 0 {
+			t.Errorf("%d database connections were left in use", leakedConns)
+		}
+		if leakedGoroutines > goroutineLeakTolerance {
+			var stacks []string
+			for _, stack := range goroutineStacks() {
+				if _, ok := existing[goroutineId(stack)]; !ok {
+					stacks = append(stacks, stack)
+				}
+			}
+			t.Errorf("%d goroutines were left running:\n\n%s", leakedGoroutines, strings.Join(stacks, "\n\n"))
+		}
+	})
+}
+
+// connsInUse returns the number of connections checked out from the pools of the sql suppliers.
+func (h *MainHelper) connsInUse() int {
+	inUse := 0
+	if h.SQLSupplier != nil {
+		for _, conn := range h.SQLSupplier.GetAllConns() {
+			inUse += conn.Db.Stats().InUse
+		}
+	}
+	if h.ReplicaSQLSupplier != nil {
+		for _, conn := range h.ReplicaSQLSupplier.GetAllConns() {
+			inUse += conn.Db.Stats().InUse
+		}
+	}
+	return inUse
+}
+
+// goroutineStacks returns the stack of every running goroutine.
+func goroutineStacks() []string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var stacks []string
+	for _, stack := range bytes.Split(bytes.TrimSpace(buf), []byte("\n\n")) {
+		stacks = append(stacks, string(stack))
+	}
+	return stacks
+}
+
+// goroutineId returns the id of the goroutine of a stack, such as 42 for a stack starting with
+// "goroutine 42 [running]:".
+func goroutineId(stack string) string {
+	header := strings.TrimPrefix(stack, "goroutine ")
+	if i := strings.Index(header, " "); i >= 0 {
+		return header[:i]
+	}
+	return header
+}
+
+func goroutineIds(stacks []string) map[string]struct{} {
+	ids := make(map[string]struct{}, len(stacks))
+	for _, stack := range stacks {
+		ids[goroutineId(stack)] = struct{}{}
+	}
+	return ids
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+// leakRecorder runs the cleanups of a test on demand and records its errors.
+type leakRecorder struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (r *leakRecorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *leakRecorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *leakRecorder) end() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func leakingGoroutine(stop chan struct{}) {
+	<-stop
+}
+
+func TestAssertNoLeaks(t *testing.T) {
+	defer func(timeout time.Duration) { leakCheckTimeout = timeout }(leakCheckTimeout)
+	leakCheckTimeout = 100 * time.Millisecond
+
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		SQLSupplier: supplier,
+	}
+
+	t.Run("passes when everything is released", func(t *testing.T) {
+		r := &leakRecorder{TB: t}
+		h.AssertNoLeaks(r)
+
+		stop := make(chan struct{})
+		for i := 0; i < 5; i++ {
+			go leakingGoroutine(stop)
+		}
+		conn, err := supplier.GetMaster().Db.Conn(context.Background())
+		require.Nil(t, err)
+		close(stop)
+		conn.Close()
+
+		r.end()
+		assert.Empty(t, r.errors)
+	})
+
+	t.Run("reports the leaked goroutines", func(t *testing.T) {
+		r := &leakRecorder{TB: t}
+		h.AssertNoLeaks(r)
+
+		stop := make(chan struct{})
+		defer close(stop)
+		for i := 0; i < 5; i++ {
+			go leakingGoroutine(stop)
+		}
+
+		r.end()
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "5 goroutines were left running")
+		assert.Contains(t, r.errors[0], "testlib.leakingGoroutine")
+	})
+
+	t.Run("reports the connections left in use", func(t *testing.T) {
+		r := &leakRecorder{TB: t}
+		h.AssertNoLeaks(r)
+
+		conn, err := supplier.GetMaster().Db.Conn(context.Background())
+		require.Nil(t, err)
+		defer conn.Close()
+
+		r.end()
+		require.Len(t, r.errors, 1)
+		assert.Equal(t, "1 database connections were left in use", r.errors[0])
+	})
+}
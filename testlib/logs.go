@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+// LogCapture records the logs emitted through the global logger while active.
+type LogCapture struct {
+	*mlog.LogCapture
+	helper *MainHelper
+}
+
+// CaptureLogs replaces the global logger with one recording every log entry in memory,
+// down to the debug level. The default global logger is restored by closing the returned capture.
+func (h *MainHelper) CaptureLogs() *LogCapture {
+	if h.logCapture != nil {
+		panic("logs are already being captured.")
+	}
+
+	logger, capture := mlog.NewCapturingLogger(mlog.LevelDebug)
+	mlog.InitGlobalLogger(logger)
+
+	h.logCapture = &LogCapture{
+		LogCapture: capture,
+		helper:     h,
+	}
+
+	return h.logCapture
+}
+
+// Logs returns the active log capture started by CaptureLogs.
+func (h *MainHelper) Logs() *LogCapture {
+	if h.logCapture == nil {
+		panic("MainHelper not capturing logs.")
+	}
+
+	return h.logCapture
+}
+
+// Close stops capturing logs and restores the default global logger.
+func (c *LogCapture) Close() {
+	if c.helper.logCapture != c {
+		return
+	}
+
+	c.helper.logCapture = nil
+	initGlobalLogger()
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/mlog"
+)
+
+func TestCaptureLogs(t *testing.T) {
+	h := &MainHelper{}
+
+	capture := h.CaptureLogs()
+	mlog.Debug("debug message", mlog.String("key", "value"))
+	mlog.Error("error message")
+
+	entries := h.Logs().Entries()
+	require.Len(t, entries, 2)
+	assert.Contains(t, entries, mlog.LogEntry{
+		Level:   "debug",
+		Message: "debug message",
+		Fields:  map[string]interface{}{"key": "value"},
+	})
+	assert.Equal(t, "error", entries[1].Level)
+	assert.Equal(t, "error message", entries[1].Message)
+
+	capture.Close()
+	assert.Panics(t, func() { h.Logs() })
+
+	mlog.Error("not captured")
+	assert.Len(t, capture.Entries(), 2)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"sync"
+
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// QueryMetrics counts the queries executed through the sql supplier while active,
+// by store method.
+type QueryMetrics struct {
+	mut      sync.Mutex
+	counts   map[string]int
+	supplier *sqlstore.SqlSupplier
+}
+
+// RecordQueryMetrics starts counting the queries executed through the sql supplier,
+// until the returned QueryMetrics is closed.
+func (h *MainHelper) RecordQueryMetrics() *QueryMetrics {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	metrics := &QueryMetrics{
+		counts:   make(map[string]int),
+		supplier: h.SQLSupplier,
+	}
+	h.SQLSupplier.SetQueryMetricsCollector(metrics)
+
+	return metrics
+}
+
+func (m *QueryMetrics) ObserveStoreQueryDuration(method string, elapsed float64) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.counts[method]++
+}
+
+// Count returns the number of queries executed by the given store method, e.g. PostStore.Save.
+func (m *QueryMetrics) Count(method string) int {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.counts[method]
+}
+
+// Counts returns the number of queries executed so far, by store method.
+func (m *QueryMetrics) Counts() map[string]int {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	counts := make(map[string]int, len(m.counts))
+	for method, count := range m.counts {
+		counts[method] = count
+	}
+	return counts
+}
+
+// Reset discards the queries counted so far.
+func (m *QueryMetrics) Reset() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.counts = make(map[string]int)
+}
+
+// Close stops counting queries.
+func (m *QueryMetrics) Close() {
+	m.supplier.SetQueryMetricsCollector(nil)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestRecordQueryMetrics(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		SQLSupplier: supplier,
+	}
+
+	metrics := h.RecordQueryMetrics()
+	require.Nil(t, supplier.System().Save(&model.System{Name: "TestQueryMetrics", Value: "1"}))
+	_, err = supplier.System().GetByName("TestQueryMetrics")
+	require.Nil(t, err)
+	_, err = supplier.System().GetByName("TestQueryMetrics")
+	require.Nil(t, err)
+
+	assert.Equal(t, 2, metrics.Count("SystemStore.GetByName"))
+	assert.NotZero(t, metrics.Count("SystemStore.Save"))
+
+	metrics.Reset()
+	assert.Empty(t, metrics.Counts())
+
+	metrics.Close()
+	_, err = supplier.System().GetByName("TestQueryMetrics")
+	require.Nil(t, err)
+	assert.Empty(t, metrics.Counts())
+}
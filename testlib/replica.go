@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"github.com/mattermost/gorp"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// setupReplica configures the store with a read replica connection to the test database,
+// and creates a supplier issuing all its queries against that replica.
+func (h *MainHelper) setupReplica() {
+	replicaSettings := *h.Settings
+	replicaSettings.DataSourceReplicas = []string{}
+	replicaSettings.DataSourceSearchReplicas = []string{}
+	supplier, err := sqlstore.NewSqlSupplier(replicaSettings, nil)
+	if err != nil {
+		panic("failed to initialize replica sql supplier: " + err.Error())
+	}
+	h.ReplicaSQLSupplier = supplier
+
+	// Queries are only routed to replicas when a license is present.
+	h.SQLSupplier.UpdateLicense(model.NewTestLicense())
+}
+
+func (h *MainHelper) replicaConns() []*gorp.DbMap {
+	var conns []*gorp.DbMap
+	for _, conn := range h.SQLSupplier.GetAllConns() {
+		if conn != h.SQLSupplier.GetMaster() {
+			conns = append(conns, conn)
+		}
+	}
+	return append(conns, h.ReplicaSQLSupplier.GetAllConns()...)
+}
+
+// PauseReplication simulates replication lag: until ResumeReplication is called, queries
+// issued against the read replica only see the data committed before the pause.
+//
+// The replica serves all its queries from a single connection while paused, with the same
+// limitations as BeginTestTx.
+func (h *MainHelper) PauseReplication() {
+	if h.ReplicaSQLSupplier == nil {
+		panic("MainHelper not initialized with read replica.")
+	}
+	if h.replicaSnapshot != nil {
+		panic("replication already paused.")
+	}
+
+	var statements []string
+	switch *h.Settings.DriverName {
+	case model.DATABASE_DRIVER_POSTGRES:
+		// The snapshot is taken by the first query of the transaction.
+		statements = []string{"BEGIN TRANSACTION ISOLATION LEVEL REPEATABLE READ", "SELECT 1"}
+	case model.DATABASE_DRIVER_MYSQL:
+		statements = []string{"START TRANSACTION WITH CONSISTENT SNAPSHOT"}
+	default:
+		panic("replication lag is not supported with driver " + *h.Settings.DriverName)
+	}
+
+	snapshot, err := openTestTransaction(h.SQLSupplier.GetMaster().Db.Driver(), h.Settings.DataSourceReplicas[0], statements...)
+	if err != nil {
+		panic("failed to pause replication: " + err.Error())
+	}
+	snapshot.reroute(h.replicaConns()...)
+
+	h.replicaSnapshot = snapshot
+}
+
+// ResumeReplication makes the read replica catch up with the master again.
+func (h *MainHelper) ResumeReplication() {
+	if h.replicaSnapshot == nil {
+		panic("replication not paused.")
+	}
+
+	snapshot := h.replicaSnapshot
+	h.replicaSnapshot = nil
+
+	if err := snapshot.rollback(); err != nil {
+		panic("failed to resume replication: " + err.Error())
+	}
+}
+
+func (h *MainHelper) GetReplicaSupplier() *sqlstore.SqlSupplier {
+	if h.ReplicaSQLSupplier == nil {
+		panic("MainHelper not initialized with read replica.")
+	}
+
+	return h.ReplicaSQLSupplier
+}
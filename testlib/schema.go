@@ -0,0 +1,49 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// AssertSchemaUpToDate panics unless the schema version recorded in the test database is at
+// least the one the store code expects, listing the migrations missing from the database. It turns a
+// schema drift into a clear failure during the setup, rather than into errors deep inside the
+// queries of the tests.
+func (h *MainHelper) AssertSchemaUpToDate() {
+	if err := h.checkSchemaUpToDate(); err != nil {
+		panic(err.Error())
+	}
+}
+
+func (h *MainHelper) checkSchemaUpToDate() error {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	version := h.SQLSupplier.GetCurrentSchemaVersion()
+	if version == "" {
+		return errors.Errorf("no database schema version recorded, expected %s", sqlstore.CURRENT_SCHEMA_VERSION)
+	}
+
+	plan, err := h.SQLSupplier.MigrationPlan()
+	if err != nil {
+		return errors.Wrapf(err, "database schema version %q can't be upgraded to %s", version, sqlstore.CURRENT_SCHEMA_VERSION)
+	}
+	if len(plan) == 0 {
+		return nil
+	}
+
+	var missing strings.Builder
+	for _, step := range plan {
+		fmt.Fprintf(&missing, "\n\t%s -> %s: %s", step.FromVersion, step.Version, step.Description)
+	}
+
+	return errors.Errorf("database schema version %q is behind the expected version %s, missing migrations:%s", version, sqlstore.CURRENT_SCHEMA_VERSION, missing.String())
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestAssertSchemaUpToDate(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		Store:       supplier,
+		SQLSupplier: supplier,
+	}
+
+	setSchemaVersion := func(version string) {
+		require.NoError(t, supplier.System().SaveOrUpdate(&model.System{Name: "Version", Value: version}))
+	}
+	defer setSchemaVersion(model.CurrentVersion)
+
+	t.Run("up to date", func(t *testing.T) {
+		assert.NotPanics(t, h.AssertSchemaUpToDate)
+	})
+
+	t.Run("missing migrations", func(t *testing.T) {
+		setSchemaVersion(sqlstore.VERSION_5_28_0)
+		assert.PanicsWithValue(t, `database schema version "5.28.0" is behind the expected version 5.29.0, missing migrations:
+	5.28.0 -> 5.28.1: Add FileInfo.MiniPreview
+	5.28.1 -> 5.29.0: Resize the sidebar category ids, add Threads.ChannelId`, h.AssertSchemaUpToDate)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		setSchemaVersion("2.0.0")
+		err := h.checkSchemaUpToDate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `database schema version "2.0.0" can't be upgraded to 5.29.0`)
+	})
+
+	t.Run("no recorded version", func(t *testing.T) {
+		setSchemaVersion("")
+		err := h.checkSchemaUpToDate()
+		require.Error(t, err)
+		assert.EqualError(t, err, "no database schema version recorded, expected 5.29.0")
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+// searchIndexPollInterval is the delay between two checks while waiting for a document to be
+// searchable.
+const searchIndexPollInterval = 50 * time.Millisecond
+
+// WaitForSearchIndex blocks until the post, channel, user or file with the given id can be
+// found by the active search engines, refreshing their indexes until then, and returns an
+// error if it's still missing after the timeout. It returns right away when the searches fall
+// back to the database, and after a single refresh for the engines which can't tell whether
+// a document is searchable.
+func (h *MainHelper) WaitForSearchIndex(entityId string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for _, engine := range h.GetSearchEngine().GetActiveEngines() {
+		for {
+			if appErr := engine.RefreshIndexes(); appErr != nil {
+				return errors.Wrapf(appErr, "failed to refresh the %s indexes", engine.GetName())
+			}
+
+			finder, ok := engine.(searchengine.DocumentFinder)
+			if !ok {
+				break
+			}
+
+			searchable, appErr := finder.IsDocumentSearchable(entityId)
+			if appErr != nil {
+				return errors.Wrapf(appErr, "failed to search %s in %s", entityId, engine.GetName())
+			}
+			if searchable {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				return errors.Errorf("%s wasn't searchable in %s after %s", entityId, engine.GetName(), timeout)
+			}
+			time.Sleep(searchIndexPollInterval)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,602 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+const FakeSearchEngineName = "fake"
+
+// FakeSearchEngineCall records a call to the FakeSearchEngine, with the id of the document it
+// was about, if any.
+type FakeSearchEngineCall struct {
+	Method string
+	Id     string
+}
+
+type fakeIndexedPost struct {
+	post   *model.Post
+	teamId string
+}
+
+type fakeIndexedUser struct {
+	user        *model.User
+	teamsIds    []string
+	channelsIds []string
+}
+
+type fakeIndexedFile struct {
+	file      *model.FileInfo
+	channelId string
+}
+
+// FakeSearchEngine is an in-memory search engine, indexing synchronously, for testing the code
+// routing the searches and the indexing to the engines. The posts match a search when their
+// words include the terms of the search, or their hashtags for the hashtag searches, and are
+// returned newest first. The channels and users match a term with the prefixes of their names.
+// The other filters of the searches are ignored.
+type FakeSearchEngine struct {
+	mut      sync.Mutex
+	active   bool
+	posts    map[string]*fakeIndexedPost
+	channels map[string]*model.Channel
+	users    map[string]*fakeIndexedUser
+	files    map[string]*fakeIndexedFile
+	calls    []FakeSearchEngineCall
+	failures map[string]*model.AppError
+}
+
+var _ searchengine.SearchEngineInterface = (*FakeSearchEngine)(nil)
+var _ searchengine.DocumentFinder = (*FakeSearchEngine)(nil)
+
+// NewFakeSearchEngine returns an empty engine, which becomes active once started.
+func NewFakeSearchEngine() *FakeSearchEngine {
+	return &FakeSearchEngine{
+		posts:    map[string]*fakeIndexedPost{},
+		channels: map[string]*model.Channel{},
+		users:    map[string]*fakeIndexedUser{},
+		files:    map[string]*fakeIndexedFile{},
+		failures: map[string]*model.AppError{},
+	}
+}
+
+// FailOn makes the calls to the given method return an error, or all the calls failing for an
+// empty method, starting with the next call. A failing call is still recorded, but doesn't
+// change the index.
+func (e *FakeSearchEngine) FailOn(method string) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	e.failures[method] = model.NewAppError("FakeSearchEngine."+method, "fake_search_engine.failure", nil, "injected failure", http.StatusInternalServerError)
+}
+
+// ClearFailures makes all the calls succeed again.
+func (e *FakeSearchEngine) ClearFailures() {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	e.failures = map[string]*model.AppError{}
+}
+
+// Calls returns the calls to the engine so far, in order.
+func (e *FakeSearchEngine) Calls() []FakeSearchEngineCall {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	return append([]FakeSearchEngineCall{}, e.calls...)
+}
+
+// CallsTo returns the ids of the documents of the calls to the given method so far, in order.
+func (e *FakeSearchEngine) CallsTo(method string) []string {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	ids := []string{}
+	for _, call := range e.calls {
+		if call.Method == method {
+			ids = append(ids, call.Id)
+		}
+	}
+	return ids
+}
+
+// ResetCalls forgets the calls recorded so far.
+func (e *FakeSearchEngine) ResetCalls() {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	e.calls = nil
+}
+
+// record records a call while holding the lock, returning the injected failure of the method.
+func (e *FakeSearchEngine) record(method, id string) *model.AppError {
+	e.calls = append(e.calls, FakeSearchEngineCall{Method: method, Id: id})
+	if appErr, ok := e.failures[method]; ok {
+		return appErr
+	}
+	return e.failures[""]
+}
+
+func (e *FakeSearchEngine) Start() *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("Start", ""); appErr != nil {
+		return appErr
+	}
+	e.active = true
+	return nil
+}
+
+func (e *FakeSearchEngine) Stop() *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("Stop", ""); appErr != nil {
+		return appErr
+	}
+	e.active = false
+	return nil
+}
+
+func (e *FakeSearchEngine) GetVersion() int                { return 1 }
+func (e *FakeSearchEngine) UpdateConfig(cfg *model.Config) {}
+func (e *FakeSearchEngine) GetName() string                { return FakeSearchEngineName }
+
+func (e *FakeSearchEngine) IsActive() bool {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	return e.active
+}
+
+func (e *FakeSearchEngine) IsIndexingEnabled() bool       { return e.IsActive() }
+func (e *FakeSearchEngine) IsSearchEnabled() bool         { return e.IsActive() }
+func (e *FakeSearchEngine) IsAutocompletionEnabled() bool { return e.IsActive() }
+func (e *FakeSearchEngine) IsIndexingSync() bool          { return true }
+
+func (e *FakeSearchEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("IndexPost", post.Id); appErr != nil {
+		return appErr
+	}
+	e.posts[post.Id] = &fakeIndexedPost{post: post.Clone(), teamId: teamId}
+	return nil
+}
+
+func (e *FakeSearchEngine) SearchPosts(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("SearchPosts", ""); appErr != nil {
+		return nil, nil, appErr
+	}
+
+	channelIds := map[string]bool{}
+	if channels != nil {
+		for _, channel := range *channels {
+			channelIds[channel.Id] = true
+		}
+	}
+
+	var found []*model.Post
+	matches := model.PostSearchMatches{}
+	for _, indexed := range e.posts {
+		post := indexed.post
+		if !channelIds[post.ChannelId] || post.DeleteAt != 0 {
+			continue
+		}
+
+		var postMatches []string
+		matched := true
+		for _, params := range searchParams {
+			terms, ok := matchPostTerms(post, params)
+			if !ok {
+				matched = false
+				break
+			}
+			postMatches = append(postMatches, terms...)
+		}
+		if !matched {
+			continue
+		}
+
+		found = append(found, post)
+		if len(postMatches) > 0 {
+			matches[post.Id] = postMatches
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].CreateAt != found[j].CreateAt {
+			return found[i].CreateAt > found[j].CreateAt
+		}
+		return found[i].Id > found[j].Id
+	})
+
+	postIds := []string{}
+	for i := page * perPage; i < len(found) && i < (page+1)*perPage; i++ {
+		postIds = append(postIds, found[i].Id)
+	}
+	for id := range matches {
+		if !containsString(postIds, id) {
+			delete(matches, id)
+		}
+	}
+
+	return postIds, matches, nil
+}
+
+// matchPostTerms returns the terms of the search found in the post, and whether the post
+// matches the search.
+func matchPostTerms(post *model.Post, params *model.SearchParams) ([]string, bool) {
+	text := post.Message
+	if params.IsHashtag {
+		text = post.Hashtags
+	}
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isWordSeparator) {
+		words[word] = true
+	}
+
+	for _, term := range strings.Fields(strings.ToLower(params.ExcludedTerms)) {
+		if words[strings.Trim(term, "\"")] {
+			return nil, false
+		}
+	}
+
+	terms := strings.Fields(strings.ToLower(params.Terms))
+	var found []string
+	for _, term := range terms {
+		term = strings.Trim(term, "\"")
+		if words[term] {
+			found = append(found, term)
+		} else if !params.OrTerms {
+			return nil, false
+		}
+	}
+
+	return found, len(terms) == 0 || len(found) > 0
+}
+
+func isWordSeparator(r rune) bool {
+	return !(r == '#' || r == '_' || r == '-' || r == '@' || r == '.' ||
+		(r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || r > 127)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *FakeSearchEngine) DeletePost(post *model.Post) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeletePost", post.Id); appErr != nil {
+		return appErr
+	}
+	delete(e.posts, post.Id)
+	return nil
+}
+
+func (e *FakeSearchEngine) DeleteChannelPosts(channelID string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteChannelPosts", channelID); appErr != nil {
+		return appErr
+	}
+	for id, indexed := range e.posts {
+		if indexed.post.ChannelId == channelID {
+			delete(e.posts, id)
+		}
+	}
+	return nil
+}
+
+func (e *FakeSearchEngine) DeleteUserPosts(userID string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteUserPosts", userID); appErr != nil {
+		return appErr
+	}
+	for id, indexed := range e.posts {
+		if indexed.post.UserId == userID {
+			delete(e.posts, id)
+		}
+	}
+	return nil
+}
+
+func (e *FakeSearchEngine) IndexChannel(channel *model.Channel) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("IndexChannel", channel.Id); appErr != nil {
+		return appErr
+	}
+	channelCopy := *channel
+	e.channels[channel.Id] = &channelCopy
+	return nil
+}
+
+func (e *FakeSearchEngine) SearchChannels(teamId, term string) ([]string, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("SearchChannels", ""); appErr != nil {
+		return nil, appErr
+	}
+
+	channelIds := []string{}
+	for _, channel := range e.channels {
+		if channel.TeamId == teamId && channel.Type == model.CHANNEL_OPEN && matchPrefix(term, channel.Name, channel.DisplayName) {
+			channelIds = append(channelIds, channel.Id)
+		}
+	}
+	sort.Strings(channelIds)
+	return channelIds, nil
+}
+
+func (e *FakeSearchEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteChannel", channel.Id); appErr != nil {
+		return appErr
+	}
+	delete(e.channels, channel.Id)
+	return nil
+}
+
+func (e *FakeSearchEngine) IndexUser(user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("IndexUser", user.Id); appErr != nil {
+		return appErr
+	}
+	userCopy := *user
+	e.users[user.Id] = &fakeIndexedUser{user: &userCopy, teamsIds: teamsIds, channelsIds: channelsIds}
+	return nil
+}
+
+func (e *FakeSearchEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("SearchUsersInChannel", ""); appErr != nil {
+		return nil, nil, appErr
+	}
+
+	inChannel, notInChannel := []string{}, []string{}
+	for _, indexed := range e.searchUsers(term, options) {
+		if containsString(indexed.channelsIds, channelId) {
+			inChannel = append(inChannel, indexed.user.Id)
+		} else if teamId == "" || containsString(indexed.teamsIds, teamId) {
+			notInChannel = append(notInChannel, indexed.user.Id)
+		}
+	}
+	return inChannel, notInChannel, nil
+}
+
+func (e *FakeSearchEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("SearchUsersInTeam", ""); appErr != nil {
+		return nil, appErr
+	}
+
+	userIds := []string{}
+	for _, indexed := range e.searchUsers(term, options) {
+		if teamId == "" || containsString(indexed.teamsIds, teamId) {
+			userIds = append(userIds, indexed.user.Id)
+		}
+	}
+	return userIds, nil
+}
+
+// searchUsers returns the users matching the term and the options, sorted by username.
+func (e *FakeSearchEngine) searchUsers(term string, options *model.UserSearchOptions) []*fakeIndexedUser {
+	var users []*fakeIndexedUser
+	for _, indexed := range e.users {
+		user := indexed.user
+		if user.DeleteAt != 0 && (options == nil || !options.AllowInactive) {
+			continue
+		}
+		if matchPrefix(term, user.Username, user.FirstName, user.LastName, user.Nickname) {
+			users = append(users, indexed)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].user.Username < users[j].user.Username
+	})
+	if options != nil && options.Limit > 0 && len(users) > options.Limit {
+		users = users[:options.Limit]
+	}
+	return users
+}
+
+// matchPrefix returns whether one of the words of the values starts with the term, ignoring
+// the case.
+func matchPrefix(term string, values ...string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	for _, value := range values {
+		for _, word := range strings.FieldsFunc(strings.ToLower(value), isWordSeparator) {
+			if strings.HasPrefix(word, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *FakeSearchEngine) DeleteUser(user *model.User) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteUser", user.Id); appErr != nil {
+		return appErr
+	}
+	delete(e.users, user.Id)
+	return nil
+}
+
+func (e *FakeSearchEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("IndexFile", file.Id); appErr != nil {
+		return appErr
+	}
+	fileCopy := *file
+	e.files[file.Id] = &fakeIndexedFile{file: &fileCopy, channelId: channelId}
+	return nil
+}
+
+func (e *FakeSearchEngine) SearchFiles(channels *model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("SearchFiles", ""); appErr != nil {
+		return nil, appErr
+	}
+
+	channelIds := map[string]bool{}
+	if channels != nil {
+		for _, channel := range *channels {
+			channelIds[channel.Id] = true
+		}
+	}
+
+	var found []*model.FileInfo
+	for _, indexed := range e.files {
+		if !channelIds[indexed.channelId] || indexed.file.DeleteAt != 0 {
+			continue
+		}
+
+		matched := true
+		for _, params := range searchParams {
+			if !matchPrefix(params.Terms, indexed.file.Name) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			found = append(found, indexed.file)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].CreateAt != found[j].CreateAt {
+			return found[i].CreateAt > found[j].CreateAt
+		}
+		return found[i].Id > found[j].Id
+	})
+
+	fileIds := []string{}
+	for i := page * perPage; i < len(found) && i < (page+1)*perPage; i++ {
+		fileIds = append(fileIds, found[i].Id)
+	}
+	return fileIds, nil
+}
+
+func (e *FakeSearchEngine) DeleteFile(fileID string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteFile", fileID); appErr != nil {
+		return appErr
+	}
+	delete(e.files, fileID)
+	return nil
+}
+
+func (e *FakeSearchEngine) DeletePostFiles(postID string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeletePostFiles", postID); appErr != nil {
+		return appErr
+	}
+	for id, indexed := range e.files {
+		if indexed.file.PostId == postID {
+			delete(e.files, id)
+		}
+	}
+	return nil
+}
+
+func (e *FakeSearchEngine) DeleteUserFiles(userID string) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DeleteUserFiles", userID); appErr != nil {
+		return appErr
+	}
+	for id, indexed := range e.files {
+		if indexed.file.CreatorId == userID {
+			delete(e.files, id)
+		}
+	}
+	return nil
+}
+
+func (e *FakeSearchEngine) TestConfig(cfg *model.Config) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	return e.record("TestConfig", "")
+}
+
+func (e *FakeSearchEngine) PurgeIndexes() *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("PurgeIndexes", ""); appErr != nil {
+		return appErr
+	}
+	e.posts = map[string]*fakeIndexedPost{}
+	e.channels = map[string]*model.Channel{}
+	e.users = map[string]*fakeIndexedUser{}
+	e.files = map[string]*fakeIndexedFile{}
+	return nil
+}
+
+func (e *FakeSearchEngine) RefreshIndexes() *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	return e.record("RefreshIndexes", "")
+}
+
+func (e *FakeSearchEngine) DataRetentionDeleteIndexes(cutoff time.Time) *model.AppError {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("DataRetentionDeleteIndexes", ""); appErr != nil {
+		return appErr
+	}
+	cutoffMillis := model.GetMillisForTime(cutoff)
+	for id, indexed := range e.posts {
+		if indexed.post.CreateAt < cutoffMillis {
+			delete(e.posts, id)
+		}
+	}
+	return nil
+}
+
+// IsDocumentSearchable reports whether a post, channel, user or file with the given id is indexed.
+func (e *FakeSearchEngine) IsDocumentSearchable(id string) (bool, *model.AppError) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if appErr := e.record("IsDocumentSearchable", id); appErr != nil {
+		return false, appErr
+	}
+	_, isPost := e.posts[id]
+	_, isChannel := e.channels[id]
+	_, isUser := e.users[id]
+	_, isFile := e.files[id]
+	return isPost || isChannel || isUser || isFile, nil
+}
+
+// UseFakeSearchEngine starts a new FakeSearchEngine and registers it as the engine of the search
+// engine broker, as UseOpenSearchEngine does.
+func (h *MainHelper) UseFakeSearchEngine() *FakeSearchEngine {
+	engine := NewFakeSearchEngine()
+	engine.Start()
+	h.UseOpenSearchEngine(engine)
+	engine.ResetCalls()
+	return engine
+}
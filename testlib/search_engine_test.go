@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+	"github.com/mattermost/mattermost-server/v5/store/searchlayer"
+	"github.com/mattermost/mattermost-server/v5/store/storetest/mocks"
+)
+
+func TestFakeSearchEngine(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Name: "town-square", DisplayName: "Town Square", Type: model.CHANNEL_OPEN}
+	channels := &model.ChannelList{channel}
+
+	newEngine := func(t *testing.T) (*FakeSearchEngine, []*model.Post) {
+		engine := NewFakeSearchEngine()
+		require.Nil(t, engine.Start())
+
+		posts := []*model.Post{
+			{Id: model.NewId(), ChannelId: channel.Id, Message: "Hello world", CreateAt: 1000},
+			{Id: model.NewId(), ChannelId: channel.Id, Message: "hello everyone", Hashtags: "#release", CreateAt: 2000},
+			{Id: model.NewId(), ChannelId: model.NewId(), Message: "hello from elsewhere", CreateAt: 3000},
+		}
+		for _, post := range posts {
+			require.Nil(t, engine.IndexPost(post, channel.TeamId))
+		}
+		return engine, posts
+	}
+
+	t.Run("searches the posts of the channels", func(t *testing.T) {
+		engine, posts := newEngine(t)
+
+		ids, matches, appErr := engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[1].Id, posts[0].Id}, ids)
+		assert.Equal(t, []string{"hello"}, matches[posts[0].Id])
+
+		ids, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("hello world", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[0].Id}, ids)
+
+		ids, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("hello -world", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[1].Id}, ids)
+
+		ids, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("#release", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[1].Id}, ids)
+
+		ids, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 1, 1)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[0].Id}, ids)
+	})
+
+	t.Run("forgets the deleted posts", func(t *testing.T) {
+		engine, posts := newEngine(t)
+		require.Nil(t, engine.DeletePost(posts[0]))
+
+		ids, _, appErr := engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{posts[1].Id}, ids)
+
+		searchable, appErr := engine.IsDocumentSearchable(posts[0].Id)
+		require.Nil(t, appErr)
+		assert.False(t, searchable)
+
+		require.Nil(t, engine.DataRetentionDeleteIndexes(time.Unix(0, 0).Add(1500*time.Millisecond)))
+		searchable, appErr = engine.IsDocumentSearchable(posts[1].Id)
+		require.Nil(t, appErr)
+		assert.True(t, searchable)
+		require.Nil(t, engine.DataRetentionDeleteIndexes(time.Unix(0, 0).Add(2500*time.Millisecond)))
+		searchable, appErr = engine.IsDocumentSearchable(posts[1].Id)
+		require.Nil(t, appErr)
+		assert.False(t, searchable)
+	})
+
+	t.Run("searches the channels and users by prefix", func(t *testing.T) {
+		engine, _ := newEngine(t)
+		require.Nil(t, engine.IndexChannel(channel))
+		user := &model.User{Id: model.NewId(), Username: "alice", FirstName: "Alice", LastName: "Smith"}
+		require.Nil(t, engine.IndexUser(user, []string{channel.TeamId}, []string{channel.Id}))
+
+		ids, appErr := engine.SearchChannels(channel.TeamId, "squ")
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{channel.Id}, ids)
+
+		ids, appErr = engine.SearchUsersInTeam(channel.TeamId, nil, "smi", &model.UserSearchOptions{Limit: 10})
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{user.Id}, ids)
+
+		inChannel, notInChannel, appErr := engine.SearchUsersInChannel(channel.TeamId, model.NewId(), nil, "ali", &model.UserSearchOptions{Limit: 10})
+		require.Nil(t, appErr)
+		assert.Empty(t, inChannel)
+		assert.Equal(t, []string{user.Id}, notInChannel)
+	})
+
+	t.Run("records the calls and injects failures", func(t *testing.T) {
+		engine, posts := newEngine(t)
+		assert.Equal(t, []string{posts[0].Id, posts[1].Id, posts[2].Id}, engine.CallsTo("IndexPost"))
+
+		engine.FailOn("SearchPosts")
+		_, _, appErr := engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.NotNil(t, appErr)
+		assert.Nil(t, engine.RefreshIndexes())
+
+		engine.FailOn("")
+		assert.NotNil(t, engine.RefreshIndexes())
+		assert.NotNil(t, engine.IndexPost(&model.Post{Id: model.NewId()}, ""))
+		assert.Len(t, engine.CallsTo("IndexPost"), 4)
+
+		engine.ClearFailures()
+		_, _, appErr = engine.SearchPosts(channels, model.ParseSearchParams("hello", 0), 0, 20)
+		require.Nil(t, appErr)
+
+		engine.ResetCalls()
+		assert.Empty(t, engine.Calls())
+	})
+}
+
+func TestFakeSearchEngineRouting(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.CHANNEL_OPEN}
+	post := &model.Post{Id: model.NewId(), ChannelId: channel.Id, Message: "hello world", CreateAt: 1000}
+	dbResults := model.MakePostSearchResults(model.NewPostList(), nil)
+
+	setup := func() (*MainHelper, *FakeSearchEngine, *searchlayer.SearchStore) {
+		config := &model.Config{}
+		config.SetDefaults()
+		h := &MainHelper{SearchEngine: searchengine.NewBroker(config, nil)}
+		engine := h.UseFakeSearchEngine()
+
+		postStore := &mocks.PostStore{}
+		postStore.On("Save", mock.Anything).Return(post, nil)
+		postStore.On("GetPostsByIds", []string{post.Id}).Return([]*model.Post{post}, nil)
+		postStore.On("SearchPostsInTeamForUser", mock.Anything, "user", channel.TeamId, 0, 20).Return(dbResults, nil)
+		channelStore := &mocks.ChannelStore{}
+		channelStore.On("Get", channel.Id, true).Return(channel, nil)
+		channelStore.On("GetChannels", channel.TeamId, "user", false, 0).Return(&model.ChannelList{channel}, nil)
+
+		baseStore := &mocks.Store{}
+		baseStore.On("Channel").Return(channelStore)
+		baseStore.On("Post").Return(postStore)
+		baseStore.On("FileInfo").Return(&mocks.FileInfoStore{})
+		baseStore.On("Team").Return(&mocks.TeamStore{})
+		baseStore.On("User").Return(&mocks.UserStore{})
+
+		return h, engine, searchlayer.NewSearchLayer(baseStore, h.GetSearchEngine(), config)
+	}
+
+	t.Run("indexes the saved posts and searches them", func(t *testing.T) {
+		_, engine, searchStore := setup()
+
+		_, err := searchStore.Post().Save(post)
+		require.NoError(t, err)
+		assert.Equal(t, []string{post.Id}, engine.CallsTo("IndexPost"))
+
+		results, err := searchStore.Post().SearchPostsInTeamForUser(model.ParseSearchParams("hello", 0), "user", channel.TeamId, 0, 20)
+		require.NoError(t, err)
+		assert.Equal(t, []string{post.Id}, results.Order)
+	})
+
+	t.Run("falls back to the database when the engine fails", func(t *testing.T) {
+		_, engine, searchStore := setup()
+		engine.FailOn("SearchPosts")
+
+		results, err := searchStore.Post().SearchPostsInTeamForUser(model.ParseSearchParams("hello", 0), "user", channel.TeamId, 0, 20)
+		require.NoError(t, err)
+		assert.Same(t, dbResults, results)
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/services/searchengine"
+)
+
+// delayedSearchEngine makes its documents searchable after a given number of refreshes.
+type delayedSearchEngine struct {
+	searchengine.SearchEngineInterface
+
+	refreshes       int32
+	refreshesNeeded int32
+}
+
+func (e *delayedSearchEngine) IsActive() bool  { return true }
+func (e *delayedSearchEngine) GetName() string { return "delayed" }
+
+func (e *delayedSearchEngine) RefreshIndexes() *model.AppError {
+	atomic.AddInt32(&e.refreshes, 1)
+	return nil
+}
+
+func (e *delayedSearchEngine) IsDocumentSearchable(id string) (bool, *model.AppError) {
+	return atomic.LoadInt32(&e.refreshes) >= e.refreshesNeeded, nil
+}
+
+func TestWaitForSearchIndex(t *testing.T) {
+	newHelper := func() *MainHelper {
+		config := &model.Config{}
+		config.SetDefaults()
+		return &MainHelper{SearchEngine: searchengine.NewBroker(config, nil)}
+	}
+
+	t.Run("no active engine", func(t *testing.T) {
+		assert.NoError(t, newHelper().WaitForSearchIndex(model.NewId(), time.Second))
+	})
+
+	t.Run("waits for the document", func(t *testing.T) {
+		h := newHelper()
+		engine := &delayedSearchEngine{refreshesNeeded: 3}
+		h.UseOpenSearchEngine(engine)
+
+		assert.NoError(t, h.WaitForSearchIndex(model.NewId(), 5*time.Second))
+		assert.EqualValues(t, 3, atomic.LoadInt32(&engine.refreshes))
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		h := newHelper()
+		h.UseOpenSearchEngine(&delayedSearchEngine{refreshesNeeded: 1000})
+
+		start := time.Now()
+		err := h.WaitForSearchIndex(model.NewId(), 200*time.Millisecond)
+		assert.Error(t, err)
+		assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"encoding/base32"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// seederBaseTime is the creation time of the first object created by a Seeder, with every
+// following object created a millisecond later.
+const seederBaseTime = 1577836800000
+
+var seederIdEncoding = base32.NewEncoding("ybndrfg8ejkmcpqxot1uwisza345h769")
+
+// Seeder creates users, teams and channels in the test store, deriving their ids, names and
+// timestamps from a seed so that a test seeding the same objects in the same order always gets
+// the same data. The created objects are deleted when the test finishes. Since the data only
+// depends on the seed, tests sharing a database must not seed concurrently with the same seed.
+type Seeder struct {
+	t        *testing.T
+	store    store.Store
+	supplier *sqlstore.SqlSupplier
+	rand     *rand.Rand
+	now      int64
+
+	users    []*model.User
+	teams    []*model.Team
+	channels []*model.Channel
+}
+
+// NewSeeder returns a Seeder creating objects in the store of the helper.
+func (h *MainHelper) NewSeeder(t *testing.T, seed int64) *Seeder {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	s := &Seeder{
+		t:        t,
+		store:    h.GetStore(),
+		supplier: h.SQLSupplier,
+		rand:     rand.New(rand.NewSource(seed)),
+		now:      seederBaseTime,
+	}
+	t.Cleanup(s.Teardown)
+
+	return s
+}
+
+// newId returns an id formatted like model.NewId, generated from the seed.
+func (s *Seeder) newId() string {
+	b := make([]byte, 16)
+	s.rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return seederIdEncoding.EncodeToString(b)[:26]
+}
+
+func (s *Seeder) nextTime() int64 {
+	s.now++
+	return s.now
+}
+
+// CreateUsers creates n users, all of them with an empty password.
+func (s *Seeder) CreateUsers(n int) []*model.User {
+	users := make([]*model.User, 0, n)
+	for i := 0; i < n; i++ {
+		suffix := s.newId()
+		user := &model.User{
+			Id:            s.newId(),
+			Username:      "user-" + suffix,
+			Email:         "success_" + suffix + "@simulator.amazonses.com",
+			FirstName:     fmt.Sprintf("First%d", len(s.users)),
+			LastName:      fmt.Sprintf("Last%d", len(s.users)),
+			EmailVerified: true,
+		}
+		user.PreSave()
+		user.CreateAt = s.nextTime()
+		user.UpdateAt = user.CreateAt
+		user.LastPasswordUpdate = user.CreateAt
+
+		require.Nil(s.t, user.IsValid())
+		require.NoError(s.t, s.supplier.GetMaster().Insert(user))
+
+		s.users = append(s.users, user)
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// CreateTeam creates an open team.
+func (s *Seeder) CreateTeam() *model.Team {
+	suffix := s.newId()
+	team := &model.Team{
+		Id:          s.newId(),
+		Name:        "team-" + suffix,
+		DisplayName: fmt.Sprintf("Team %d", len(s.teams)),
+		Email:       "success_" + suffix + "@simulator.amazonses.com",
+		Type:        model.TEAM_OPEN,
+		InviteId:    s.newId(),
+	}
+	team.PreSave()
+	team.CreateAt = s.nextTime()
+	team.UpdateAt = team.CreateAt
+
+	require.Nil(s.t, team.IsValid())
+	require.NoError(s.t, s.supplier.GetMaster().Insert(team))
+
+	s.teams = append(s.teams, team)
+
+	return team
+}
+
+// CreateChannel creates a channel of the given type, model.CHANNEL_OPEN or
+// model.CHANNEL_PRIVATE, in the team.
+func (s *Seeder) CreateChannel(team *model.Team, channelType string) *model.Channel {
+	channel := &model.Channel{
+		Id:          s.newId(),
+		TeamId:      team.Id,
+		Name:        "channel-" + s.newId(),
+		DisplayName: fmt.Sprintf("Channel %d", len(s.channels)),
+		Type:        channelType,
+	}
+	channel.PreSave()
+	channel.CreateAt = s.nextTime()
+	channel.UpdateAt = channel.CreateAt
+
+	require.Nil(s.t, channel.IsValid())
+	require.NoError(s.t, s.supplier.GetMaster().Insert(channel))
+	if channel.Type == model.CHANNEL_OPEN {
+		_, err := s.supplier.GetMaster().Exec(`
+			INSERT INTO
+			    PublicChannels(Id, DeleteAt, TeamId, DisplayName, Name, Header, Purpose)
+			VALUES
+			    (:Id, :DeleteAt, :TeamId, :DisplayName, :Name, :Header, :Purpose)
+		`, map[string]interface{}{
+			"Id":          channel.Id,
+			"DeleteAt":    channel.DeleteAt,
+			"TeamId":      channel.TeamId,
+			"DisplayName": channel.DisplayName,
+			"Name":        channel.Name,
+			"Header":      channel.Header,
+			"Purpose":     channel.Purpose,
+		})
+		require.NoError(s.t, err)
+	}
+
+	s.channels = append(s.channels, channel)
+
+	return channel
+}
+
+// AddToTeam makes the users members of the team.
+func (s *Seeder) AddToTeam(team *model.Team, users ...*model.User) []*model.TeamMember {
+	members := make([]*model.TeamMember, 0, len(users))
+	for _, user := range users {
+		member, err := s.store.Team().SaveMember(&model.TeamMember{TeamId: team.Id, UserId: user.Id}, -1)
+		require.NoError(s.t, err)
+		members = append(members, member)
+	}
+
+	return members
+}
+
+// AddToChannel makes the users members of the channel. The users are expected to be members of
+// the team of the channel already.
+func (s *Seeder) AddToChannel(channel *model.Channel, users ...*model.User) []*model.ChannelMember {
+	members := make([]*model.ChannelMember, 0, len(users))
+	for _, user := range users {
+		member, err := s.store.Channel().SaveMember(&model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(s.t, err)
+		members = append(members, member)
+	}
+
+	return members
+}
+
+// Teardown deletes the objects created so far, along with their memberships. It's called when
+// the test finishes, and can be called earlier to start over.
+func (s *Seeder) Teardown() {
+	for _, channel := range s.channels {
+		require.NoError(s.t, s.store.Channel().PermanentDeleteMembersByChannel(channel.Id))
+		require.NoError(s.t, s.store.Channel().PermanentDelete(channel.Id))
+	}
+	for _, team := range s.teams {
+		require.NoError(s.t, s.store.Team().RemoveAllMembersByTeam(team.Id))
+		require.NoError(s.t, s.store.Team().PermanentDelete(team.Id))
+	}
+	for _, user := range s.users {
+		require.NoError(s.t, s.store.User().PermanentDelete(user.Id))
+	}
+
+	s.users = nil
+	s.teams = nil
+	s.channels = nil
+}
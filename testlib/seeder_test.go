@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestSeeder(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+	// Saving channel members reads the default roles outside of the saving transaction.
+	*settings.MaxOpenConns = 2
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		Store:       supplier,
+		SQLSupplier: supplier,
+	}
+
+	seed := func(t *testing.T) ([]*model.User, *model.Team, *model.Channel) {
+		seeder := h.NewSeeder(t, 42)
+		users := seeder.CreateUsers(2)
+		team := seeder.CreateTeam()
+		channel := seeder.CreateChannel(team, model.CHANNEL_OPEN)
+		seeder.AddToTeam(team, users...)
+		seeder.AddToChannel(channel, users[0])
+		return users, team, channel
+	}
+
+	var users []*model.User
+	var team *model.Team
+	var channel *model.Channel
+	t.Run("creates the objects", func(t *testing.T) {
+		users, team, channel = seed(t)
+
+		members, err := supplier.Team().GetMembers(team.Id, 0, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+
+		channelMember, err := supplier.Channel().GetMember(channel.Id, users[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, users[0].Id, channelMember.UserId)
+
+		_, err = supplier.Channel().GetByName(team.Id, channel.Name, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("deletes the objects once done", func(t *testing.T) {
+		_, err := supplier.User().Get(users[0].Id)
+		assert.Error(t, err)
+		_, err = supplier.Team().Get(team.Id)
+		assert.Error(t, err)
+		_, err = supplier.Channel().Get(channel.Id, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("creates the same objects from the same seed", func(t *testing.T) {
+		sameUsers, sameTeam, sameChannel := seed(t)
+
+		assert.Equal(t, users, sameUsers)
+		assert.Equal(t, team, sameTeam)
+		assert.Equal(t, channel, sameChannel)
+	})
+}
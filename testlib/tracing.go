@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"github.com/opentracing/opentracing-go/mocktracer"
+
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+)
+
+// SpanRecorder records the spans of the queries executed through the sql supplier while
+// active.
+type SpanRecorder struct {
+	*mocktracer.MockTracer
+	supplier *sqlstore.SqlSupplier
+}
+
+// RecordSpans starts recording the spans of the queries executed through the sql supplier,
+// until the returned SpanRecorder is closed. The spans are children of the span found in the
+// context of the store, which can be started from the recorder itself.
+func (h *MainHelper) RecordSpans() *SpanRecorder {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	recorder := &SpanRecorder{
+		MockTracer: mocktracer.New(),
+		supplier:   h.SQLSupplier,
+	}
+	h.SQLSupplier.SetTracer(recorder.MockTracer)
+
+	return recorder
+}
+
+// SpansOf returns the finished spans of the given operation, e.g. SystemStore.GetByName.
+func (r *SpanRecorder) SpansOf(operation string) []*mocktracer.MockSpan {
+	spans := []*mocktracer.MockSpan{}
+	for _, span := range r.FinishedSpans() {
+		if span.OperationName == operation {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Close stops recording spans.
+func (r *SpanRecorder) Close() {
+	r.supplier.SetTracer(nil)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestRecordSpans(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		SQLSupplier: supplier,
+	}
+
+	recorder := h.RecordSpans()
+	parent := recorder.StartSpan("web:ServeHTTP")
+	supplier.SetContext(opentracing.ContextWithSpan(context.Background(), parent))
+	defer supplier.SetContext(context.Background())
+
+	require.Nil(t, supplier.System().Save(&model.System{Name: "TestRecordSpans", Value: "1"}))
+	_, err = supplier.System().GetByName("TestRecordSpans")
+	require.Nil(t, err)
+
+	spans := recorder.SpansOf("SystemStore.GetByName")
+	require.Len(t, spans, 1)
+	assert.Equal(t, parent.(*mocktracer.MockSpan).SpanContext.SpanID, spans[0].ParentID)
+	assert.Equal(t, "sql", spans[0].Tag("db.type"))
+	assert.Contains(t, spans[0].Tag("db.statement"), "FROM Systems")
+	assert.NotEmpty(t, recorder.SpansOf("SystemStore.Save"))
+
+	recorder.Close()
+	recorder.Reset()
+	_, err = supplier.System().GetByName("TestRecordSpans")
+	require.Nil(t, err)
+	assert.Empty(t, recorder.FinishedSpans())
+}
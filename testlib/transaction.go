@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"github.com/mattermost/gorp"
+	"github.com/pkg/errors"
+)
+
+// testTransaction holds a transaction opened on a dedicated connection, together with
+// the store connections rerouted into it.
+type testTransaction struct {
+	conn     *txConn
+	db       *sql.DB
+	original map[*gorp.DbMap]*sql.DB
+}
+
+// openTestTransaction opens a connection to the given data source and starts a
+// transaction on it by executing the given statements.
+func openTestTransaction(drv driver.Driver, dataSource string, statements ...string) (*testTransaction, error) {
+	rawConn, err := drv.Open(dataSource)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open connection")
+	}
+
+	conn := &txConn{Conn: rawConn}
+	for _, statement := range statements {
+		if err = conn.exec(statement); err != nil {
+			rawConn.Close()
+			return nil, errors.Wrapf(err, "failed to execute %s", statement)
+		}
+	}
+
+	db := sql.OpenDB(&txConnector{conn: conn})
+	db.SetMaxOpenConns(1)
+
+	return &testTransaction{
+		conn:     conn,
+		db:       db,
+		original: make(map[*gorp.DbMap]*sql.DB),
+	}, nil
+}
+
+// reroute makes the given store connections issue their queries within the transaction.
+func (tx *testTransaction) reroute(dbMaps ...*gorp.DbMap) {
+	for _, dbMap := range dbMaps {
+		tx.original[dbMap] = dbMap.Db
+		dbMap.Db = tx.db
+	}
+}
+
+// rollback restores the rerouted store connections and rolls back the transaction.
+func (tx *testTransaction) rollback() error {
+	for dbMap, db := range tx.original {
+		dbMap.Db = db
+	}
+
+	tx.db.Close()
+	err := tx.conn.exec("ROLLBACK")
+	tx.conn.Conn.Close()
+	return err
+}
+
+// BeginTestTx opens a transaction and routes every query issued through the store
+// into it, until RollbackTestTx is called. Transactions started by the code under test
+// become savepoints of the test transaction, so they can still be committed or rolled
+// back independently.
+//
+// All queries share a single connection while the test transaction is active. Code paths
+// that keep a connection busy, e.g. by holding a transaction or iterating over rows, and
+// then open another connection through GetMaster() or GetReplica() will deadlock. Such
+// code can't be tested with UseTransactionPerTest.
+//
+// BeginTestTx must be called before the test starts any goroutine accessing the store.
+func (h *MainHelper) BeginTestTx() {
+	if !h.useTransactionPerTest || h.SQLSupplier == nil {
+		panic("MainHelper not initialized with transaction per test.")
+	}
+	if h.testTx != nil {
+		panic("test transaction already started.")
+	}
+
+	tx, err := openTestTransaction(h.SQLSupplier.GetMaster().Db.Driver(), *h.Settings.DataSource, "BEGIN")
+	if err != nil {
+		panic("failed to begin test transaction: " + err.Error())
+	}
+	tx.reroute(h.SQLSupplier.GetAllConns()...)
+
+	h.testTx = tx
+}
+
+// RollbackTestTx discards every change made since BeginTestTx and restores
+// the original store connections.
+func (h *MainHelper) RollbackTestTx() {
+	if h.testTx == nil {
+		panic("test transaction not started.")
+	}
+
+	tx := h.testTx
+	h.testTx = nil
+
+	if err := tx.rollback(); err != nil {
+		panic("failed to rollback test transaction: " + err.Error())
+	}
+}
+
+// txConnector always hands out the connection holding the test transaction.
+type txConnector struct {
+	conn *txConn
+}
+
+func (c *txConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c *txConnector) Driver() driver.Driver {
+	return c
+}
+
+func (c *txConnector) Open(string) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+// txConn wraps the connection holding the test transaction, turning
+// transactions into savepoints and ignoring attempts to close it.
+type txConn struct {
+	driver.Conn
+
+	mut       sync.Mutex
+	savepoint int
+}
+
+func (c *txConn) exec(query string) error {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(context.Background(), query, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(nil)
+	return err
+}
+
+func (c *txConn) Close() error {
+	// The connection is closed once the test transaction is rolled back.
+	return nil
+}
+
+func (c *txConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *txConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.mut.Lock()
+	c.savepoint++
+	name := fmt.Sprintf("test_savepoint_%d", c.savepoint)
+	c.mut.Unlock()
+
+	if err := c.exec("SAVEPOINT " + name); err != nil {
+		return nil, errors.Wrapf(err, "failed to create savepoint %s", name)
+	}
+
+	return &txSavepoint{conn: c, name: name}, nil
+}
+
+func (c *txConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *txConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *txConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *txConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+func (c *txConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// txSavepoint is a transaction started by the code under test while the test transaction is active.
+type txSavepoint struct {
+	conn *txConn
+	name string
+}
+
+func (s *txSavepoint) Commit() error {
+	return s.conn.exec("RELEASE SAVEPOINT " + s.name)
+}
+
+func (s *txSavepoint) Rollback() error {
+	return s.conn.exec("ROLLBACK TO SAVEPOINT " + s.name)
+}
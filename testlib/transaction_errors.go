@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"sync"
+
+	"github.com/lib/pq"
+)
+
+// SerializationFailure returns the error Postgres aborts conflicting serializable transactions
+// with, which the store retries.
+func SerializationFailure() error {
+	return &pq.Error{
+		Severity: "ERROR",
+		Code:     "40001",
+		Message:  "could not serialize access due to concurrent update",
+	}
+}
+
+// InjectTransactionErrors makes the next retryable transactions of the sql supplier fail with
+// the given errors, one attempt each, before letting the following attempts commit. Calling it
+// without errors stops injecting them.
+func (h *MainHelper) InjectTransactionErrors(errs ...error) {
+	if h.SQLSupplier == nil {
+		panic("MainHelper not initialized with sql supplier.")
+	}
+
+	if len(errs) == 0 {
+		h.SQLSupplier.SetTransactionErrorInjector(nil)
+		return
+	}
+
+	var mut sync.Mutex
+	h.SQLSupplier.SetTransactionErrorInjector(func(attempt int) error {
+		mut.Lock()
+		defer mut.Unlock()
+		if len(errs) == 0 {
+			return nil
+		}
+		err := errs[0]
+		errs = errs[1:]
+		return err
+	})
+}
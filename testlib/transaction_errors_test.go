@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/mattermost/gorp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestInjectTransactionErrors(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:    settings,
+		SQLSupplier: supplier,
+	}
+
+	var attempts int
+	count := func(transaction *gorp.Transaction) error {
+		attempts++
+		return nil
+	}
+
+	h.InjectTransactionErrors(SerializationFailure(), SerializationFailure())
+	require.NoError(t, supplier.WithRetryableTransaction(count))
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	require.NoError(t, supplier.WithRetryableTransaction(count))
+	assert.Equal(t, 1, attempts, "the injected errors should have been used up")
+
+	attempts = 0
+	h.InjectTransactionErrors(SerializationFailure())
+	h.InjectTransactionErrors()
+	require.NoError(t, supplier.WithRetryableTransaction(count))
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package testlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/store/sqlstore"
+	"github.com/mattermost/mattermost-server/v5/store/storetest"
+)
+
+func TestTestTransaction(t *testing.T) {
+	settings := storetest.MakeSqlSettings(model.DATABASE_DRIVER_SQLITE)
+	defer storetest.CleanupSqlSettings(settings)
+
+	supplier, err := sqlstore.NewSqlSupplier(*settings, nil)
+	require.Nil(t, err)
+	defer supplier.Close()
+
+	h := &MainHelper{
+		Settings:              settings,
+		SQLSupplier:           supplier,
+		useTransactionPerTest: true,
+	}
+
+	t.Run("changes are rolled back", func(t *testing.T) {
+		h.BeginTestTx()
+		require.Nil(t, supplier.System().Save(&model.System{Name: "TestTxRolledBack", Value: "1"}))
+		_, err := supplier.System().GetByName("TestTxRolledBack")
+		require.Nil(t, err)
+		h.RollbackTestTx()
+
+		_, err = supplier.System().GetByName("TestTxRolledBack")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("nested transactions use savepoints", func(t *testing.T) {
+		h.BeginTestTx()
+
+		tx, err := supplier.GetMaster().Begin()
+		require.Nil(t, err)
+		require.Nil(t, tx.Insert(&model.System{Name: "TestTxCommitted", Value: "1"}))
+		require.Nil(t, tx.Commit())
+
+		tx, err = supplier.GetMaster().Begin()
+		require.Nil(t, err)
+		require.Nil(t, tx.Insert(&model.System{Name: "TestTxAborted", Value: "1"}))
+		require.Nil(t, tx.Rollback())
+
+		_, err = supplier.System().GetByName("TestTxCommitted")
+		assert.Nil(t, err)
+		_, err = supplier.System().GetByName("TestTxAborted")
+		assert.NotNil(t, err)
+
+		h.RollbackTestTx()
+
+		_, err = supplier.System().GetByName("TestTxCommitted")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		disabled := &MainHelper{Settings: settings, SQLSupplier: supplier}
+		assert.Panics(t, disabled.BeginTestTx)
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+// rateLimiterShards is the number of independently locked parts the buckets of a RateLimiter are
+// spread over, so that concurrent calls for different keys rarely wait for each other.
+const rateLimiterShards = 32
+
+type RateLimiterSettings struct {
+	// Name identifies the limiter in the cluster messages, so it must be the same on every node.
+	Name string
+	// PerSecond is the number of tokens added back to a bucket every second.
+	PerSecond float64
+	// Burst is the number of tokens of a full bucket, that is how many operations can be allowed
+	// at once for a key.
+	Burst int
+	// Cluster, if set, is told about the tokens consumed on this node so that the other nodes
+	// consume them too, making the limit apply to the whole cluster rather than to each node.
+	Cluster einterfaces.ClusterInterface
+	// Now returns the current time, and defaults to time.Now. Tests replace it to control the
+	// refilling of the buckets.
+	Now func() time.Time
+}
+
+// RateLimiter limits how often operations can run per key, each key holding a bucket of tokens
+// which is refilled at a constant rate and which an operation takes a token from.
+type RateLimiter struct {
+	settings RateLimiterSettings
+	shards   [rateLimiterShards]rateLimiterShard
+}
+
+type rateLimiterShard struct {
+	mutex     sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewRateLimiter returns a limiter starting with a full bucket for every key. When a cluster is
+// given, the limiter registers itself for the messages of the limiters of the same name on the
+// other nodes.
+func NewRateLimiter(settings RateLimiterSettings) *RateLimiter {
+	if settings.Now == nil {
+		settings.Now = time.Now
+	}
+
+	l := &RateLimiter{settings: settings}
+	now := settings.Now()
+	for i := range l.shards {
+		l.shards[i].buckets = make(map[string]*tokenBucket)
+		l.shards[i].lastSweep = now
+	}
+
+	if settings.Cluster != nil {
+		settings.Cluster.RegisterClusterMessageHandler(l.clusterEvent(), l.ClusterEventConsumed)
+	}
+
+	return l
+}
+
+// Allow takes a token from the bucket of the key, and reports whether there was one, in which case
+// the operation can run.
+func (l *RateLimiter) Allow(key string) bool {
+	if !l.take(key) {
+		return false
+	}
+
+	if l.settings.Cluster != nil {
+		l.settings.Cluster.SendClusterMessage(&model.ClusterMessage{
+			Event:    l.clusterEvent(),
+			SendType: model.CLUSTER_SEND_BEST_EFFORT,
+			Data:     key,
+		})
+	}
+
+	return true
+}
+
+// ClusterEventConsumed is called when another node took a token for the key in the message, and
+// takes it on this node too if there's one left.
+func (l *RateLimiter) ClusterEventConsumed(msg *model.ClusterMessage) {
+	l.take(msg.Data)
+}
+
+func (l *RateLimiter) clusterEvent() string {
+	return model.CLUSTER_EVENT_RATE_LIMIT_PREFIX + l.settings.Name
+}
+
+func (l *RateLimiter) take(key string) bool {
+	now := l.settings.Now()
+	shard := &l.shards[rateLimiterShardIndex(key)]
+
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	l.sweep(shard, now)
+
+	bucket, ok := shard.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.settings.Burst), updatedAt: now}
+		shard.buckets[key] = bucket
+	} else if elapsed := now.Sub(bucket.updatedAt); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * l.settings.PerSecond
+		if bucket.tokens > float64(l.settings.Burst) {
+			bucket.tokens = float64(l.settings.Burst)
+		}
+		bucket.updatedAt = now
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+	return true
+}
+
+// sweep forgets about the buckets which have had the time to fill up again since they were last
+// used, as they're no different from the new buckets the next call would create for them. It
+// runs at most once per refill time, to keep the number of buckets bounded by the keys used
+// recently without walking them on every call.
+func (l *RateLimiter) sweep(shard *rateLimiterShard, now time.Time) {
+	if l.settings.PerSecond <= 0 {
+		return
+	}
+
+	refillTime := time.Duration(float64(l.settings.Burst) / l.settings.PerSecond * float64(time.Second))
+	if now.Sub(shard.lastSweep) < refillTime {
+		return
+	}
+
+	for key, bucket := range shard.buckets {
+		if now.Sub(bucket.updatedAt) >= refillTime {
+			delete(shard.buckets, key)
+		}
+	}
+	shard.lastSweep = now
+}
+
+// rateLimiterShardIndex hashes the key with FNV-1a, without the allocation of hash/fnv.
+func rateLimiterShardIndex(key string) uint32 {
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+
+	return hash % rateLimiterShards
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v5/einterfaces"
+	"github.com/mattermost/mattermost-server/v5/model"
+)
+
+type fakeTime struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeTime) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// fakeClusterNode delivers the messages it sends to the handlers registered on the other nodes.
+type fakeClusterNode struct {
+	einterfaces.ClusterInterface
+
+	handlers map[string]einterfaces.ClusterMessageHandler
+	peers    []*fakeClusterNode
+}
+
+func (n *fakeClusterNode) RegisterClusterMessageHandler(event string, handler einterfaces.ClusterMessageHandler) {
+	n.handlers[event] = handler
+}
+
+func (n *fakeClusterNode) SendClusterMessage(msg *model.ClusterMessage) {
+	for _, peer := range n.peers {
+		if handler, ok := peer.handlers[msg.Event]; ok {
+			handler(msg)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := &fakeTime{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	newLimiter := func(cluster einterfaces.ClusterInterface) *RateLimiter {
+		return NewRateLimiter(RateLimiterSettings{
+			Name:      "export",
+			PerSecond: 2,
+			Burst:     3,
+			Cluster:   cluster,
+			Now:       clock.Now,
+		})
+	}
+
+	t.Run("allows a burst and then refills at the rate", func(t *testing.T) {
+		limiter := newLimiter(nil)
+		for i := 0; i < 3; i++ {
+			assert.True(t, limiter.Allow("user1"))
+		}
+		assert.False(t, limiter.Allow("user1"))
+
+		clock.Advance(250 * time.Millisecond)
+		assert.False(t, limiter.Allow("user1"))
+
+		clock.Advance(250 * time.Millisecond)
+		assert.True(t, limiter.Allow("user1"))
+		assert.False(t, limiter.Allow("user1"))
+
+		clock.Advance(time.Hour)
+		for i := 0; i < 3; i++ {
+			assert.True(t, limiter.Allow("user1"))
+		}
+		assert.False(t, limiter.Allow("user1"))
+	})
+
+	t.Run("limits each key separately", func(t *testing.T) {
+		limiter := newLimiter(nil)
+		for i := 0; i < 3; i++ {
+			require.True(t, limiter.Allow("user1"))
+		}
+		assert.False(t, limiter.Allow("user1"))
+		assert.True(t, limiter.Allow("user2"))
+	})
+
+	t.Run("forgets the buckets which filled up again", func(t *testing.T) {
+		limiter := newLimiter(nil)
+		limiter.Allow("user1")
+		shard := &limiter.shards[rateLimiterShardIndex("user1")]
+		require.Len(t, shard.buckets, 1)
+
+		other := model.NewId()
+		for rateLimiterShardIndex(other) != rateLimiterShardIndex("user1") {
+			other = model.NewId()
+		}
+
+		clock.Advance(1500 * time.Millisecond)
+		limiter.Allow(other)
+		assert.Len(t, shard.buckets, 1)
+		assert.NotContains(t, shard.buckets, "user1")
+	})
+
+	t.Run("shares the tokens with the other nodes", func(t *testing.T) {
+		node1 := &fakeClusterNode{handlers: map[string]einterfaces.ClusterMessageHandler{}}
+		node2 := &fakeClusterNode{handlers: map[string]einterfaces.ClusterMessageHandler{}}
+		node1.peers = []*fakeClusterNode{node2}
+		node2.peers = []*fakeClusterNode{node1}
+		limiter1 := newLimiter(node1)
+		limiter2 := newLimiter(node2)
+
+		assert.True(t, limiter1.Allow("user1"))
+		assert.True(t, limiter2.Allow("user1"))
+		assert.True(t, limiter1.Allow("user1"))
+		assert.False(t, limiter2.Allow("user1"))
+		assert.False(t, limiter1.Allow("user1"))
+	})
+
+	t.Run("allows the burst once under concurrent calls", func(t *testing.T) {
+		limiter := NewRateLimiter(RateLimiterSettings{Name: "export", PerSecond: 1, Burst: 10, Now: clock.Now})
+
+		var allowed int32
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if limiter.Allow("user1") {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(10), allowed)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/mattermost/mattermost-server/v5/mlog"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/services/tracing"
+	"github.com/mattermost/mattermost-server/v5/store/auditlayer"
 	"github.com/mattermost/mattermost-server/v5/store/opentracinglayer"
 	"github.com/mattermost/mattermost-server/v5/utils"
 	"github.com/opentracing/opentracing-go"
@@ -243,6 +244,17 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if c.Err == nil {
+		if auditStore := c.App.Srv().AuditStore(); auditStore != nil {
+			// The changes made by the request are recorded as made by the user of its session.
+			ctx := auditlayer.WithActor(c.App.Context(), c.App.Session().UserId)
+			tmpSrv := *c.App.Srv()
+			tmpSrv.Store = auditStore.WithContext(ctx)
+			if *c.App.Config().ServiceSettings.EnableOpenTracing {
+				tmpSrv.Store = opentracinglayer.New(tmpSrv.Store, ctx)
+			}
+			c.App.SetServer(&tmpSrv)
+		}
+
 		h.HandleFunc(c, w, r)
 	}
 